@@ -0,0 +1,152 @@
+package apply
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"google.golang.org/protobuf/encoding/protojson"
+	"sigs.k8s.io/yaml"
+
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+const (
+	flagFile   = "file"
+	flagURL    = "url"
+	flagToken  = "token"
+	flagDryRun = "dry-run"
+)
+
+func New() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "apply -f file",
+		Short: "applies the desired state of organizations to an instance",
+		Long: `applies the desired state of organizations to an instance
+The file (YAML or JSON) has the same format as the organizations of the admin import (ImportDataOrg).
+The desired state is compared with the current state and only the missing changes are made.
+Organizations, domains, domain, label, login, password complexity, lockout and privacy policies,
+identity providers, projects, project roles, OIDC and API applications, actions and trigger actions are applied.
+The client id and secret of added applications are printed, the secret can't be retrieved again.
+If the file contains parts which cannot be applied (e.g. users, grants, members or texts),
+they are printed and nothing is applied.
+Resources which are not part of the file are not removed.
+Requirements:
+- a token of a user with the IAM_OWNER role`,
+		Example: `apply -f orgs.yaml --url https://my-instance.zitadel.cloud --token $TOKEN --dry-run
+apply -f orgs.yaml --url https://my-instance.zitadel.cloud --token $TOKEN`,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			filePath, _ := cmd.Flags().GetString(flagFile)
+			url, _ := cmd.Flags().GetString(flagURL)
+			token, _ := cmd.Flags().GetString(flagToken)
+			dryRun, _ := cmd.Flags().GetBool(flagDryRun)
+			if filePath == "" {
+				return errors.New("no file provided")
+			}
+			if token == "" {
+				token = os.Getenv("ZITADEL_TOKEN")
+			}
+			req, err := requestFromFile(filePath, dryRun)
+			if err != nil {
+				return err
+			}
+			resp, err := apply(url, token, req)
+			if err != nil {
+				return err
+			}
+			if len(resp.GetErrors()) > 0 {
+				printErrors(cmd.ErrOrStderr(), resp)
+				return errors.New("the file contains parts which cannot be applied, nothing applied")
+			}
+			printPlan(cmd.OutOrStdout(), resp, dryRun)
+			return nil
+		},
+	}
+	cmd.Flags().StringP(flagFile, "f", "", "path to the file (YAML or JSON) containing the desired state")
+	cmd.Flags().String(flagURL, "http://localhost:8080", "url of the instance")
+	cmd.Flags().String(flagToken, "", "token used to authenticate, defaults to the ZITADEL_TOKEN env variable")
+	cmd.Flags().Bool(flagDryRun, false, "only print the planned changes without applying them")
+	return cmd
+}
+
+func requestFromFile(filePath string, dryRun bool) (*admin_pb.ApplyDataRequest, error) {
+	data, err := os.ReadFile(filePath)
+	if err != nil {
+		return nil, err
+	}
+	// JSON is valid YAML, so both formats are converted the same way
+	data, err = yaml.YAMLToJSON(data)
+	if err != nil {
+		return nil, err
+	}
+	orgs := new(admin_pb.ImportDataOrg)
+	if err = protojson.Unmarshal(data, orgs); err != nil {
+		return nil, err
+	}
+	return &admin_pb.ApplyDataRequest{
+		DataOrgs: orgs,
+		DryRun:   dryRun,
+	}, nil
+}
+
+func apply(url, token string, req *admin_pb.ApplyDataRequest) (*admin_pb.ApplyDataResponse, error) {
+	body, err := protojson.Marshal(req)
+	if err != nil {
+		return nil, err
+	}
+	httpReq, err := http.NewRequest(http.MethodPost, strings.TrimSuffix(url, "/")+"/admin/v1/apply", bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+	httpReq.Header.Set("Authorization", "Bearer "+token)
+	res, err := http.DefaultClient.Do(httpReq)
+	if err != nil {
+		return nil, err
+	}
+	defer res.Body.Close()
+	resBody, err := io.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("apply failed with status %d: %s", res.StatusCode, resBody)
+	}
+	resp := new(admin_pb.ApplyDataResponse)
+	if err = protojson.Unmarshal(resBody, resp); err != nil {
+		return nil, err
+	}
+	return resp, nil
+}
+
+func printPlan(out io.Writer, resp *admin_pb.ApplyDataResponse, dryRun bool) {
+	if len(resp.GetChanges()) == 0 {
+		fmt.Fprintln(out, "no changes, the instance is up to date")
+		return
+	}
+	for _, change := range resp.GetChanges() {
+		fmt.Fprintf(out, "%s %s: %s\n", change.GetAggregateType(), change.GetAggregateId(), change.GetEventType())
+		if change.GetClientId() != "" {
+			fmt.Fprintf(out, "  client id: %s\n", change.GetClientId())
+		}
+		if change.GetClientSecret() != "" {
+			fmt.Fprintf(out, "  client secret: %s\n", change.GetClientSecret())
+		}
+	}
+	if dryRun {
+		fmt.Fprintf(out, "%d changes planned, nothing applied (dry run)\n", len(resp.GetChanges()))
+		return
+	}
+	fmt.Fprintf(out, "%d changes applied\n", len(resp.GetChanges()))
+}
+
+func printErrors(out io.Writer, resp *admin_pb.ApplyDataResponse) {
+	for _, applyErr := range resp.GetErrors() {
+		fmt.Fprintf(out, "org %s: %s: %s\n", applyErr.GetId(), applyErr.GetType(), applyErr.GetMessage())
+	}
+}
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 16/16_add_dpop_jkt_column.sql
+	addDPoPJKTColumn string
+)
+
+type AddDPoPJKTColumn struct {
+	dbClient *database.DB
+}
+
+func (mig *AddDPoPJKTColumn) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addDPoPJKTColumn)
+	return err
+}
+
+func (mig *AddDPoPJKTColumn) String() string {
+	return "16_auth_tokens_dpop_jkt"
+}
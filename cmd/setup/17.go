@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 17/17_action_executions.sql
+	addActionExecutionsTable string
+)
+
+type AddActionExecutionsTable struct {
+	dbClient *database.DB
+}
+
+func (mig *AddActionExecutionsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addActionExecutionsTable)
+	return err
+}
+
+func (mig *AddActionExecutionsTable) String() string {
+	return "17_logstore_action_executions"
+}
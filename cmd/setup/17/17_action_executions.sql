@@ -0,0 +1,21 @@
+CREATE TABLE IF NOT EXISTS logstore.action_executions (
+    instance_id TEXT NOT NULL
+    , id TEXT NOT NULL
+    , resource_owner TEXT NOT NULL
+    , action_id TEXT NOT NULL
+    , flow_type SMALLINT NOT NULL
+    , trigger_type SMALLINT NOT NULL
+    , aggregate_type TEXT
+    , aggregate_id TEXT
+    , sequence BIGINT
+    , event_type TEXT
+    , log_date TIMESTAMPTZ NOT NULL
+    , took INTERVAL
+    , succeeded BOOLEAN NOT NULL
+    , error TEXT
+    , logs JSONB
+
+    , PRIMARY KEY (instance_id, id)
+);
+
+CREATE INDEX IF NOT EXISTS action_executions_action_date_desc ON logstore.action_executions (instance_id, action_id, log_date DESC);
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 18/18_import_checkpoints.sql
+	addImportCheckpointsTable string
+)
+
+type AddImportCheckpointsTable struct {
+	dbClient *database.DB
+}
+
+func (mig *AddImportCheckpointsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addImportCheckpointsTable)
+	return err
+}
+
+func (mig *AddImportCheckpointsTable) String() string {
+	return "18_adminapi_import_checkpoints"
+}
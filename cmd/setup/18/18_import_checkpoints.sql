@@ -0,0 +1,9 @@
+CREATE TABLE IF NOT EXISTS adminapi.import_checkpoints (
+    instance_id TEXT NOT NULL
+    , import_id TEXT NOT NULL
+    , object_type TEXT NOT NULL
+    , object_id TEXT NOT NULL
+    , creation_date TIMESTAMPTZ NOT NULL DEFAULT now()
+
+    , PRIMARY KEY (instance_id, import_id, object_type, object_id)
+);
@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 19/19_dpop_proofs.sql
+	addDPoPProofsTable string
+)
+
+type AddDPoPProofsTable struct {
+	dbClient *database.DB
+}
+
+func (mig *AddDPoPProofsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addDPoPProofsTable)
+	return err
+}
+
+func (mig *AddDPoPProofsTable) String() string {
+	return "19_auth_dpop_proofs"
+}
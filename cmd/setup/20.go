@@ -0,0 +1,26 @@
+package setup
+
+import (
+	"context"
+	_ "embed"
+
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var (
+	//go:embed 20/20_backchannel_auth_polls.sql
+	addBackChannelAuthPollsTable string
+)
+
+type AddBackChannelAuthPollsTable struct {
+	dbClient *database.DB
+}
+
+func (mig *AddBackChannelAuthPollsTable) Execute(ctx context.Context) error {
+	_, err := mig.dbClient.ExecContext(ctx, addBackChannelAuthPollsTable)
+	return err
+}
+
+func (mig *AddBackChannelAuthPollsTable) String() string {
+	return "20_auth_backchannel_auth_polls"
+}
@@ -0,0 +1,10 @@
+CREATE TABLE IF NOT EXISTS auth.backchannel_auth_polls (
+    instance_id TEXT NOT NULL
+    , client_id TEXT NOT NULL
+    , auth_req_id TEXT NOT NULL
+    , next_poll TIMESTAMPTZ NOT NULL
+
+    , PRIMARY KEY (instance_id, client_id, auth_req_id)
+);
+
+CREATE INDEX IF NOT EXISTS backchannel_auth_polls_next_poll ON auth.backchannel_auth_polls (next_poll);
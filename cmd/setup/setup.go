@@ -100,6 +100,11 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	steps.s13FixQuotaProjection = &FixQuotaConstraints{dbClient: zitadelDBClient}
 	steps.s14NewEventsTable = &NewEventsTable{dbClient: esPusherDBClient}
 	steps.s15CurrentStates = &CurrentProjectionState{dbClient: zitadelDBClient}
+	steps.s16AddDPoPJKTColumn = &AddDPoPJKTColumn{dbClient: zitadelDBClient}
+	steps.s17AddActionExecutionsTable = &AddActionExecutionsTable{dbClient: zitadelDBClient}
+	steps.s18ImportCheckpoints = &AddImportCheckpointsTable{dbClient: zitadelDBClient}
+	steps.s19AddDPoPProofsTable = &AddDPoPProofsTable{dbClient: zitadelDBClient}
+	steps.s20BackChannelAuthPolls = &AddBackChannelAuthPollsTable{dbClient: zitadelDBClient}
 
 	err = projection.Create(ctx, zitadelDBClient, eventstoreClient, config.Projections, nil, nil, nil)
 	logging.OnError(err).Fatal("unable to start projections")
@@ -140,6 +145,16 @@ func Setup(config *Config, steps *Steps, masterKey string) {
 	logging.WithFields("name", steps.s13FixQuotaProjection.String()).OnError(err).Fatal("migration failed")
 	err = migration.Migrate(ctx, eventstoreClient, steps.s15CurrentStates)
 	logging.WithFields("name", steps.s15CurrentStates.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s16AddDPoPJKTColumn)
+	logging.WithFields("name", steps.s16AddDPoPJKTColumn.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s17AddActionExecutionsTable)
+	logging.WithFields("name", steps.s17AddActionExecutionsTable.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s18ImportCheckpoints)
+	logging.WithFields("name", steps.s18ImportCheckpoints.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s19AddDPoPProofsTable)
+	logging.WithFields("name", steps.s19AddDPoPProofsTable.String()).OnError(err).Fatal("migration failed")
+	err = migration.Migrate(ctx, eventstoreClient, steps.s20BackChannelAuthPolls)
+	logging.WithFields("name", steps.s20BackChannelAuthPolls.String()).OnError(err).Fatal("migration failed")
 
 	for _, repeatableStep := range repeatableSteps {
 		err = migration.Migrate(ctx, eventstoreClient, repeatableStep)
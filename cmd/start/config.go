@@ -13,6 +13,7 @@ import (
 	admin_es "github.com/zitadel/zitadel/internal/admin/repository/eventsourcing"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/http/scim"
 	"github.com/zitadel/zitadel/internal/api/oidc"
 	"github.com/zitadel/zitadel/internal/api/saml"
 	"github.com/zitadel/zitadel/internal/api/ui/console"
@@ -25,51 +26,63 @@ import (
 	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventactions"
+	"github.com/zitadel/zitadel/internal/eventforwarding"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/ldapsync"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/notification/handlers"
+	"github.com/zitadel/zitadel/internal/provisioning"
 	"github.com/zitadel/zitadel/internal/query/projection"
 	static_config "github.com/zitadel/zitadel/internal/static/config"
 	metrics "github.com/zitadel/zitadel/internal/telemetry/metrics/config"
 	tracing "github.com/zitadel/zitadel/internal/telemetry/tracing/config"
+	"github.com/zitadel/zitadel/internal/userimport"
 )
 
 type Config struct {
-	Log               *logging.Config
-	Port              uint16
-	ExternalPort      uint16
-	ExternalDomain    string
-	ExternalSecure    bool
-	TLS               network.TLS
-	HTTP2HostHeader   string
-	HTTP1HostHeader   string
-	WebAuthNName      string
-	Database          database.Config
-	Tracing           tracing.Config
-	Metrics           metrics.Config
-	Projections       projection.Config
-	Auth              auth_es.Config
-	Admin             admin_es.Config
-	UserAgentCookie   *middleware.UserAgentCookieConfig
-	OIDC              oidc.Config
-	SAML              saml.Config
-	Login             login.Config
-	Console           console.Config
-	AssetStorage      static_config.AssetStorageConfig
-	InternalAuthZ     internal_authz.Config
-	SystemDefaults    systemdefaults.SystemDefaults
-	EncryptionKeys    *encryptionKeyConfig
-	DefaultInstance   command.InstanceSetup
-	AuditLogRetention time.Duration
-	SystemAPIUsers    SystemAPIUsers
-	CustomerPortal    string
-	Machine           *id.Config
-	Actions           *actions.Config
-	Eventstore        *eventstore.Config
-	LogStore          *logstore.Configs
-	Quotas            *QuotasConfig
-	Telemetry         *handlers.TelemetryPusherConfig
+	Log                      *logging.Config
+	Port                     uint16
+	ExternalPort             uint16
+	ExternalDomain           string
+	ExternalSecure           bool
+	TLS                      network.TLS
+	HTTP2HostHeader          string
+	HTTP1HostHeader          string
+	WebAuthNName             string
+	Database                 database.Config
+	Tracing                  tracing.Config
+	Metrics                  metrics.Config
+	Projections              projection.Config
+	Auth                     auth_es.Config
+	Admin                    admin_es.Config
+	UserAgentCookie          *middleware.UserAgentCookieConfig
+	OIDC                     oidc.Config
+	SAML                     saml.Config
+	SCIM                     scim.Config
+	Login                    login.Config
+	Console                  console.Config
+	AssetStorage             static_config.AssetStorageConfig
+	InternalAuthZ            internal_authz.Config
+	SystemDefaults           systemdefaults.SystemDefaults
+	EncryptionKeys           *encryptionKeyConfig
+	DefaultInstance          command.InstanceSetup
+	AuditLogRetention        time.Duration
+	ActionExecutionRetention time.Duration
+	SystemAPIUsers           SystemAPIUsers
+	CustomerPortal           string
+	Machine                  *id.Config
+	Actions                  *actions.Config
+	Eventstore               *eventstore.Config
+	LogStore                 *logstore.Configs
+	Quotas                   *QuotasConfig
+	Telemetry                *handlers.TelemetryPusherConfig
+	EventForwarding          *eventforwarding.Config
+	Provisioning             *provisioning.Config
+	EventActions             *eventactions.Config
+	LDAPSync                 *ldapsync.Config
+	UserImport               *userimport.Config
 }
 
 type QuotasConfig struct {
@@ -123,6 +136,7 @@ type encryptionKeyConfig struct {
 	SMS                  *crypto.KeyConfig
 	SMTP                 *crypto.KeyConfig
 	User                 *crypto.KeyConfig
+	Action               *crypto.KeyConfig
 	CSRFCookieKeyID      string
 	UserAgentCookieKeyID string
 }
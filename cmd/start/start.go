@@ -33,6 +33,8 @@ import (
 	"github.com/zitadel/zitadel/internal/api"
 	"github.com/zitadel/zitadel/internal/api/assets"
 	internal_authz "github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
+	"github.com/zitadel/zitadel/internal/api/dpop/replay"
 	"github.com/zitadel/zitadel/internal/api/grpc/admin"
 	"github.com/zitadel/zitadel/internal/api/grpc/auth"
 	"github.com/zitadel/zitadel/internal/api/grpc/management"
@@ -44,6 +46,7 @@ import (
 	user_v2 "github.com/zitadel/zitadel/internal/api/grpc/user/v2"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/api/http/scim"
 	"github.com/zitadel/zitadel/internal/api/idp"
 	"github.com/zitadel/zitadel/internal/api/oidc"
 	"github.com/zitadel/zitadel/internal/api/robots_txt"
@@ -59,10 +62,13 @@ import (
 	cryptoDB "github.com/zitadel/zitadel/internal/crypto/database"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventactions"
+	"github.com/zitadel/zitadel/internal/eventforwarding"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	old_es "github.com/zitadel/zitadel/internal/eventstore/repository/sql"
 	new_es "github.com/zitadel/zitadel/internal/eventstore/v3"
 	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/ldapsync"
 	"github.com/zitadel/zitadel/internal/logstore"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/access"
 	"github.com/zitadel/zitadel/internal/logstore/emitters/execution"
@@ -70,8 +76,10 @@ import (
 	"github.com/zitadel/zitadel/internal/logstore/record"
 	"github.com/zitadel/zitadel/internal/net"
 	"github.com/zitadel/zitadel/internal/notification"
+	"github.com/zitadel/zitadel/internal/provisioning"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/static"
+	"github.com/zitadel/zitadel/internal/userimport"
 	"github.com/zitadel/zitadel/internal/webauthn"
 	"github.com/zitadel/zitadel/openapi"
 )
@@ -165,13 +173,19 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 			}
 		},
 		config.AuditLogRetention,
+		config.ActionExecutionRetention,
 		config.SystemAPIUsers,
 	)
 	if err != nil {
 		return fmt.Errorf("cannot start queries: %w", err)
 	}
 
-	authZRepo, err := authz.Start(queries, eventstoreClient, zitadelDBClient, keys.OIDC, config.ExternalSecure)
+	// the verifier of DPoP proofs is shared by the OIDC provider and the APIs,
+	// the used proofs are stored in the database to prevent their replay against any ZITADEL process
+	dpopReplays := replay.NewDatabaseStorage(zitadelDBClient)
+	dpopReplays.StartCleanup(ctx, config.OIDC.DPoP.ReplayCleanupInterval)
+	dpopVerifier := dpop.NewVerifier(config.OIDC.DPoP, keys.OIDCKey, dpopReplays)
+	authZRepo, err := authz.Start(queries, eventstoreClient, zitadelDBClient, keys.OIDC, config.ExternalSecure, dpopVerifier)
 	if err != nil {
 		return fmt.Errorf("error starting authz repo: %w", err)
 	}
@@ -204,6 +218,7 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		keys.DomainVerification,
 		keys.OIDC,
 		keys.SAML,
+		keys.Action,
 		&http.Client{},
 		permissionCheck,
 		sessionTokenVerifier,
@@ -226,8 +241,16 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		return err
 	}
 
-	actionsLogstoreSvc := logstore.New(queries, actionsExecutionDBEmitter, actionsExecutionStdoutEmitter)
+	actionsExecutionRunStorage := execution.NewDatabaseRunStorage(zitadelDBClient, queries)
+	actionsExecutionRunsEmitter, err := logstore.NewEmitter[*record.ExecutionLog](ctx, clock, config.LogStore.Execution.Database, actionsExecutionRunStorage)
+	if err != nil {
+		return err
+	}
+	actionsExecutionRunStorage.StartCleanup(ctx, config.LogStore.Execution.RunsCleanupInterval)
+
+	actionsLogstoreSvc := logstore.New(queries, actionsExecutionDBEmitter, actionsExecutionStdoutEmitter, actionsExecutionRunsEmitter)
 	actions.SetLogstoreService(actionsLogstoreSvc)
+	actions.SetSigningKeyAlgorithm(keys.Action)
 
 	notification.Start(
 		ctx,
@@ -248,6 +271,14 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		keys.SMS,
 	)
 
+	if err = eventforwarding.Start(ctx, config.EventForwarding, config.Projections.Customizations["eventforwarding"]); err != nil {
+		return err
+	}
+	provisioning.Start(ctx, config.Provisioning, config.Projections.Customizations["provisioning"], queries, eventstoreClient, keys.IDPConfig)
+	eventactions.Start(ctx, config.EventActions, config.Projections.Customizations["eventactions"], queries)
+	ldapsync.Start(ctx, config.LDAPSync, commands, queries, keys.IDPConfig, keys.User)
+	userimport.Start(ctx, config.UserImport, commands, queries)
+
 	router := mux.NewRouter()
 	tlsConfig, err := config.TLS.Config()
 	if err != nil {
@@ -266,6 +297,7 @@ func startZitadel(config *Config, masterKey string, server chan<- *Server) error
 		authZRepo,
 		keys,
 		permissionCheck,
+		dpopVerifier,
 	)
 	if err != nil {
 		return err
@@ -308,6 +340,7 @@ func startAPIs(
 	authZRepo authz_repo.Repository,
 	keys *encryptionKeys,
 	permissionCheck domain.PermissionCheck,
+	dpopVerifier *dpop.Verifier,
 ) error {
 	repo := struct {
 		authz_repo.Repository
@@ -370,32 +403,35 @@ func startAPIs(
 	if err := apis.RegisterServer(ctx, system.CreateServer(commands, queries, config.Database.DatabaseName(), config.DefaultInstance, config.ExternalDomain), tlsConfig); err != nil {
 		return err
 	}
-	if err := apis.RegisterServer(ctx, admin.CreateServer(config.Database.DatabaseName(), commands, queries, config.SystemDefaults, config.ExternalSecure, keys.User, config.AuditLogRetention), tlsConfig); err != nil {
+	if err := apis.RegisterServer(ctx, admin.CreateServer(config.Database.DatabaseName(), commands, queries, store, config.SystemDefaults, config.ExternalSecure, keys.User, config.AuditLogRetention, dbClient), tlsConfig); err != nil {
 		return err
 	}
+	admin.StartImportCheckpointCleanup(ctx, dbClient, config.Admin.ImportCheckpointLifetime)
 	if err := apis.RegisterServer(ctx, management.CreateServer(commands, queries, config.SystemDefaults, keys.User, config.ExternalSecure), tlsConfig); err != nil {
 		return err
 	}
 	if err := apis.RegisterServer(ctx, auth.CreateServer(commands, queries, authRepo, config.SystemDefaults, keys.User, config.ExternalSecure), tlsConfig); err != nil {
 		return err
 	}
-	if err := apis.RegisterService(ctx, user_v2.CreateServer(commands, queries, keys.User, keys.IDPConfig, idp.CallbackURL(config.ExternalSecure), idp.SAMLRootURL(config.ExternalSecure))); err != nil {
+	if err := apis.RegisterService(ctx, user_v2.CreateServer(commands, queries, keys.User, keys.IDPConfig, idp.CallbackURL(config.ExternalSecure), idp.SAMLRootURL(config.ExternalSecure), crypto.NewBCrypt(config.SystemDefaults.SecretGenerators.PasswordSaltCost), config.ExternalSecure)); err != nil {
 		return err
 	}
 	if err := apis.RegisterService(ctx, session.CreateServer(commands, queries, permissionCheck)); err != nil {
 		return err
 	}
 
-	if err := apis.RegisterService(ctx, settings.CreateServer(commands, queries, config.ExternalSecure)); err != nil {
+	if err := apis.RegisterService(ctx, settings.CreateServer(commands, queries, permissionCheck, config.ExternalSecure)); err != nil {
 		return err
 	}
-	if err := apis.RegisterService(ctx, org.CreateServer(commands, queries, permissionCheck)); err != nil {
+	if err := apis.RegisterService(ctx, org.CreateServer(commands, queries)); err != nil {
 		return err
 	}
 	instanceInterceptor := middleware.InstanceInterceptor(queries, config.HTTP1HostHeader, login.IgnoreInstanceEndpoints...)
 	assetsCache := middleware.AssetsCacheInterceptor(config.AssetStorage.Cache.MaxAge, config.AssetStorage.Cache.SharedMaxAge)
 	apis.RegisterHandlerOnPrefix(assets.HandlerPrefix, assets.NewHandler(commands, verifier, config.InternalAuthZ, id.SonyFlakeGenerator(), store, queries, middleware.CallDurationHandler, instanceInterceptor.Handler, assetsCache.Handler, limitingAccessInterceptor.Handle))
 
+	apis.RegisterHandlerOnPrefix(scim.HandlerPrefix, scim.NewHandler(config.SCIM, commands, queries, verifier, config.InternalAuthZ, keys.User, config.ExternalSecure, middleware.CallDurationHandler, instanceInterceptor.Handler, limitingAccessInterceptor.Handle))
+
 	apis.RegisterHandlerOnPrefix(idp.HandlerPrefix, idp.NewHandler(commands, queries, keys.IDPConfig, config.ExternalSecure, instanceInterceptor.Handler))
 
 	userAgentInterceptor, err := middleware.NewUserAgentHandler(config.UserAgentCookie, keys.UserAgentCookieKey, id.SonyFlakeGenerator(), config.ExternalSecure, login.EndpointResources, login.EndpointExternalLoginCallbackFormPost, login.EndpointSAMLACS)
@@ -417,17 +453,20 @@ func startAPIs(
 	}
 	apis.RegisterHandlerOnPrefix(openapi.HandlerPrefix, openAPIHandler)
 
-	oidcServer, err := oidc.NewServer(config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.OIDCKey, eventstore, dbClient, userAgentInterceptor, instanceInterceptor.Handler, limitingAccessInterceptor, config.Log.Slog())
+	oidcServer, err := oidc.NewServer(config.OIDC, login.DefaultLoggedOutPath, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.OIDCKey, eventstore, dbClient, userAgentInterceptor, instanceInterceptor.Handler, limitingAccessInterceptor, config.Log.Slog(), dpopVerifier)
 	if err != nil {
 		return fmt.Errorf("unable to start oidc provider: %w", err)
 	}
 	apis.RegisterHandlerPrefixes(oidcServer, oidcPrefixes...)
+	oidcServer.StartBackChannelLogout(ctx, config.Projections.Customizations["backchannellogout"])
+	oidcServer.StartBackChannelAuthPing(ctx, config.Projections.Customizations["backchannelauthping"])
 
 	samlProvider, err := saml.NewProvider(config.SAML, config.ExternalSecure, commands, queries, authRepo, keys.OIDC, keys.SAML, eventstore, dbClient, instanceInterceptor.Handler, userAgentInterceptor, limitingAccessInterceptor)
 	if err != nil {
 		return fmt.Errorf("unable to start saml provider: %w", err)
 	}
 	apis.RegisterHandlerOnPrefix(saml.HandlerPrefix, samlProvider.HttpHandler())
+	samlProvider.StartSingleLogout(ctx, config.Projections.Customizations["samlsinglelogout"], config.ExternalPort)
 
 	c, err := console.Start(config.Console, config.ExternalSecure, oidcServer.IssuerFromRequest, middleware.CallDurationHandler, instanceInterceptor.Handler, limitingAccessInterceptor, config.CustomerPortal)
 	if err != nil {
@@ -443,7 +482,8 @@ func startAPIs(
 		store,
 		console.HandlerPrefix+"/",
 		oidcServer.AuthCallbackURL(),
-		provider.AuthCallbackURL(samlProvider),
+		provider.AuthCallbackURL(samlProvider.Provider),
+		samlProvider.IdPInitiatedURL(),
 		config.ExternalSecure,
 		userAgentInterceptor,
 		op.NewIssuerInterceptor(oidcServer.IssuerFromRequest).Handler,
@@ -615,6 +615,8 @@ module.exports = {
         "apis/actions/external-authentication",
         "apis/actions/complement-token",
         "apis/actions/customize-samlresponse",
+        "apis/actions/api-request",
+        "apis/actions/event",
         "apis/actions/objects",
       ]
     },
@@ -8,6 +8,7 @@ import (
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/sirupsen/logrus"
 
+	"github.com/zitadel/zitadel/internal/domain"
 	z_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
 )
@@ -18,7 +19,7 @@ type Config struct {
 
 var ErrHalt = errors.New("interrupt")
 
-type jsAction func(fields, fields) error
+type jsAction func(fields, interface{}) error
 
 const (
 	actionStartedMessage   = "action run started"
@@ -38,22 +39,22 @@ func Run(ctx context.Context, ctxParam contextFields, apiParam apiFields, script
 	remaining := logstoreService.Limit(ctx, config.instanceID)
 	config.cutTimeouts(remaining)
 
-	config.logger.Log(actionStartedMessage)
+	config.logger.log(actionStartedMessage, logrus.InfoLevel, false)
 	if remaining != nil && *remaining == 0 {
 		return z_errs.ThrowResourceExhausted(nil, "ACTIO-f19Ii", "Errors.Quota.Execution.Exhausted")
 	}
 
 	defer func() {
-		if err != nil {
-			config.logger.log(actionFailedMessage(err), logrus.ErrorLevel, true)
-		} else {
-			config.logger.log(actionSucceededMessage, logrus.InfoLevel, true)
-		}
+		config.logRun(err)
 		if config.allowedToFail {
 			err = nil
 		}
 	}()
 
+	if config.target != nil {
+		return executeTarget(ctx, config, ctxParam, apiParam, name)
+	}
+
 	if err := executeScript(config, ctxParam, apiParam, script); err != nil {
 		return err
 	}
@@ -126,16 +127,20 @@ func executeFn(config *runConfig, fn jsAction) (err error) {
 		err = fmt.Errorf("unknown error occurred: %v", r)
 	}()
 
-	if err = fn(config.ctxParam.fields, config.apiParam.fields); err != nil {
+	if err = fn(config.ctxParam.fields, config.api()); err != nil {
 		return err
 	}
 	return nil
 }
 
 func ActionToOptions(a *query.Action) []Option {
-	opts := make([]Option, 0, 1)
+	opts := make([]Option, 0, 3)
+	opts = append(opts, withAction(a.ID, a.ResourceOwner))
 	if a.AllowedToFail {
 		opts = append(opts, WithAllowedToFail())
 	}
+	if a.Type == domain.ActionTypeTarget {
+		opts = append(opts, WithTarget(a.TargetURL, a.MaxRetries, a.SigningKey))
+	}
 	return opts
 }
@@ -7,6 +7,9 @@ import (
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/require"
 	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore/record"
 )
 
 const (
@@ -21,6 +24,35 @@ func WithAllowedToFail() Option {
 	}
 }
 
+// WithTrigger sets the flow and trigger type the action runs on,
+// they are stored with the execution of the action
+func WithTrigger(flowType domain.FlowType, triggerType domain.TriggerType) Option {
+	return func(c *runConfig) {
+		c.flowType = flowType
+		c.triggerType = triggerType
+	}
+}
+
+// WithEvent sets the event the action runs on,
+// it's stored with the execution of the action
+func WithEvent(aggregateType, aggregateID string, sequence uint64, eventType string) Option {
+	return func(c *runConfig) {
+		c.event = &record.ExecutionRunEvent{
+			AggregateType: aggregateType,
+			AggregateID:   aggregateID,
+			Sequence:      sequence,
+			EventType:     eventType,
+		}
+	}
+}
+
+func withAction(actionID, resourceOwner string) Option {
+	return func(c *runConfig) {
+		c.actionID = actionID
+		c.resourceOwner = resourceOwner
+	}
+}
+
 type runConfig struct {
 	allowedToFail bool
 	functionTimeout,
@@ -31,6 +63,15 @@ type runConfig struct {
 	vm         *goja.Runtime
 	ctxParam   *ctxConfig
 	apiParam   *apiConfig
+	target     *target
+	dryRun     *DryRun
+	httpMocks  []*HTTPMock
+
+	actionID      string
+	resourceOwner string
+	flowType      domain.FlowType
+	triggerType   domain.TriggerType
+	event         *record.ExecutionRunEvent
 }
 
 func newRunConfig(ctx context.Context, opts ...Option) *runConfig {
@@ -0,0 +1,128 @@
+package actions
+
+import (
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+)
+
+// DryRun contains the result of an action run which is neither applied nor stored
+type DryRun struct {
+	// Mutations are the calls of the functions of the api object (and the assignments to it) in the order of the calls,
+	// a mutation has the same form as the calls returned by a target, e.g. {"function": "v1.claims.setClaim", "args": ["key", "value"]}
+	Mutations []*Mutation
+	Logs      []*record.ExecutionRunLog
+	Took      time.Duration
+}
+
+type Mutation struct {
+	Function string        `json:"function"`
+	Args     []interface{} `json:"args"`
+}
+
+// WithDryRun records the calls on the api object instead of calling the api fields passed to Run.
+// The logs of the run are returned in the result and not passed to the logstore.
+func WithDryRun(result *DryRun) Option {
+	return func(c *runConfig) {
+		c.dryRun = result
+	}
+}
+
+// HTTPMock is returned by the http module and the target instead of calling the url
+type HTTPMock struct {
+	// Method of the request, all methods match if empty
+	Method string
+	URL    string
+	Status int
+	Body   string
+}
+
+// WithHTTPMocks returns the mocked responses for matching requests,
+// other requests are sent. The deny list is checked before the mocks.
+func WithHTTPMocks(mocks ...*HTTPMock) Option {
+	return func(c *runConfig) {
+		c.httpMocks = mocks
+	}
+}
+
+func (c *runConfig) httpTransport() http.RoundTripper {
+	if len(c.httpMocks) == 0 {
+		return new(transport)
+	}
+	return &mockTransport{mocks: c.httpMocks, next: new(transport)}
+}
+
+type mockTransport struct {
+	mocks []*HTTPMock
+	next  http.RoundTripper
+}
+
+func (t *mockTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if httpConfig != nil && isHostBlocked(httpConfig.DenyList, req.URL) {
+		return nil, z_errs.ThrowInvalidArgument(nil, "ACTIO-Ung5a", "host is denied")
+	}
+	for _, mock := range t.mocks {
+		if mock.URL != req.URL.String() || (mock.Method != "" && !strings.EqualFold(mock.Method, req.Method)) {
+			continue
+		}
+		status := mock.Status
+		if status == 0 {
+			status = http.StatusOK
+		}
+		return &http.Response{
+			Status:     http.StatusText(status),
+			StatusCode: status,
+			Header:     http.Header{"Content-Type": []string{"application/json"}},
+			Body:       io.NopCloser(strings.NewReader(mock.Body)),
+			Request:    req,
+		}, nil
+	}
+	return t.next.RoundTrip(req)
+}
+
+// api returns the api object passed to the function of the action
+func (c *runConfig) api() interface{} {
+	if c.dryRun == nil {
+		return c.apiParam.fields
+	}
+	return c.dryRun.recorder(c.vm, "")
+}
+
+// recorder returns an object which records each call of a function
+// and each assignment on any path of the object as mutation
+func (d *DryRun) recorder(vm *goja.Runtime, path string) goja.Value {
+	target := vm.ToValue(func(goja.FunctionCall) goja.Value { return goja.Undefined() }).ToObject(vm)
+	return vm.ToValue(vm.NewProxy(target, &goja.ProxyTrapConfig{
+		Get: func(_ *goja.Object, property string, _ goja.Value) goja.Value {
+			return d.recorder(vm, joinPath(path, property))
+		},
+		Set: func(_ *goja.Object, property string, value goja.Value, _ goja.Value) bool {
+			d.Mutations = append(d.Mutations, &Mutation{
+				Function: joinPath(path, property),
+				Args:     []interface{}{value.Export()},
+			})
+			return true
+		},
+		Apply: func(_ *goja.Object, _ goja.Value, args []goja.Value) goja.Value {
+			mutation := &Mutation{Function: path, Args: make([]interface{}, len(args))}
+			for i, arg := range args {
+				mutation.Args[i] = arg.Export()
+			}
+			d.Mutations = append(d.Mutations, mutation)
+			return goja.Undefined()
+		},
+	}))
+}
+
+func joinPath(path, property string) string {
+	if path == "" {
+		return property
+	}
+	return path + "." + property
+}
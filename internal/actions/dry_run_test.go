@@ -0,0 +1,132 @@
+package actions
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+)
+
+func TestRun_dryRun(t *testing.T) {
+	SetHTTPConfig(&HTTPConfig{DenyList: []AddressChecker{&DomainChecker{Domain: "denied.com"}}})
+	defer SetHTTPConfig(nil)
+	type res struct {
+		mutations []*Mutation
+		logs      []string
+		wantErr   bool
+	}
+	tests := []struct {
+		name   string
+		script string
+		mocks  []*HTTPMock
+		res    res
+	}{
+		{
+			name: "mutations recorded",
+			script: `function testFunc(ctx, api) {
+	api.v1.claims.setClaim("key", ctx.v1.user.name);
+	api.v1.user.appendMetadata("meta", {"value": 1});
+	api.setFirstName("first");
+	api.userGrants = [];
+}`,
+			res: res{
+				mutations: []*Mutation{
+					{Function: "v1.claims.setClaim", Args: []interface{}{"key", "user"}},
+					{Function: "v1.user.appendMetadata", Args: []interface{}{"meta", map[string]interface{}{"value": int64(1)}}},
+					{Function: "setFirstName", Args: []interface{}{"first"}},
+					{Function: "userGrants", Args: []interface{}{[]interface{}{}}},
+				},
+			},
+		},
+		{
+			name: "logs returned",
+			script: `function testFunc(ctx, api) {
+	let logger = require("zitadel/log");
+	logger.log("first");
+	logger.error("second");
+}`,
+			res: res{
+				logs: []string{"first", "second"},
+			},
+		},
+		{
+			name: "http mocked",
+			script: `function testFunc(ctx, api) {
+	let http = require("zitadel/http");
+	let res = http.fetch("https://mocked.com/user", {method: "POST"});
+	api.v1.claims.setClaim("status", res.status);
+	api.v1.claims.setClaim("name", res.json().name);
+}`,
+			mocks: []*HTTPMock{
+				{Method: "GET", URL: "https://mocked.com/user", Status: 500},
+				{Method: "POST", URL: "https://mocked.com/user", Status: 201, Body: `{"name": "mocked"}`},
+			},
+			res: res{
+				mutations: []*Mutation{
+					{Function: "v1.claims.setClaim", Args: []interface{}{"status", int64(201)}},
+					{Function: "v1.claims.setClaim", Args: []interface{}{"name", "mocked"}},
+				},
+			},
+		},
+		{
+			name: "mocked host denied, error",
+			script: `function testFunc(ctx, api) {
+	let http = require("zitadel/http");
+	http.fetch("https://denied.com/user");
+}`,
+			mocks: []*HTTPMock{
+				{URL: "https://denied.com/user", Body: `{}`},
+			},
+			res: res{
+				wantErr: true,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var emitted int
+			emitter, err := logstore.NewEmitter[*record.ExecutionLog](context.Background(), clock.New(), &logstore.EmitterConfig{Enabled: true}, logstore.LogEmitterFunc[*record.ExecutionLog](func(_ context.Context, bulk []*record.ExecutionLog) error {
+				emitted += len(bulk)
+				return nil
+			}))
+			require.NoError(t, err)
+			SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil, emitter))
+
+			ctx, cancel := context.WithTimeout(authz.WithInstanceID(context.Background(), "instance1"), 10*time.Second)
+			defer cancel()
+			result := new(DryRun)
+			err = Run(ctx,
+				SetContextFields(SetFields("v1", SetFields("user", SetFields("name", "user")))),
+				nil,
+				tt.script,
+				"testFunc",
+				WithDryRun(result),
+				WithHTTP(ctx),
+				WithHTTPMocks(tt.mocks...),
+			)
+			assert.Zero(t, emitted, "dry run must not be passed to the logstore")
+			if tt.res.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.mutations, result.Mutations)
+			logs := make([]string, len(result.Logs))
+			for i, log := range result.Logs {
+				logs[i] = log.Message
+			}
+			if tt.res.logs == nil {
+				tt.res.logs = []string{}
+			}
+			assert.Equal(t, tt.res.logs, logs)
+			assert.NotZero(t, result.Took)
+		})
+	}
+}
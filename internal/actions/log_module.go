@@ -6,6 +6,7 @@ import (
 
 	"github.com/dop251/goja"
 	"github.com/dop251/goja_nodejs/console"
+	"github.com/google/uuid"
 	"github.com/sirupsen/logrus"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
@@ -13,6 +14,15 @@ import (
 	"github.com/zitadel/zitadel/internal/logstore/record"
 )
 
+const (
+	// maxLogEntries and maxLogBytes bound the output of the log module which is kept per action run,
+	// the last entry marks the truncation and the calls after the limit is reached are dropped
+	maxLogEntries = 100
+	maxLogBytes   = 64 * 1024
+
+	logTruncatedMessage = "log output truncated"
+)
+
 var (
 	logstoreService *logstore.Service[*record.ExecutionLog]
 	_               console.Printer = (*logger)(nil)
@@ -26,6 +36,14 @@ type logger struct {
 	ctx        context.Context
 	started    time.Time
 	instanceID string
+	// output contains the calls of the log module by the action
+	output []*record.ExecutionRunLog
+	// outputBytes is the size of the messages in output
+	outputBytes int
+	// truncated is set as soon as the output reached maxLogEntries or maxLogBytes
+	truncated bool
+	// dryRun prevents the records from being passed to the logstore
+	dryRun bool
 }
 
 // newLogger returns a *logger instance that should only be used for a single action run.
@@ -39,18 +57,47 @@ func newLogger(ctx context.Context, instanceID string) *logger {
 }
 
 func (l *logger) Log(msg string) {
-	l.log(msg, logrus.InfoLevel, false)
+	l.print(msg, logrus.InfoLevel)
 }
 
 func (l *logger) Warn(msg string) {
-	l.log(msg, logrus.WarnLevel, false)
+	l.print(msg, logrus.WarnLevel)
 }
 
 func (l *logger) Error(msg string) {
-	l.log(msg, logrus.ErrorLevel, false)
+	l.print(msg, logrus.ErrorLevel)
+}
+
+func (l *logger) print(msg string, level logrus.Level) {
+	if l.truncated {
+		return
+	}
+	if len(l.output)+1 >= maxLogEntries || l.outputBytes+len(msg) > maxLogBytes {
+		l.truncated = true
+		msg, level = logTruncatedMessage, logrus.WarnLevel
+	}
+	l.outputBytes += len(msg)
+	r := l.newRecord(msg, level, false)
+	l.output = append(l.output, &record.ExecutionRunLog{
+		LogDate:  r.LogDate,
+		LogLevel: level,
+		Message:  msg,
+	})
+	l.handle(r)
 }
 
 func (l *logger) log(msg string, level logrus.Level, last bool) {
+	l.handle(l.newRecord(msg, level, last))
+}
+
+func (l *logger) handle(r *record.ExecutionLog) {
+	if l.dryRun {
+		return
+	}
+	logstoreService.Handle(l.ctx, r)
+}
+
+func (l *logger) newRecord(msg string, level logrus.Level, last bool) *record.ExecutionLog {
 	ts := time.Now()
 	if l.started.IsZero() {
 		l.started = ts
@@ -64,7 +111,37 @@ func (l *logger) log(msg string, level logrus.Level, last bool) {
 	if last {
 		r.Took = ts.Sub(l.started)
 	}
-	logstoreService.Handle(l.ctx, r)
+	return r
+}
+
+// logRun logs the end of the action run,
+// the record contains the summary of the run which is stored as execution of the action
+func (c *runConfig) logRun(err error) {
+	msg, level := actionSucceededMessage, logrus.InfoLevel
+	if err != nil {
+		msg, level = actionFailedMessage(err), logrus.ErrorLevel
+	}
+	r := c.logger.newRecord(msg, level, true)
+	if c.dryRun != nil {
+		c.dryRun.Logs = c.logger.output
+		c.dryRun.Took = r.Took
+		return
+	}
+	r.ActionID = c.actionID
+	r.Run = &record.ExecutionRun{
+		ID:            uuid.NewString(),
+		ResourceOwner: c.resourceOwner,
+		FlowType:      c.flowType,
+		TriggerType:   c.triggerType,
+		Event:         c.event,
+		Started:       c.logger.started,
+		Succeeded:     err == nil,
+		Logs:          c.logger.output,
+	}
+	if err != nil {
+		r.Run.Error = err.Error()
+	}
+	logstoreService.Handle(c.logger.ctx, r)
 }
 
 func withLogger(ctx context.Context) Option {
@@ -72,6 +149,7 @@ func withLogger(ctx context.Context) Option {
 	instanceID := instance.InstanceID()
 	return func(c *runConfig) {
 		c.logger = newLogger(ctx, instanceID)
+		c.logger.dryRun = c.dryRun != nil
 		c.instanceID = instanceID
 		c.modules["zitadel/log"] = func(runtime *goja.Runtime, module *goja.Object) {
 			console.RequireWithPrinter(c.logger)(runtime, module)
@@ -0,0 +1,123 @@
+package actions
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func TestRun_executionRun(t *testing.T) {
+	tests := []struct {
+		name          string
+		script        string
+		wantSucceeded bool
+		wantError     string
+		wantLogs      []string
+	}{
+		{
+			name: "succeeded with logs",
+			script: `function testFunc() {
+	let logger = require("zitadel/log");
+	logger.log("first");
+	logger.warn("second");
+}`,
+			wantSucceeded: true,
+			wantLogs:      []string{"first", "second"},
+		},
+		{
+			name: "failed",
+			script: `function testFunc() {
+	let logger = require("zitadel/log");
+	logger.error("before");
+	throw "failed";
+}`,
+			wantError: "failed",
+			wantLogs:  []string{"before"},
+		},
+		{
+			name: "truncated after max entries",
+			script: `function testFunc() {
+	let logger = require("zitadel/log");
+	for (let i = 0; i < 150; i++) {
+		logger.log("entry");
+	}
+}`,
+			wantSucceeded: true,
+			wantLogs:      append(repeatLogs("entry", maxLogEntries-1), logTruncatedMessage),
+		},
+		{
+			name: "truncated after max bytes",
+			script: `function testFunc() {
+	let logger = require("zitadel/log");
+	for (let i = 0; i < 50; i++) {
+		logger.log("a".repeat(2000));
+	}
+}`,
+			wantSucceeded: true,
+			wantLogs:      append(repeatLogs(strings.Repeat("a", 2000), maxLogBytes/2000), logTruncatedMessage),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			records := make([]*record.ExecutionLog, 0)
+			emitter, err := logstore.NewEmitter[*record.ExecutionLog](context.Background(), clock.New(), &logstore.EmitterConfig{Enabled: true}, logstore.LogEmitterFunc[*record.ExecutionLog](func(_ context.Context, bulk []*record.ExecutionLog) error {
+				records = append(records, bulk...)
+				return nil
+			}))
+			require.NoError(t, err)
+			SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil, emitter))
+
+			ctx, cancel := context.WithTimeout(authz.WithInstanceID(context.Background(), "instance1"), 10*time.Second)
+			defer cancel()
+			action := &query.Action{ID: "action1", ResourceOwner: "org1", AllowedToFail: true}
+			err = Run(ctx,
+				SetContextFields(),
+				WithAPIFields(),
+				tt.script,
+				"testFunc",
+				append(ActionToOptions(action), WithTrigger(domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation))...,
+			)
+			require.NoError(t, err)
+
+			require.NotEmpty(t, records)
+			last := records[len(records)-1]
+			require.NotNil(t, last.Run)
+			assert.Equal(t, "action1", last.ActionID)
+			assert.Equal(t, "instance1", last.InstanceID)
+			assert.NotEmpty(t, last.Run.ID)
+			assert.Equal(t, "org1", last.Run.ResourceOwner)
+			assert.Equal(t, domain.FlowTypeCustomiseToken, last.Run.FlowType)
+			assert.Equal(t, domain.TriggerTypePreAccessTokenCreation, last.Run.TriggerType)
+			assert.Equal(t, tt.wantSucceeded, last.Run.Succeeded)
+			assert.Contains(t, last.Run.Error, tt.wantError)
+			logs := make([]string, len(last.Run.Logs))
+			for i, log := range last.Run.Logs {
+				logs[i] = log.Message
+			}
+			assert.Equal(t, tt.wantLogs, logs)
+			if !tt.wantSucceeded {
+				assert.Equal(t, logrus.ErrorLevel, last.LogLevel)
+			}
+		})
+	}
+}
+
+func repeatLogs(msg string, count int) []string {
+	logs := make([]string, count)
+	for i := range logs {
+		logs[i] = msg
+	}
+	return logs
+}
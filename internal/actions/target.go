@@ -0,0 +1,214 @@
+package actions
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/dop251/goja"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/crypto"
+	z_errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	targetRetryDelay = 200 * time.Millisecond
+	// maxTargetResponseSize limits the body read from the target
+	maxTargetResponseSize = 1 << 20
+)
+
+func SetSigningKeyAlgorithm(alg crypto.EncryptionAlgorithm) {
+	signingKeyAlgorithm = alg
+}
+
+var signingKeyAlgorithm crypto.EncryptionAlgorithm
+
+type target struct {
+	url        string
+	maxRetries uint32
+	signingKey *crypto.CryptoValue
+	client     *http.Client
+	now        func() time.Time
+}
+
+// WithTarget calls the url instead of running the script of the action
+func WithTarget(url string, maxRetries uint32, signingKey *crypto.CryptoValue) Option {
+	return func(c *runConfig) {
+		c.target = &target{
+			url:        url,
+			maxRetries: maxRetries,
+			signingKey: signingKey,
+			client:     &http.Client{Transport: new(transport)},
+			now:        time.Now,
+		}
+	}
+}
+
+// targetPayload is sent to the target,
+// ctx contains the same objects as the ctx parameter of a script (without functions)
+type targetPayload struct {
+	Function string          `json:"function"`
+	Ctx      json.RawMessage `json:"ctx"`
+}
+
+// targetResponse is returned by the target,
+// the calls are executed on the api object in the returned order
+type targetResponse struct {
+	Calls []*targetCall `json:"calls"`
+}
+
+// targetCall is a call of a function of the api object, e.g.
+// {"function": "v1.claims.setClaim", "args": ["key", "value"]}
+type targetCall struct {
+	Function string        `json:"function"`
+	Args     []interface{} `json:"args"`
+}
+
+func executeTarget(ctx context.Context, config *runConfig, ctxParam contextFields, apiParam apiFields, name string) (err error) {
+	if ctxParam != nil {
+		ctxParam(config.ctxParam)
+	}
+	if apiParam != nil {
+		apiParam(config.apiParam)
+	}
+	// overload error if the preparation of the fields panics
+	defer func() {
+		r := recover()
+		if r == nil {
+			return
+		}
+		if err, _ = r.(error); err == nil {
+			err = fmt.Errorf("unknown error occurred: %v", r)
+		}
+	}()
+
+	ctxJSON, err := config.vm.RunString("JSON.stringify")
+	if err != nil {
+		return err
+	}
+	stringify, _ := goja.AssertFunction(ctxJSON)
+	ctxValue, err := stringify(goja.Undefined(), config.vm.ToValue(config.ctxParam.fields))
+	if err != nil {
+		return err
+	}
+	body, err := json.Marshal(&targetPayload{
+		Function: name,
+		Ctx:      json.RawMessage(ctxValue.String()),
+	})
+	if err != nil {
+		return err
+	}
+	if len(config.httpMocks) > 0 {
+		config.target.client = &http.Client{Transport: config.httpTransport()}
+	}
+	resp, err := config.target.call(ctx, body)
+	if err != nil {
+		return err
+	}
+	api := config.vm.ToValue(config.api()).ToObject(config.vm)
+	for _, call := range resp.Calls {
+		if err = callAPI(config.vm, api, call); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// call sends the body to the target and retries on connection errors and server errors
+// as long as the context is not done
+func (t *target) call(ctx context.Context, body []byte) (resp *targetResponse, err error) {
+	for attempt := uint32(0); attempt <= t.maxRetries; attempt++ {
+		if attempt > 0 {
+			select {
+			case <-ctx.Done():
+				return nil, err
+			case <-time.After(targetRetryDelay):
+			}
+		}
+		var retry bool
+		resp, retry, err = t.send(ctx, body)
+		if !retry {
+			return resp, err
+		}
+	}
+	return nil, err
+}
+
+func (t *target) send(ctx context.Context, body []byte) (_ *targetResponse, retry bool, err error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, t.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Accept", "application/json")
+	signature, err := t.sign(body)
+	if err != nil {
+		return nil, false, err
+	}
+	req.Header.Set(http_utils.SignatureHeader, signature)
+
+	res, err := t.client.Do(req)
+	if err != nil {
+		return nil, ctx.Err() == nil, err
+	}
+	defer res.Body.Close()
+
+	if res.StatusCode >= http.StatusInternalServerError || res.StatusCode == http.StatusTooManyRequests {
+		return nil, true, z_errs.ThrowUnavailable(fmt.Errorf("calling target %s returned %s", t.url, res.Status), "ACTIO-Ooy7e", "Errors.Action.Target.Failed")
+	}
+	if res.StatusCode < 200 || res.StatusCode >= 300 {
+		return nil, false, z_errs.ThrowPreconditionFailed(fmt.Errorf("calling target %s returned %s", t.url, res.Status), "ACTIO-ahN4i", "Errors.Action.Target.Failed")
+	}
+	resBody, err := io.ReadAll(io.LimitReader(res.Body, maxTargetResponseSize))
+	if err != nil {
+		return nil, true, err
+	}
+	resp := new(targetResponse)
+	if len(bytes.TrimSpace(resBody)) == 0 {
+		return resp, false, nil
+	}
+	if err = json.Unmarshal(resBody, resp); err != nil {
+		return nil, false, z_errs.ThrowInvalidArgument(err, "ACTIO-ieX5a", "Errors.Action.Target.InvalidResponse")
+	}
+	return resp, false, nil
+}
+
+func (t *target) sign(body []byte) (string, error) {
+	if t.signingKey == nil || signingKeyAlgorithm == nil {
+		return "", z_errs.ThrowInternal(nil, "ACTIO-Eix2a", "Errors.Internal")
+	}
+	key, err := crypto.DecryptString(t.signingKey, signingKeyAlgorithm)
+	if err != nil {
+		return "", err
+	}
+	return http_utils.Sign([]byte(key), t.now(), body), nil
+}
+
+// callAPI calls the function of the api object the same way a script would
+func callAPI(vm *goja.Runtime, api *goja.Object, call *targetCall) error {
+	this := api
+	var value goja.Value = api
+	for _, name := range strings.Split(call.Function, ".") {
+		this = value.ToObject(vm)
+		value = this.Get(name)
+		if value == nil || goja.IsUndefined(value) || goja.IsNull(value) {
+			return z_errs.ThrowInvalidArgumentf(nil, "ACTIO-Tho4e", "function %s of api not found", call.Function)
+		}
+	}
+	fn, ok := goja.AssertFunction(value)
+	if !ok {
+		return z_errs.ThrowInvalidArgumentf(nil, "ACTIO-Ree8a", "%s of api is not a function", call.Function)
+	}
+	args := make([]goja.Value, len(call.Args))
+	for i, arg := range call.Args {
+		args[i] = vm.ToValue(arg)
+	}
+	_, err := fn(this, args...)
+	return err
+}
@@ -0,0 +1,165 @@
+package actions
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+)
+
+func TestRun_target(t *testing.T) {
+	SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	SetSigningKeyAlgorithm(crypto.CreateMockEncryptionAlg(gomock.NewController(t)))
+	signingKey := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("key"),
+	}
+	type res struct {
+		calls  int
+		claims map[string]interface{}
+		err    func(error) bool
+	}
+	tests := []struct {
+		name       string
+		maxRetries uint32
+		handler    func(calls int, w http.ResponseWriter, r *http.Request)
+		res        res
+	}{
+		{
+			name: "calls applied",
+			handler: func(_ int, w http.ResponseWriter, _ *http.Request) {
+				w.Write([]byte(`{"calls": [{"function": "v1.setClaim", "args": ["key", "value"]}]}`))
+			},
+			res: res{
+				calls:  1,
+				claims: map[string]interface{}{"key": "value"},
+			},
+		},
+		{
+			name: "empty response",
+			handler: func(_ int, w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusNoContent)
+			},
+			res: res{
+				calls:  1,
+				claims: map[string]interface{}{},
+			},
+		},
+		{
+			name:       "retried on server error",
+			maxRetries: 1,
+			handler: func(calls int, w http.ResponseWriter, _ *http.Request) {
+				if calls == 1 {
+					w.WriteHeader(http.StatusServiceUnavailable)
+					return
+				}
+				w.Write([]byte(`{"calls": [{"function": "v1.setClaim", "args": ["key", "value"]}]}`))
+			},
+			res: res{
+				calls:  2,
+				claims: map[string]interface{}{"key": "value"},
+			},
+		},
+		{
+			name:       "retries exhausted, error",
+			maxRetries: 2,
+			handler: func(_ int, w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusInternalServerError)
+			},
+			res: res{
+				calls: 3,
+				err:   errors.IsUnavailable,
+			},
+		},
+		{
+			name:       "client error not retried, error",
+			maxRetries: 2,
+			handler: func(_ int, w http.ResponseWriter, _ *http.Request) {
+				w.WriteHeader(http.StatusBadRequest)
+			},
+			res: res{
+				calls: 1,
+				err:   errors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "invalid response, error",
+			handler: func(_ int, w http.ResponseWriter, _ *http.Request) {
+				w.Write([]byte(`invalid`))
+			},
+			res: res{
+				calls: 1,
+				err:   errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unknown function, error",
+			handler: func(_ int, w http.ResponseWriter, _ *http.Request) {
+				w.Write([]byte(`{"calls": [{"function": "v1.unknown", "args": []}]}`))
+			},
+			res: res{
+				calls: 1,
+				err:   errors.IsErrorInvalidArgument,
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Unix(1700000000, 0)
+			var calls int
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				calls++
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, http_utils.Sign([]byte("key"), now, body), r.Header.Get(http_utils.SignatureHeader))
+
+				payload := new(targetPayload)
+				require.NoError(t, json.Unmarshal(body, payload))
+				assert.Equal(t, "testFunc", payload.Function)
+				assert.JSONEq(t, `{"user": {"id": "user1"}}`, string(payload.Ctx))
+
+				tt.handler(calls, w, r)
+			}))
+			defer server.Close()
+
+			claims := make(map[string]interface{})
+			ctx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+			defer cancel()
+			err := Run(ctx,
+				SetContextFields(SetFields("user", SetFields("id", "user1"))),
+				WithAPIFields(SetFields("v1", SetFields("setClaim", func(key string, value interface{}) {
+					claims[key] = value
+				}))),
+				"",
+				"testFunc",
+				WithTarget(server.URL, tt.maxRetries, signingKey),
+				func(c *runConfig) {
+					c.target.client = server.Client()
+					c.target.now = func() time.Time { return now }
+				},
+			)
+			assert.Equal(t, tt.res.calls, calls)
+			if tt.res.err != nil {
+				assert.True(t, tt.res.err(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.res.claims, claims)
+		})
+	}
+}
@@ -2,6 +2,7 @@ package eventsourcing
 
 import (
 	"context"
+	"time"
 
 	admin_handler "github.com/zitadel/zitadel/internal/admin/repository/eventsourcing/handler"
 	admin_view "github.com/zitadel/zitadel/internal/admin/repository/eventsourcing/view"
@@ -11,6 +12,8 @@ import (
 
 type Config struct {
 	Spooler admin_handler.Config
+	// ImportCheckpointLifetime is the time after which the checkpoint of an import, which isn't resumed, is removed
+	ImportCheckpointLifetime time.Duration
 }
 
 func Start(ctx context.Context, conf Config, static static.Storage, dbClient *database.DB) error {
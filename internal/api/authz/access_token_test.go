@@ -46,6 +46,14 @@ func Test_extractBearerToken(t *testing.T) {
 			},
 			wantErr: false,
 		},
+		{
+			name: "dpop auth header set",
+			args: args{
+				ctx:   context.Background(),
+				token: "DPoP AUTH",
+			},
+			wantErr: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -5,8 +5,8 @@ package authz
 import (
 	"context"
 	"errors"
-	"strings"
 
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	"github.com/zitadel/zitadel/internal/api/grpc"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	zitadel_errors "github.com/zitadel/zitadel/internal/errors"
@@ -176,10 +176,12 @@ func checkOrigin(ctx context.Context, origins []string) error {
 	return zitadel_errors.ThrowPermissionDenied(nil, "AUTH-DZG21", "Errors.OriginNotAllowed")
 }
 
+// extractBearerToken returns the token of the authorization header,
+// which can be sent with the Bearer or the DPoP scheme
 func extractBearerToken(token string) (part string, err error) {
-	parts := strings.Split(token, BearerPrefix)
-	if len(parts) != 2 {
+	_, part, ok := dpop.SplitAuthorization(token)
+	if !ok {
 		return "", zitadel_errors.ThrowUnauthenticated(nil, "AUTH-7fs1e", "invalid auth header")
 	}
-	return parts[1], nil
+	return part, nil
 }
@@ -0,0 +1,83 @@
+package dpop
+
+import (
+	"context"
+	"net/http"
+	"strings"
+)
+
+type requestKey struct{}
+
+// Request contains the information of a request to a protected resource,
+// which is needed to verify a DPoP bound access token
+type Request struct {
+	// Scheme of the Authorization header (Bearer or DPoP)
+	Scheme string
+	// Proof is the value of the DPoP header
+	Proof string
+	// Method and URI of the request, which must match the `htm` and `htu` claims of the proof
+	Method string
+	URI    string
+}
+
+// WithRequest stores the information of the request to the protected resource in the context
+func WithRequest(ctx context.Context, request *Request) context.Context {
+	return context.WithValue(ctx, requestKey{}, request)
+}
+
+// RequestFromContext returns the information of the request to the protected resource, if any
+func RequestFromContext(ctx context.Context) *Request {
+	request, _ := ctx.Value(requestKey{}).(*Request)
+	return request
+}
+
+// NewRequest creates the [Request] of the http request, where uri is the absolute URI of the request
+func NewRequest(r *http.Request, uri string) *Request {
+	scheme, _, _ := strings.Cut(r.Header.Get("Authorization"), " ")
+	return &Request{
+		Scheme: scheme,
+		Proof:  r.Header.Get(HeaderName),
+		Method: r.Method,
+		URI:    uri,
+	}
+}
+
+// SplitAuthorization returns the token of the Authorization header,
+// which can either use the Bearer or the DPoP scheme
+func SplitAuthorization(authorization string) (scheme, token string, ok bool) {
+	scheme, token, ok = strings.Cut(authorization, " ")
+	if !ok || token == "" {
+		return "", "", false
+	}
+	if !strings.EqualFold(scheme, "Bearer") && !strings.EqualFold(scheme, TokenType) {
+		return "", "", false
+	}
+	return scheme, token, true
+}
+
+// CheckBinding checks the presentation of an access token bound to the key with the thumbprint jkt:
+// The token must be sent with the DPoP scheme and a valid proof of the same key.
+// Tokens which are not bound (jkt is empty) can be used as bearer tokens.
+func (v *Verifier) CheckBinding(ctx context.Context, jkt, accessToken string) error {
+	if jkt == "" {
+		return nil
+	}
+	if v == nil {
+		return &Error{Type: ErrorTypeInvalidToken, Description: "DPoP bound tokens are not supported"}
+	}
+	request := RequestFromContext(ctx)
+	if request == nil || !strings.EqualFold(request.Scheme, TokenType) {
+		return &Error{Type: ErrorTypeInvalidToken, Description: "token is DPoP bound and must be sent with the DPoP scheme"}
+	}
+	if request.Proof == "" {
+		return invalidProof("proof is missing", nil)
+	}
+	proofJKT, err := v.Verify(ctx, request.Proof, request.Method, request.URI, accessToken)
+	if err != nil {
+		return err
+	}
+	if proofJKT != jkt {
+		return invalidProof("key of proof does not match the key bound to the token", nil)
+	}
+	return nil
+}
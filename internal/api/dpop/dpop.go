@@ -0,0 +1,223 @@
+// Package dpop implements the verification of DPoP proofs and the binding of tokens
+// to the key of the proofs as defined in RFC 9449 (OAuth 2.0 Demonstrating Proof of Possession).
+package dpop
+
+import (
+	"context"
+	"crypto"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+)
+
+const (
+	// HeaderName is the name of the header containing the DPoP proof
+	HeaderName = "DPoP"
+	// NonceHeaderName is the name of the header containing the nonce to be used in the next DPoP proof
+	NonceHeaderName = "DPoP-Nonce"
+	// TokenType is the token_type of DPoP bound access tokens and the scheme of the Authorization header
+	TokenType = "DPoP"
+
+	proofType = "dpop+jwt"
+)
+
+// supportedAlgorithms are the asymmetric signature algorithms accepted for DPoP proofs
+var supportedAlgorithms = []string{
+	string(jose.RS256), string(jose.RS384), string(jose.RS512),
+	string(jose.PS256), string(jose.PS384), string(jose.PS512),
+	string(jose.ES256), string(jose.ES384), string(jose.ES512),
+	string(jose.EdDSA),
+}
+
+// SupportedAlgorithms returns the signature algorithms accepted for DPoP proofs,
+// as published in the `dpop_signing_alg_values_supported` discovery metadata
+func SupportedAlgorithms() []string {
+	return supportedAlgorithms
+}
+
+type Config struct {
+	// ProofLifetime is the time a proof is accepted after it was issued (iat).
+	ProofLifetime time.Duration
+	// ClockSkew is the time a proof is accepted, which was issued in the future.
+	ClockSkew time.Duration
+	// RequireNonce requires every proof to contain a nonce issued by ZITADEL in the DPoP-Nonce header.
+	RequireNonce bool
+	// NonceLifetime is the time a nonce issued by ZITADEL is valid.
+	NonceLifetime time.Duration
+	// ReplayCleanupInterval is the interval in which the expired proofs are removed from the replay storage.
+	ReplayCleanupInterval time.Duration
+}
+
+// Verifier verifies DPoP proofs.
+// Nonces are stateless (signed by the key of the verifier), so they are valid on any ZITADEL instance sharing the key.
+// The used proofs are remembered in the shared [ReplayStorage].
+type Verifier struct {
+	config  Config
+	nonces  *nonces
+	replays ReplayStorage
+	now     func() time.Time
+}
+
+func NewVerifier(config Config, key []byte, replays ReplayStorage) *Verifier {
+	return &Verifier{
+		config:  config,
+		nonces:  newNonces(key, config.NonceLifetime),
+		replays: replays,
+		now:     time.Now,
+	}
+}
+
+// NonceRequired returns if the proofs need to contain a nonce issued by ZITADEL
+func (v *Verifier) NonceRequired() bool {
+	return v != nil && v.config.RequireNonce
+}
+
+// Nonce returns a new nonce, which can be used by the client in the next proof
+func (v *Verifier) Nonce() string {
+	return v.nonces.create(v.now())
+}
+
+type proofClaims struct {
+	JWTID           string `json:"jti"`
+	HTTPMethod      string `json:"htm"`
+	HTTPURI         string `json:"htu"`
+	IssuedAt        int64  `json:"iat"`
+	AccessTokenHash string `json:"ath,omitempty"`
+	Nonce           string `json:"nonce,omitempty"`
+}
+
+// Verify verifies the DPoP proof of a request with the method and the uri
+// and returns the JWK SHA-256 thumbprint (jkt) of the key of the proof.
+// If an access token is passed, the proof must contain its hash (ath).
+func (v *Verifier) Verify(ctx context.Context, proof, method, uri, accessToken string) (jkt string, err error) {
+	signature, err := jose.ParseSigned(proof)
+	if err != nil {
+		return "", invalidProof("proof is not a valid JWS", err)
+	}
+	if len(signature.Signatures) != 1 {
+		return "", invalidProof("proof must have exactly one signature", nil)
+	}
+	header := signature.Signatures[0].Protected
+	if typ, _ := header.ExtraHeaders[jose.HeaderType].(string); typ != proofType {
+		return "", invalidProof("typ of proof must be "+proofType, nil)
+	}
+	if !isSupportedAlgorithm(header.Algorithm) {
+		return "", invalidProof("alg of proof is not supported", nil)
+	}
+	key := header.JSONWebKey
+	if key == nil || !key.Valid() || !key.IsPublic() {
+		return "", invalidProof("jwk of proof must be a public key", nil)
+	}
+	payload, err := signature.Verify(key)
+	if err != nil {
+		return "", invalidProof("signature of proof is invalid", err)
+	}
+	claims := new(proofClaims)
+	if err = json.Unmarshal(payload, claims); err != nil {
+		return "", invalidProof("claims of proof are invalid", err)
+	}
+	if claims.JWTID == "" {
+		return "", invalidProof("jti of proof is missing", nil)
+	}
+	if !strings.EqualFold(claims.HTTPMethod, method) {
+		return "", invalidProof("htm of proof does not match", nil)
+	}
+	if !equalURI(claims.HTTPURI, uri) {
+		return "", invalidProof("htu of proof does not match", nil)
+	}
+	now := v.now()
+	issuedAt := time.Unix(claims.IssuedAt, 0)
+	if issuedAt.After(now.Add(v.config.ClockSkew)) || issuedAt.Add(v.config.ProofLifetime).Before(now) {
+		return "", invalidProof("iat of proof is not within the accepted window", nil)
+	}
+	if accessToken != "" && claims.AccessTokenHash != AccessTokenHash(accessToken) {
+		return "", invalidProof("ath of proof does not match the access token", nil)
+	}
+	if claims.Nonce != "" || v.config.RequireNonce {
+		if !v.nonces.valid(claims.Nonce, now) {
+			return "", &Error{Type: ErrorTypeUseNonce, Description: "proof must contain a valid nonce"}
+		}
+	}
+	thumbprint, err := key.Thumbprint(crypto.SHA256)
+	if err != nil {
+		return "", invalidProof("unable to compute thumbprint of jwk", err)
+	}
+	jkt = base64.RawURLEncoding.EncodeToString(thumbprint)
+	// the proof must only be used once in its lifetime
+	added, err := v.replays.Add(ctx, jkt+":"+claims.JWTID, issuedAt.Add(v.config.ProofLifetime+v.config.ClockSkew), now)
+	if err != nil {
+		return "", err
+	}
+	if !added {
+		return "", invalidProof("proof was already used", nil)
+	}
+	return jkt, nil
+}
+
+// AccessTokenHash returns the `ath` claim of the access token (base64url encoded SHA-256 hash)
+func AccessTokenHash(accessToken string) string {
+	hash := sha256.Sum256([]byte(accessToken))
+	return base64.RawURLEncoding.EncodeToString(hash[:])
+}
+
+func isSupportedAlgorithm(alg string) bool {
+	for _, supported := range supportedAlgorithms {
+		if alg == supported {
+			return true
+		}
+	}
+	return false
+}
+
+// equalURI compares the htu claim with the uri of the request without query and fragment
+// https://www.rfc-editor.org/rfc/rfc9449.html#section-4.3
+func equalURI(htu, uri string) bool {
+	proofURI, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	requestURI, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(proofURI.Scheme, requestURI.Scheme) &&
+		strings.EqualFold(proofURI.Host, requestURI.Host) &&
+		strings.TrimSuffix(proofURI.EscapedPath(), "/") == strings.TrimSuffix(requestURI.EscapedPath(), "/")
+}
+
+type ErrorType string
+
+const (
+	ErrorTypeInvalidProof ErrorType = "invalid_dpop_proof"
+	ErrorTypeUseNonce     ErrorType = "use_dpop_nonce"
+	ErrorTypeInvalidToken ErrorType = "invalid_token"
+)
+
+// Error is returned if a proof is invalid or missing.
+// The Type can directly be used as OAuth error code.
+type Error struct {
+	Type        ErrorType
+	Description string
+	Parent      error
+}
+
+func (e *Error) Error() string {
+	if e.Parent != nil {
+		return fmt.Sprintf("%s: %s: %v", e.Type, e.Description, e.Parent)
+	}
+	return fmt.Sprintf("%s: %s", e.Type, e.Description)
+}
+
+func (e *Error) Unwrap() error {
+	return e.Parent
+}
+
+func invalidProof(description string, parent error) *Error {
+	return &Error{Type: ErrorTypeInvalidProof, Description: description, Parent: parent}
+}
@@ -0,0 +1,296 @@
+package dpop
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var testNow = time.Unix(1700000000, 0)
+
+func testVerifier(requireNonce bool) *Verifier {
+	v := NewVerifier(Config{
+		ProofLifetime: time.Minute,
+		ClockSkew:     5 * time.Second,
+		RequireNonce:  requireNonce,
+		NonceLifetime: 5 * time.Minute,
+	}, []byte("01234567890123456789012345678901"), make(memoryReplays))
+	v.now = func() time.Time { return testNow }
+	return v
+}
+
+// memoryReplays is a [ReplayStorage] of a single process for the tests
+type memoryReplays map[string]time.Time
+
+func (r memoryReplays) Add(_ context.Context, id string, expiration, now time.Time) (bool, error) {
+	if exp, ok := r[id]; ok && !exp.Before(now) {
+		return false, nil
+	}
+	r[id] = expiration
+	return true, nil
+}
+
+func testProof(t *testing.T, key *ecdsa.PrivateKey, typ string, claims *proofClaims) string {
+	signer, err := jose.NewSigner(
+		jose.SigningKey{Algorithm: jose.ES256, Key: key},
+		(&jose.SignerOptions{EmbedJWK: true}).WithType(jose.ContentType(typ)),
+	)
+	require.NoError(t, err)
+	payload, err := json.Marshal(claims)
+	require.NoError(t, err)
+	signature, err := signer.Sign(payload)
+	require.NoError(t, err)
+	proof, err := signature.CompactSerialize()
+	require.NoError(t, err)
+	return proof
+}
+
+func testThumbprint(t *testing.T, key *ecdsa.PrivateKey) string {
+	thumbprint, err := (&jose.JSONWebKey{Key: key.Public()}).Thumbprint(crypto.SHA256)
+	require.NoError(t, err)
+	return base64.RawURLEncoding.EncodeToString(thumbprint)
+}
+
+func TestVerifier_Verify(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	validClaims := func() *proofClaims {
+		return &proofClaims{
+			JWTID:      "id1",
+			HTTPMethod: "POST",
+			HTTPURI:    "https://issuer.com/oauth/v2/token",
+			IssuedAt:   testNow.Unix(),
+		}
+	}
+	tests := []struct {
+		name         string
+		requireNonce bool
+		typ          string
+		claims       func() *proofClaims
+		accessToken  string
+		wantErr      ErrorType
+	}{
+		{
+			name:   "valid proof",
+			typ:    proofType,
+			claims: validClaims,
+		},
+		{
+			name:    "wrong typ, error",
+			typ:     "JWT",
+			claims:  validClaims,
+			wantErr: ErrorTypeInvalidProof,
+		},
+		{
+			name: "wrong method, error",
+			typ:  proofType,
+			claims: func() *proofClaims {
+				c := validClaims()
+				c.HTTPMethod = "GET"
+				return c
+			},
+			wantErr: ErrorTypeInvalidProof,
+		},
+		{
+			name: "wrong uri, error",
+			typ:  proofType,
+			claims: func() *proofClaims {
+				c := validClaims()
+				c.HTTPURI = "https://issuer.com/oidc/v1/userinfo"
+				return c
+			},
+			wantErr: ErrorTypeInvalidProof,
+		},
+		{
+			name: "uri with query",
+			typ:  proofType,
+			claims: func() *proofClaims {
+				c := validClaims()
+				c.HTTPURI = "https://ISSUER.com/oauth/v2/token?foo=bar"
+				return c
+			},
+		},
+		{
+			name: "expired, error",
+			typ:  proofType,
+			claims: func() *proofClaims {
+				c := validClaims()
+				c.IssuedAt = testNow.Add(-2 * time.Minute).Unix()
+				return c
+			},
+			wantErr: ErrorTypeInvalidProof,
+		},
+		{
+			name: "issued in the future, error",
+			typ:  proofType,
+			claims: func() *proofClaims {
+				c := validClaims()
+				c.IssuedAt = testNow.Add(time.Minute).Unix()
+				return c
+			},
+			wantErr: ErrorTypeInvalidProof,
+		},
+		{
+			name:        "missing ath, error",
+			typ:         proofType,
+			claims:      validClaims,
+			accessToken: "token",
+			wantErr:     ErrorTypeInvalidProof,
+		},
+		{
+			name: "ath",
+			typ:  proofType,
+			claims: func() *proofClaims {
+				c := validClaims()
+				c.AccessTokenHash = AccessTokenHash("token")
+				return c
+			},
+			accessToken: "token",
+		},
+		{
+			name:         "missing nonce, error",
+			requireNonce: true,
+			typ:          proofType,
+			claims:       validClaims,
+			wantErr:      ErrorTypeUseNonce,
+		},
+		{
+			name:         "invalid nonce, error",
+			requireNonce: true,
+			typ:          proofType,
+			claims: func() *proofClaims {
+				c := validClaims()
+				c.Nonce = "nonce"
+				return c
+			},
+			wantErr: ErrorTypeUseNonce,
+		},
+		{
+			name:         "nonce",
+			requireNonce: true,
+			typ:          proofType,
+			claims: func() *proofClaims {
+				c := validClaims()
+				c.Nonce = testVerifier(true).Nonce()
+				return c
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			v := testVerifier(tt.requireNonce)
+			proof := testProof(t, key, tt.typ, tt.claims())
+			jkt, err := v.Verify(context.Background(), proof, "POST", "https://issuer.com/oauth/v2/token", tt.accessToken)
+			if tt.wantErr != "" {
+				var dpopErr *Error
+				require.True(t, errors.As(err, &dpopErr))
+				assert.Equal(t, tt.wantErr, dpopErr.Type)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, testThumbprint(t, key), jkt)
+		})
+	}
+}
+
+func TestVerifier_Verify_replay(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	v := testVerifier(false)
+	proof := testProof(t, key, proofType, &proofClaims{
+		JWTID:      "id1",
+		HTTPMethod: "POST",
+		HTTPURI:    "https://issuer.com/oauth/v2/token",
+		IssuedAt:   testNow.Unix(),
+	})
+	_, err = v.Verify(context.Background(), proof, "POST", "https://issuer.com/oauth/v2/token", "")
+	require.NoError(t, err)
+	_, err = v.Verify(context.Background(), proof, "POST", "https://issuer.com/oauth/v2/token", "")
+	require.Error(t, err)
+}
+
+func TestVerifier_CheckBinding(t *testing.T) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	otherKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	proof := func(key *ecdsa.PrivateKey) string {
+		return testProof(t, key, proofType, &proofClaims{
+			JWTID:           "id1",
+			HTTPMethod:      "GET",
+			HTTPURI:         "https://issuer.com/oidc/v1/userinfo",
+			IssuedAt:        testNow.Unix(),
+			AccessTokenHash: AccessTokenHash("token"),
+		})
+	}
+	tests := []struct {
+		name    string
+		jkt     string
+		request *Request
+		wantErr bool
+	}{
+		{
+			name:    "unbound token",
+			request: &Request{Scheme: "Bearer"},
+		},
+		{
+			name:    "bound token with bearer scheme, error",
+			jkt:     testThumbprint(t, key),
+			request: &Request{Scheme: "Bearer", Proof: proof(key), Method: "GET", URI: "https://issuer.com/oidc/v1/userinfo"},
+			wantErr: true,
+		},
+		{
+			name:    "bound token without proof, error",
+			jkt:     testThumbprint(t, key),
+			request: &Request{Scheme: "DPoP", Method: "GET", URI: "https://issuer.com/oidc/v1/userinfo"},
+			wantErr: true,
+		},
+		{
+			name:    "bound token with proof of other key, error",
+			jkt:     testThumbprint(t, key),
+			request: &Request{Scheme: "DPoP", Proof: proof(otherKey), Method: "GET", URI: "https://issuer.com/oidc/v1/userinfo"},
+			wantErr: true,
+		},
+		{
+			name:    "bound token",
+			jkt:     testThumbprint(t, key),
+			request: &Request{Scheme: "DPoP", Proof: proof(key), Method: "GET", URI: "https://issuer.com/oidc/v1/userinfo"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := testVerifier(false).CheckBinding(WithRequest(context.Background(), tt.request), tt.jkt, "token")
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func TestSplitAuthorization(t *testing.T) {
+	scheme, token, ok := SplitAuthorization("DPoP token")
+	assert.True(t, ok)
+	assert.Equal(t, "DPoP", scheme)
+	assert.Equal(t, "token", token)
+
+	scheme, token, ok = SplitAuthorization("Bearer token")
+	assert.True(t, ok)
+	assert.Equal(t, "Bearer", scheme)
+	assert.Equal(t, "token", token)
+
+	_, _, ok = SplitAuthorization("Basic dXNlcjpwYXNz")
+	assert.False(t, ok)
+}
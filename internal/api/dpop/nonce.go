@@ -0,0 +1,49 @@
+package dpop
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/binary"
+	"time"
+)
+
+const nonceTimeLength = 8
+
+// nonces creates and verifies stateless nonces
+// consisting of the creation time and a HMAC of it
+type nonces struct {
+	key      []byte
+	lifetime time.Duration
+}
+
+func newNonces(key []byte, lifetime time.Duration) *nonces {
+	return &nonces{
+		key:      key,
+		lifetime: lifetime,
+	}
+}
+
+func (n *nonces) create(now time.Time) string {
+	nonce := make([]byte, nonceTimeLength, nonceTimeLength+sha256.Size)
+	binary.BigEndian.PutUint64(nonce, uint64(now.Unix()))
+	return base64.RawURLEncoding.EncodeToString(append(nonce, n.mac(nonce)...))
+}
+
+func (n *nonces) valid(nonce string, now time.Time) bool {
+	decoded, err := base64.RawURLEncoding.DecodeString(nonce)
+	if err != nil || len(decoded) != nonceTimeLength+sha256.Size {
+		return false
+	}
+	if !hmac.Equal(decoded[nonceTimeLength:], n.mac(decoded[:nonceTimeLength])) {
+		return false
+	}
+	created := time.Unix(int64(binary.BigEndian.Uint64(decoded[:nonceTimeLength])), 0)
+	return !created.After(now) && created.Add(n.lifetime).After(now)
+}
+
+func (n *nonces) mac(data []byte) []byte {
+	mac := hmac.New(sha256.New, n.key)
+	mac.Write(data)
+	return mac.Sum(nil)
+}
@@ -0,0 +1,13 @@
+package dpop
+
+import (
+	"context"
+	"time"
+)
+
+// ReplayStorage remembers the used proofs until they expire.
+// It must be shared by all ZITADEL processes, so a proof can't be replayed against another process.
+type ReplayStorage interface {
+	// Add stores the id of the proof and returns false if it was already used and is not yet expired
+	Add(ctx context.Context, id string, expiration, now time.Time) (bool, error)
+}
@@ -0,0 +1,89 @@
+// Package replay provides the storage of the used DPoP proofs shared by all ZITADEL processes.
+package replay
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var _ dpop.ReplayStorage = (*DatabaseStorage)(nil)
+
+const (
+	table         = "auth.dpop_proofs"
+	instanceIDCol = "instance_id"
+	jtiCol        = "jti"
+	expirationCol = "expiration"
+)
+
+// DatabaseStorage stores the used DPoP proofs in the database with a unique key on the instance and the jti,
+// so the replay of a proof is detected by all ZITADEL processes
+type DatabaseStorage struct {
+	dbClient *database.DB
+}
+
+func NewDatabaseStorage(dbClient *database.DB) *DatabaseStorage {
+	return &DatabaseStorage{dbClient: dbClient}
+}
+
+// Add inserts the proof, an expired entry of the same id is overwritten
+func (s *DatabaseStorage) Add(ctx context.Context, id string, expiration, now time.Time) (bool, error) {
+	stmt, args, err := sq.Insert(table).
+		Columns(instanceIDCol, jtiCol, expirationCol).
+		Values(authz.GetInstance(ctx).InstanceID(), id, expiration).
+		Suffix("ON CONFLICT ("+instanceIDCol+", "+jtiCol+") DO UPDATE SET "+expirationCol+" = EXCLUDED."+expirationCol+" WHERE "+table+"."+expirationCol+" < ?", now).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+	result, err := s.dbClient.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return false, err
+	}
+	// no row is affected if the proof exists and is not yet expired
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// StartCleanup periodically removes the expired proofs of all instances
+func (s *DatabaseStorage) StartCleanup(ctx context.Context, interval time.Duration) {
+	if interval == 0 {
+		return
+	}
+	go s.scheduleCleanup(ctx, interval)
+}
+
+func (s *DatabaseStorage) scheduleCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			logging.OnError(s.cleanup(ctx, time.Now())).Warn("unable to remove expired DPoP proofs")
+		}
+	}
+}
+
+func (s *DatabaseStorage) cleanup(ctx context.Context, now time.Time) error {
+	stmt, args, err := sq.Delete(table).
+		Where(sq.Lt{expirationCol: now}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = s.dbClient.ExecContext(ctx, stmt, args...)
+	return err
+}
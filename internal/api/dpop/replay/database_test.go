@@ -0,0 +1,67 @@
+package replay
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+var testNow = time.Unix(1700000000, 0)
+
+func TestDatabaseStorage_Add(t *testing.T) {
+	expectedStmt := regexp.QuoteMeta("INSERT INTO auth.dpop_proofs (instance_id,jti,expiration) VALUES ($1,$2,$3) ON CONFLICT (instance_id, jti) DO UPDATE SET expiration = EXCLUDED.expiration WHERE auth.dpop_proofs.expiration < $4")
+	expiration := testNow.Add(time.Minute)
+	tests := []struct {
+		name     string
+		affected int64
+		want     bool
+	}{
+		{
+			name:     "new proof",
+			affected: 1,
+			want:     true,
+		},
+		{
+			name:     "used proof",
+			affected: 0,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			mock.ExpectExec(expectedStmt).
+				WithArgs("instance1", "jkt:id1", expiration, testNow).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			storage := NewDatabaseStorage(&database.DB{DB: db})
+			got, err := storage.Add(authz.WithInstanceID(context.Background(), "instance1"), "jkt:id1", expiration, testNow)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
+
+func TestDatabaseStorage_cleanup(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM auth.dpop_proofs WHERE expiration < $1")).
+		WithArgs(testNow).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	err = NewDatabaseStorage(&database.DB{DB: db}).cleanup(context.Background(), testNow)
+	require.NoError(t, err)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
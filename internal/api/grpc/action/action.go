@@ -1,10 +1,19 @@
 package action
 
 import (
+	"encoding/json"
+
+	"github.com/sirupsen/logrus"
+	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/structpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/zitadel/internal/actions"
 	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore/record"
 	"github.com/zitadel/zitadel/internal/query"
 	action_pb "github.com/zitadel/zitadel/pkg/grpc/action"
 	message_pb "github.com/zitadel/zitadel/pkg/grpc/message"
@@ -21,6 +30,10 @@ func FlowTypeToDomain(flowType string) domain.FlowType {
 		return domain.FlowTypeInternalAuthentication
 	case domain.FlowTypeCustomizeSAMLResponse.ID():
 		return domain.FlowTypeCustomizeSAMLResponse
+	case domain.FlowTypeAPIRequest.ID():
+		return domain.FlowTypeAPIRequest
+	case domain.FlowTypeEvent.ID():
+		return domain.FlowTypeEvent
 	default:
 		return domain.FlowTypeUnspecified
 	}
@@ -51,6 +64,18 @@ func TriggerTypeToDomain(triggerType string) domain.TriggerType {
 		return domain.TriggerTypePreUserinfoCreation
 	case domain.TriggerTypePreSAMLResponseCreation.ID():
 		return domain.TriggerTypePreSAMLResponseCreation
+	case domain.TriggerTypePreRequest.ID():
+		return domain.TriggerTypePreRequest
+	case domain.TriggerTypePostRequest.ID():
+		return domain.TriggerTypePostRequest
+	case domain.TriggerTypeUserHumanAdded.ID():
+		return domain.TriggerTypeUserHumanAdded
+	case domain.TriggerTypeUserRemoved.ID():
+		return domain.TriggerTypeUserRemoved
+	case domain.TriggerTypeUserGrantAdded.ID():
+		return domain.TriggerTypeUserGrantAdded
+	case domain.TriggerTypeUserGrantRemoved.ID():
+		return domain.TriggerTypeUserGrantRemoved
 	default:
 		return domain.TriggerTypeUnspecified
 	}
@@ -114,6 +139,27 @@ func ActionToPb(action *query.Action) *action_pb.Action {
 		Script:        action.Script,
 		Timeout:       durationpb.New(action.Timeout()),
 		AllowedToFail: action.AllowedToFail,
+		Type:          ActionTypeToPb(action.Type),
+		Target:        ActionTargetToPb(action),
+	}
+}
+
+func ActionTargetToPb(action *query.Action) *action_pb.ActionTarget {
+	if action.Type != domain.ActionTypeTarget {
+		return nil
+	}
+	return &action_pb.ActionTarget{
+		Url:        action.TargetURL,
+		MaxRetries: action.MaxRetries,
+	}
+}
+
+func ActionTypeToPb(actionType domain.ActionType) action_pb.ActionType {
+	switch actionType {
+	case domain.ActionTypeTarget:
+		return action_pb.ActionType_ACTION_TYPE_TARGET
+	default:
+		return action_pb.ActionType_ACTION_TYPE_SCRIPT
 	}
 }
 
@@ -149,3 +195,119 @@ func ActionStateToDomain(state action_pb.ActionState) domain.ActionState {
 		return domain.ActionStateUnspecified
 	}
 }
+
+func ActionExecutionsToPb(executions []*query.ActionExecution) []*action_pb.ActionExecution {
+	list := make([]*action_pb.ActionExecution, len(executions))
+	for i, execution := range executions {
+		list[i] = ActionExecutionToPb(execution)
+	}
+	return list
+}
+
+func ActionExecutionToPb(execution *query.ActionExecution) *action_pb.ActionExecution {
+	return &action_pb.ActionExecution{
+		Id:            execution.ID,
+		FlowType:      FlowTypeToPb(execution.FlowType),
+		TriggerType:   TriggerTypeToPb(execution.TriggerType),
+		Started:       timestamppb.New(execution.Started),
+		Took:          durationpb.New(execution.Took),
+		Succeeded:     execution.Succeeded,
+		Error:         execution.Error,
+		Logs:          ActionExecutionLogsToPb(execution.Logs),
+		AggregateType: execution.AggregateType,
+		AggregateId:   execution.AggregateID,
+		Sequence:      execution.Sequence,
+		EventType:     execution.EventType,
+	}
+}
+
+func ActionExecutionLogsToPb(logs []*record.ExecutionRunLog) []*action_pb.ActionExecutionLog {
+	list := make([]*action_pb.ActionExecutionLog, len(logs))
+	for i, log := range logs {
+		list[i] = &action_pb.ActionExecutionLog{
+			LogDate: timestamppb.New(log.LogDate),
+			Level:   ActionExecutionLogLevelToPb(log.LogLevel),
+			Message: log.Message,
+		}
+	}
+	return list
+}
+
+func ActionExecutionLogLevelToPb(level logrus.Level) action_pb.ActionExecutionLogLevel {
+	switch level {
+	case logrus.InfoLevel:
+		return action_pb.ActionExecutionLogLevel_ACTION_EXECUTION_LOG_LEVEL_INFO
+	case logrus.WarnLevel:
+		return action_pb.ActionExecutionLogLevel_ACTION_EXECUTION_LOG_LEVEL_WARN
+	case logrus.ErrorLevel:
+		return action_pb.ActionExecutionLogLevel_ACTION_EXECUTION_LOG_LEVEL_ERROR
+	default:
+		return action_pb.ActionExecutionLogLevel_ACTION_EXECUTION_LOG_LEVEL_UNSPECIFIED
+	}
+}
+
+func ActionExecutionQueriesToModel(queries []*action_pb.ActionExecutionQuery) (q []query.SearchQuery, err error) {
+	q = make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = ActionExecutionQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func ActionExecutionQueryToModel(executionQuery *action_pb.ActionExecutionQuery) (query.SearchQuery, error) {
+	switch q := executionQuery.Query.(type) {
+	case *action_pb.ActionExecutionQuery_SucceededQuery:
+		return query.NewActionExecutionSucceededSearchQuery(q.SucceededQuery.Succeeded)
+	case *action_pb.ActionExecutionQuery_FlowTypeQuery:
+		return query.NewActionExecutionFlowTypeSearchQuery(FlowTypeToDomain(q.FlowTypeQuery.FlowType))
+	case *action_pb.ActionExecutionQuery_TriggerTypeQuery:
+		return query.NewActionExecutionTriggerTypeSearchQuery(TriggerTypeToDomain(q.TriggerTypeQuery.TriggerType))
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "ACTION-eiG4a", "List.Query.Invalid")
+	}
+}
+
+func ActionHTTPMocksToModel(mocks []*action_pb.ActionHTTPMock) []*actions.HTTPMock {
+	list := make([]*actions.HTTPMock, len(mocks))
+	for i, mock := range mocks {
+		list[i] = &actions.HTTPMock{
+			Method: mock.Method,
+			URL:    mock.Url,
+			Status: int(mock.Status),
+			Body:   mock.Body,
+		}
+	}
+	return list
+}
+
+func ActionMutationsToPb(mutations []*actions.Mutation) ([]*action_pb.ActionMutation, error) {
+	list := make([]*action_pb.ActionMutation, len(mutations))
+	for i, mutation := range mutations {
+		args, err := mutationArgsToPb(mutation.Args)
+		if err != nil {
+			return nil, err
+		}
+		list[i] = &action_pb.ActionMutation{
+			Function: mutation.Function,
+			Args:     args,
+		}
+	}
+	return list, nil
+}
+
+// mutationArgsToPb converts the exported values of the script to their json representation,
+// e.g. dates are returned as strings
+func mutationArgsToPb(args []interface{}) (*structpb.ListValue, error) {
+	data, err := json.Marshal(args)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ACTION-Jee3o", "Errors.Internal")
+	}
+	list := new(structpb.ListValue)
+	if err = protojson.Unmarshal(data, list); err != nil {
+		return nil, errors.ThrowInternal(err, "ACTION-ut2Ae", "Errors.Internal")
+	}
+	return list, nil
+}
@@ -0,0 +1,28 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ApplyData(ctx context.Context, req *admin_pb.ApplyDataRequest) (_ *admin_pb.ApplyDataResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	// nothing is applied if parts of the data can't be applied, so the result never differs from the data partially
+	if applyErrors := unsupportedApplyFields(req.GetDataOrgs().GetOrgs()); len(applyErrors) > 0 {
+		return &admin_pb.ApplyDataResponse{
+			Errors: applyErrors,
+		}, nil
+	}
+	changes, err := s.command.ApplyOrgs(ctx, dataOrgsToApplyOrgs(req.GetDataOrgs().GetOrgs()), authz.GetCtxData(ctx).UserID, req.GetDryRun())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ApplyDataResponse{
+		Changes: applyChangesToPb(changes),
+	}, nil
+}
@@ -0,0 +1,151 @@
+package admin
+
+import (
+	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
+	"github.com/zitadel/zitadel/internal/api/grpc/management"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func dataOrgsToApplyOrgs(orgs []*admin_pb.DataOrg) []*command.ApplyOrg {
+	applyOrgs := make([]*command.ApplyOrg, len(orgs))
+	for i, org := range orgs {
+		applyOrgs[i] = dataOrgToApplyOrg(org)
+	}
+	return applyOrgs
+}
+
+// unsupportedApplyFields returns an error for each part of the orgs which cannot be applied,
+// so they are reported instead of being silently ignored.
+func unsupportedApplyFields(orgs []*admin_pb.DataOrg) []*admin_pb.ImportDataError {
+	var applyErrors []*admin_pb.ImportDataError
+	for _, org := range orgs {
+		fields := []struct {
+			name    string
+			present bool
+		}{
+			{"human_users", len(org.GetHumanUsers()) > 0},
+			{"machine_users", len(org.GetMachineUsers()) > 0},
+			{"machine_keys", len(org.GetMachineKeys()) > 0},
+			{"app_keys", len(org.GetAppKeys()) > 0},
+			{"project_grants", len(org.GetProjectGrants()) > 0},
+			{"user_grants", len(org.GetUserGrants()) > 0},
+			{"org_members", len(org.GetOrgMembers()) > 0},
+			{"project_members", len(org.GetProjectMembers()) > 0},
+			{"project_grant_members", len(org.GetProjectGrantMembers()) > 0},
+			{"user_metadata", len(org.GetUserMetadata()) > 0},
+			{"user_links", len(org.GetUserLinks()) > 0},
+			{"login_texts", len(org.GetLoginTexts()) > 0},
+			{"init_messages", len(org.GetInitMessages()) > 0},
+			{"password_reset_messages", len(org.GetPasswordResetMessages()) > 0},
+			{"verify_email_messages", len(org.GetVerifyEmailMessages()) > 0},
+			{"verify_phone_messages", len(org.GetVerifyPhoneMessages()) > 0},
+			{"verify_sms_otp_messages", len(org.GetVerifySmsOtpMessages()) > 0},
+			{"verify_email_otp_messages", len(org.GetVerifyEmailOtpMessages()) > 0},
+			{"domain_claimed_messages", len(org.GetDomainClaimedMessages()) > 0},
+			{"passwordless_registration_messages", len(org.GetPasswordlessRegistrationMessages()) > 0},
+		}
+		for _, field := range fields {
+			if !field.present {
+				continue
+			}
+			applyErrors = append(applyErrors, &admin_pb.ImportDataError{
+				Type:    field.name,
+				Id:      org.GetOrgId(),
+				Message: errors.ThrowInvalidArgument(nil, "ADMIN-Ohb4i", "Errors.Org.ApplyUnsupported").Error(),
+			})
+		}
+	}
+	return applyErrors
+}
+
+// dataOrgToApplyOrg converts the org of the data into its desired state.
+// The parts which cannot be applied are reported by unsupportedApplyFields.
+func dataOrgToApplyOrg(org *admin_pb.DataOrg) *command.ApplyOrg {
+	applyOrg := &command.ApplyOrg{
+		ID:   org.GetOrgId(),
+		Name: org.GetOrg().GetName(),
+	}
+	for _, orgDomain := range org.GetDomains() {
+		applyOrg.Domains = append(applyOrg.Domains, orgDomain.GetDomainName())
+	}
+	if org.GetDomainPolicy() != nil {
+		applyOrg.DomainPolicy = &domain.DomainPolicy{
+			UserLoginMustBeDomain:                  org.GetDomainPolicy().GetUserLoginMustBeDomain(),
+			ValidateOrgDomains:                     org.GetDomainPolicy().GetValidateOrgDomains(),
+			SMTPSenderAddressMatchesInstanceDomain: org.GetDomainPolicy().GetSmtpSenderAddressMatchesInstanceDomain(),
+		}
+	}
+	if org.GetLabelPolicy() != nil {
+		applyOrg.LabelPolicy = management.AddLabelPolicyToDomain(org.GetLabelPolicy())
+	}
+	for _, idp := range org.GetOidcIdps() {
+		config := management.AddOIDCIDPRequestToDomain(idp.GetIdp())
+		config.IDPConfigID = idp.GetIdpId()
+		applyOrg.IDPConfigs = append(applyOrg.IDPConfigs, config)
+	}
+	for _, idp := range org.GetJwtIdps() {
+		config := management.AddJWTIDPRequestToDomain(idp.GetIdp())
+		config.IDPConfigID = idp.GetIdpId()
+		applyOrg.IDPConfigs = append(applyOrg.IDPConfigs, config)
+	}
+	if org.GetLoginPolicy() != nil {
+		applyOrg.LoginPolicy = management.AddLoginPolicyToCommand(org.GetLoginPolicy())
+	}
+	if org.GetPasswordComplexityPolicy() != nil {
+		applyOrg.PasswordComplexityPolicy = management.AddPasswordComplexityPolicyToDomain(org.GetPasswordComplexityPolicy())
+	}
+	if org.GetLockoutPolicy() != nil {
+		applyOrg.LockoutPolicy = management.AddLockoutPolicyToDomain(org.GetLockoutPolicy())
+	}
+	if org.GetPrivacyPolicy() != nil {
+		applyOrg.PrivacyPolicy = management.AddPrivacyPolicyToDomain(org.GetPrivacyPolicy())
+	}
+	for _, project := range org.GetProjects() {
+		applyProject := management.ProjectCreateToDomain(project.GetProject())
+		applyProject.AggregateID = project.GetProjectId()
+		applyOrg.Projects = append(applyOrg.Projects, applyProject)
+	}
+	for _, role := range org.GetProjectRoles() {
+		applyOrg.ProjectRoles = append(applyOrg.ProjectRoles, management.AddProjectRoleRequestToDomain(role))
+	}
+	for _, app := range org.GetOidcApps() {
+		applyApp := management.AddOIDCAppRequestToDomain(app.GetApp())
+		applyApp.AppID = app.GetAppId()
+		applyOrg.OIDCApps = append(applyOrg.OIDCApps, applyApp)
+	}
+	for _, app := range org.GetApiApps() {
+		applyApp := management.AddAPIAppRequestToDomain(app.GetApp())
+		applyApp.AppID = app.GetAppId()
+		applyOrg.APIApps = append(applyOrg.APIApps, applyApp)
+	}
+	for _, action := range org.GetActions() {
+		applyAction := management.CreateActionRequestToDomain(action.GetAction())
+		applyAction.AggregateID = action.GetActionId()
+		applyOrg.Actions = append(applyOrg.Actions, applyAction)
+	}
+	for _, trigger := range org.GetTriggerActions() {
+		applyOrg.TriggerActions = append(applyOrg.TriggerActions, &command.ApplyTriggerActions{
+			FlowType:    action_grpc.FlowTypeToDomain(trigger.GetFlowType()),
+			TriggerType: action_grpc.TriggerTypeToDomain(trigger.GetTriggerType()),
+			ActionIDs:   trigger.GetActionIds(),
+		})
+	}
+	return applyOrg
+}
+
+func applyChangesToPb(changes []*command.ApplyChange) []*admin_pb.ApplyDataChange {
+	result := make([]*admin_pb.ApplyDataChange, len(changes))
+	for i, change := range changes {
+		result[i] = &admin_pb.ApplyDataChange{
+			AggregateType: string(change.AggregateType),
+			AggregateId:   change.AggregateID,
+			EventType:     string(change.EventType),
+			ClientId:      change.ClientID,
+			ClientSecret:  change.ClientSecret,
+		}
+	}
+	return result
+}
@@ -0,0 +1,200 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+	app_pb "github.com/zitadel/zitadel/pkg/grpc/app"
+	management_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+	org_pb "github.com/zitadel/zitadel/pkg/grpc/org"
+	v1_pb "github.com/zitadel/zitadel/pkg/grpc/v1"
+)
+
+func Test_dataOrgToApplyOrg(t *testing.T) {
+	tests := []struct {
+		name string
+		org  *admin_pb.DataOrg
+		want *command.ApplyOrg
+	}{
+		{
+			name: "org only",
+			org: &admin_pb.DataOrg{
+				OrgId: "org1",
+				Org:   &management_pb.AddOrgRequest{Name: "org"},
+			},
+			want: &command.ApplyOrg{
+				ID:   "org1",
+				Name: "org",
+			},
+		},
+		{
+			name: "with resources",
+			org: &admin_pb.DataOrg{
+				OrgId:   "org1",
+				Org:     &management_pb.AddOrgRequest{Name: "org"},
+				Domains: []*org_pb.Domain{{DomainName: "zitadel.ch"}},
+				LockoutPolicy: &management_pb.AddCustomLockoutPolicyRequest{
+					MaxPasswordAttempts: 5,
+				},
+				Projects: []*v1_pb.DataProject{
+					{
+						ProjectId: "project1",
+						Project:   &management_pb.AddProjectRequest{Name: "project"},
+					},
+				},
+				ProjectRoles: []*management_pb.AddProjectRoleRequest{
+					{
+						ProjectId:   "project1",
+						RoleKey:     "key",
+						DisplayName: "display",
+					},
+				},
+				TriggerActions: []*management_pb.SetTriggerActionsRequest{
+					{
+						FlowType:    "1",
+						TriggerType: "1",
+						ActionIds:   []string{"action1"},
+					},
+				},
+			},
+			want: &command.ApplyOrg{
+				ID:      "org1",
+				Name:    "org",
+				Domains: []string{"zitadel.ch"},
+				LockoutPolicy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 5,
+				},
+				Projects: []*domain.Project{
+					{
+						ObjectRoot:             models.ObjectRoot{AggregateID: "project1"},
+						Name:                   "project",
+						PrivateLabelingSetting: domain.PrivateLabelingSettingUnspecified,
+					},
+				},
+				ProjectRoles: []*domain.ProjectRole{
+					{
+						ObjectRoot:  models.ObjectRoot{AggregateID: "project1"},
+						Key:         "key",
+						DisplayName: "display",
+					},
+				},
+				TriggerActions: []*command.ApplyTriggerActions{
+					{
+						FlowType:    domain.FlowTypeExternalAuthentication,
+						TriggerType: domain.TriggerTypePostAuthentication,
+						ActionIDs:   []string{"action1"},
+					},
+				},
+			},
+		},
+		{
+			name: "with apps and idps",
+			org: &admin_pb.DataOrg{
+				OrgId: "org1",
+				Org:   &management_pb.AddOrgRequest{Name: "org"},
+				ApiApps: []*v1_pb.DataAPIApplication{
+					{
+						AppId: "app1",
+						App: &management_pb.AddAPIAppRequest{
+							ProjectId:      "project1",
+							Name:           "api",
+							AuthMethodType: app_pb.APIAuthMethodType_API_AUTH_METHOD_TYPE_PRIVATE_KEY_JWT,
+						},
+					},
+				},
+				JwtIdps: []*v1_pb.DataJWTIDP{
+					{
+						IdpId: "idp1",
+						Idp: &management_pb.AddOrgJWTIDPRequest{
+							Name:         "jwt",
+							JwtEndpoint:  "https://jwt.endpoint",
+							Issuer:       "https://issuer",
+							KeysEndpoint: "https://keys.endpoint",
+							HeaderName:   "x-auth",
+						},
+					},
+				},
+			},
+			want: &command.ApplyOrg{
+				ID:   "org1",
+				Name: "org",
+				IDPConfigs: []*domain.IDPConfig{
+					{
+						IDPConfigID: "idp1",
+						Name:        "jwt",
+						Type:        domain.IDPConfigTypeJWT,
+						JWTConfig: &domain.JWTIDPConfig{
+							JWTEndpoint:  "https://jwt.endpoint",
+							Issuer:       "https://issuer",
+							KeysEndpoint: "https://keys.endpoint",
+							HeaderName:   "x-auth",
+						},
+					},
+				},
+				APIApps: []*domain.APIApp{
+					{
+						ObjectRoot:     models.ObjectRoot{AggregateID: "project1"},
+						AppID:          "app1",
+						AppName:        "api",
+						AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, dataOrgToApplyOrg(tt.org))
+		})
+	}
+}
+
+func Test_unsupportedApplyFields(t *testing.T) {
+	tests := []struct {
+		name string
+		orgs []*admin_pb.DataOrg
+		want []string
+	}{
+		{
+			name: "supported only",
+			orgs: []*admin_pb.DataOrg{
+				{
+					OrgId:    "org1",
+					Projects: []*v1_pb.DataProject{{ProjectId: "project1"}},
+					OidcApps: []*v1_pb.DataOIDCApplication{{AppId: "app1"}},
+					OidcIdps: []*v1_pb.DataOIDCIDP{{IdpId: "idp1"}},
+				},
+			},
+		},
+		{
+			name: "unsupported of multiple orgs",
+			orgs: []*admin_pb.DataOrg{
+				{
+					OrgId:      "org1",
+					HumanUsers: []*v1_pb.DataHumanUser{{UserId: "user1"}},
+				},
+				{
+					OrgId:      "org2",
+					UserGrants: []*management_pb.AddUserGrantRequest{{UserId: "user1"}},
+					LoginTexts: []*management_pb.SetCustomLoginTextsRequest{{Language: "en"}},
+				},
+			},
+			want: []string{"org1 human_users", "org2 user_grants", "org2 login_texts"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, applyErr := range unsupportedApplyFields(tt.orgs) {
+				assert.Contains(t, applyErr.GetMessage(), "Errors.Org.ApplyUnsupported")
+				got = append(got, applyErr.GetId()+" "+applyErr.GetType())
+			}
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
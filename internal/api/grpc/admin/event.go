@@ -2,14 +2,22 @@ package admin
 
 import (
 	"context"
+	"time"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	event_grpc "github.com/zitadel/zitadel/internal/api/grpc/event"
+	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
 const (
 	maxLimit = 1000
+	// watchEventsInterval is the maximum duration until new events are queried.
+	// The subscription only notifies about events pushed by this ZITADEL process,
+	// events pushed by other processes are found after the interval.
+	watchEventsInterval = 5 * time.Second
 )
 
 func (s *Server) ListEvents(ctx context.Context, in *admin_pb.ListEventsRequest) (*admin_pb.ListEventsResponse, error) {
@@ -36,18 +44,6 @@ func (s *Server) ListAggregateTypes(ctx context.Context, in *admin_pb.ListAggreg
 }
 
 func eventRequestToFilter(ctx context.Context, req *admin_pb.ListEventsRequest) (*eventstore.SearchQueryBuilder, error) {
-	eventTypes := make([]eventstore.EventType, len(req.EventTypes))
-	for i, eventType := range req.EventTypes {
-		eventTypes[i] = eventstore.EventType(eventType)
-	}
-	aggregateIDs := make([]string, 0, 1)
-	if req.AggregateId != "" {
-		aggregateIDs = append(aggregateIDs, req.AggregateId)
-	}
-	aggregateTypes := make([]eventstore.AggregateType, len(req.AggregateTypes))
-	for i, aggregateType := range req.AggregateTypes {
-		aggregateTypes[i] = eventstore.AggregateType(aggregateType)
-	}
 	limit := uint64(req.Limit)
 	if limit == 0 || limit > maxLimit {
 		limit = maxLimit
@@ -61,14 +57,7 @@ func eventRequestToFilter(ctx context.Context, req *admin_pb.ListEventsRequest)
 		ResourceOwner(req.ResourceOwner).
 		EditorUser(req.EditorUserId).
 		SequenceGreater(req.Sequence)
-
-	if len(aggregateIDs) > 0 || len(aggregateTypes) > 0 || len(eventTypes) > 0 {
-		builder.AddQuery().
-			AggregateIDs(aggregateIDs...).
-			AggregateTypes(aggregateTypes...).
-			EventTypes(eventTypes...).
-			Builder()
-	}
+	addEventsQuery(builder, req.AggregateId, req.AggregateTypes, req.EventTypes)
 
 	if req.GetAsc() {
 		builder.OrderAsc()
@@ -79,3 +68,154 @@ func eventRequestToFilter(ctx context.Context, req *admin_pb.ListEventsRequest)
 
 	return builder, nil
 }
+
+func addEventsQuery(builder *eventstore.SearchQueryBuilder, aggregateID string, aggregateTypes, eventTypes []string) {
+	if aggregateID == "" && len(aggregateTypes) == 0 && len(eventTypes) == 0 {
+		return
+	}
+	searchQuery := builder.AddQuery()
+	if aggregateID != "" {
+		searchQuery.AggregateIDs(aggregateID)
+	}
+	if len(aggregateTypes) > 0 {
+		searchQuery.AggregateTypes(aggregateTypesToEventstore(aggregateTypes)...)
+	}
+	if len(eventTypes) > 0 {
+		types := make([]eventstore.EventType, len(eventTypes))
+		for i, eventType := range eventTypes {
+			types[i] = eventstore.EventType(eventType)
+		}
+		searchQuery.EventTypes(types...)
+	}
+}
+
+func aggregateTypesToEventstore(aggregateTypes []string) []eventstore.AggregateType {
+	types := make([]eventstore.AggregateType, len(aggregateTypes))
+	for i, aggregateType := range aggregateTypes {
+		types[i] = eventstore.AggregateType(aggregateType)
+	}
+	return types
+}
+
+// WatchEvents streams the events after the requested position until the client closes the stream.
+// New events are queried as soon as the subscription notifies about an event of the instance
+// or at the latest after the watchEventsInterval.
+func (s *Server) WatchEvents(req *admin_pb.WatchEventsRequest, stream admin_pb.AdminService_WatchEventsServer) error {
+	ctx := stream.Context()
+	position := req.GetPosition()
+	if position == 0 {
+		var err error
+		position, err = s.latestEventPosition(ctx, req)
+		if err != nil {
+			return err
+		}
+	}
+
+	aggregateTypes := req.GetAggregateTypes()
+	if len(aggregateTypes) == 0 {
+		aggregateTypes = s.query.SearchAggregateTypes(ctx)
+	}
+	queue := make(chan eventstore.Event, 100)
+	subscription := eventstore.SubscribeAggregatesWithOverflow(queue, aggregateTypesToEventstore(aggregateTypes)...)
+	defer subscription.Unsubscribe()
+	ticker := time.NewTicker(watchEventsInterval)
+	defer ticker.Stop()
+
+	for {
+		events, err := s.eventsAfter(ctx, req, position)
+		if err != nil {
+			return err
+		}
+		for _, event := range events {
+			pbEvent, err := event_grpc.EventToPb(event)
+			if err != nil {
+				return err
+			}
+			if err = stream.Send(&admin_pb.WatchEventsResponse{Event: pbEvent}); err != nil {
+				return err
+			}
+			position = event.Position
+		}
+		if ok, err := awaitEvents(ctx, queue, subscription.Overflow(), ticker.C, authz.GetInstance(ctx).InstanceID()); !ok {
+			return err
+		}
+	}
+}
+
+// latestEventPosition returns the position of the latest event matching the request,
+// so that only events pushed after the stream was opened are streamed
+func (s *Server) latestEventPosition(ctx context.Context, req *admin_pb.WatchEventsRequest) (float64, error) {
+	events, err := s.query.SearchEvents(ctx, watchEventsFilter(ctx, req, 0, 1).OrderDesc())
+	if err != nil || len(events) == 0 {
+		return 0, err
+	}
+	return events[0].Position, nil
+}
+
+// eventsAfter returns the events after the position.
+// As the events pushed in the same transaction share the position,
+// the limit is increased until all events of the last position are found.
+func (s *Server) eventsAfter(ctx context.Context, req *admin_pb.WatchEventsRequest, position float64) ([]*query.Event, error) {
+	for limit := uint64(maxLimit); ; limit *= 2 {
+		events, err := s.query.SearchEvents(ctx, watchEventsFilter(ctx, req, position, limit))
+		if err != nil {
+			return nil, err
+		}
+		if uint64(len(events)) < limit {
+			return events, nil
+		}
+		if complete := completePositions(events); len(complete) > 0 {
+			return complete, nil
+		}
+	}
+}
+
+func watchEventsFilter(ctx context.Context, req *admin_pb.WatchEventsRequest, position float64, limit uint64) *eventstore.SearchQueryBuilder {
+	builder := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		OrderAsc().
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		Limit(limit).
+		AwaitOpenTransactions().
+		ResourceOwner(req.GetResourceOwner()).
+		EditorUser(req.GetEditorUserId()).
+		PositionAfter(position)
+	addEventsQuery(builder, req.GetAggregateId(), req.GetAggregateTypes(), req.GetEventTypes())
+	return builder
+}
+
+// completePositions removes the events of the last position,
+// because further events of the same position might not be part of the result
+func completePositions(events []*query.Event) []*query.Event {
+	last := events[len(events)-1].Position
+	for i := len(events) - 2; i >= 0; i-- {
+		if events[i].Position != last {
+			return events[:i+1]
+		}
+	}
+	return nil
+}
+
+// awaitEvents blocks until an event of the instance is pushed or the ticker ticks.
+// It returns false if the context is done or the queue overflowed,
+// in the latter case the stream is closed with an error, as events were dropped.
+func awaitEvents(ctx context.Context, queue <-chan eventstore.Event, overflow <-chan struct{}, tick <-chan time.Time, instanceID string) (bool, error) {
+	for {
+		select {
+		case <-ctx.Done():
+			return false, nil
+		case <-overflow:
+			return false, errors.ThrowResourceExhausted(nil, "ADMIN-Ahl3u", "Errors.Changes.WatchOverflow")
+		case <-tick:
+			return true, nil
+		case event := <-queue:
+			if event.Aggregate().InstanceID != instanceID {
+				continue
+			}
+			// further queued events are found by the same query
+			for len(queue) > 0 {
+				<-queue
+			}
+			return true, nil
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package admin
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_completePositions(t *testing.T) {
+	tests := []struct {
+		name   string
+		events []*query.Event
+		want   []*query.Event
+	}{
+		{
+			name: "last position removed",
+			events: []*query.Event{
+				{Sequence: 1, Position: 1},
+				{Sequence: 2, Position: 2},
+				{Sequence: 3, Position: 2},
+			},
+			want: []*query.Event{
+				{Sequence: 1, Position: 1},
+			},
+		},
+		{
+			name: "single position, nothing complete",
+			events: []*query.Event{
+				{Sequence: 1, Position: 1},
+				{Sequence: 2, Position: 1},
+			},
+			want: nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, completePositions(tt.events))
+		})
+	}
+}
+
+func Test_awaitEvents(t *testing.T) {
+	eventOfInstance := func(instanceID string) eventstore.Event {
+		return &eventstore.BaseEvent{Agg: &eventstore.Aggregate{InstanceID: instanceID}}
+	}
+	t.Run("event of instance", func(t *testing.T) {
+		queue := make(chan eventstore.Event, 3)
+		queue <- eventOfInstance("other")
+		queue <- eventOfInstance("instance")
+		queue <- eventOfInstance("instance")
+		ok, err := awaitEvents(context.Background(), queue, nil, nil, "instance")
+		assert.True(t, ok)
+		assert.NoError(t, err)
+		assert.Len(t, queue, 0)
+	})
+	t.Run("tick", func(t *testing.T) {
+		tick := make(chan time.Time, 1)
+		tick <- time.Now()
+		ok, err := awaitEvents(context.Background(), make(chan eventstore.Event), nil, tick, "instance")
+		assert.True(t, ok)
+		assert.NoError(t, err)
+	})
+	t.Run("context done", func(t *testing.T) {
+		ctx, cancel := context.WithCancel(context.Background())
+		cancel()
+		ok, err := awaitEvents(ctx, make(chan eventstore.Event), nil, nil, "instance")
+		assert.False(t, ok)
+		assert.NoError(t, err)
+	})
+	t.Run("overflow", func(t *testing.T) {
+		overflow := make(chan struct{})
+		close(overflow)
+		ok, err := awaitEvents(context.Background(), make(chan eventstore.Event), overflow, nil, "instance")
+		assert.False(t, ok)
+		assert.True(t, caos_errs.IsResourceExhausted(err))
+	})
+}
@@ -2,12 +2,19 @@ package admin
 
 import (
 	"context"
+	"strings"
 
+	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/durationpb"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	authn_grpc "github.com/zitadel/zitadel/internal/api/grpc/authn"
 	text_grpc "github.com/zitadel/zitadel/internal/api/grpc/text"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errors "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
@@ -23,66 +30,151 @@ import (
 	v1_pb "github.com/zitadel/zitadel/pkg/grpc/v1"
 )
 
+const (
+	// dataVersion is the version of the format of the exported data,
+	// it must be increased if the data can no longer be imported by previous versions
+	dataVersion uint32 = 1
+
+	exportUsersPageSize = 1000
+)
+
 func (s *Server) ExportData(ctx context.Context, req *admin_pb.ExportDataRequest) (_ *admin_pb.ExportDataResponse, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	orgSearchQuery := &query.OrgSearchQueries{}
+	selectedOrgs, pagedOrgs, err := s.exportOrgs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	outputs, err := openExportOutputs(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	// the export is only kept in memory if it's requested in the response,
+	// large exports should be written to a file or use ExportDataStream
+	toResponse := req.GetResponseOutput() || len(outputs) == 0
+	var chunks []*admin_pb.DataOrg
+	err = s.exportData(ctx, req, selectedOrgs, pagedOrgs, func(chunk *admin_pb.DataOrg) error {
+		for _, output := range outputs {
+			if err := output.write(chunk); err != nil {
+				return err
+			}
+		}
+		if toResponse {
+			chunks = append(chunks, chunk)
+		}
+		return nil
+	})
+	if err = closeExportOutputs(outputs, err); err != nil {
+		return nil, err
+	}
+	resp := &admin_pb.ExportDataResponse{
+		Version:     dataVersion,
+		TotalResult: uint64(len(selectedOrgs)),
+	}
+	if toResponse {
+		resp.Orgs = mergeDataOrgs(chunks)
+	}
+	return resp, nil
+}
+
+// ExportDataStream exports the same data as ExportData,
+// but sends each part of an organization as soon as it is queried instead of building the whole export in memory.
+// The parts of an organization share the org_id and are merged by the import.
+func (s *Server) ExportDataStream(req *admin_pb.ExportDataRequest, stream admin_pb.AdminService_ExportDataStreamServer) (err error) {
+	ctx, span := tracing.NewSpan(stream.Context())
+	defer func() { span.EndWithError(err) }()
+
+	selectedOrgs, pagedOrgs, err := s.exportOrgs(ctx, req)
+	if err != nil {
+		return err
+	}
+	return s.exportData(ctx, req, selectedOrgs, pagedOrgs, func(chunk *admin_pb.DataOrg) error {
+		return stream.Send(&admin_pb.ExportDataResponse{
+			Orgs:        []*admin_pb.DataOrg{chunk},
+			Version:     dataVersion,
+			TotalResult: uint64(len(selectedOrgs)),
+		})
+	})
+}
+
+// exportOrgs returns all orgs selected by the request and the orgs of the requested page
+func (s *Server) exportOrgs(ctx context.Context, req *admin_pb.ExportDataRequest) (selectedOrgs, pagedOrgs []*query.Org, err error) {
+	orgSearchQuery := &query.OrgSearchQueries{
+		SearchRequest: query.SearchRequest{
+			SortingColumn: query.OrgColumnID,
+			Asc:           true,
+		},
+	}
 	if len(req.OrgIds) > 0 {
 		orgIDsSearchQuery, err := query.NewOrgIDsSearchQuery(req.OrgIds...)
 		if err != nil {
-			return nil, err
+			return nil, nil, err
 		}
 		orgSearchQuery.Queries = []query.SearchQuery{orgIDsSearchQuery}
 	}
 	queriedOrgs, err := s.query.SearchOrgs(ctx, orgSearchQuery)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+
+	selectedOrgs = make([]*query.Org, 0, len(queriedOrgs.Orgs))
+	for _, queriedOrg := range queriedOrgs.Orgs {
+		if isExcludedOrg(queriedOrg.ID, req.ExcludedOrgIds) {
+			continue
+		}
+		selectedOrgs = append(selectedOrgs, queriedOrg)
 	}
+	return selectedOrgs, pageOrgs(selectedOrgs, req.Offset, req.Limit), nil
+}
 
-	orgs := make([]*admin_pb.DataOrg, len(queriedOrgs.Orgs))
-	processedOrgs := make([]string, len(queriedOrgs.Orgs))
+// exportData passes the data of the paged orgs in chunks to send.
+// The first chunk of an org contains its settings, projects and actions, followed by a chunk per page of users
+// and the chunks of the grants and members, which can only be determined after all orgs are exported.
+// Only the ids of the exported objects are kept in memory.
+func (s *Server) exportData(ctx context.Context, req *admin_pb.ExportDataRequest, selectedOrgs, pagedOrgs []*query.Org, send func(*admin_pb.DataOrg) error) (err error) {
+	// the otp secrets are only exported encrypted with the key of the request
+	otpEncryptionKey := ""
+	if req.WithOtp {
+		if err = checkOTPEncryptionKey(req.GetOtpEncryptionKey()); err != nil {
+			return err
+		}
+		otpEncryptionKey = req.GetOtpEncryptionKey()
+	}
+	// all requested orgs are processed, so that grants to orgs of other pages are exported as well
+	processedOrgs := make([]string, len(selectedOrgs))
+	for i, selectedOrg := range selectedOrgs {
+		processedOrgs[i] = selectedOrg.ID
+	}
 	processedProjects := make([]string, 0)
 	processedGrants := make([]string, 0)
 	processedUsers := make([]string, 0)
 	processedActions := make([]string, 0)
 
-	for i, queriedOrg := range queriedOrgs.Orgs {
-		if req.ExcludedOrgIds != nil {
-			found := false
-			for _, excludedOrg := range req.ExcludedOrgIds {
-				if excludedOrg == queriedOrg.ID {
-					found = true
-				}
-			}
-			if found {
-				continue
-			}
-		}
-		processedOrgs = append(processedOrgs, queriedOrg.ID)
+	langResp, err := s.GetSupportedLanguages(ctx, &admin_pb.GetSupportedLanguagesRequest{})
+	if err != nil {
+		return err
+	}
 
+	for _, queriedOrg := range pagedOrgs {
 		/******************************************************************************************************************
 		Organization
 		******************************************************************************************************************/
 		org := &admin_pb.DataOrg{OrgId: queriedOrg.ID, Org: &management_pb.AddOrgRequest{Name: queriedOrg.Name}}
-		orgs[i] = org
-	}
-
-	for _, org := range orgs {
 
 		org.DomainPolicy, err = s.getDomainPolicy(ctx, org.GetOrgId())
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.Domains, err = s.getDomains(ctx, org.GetOrgId())
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.OidcIdps, org.JwtIdps, err = s.getIDPs(ctx, org.GetOrgId())
 		if err != nil {
-			return nil, err
+			return err
 		}
 		orgIDPs := make([]string, 0)
 		for _, idp := range org.OidcIdps {
@@ -94,96 +186,77 @@ func (s *Server) ExportData(ctx context.Context, req *admin_pb.ExportDataRequest
 
 		org.LabelPolicy, err = s.getLabelPolicy(ctx, org.GetOrgId())
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.LoginPolicy, err = s.getLoginPolicy(ctx, org.GetOrgId(), orgIDPs)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.UserLinks, err = s.getUserLinks(ctx, org.GetOrgId())
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.LockoutPolicy, err = s.getLockoutPolicy(ctx, org.GetOrgId())
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.PasswordComplexityPolicy, err = s.getPasswordComplexityPolicy(ctx, org.GetOrgId())
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.PrivacyPolicy, err = s.getPrivacyPolicy(ctx, org.GetOrgId())
 		if err != nil {
-			return nil, err
-		}
-
-		langResp, err := s.GetSupportedLanguages(ctx, &admin_pb.GetSupportedLanguagesRequest{})
-		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.LoginTexts, err = s.getCustomLoginTexts(ctx, org.GetOrgId(), langResp.Languages)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.InitMessages, err = s.getCustomInitMessageTexts(ctx, org.GetOrgId(), langResp.Languages)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.PasswordResetMessages, err = s.getCustomPasswordResetMessageTexts(ctx, org.GetOrgId(), langResp.Languages)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.VerifyEmailMessages, err = s.getCustomVerifyEmailMessageTexts(ctx, org.GetOrgId(), langResp.Languages)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.VerifyPhoneMessages, err = s.getCustomVerifyPhoneMessageTexts(ctx, org.GetOrgId(), langResp.Languages)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.VerifySmsOtpMessages, err = s.getCustomVerifySMSOTPMessageTexts(ctx, org.GetOrgId(), langResp.Languages)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.VerifyEmailOtpMessages, err = s.getCustomVerifyEmailOTPMessageTexts(ctx, org.GetOrgId(), langResp.Languages)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.DomainClaimedMessages, err = s.getCustomDomainClaimedMessageTexts(ctx, org.GetOrgId(), langResp.Languages)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.PasswordlessRegistrationMessages, err = s.getCustomPasswordlessRegistrationMessageTexts(ctx, org.GetOrgId(), langResp.Languages)
 		if err != nil {
-			return nil, err
-		}
-
-		/******************************************************************************************************************
-		Users
-		******************************************************************************************************************/
-		org.HumanUsers, org.MachineUsers, org.UserMetadata, org.MachineKeys, err = s.getUsers(ctx, org.GetOrgId(), req.WithPasswords, req.WithOtp)
-		if err != nil {
-			return nil, err
-		}
-		for _, processedUser := range org.HumanUsers {
-			processedUsers = append(processedUsers, processedUser.UserId)
-		}
-		for _, processedUser := range org.MachineUsers {
-			processedUsers = append(processedUsers, processedUser.UserId)
+			return err
 		}
 
 		/******************************************************************************************************************
@@ -191,7 +264,7 @@ func (s *Server) ExportData(ctx context.Context, req *admin_pb.ExportDataRequest
 		******************************************************************************************************************/
 		org.Projects, org.ProjectRoles, org.OidcApps, org.ApiApps, org.AppKeys, err = s.getProjectsAndApps(ctx, org.GetOrgId())
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for _, processedProject := range org.Projects {
 			processedProjects = append(processedProjects, processedProject.ProjectId)
@@ -202,20 +275,34 @@ func (s *Server) ExportData(ctx context.Context, req *admin_pb.ExportDataRequest
 		******************************************************************************************************************/
 		org.Actions, err = s.getActions(ctx, org.GetOrgId())
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for _, processedAction := range org.Actions {
 			processedActions = append(processedActions, processedAction.ActionId)
 		}
+
+		if err = send(org); err != nil {
+			return err
+		}
+
+		/******************************************************************************************************************
+		Users
+		******************************************************************************************************************/
+		userIDs, err := s.exportUsers(ctx, org.GetOrgId(), req.WithPasswords, req.WithAvatars, otpEncryptionKey, send)
+		if err != nil {
+			return err
+		}
+		processedUsers = append(processedUsers, userIDs...)
 	}
 
-	for _, org := range orgs {
+	for _, queriedOrg := range pagedOrgs {
+		org := &admin_pb.DataOrg{OrgId: queriedOrg.ID}
 		/******************************************************************************************************************
 		  Flows
 		  ******************************************************************************************************************/
 		org.TriggerActions, err = s.getTriggerActions(ctx, org.OrgId, processedActions)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		/******************************************************************************************************************
@@ -223,7 +310,7 @@ func (s *Server) ExportData(ctx context.Context, req *admin_pb.ExportDataRequest
 		  ******************************************************************************************************************/
 		org.ProjectGrants, err = s.getNecessaryProjectGrantsForOrg(ctx, org.OrgId, processedOrgs, processedProjects)
 		if err != nil {
-			return nil, err
+			return err
 		}
 		for _, processedGrant := range org.ProjectGrants {
 			processedGrants = append(processedGrants, processedGrant.GrantId)
@@ -231,33 +318,85 @@ func (s *Server) ExportData(ctx context.Context, req *admin_pb.ExportDataRequest
 
 		org.UserGrants, err = s.getNecessaryUserGrantsForOrg(ctx, org.OrgId, processedProjects, processedGrants, processedUsers)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err = send(org); err != nil {
+			return err
 		}
 	}
 
-	for _, org := range orgs {
+	for _, queriedOrg := range pagedOrgs {
+		org := &admin_pb.DataOrg{OrgId: queriedOrg.ID}
 		/******************************************************************************************************************
 		  Members
 		  ******************************************************************************************************************/
 		org.OrgMembers, err = s.getNecessaryOrgMembersForOrg(ctx, org.OrgId, processedUsers)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.ProjectMembers, err = s.getNecessaryProjectMembersForOrg(ctx, processedProjects, processedUsers)
 		if err != nil {
-			return nil, err
+			return err
 		}
 
 		org.ProjectGrantMembers, err = s.getNecessaryProjectGrantMembersForOrg(ctx, org.OrgId, processedProjects, processedGrants, processedUsers)
 		if err != nil {
-			return nil, err
+			return err
+		}
+		if err = send(org); err != nil {
+			return err
 		}
 	}
+	return nil
+}
+
+// mergeDataOrgs merges the chunks of the same org into a single org,
+// the orgs keep the order of their first chunk
+func mergeDataOrgs(chunks []*admin_pb.DataOrg) []*admin_pb.DataOrg {
+	orgs := make([]*admin_pb.DataOrg, 0, len(chunks))
+	byID := make(map[string]*admin_pb.DataOrg, len(chunks))
+	for _, chunk := range chunks {
+		org, ok := byID[chunk.GetOrgId()]
+		if !ok {
+			byID[chunk.GetOrgId()] = chunk
+			orgs = append(orgs, chunk)
+			continue
+		}
+		proto.Merge(org, chunk)
+	}
+	return orgs
+}
 
-	return &admin_pb.ExportDataResponse{
-		Orgs: orgs,
-	}, nil
+// checkOTPEncryptionKey ensures the key can be used for AES-128, AES-192 or AES-256
+func checkOTPEncryptionKey(key string) error {
+	switch len(key) {
+	case 16, 24, 32:
+		return nil
+	default:
+		return caos_errors.ThrowInvalidArgument(nil, "ADMIN-Eo0ae", "Errors.DataImport.OTPEncryptionKeyInvalid")
+	}
+}
+
+func isExcludedOrg(orgID string, excludedOrgIDs []string) bool {
+	for _, excludedOrgID := range excludedOrgIDs {
+		if excludedOrgID == orgID {
+			return true
+		}
+	}
+	return false
+}
+
+// pageOrgs returns the orgs of the requested page, a limit of 0 returns all orgs after the offset
+func pageOrgs(orgs []*query.Org, offset uint64, limit uint32) []*query.Org {
+	if offset >= uint64(len(orgs)) {
+		return []*query.Org{}
+	}
+	orgs = orgs[offset:]
+	if limit > 0 && uint64(limit) < uint64(len(orgs)) {
+		orgs = orgs[:limit]
+	}
+	return orgs
 }
 
 func (s *Server) getDomainPolicy(ctx context.Context, orgID string) (_ *admin_pb.AddCustomDomainPolicyRequest, err error) {
@@ -541,23 +680,57 @@ func (s *Server) getPrivacyPolicy(ctx context.Context, orgID string) (_ *managem
 	return nil, nil
 }
 
-func (s *Server) getUsers(ctx context.Context, org string, withPasswords bool, withOTP bool) (_ []*v1_pb.DataHumanUser, _ []*v1_pb.DataMachineUser, _ []*management_pb.SetUserMetadataRequest, _ []*v1_pb.DataMachineKey, err error) {
+// exportUsers queries the users of the org page by page and sends each page as a chunk of the org,
+// so that orgs with a lot of users are never kept in memory. It returns the ids of the exported users.
+// The otp secrets are only exported if an otpEncryptionKey is passed.
+func (s *Server) exportUsers(ctx context.Context, org string, withPasswords, withAvatars bool, otpEncryptionKey string, send func(*admin_pb.DataOrg) error) (_ []string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
 	orgSearch, err := query.NewUserResourceOwnerSearchQuery(org, query.TextEquals)
 	if err != nil {
-		return nil, nil, nil, nil, err
+		return nil, err
 	}
-	users, err := s.query.SearchUsers(ctx, &query.UserSearchQueries{Queries: []query.SearchQuery{orgSearch}})
-	if err != nil {
-		return nil, nil, nil, nil, err
+	userIDs := make([]string, 0)
+	for offset := uint64(0); ; offset += exportUsersPageSize {
+		page, err := s.query.SearchUsers(ctx, &query.UserSearchQueries{
+			SearchRequest: query.SearchRequest{
+				Offset:        offset,
+				Limit:         exportUsersPageSize,
+				SortingColumn: query.UserIDCol,
+				Asc:           true,
+			},
+			Queries: []query.SearchQuery{orgSearch},
+		})
+		if err != nil {
+			return nil, err
+		}
+		chunk := &admin_pb.DataOrg{OrgId: org}
+		chunk.HumanUsers, chunk.MachineUsers, chunk.UserMetadata, chunk.MachineKeys, err = s.getUsers(ctx, org, page.Users, withPasswords, withAvatars, otpEncryptionKey)
+		if err != nil {
+			return nil, err
+		}
+		if err = send(chunk); err != nil {
+			return nil, err
+		}
+		for _, user := range page.Users {
+			userIDs = append(userIDs, user.ID)
+		}
+		if len(page.Users) < exportUsersPageSize {
+			return userIDs, nil
+		}
 	}
+}
+
+func (s *Server) getUsers(ctx context.Context, org string, users []*query.User, withPasswords, withAvatars bool, otpEncryptionKey string) (_ []*v1_pb.DataHumanUser, _ []*v1_pb.DataMachineUser, _ []*management_pb.SetUserMetadataRequest, _ []*v1_pb.DataMachineKey, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
 	humanUsers := make([]*v1_pb.DataHumanUser, 0)
 	machineUsers := make([]*v1_pb.DataMachineUser, 0)
 	userMetadata := make([]*management_pb.SetUserMetadataRequest, 0)
 	machineKeys := make([]*v1_pb.DataMachineKey, 0)
-	for _, user := range users.Users {
+	for _, user := range users {
 		switch user.Type {
 		case domain.UserTypeHuman:
 			dataUser := &v1_pb.DataHumanUser{
@@ -599,7 +772,7 @@ func (s *Server) getUsers(ctx context.Context, org string, withPasswords bool, w
 					}
 				}
 			}
-			if withOTP {
+			if otpEncryptionKey != "" {
 				ctx, otpspan := tracing.NewSpan(ctx)
 				code, err := s.query.GetHumanOTPSecret(ctx, user.ID, org)
 				otpspan.EndWithError(err)
@@ -607,9 +780,15 @@ func (s *Server) getUsers(ctx context.Context, org string, withPasswords bool, w
 					return nil, nil, nil, nil, err
 				}
 				if err == nil && code != "" {
-					dataUser.User.OtpCode = code
+					dataUser.EncryptedOtpCode, err = crypto.EncryptAESGCMString(code, otpEncryptionKey)
+					if err != nil {
+						return nil, nil, nil, nil, err
+					}
 				}
 			}
+			if withAvatars && user.Human.AvatarKey != "" {
+				dataUser.Avatar, dataUser.AvatarContentType = s.getAvatar(ctx, org, user.ID, user.Human.AvatarKey)
+			}
 
 			humanUsers = append(humanUsers, dataUser)
 		case domain.UserTypeMachine:
@@ -667,6 +846,26 @@ func (s *Server) getUsers(ctx context.Context, org string, withPasswords bool, w
 	return humanUsers, machineUsers, userMetadata, machineKeys, nil
 }
 
+// getAvatar returns the avatar of the user from the static storage,
+// a missing avatar does not fail the export
+func (s *Server) getAvatar(ctx context.Context, org, userID, avatarKey string) ([]byte, string) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer span.End()
+
+	objectName := strings.Split(avatarKey, "?v=")[0]
+	data, getInfo, err := s.storage.GetObject(ctx, authz.GetInstance(ctx).InstanceID(), org, objectName)
+	if err != nil {
+		logging.WithFields("user", userID).WithError(err).Warn("unable to export avatar")
+		return nil, ""
+	}
+	info, err := getInfo()
+	if err != nil {
+		logging.WithFields("user", userID).WithError(err).Warn("unable to export avatar")
+		return nil, ""
+	}
+	return data, info.ContentType
+}
+
 func (s *Server) getTriggerActions(ctx context.Context, org string, processedActions []string) (_ []*management_pb.SetTriggerActionsRequest, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -719,6 +918,7 @@ func (s *Server) getActions(ctx context.Context, org string) ([]*v1_pb.DataActio
 				Script:        action.Script,
 				Timeout:       timeout,
 				AllowedToFail: action.AllowedToFail,
+				Target:        action_grpc.ActionTargetToPb(action),
 			},
 		}
 	}
@@ -794,23 +994,30 @@ func (s *Server) getProjectsAndApps(ctx context.Context, org string) ([]*v1_pb.D
 				oidcApps = append(oidcApps, &v1_pb.DataOIDCApplication{
 					AppId: app.ID,
 					App: &management_pb.AddOIDCAppRequest{
-						ProjectId:                app.ProjectID,
-						Name:                     app.Name,
-						RedirectUris:             app.OIDCConfig.RedirectURIs,
-						ResponseTypes:            responseTypes,
-						GrantTypes:               grantTypes,
-						AppType:                  app_pb.OIDCAppType(app.OIDCConfig.AppType),
-						AuthMethodType:           app_pb.OIDCAuthMethodType(app.OIDCConfig.AuthMethodType),
-						PostLogoutRedirectUris:   app.OIDCConfig.PostLogoutRedirectURIs,
-						Version:                  app_pb.OIDCVersion(app.OIDCConfig.Version),
-						DevMode:                  app.OIDCConfig.IsDevMode,
-						AccessTokenType:          app_pb.OIDCTokenType(app.OIDCConfig.AccessTokenType),
-						AccessTokenRoleAssertion: app.OIDCConfig.AssertAccessTokenRole,
-						IdTokenRoleAssertion:     app.OIDCConfig.AssertIDTokenRole,
-						IdTokenUserinfoAssertion: app.OIDCConfig.AssertIDTokenUserinfo,
-						ClockSkew:                durationpb.New(app.OIDCConfig.ClockSkew),
-						AdditionalOrigins:        app.OIDCConfig.AdditionalOrigins,
-						SkipNativeAppSuccessPage: app.OIDCConfig.SkipNativeAppSuccessPage,
+						ProjectId:                          app.ProjectID,
+						Name:                               app.Name,
+						RedirectUris:                       app.OIDCConfig.RedirectURIs,
+						ResponseTypes:                      responseTypes,
+						GrantTypes:                         grantTypes,
+						AppType:                            app_pb.OIDCAppType(app.OIDCConfig.AppType),
+						AuthMethodType:                     app_pb.OIDCAuthMethodType(app.OIDCConfig.AuthMethodType),
+						PostLogoutRedirectUris:             app.OIDCConfig.PostLogoutRedirectURIs,
+						Version:                            app_pb.OIDCVersion(app.OIDCConfig.Version),
+						DevMode:                            app.OIDCConfig.IsDevMode,
+						AccessTokenType:                    app_pb.OIDCTokenType(app.OIDCConfig.AccessTokenType),
+						AccessTokenRoleAssertion:           app.OIDCConfig.AssertAccessTokenRole,
+						IdTokenRoleAssertion:               app.OIDCConfig.AssertIDTokenRole,
+						IdTokenUserinfoAssertion:           app.OIDCConfig.AssertIDTokenUserinfo,
+						ClockSkew:                          durationpb.New(app.OIDCConfig.ClockSkew),
+						AdditionalOrigins:                  app.OIDCConfig.AdditionalOrigins,
+						SkipNativeAppSuccessPage:           app.OIDCConfig.SkipNativeAppSuccessPage,
+						BackChannelLogoutUri:               app.OIDCConfig.BackChannelLogoutURI,
+						FrontChannelLogoutUri:              app.OIDCConfig.FrontChannelLogoutURI,
+						RequirePushedAuthorizationRequests: app.OIDCConfig.RequirePushedAuthRequest,
+						RequireSignedRequestObject:         app.OIDCConfig.RequireSignedRequest,
+						DpopBoundAccessTokens:              app.OIDCConfig.DPoPBoundAccessTokens,
+						ForceRsaSignedTokens:               app.OIDCConfig.ForceRSASignedTokens,
+						BackChannelClientNotificationUri:   app.OIDCConfig.BackChannelClientNotificationURI,
 					},
 				})
 			}
@@ -0,0 +1,172 @@
+package admin
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+
+	"cloud.google.com/go/storage"
+	"github.com/minio/minio-go/v7"
+	"github.com/minio/minio-go/v7/pkg/credentials"
+	"google.golang.org/api/option"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+// exportOutput writes the chunks of an export as they are queried to a file,
+// so the export is never kept in memory.
+// The file contains a single ImportDataOrg, which can be imported from the same location.
+type exportOutput struct {
+	w io.Writer
+	// close completes the file, it's discarded if the export failed
+	close  func(exportErr error) error
+	chunks int
+}
+
+// openExportOutputs opens the outputs set on the request and writes the beginning of the files
+func openExportOutputs(ctx context.Context, req *admin_pb.ExportDataRequest) (outputs []*exportOutput, err error) {
+	defer func() {
+		if err != nil {
+			closeExportOutputs(outputs, err)
+		}
+	}()
+	if local := req.GetLocalOutput(); local != nil {
+		output, err := openLocalExportOutput(local)
+		if err != nil {
+			return outputs, err
+		}
+		outputs = append(outputs, output)
+	}
+	if s3 := req.GetS3Output(); s3 != nil {
+		output, err := openS3ExportOutput(ctx, s3)
+		if err != nil {
+			return outputs, err
+		}
+		outputs = append(outputs, output)
+	}
+	if gcs := req.GetGcsOutput(); gcs != nil {
+		output, err := openGCSExportOutput(ctx, gcs)
+		if err != nil {
+			return outputs, err
+		}
+		outputs = append(outputs, output)
+	}
+	for _, output := range outputs {
+		if _, err = io.WriteString(output.w, `{"version":`+strconv.FormatUint(uint64(dataVersion), 10)+`,"orgs":[`); err != nil {
+			return outputs, err
+		}
+	}
+	return outputs, nil
+}
+
+func (o *exportOutput) write(chunk *admin_pb.DataOrg) error {
+	data, err := protojson.Marshal(chunk)
+	if err != nil {
+		return err
+	}
+	if o.chunks > 0 {
+		if _, err = io.WriteString(o.w, ","); err != nil {
+			return err
+		}
+	}
+	o.chunks++
+	_, err = o.w.Write(data)
+	return err
+}
+
+// closeExportOutputs completes the files of a successful export and discards them otherwise.
+// The first error of the export or of closing the outputs is returned.
+func closeExportOutputs(outputs []*exportOutput, exportErr error) error {
+	for _, output := range outputs {
+		if exportErr == nil {
+			_, exportErr = io.WriteString(output.w, "]}")
+		}
+		if err := output.close(exportErr); err != nil && exportErr == nil {
+			exportErr = err
+		}
+	}
+	return exportErr
+}
+
+func openLocalExportOutput(local *admin_pb.ExportDataRequest_LocalOutput) (*exportOutput, error) {
+	file, err := os.Create(local.GetPath())
+	if err != nil {
+		return nil, err
+	}
+	return &exportOutput{
+		w: file,
+		close: func(exportErr error) error {
+			err := file.Close()
+			if exportErr != nil {
+				return os.Remove(local.GetPath())
+			}
+			return err
+		},
+	}, nil
+}
+
+// openS3ExportOutput uploads the file while it's written, the upload is aborted if the export fails
+func openS3ExportOutput(ctx context.Context, s3 *admin_pb.ExportDataRequest_S3Output) (*exportOutput, error) {
+	minioClient, err := minio.New(s3.GetEndpoint(), &minio.Options{
+		Creds:  credentials.NewStaticV4(s3.GetAccessKeyId(), s3.GetSecretAccessKey(), ""),
+		Secure: s3.GetSsl(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	exists, err := minioClient.BucketExists(ctx, s3.GetBucket())
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, fmt.Errorf("bucket not existing: %s", s3.GetBucket())
+	}
+
+	reader, writer := io.Pipe()
+	uploaded := make(chan error, 1)
+	go func() {
+		_, err := minioClient.PutObject(ctx, s3.GetBucket(), s3.GetPath(), reader, -1, minio.PutObjectOptions{ContentType: "application/json"})
+		reader.CloseWithError(err)
+		uploaded <- err
+	}()
+	return &exportOutput{
+		w: writer,
+		close: func(exportErr error) error {
+			writer.CloseWithError(exportErr)
+			return <-uploaded
+		},
+	}, nil
+}
+
+// openGCSExportOutput uploads the file while it's written, the upload is aborted if the export fails
+func openGCSExportOutput(ctx context.Context, gcs *admin_pb.ExportDataRequest_GCSOutput) (*exportOutput, error) {
+	saJson, err := base64.StdEncoding.DecodeString(gcs.GetServiceaccountJson())
+	if err != nil {
+		return nil, err
+	}
+	client, err := storage.NewClient(ctx, option.WithCredentialsJSON(saJson))
+	if err != nil {
+		return nil, err
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	writer := client.Bucket(gcs.GetBucket()).Object(gcs.GetPath()).NewWriter(ctx)
+	writer.ContentType = "application/json"
+	return &exportOutput{
+		w: writer,
+		close: func(exportErr error) error {
+			defer client.Close()
+			// the object is only created if the writer is closed without cancelling the upload
+			if exportErr != nil {
+				cancel()
+			}
+			err := writer.Close()
+			cancel()
+			return err
+		},
+	}, nil
+}
@@ -0,0 +1,126 @@
+package admin
+
+import (
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protojson"
+
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+	management_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+	v1_pb "github.com/zitadel/zitadel/pkg/grpc/v1"
+)
+
+func Test_pageOrgs(t *testing.T) {
+	orgs := []*query.Org{{ID: "org1"}, {ID: "org2"}, {ID: "org3"}}
+	type args struct {
+		offset uint64
+		limit  uint32
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*query.Org
+	}{
+		{
+			name: "no limit, all orgs",
+			args: args{},
+			want: orgs,
+		},
+		{
+			name: "first page",
+			args: args{limit: 2},
+			want: orgs[:2],
+		},
+		{
+			name: "last page",
+			args: args{offset: 2, limit: 2},
+			want: orgs[2:],
+		},
+		{
+			name: "offset after last org, empty",
+			args: args{offset: 3, limit: 2},
+			want: []*query.Org{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, pageOrgs(orgs, tt.args.offset, tt.args.limit))
+		})
+	}
+}
+
+func Test_mergeDataOrgs(t *testing.T) {
+	chunks := []*admin_pb.DataOrg{
+		{OrgId: "org1", Org: &management_pb.AddOrgRequest{Name: "org1"}},
+		{OrgId: "org2", Org: &management_pb.AddOrgRequest{Name: "org2"}},
+		{OrgId: "org1", HumanUsers: []*v1_pb.DataHumanUser{{UserId: "user1"}}},
+		{OrgId: "org1", HumanUsers: []*v1_pb.DataHumanUser{{UserId: "user2"}}},
+		{OrgId: "org2", OrgMembers: []*management_pb.AddOrgMemberRequest{{UserId: "user3"}}},
+	}
+
+	orgs := mergeDataOrgs(chunks)
+
+	if assert.Len(t, orgs, 2) {
+		assert.Equal(t, "org1", orgs[0].GetOrgId())
+		assert.Equal(t, "org1", orgs[0].GetOrg().GetName())
+		if assert.Len(t, orgs[0].GetHumanUsers(), 2) {
+			assert.Equal(t, "user1", orgs[0].GetHumanUsers()[0].GetUserId())
+			assert.Equal(t, "user2", orgs[0].GetHumanUsers()[1].GetUserId())
+		}
+		assert.Equal(t, "org2", orgs[1].GetOrgId())
+		assert.Equal(t, "org2", orgs[1].GetOrg().GetName())
+		assert.Len(t, orgs[1].GetOrgMembers(), 1)
+	}
+}
+
+func Test_checkOTPEncryptionKey(t *testing.T) {
+	assert.NoError(t, checkOTPEncryptionKey("passphrase16byte"))
+	assert.NoError(t, checkOTPEncryptionKey("passphrasewhichis24bytes"))
+	assert.NoError(t, checkOTPEncryptionKey("passphrasewhichneedstobe32bytes!"))
+	assert.True(t, caos_errors.IsErrorInvalidArgument(checkOTPEncryptionKey("")))
+	assert.True(t, caos_errors.IsErrorInvalidArgument(checkOTPEncryptionKey("tooshort")))
+}
+
+func Test_exportOutput_local(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.json")
+	req := &admin_pb.ExportDataRequest{LocalOutput: &admin_pb.ExportDataRequest_LocalOutput{Path: path}}
+
+	outputs, err := openExportOutputs(context.Background(), req)
+	require.NoError(t, err)
+	require.Len(t, outputs, 1)
+	require.NoError(t, outputs[0].write(&admin_pb.DataOrg{OrgId: "org1", Org: &management_pb.AddOrgRequest{Name: "org1"}}))
+	require.NoError(t, outputs[0].write(&admin_pb.DataOrg{OrgId: "org1", HumanUsers: []*v1_pb.DataHumanUser{{UserId: "user1"}}}))
+	require.NoError(t, closeExportOutputs(outputs, nil))
+
+	data, err := os.ReadFile(path)
+	require.NoError(t, err)
+	imported := new(admin_pb.ImportDataOrg)
+	require.NoError(t, protojson.Unmarshal(data, imported))
+	assert.Equal(t, dataVersion, imported.GetVersion())
+	orgs := mergeDataOrgs(imported.GetOrgs())
+	require.Len(t, orgs, 1)
+	assert.Equal(t, "org1", orgs[0].GetOrg().GetName())
+	assert.Equal(t, "user1", orgs[0].GetHumanUsers()[0].GetUserId())
+}
+
+func Test_exportOutput_localFailed(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "export.json")
+	req := &admin_pb.ExportDataRequest{LocalOutput: &admin_pb.ExportDataRequest_LocalOutput{Path: path}}
+	errExport := errors.New("export failed")
+
+	outputs, err := openExportOutputs(context.Background(), req)
+	require.NoError(t, err)
+	require.NoError(t, outputs[0].write(&admin_pb.DataOrg{OrgId: "org1"}))
+	assert.ErrorIs(t, closeExportOutputs(outputs, errExport), errExport)
+
+	_, err = os.Stat(path)
+	assert.True(t, os.IsNotExist(err), "incomplete export must be removed")
+}
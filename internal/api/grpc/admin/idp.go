@@ -405,6 +405,55 @@ func (s *Server) UpdateLDAPProvider(ctx context.Context, req *admin_pb.UpdateLDA
 	}, nil
 }
 
+func (s *Server) GetLDAPSync(ctx context.Context, req *admin_pb.GetLDAPSyncRequest) (*admin_pb.GetLDAPSyncResponse, error) {
+	resourceOwner, err := query.NewLDAPSyncResourceOwnerSearchQuery(authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	sync, err := s.query.LDAPSyncByIDPID(ctx, true, req.Id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetLDAPSyncResponse{
+		Sync: idp_grpc.LDAPSyncToPb(sync),
+	}, nil
+}
+
+func (s *Server) SetLDAPSync(ctx context.Context, req *admin_pb.SetLDAPSyncRequest) (*admin_pb.SetLDAPSyncResponse, error) {
+	details, err := s.command.SetLDAPSync(ctx, idp_grpc.LDAPSyncToDomain(req.Id, req.GroupAttribute, req.GroupMappings, req.SyncEnabled, req.SyncInterval), authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.SetLDAPSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveLDAPSync(ctx context.Context, req *admin_pb.RemoveLDAPSyncRequest) (*admin_pb.RemoveLDAPSyncResponse, error) {
+	details, err := s.command.RemoveLDAPSync(ctx, req.Id, authz.GetInstance(ctx).InstanceID())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.RemoveLDAPSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListLDAPSyncReports(ctx context.Context, req *admin_pb.ListLDAPSyncReportsRequest) (*admin_pb.ListLDAPSyncReportsResponse, error) {
+	queries, err := listLDAPSyncReportsToModel(authz.GetInstance(ctx).InstanceID(), req)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := s.query.SearchLDAPSyncReports(ctx, req.Id, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListLDAPSyncReportsResponse{
+		Result:  idp_grpc.LDAPSyncReportsToPb(reports.Reports),
+		Details: object_pb.ToListDetails(reports.Count, reports.Sequence, reports.LastRun),
+	}, nil
+}
+
 func (s *Server) AddAppleProvider(ctx context.Context, req *admin_pb.AddAppleProviderRequest) (*admin_pb.AddAppleProviderResponse, error) {
 	id, details, err := s.command.AddInstanceAppleProvider(ctx, addAppleProviderToCommand(req))
 	if err != nil {
@@ -444,6 +444,26 @@ func updateLDAPProviderToCommand(req *admin_pb.UpdateLDAPProviderRequest) comman
 	}
 }
 
+func listLDAPSyncReportsToModel(resourceOwner string, req *admin_pb.ListLDAPSyncReportsRequest) (*query.LDAPSyncReportSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := idp_grpc.LDAPSyncReportQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	resourceOwnerQuery, err := query.NewLDAPSyncReportResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &query.LDAPSyncReportSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, resourceOwnerQuery),
+	}, nil
+}
+
 func addAppleProviderToCommand(req *admin_pb.AddAppleProviderRequest) command.AppleProvider {
 	return command.AppleProvider{
 		Name:       req.Name,
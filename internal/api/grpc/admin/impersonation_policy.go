@@ -0,0 +1,46 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) AddImpersonationPolicy(ctx context.Context, req *admin_pb.AddImpersonationPolicyRequest) (*admin_pb.AddImpersonationPolicyResponse, error) {
+	result, err := s.command.AddDefaultImpersonationPolicy(ctx, authz.GetInstance(ctx).InstanceID(), req.GetAllowImpersonation())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.AddImpersonationPolicyResponse{
+		Details: object.AddToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) GetImpersonationPolicy(ctx context.Context, _ *admin_pb.GetImpersonationPolicyRequest) (*admin_pb.GetImpersonationPolicyResponse, error) {
+	policy, err := s.query.DefaultImpersonationPolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetImpersonationPolicyResponse{Policy: policy_grpc.ModelImpersonationPolicyToPb(policy)}, nil
+}
+
+func (s *Server) UpdateImpersonationPolicy(ctx context.Context, req *admin_pb.UpdateImpersonationPolicyRequest) (*admin_pb.UpdateImpersonationPolicyResponse, error) {
+	result, err := s.command.ChangeDefaultImpersonationPolicy(ctx, authz.GetInstance(ctx).InstanceID(), req.GetAllowImpersonation())
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.UpdateImpersonationPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
@@ -1,6 +1,7 @@
 package admin
 
 import (
+	"bytes"
 	"context"
 	"encoding/base64"
 	"fmt"
@@ -23,8 +24,11 @@ import (
 	"github.com/zitadel/zitadel/internal/api/grpc/authn"
 	"github.com/zitadel/zitadel/internal/api/grpc/management"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/static"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
 	management_pb "github.com/zitadel/zitadel/pkg/grpc/management"
@@ -103,6 +107,10 @@ func (s *Server) ImportData(ctx context.Context, req *admin_pb.ImportDataRequest
 
 		go func() {
 			orgs := make([]*admin_pb.DataOrg, 0)
+			if err := checkDataVersion(req.GetDataOrgs().GetVersion()); err != nil {
+				ch <- importResponse{ret: nil, err: err}
+				return
+			}
 			if req.GetDataOrgsv1() != nil {
 				dataOrgs, err := s.dataOrgsV1ToDataOrgs(ctx, req.GetDataOrgsv1())
 				if err != nil {
@@ -111,10 +119,11 @@ func (s *Server) ImportData(ctx context.Context, req *admin_pb.ImportDataRequest
 				}
 				orgs = dataOrgs.GetOrgs()
 			} else {
-				orgs = req.GetDataOrgs().GetOrgs()
+				// the chunks of a streamed export are merged per org
+				orgs = mergeDataOrgs(req.GetDataOrgs().GetOrgs())
 			}
 
-			ret, count, err := s.importData(ctx, orgs)
+			ret, count, err := s.importData(ctx, orgs, importOptionsFromRequest(req))
 			ch <- importResponse{ret: ret, count: count, err: err}
 		}()
 
@@ -169,12 +178,8 @@ func (s *Server) ImportData(ctx context.Context, req *admin_pb.ImportDataRequest
 					ch <- importResponse{nil, nil, err}
 					return
 				}
-				resp, count, err := s.importData(ctxTimeout, dataOrgs)
-				if err != nil {
-					ch <- importResponse{nil, count, err}
-					return
-				}
-				ch <- importResponse{resp, count, nil}
+				resp, count, err := s.importData(ctxTimeout, dataOrgs, importOptionsFromRequest(req))
+				ch <- importResponse{resp, count, err}
 			}()
 
 			select {
@@ -183,6 +188,13 @@ func (s *Server) ImportData(ctx context.Context, req *admin_pb.ImportDataRequest
 				return
 			case result := <-ch:
 				logging.OnError(result.err).Errorf("error while importing: %v", err)
+				// the response of imports from files is not returned to the caller, so the failed objects are logged
+				for _, importErr := range result.ret.GetErrors() {
+					logging.WithFields("type", importErr.GetType(), "id", importErr.GetId()).Warn(importErr.GetMessage())
+				}
+				if skipped := len(result.ret.GetSkipped()); skipped > 0 {
+					logging.Infof("Import skipped %d objects imported by a previous run", skipped)
+				}
 				if result.count != nil {
 					logging.Infof("Import done: %s", result.count.getProgress())
 				}
@@ -241,7 +253,11 @@ func (s *Server) transportDataFromFile(ctx context.Context, v1Transformation boo
 		if err := jsonpb.Unmarshal(data, dataImport); err != nil {
 			return nil, err
 		}
-		dataOrgs = dataImport.Orgs
+		if err := checkDataVersion(dataImport.GetVersion()); err != nil {
+			return nil, err
+		}
+		// the chunks of a streamed export are merged per org
+		dataOrgs = mergeDataOrgs(dataImport.Orgs)
 	}
 
 	return dataOrgs, nil
@@ -293,7 +309,56 @@ func getFileFromGCS(ctx context.Context, input *admin_pb.ImportDataRequest_GCSIn
 	return ioutil.ReadAll(reader)
 }
 
-func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*admin_pb.ImportDataResponse, *count, error) {
+// checkDataVersion ensures the data was exported in a format this version can import,
+// data exported before the format was versioned has version 0
+func checkDataVersion(version uint32) error {
+	if version > dataVersion {
+		return caos_errors.ThrowInvalidArgument(nil, "IMPORT-Mee0u", "Errors.Invalid.Argument")
+	}
+	return nil
+}
+
+type importOptions struct {
+	skipExisting     bool
+	otpEncryptionKey string
+	importID         string
+}
+
+func importOptionsFromRequest(req *admin_pb.ImportDataRequest) importOptions {
+	return importOptions{
+		skipExisting:     req.GetSkipExisting(),
+		otpEncryptionKey: req.GetOtpEncryptionKey(),
+		importID:         req.GetImportId(),
+	}
+}
+
+// importOTPCode returns the otp secret of the user,
+// secrets exported encrypted are decrypted with the otpEncryptionKey of the import
+func importOTPCode(user *v1_pb.DataHumanUser, otpEncryptionKey string) (string, error) {
+	if user.GetEncryptedOtpCode() == "" {
+		return user.GetUser().GetOtpCode(), nil
+	}
+	if err := checkOTPEncryptionKey(otpEncryptionKey); err != nil {
+		return "", err
+	}
+	code, err := crypto.DecryptAESGCMString(user.GetEncryptedOtpCode(), otpEncryptionKey)
+	if err != nil {
+		return "", caos_errors.ThrowInvalidArgument(err, "IMPORT-Vee4a", "Errors.DataImport.OTPSecretInvalid")
+	}
+	return code, nil
+}
+
+// appendImportError adds the error of the object to the import errors,
+// if skipExisting is set objects which already exist (e.g. from a previous import) are skipped silently
+func appendImportError(errors []*admin_pb.ImportDataError, skipExisting bool, objectType, id string, err error) []*admin_pb.ImportDataError {
+	if skipExisting && caos_errors.IsErrorAlreadyExists(err) {
+		logging.WithFields("type", objectType, "id", id).Debug("skip existing object")
+		return errors
+	}
+	return append(errors, &admin_pb.ImportDataError{Type: objectType, Id: id, Message: err.Error()})
+}
+
+func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg, opts importOptions) (*admin_pb.ImportDataResponse, *count, error) {
 	errors := make([]*admin_pb.ImportDataError, 0)
 	success := &admin_pb.ImportDataSuccess{}
 	count := &count{}
@@ -318,6 +383,10 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 	if err != nil {
 		return nil, nil, err
 	}
+	checkpoint, err := loadImportCheckpoint(ctx, s.dbClient, opts.importID)
+	if err != nil {
+		return nil, nil, err
+	}
 
 	ctxData := authz.GetCtxData(ctx)
 	for _, org := range orgs {
@@ -340,12 +409,17 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 	}
 
 	for _, org := range orgs {
-		_, err := s.command.AddOrgWithID(ctx, org.GetOrg().GetName(), ctxData.UserID, ctxData.ResourceOwner, org.GetOrgId(), []string{})
-		if err != nil {
-			errors = append(errors, &admin_pb.ImportDataError{Type: "org", Id: org.GetOrgId(), Message: err.Error()})
+		// the objects of an org imported by a previous run are checked separately
+		if !checkpoint.isImported("org", org.GetOrgId()) {
+			_, err := s.command.AddOrgWithID(ctx, org.GetOrg().GetName(), ctxData.UserID, ctxData.ResourceOwner, org.GetOrgId(), []string{})
+			if err != nil {
+				errors = appendImportError(errors, opts.skipExisting, "org", org.GetOrgId(), err)
 
-			if _, err := s.query.OrgByID(ctx, true, org.OrgId); err != nil {
-				continue
+				if _, err := s.query.OrgByID(ctx, true, org.OrgId); err != nil {
+					continue
+				}
+			} else {
+				checkpoint.add(ctx, "org", org.GetOrgId())
 			}
 		}
 		successOrg := &admin_pb.ImportDataSuccessOrg{
@@ -369,7 +443,7 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 		if org.DomainPolicy != nil {
 			_, err := s.command.AddOrgDomainPolicy(ctx, org.GetOrgId(), domainPolicy.UserLoginMustBeDomain, domainPolicy.ValidateOrgDomains, domainPolicy.SmtpSenderAddressMatchesInstanceDomain)
 			if err != nil {
-				errors = append(errors, &admin_pb.ImportDataError{Type: "domain_policy", Id: org.GetOrgId(), Message: err.Error()})
+				errors = appendImportError(errors, opts.skipExisting, "domain_policy", org.GetOrgId(), err)
 			}
 		}
 		if org.Domains != nil {
@@ -384,9 +458,9 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 				}
 				_, err := s.command.AddOrgDomain(ctx, org.GetOrgId(), domainR.DomainName, []string{})
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "domain", Id: org.GetOrgId() + "_" + domainR.DomainName, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "domain", org.GetOrgId()+"_"+domainR.DomainName, err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
@@ -395,12 +469,12 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 
 				if domainR.IsVerified {
 					if _, err := s.command.VerifyOrgDomain(ctx, org.GetOrgId(), domainR.DomainName); err != nil {
-						errors = append(errors, &admin_pb.ImportDataError{Type: "domain_isverified", Id: org.GetOrgId() + "_" + domainR.DomainName, Message: err.Error()})
+						errors = appendImportError(errors, opts.skipExisting, "domain_isverified", org.GetOrgId()+"_"+domainR.DomainName, err)
 					}
 				}
 				if domainR.IsPrimary {
 					if _, err := s.command.SetPrimaryOrgDomain(ctx, orgDomain); err != nil {
-						errors = append(errors, &admin_pb.ImportDataError{Type: "domain_isprimary", Id: org.GetOrgId() + "_" + domainR.DomainName, Message: err.Error()})
+						errors = appendImportError(errors, opts.skipExisting, "domain_isprimary", org.GetOrgId()+"_"+domainR.DomainName, err)
 					}
 				}
 			}
@@ -408,16 +482,16 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 		if org.LabelPolicy != nil {
 			_, err = s.command.AddLabelPolicy(ctx, org.GetOrgId(), management.AddLabelPolicyToDomain(org.GetLabelPolicy()))
 			if err != nil {
-				errors = append(errors, &admin_pb.ImportDataError{Type: "label_policy", Id: org.GetOrgId(), Message: err.Error()})
+				errors = appendImportError(errors, opts.skipExisting, "label_policy", org.GetOrgId(), err)
 				if isCtxTimeout(ctx) {
-					return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+					return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 				}
 			} else {
 				_, err = s.command.ActivateLabelPolicy(ctx, org.GetOrgId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "label_policy", Id: org.GetOrgId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "label_policy", org.GetOrgId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 				}
 			}
@@ -425,7 +499,7 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 		if org.LockoutPolicy != nil {
 			_, err = s.command.AddLockoutPolicy(ctx, org.GetOrgId(), management.AddLockoutPolicyToDomain(org.GetLockoutPolicy()))
 			if err != nil {
-				errors = append(errors, &admin_pb.ImportDataError{Type: "lockout_policy", Id: org.GetOrgId(), Message: err.Error()})
+				errors = appendImportError(errors, opts.skipExisting, "lockout_policy", org.GetOrgId(), err)
 			}
 		}
 		if org.OidcIdps != nil {
@@ -433,9 +507,9 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 				logging.Debugf("import oidcidp: %s", idp.IdpId)
 				_, err := s.command.ImportIDPConfig(ctx, management.AddOIDCIDPRequestToDomain(idp.Idp), idp.IdpId, org.GetOrgId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "oidc_idp", Id: idp.IdpId, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "oidc_idp", idp.IdpId, err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
@@ -448,9 +522,9 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 				logging.Debugf("import jwtidp: %s", idp.IdpId)
 				_, err := s.command.ImportIDPConfig(ctx, management.AddJWTIDPRequestToDomain(idp.Idp), idp.IdpId, org.GetOrgId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "jwt_idp", Id: idp.IdpId, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "jwt_idp", idp.IdpId, err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
@@ -461,26 +535,26 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 		if org.LoginPolicy != nil {
 			_, err = s.command.AddLoginPolicy(ctx, org.GetOrgId(), management.AddLoginPolicyToCommand(org.GetLoginPolicy()))
 			if err != nil {
-				errors = append(errors, &admin_pb.ImportDataError{Type: "login_policy", Id: org.GetOrgId(), Message: err.Error()})
+				errors = appendImportError(errors, opts.skipExisting, "login_policy", org.GetOrgId(), err)
 			}
 		}
 		if org.PasswordComplexityPolicy != nil {
 			_, err = s.command.AddPasswordComplexityPolicy(ctx, org.GetOrgId(), management.AddPasswordComplexityPolicyToDomain(org.GetPasswordComplexityPolicy()))
 			if err != nil {
-				errors = append(errors, &admin_pb.ImportDataError{Type: "password_complexity_policy", Id: org.GetOrgId(), Message: err.Error()})
+				errors = appendImportError(errors, opts.skipExisting, "password_complexity_policy", org.GetOrgId(), err)
 			}
 		}
 		if org.PrivacyPolicy != nil {
 			_, err = s.command.AddPrivacyPolicy(ctx, org.GetOrgId(), management.AddPrivacyPolicyToDomain(org.GetPrivacyPolicy()))
 			if err != nil {
-				errors = append(errors, &admin_pb.ImportDataError{Type: "privacy_policy", Id: org.GetOrgId(), Message: err.Error()})
+				errors = appendImportError(errors, opts.skipExisting, "privacy_policy", org.GetOrgId(), err)
 			}
 		}
 		if org.LoginTexts != nil {
 			for _, text := range org.GetLoginTexts() {
 				_, err := s.command.SetOrgLoginText(ctx, org.GetOrgId(), management.SetLoginCustomTextToDomain(text))
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "login_texts", Id: org.GetOrgId() + "_" + text.Language, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "login_texts", org.GetOrgId()+"_"+text.Language, err)
 				}
 			}
 		}
@@ -488,7 +562,7 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 			for _, message := range org.GetInitMessages() {
 				_, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, management.SetInitCustomTextToDomain(message))
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "init_message", Id: org.GetOrgId() + "_" + message.Language, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "init_message", org.GetOrgId()+"_"+message.Language, err)
 				}
 			}
 		}
@@ -496,7 +570,7 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 			for _, message := range org.GetPasswordResetMessages() {
 				_, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, management.SetPasswordResetCustomTextToDomain(message))
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "password_reset_message", Id: org.GetOrgId() + "_" + message.Language, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "password_reset_message", org.GetOrgId()+"_"+message.Language, err)
 				}
 			}
 		}
@@ -504,7 +578,7 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 			for _, message := range org.GetVerifyEmailMessages() {
 				_, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, management.SetVerifyEmailCustomTextToDomain(message))
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "verify_email_message", Id: org.GetOrgId() + "_" + message.Language, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "verify_email_message", org.GetOrgId()+"_"+message.Language, err)
 				}
 			}
 		}
@@ -512,7 +586,7 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 			for _, message := range org.GetVerifyPhoneMessages() {
 				_, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, management.SetVerifyPhoneCustomTextToDomain(message))
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "verify_phone_message", Id: org.GetOrgId() + "_" + message.Language, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "verify_phone_message", org.GetOrgId()+"_"+message.Language, err)
 				}
 			}
 		}
@@ -520,7 +594,7 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 			for _, message := range org.GetDomainClaimedMessages() {
 				_, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, management.SetDomainClaimedCustomTextToDomain(message))
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "domain_claimed_message", Id: org.GetOrgId() + "_" + message.Language, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "domain_claimed_message", org.GetOrgId()+"_"+message.Language, err)
 				}
 			}
 		}
@@ -528,34 +602,58 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 			for _, message := range org.GetPasswordlessRegistrationMessages() {
 				_, err := s.command.SetOrgMessageText(ctx, authz.GetCtxData(ctx).OrgID, management.SetPasswordlessRegistrationCustomTextToDomain(message))
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "passwordless_registration_message", Id: org.GetOrgId() + "_" + message.Language, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "passwordless_registration_message", org.GetOrgId()+"_"+message.Language, err)
 				}
 			}
 		}
 
 		if org.HumanUsers != nil {
 			for _, user := range org.GetHumanUsers() {
+				if checkpoint.isImported("human_user", user.GetUserId()) {
+					continue
+				}
 				logging.Debugf("import user: %s", user.GetUserId())
 				human, passwordless, links := management.ImportHumanUserRequestToDomain(user.User)
 				human.AggregateID = user.UserId
 				_, _, err := s.command.ImportHuman(ctx, org.GetOrgId(), human, passwordless, links, initCodeGenerator, emailCodeGenerator, phoneCodeGenerator, passwordlessInitCode)
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "human_user", Id: user.GetUserId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "human_user", user.GetUserId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 				} else {
 					count.humanUserCount += 1
 					logging.Debugf("successful user %d: %s", count.humanUserCount, user.GetUserId())
 					successOrg.HumanUserIds = append(successOrg.HumanUserIds, user.GetUserId())
+					checkpoint.add(ctx, "human_user", user.GetUserId())
+
+					if len(user.GetAvatar()) > 0 {
+						logging.Debugf("import user avatar: %s", user.GetUserId())
+						if _, err := s.command.AddHumanAvatar(ctx, org.GetOrgId(), user.GetUserId(), &command.AssetUpload{
+							ResourceOwner: org.GetOrgId(),
+							ObjectName:    domain.GetHumanAvatarAssetPath(user.GetUserId()),
+							ContentType:   user.GetAvatarContentType(),
+							ObjectType:    static.ObjectTypeUserAvatar,
+							File:          bytes.NewReader(user.GetAvatar()),
+							Size:          int64(len(user.GetAvatar())),
+						}); err != nil {
+							errors = appendImportError(errors, opts.skipExisting, "human_user_avatar", user.GetUserId(), err)
+							if isCtxTimeout(ctx) {
+								return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
+							}
+						}
+					}
 				}
 
-				if user.User.OtpCode != "" {
+				otpCode, err := importOTPCode(user, opts.otpEncryptionKey)
+				if err != nil {
+					errors = appendImportError(errors, opts.skipExisting, "human_user_otp", user.GetUserId(), err)
+				} else if otpCode != "" {
 					logging.Debugf("import user otp: %s", user.GetUserId())
-					if err := s.command.ImportHumanTOTP(ctx, user.UserId, "", org.GetOrgId(), user.User.OtpCode); err != nil {
-						errors = append(errors, &admin_pb.ImportDataError{Type: "human_user_otp", Id: user.GetUserId(), Message: err.Error()})
+					if err := s.command.ImportHumanTOTP(ctx, user.UserId, "", org.GetOrgId(), otpCode); err != nil {
+						errors = appendImportError(errors, opts.skipExisting, "human_user_otp", user.GetUserId(), err)
 						if isCtxTimeout(ctx) {
-							return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+							return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 						}
 					} else {
 						logging.Debugf("successful user otp: %s", user.GetUserId())
@@ -565,38 +663,49 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 		}
 		if org.MachineUsers != nil {
 			for _, user := range org.GetMachineUsers() {
+				if checkpoint.isImported("machine_user", user.GetUserId()) {
+					continue
+				}
 				logging.Debugf("import user: %s", user.GetUserId())
 				_, err := s.command.AddMachine(ctx, management.AddMachineUserRequestToCommand(user.GetUser(), org.GetOrgId()))
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "machine_user", Id: user.GetUserId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "machine_user", user.GetUserId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.machineUserCount += 1
 				logging.Debugf("successful user %d: %s", count.machineUserCount, user.GetUserId())
 				successOrg.MachineUserIds = append(successOrg.MachineUserIds, user.GetUserId())
+				checkpoint.add(ctx, "machine_user", user.GetUserId())
 			}
 		}
 		if org.UserMetadata != nil {
 			for _, userMetadata := range org.GetUserMetadata() {
+				if checkpoint.isImported("user_metadata", userMetadata.GetId()+"_"+userMetadata.GetKey()) {
+					continue
+				}
 				logging.Debugf("import usermetadata: %s", userMetadata.GetId()+"_"+userMetadata.GetKey())
 				_, err := s.command.SetUserMetadata(ctx, &domain.Metadata{Key: userMetadata.GetKey(), Value: userMetadata.GetValue()}, userMetadata.GetId(), org.GetOrgId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "user_metadata", Id: userMetadata.GetId() + "_" + userMetadata.GetKey(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "user_metadata", userMetadata.GetId()+"_"+userMetadata.GetKey(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.userMetadataCount += 1
 				logging.Debugf("successful usermetadata %d: %s", count.userMetadataCount, userMetadata.GetId()+"_"+userMetadata.GetKey())
 				successOrg.UserMetadata = append(successOrg.UserMetadata, &admin_pb.ImportDataSuccessUserMetadata{UserId: userMetadata.GetId(), Key: userMetadata.GetKey()})
+				checkpoint.add(ctx, "user_metadata", userMetadata.GetId()+"_"+userMetadata.GetKey())
 			}
 		}
 		if org.MachineKeys != nil {
 			for _, key := range org.GetMachineKeys() {
+				if checkpoint.isImported("machine_user_key", key.KeyId) {
+					continue
+				}
 				logging.Debugf("import machine_user_key: %s", key.KeyId)
 				_, err := s.command.AddUserMachineKey(ctx, &command.MachineKey{
 					ObjectRoot: models.ObjectRoot{
@@ -609,19 +718,23 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 					PublicKey:      key.PublicKey,
 				})
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "machine_user_key", Id: key.KeyId, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "machine_user_key", key.KeyId, err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.machineKeysCount += 1
 				logging.Debugf("successful machine_user_key %d: %s", count.machineKeysCount, key.KeyId)
 				successOrg.MachineKeys = append(successOrg.MachineKeys, key.KeyId)
+				checkpoint.add(ctx, "machine_user_key", key.KeyId)
 			}
 		}
 		if org.UserLinks != nil {
 			for _, userLinks := range org.GetUserLinks() {
+				if checkpoint.isImported("user_link", userLinks.UserId+"_"+userLinks.IdpId) {
+					continue
+				}
 				logging.Debugf("import userlink: %s", userLinks.GetUserId()+"_"+userLinks.GetIdpId()+"_"+userLinks.GetProvidedUserId()+"_"+userLinks.GetProvidedUserName())
 				externalIDP := &command.AddLink{
 					IDPID:         userLinks.IdpId,
@@ -629,67 +742,83 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 					DisplayName:   userLinks.ProvidedUserName,
 				}
 				if _, err := s.command.AddUserIDPLink(ctx, userLinks.UserId, org.GetOrgId(), externalIDP); err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "user_link", Id: userLinks.UserId + "_" + userLinks.IdpId, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "user_link", userLinks.UserId+"_"+userLinks.IdpId, err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.userLinksCount += 1
 				logging.Debugf("successful userlink %d: %s", count.userLinksCount, userLinks.GetUserId()+"_"+userLinks.GetIdpId()+"_"+userLinks.GetProvidedUserId()+"_"+userLinks.GetProvidedUserName())
 				successOrg.UserLinks = append(successOrg.UserLinks, &admin_pb.ImportDataSuccessUserLinks{UserId: userLinks.GetUserId(), IdpId: userLinks.GetIdpId(), ExternalUserId: userLinks.GetProvidedUserId(), DisplayName: userLinks.GetProvidedUserName()})
+				checkpoint.add(ctx, "user_link", userLinks.UserId+"_"+userLinks.IdpId)
 			}
 		}
 		if org.Projects != nil {
 			for _, project := range org.GetProjects() {
+				if checkpoint.isImported("project", project.GetProjectId()) {
+					continue
+				}
 				logging.Debugf("import project: %s", project.GetProjectId())
 				_, err := s.command.AddProjectWithID(ctx, management.ProjectCreateToDomain(project.GetProject()), org.GetOrgId(), project.GetProjectId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "project", Id: project.GetProjectId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "project", project.GetProjectId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.projectCount += 1
 				logging.Debugf("successful project %d: %s", count.projectCount, project.GetProjectId())
 				successOrg.ProjectIds = append(successOrg.ProjectIds, project.GetProjectId())
+				checkpoint.add(ctx, "project", project.GetProjectId())
 			}
 		}
 		if org.OidcApps != nil {
 			for _, app := range org.GetOidcApps() {
+				if checkpoint.isImported("oidc_app", app.GetAppId()) {
+					continue
+				}
 				logging.Debugf("import oidcapplication: %s", app.GetAppId())
 				_, err := s.command.AddOIDCApplicationWithID(ctx, management.AddOIDCAppRequestToDomain(app.App), org.GetOrgId(), app.GetAppId(), appSecretGenerator)
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "oidc_app", Id: app.GetAppId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "oidc_app", app.GetAppId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.oidcAppCount += 1
 				logging.Debugf("successful oidcapplication %d: %s", count.oidcAppCount, app.GetAppId())
 				successOrg.OidcAppIds = append(successOrg.OidcAppIds, app.GetAppId())
+				checkpoint.add(ctx, "oidc_app", app.GetAppId())
 			}
 		}
 		if org.ApiApps != nil {
 			for _, app := range org.GetApiApps() {
+				if checkpoint.isImported("api_app", app.GetAppId()) {
+					continue
+				}
 				logging.Debugf("import apiapplication: %s", app.GetAppId())
 				_, err := s.command.AddAPIApplicationWithID(ctx, management.AddAPIAppRequestToDomain(app.GetApp()), org.GetOrgId(), app.GetAppId(), appSecretGenerator)
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "api_app", Id: app.GetAppId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "api_app", app.GetAppId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.apiAppCount += 1
 				logging.Debugf("successful apiapplication %d: %s", count.apiAppCount, app.GetAppId())
 				successOrg.ApiAppIds = append(successOrg.ApiAppIds, app.GetAppId())
+				checkpoint.add(ctx, "api_app", app.GetAppId())
 			}
 		}
 		if org.AppKeys != nil {
 			for _, key := range org.GetAppKeys() {
+				if checkpoint.isImported("app_key", key.Id) {
+					continue
+				}
 				logging.Debugf("import app_key: %s", key.Id)
 				_, err := s.command.AddApplicationKeyWithID(ctx, &domain.ApplicationKey{
 					ObjectRoot: models.ObjectRoot{
@@ -704,47 +833,56 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 					PublicKey:      key.PublicKey,
 				}, org.GetOrgId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "app_key", Id: key.Id, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "app_key", key.Id, err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.appKeysCount += 1
 				logging.Debugf("successful app_key %d: %s", count.appKeysCount, key.Id)
 				successOrg.AppKeys = append(successOrg.AppKeys, key.Id)
+				checkpoint.add(ctx, "app_key", key.Id)
 			}
 		}
 		if org.Actions != nil {
 			for _, action := range org.GetActions() {
+				if checkpoint.isImported("action", action.GetActionId()) {
+					continue
+				}
 				logging.Debugf("import action: %s", action.GetActionId())
 				_, _, err := s.command.AddActionWithID(ctx, management.CreateActionRequestToDomain(action.GetAction()), org.GetOrgId(), action.GetActionId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "action", Id: action.GetActionId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "action", action.GetActionId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.actionCount += 1
 				logging.Debugf("successful action %d: %s", count.actionCount, action.GetActionId())
 				successOrg.ActionIds = append(successOrg.ActionIds, action.ActionId)
+				checkpoint.add(ctx, "action", action.GetActionId())
 			}
 		}
 		if org.ProjectRoles != nil {
 			for _, role := range org.GetProjectRoles() {
+				if checkpoint.isImported("project_role", role.ProjectId+"_"+role.RoleKey) {
+					continue
+				}
 				logging.Debugf("import projectroles: %s", role.ProjectId+"_"+role.RoleKey)
 				_, err := s.command.AddProjectRole(ctx, management.AddProjectRoleRequestToDomain(role), org.GetOrgId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "project_role", Id: role.ProjectId + "_" + role.RoleKey, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "project_role", role.ProjectId+"_"+role.RoleKey, err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.projectRolesCount += 1
 				logging.Debugf("successful projectroles %d: %s", count.projectRolesCount, role.ProjectId+"_"+role.RoleKey)
 				successOrg.ProjectRoles = append(successOrg.ActionIds, role.ProjectId+"_"+role.RoleKey)
+				checkpoint.add(ctx, "project_role", role.ProjectId+"_"+role.RoleKey)
 			}
 		}
 	}
@@ -764,7 +902,7 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 			for _, triggerAction := range org.GetTriggerActions() {
 				_, err := s.command.SetTriggerActions(ctx, action_grpc.FlowTypeToDomain(triggerAction.FlowType), action_grpc.TriggerTypeToDomain(triggerAction.TriggerType), triggerAction.ActionIds, org.GetOrgId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "trigger_action", Id: triggerAction.FlowType + "_" + triggerAction.TriggerType, Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "trigger_action", triggerAction.FlowType+"_"+triggerAction.TriggerType, err)
 					continue
 				}
 				successOrg.TriggerActions = append(successOrg.TriggerActions, &management_pb.SetTriggerActionsRequest{FlowType: triggerAction.FlowType, TriggerType: triggerAction.TriggerType, ActionIds: triggerAction.GetActionIds()})
@@ -772,34 +910,42 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 		}
 		if org.ProjectGrants != nil {
 			for _, grant := range org.GetProjectGrants() {
+				if checkpoint.isImported("project_grant", org.GetOrgId()+"_"+grant.GetProjectGrant().GetProjectId()+"_"+grant.GetProjectGrant().GetGrantedOrgId()) {
+					continue
+				}
 				logging.Debugf("import projectgrant: %s", grant.GetGrantId()+"_"+grant.GetProjectGrant().GetProjectId()+"_"+grant.GetProjectGrant().GetGrantedOrgId())
 				_, err := s.command.AddProjectGrantWithID(ctx, management.AddProjectGrantRequestToDomain(grant.GetProjectGrant()), grant.GetGrantId(), org.GetOrgId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "project_grant", Id: org.GetOrgId() + "_" + grant.GetProjectGrant().GetProjectId() + "_" + grant.GetProjectGrant().GetGrantedOrgId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "project_grant", org.GetOrgId()+"_"+grant.GetProjectGrant().GetProjectId()+"_"+grant.GetProjectGrant().GetGrantedOrgId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.projectGrantCount += 1
 				logging.Debugf("successful projectgrant %d: %s", count.projectGrantCount, grant.GetGrantId()+"_"+grant.GetProjectGrant().GetProjectId()+"_"+grant.GetProjectGrant().GetGrantedOrgId())
 				successOrg.ProjectGrants = append(successOrg.ProjectGrants, &admin_pb.ImportDataSuccessProjectGrant{GrantId: grant.GetGrantId(), ProjectId: grant.GetProjectGrant().GetProjectId(), OrgId: grant.GetProjectGrant().GetGrantedOrgId()})
+				checkpoint.add(ctx, "project_grant", org.GetOrgId()+"_"+grant.GetProjectGrant().GetProjectId()+"_"+grant.GetProjectGrant().GetGrantedOrgId())
 			}
 		}
 		if org.UserGrants != nil {
 			for _, grant := range org.GetUserGrants() {
+				if checkpoint.isImported("user_grant", org.GetOrgId()+"_"+grant.GetProjectId()+"_"+grant.GetUserId()) {
+					continue
+				}
 				logging.Debugf("import usergrant: %s", grant.GetProjectId()+"_"+grant.GetUserId())
 				_, err := s.command.AddUserGrant(ctx, management.AddUserGrantRequestToDomain(grant), org.GetOrgId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "user_grant", Id: org.GetOrgId() + "_" + grant.GetProjectId() + "_" + grant.GetUserId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "user_grant", org.GetOrgId()+"_"+grant.GetProjectId()+"_"+grant.GetUserId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.userGrantCount += 1
 				logging.Debugf("successful usergrant %d: %s", count.userGrantCount, grant.GetProjectId()+"_"+grant.GetUserId())
 				successOrg.UserGrants = append(successOrg.UserGrants, &admin_pb.ImportDataSuccessUserGrant{ProjectId: grant.GetProjectId(), UserId: grant.GetUserId()})
+				checkpoint.add(ctx, "user_grant", org.GetOrgId()+"_"+grant.GetProjectId()+"_"+grant.GetUserId())
 			}
 		}
 	}
@@ -817,57 +963,73 @@ func (s *Server) importData(ctx context.Context, orgs []*admin_pb.DataOrg) (*adm
 
 		if org.OrgMembers != nil {
 			for _, member := range org.GetOrgMembers() {
+				if checkpoint.isImported("org_member", org.GetOrgId()+"_"+member.GetUserId()) {
+					continue
+				}
 				logging.Debugf("import orgmember: %s", member.GetUserId())
 				_, err := s.command.AddOrgMember(ctx, org.GetOrgId(), member.GetUserId(), member.GetRoles()...)
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "org_member", Id: org.GetOrgId() + "_" + member.GetUserId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "org_member", org.GetOrgId()+"_"+member.GetUserId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.orgMemberCount += 1
 				logging.Debugf("successful orgmember %d: %s", count.orgMemberCount, member.GetUserId())
 				successOrg.OrgMembers = append(successOrg.OrgMembers, member.GetUserId())
+				checkpoint.add(ctx, "org_member", org.GetOrgId()+"_"+member.GetUserId())
 			}
 		}
 		if org.ProjectGrantMembers != nil {
 			for _, member := range org.GetProjectGrantMembers() {
+				if checkpoint.isImported("project_grant_member", org.GetOrgId()+"_"+member.GetProjectId()+"_"+member.GetGrantId()+"_"+member.GetUserId()) {
+					continue
+				}
 				logging.Debugf("import projectgrantmember: %s", member.GetProjectId()+"_"+member.GetGrantId()+"_"+member.GetUserId())
 				_, err := s.command.AddProjectGrantMember(ctx, management.AddProjectGrantMemberRequestToDomain(member))
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "project_grant_member", Id: org.GetOrgId() + "_" + member.GetProjectId() + "_" + member.GetGrantId() + "_" + member.GetUserId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "project_grant_member", org.GetOrgId()+"_"+member.GetProjectId()+"_"+member.GetGrantId()+"_"+member.GetUserId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.projectGrantMemberCount += 1
 				logging.Debugf("successful projectgrantmember %d: %s", count.projectGrantMemberCount, member.GetProjectId()+"_"+member.GetGrantId()+"_"+member.GetUserId())
 				successOrg.ProjectGrantMembers = append(successOrg.ProjectGrantMembers, &admin_pb.ImportDataSuccessProjectGrantMember{ProjectId: member.GetProjectId(), GrantId: member.GetGrantId(), UserId: member.GetUserId()})
+				checkpoint.add(ctx, "project_grant_member", org.GetOrgId()+"_"+member.GetProjectId()+"_"+member.GetGrantId()+"_"+member.GetUserId())
 			}
 		}
 		if org.ProjectMembers != nil {
 			for _, member := range org.GetProjectMembers() {
+				if checkpoint.isImported("project_member", org.GetOrgId()+"_"+member.GetProjectId()+"_"+member.GetUserId()) {
+					continue
+				}
 				logging.Debugf("import orgmember: %s", member.GetProjectId()+"_"+member.GetUserId())
 				_, err := s.command.AddProjectMember(ctx, management.AddProjectMemberRequestToDomain(member), org.GetOrgId())
 				if err != nil {
-					errors = append(errors, &admin_pb.ImportDataError{Type: "project_member", Id: org.GetOrgId() + "_" + member.GetProjectId() + "_" + member.GetUserId(), Message: err.Error()})
+					errors = appendImportError(errors, opts.skipExisting, "project_member", org.GetOrgId()+"_"+member.GetProjectId()+"_"+member.GetUserId(), err)
 					if isCtxTimeout(ctx) {
-						return &admin_pb.ImportDataResponse{Errors: errors, Success: success}, count, err
+						return &admin_pb.ImportDataResponse{Errors: errors, Success: success, Skipped: checkpoint.skipped}, count, err
 					}
 					continue
 				}
 				count.projectMembersCount += 1
 				logging.Debugf("successful orgmember %d: %s", count.projectMembersCount, member.GetProjectId()+"_"+member.GetUserId())
 				successOrg.ProjectMembers = append(successOrg.ProjectMembers, &admin_pb.ImportDataSuccessProjectMember{ProjectId: member.GetProjectId(), UserId: member.GetUserId()})
+				checkpoint.add(ctx, "project_member", org.GetOrgId()+"_"+member.GetProjectId()+"_"+member.GetUserId())
 			}
 		}
 	}
 
+	if len(errors) == 0 {
+		checkpoint.done(ctx)
+	}
 	return &admin_pb.ImportDataResponse{
 		Errors:  errors,
 		Success: success,
+		Skipped: checkpoint.skipped,
 	}, count, nil
 }
 
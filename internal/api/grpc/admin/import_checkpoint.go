@@ -0,0 +1,179 @@
+package admin
+
+import (
+	"context"
+	"database/sql"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+const (
+	importCheckpointTable       = "adminapi.import_checkpoints"
+	importCheckpointInstanceCol = "instance_id"
+	importCheckpointImportIDCol = "import_id"
+	importCheckpointTypeCol     = "object_type"
+	importCheckpointObjectCol   = "object_id"
+	importCheckpointCreationCol = "creation_date"
+
+	importCheckpointCleanupInterval = time.Hour
+)
+
+// importCheckpoint persists the objects imported under the id of an import,
+// so that a failed import can be resumed with the same id and continues after the last imported object
+type importCheckpoint struct {
+	dbClient   *database.DB
+	instanceID string
+	importID   string
+	imported   map[string]struct{}
+	// skipped are the objects of the current run, which were imported by a previous run
+	skipped []*admin_pb.ImportDataSkipped
+}
+
+// loadImportCheckpoint returns the checkpoint of a previous run of the import,
+// without an importID nothing is persisted and all objects are imported
+func loadImportCheckpoint(ctx context.Context, dbClient *database.DB, importID string) (*importCheckpoint, error) {
+	checkpoint := &importCheckpoint{
+		dbClient:   dbClient,
+		instanceID: authz.GetInstance(ctx).InstanceID(),
+		importID:   importID,
+		imported:   make(map[string]struct{}),
+	}
+	if importID == "" {
+		return checkpoint, nil
+	}
+
+	stmt, args, err := sq.Select(importCheckpointTypeCol, importCheckpointObjectCol).
+		From(importCheckpointTable).
+		Where(sq.Eq{
+			importCheckpointInstanceCol: checkpoint.instanceID,
+			importCheckpointImportIDCol: importID,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "IMPORT-Sho8e", "Errors.Query.SQLStatement")
+	}
+	err = dbClient.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var objectType, objectID string
+			if err := rows.Scan(&objectType, &objectID); err != nil {
+				return err
+			}
+			checkpoint.imported[checkpointKey(objectType, objectID)] = struct{}{}
+		}
+		return rows.Err()
+	}, stmt, args...)
+	if err != nil {
+		return nil, caos_errors.ThrowInternal(err, "IMPORT-ooC5i", "Errors.Internal")
+	}
+	return checkpoint, nil
+}
+
+// isImported returns if the object was already imported by a previous run of the import,
+// the object is then reported as skipped
+func (c *importCheckpoint) isImported(objectType, objectID string) bool {
+	_, ok := c.imported[checkpointKey(objectType, objectID)]
+	if ok {
+		logging.WithFields("type", objectType, "id", objectID).Debug("skip object imported by previous run")
+		c.skipped = append(c.skipped, &admin_pb.ImportDataSkipped{Type: objectType, Id: objectID})
+	}
+	return ok
+}
+
+// add persists the imported object,
+// a failure is only logged as the object is then reported as existing when the import is resumed
+func (c *importCheckpoint) add(ctx context.Context, objectType, objectID string) {
+	if c.importID == "" {
+		return
+	}
+	stmt, args, err := sq.Insert(importCheckpointTable).
+		Columns(
+			importCheckpointInstanceCol,
+			importCheckpointImportIDCol,
+			importCheckpointTypeCol,
+			importCheckpointObjectCol,
+		).
+		Values(c.instanceID, c.importID, objectType, objectID).
+		Suffix("ON CONFLICT DO NOTHING").
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err == nil {
+		_, err = c.dbClient.ExecContext(ctx, stmt, args...)
+	}
+	logging.WithFields("type", objectType, "id", objectID).OnError(err).Warn("unable to persist import checkpoint")
+	if err == nil {
+		c.imported[checkpointKey(objectType, objectID)] = struct{}{}
+	}
+}
+
+// done removes the checkpoint after the import finished without errors
+func (c *importCheckpoint) done(ctx context.Context) {
+	if c.importID == "" {
+		return
+	}
+	stmt, args, err := sq.Delete(importCheckpointTable).
+		Where(sq.Eq{
+			importCheckpointInstanceCol: c.instanceID,
+			importCheckpointImportIDCol: c.importID,
+		}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err == nil {
+		_, err = c.dbClient.ExecContext(ctx, stmt, args...)
+	}
+	logging.WithFields("import", c.importID).OnError(err).Warn("unable to remove import checkpoint")
+}
+
+// StartImportCheckpointCleanup periodically removes the checkpoints of all instances,
+// whose import wasn't resumed within the lifetime, 0 disables the removal
+func StartImportCheckpointCleanup(ctx context.Context, dbClient *database.DB, lifetime time.Duration) {
+	if lifetime == 0 {
+		return
+	}
+	go scheduleImportCheckpointCleanup(ctx, dbClient, lifetime)
+}
+
+func scheduleImportCheckpointCleanup(ctx context.Context, dbClient *database.DB, lifetime time.Duration) {
+	ticker := time.NewTicker(importCheckpointCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			logging.OnError(cleanupImportCheckpoints(ctx, dbClient, now.Add(-lifetime))).Warn("unable to remove expired import checkpoints")
+		}
+	}
+}
+
+// cleanupImportCheckpoints removes the checkpoints of the imports, which didn't import an object since before.
+// The checkpoint of an import is removed as a whole, so a resumed import never skips only a part of the imported objects.
+func cleanupImportCheckpoints(ctx context.Context, dbClient *database.DB, before time.Time) error {
+	stmt, args, err := sq.Delete(importCheckpointTable).
+		Where(
+			"("+importCheckpointInstanceCol+", "+importCheckpointImportIDCol+") IN ("+
+				"SELECT "+importCheckpointInstanceCol+", "+importCheckpointImportIDCol+
+				" FROM "+importCheckpointTable+
+				" GROUP BY "+importCheckpointInstanceCol+", "+importCheckpointImportIDCol+
+				" HAVING MAX("+importCheckpointCreationCol+") < ?)",
+			before,
+		).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = dbClient.ExecContext(ctx, stmt, args...)
+	return err
+}
+
+func checkpointKey(objectType, objectID string) string {
+	return objectType + ":" + objectID
+}
@@ -0,0 +1,71 @@
+package admin
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func Test_importCheckpoint(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(regexp.QuoteMeta("SELECT object_type, object_id FROM adminapi.import_checkpoints WHERE import_id = $1 AND instance_id = $2")).
+		WithArgs("import1", "instance1").
+		WillReturnRows(sqlmock.NewRows([]string{"object_type", "object_id"}).AddRow("human_user", "user1"))
+	mock.ExpectCommit()
+	mock.ExpectExec(regexp.QuoteMeta("INSERT INTO adminapi.import_checkpoints (instance_id,import_id,object_type,object_id) VALUES ($1,$2,$3,$4) ON CONFLICT DO NOTHING")).
+		WithArgs("instance1", "import1", "human_user", "user2").
+		WillReturnResult(sqlmock.NewResult(0, 1))
+
+	checkpoint, err := loadImportCheckpoint(ctx, &database.DB{DB: db}, "import1")
+	require.NoError(t, err)
+	assert.True(t, checkpoint.isImported("human_user", "user1"))
+	assert.False(t, checkpoint.isImported("machine_user", "user1"))
+	assert.False(t, checkpoint.isImported("human_user", "user2"))
+
+	checkpoint.add(ctx, "human_user", "user2")
+	assert.True(t, checkpoint.isImported("human_user", "user2"))
+	assert.Equal(t, []*admin_pb.ImportDataSkipped{
+		{Type: "human_user", Id: "user1"},
+		{Type: "human_user", Id: "user2"},
+	}, checkpoint.skipped)
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_importCheckpoint_withoutImportID(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+
+	checkpoint, err := loadImportCheckpoint(ctx, nil, "")
+	require.NoError(t, err)
+	checkpoint.add(ctx, "human_user", "user1")
+	assert.False(t, checkpoint.isImported("human_user", "user1"))
+	checkpoint.done(ctx)
+	assert.Empty(t, checkpoint.skipped)
+}
+
+func Test_cleanupImportCheckpoints(t *testing.T) {
+	db, mock, err := sqlmock.New()
+	require.NoError(t, err)
+	defer db.Close()
+	before := time.Now()
+
+	mock.ExpectExec(regexp.QuoteMeta("DELETE FROM adminapi.import_checkpoints WHERE (instance_id, import_id) IN (SELECT instance_id, import_id FROM adminapi.import_checkpoints GROUP BY instance_id, import_id HAVING MAX(creation_date) < $1)")).
+		WithArgs(before).
+		WillReturnResult(sqlmock.NewResult(0, 2))
+
+	assert.NoError(t, cleanupImportCheckpoints(context.Background(), &database.DB{DB: db}, before))
+	assert.NoError(t, mock.ExpectationsWereMet())
+}
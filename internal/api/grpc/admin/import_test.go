@@ -0,0 +1,110 @@
+package admin
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	caos_errors "github.com/zitadel/zitadel/internal/errors"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+	management_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+	v1_pb "github.com/zitadel/zitadel/pkg/grpc/v1"
+)
+
+func Test_appendImportError(t *testing.T) {
+	type args struct {
+		skipExisting bool
+		err          error
+	}
+	tests := []struct {
+		name string
+		args args
+		want []*admin_pb.ImportDataError
+	}{
+		{
+			name: "already exists, appended",
+			args: args{
+				err: caos_errors.ThrowAlreadyExists(nil, "id", "Errors.Already.Exists"),
+			},
+			want: []*admin_pb.ImportDataError{{Type: "human_user", Id: "user1", Message: "ID=id Message=Errors.Already.Exists"}},
+		},
+		{
+			name: "already exists, skipped",
+			args: args{
+				skipExisting: true,
+				err:          caos_errors.ThrowAlreadyExists(nil, "id", "Errors.Already.Exists"),
+			},
+			want: []*admin_pb.ImportDataError{},
+		},
+		{
+			name: "other error, appended",
+			args: args{
+				skipExisting: true,
+				err:          caos_errors.ThrowInternal(nil, "id", "Errors.Internal"),
+			},
+			want: []*admin_pb.ImportDataError{{Type: "human_user", Id: "user1", Message: "ID=id Message=Errors.Internal"}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := appendImportError([]*admin_pb.ImportDataError{}, tt.args.skipExisting, "human_user", "user1", tt.args.err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_checkDataVersion(t *testing.T) {
+	assert.NoError(t, checkDataVersion(0))
+	assert.NoError(t, checkDataVersion(dataVersion))
+	assert.True(t, caos_errors.IsErrorInvalidArgument(checkDataVersion(dataVersion+1)))
+}
+
+func Test_importOTPCode(t *testing.T) {
+	key := "passphrasewhichneedstobe32bytes!"
+	encrypted, err := crypto.EncryptAESGCMString("secret", key)
+	assert.NoError(t, err)
+
+	tests := []struct {
+		name    string
+		user    *v1_pb.DataHumanUser
+		key     string
+		want    string
+		wantErr func(error) bool
+	}{
+		{
+			name: "plain code",
+			user: &v1_pb.DataHumanUser{User: &management_pb.ImportHumanUserRequest{OtpCode: "secret"}},
+			want: "secret",
+		},
+		{
+			name: "encrypted code",
+			user: &v1_pb.DataHumanUser{EncryptedOtpCode: encrypted},
+			key:  key,
+			want: "secret",
+		},
+		{
+			name:    "encrypted code, invalid key",
+			user:    &v1_pb.DataHumanUser{EncryptedOtpCode: encrypted},
+			key:     "tooshort",
+			wantErr: caos_errors.IsErrorInvalidArgument,
+		},
+		{
+			name:    "encrypted code, wrong key",
+			user:    &v1_pb.DataHumanUser{EncryptedOtpCode: encrypted},
+			key:     "anotherpassphrasewith32bytes!!!!",
+			wantErr: caos_errors.IsErrorInvalidArgument,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := importOTPCode(tt.user, tt.key)
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err))
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -13,7 +13,9 @@ import (
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/config/systemdefaults"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/static"
 	"github.com/zitadel/zitadel/pkg/grpc/admin"
 )
 
@@ -26,8 +28,10 @@ var _ admin.AdminServiceServer = (*Server)(nil)
 type Server struct {
 	admin.UnimplementedAdminServiceServer
 	database          string
+	dbClient          *database.DB
 	command           *command.Commands
 	query             *query.Queries
+	storage           static.Storage
 	assetsAPIDomain   func(context.Context) string
 	userCodeAlg       crypto.EncryptionAlgorithm
 	passwordHashAlg   crypto.HashAlgorithm
@@ -42,19 +46,23 @@ func CreateServer(
 	database string,
 	command *command.Commands,
 	query *query.Queries,
+	storage static.Storage,
 	sd systemdefaults.SystemDefaults,
 	externalSecure bool,
 	userCodeAlg crypto.EncryptionAlgorithm,
 	auditLogRetention time.Duration,
+	dbClient *database.DB,
 ) *Server {
 	return &Server{
 		database:          database,
 		command:           command,
 		query:             query,
+		storage:           storage,
 		assetsAPIDomain:   assets.AssetAPI(externalSecure),
 		userCodeAlg:       userCodeAlg,
 		passwordHashAlg:   crypto.NewBCrypt(sd.SecretGenerators.PasswordSaltCost),
 		auditLogRetention: auditLogRetention,
+		dbClient:          dbClient,
 	}
 }
 
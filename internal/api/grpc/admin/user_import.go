@@ -0,0 +1,96 @@
+package admin
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	user_grpc "github.com/zitadel/zitadel/internal/api/grpc/user"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+	admin_pb "github.com/zitadel/zitadel/pkg/grpc/admin"
+)
+
+func (s *Server) ImportHumanUsers(ctx context.Context, req *admin_pb.ImportHumanUsersRequest) (*admin_pb.ImportHumanUsersResponse, error) {
+	if _, err := s.query.OrgByID(ctx, true, req.OrgId); err != nil {
+		return nil, err
+	}
+	id, details, err := s.command.AddUserImport(ctx, importHumanUsersRequestToDomain(req), req.OrgId)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ImportHumanUsersResponse{
+		Id:      id,
+		Details: object.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetHumanUserImportByID(ctx context.Context, req *admin_pb.GetHumanUserImportByIDRequest) (*admin_pb.GetHumanUserImportByIDResponse, error) {
+	userImport, err := s.query.UserImportByID(ctx, true, req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.GetHumanUserImportByIDResponse{
+		UserImport: user_grpc.UserImportToPb(userImport),
+	}, nil
+}
+
+func (s *Server) ListHumanUserImports(ctx context.Context, req *admin_pb.ListHumanUserImportsRequest) (*admin_pb.ListHumanUserImportsResponse, error) {
+	res, err := s.query.SearchUserImports(ctx, listHumanUserImportsRequestToQuery(req))
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListHumanUserImportsResponse{
+		Result:  user_grpc.UserImportsToPb(res.Imports),
+		Details: object.ToListDetails(res.Count, res.Sequence, res.LastRun),
+	}, nil
+}
+
+func (s *Server) ListHumanUserImportRows(ctx context.Context, req *admin_pb.ListHumanUserImportRowsRequest) (*admin_pb.ListHumanUserImportRowsResponse, error) {
+	queries, err := listHumanUserImportRowsRequestToQuery(req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchUserImportRows(ctx, req.Id, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &admin_pb.ListHumanUserImportRowsResponse{
+		Result:  user_grpc.UserImportRowsToPb(res.Rows),
+		Details: object.ToListDetails(res.Count, res.Sequence, res.LastRun),
+	}, nil
+}
+
+func importHumanUsersRequestToDomain(req *admin_pb.ImportHumanUsersRequest) *domain.UserImport {
+	return &domain.UserImport{
+		Format: user_grpc.UserImportFormatToDomain(req.Format),
+		DryRun: req.DryRun,
+		Data:   req.Data,
+	}
+}
+
+func listHumanUserImportsRequestToQuery(req *admin_pb.ListHumanUserImportsRequest) *query.UserImportSearchQueries {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	return &query.UserImportSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+	}
+}
+
+func listHumanUserImportRowsRequestToQuery(req *admin_pb.ListHumanUserImportRowsRequest) (*query.UserImportRowSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := user_grpc.UserImportRowQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	return &query.UserImportRowSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
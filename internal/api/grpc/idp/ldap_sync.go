@@ -0,0 +1,122 @@
+package idp
+
+import (
+	"google.golang.org/protobuf/types/known/durationpb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	idp_pb "github.com/zitadel/zitadel/pkg/grpc/idp"
+)
+
+func LDAPSyncToDomain(id, groupAttribute string, mappings []*idp_pb.LDAPGroupMapping, syncEnabled bool, syncInterval *durationpb.Duration) *domain.LDAPSync {
+	return &domain.LDAPSync{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: id,
+		},
+		GroupAttribute: groupAttribute,
+		GroupMappings:  LDAPGroupMappingsToDomain(mappings),
+		SyncEnabled:    syncEnabled,
+		SyncInterval:   syncInterval.AsDuration(),
+	}
+}
+
+func LDAPGroupMappingsToDomain(mappings []*idp_pb.LDAPGroupMapping) []*domain.LDAPGroupMapping {
+	m := make([]*domain.LDAPGroupMapping, len(mappings))
+	for i, mapping := range mappings {
+		m[i] = &domain.LDAPGroupMapping{
+			Group:     mapping.Group,
+			ProjectID: mapping.ProjectId,
+			Roles:     mapping.Roles,
+		}
+	}
+	return m
+}
+
+func LDAPSyncToPb(sync *query.LDAPSync) *idp_pb.LDAPSync {
+	pb := &idp_pb.LDAPSync{
+		Details:        obj_grpc.ToViewDetailsPb(sync.Sequence, sync.CreationDate, sync.ChangeDate, sync.ResourceOwner),
+		GroupAttribute: sync.GroupAttribute,
+		GroupMappings:  ldapGroupMappingsToPb(sync.GroupMappings),
+		SyncEnabled:    sync.SyncEnabled,
+		SyncInterval:   durationpb.New(sync.SyncInterval),
+	}
+	if !sync.LastStarted.IsZero() {
+		pb.LastStarted = timestamppb.New(sync.LastStarted)
+	}
+	return pb
+}
+
+func ldapGroupMappingsToPb(mappings []*domain.LDAPGroupMapping) []*idp_pb.LDAPGroupMapping {
+	m := make([]*idp_pb.LDAPGroupMapping, len(mappings))
+	for i, mapping := range mappings {
+		m[i] = &idp_pb.LDAPGroupMapping{
+			Group:     mapping.Group,
+			ProjectId: mapping.ProjectID,
+			Roles:     mapping.Roles,
+		}
+	}
+	return m
+}
+
+func LDAPSyncReportsToPb(reports []*query.LDAPSyncReport) []*idp_pb.LDAPSyncReport {
+	r := make([]*idp_pb.LDAPSyncReport, len(reports))
+	for i, report := range reports {
+		r[i] = LDAPSyncReportToPb(report)
+	}
+	return r
+}
+
+func LDAPSyncReportToPb(report *query.LDAPSyncReport) *idp_pb.LDAPSyncReport {
+	return &idp_pb.LDAPSyncReport{
+		Details:          obj_grpc.ToViewDetailsPb(report.Sequence, report.Finished, report.Finished, report.ResourceOwner),
+		Started:          timestamppb.New(report.Started),
+		Finished:         timestamppb.New(report.Finished),
+		Succeeded:        report.Succeeded,
+		Error:            report.Error,
+		UsersFound:       report.UsersFound,
+		UsersCreated:     report.UsersCreated,
+		UsersUpdated:     report.UsersUpdated,
+		UsersDeactivated: report.UsersDeactivated,
+		UsersReactivated: report.UsersReactivated,
+		GrantsAdded:      report.GrantsAdded,
+		GrantsChanged:    report.GrantsChanged,
+		GrantsRemoved:    report.GrantsRemoved,
+		Failures:         ldapSyncFailuresToPb(report.Failures),
+	}
+}
+
+func ldapSyncFailuresToPb(failures []*domain.LDAPSyncFailure) []*idp_pb.LDAPSyncFailure {
+	f := make([]*idp_pb.LDAPSyncFailure, len(failures))
+	for i, failure := range failures {
+		f[i] = &idp_pb.LDAPSyncFailure{
+			ExternalUserId: failure.ExternalUserID,
+			UserId:         failure.UserID,
+			Error:          failure.Error,
+		}
+	}
+	return f
+}
+
+func LDAPSyncReportQueriesToModel(queries []*idp_pb.LDAPSyncReportQuery) (q []query.SearchQuery, err error) {
+	q = make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = LDAPSyncReportQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func LDAPSyncReportQueryToModel(reportQuery *idp_pb.LDAPSyncReportQuery) (query.SearchQuery, error) {
+	switch q := reportQuery.Query.(type) {
+	case *idp_pb.LDAPSyncReportQuery_SucceededQuery:
+		return query.NewLDAPSyncReportSucceededSearchQuery(q.SucceededQuery.Succeeded)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "IDP-Oow1e", "List.Query.Invalid")
+	}
+}
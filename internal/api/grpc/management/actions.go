@@ -2,10 +2,18 @@ package management
 
 import (
 	"context"
+	"time"
 
+	"github.com/dop251/goja"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/actions/object"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	obj_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
 	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
 )
 
@@ -35,7 +43,8 @@ func (s *Server) GetAction(ctx context.Context, req *mgmt_pb.GetActionRequest) (
 }
 
 func (s *Server) CreateAction(ctx context.Context, req *mgmt_pb.CreateActionRequest) (*mgmt_pb.CreateActionResponse, error) {
-	id, details, err := s.command.AddAction(ctx, CreateActionRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	action := CreateActionRequestToDomain(req)
+	id, details, err := s.command.AddAction(ctx, action, authz.GetCtxData(ctx).OrgID)
 	if err != nil {
 		return nil, err
 	}
@@ -46,6 +55,7 @@ func (s *Server) CreateAction(ctx context.Context, req *mgmt_pb.CreateActionRequ
 			details.EventDate,
 			details.ResourceOwner,
 		),
+		SigningKey: action.SigningKey,
 	}, nil
 }
 
@@ -63,6 +73,152 @@ func (s *Server) UpdateAction(ctx context.Context, req *mgmt_pb.UpdateActionRequ
 	}, nil
 }
 
+func (s *Server) RegenerateActionSigningKey(ctx context.Context, req *mgmt_pb.RegenerateActionSigningKeyRequest) (*mgmt_pb.RegenerateActionSigningKeyResponse, error) {
+	signingKey, details, err := s.command.RegenerateActionSigningKey(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RegenerateActionSigningKeyResponse{
+		Details: obj_grpc.ChangeToDetailsPb(
+			details.Sequence,
+			details.EventDate,
+			details.ResourceOwner,
+		),
+		SigningKey: signingKey,
+	}, nil
+}
+
+func (s *Server) ListActionExecutions(ctx context.Context, req *mgmt_pb.ListActionExecutionsRequest) (*mgmt_pb.ListActionExecutionsResponse, error) {
+	queries, err := listActionExecutionsToQuery(authz.GetCtxData(ctx).OrgID, req)
+	if err != nil {
+		return nil, err
+	}
+	executions, err := s.query.SearchActionExecutions(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListActionExecutionsResponse{
+		// the executions are written directly by the log storage, there is no processed sequence
+		Details: obj_grpc.ToListDetails(executions.Count, 0, time.Now()),
+		Result:  action_grpc.ActionExecutionsToPb(executions.Executions),
+	}, nil
+}
+
+func (s *Server) GetActionExecution(ctx context.Context, req *mgmt_pb.GetActionExecutionRequest) (*mgmt_pb.GetActionExecutionResponse, error) {
+	execution, err := s.query.ActionExecutionByID(ctx, req.ExecutionId, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetActionExecutionResponse{
+		Execution: action_grpc.ActionExecutionToPb(execution),
+	}, nil
+}
+
+func (s *Server) TestAction(ctx context.Context, req *mgmt_pb.TestActionRequest) (*mgmt_pb.TestActionResponse, error) {
+	action, err := s.testedAction(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	ctxFields, err := s.testActionContextFields(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+
+	actionCtx, cancel := context.WithTimeout(ctx, action.timeout)
+	defer cancel()
+	result := new(actions.DryRun)
+	runErr := actions.Run(
+		actionCtx,
+		actions.SetContextFields(ctxFields...),
+		nil,
+		action.script,
+		action.name,
+		actions.WithDryRun(result),
+		actions.WithTrigger(action_grpc.FlowTypeToDomain(req.FlowType), action_grpc.TriggerTypeToDomain(req.TriggerType)),
+		actions.WithHTTP(actionCtx),
+		actions.WithHTTPMocks(action_grpc.ActionHTTPMocksToModel(req.HttpMocks)...),
+		actions.WithUUID(actionCtx),
+	)
+	return testActionResultToPb(result, runErr)
+}
+
+type testedAction struct {
+	name    string
+	script  string
+	timeout time.Duration
+}
+
+func (s *Server) testedAction(ctx context.Context, req *mgmt_pb.TestActionRequest) (*testedAction, error) {
+	if script := req.GetScript(); script != nil {
+		timeout := script.Timeout.AsDuration()
+		if timeout == 0 {
+			// the default timeout of actions
+			timeout = new(query.Action).Timeout()
+		}
+		return &testedAction{name: script.Name, script: script.Script, timeout: timeout}, nil
+	}
+	action, err := s.query.GetActionByID(ctx, req.GetActionId(), authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	// the signing key of a target is only used for the execution of a trigger
+	if action.Type != domain.ActionTypeScript {
+		return nil, errors.ThrowPreconditionFailed(nil, "MANAG-Eij4u", "Errors.Action.NotScript")
+	}
+	return &testedAction{name: action.Name, script: action.Script, timeout: action.Timeout()}, nil
+}
+
+// testActionContextFields returns the supplied context
+// or samples the context from the calling user in the form of the user related flows
+func (s *Server) testActionContextFields(ctx context.Context, req *mgmt_pb.TestActionRequest) ([]actions.FieldOption, error) {
+	if req.Context != nil {
+		supplied := req.Context.AsMap()
+		fields := make([]actions.FieldOption, 0, len(supplied))
+		for key, value := range supplied {
+			fields = append(fields, actions.SetFields(key, value))
+		}
+		return fields, nil
+	}
+	ctxData := authz.GetCtxData(ctx)
+	user, err := s.query.GetUserByID(ctx, true, ctxData.UserID)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := s.query.SearchUserMetadata(ctx, true, user.ID, new(query.UserMetadataSearchQueries), false)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(user.ID)
+	if err != nil {
+		return nil, err
+	}
+	userGrants, err := s.query.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return []actions.FieldOption{
+		actions.SetFields("v1",
+			actions.SetFields("orgId", ctxData.OrgID),
+			actions.SetFields("userId", user.ID),
+			actions.SetFields("getUser", func(c *actions.FieldConfig) interface{} {
+				return func(goja.FunctionCall) goja.Value {
+					return object.UserFromQuery(c, user)
+				}
+			}),
+			actions.SetFields("user",
+				actions.SetFields("getMetadata", func(c *actions.FieldConfig) interface{} {
+					return func(goja.FunctionCall) goja.Value {
+						return object.UserMetadataListFromQuery(c, metadata)
+					}
+				}),
+				actions.SetFields("grants", func(c *actions.FieldConfig) interface{} {
+					return object.UserGrantsFromQuery(c, userGrants)
+				}),
+			),
+		),
+	}, nil
+}
+
 func (s *Server) DeactivateAction(ctx context.Context, req *mgmt_pb.DeactivateActionRequest) (*mgmt_pb.DeactivateActionResponse, error) {
 	details, err := s.command.DeactivateAction(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
 	return &mgmt_pb.DeactivateActionResponse{
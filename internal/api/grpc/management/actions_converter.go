@@ -1,26 +1,32 @@
 package management
 
 import (
+	"google.golang.org/protobuf/types/known/durationpb"
+
+	"github.com/zitadel/zitadel/internal/actions"
 	action_grpc "github.com/zitadel/zitadel/internal/api/grpc/action"
 	"github.com/zitadel/zitadel/internal/api/grpc/object"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
 	"github.com/zitadel/zitadel/internal/query"
+	action_pb "github.com/zitadel/zitadel/pkg/grpc/action"
 	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
 )
 
 func CreateActionRequestToDomain(req *mgmt_pb.CreateActionRequest) *domain.Action {
-	return &domain.Action{
+	action := &domain.Action{
 		Name:          req.Name,
 		Script:        req.Script,
 		Timeout:       req.Timeout.AsDuration(),
 		AllowedToFail: req.AllowedToFail,
 	}
+	actionTargetToDomain(action, req.Target)
+	return action
 }
 
 func updateActionRequestToDomain(req *mgmt_pb.UpdateActionRequest) *domain.Action {
-	return &domain.Action{
+	action := &domain.Action{
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.Id,
 		},
@@ -29,6 +35,17 @@ func updateActionRequestToDomain(req *mgmt_pb.UpdateActionRequest) *domain.Actio
 		Timeout:       req.Timeout.AsDuration(),
 		AllowedToFail: req.AllowedToFail,
 	}
+	actionTargetToDomain(action, req.Target)
+	return action
+}
+
+func actionTargetToDomain(action *domain.Action, target *action_pb.ActionTarget) {
+	if target == nil {
+		return
+	}
+	action.Type = domain.ActionTypeTarget
+	action.TargetURL = target.Url
+	action.MaxRetries = target.MaxRetries
 }
 
 func listActionsToQuery(orgID string, req *mgmt_pb.ListActionsRequest) (_ *query.ActionSearchQueries, err error) {
@@ -54,6 +71,47 @@ func listActionsToQuery(orgID string, req *mgmt_pb.ListActionsRequest) (_ *query
 	}, nil
 }
 
+func listActionExecutionsToQuery(orgID string, req *mgmt_pb.ListActionExecutionsRequest) (*query.ActionExecutionSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := action_grpc.ActionExecutionQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	resourceOwner, err := query.NewActionExecutionResourceOwnerSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	actionID, err := query.NewActionExecutionActionIDSearchQuery(req.Id)
+	if err != nil {
+		return nil, err
+	}
+	return &query.ActionExecutionSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, resourceOwner, actionID),
+	}, nil
+}
+
+func testActionResultToPb(result *actions.DryRun, runErr error) (*mgmt_pb.TestActionResponse, error) {
+	mutations, err := action_grpc.ActionMutationsToPb(result.Mutations)
+	if err != nil {
+		return nil, err
+	}
+	res := &mgmt_pb.TestActionResponse{
+		Succeeded: runErr == nil,
+		Took:      durationpb.New(result.Took),
+		Mutations: mutations,
+		Logs:      action_grpc.ActionExecutionLogsToPb(result.Logs),
+	}
+	if runErr != nil {
+		res.Error = runErr.Error()
+	}
+	return res, nil
+}
+
 func ActionQueryToQuery(query interface{}) (query.SearchQuery, error) {
 	switch q := query.(type) {
 	case *mgmt_pb.ActionQuery_ActionNameQuery:
@@ -19,6 +19,8 @@ func (s *Server) ListFlowTypes(ctx context.Context, _ *mgmt_pb.ListFlowTypesRequ
 			action_grpc.FlowTypeToPb(domain.FlowTypeCustomiseToken),
 			action_grpc.FlowTypeToPb(domain.FlowTypeInternalAuthentication),
 			action_grpc.FlowTypeToPb(domain.FlowTypeCustomizeSAMLResponse),
+			action_grpc.FlowTypeToPb(domain.FlowTypeAPIRequest),
+			action_grpc.FlowTypeToPb(domain.FlowTypeEvent),
 		},
 	}, nil
 }
@@ -397,6 +397,55 @@ func (s *Server) UpdateLDAPProvider(ctx context.Context, req *mgmt_pb.UpdateLDAP
 	}, nil
 }
 
+func (s *Server) GetLDAPSync(ctx context.Context, req *mgmt_pb.GetLDAPSyncRequest) (*mgmt_pb.GetLDAPSyncResponse, error) {
+	resourceOwner, err := query.NewLDAPSyncResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	sync, err := s.query.LDAPSyncByIDPID(ctx, true, req.Id, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetLDAPSyncResponse{
+		Sync: idp_grpc.LDAPSyncToPb(sync),
+	}, nil
+}
+
+func (s *Server) SetLDAPSync(ctx context.Context, req *mgmt_pb.SetLDAPSyncRequest) (*mgmt_pb.SetLDAPSyncResponse, error) {
+	details, err := s.command.SetLDAPSync(ctx, idp_grpc.LDAPSyncToDomain(req.Id, req.GroupAttribute, req.GroupMappings, req.SyncEnabled, req.SyncInterval), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetLDAPSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveLDAPSync(ctx context.Context, req *mgmt_pb.RemoveLDAPSyncRequest) (*mgmt_pb.RemoveLDAPSyncResponse, error) {
+	details, err := s.command.RemoveLDAPSync(ctx, req.Id, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveLDAPSyncResponse{
+		Details: object_pb.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListLDAPSyncReports(ctx context.Context, req *mgmt_pb.ListLDAPSyncReportsRequest) (*mgmt_pb.ListLDAPSyncReportsResponse, error) {
+	queries, err := listLDAPSyncReportsToModel(authz.GetCtxData(ctx).OrgID, req)
+	if err != nil {
+		return nil, err
+	}
+	reports, err := s.query.SearchLDAPSyncReports(ctx, req.Id, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListLDAPSyncReportsResponse{
+		Result:  idp_grpc.LDAPSyncReportsToPb(reports.Reports),
+		Details: object_pb.ToListDetails(reports.Count, reports.Sequence, reports.LastRun),
+	}, nil
+}
+
 func (s *Server) AddAppleProvider(ctx context.Context, req *mgmt_pb.AddAppleProviderRequest) (*mgmt_pb.AddAppleProviderResponse, error) {
 	id, details, err := s.command.AddOrgAppleProvider(ctx, authz.GetCtxData(ctx).OrgID, addAppleProviderToCommand(req))
 	if err != nil {
@@ -0,0 +1,64 @@
+package management
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	policy_grpc "github.com/zitadel/zitadel/internal/api/grpc/policy"
+	mgmt_pb "github.com/zitadel/zitadel/pkg/grpc/management"
+)
+
+func (s *Server) GetImpersonationPolicy(ctx context.Context, _ *mgmt_pb.GetImpersonationPolicyRequest) (*mgmt_pb.GetImpersonationPolicyResponse, error) {
+	policy, err := s.query.ImpersonationPolicyByOrg(ctx, true, authz.GetCtxData(ctx).OrgID, false)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetImpersonationPolicyResponse{Policy: policy_grpc.ModelImpersonationPolicyToPb(policy)}, nil
+}
+
+func (s *Server) GetDefaultImpersonationPolicy(ctx context.Context, _ *mgmt_pb.GetDefaultImpersonationPolicyRequest) (*mgmt_pb.GetDefaultImpersonationPolicyResponse, error) {
+	policy, err := s.query.DefaultImpersonationPolicy(ctx, true)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetDefaultImpersonationPolicyResponse{Policy: policy_grpc.ModelImpersonationPolicyToPb(policy)}, nil
+}
+
+func (s *Server) AddCustomImpersonationPolicy(ctx context.Context, req *mgmt_pb.AddCustomImpersonationPolicyRequest) (*mgmt_pb.AddCustomImpersonationPolicyResponse, error) {
+	result, err := s.command.AddImpersonationPolicy(ctx, authz.GetCtxData(ctx).OrgID, req.GetAllowImpersonation())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddCustomImpersonationPolicyResponse{
+		Details: object.AddToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) UpdateCustomImpersonationPolicy(ctx context.Context, req *mgmt_pb.UpdateCustomImpersonationPolicyRequest) (*mgmt_pb.UpdateCustomImpersonationPolicyResponse, error) {
+	result, err := s.command.ChangeImpersonationPolicy(ctx, authz.GetCtxData(ctx).OrgID, req.GetAllowImpersonation())
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.UpdateCustomImpersonationPolicyResponse{
+		Details: object.ChangeToDetailsPb(
+			result.Sequence,
+			result.EventDate,
+			result.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) ResetImpersonationPolicyToDefault(ctx context.Context, _ *mgmt_pb.ResetImpersonationPolicyToDefaultRequest) (*mgmt_pb.ResetImpersonationPolicyToDefaultResponse, error) {
+	objectDetails, err := s.command.RemoveImpersonationPolicy(ctx, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ResetImpersonationPolicyToDefaultResponse{
+		Details: object.DomainToChangeDetailsPb(objectDetails),
+	}, nil
+}
@@ -246,6 +246,107 @@ func (s *Server) RegenerateAPIClientSecret(ctx context.Context, req *mgmt_pb.Reg
 	}, nil
 }
 
+func (s *Server) GetAppProvisioning(ctx context.Context, req *mgmt_pb.GetAppProvisioningRequest) (*mgmt_pb.GetAppProvisioningResponse, error) {
+	provisioning, err := s.query.AppProvisioningByID(ctx, true, req.ProjectId, req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetAppProvisioningResponse{
+		Provisioning: project_grpc.AppProvisioningToPb(provisioning),
+	}, nil
+}
+
+func (s *Server) SetAppProvisioning(ctx context.Context, req *mgmt_pb.SetAppProvisioningRequest) (*mgmt_pb.SetAppProvisioningResponse, error) {
+	details, err := s.command.SetApplicationProvisioning(ctx, SetAppProvisioningRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetAppProvisioningResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveAppProvisioning(ctx context.Context, req *mgmt_pb.RemoveAppProvisioningRequest) (*mgmt_pb.RemoveAppProvisioningResponse, error) {
+	details, err := s.command.RemoveApplicationProvisioning(ctx, req.ProjectId, req.AppId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveAppProvisioningResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetClientRegistrationPolicy(ctx context.Context, req *mgmt_pb.GetClientRegistrationPolicyRequest) (*mgmt_pb.GetClientRegistrationPolicyResponse, error) {
+	registration, err := s.query.ClientRegistrationByProjectID(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetClientRegistrationPolicyResponse{
+		Policy: project_grpc.ClientRegistrationPolicyToPb(registration),
+	}, nil
+}
+
+func (s *Server) SetClientRegistrationPolicy(ctx context.Context, req *mgmt_pb.SetClientRegistrationPolicyRequest) (*mgmt_pb.SetClientRegistrationPolicyResponse, error) {
+	details, err := s.command.SetClientRegistrationPolicy(ctx, SetClientRegistrationPolicyRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.SetClientRegistrationPolicyResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveClientRegistrationPolicy(ctx context.Context, req *mgmt_pb.RemoveClientRegistrationPolicyRequest) (*mgmt_pb.RemoveClientRegistrationPolicyResponse, error) {
+	details, err := s.command.RemoveClientRegistrationPolicy(ctx, req.ProjectId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveClientRegistrationPolicyResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddClientRegistrationToken(ctx context.Context, req *mgmt_pb.AddClientRegistrationTokenRequest) (*mgmt_pb.AddClientRegistrationTokenResponse, error) {
+	token, err := s.command.AddClientRegistrationToken(ctx, AddClientRegistrationTokenRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.AddClientRegistrationTokenResponse{
+		TokenId: token.TokenID,
+		Token:   token.Token,
+		Details: object_grpc.AddToDetailsPb(
+			token.Sequence,
+			token.ChangeDate,
+			token.ResourceOwner,
+		),
+	}, nil
+}
+
+func (s *Server) RemoveClientRegistrationToken(ctx context.Context, req *mgmt_pb.RemoveClientRegistrationTokenRequest) (*mgmt_pb.RemoveClientRegistrationTokenResponse, error) {
+	details, err := s.command.RemoveClientRegistrationToken(ctx, req.ProjectId, req.TokenId, authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.RemoveClientRegistrationTokenResponse{
+		Details: object_grpc.DomainToChangeDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListAppProvisioningLogs(ctx context.Context, req *mgmt_pb.ListAppProvisioningLogsRequest) (*mgmt_pb.ListAppProvisioningLogsResponse, error) {
+	queries, err := ListAppProvisioningLogsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	logs, err := s.query.SearchAppProvisioningLogs(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListAppProvisioningLogsResponse{
+		Result:  project_grpc.AppProvisioningLogsToPb(logs.Logs),
+		Details: object_grpc.ToListDetails(logs.Count, logs.Sequence, logs.LastRun),
+	}, nil
+}
+
 func (s *Server) GetAppKey(ctx context.Context, req *mgmt_pb.GetAppKeyRequest) (*mgmt_pb.GetAppKeyResponse, error) {
 	resourceOwner, err := query.NewAuthNKeyResourceOwnerQuery(authz.GetCtxData(ctx).OrgID)
 	if err != nil {
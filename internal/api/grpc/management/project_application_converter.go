@@ -41,22 +41,29 @@ func AddOIDCAppRequestToDomain(req *mgmt_pb.AddOIDCAppRequest) *domain.OIDCApp {
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.ProjectId,
 		},
-		AppName:                  req.Name,
-		OIDCVersion:              app_grpc.OIDCVersionToDomain(req.Version),
-		RedirectUris:             req.RedirectUris,
-		ResponseTypes:            app_grpc.OIDCResponseTypesToDomain(req.ResponseTypes),
-		GrantTypes:               app_grpc.OIDCGrantTypesToDomain(req.GrantTypes),
-		ApplicationType:          app_grpc.OIDCApplicationTypeToDomain(req.AppType),
-		AuthMethodType:           app_grpc.OIDCAuthMethodTypeToDomain(req.AuthMethodType),
-		PostLogoutRedirectUris:   req.PostLogoutRedirectUris,
-		DevMode:                  req.DevMode,
-		AccessTokenType:          app_grpc.OIDCTokenTypeToDomain(req.AccessTokenType),
-		AccessTokenRoleAssertion: req.AccessTokenRoleAssertion,
-		IDTokenRoleAssertion:     req.IdTokenRoleAssertion,
-		IDTokenUserinfoAssertion: req.IdTokenUserinfoAssertion,
-		ClockSkew:                req.ClockSkew.AsDuration(),
-		AdditionalOrigins:        req.AdditionalOrigins,
-		SkipNativeAppSuccessPage: req.SkipNativeAppSuccessPage,
+		AppName:                          req.Name,
+		OIDCVersion:                      app_grpc.OIDCVersionToDomain(req.Version),
+		RedirectUris:                     req.RedirectUris,
+		ResponseTypes:                    app_grpc.OIDCResponseTypesToDomain(req.ResponseTypes),
+		GrantTypes:                       app_grpc.OIDCGrantTypesToDomain(req.GrantTypes),
+		ApplicationType:                  app_grpc.OIDCApplicationTypeToDomain(req.AppType),
+		AuthMethodType:                   app_grpc.OIDCAuthMethodTypeToDomain(req.AuthMethodType),
+		PostLogoutRedirectUris:           req.PostLogoutRedirectUris,
+		DevMode:                          req.DevMode,
+		AccessTokenType:                  app_grpc.OIDCTokenTypeToDomain(req.AccessTokenType),
+		AccessTokenRoleAssertion:         req.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:             req.IdTokenRoleAssertion,
+		IDTokenUserinfoAssertion:         req.IdTokenUserinfoAssertion,
+		ClockSkew:                        req.ClockSkew.AsDuration(),
+		AdditionalOrigins:                req.AdditionalOrigins,
+		SkipNativeAppSuccessPage:         req.SkipNativeAppSuccessPage,
+		BackChannelLogoutURI:             req.BackChannelLogoutUri,
+		FrontChannelLogoutURI:            req.FrontChannelLogoutUri,
+		RequirePushedAuthRequest:         req.RequirePushedAuthorizationRequests,
+		RequireSignedRequest:             req.RequireSignedRequestObject,
+		DPoPBoundAccessTokens:            req.DpopBoundAccessTokens,
+		ForceRSASignedTokens:             req.ForceRsaSignedTokens,
+		BackChannelClientNotificationURI: req.BackChannelClientNotificationUri,
 	}
 }
 
@@ -65,9 +72,12 @@ func AddSAMLAppRequestToDomain(req *mgmt_pb.AddSAMLAppRequest) *domain.SAMLApp {
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: req.ProjectId,
 		},
-		AppName:     req.Name,
-		Metadata:    req.GetMetadataXml(),
-		MetadataURL: req.GetMetadataUrl(),
+		AppName:           req.Name,
+		Metadata:          req.GetMetadataXml(),
+		MetadataURL:       req.GetMetadataUrl(),
+		EncryptAssertions: req.EncryptAssertions,
+		ArtifactBinding:   req.ArtifactBinding,
+		SingleLogout:      req.SingleLogout,
 	}
 }
 
@@ -93,21 +103,28 @@ func UpdateOIDCAppConfigRequestToDomain(app *mgmt_pb.UpdateOIDCAppConfigRequest)
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: app.ProjectId,
 		},
-		AppID:                    app.AppId,
-		RedirectUris:             app.RedirectUris,
-		ResponseTypes:            app_grpc.OIDCResponseTypesToDomain(app.ResponseTypes),
-		GrantTypes:               app_grpc.OIDCGrantTypesToDomain(app.GrantTypes),
-		ApplicationType:          app_grpc.OIDCApplicationTypeToDomain(app.AppType),
-		AuthMethodType:           app_grpc.OIDCAuthMethodTypeToDomain(app.AuthMethodType),
-		PostLogoutRedirectUris:   app.PostLogoutRedirectUris,
-		DevMode:                  app.DevMode,
-		AccessTokenType:          app_grpc.OIDCTokenTypeToDomain(app.AccessTokenType),
-		AccessTokenRoleAssertion: app.AccessTokenRoleAssertion,
-		IDTokenRoleAssertion:     app.IdTokenRoleAssertion,
-		IDTokenUserinfoAssertion: app.IdTokenUserinfoAssertion,
-		ClockSkew:                app.ClockSkew.AsDuration(),
-		AdditionalOrigins:        app.AdditionalOrigins,
-		SkipNativeAppSuccessPage: app.SkipNativeAppSuccessPage,
+		AppID:                            app.AppId,
+		RedirectUris:                     app.RedirectUris,
+		ResponseTypes:                    app_grpc.OIDCResponseTypesToDomain(app.ResponseTypes),
+		GrantTypes:                       app_grpc.OIDCGrantTypesToDomain(app.GrantTypes),
+		ApplicationType:                  app_grpc.OIDCApplicationTypeToDomain(app.AppType),
+		AuthMethodType:                   app_grpc.OIDCAuthMethodTypeToDomain(app.AuthMethodType),
+		PostLogoutRedirectUris:           app.PostLogoutRedirectUris,
+		DevMode:                          app.DevMode,
+		AccessTokenType:                  app_grpc.OIDCTokenTypeToDomain(app.AccessTokenType),
+		AccessTokenRoleAssertion:         app.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:             app.IdTokenRoleAssertion,
+		IDTokenUserinfoAssertion:         app.IdTokenUserinfoAssertion,
+		ClockSkew:                        app.ClockSkew.AsDuration(),
+		AdditionalOrigins:                app.AdditionalOrigins,
+		SkipNativeAppSuccessPage:         app.SkipNativeAppSuccessPage,
+		BackChannelLogoutURI:             app.BackChannelLogoutUri,
+		FrontChannelLogoutURI:            app.FrontChannelLogoutUri,
+		RequirePushedAuthRequest:         app.RequirePushedAuthorizationRequests,
+		RequireSignedRequest:             app.RequireSignedRequestObject,
+		DPoPBoundAccessTokens:            app.DpopBoundAccessTokens,
+		ForceRSASignedTokens:             app.ForceRsaSignedTokens,
+		BackChannelClientNotificationURI: app.BackChannelClientNotificationUri,
 	}
 }
 
@@ -116,9 +133,12 @@ func UpdateSAMLAppConfigRequestToDomain(app *mgmt_pb.UpdateSAMLAppConfigRequest)
 		ObjectRoot: models.ObjectRoot{
 			AggregateID: app.ProjectId,
 		},
-		AppID:       app.AppId,
-		Metadata:    app.GetMetadataXml(),
-		MetadataURL: app.GetMetadataUrl(),
+		AppID:             app.AppId,
+		Metadata:          app.GetMetadataXml(),
+		MetadataURL:       app.GetMetadataUrl(),
+		EncryptAssertions: app.EncryptAssertions,
+		ArtifactBinding:   app.ArtifactBinding,
+		SingleLogout:      app.SingleLogout,
 	}
 }
 
@@ -175,3 +195,61 @@ func ListAPIClientKeysRequestToQuery(ctx context.Context, req *mgmt_pb.ListAppKe
 		},
 	}, nil
 }
+
+func SetAppProvisioningRequestToDomain(req *mgmt_pb.SetAppProvisioningRequest) *domain.AppProvisioning {
+	return &domain.AppProvisioning{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.ProjectId,
+		},
+		AppID:    req.AppId,
+		Endpoint: req.Endpoint,
+		Token:    req.Token,
+	}
+}
+
+func SetClientRegistrationPolicyRequestToDomain(req *mgmt_pb.SetClientRegistrationPolicyRequest) *domain.ClientRegistrationPolicy {
+	return &domain.ClientRegistrationPolicy{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.ProjectId,
+		},
+		AllowedGrantTypes:   app_grpc.OIDCGrantTypesToDomain(req.AllowedGrantTypes),
+		RedirectURIPatterns: req.RedirectUriPatterns,
+	}
+}
+
+func AddClientRegistrationTokenRequestToDomain(req *mgmt_pb.AddClientRegistrationTokenRequest) *domain.ClientRegistrationToken {
+	return &domain.ClientRegistrationToken{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.ProjectId,
+		},
+		ExpirationDate: req.ExpirationDate.AsTime(),
+	}
+}
+
+func ListAppProvisioningLogsRequestToQuery(ctx context.Context, req *mgmt_pb.ListAppProvisioningLogsRequest) (*query.AppProvisioningLogSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := app_grpc.AppProvisioningLogQueriesToModel(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	resourceOwner, err := query.NewAppProvisioningLogResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	projectID, err := query.NewAppProvisioningLogProjectIDSearchQuery(req.ProjectId)
+	if err != nil {
+		return nil, err
+	}
+	appID, err := query.NewAppProvisioningLogAppIDSearchQuery(req.AppId)
+	if err != nil {
+		return nil, err
+	}
+	return &query.AppProvisioningLogSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, resourceOwner, projectID, appID),
+	}, nil
+}
@@ -301,6 +301,61 @@ func (s *Server) ImportHumanUser(ctx context.Context, req *mgmt_pb.ImportHumanUs
 	return resp, nil
 }
 
+func (s *Server) ImportHumanUsers(ctx context.Context, req *mgmt_pb.ImportHumanUsersRequest) (*mgmt_pb.ImportHumanUsersResponse, error) {
+	id, details, err := s.command.AddUserImport(ctx, ImportHumanUsersRequestToDomain(req), authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ImportHumanUsersResponse{
+		Id:      id,
+		Details: obj_grpc.DomainToAddDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GetHumanUserImportByID(ctx context.Context, req *mgmt_pb.GetHumanUserImportByIDRequest) (*mgmt_pb.GetHumanUserImportByIDResponse, error) {
+	owner, err := query.NewUserImportResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	userImport, err := s.query.UserImportByID(ctx, true, req.Id, owner)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.GetHumanUserImportByIDResponse{
+		UserImport: user_grpc.UserImportToPb(userImport),
+	}, nil
+}
+
+func (s *Server) ListHumanUserImports(ctx context.Context, req *mgmt_pb.ListHumanUserImportsRequest) (*mgmt_pb.ListHumanUserImportsResponse, error) {
+	queries, err := ListHumanUserImportsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchUserImports(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListHumanUserImportsResponse{
+		Result:  user_grpc.UserImportsToPb(res.Imports),
+		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.LastRun),
+	}, nil
+}
+
+func (s *Server) ListHumanUserImportRows(ctx context.Context, req *mgmt_pb.ListHumanUserImportRowsRequest) (*mgmt_pb.ListHumanUserImportRowsResponse, error) {
+	queries, err := ListHumanUserImportRowsRequestToQuery(ctx, req)
+	if err != nil {
+		return nil, err
+	}
+	res, err := s.query.SearchUserImportRows(ctx, req.Id, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &mgmt_pb.ListHumanUserImportRowsResponse{
+		Result:  user_grpc.UserImportRowsToPb(res.Rows),
+		Details: obj_grpc.ToListDetails(res.Count, res.Sequence, res.LastRun),
+	}, nil
+}
+
 func (s *Server) AddMachineUser(ctx context.Context, req *mgmt_pb.AddMachineUserRequest) (*mgmt_pb.AddMachineUserResponse, error) {
 	machine := AddMachineUserRequestToCommand(req, authz.GetCtxData(ctx).OrgID)
 	objectDetails, err := s.command.AddMachine(ctx, machine)
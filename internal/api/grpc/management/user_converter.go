@@ -91,6 +91,50 @@ func ListUserMetadataToDomain(req *mgmt_pb.ListUserMetadataRequest) (*query.User
 	}, nil
 }
 
+func ImportHumanUsersRequestToDomain(req *mgmt_pb.ImportHumanUsersRequest) *domain.UserImport {
+	return &domain.UserImport{
+		Format: user_grpc.UserImportFormatToDomain(req.Format),
+		DryRun: req.DryRun,
+		Data:   req.Data,
+	}
+}
+
+func ListHumanUserImportsRequestToQuery(ctx context.Context, req *mgmt_pb.ListHumanUserImportsRequest) (*query.UserImportSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	owner, err := query.NewUserImportResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.UserImportSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{owner},
+	}, nil
+}
+
+func ListHumanUserImportRowsRequestToQuery(ctx context.Context, req *mgmt_pb.ListHumanUserImportRowsRequest) (*query.UserImportRowSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToModel(req.Query)
+	queries, err := user_grpc.UserImportRowQueriesToQuery(req.Queries)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := query.NewUserImportRowResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	return &query.UserImportRowSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: append(queries, owner),
+	}, nil
+}
+
 func ImportHumanUserRequestToDomain(req *mgmt_pb.ImportHumanUserRequest) (human *domain.Human, passwordless bool, links []*domain.UserIDPLink) {
 	human = &domain.Human{
 		Username: req.UserName,
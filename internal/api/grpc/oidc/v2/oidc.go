@@ -123,6 +123,27 @@ func (s *Server) linkSessionToAuthRequest(ctx context.Context, authRequestID str
 	}, nil
 }
 
+func (s *Server) AuthorizeBackChannelAuth(ctx context.Context, req *oidc_pb.AuthorizeBackChannelAuthRequest) (*oidc_pb.AuthorizeBackChannelAuthResponse, error) {
+	var (
+		details *domain.ObjectDetails
+		err     error
+	)
+	switch v := req.GetDecision().(type) {
+	case *oidc_pb.AuthorizeBackChannelAuthRequest_Approve:
+		details, err = s.command.ApproveBackChannelAuthWithSession(ctx, req.GetBackchannelAuthRequestId(), v.Approve.GetSessionId(), v.Approve.GetSessionToken())
+	case *oidc_pb.AuthorizeBackChannelAuthRequest_Deny:
+		details, err = s.command.DenyBackChannelAuthWithSession(ctx, req.GetBackchannelAuthRequestId(), v.Deny.GetSessionId(), v.Deny.GetSessionToken())
+	default:
+		return nil, errors.ThrowUnimplementedf(nil, "OIDCv2-Chu7o", "decision oneOf %T in method AuthorizeBackChannelAuth not implemented", v)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &oidc_pb.AuthorizeBackChannelAuthResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
 func errorReasonToDomain(errorReason oidc_pb.ErrorReason) domain.OIDCErrorReason {
 	switch errorReason {
 	case oidc_pb.ErrorReason_ERROR_REASON_UNSPECIFIED:
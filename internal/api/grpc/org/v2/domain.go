@@ -0,0 +1,205 @@
+package org
+
+import (
+	"context"
+
+	object_v1 "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	org "github.com/zitadel/zitadel/pkg/grpc/org/v2beta"
+)
+
+func (s *Server) ListOrganizationDomains(ctx context.Context, req *org.ListOrganizationDomainsRequest) (*org.ListOrganizationDomainsResponse, error) {
+	queries, err := listOrganizationDomainsRequestToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	domains, err := s.query.SearchOrgDomainsWithPermission(ctx, req.GetOrganizationId(), queries)
+	if err != nil {
+		return nil, err
+	}
+	return &org.ListOrganizationDomainsResponse{
+		Result:  domainsToPb(domains.Domains),
+		Details: object.ToListDetails(domains.SearchResponse),
+	}, nil
+}
+
+func (s *Server) AddOrganizationDomain(ctx context.Context, req *org.AddOrganizationDomainRequest) (*org.AddOrganizationDomainResponse, error) {
+	userIDs, err := s.getClaimedUserIDsOfOrgDomain(ctx, req.GetDomain(), req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.AddOrgDomainV2(ctx, req.GetOrganizationId(), req.GetDomain(), userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &org.AddOrganizationDomainResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveOrganizationDomain(ctx context.Context, req *org.RemoveOrganizationDomainRequest) (*org.RemoveOrganizationDomainResponse, error) {
+	details, err := s.command.RemoveOrgDomainV2(ctx, orgDomainToDomain(req.GetOrganizationId(), req.GetDomain()))
+	if err != nil {
+		return nil, err
+	}
+	return &org.RemoveOrganizationDomainResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) GenerateOrganizationDomainValidation(ctx context.Context, req *org.GenerateOrganizationDomainValidationRequest) (*org.GenerateOrganizationDomainValidationResponse, error) {
+	orgDomain := orgDomainToDomain(req.GetOrganizationId(), req.GetDomain())
+	orgDomain.ValidationType = domainValidationTypeToDomain(req.GetType())
+	token, url, err := s.command.GenerateOrgDomainValidationV2(ctx, orgDomain)
+	if err != nil {
+		return nil, err
+	}
+	return &org.GenerateOrganizationDomainValidationResponse{
+		Token: token,
+		Url:   url,
+	}, nil
+}
+
+func (s *Server) VerifyOrganizationDomain(ctx context.Context, req *org.VerifyOrganizationDomainRequest) (*org.VerifyOrganizationDomainResponse, error) {
+	userIDs, err := s.getClaimedUserIDsOfOrgDomain(ctx, req.GetDomain(), req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.ValidateOrgDomainV2(ctx, orgDomainToDomain(req.GetOrganizationId(), req.GetDomain()), userIDs)
+	if err != nil {
+		return nil, err
+	}
+	return &org.VerifyOrganizationDomainResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) SetPrimaryOrganizationDomain(ctx context.Context, req *org.SetPrimaryOrganizationDomainRequest) (*org.SetPrimaryOrganizationDomainResponse, error) {
+	details, err := s.command.SetPrimaryOrgDomainV2(ctx, orgDomainToDomain(req.GetOrganizationId(), req.GetDomain()))
+	if err != nil {
+		return nil, err
+	}
+	return &org.SetPrimaryOrganizationDomainResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+// getClaimedUserIDsOfOrgDomain returns the users of other organizations,
+// which have a login name on the passed domain and will be claimed by the organization.
+func (s *Server) getClaimedUserIDsOfOrgDomain(ctx context.Context, orgDomain, orgID string) ([]string, error) {
+	loginName, err := query.NewUserPreferredLoginNameSearchQuery("@"+orgDomain, query.TextEndsWithIgnoreCase)
+	if err != nil {
+		return nil, err
+	}
+	owner, err := query.NewUserResourceOwnerSearchQuery(orgID, query.TextNotEquals)
+	if err != nil {
+		return nil, err
+	}
+	users, err := s.query.SearchUsers(ctx, &query.UserSearchQueries{Queries: []query.SearchQuery{loginName, owner}})
+	if err != nil {
+		return nil, err
+	}
+	userIDs := make([]string, len(users.Users))
+	for i, user := range users.Users {
+		userIDs[i] = user.ID
+	}
+	return userIDs, nil
+}
+
+func orgDomainToDomain(orgID, orgDomain string) *domain.OrgDomain {
+	return &domain.OrgDomain{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: orgID,
+		},
+		Domain: orgDomain,
+	}
+}
+
+func listOrganizationDomainsRequestToModel(req *org.ListOrganizationDomainsRequest) (*query.OrgDomainSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToQuery(req.GetQuery())
+	queries, err := domainQueriesToQuery(req.GetQueries())
+	if err != nil {
+		return nil, err
+	}
+	return &query.OrgDomainSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: queries,
+	}, nil
+}
+
+func domainQueriesToQuery(queries []*org.DomainSearchQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, domainQuery := range queries {
+		q[i], err = domainQueryToQuery(domainQuery)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func domainQueryToQuery(domainQuery *org.DomainSearchQuery) (query.SearchQuery, error) {
+	switch q := domainQuery.GetQuery().(type) {
+	case *org.DomainSearchQuery_DomainNameQuery:
+		return query.NewOrgDomainDomainSearchQuery(object_v1.TextMethodToQuery(q.DomainNameQuery.GetMethod()), q.DomainNameQuery.GetName())
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORGv2-Ags42", "List.Query.Invalid")
+	}
+}
+
+func domainsToPb(domains []*query.Domain) []*org.Domain {
+	d := make([]*org.Domain, len(domains))
+	for i, orgDomain := range domains {
+		d[i] = domainToPb(orgDomain)
+	}
+	return d
+}
+
+func domainToPb(orgDomain *query.Domain) *org.Domain {
+	return &org.Domain{
+		OrganizationId: orgDomain.OrgID,
+		Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+			Sequence:      orgDomain.Sequence,
+			EventDate:     orgDomain.ChangeDate,
+			ResourceOwner: orgDomain.OrgID,
+		}),
+		DomainName:     orgDomain.Domain,
+		IsVerified:     orgDomain.IsVerified,
+		IsPrimary:      orgDomain.IsPrimary,
+		ValidationType: domainValidationTypeToPb(orgDomain.ValidationType),
+	}
+}
+
+func domainValidationTypeToDomain(validationType org.DomainValidationType) domain.OrgDomainValidationType {
+	switch validationType {
+	case org.DomainValidationType_DOMAIN_VALIDATION_TYPE_HTTP:
+		return domain.OrgDomainValidationTypeHTTP
+	case org.DomainValidationType_DOMAIN_VALIDATION_TYPE_DNS:
+		return domain.OrgDomainValidationTypeDNS
+	case org.DomainValidationType_DOMAIN_VALIDATION_TYPE_UNSPECIFIED:
+		return domain.OrgDomainValidationTypeUnspecified
+	default:
+		return domain.OrgDomainValidationTypeUnspecified
+	}
+}
+
+func domainValidationTypeToPb(validationType domain.OrgDomainValidationType) org.DomainValidationType {
+	switch validationType {
+	case domain.OrgDomainValidationTypeHTTP:
+		return org.DomainValidationType_DOMAIN_VALIDATION_TYPE_HTTP
+	case domain.OrgDomainValidationTypeDNS:
+		return org.DomainValidationType_DOMAIN_VALIDATION_TYPE_DNS
+	case domain.OrgDomainValidationTypeUnspecified:
+		return org.DomainValidationType_DOMAIN_VALIDATION_TYPE_UNSPECIFIED
+	default:
+		return org.DomainValidationType_DOMAIN_VALIDATION_TYPE_UNSPECIFIED
+	}
+}
@@ -81,3 +81,43 @@ func createdOrganizationToPb(createdOrg *command.CreatedOrg) (_ *org.AddOrganiza
 		CreatedAdmins:  admins,
 	}, nil
 }
+
+func (s *Server) UpdateOrganization(ctx context.Context, req *org.UpdateOrganizationRequest) (*org.UpdateOrganizationResponse, error) {
+	details, err := s.command.ChangeOrgV2(ctx, req.GetOrganizationId(), req.GetName())
+	if err != nil {
+		return nil, err
+	}
+	return &org.UpdateOrganizationResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) DeactivateOrganization(ctx context.Context, req *org.DeactivateOrganizationRequest) (*org.DeactivateOrganizationResponse, error) {
+	details, err := s.command.DeactivateOrgV2(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	return &org.DeactivateOrganizationResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ReactivateOrganization(ctx context.Context, req *org.ReactivateOrganizationRequest) (*org.ReactivateOrganizationResponse, error) {
+	details, err := s.command.ReactivateOrgV2(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	return &org.ReactivateOrganizationResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) DeleteOrganization(ctx context.Context, req *org.DeleteOrganizationRequest) (*org.DeleteOrganizationResponse, error) {
+	details, err := s.command.RemoveOrgV2(ctx, req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	return &org.DeleteOrganizationResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
@@ -0,0 +1,150 @@
+package org
+
+import (
+	"context"
+
+	object_v1 "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object/v2beta"
+	org "github.com/zitadel/zitadel/pkg/grpc/org/v2beta"
+)
+
+func (s *Server) ListOrganizations(ctx context.Context, req *org.ListOrganizationsRequest) (*org.ListOrganizationsResponse, error) {
+	queries, err := listOrganizationsRequestToModel(req)
+	if err != nil {
+		return nil, err
+	}
+	orgs, err := s.query.SearchOrgsWithPermission(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	return &org.ListOrganizationsResponse{
+		Result:        organizationsToPb(orgs.Orgs),
+		SortingColumn: req.GetSortingColumn(),
+		Details:       object.ToListDetails(orgs.SearchResponse),
+	}, nil
+}
+
+func (s *Server) GetOrganization(ctx context.Context, req *org.GetOrganizationRequest) (*org.GetOrganizationResponse, error) {
+	orgQ, err := s.query.OrgByIDWithPermission(ctx, true, req.GetOrganizationId())
+	if err != nil {
+		return nil, err
+	}
+	return &org.GetOrganizationResponse{
+		Details:      organizationDetailsToPb(orgQ),
+		Organization: organizationToPb(orgQ),
+	}, nil
+}
+
+func listOrganizationsRequestToModel(req *org.ListOrganizationsRequest) (*query.OrgSearchQueries, error) {
+	offset, limit, asc := object.ListQueryToQuery(req.GetQuery())
+	queries, err := orgQueriesToQuery(req.GetQueries())
+	if err != nil {
+		return nil, err
+	}
+	return &query.OrgSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        offset,
+			Limit:         limit,
+			Asc:           asc,
+			SortingColumn: organizationFieldNameToSortingColumn(req.GetSortingColumn()),
+		},
+		Queries: queries,
+	}, nil
+}
+
+func organizationFieldNameToSortingColumn(field org.OrganizationFieldName) query.Column {
+	switch field {
+	case org.OrganizationFieldName_ORGANIZATION_FIELD_NAME_NAME:
+		return query.OrgColumnName
+	case org.OrganizationFieldName_ORGANIZATION_FIELD_NAME_UNSPECIFIED:
+		return query.Column{}
+	default:
+		return query.Column{}
+	}
+}
+
+func orgQueriesToQuery(queries []*org.SearchQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, orgQuery := range queries {
+		q[i], err = orgQueryToQuery(orgQuery)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func orgQueryToQuery(orgQuery *org.SearchQuery) (query.SearchQuery, error) {
+	switch q := orgQuery.GetQuery().(type) {
+	case *org.SearchQuery_NameQuery:
+		return query.NewOrgNameSearchQuery(object_v1.TextMethodToQuery(q.NameQuery.GetMethod()), q.NameQuery.GetName())
+	case *org.SearchQuery_DomainQuery:
+		return query.NewOrgDomainSearchQuery(object_v1.TextMethodToQuery(q.DomainQuery.GetMethod()), q.DomainQuery.GetDomain())
+	case *org.SearchQuery_StateQuery:
+		return query.NewOrgStateSearchQuery(organizationStateToDomain(q.StateQuery.GetState()))
+	case *org.SearchQuery_IdQuery:
+		return query.NewOrgIDsSearchQuery(q.IdQuery.GetIds()...)
+	default:
+		return nil, caos_errs.ThrowInvalidArgument(nil, "ORGv2-vR9nC", "List.Query.Invalid")
+	}
+}
+
+func organizationsToPb(orgs []*query.Org) []*org.Organization {
+	o := make([]*org.Organization, len(orgs))
+	for i, orgQ := range orgs {
+		o[i] = organizationToPb(orgQ)
+	}
+	return o
+}
+
+func organizationToPb(orgQ *query.Org) *org.Organization {
+	return &org.Organization{
+		Id:            orgQ.ID,
+		Details:       organizationDetailsToPb(orgQ),
+		State:         organizationStateToPb(orgQ.State),
+		Name:          orgQ.Name,
+		PrimaryDomain: orgQ.Domain,
+	}
+}
+
+func organizationDetailsToPb(orgQ *query.Org) *object_pb.Details {
+	return object.DomainToDetailsPb(&domain.ObjectDetails{
+		Sequence:      orgQ.Sequence,
+		EventDate:     orgQ.ChangeDate,
+		ResourceOwner: orgQ.ResourceOwner,
+	})
+}
+
+func organizationStateToPb(state domain.OrgState) org.OrganizationState {
+	switch state {
+	case domain.OrgStateActive:
+		return org.OrganizationState_ORGANIZATION_STATE_ACTIVE
+	case domain.OrgStateInactive:
+		return org.OrganizationState_ORGANIZATION_STATE_INACTIVE
+	case domain.OrgStateRemoved:
+		return org.OrganizationState_ORGANIZATION_STATE_REMOVED
+	case domain.OrgStateUnspecified:
+		return org.OrganizationState_ORGANIZATION_STATE_UNSPECIFIED
+	default:
+		return org.OrganizationState_ORGANIZATION_STATE_UNSPECIFIED
+	}
+}
+
+func organizationStateToDomain(state org.OrganizationState) domain.OrgState {
+	switch state {
+	case org.OrganizationState_ORGANIZATION_STATE_ACTIVE:
+		return domain.OrgStateActive
+	case org.OrganizationState_ORGANIZATION_STATE_INACTIVE:
+		return domain.OrgStateInactive
+	case org.OrganizationState_ORGANIZATION_STATE_REMOVED:
+		return domain.OrgStateRemoved
+	case org.OrganizationState_ORGANIZATION_STATE_UNSPECIFIED:
+		return domain.OrgStateUnspecified
+	default:
+		return domain.OrgStateUnspecified
+	}
+}
@@ -0,0 +1,115 @@
+package org
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	object "github.com/zitadel/zitadel/pkg/grpc/object/v2beta"
+	org "github.com/zitadel/zitadel/pkg/grpc/org/v2beta"
+)
+
+func Test_organizationToPb(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		org  *query.Org
+		want *org.Organization
+	}{
+		{
+			name: "active org",
+			org: &query.Org{
+				ID:            "org1",
+				CreationDate:  now,
+				ChangeDate:    now,
+				ResourceOwner: "org1",
+				State:         domain.OrgStateActive,
+				Sequence:      10,
+				Name:          "name",
+				Domain:        "name.zitadel.cloud",
+			},
+			want: &org.Organization{
+				Id: "org1",
+				Details: &object.Details{
+					Sequence:      10,
+					ChangeDate:    timestamppb.New(now),
+					ResourceOwner: "org1",
+				},
+				State:         org.OrganizationState_ORGANIZATION_STATE_ACTIVE,
+				Name:          "name",
+				PrimaryDomain: "name.zitadel.cloud",
+			},
+		},
+		{
+			name: "inactive org",
+			org: &query.Org{
+				ID:            "org1",
+				ChangeDate:    now,
+				ResourceOwner: "org1",
+				State:         domain.OrgStateInactive,
+				Name:          "name",
+			},
+			want: &org.Organization{
+				Id: "org1",
+				Details: &object.Details{
+					ChangeDate:    timestamppb.New(now),
+					ResourceOwner: "org1",
+				},
+				State: org.OrganizationState_ORGANIZATION_STATE_INACTIVE,
+				Name:  "name",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := organizationToPb(tt.org)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_orgQueryToQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   *org.SearchQuery
+		wantErr error
+	}{
+		{
+			name:    "missing query",
+			query:   &org.SearchQuery{},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "ORGv2-vR9nC", "List.Query.Invalid"),
+		},
+		{
+			name: "name query",
+			query: &org.SearchQuery{
+				Query: &org.SearchQuery_NameQuery{
+					NameQuery: &org.OrganizationNameQuery{Name: "name"},
+				},
+			},
+		},
+		{
+			name: "state query",
+			query: &org.SearchQuery{
+				Query: &org.SearchQuery_StateQuery{
+					StateQuery: &org.OrganizationStateQuery{State: org.OrganizationState_ORGANIZATION_STATE_ACTIVE},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := orgQueryToQuery(tt.query)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			assert.NoError(t, err)
+			assert.NotNil(t, got)
+		})
+	}
+}
@@ -0,0 +1,20 @@
+package policy
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	policy_pb "github.com/zitadel/zitadel/pkg/grpc/policy"
+)
+
+func ModelImpersonationPolicyToPb(policy *query.ImpersonationPolicy) *policy_pb.ImpersonationPolicy {
+	return &policy_pb.ImpersonationPolicy{
+		IsDefault:          policy.IsDefault,
+		AllowImpersonation: policy.AllowImpersonation,
+		Details: object.ToViewDetailsPb(
+			policy.Sequence,
+			policy.CreationDate,
+			policy.ChangeDate,
+			policy.ResourceOwner,
+		),
+	}
+}
@@ -0,0 +1,76 @@
+package project
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	app_pb "github.com/zitadel/zitadel/pkg/grpc/app"
+)
+
+func AppProvisioningToPb(provisioning *query.AppProvisioning) *app_pb.AppProvisioning {
+	return &app_pb.AppProvisioning{
+		Details:  object_grpc.ToViewDetailsPb(provisioning.Sequence, provisioning.CreationDate, provisioning.ChangeDate, provisioning.ResourceOwner),
+		Endpoint: provisioning.Endpoint,
+	}
+}
+
+func AppProvisioningLogsToPb(logs []*query.AppProvisioningLog) []*app_pb.AppProvisioningLog {
+	l := make([]*app_pb.AppProvisioningLog, len(logs))
+	for i, log := range logs {
+		l[i] = AppProvisioningLogToPb(log)
+	}
+	return l
+}
+
+func AppProvisioningLogToPb(log *query.AppProvisioningLog) *app_pb.AppProvisioningLog {
+	return &app_pb.AppProvisioningLog{
+		UserId:       log.UserID,
+		EventType:    log.EventType,
+		Sequence:     log.Sequence,
+		CreationDate: timestamppb.New(log.CreationDate),
+		Operation:    AppProvisioningOperationToPb(log.Operation),
+		Succeeded:    log.Succeeded,
+		Attempts:     uint32(log.Attempts),
+		Error:        log.Error,
+	}
+}
+
+func AppProvisioningOperationToPb(operation domain.AppProvisioningOperation) app_pb.AppProvisioningOperation {
+	switch operation {
+	case domain.AppProvisioningOperationCreate:
+		return app_pb.AppProvisioningOperation_APP_PROVISIONING_OPERATION_CREATE
+	case domain.AppProvisioningOperationUpdate:
+		return app_pb.AppProvisioningOperation_APP_PROVISIONING_OPERATION_UPDATE
+	case domain.AppProvisioningOperationDeactivate:
+		return app_pb.AppProvisioningOperation_APP_PROVISIONING_OPERATION_DEACTIVATE
+	case domain.AppProvisioningOperationDelete:
+		return app_pb.AppProvisioningOperation_APP_PROVISIONING_OPERATION_DELETE
+	default:
+		return app_pb.AppProvisioningOperation_APP_PROVISIONING_OPERATION_UNSPECIFIED
+	}
+}
+
+func AppProvisioningLogQueriesToModel(queries []*app_pb.AppProvisioningLogQuery) (q []query.SearchQuery, err error) {
+	q = make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = AppProvisioningLogQueryToModel(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func AppProvisioningLogQueryToModel(logQuery *app_pb.AppProvisioningLogQuery) (query.SearchQuery, error) {
+	switch q := logQuery.Query.(type) {
+	case *app_pb.AppProvisioningLogQuery_UserIdQuery:
+		return query.NewAppProvisioningLogUserIDSearchQuery(q.UserIdQuery.UserId)
+	case *app_pb.AppProvisioningLogQuery_SucceededQuery:
+		return query.NewAppProvisioningLogSucceededSearchQuery(q.SucceededQuery.Succeeded)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "APP-Pha7i", "List.Query.Invalid")
+	}
+}
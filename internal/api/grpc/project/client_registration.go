@@ -0,0 +1,30 @@
+package project
+
+import (
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	object_grpc "github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/query"
+	app_pb "github.com/zitadel/zitadel/pkg/grpc/app"
+)
+
+func ClientRegistrationPolicyToPb(registration *query.ClientRegistration) *app_pb.ClientRegistrationPolicy {
+	return &app_pb.ClientRegistrationPolicy{
+		Details:             object_grpc.ToViewDetailsPb(registration.Sequence, registration.ChangeDate, registration.ChangeDate, registration.ResourceOwner),
+		AllowedGrantTypes:   OIDCGrantTypesFromModel(registration.AllowedGrantTypes),
+		RedirectUriPatterns: registration.RedirectURIPatterns,
+		Tokens:              ClientRegistrationTokensToPb(registration.Tokens),
+	}
+}
+
+func ClientRegistrationTokensToPb(tokens []*query.ClientRegistrationToken) []*app_pb.ClientRegistrationToken {
+	t := make([]*app_pb.ClientRegistrationToken, len(tokens))
+	for i, token := range tokens {
+		t[i] = &app_pb.ClientRegistrationToken{
+			Id:             token.ID,
+			CreationDate:   timestamppb.New(token.CreationDate),
+			ExpirationDate: timestamppb.New(token.Expiration),
+		}
+	}
+	return t
+}
@@ -16,8 +16,10 @@ import (
 	"google.golang.org/protobuf/encoding/protojson"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	client_middleware "github.com/zitadel/zitadel/internal/api/grpc/client/middleware"
 	"github.com/zitadel/zitadel/internal/api/grpc/server/middleware"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/query"
 )
@@ -47,6 +49,10 @@ var (
 
 	headerMatcher = runtime.HeaderMatcherFunc(
 		func(header string) (string, bool) {
+			// the DPoP proof is passed as is, so it can be verified like on direct gRPC calls
+			if strings.EqualFold(header, dpop.HeaderName) {
+				return header, true
+			}
 			for _, customHeader := range customHeaders {
 				if strings.HasPrefix(strings.ToLower(header), customHeader) {
 					return header, true
@@ -96,7 +102,10 @@ func CreateGatewayWithPrefix(
 	runtimeMux := runtime.NewServeMux(serveMuxOptions...)
 	opts := []grpc.DialOption{
 		grpc.WithTransportCredentials(grpcCredentials(tlsConfig)),
-		grpc.WithUnaryInterceptor(client_middleware.DefaultTracingClient()),
+		grpc.WithChainUnaryInterceptor(
+			client_middleware.DefaultTracingClient(),
+			middleware.DPoPGatewayClientInterceptor(),
+		),
 	}
 	connection, err := dial(ctx, port, opts)
 	if err != nil {
@@ -120,7 +129,10 @@ func CreateGateway(
 		port,
 		[]grpc.DialOption{
 			grpc.WithTransportCredentials(grpcCredentials(tlsConfig)),
-			grpc.WithUnaryInterceptor(client_middleware.DefaultTracingClient()),
+			grpc.WithChainUnaryInterceptor(
+				client_middleware.DefaultTracingClient(),
+				middleware.DPoPGatewayClientInterceptor(),
+			),
 		})
 	if err != nil {
 		return nil, err
@@ -173,6 +185,7 @@ func addInterceptors(
 ) http.Handler {
 	handler = http_mw.CallDurationHandler(handler)
 	handler = http1Host(handler, http1HostName)
+	handler = dpopRequest(handler)
 	handler = http_mw.CORSInterceptor(handler)
 	handler = http_mw.RobotsTagHandler(handler)
 	handler = http_mw.DefaultTelemetryHandler(handler)
@@ -195,6 +208,15 @@ func http1Host(next http.Handler, http1HostName string) http.Handler {
 	})
 }
 
+// dpopRequest passes the method and uri of the request to the gRPC server (see [middleware.DPoPGatewayClientInterceptor]),
+// so DPoP proofs can be verified against the original request
+func dpopRequest(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := middleware.WithDPoPGatewayRequest(r.Context(), r.Method, http_util.ComposedOrigin(r.Context())+r.URL.Path)
+		next.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
 func exhaustedCookieInterceptor(
 	next http.Handler,
 	accessInterceptor *http_mw.AccessInterceptor,
@@ -15,41 +15,57 @@ import (
 )
 
 func AccessStorageInterceptor(svc *logstore.Service[*record.AccessLog]) grpc.UnaryServerInterceptor {
-	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		if !svc.Enabled() {
 			return handler(ctx, req)
 		}
 
-		reqMd, _ := metadata.FromIncomingContext(ctx)
-
 		resp, handlerErr := handler(ctx, req)
+		storeAccessLog(ctx, svc, info.FullMethod, handlerErr)
+		return resp, handlerErr
+	}
+}
 
-		interceptorCtx, span := tracing.NewServerInterceptorSpan(ctx)
-		defer func() { span.EndWithError(err) }()
-
-		var respStatus uint32
-		grpcStatus, ok := status.FromError(handlerErr)
-		if ok {
-			respStatus = uint32(grpcStatus.Code())
+// AccessStorageStreamInterceptor stores the access log as soon as the stream is closed.
+// It must be chained after the instance interceptor, as the instance is read from the context of the stream.
+func AccessStorageStreamInterceptor(svc *logstore.Service[*record.AccessLog]) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		if !svc.Enabled() {
+			return handler(srv, stream)
 		}
+		handlerErr := handler(srv, stream)
+		// the context of the stream contains the instance as soon as the request was received
+		storeAccessLog(stream.Context(), svc, info.FullMethod, handlerErr)
+		return handlerErr
+	}
+}
 
-		resMd, _ := metadata.FromOutgoingContext(ctx)
-		instance := authz.GetInstance(ctx)
-
-		r := &record.AccessLog{
-			LogDate:         time.Now(),
-			Protocol:        record.GRPC,
-			RequestURL:      info.FullMethod,
-			ResponseStatus:  respStatus,
-			RequestHeaders:  reqMd,
-			ResponseHeaders: resMd,
-			InstanceID:      instance.InstanceID(),
-			ProjectID:       instance.ProjectID(),
-			RequestedDomain: instance.RequestedDomain(),
-			RequestedHost:   instance.RequestedHost(),
-		}
+func storeAccessLog(ctx context.Context, svc *logstore.Service[*record.AccessLog], fullMethod string, handlerErr error) {
+	interceptorCtx, span := tracing.NewServerInterceptorSpan(ctx)
+	defer span.End()
 
-		svc.Handle(interceptorCtx, r)
-		return resp, handlerErr
+	var respStatus uint32
+	grpcStatus, ok := status.FromError(handlerErr)
+	if ok {
+		respStatus = uint32(grpcStatus.Code())
 	}
+
+	reqMd, _ := metadata.FromIncomingContext(ctx)
+	resMd, _ := metadata.FromOutgoingContext(ctx)
+	instance := authz.GetInstance(ctx)
+
+	r := &record.AccessLog{
+		LogDate:         time.Now(),
+		Protocol:        record.GRPC,
+		RequestURL:      fullMethod,
+		ResponseStatus:  respStatus,
+		RequestHeaders:  reqMd,
+		ResponseHeaders: resMd,
+		InstanceID:      instance.InstanceID(),
+		ProjectID:       instance.ProjectID(),
+		RequestedDomain: instance.RequestedDomain(),
+		RequestedHost:   instance.RequestedHost(),
+	}
+
+	svc.Handle(interceptorCtx, r)
 }
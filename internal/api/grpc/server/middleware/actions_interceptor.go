@@ -0,0 +1,242 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/zitadel/logging"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type ActionsQueries interface {
+	GetActiveActionsByFlowAndTriggerType(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, orgID string) ([]*query.Action, error)
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string, queries ...query.SearchQuery) (*query.User, error)
+}
+
+// resourceOwnerFunc returns the organisation of the resource changed by the request
+type resourceOwnerFunc func(ctx context.Context, queries ActionsQueries, request proto.Message) (string, error)
+
+// requestedOrg is the organisation the request is made for (x-zitadel-orgid), in which new resources are created
+func requestedOrg(ctx context.Context, _ ActionsQueries, _ proto.Message) (string, error) {
+	return authz.GetCtxData(ctx).OrgID, nil
+}
+
+// orgOfUser is the organisation of the user referenced by the `userId` of the request
+func orgOfUser(ctx context.Context, queries ActionsQueries, request proto.Message) (string, error) {
+	object, err := messageToObject(request)
+	if err != nil {
+		return "", err
+	}
+	userID, _ := object["userId"].(string)
+	if userID == "" {
+		return "", errors.ThrowInvalidArgument(nil, "MIDDL-Eeng4", "Errors.User.UserIDMissing")
+	}
+	user, err := queries.GetUserByID(ctx, false, userID)
+	if err != nil {
+		return "", err
+	}
+	return user.ResourceOwner, nil
+}
+
+// actionMethods are the methods on which the actions of the api request flow are run,
+// with the resolution of the organisation whose actions are run.
+// All other methods (e.g. reads and health checks) are handled without querying any action.
+var actionMethods = map[string]resourceOwnerFunc{
+	"/zitadel.management.v1.ManagementService/AddHumanUser":       requestedOrg,
+	"/zitadel.management.v1.ManagementService/ImportHumanUser":    requestedOrg,
+	"/zitadel.management.v1.ManagementService/AddMachineUser":     requestedOrg,
+	"/zitadel.management.v1.ManagementService/UpdateUserName":     orgOfUser,
+	"/zitadel.management.v1.ManagementService/UpdateHumanProfile": orgOfUser,
+	"/zitadel.management.v1.ManagementService/UpdateHumanEmail":   orgOfUser,
+	"/zitadel.management.v1.ManagementService/UpdateHumanPhone":   orgOfUser,
+	"/zitadel.management.v1.ManagementService/RemoveHumanPhone":   orgOfUser,
+	"/zitadel.management.v1.ManagementService/SetHumanPassword":   orgOfUser,
+	"/zitadel.management.v1.ManagementService/UpdateMachine":      orgOfUser,
+	"/zitadel.management.v1.ManagementService/DeactivateUser":     orgOfUser,
+	"/zitadel.management.v1.ManagementService/ReactivateUser":     orgOfUser,
+	"/zitadel.management.v1.ManagementService/LockUser":           orgOfUser,
+	"/zitadel.management.v1.ManagementService/UnlockUser":         orgOfUser,
+	"/zitadel.management.v1.ManagementService/RemoveUser":         orgOfUser,
+	"/zitadel.user.v2beta.UserService/AddHumanUser":               requestedOrg,
+	"/zitadel.user.v2beta.UserService/AddMachineUser":             requestedOrg,
+	"/zitadel.user.v2beta.UserService/UpdateHumanUser":            orgOfUser,
+	"/zitadel.user.v2beta.UserService/UpdateMachineUser":          orgOfUser,
+	"/zitadel.user.v2beta.UserService/SetEmail":                   orgOfUser,
+	"/zitadel.user.v2beta.UserService/SetPhone":                   orgOfUser,
+	"/zitadel.user.v2beta.UserService/SetPassword":                orgOfUser,
+	"/zitadel.user.v2beta.UserService/AddIDPLink":                 orgOfUser,
+	"/zitadel.user.v2beta.UserService/DeactivateUser":             orgOfUser,
+	"/zitadel.user.v2beta.UserService/ReactivateUser":             orgOfUser,
+	"/zitadel.user.v2beta.UserService/LockUser":                   orgOfUser,
+	"/zitadel.user.v2beta.UserService/UnlockUser":                 orgOfUser,
+	"/zitadel.user.v2beta.UserService/DeleteUser":                 orgOfUser,
+}
+
+// ActionsInterceptor runs the actions of the api request flow on the [actionMethods]
+// of the organisation of the changed resource.
+// The actions of the pre request trigger can deny or change the request,
+// the actions of the post request trigger are called with the request and the response of a successful call.
+// Failures of post request actions are only logged, as the request was already handled.
+func ActionsInterceptor(queries ActionsQueries) grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (_ interface{}, err error) {
+		resourceOwner, ok := actionMethods[info.FullMethod]
+		if !ok {
+			return handler(ctx, req)
+		}
+		request, ok := req.(proto.Message)
+		ctxData := authz.GetCtxData(ctx)
+		if !ok || ctxData.IsZero() || ctxData.OrgID == "" {
+			return handler(ctx, req)
+		}
+
+		interceptorCtx, span := tracing.NewServerInterceptorSpan(ctx)
+		defer func() { span.EndWithError(err) }()
+
+		orgID, err := resourceOwner(interceptorCtx, queries, request)
+		if err != nil {
+			return nil, err
+		}
+		preActions, err := queries.GetActiveActionsByFlowAndTriggerType(interceptorCtx, domain.FlowTypeAPIRequest, domain.TriggerTypePreRequest, orgID)
+		if err != nil {
+			return nil, err
+		}
+		request, err = runPreRequestActions(interceptorCtx, preActions, info.FullMethod, orgID, ctxData, request)
+		if err != nil {
+			return nil, err
+		}
+
+		resp, err := handler(ctx, request)
+		if err != nil {
+			return nil, err
+		}
+		response, ok := resp.(proto.Message)
+		if !ok {
+			return resp, nil
+		}
+
+		postActions, err := queries.GetActiveActionsByFlowAndTriggerType(interceptorCtx, domain.FlowTypeAPIRequest, domain.TriggerTypePostRequest, orgID)
+		if err != nil {
+			logging.WithError(err).WithField("method", info.FullMethod).Warn("unable to query post request actions")
+			return resp, nil
+		}
+		err = runPostRequestActions(interceptorCtx, postActions, info.FullMethod, orgID, ctxData, request, response)
+		logging.WithFields("method", info.FullMethod, "org", orgID).OnError(err).Warn("post request action failed")
+		return resp, nil
+	}
+}
+
+func runPreRequestActions(ctx context.Context, queriedActions []*query.Action, method, orgID string, ctxData authz.CtxData, request proto.Message) (proto.Message, error) {
+	for _, action := range queriedActions {
+		requestObject, err := messageToObject(request)
+		if err != nil {
+			return nil, err
+		}
+		// an invalid request set by the action is returned as is, instead of denying the request
+		var setRequestErr error
+		apiFields := []actions.FieldOption{
+			actions.SetFields("v1",
+				actions.SetFields("setRequest", func(object interface{}) error {
+					changed, err := objectToMessage(object, request)
+					if err != nil {
+						setRequestErr = err
+						return err
+					}
+					request = changed
+					return nil
+				}),
+			),
+		}
+		err = runRequestAction(ctx, action, domain.TriggerTypePreRequest, requestContextFields(method, orgID, ctxData, requestObject), apiFields)
+		if setRequestErr != nil {
+			return nil, setRequestErr
+		}
+		if err != nil {
+			return nil, errors.ThrowPreconditionFailed(err, "MIDDL-aeK4o", "Errors.Action.Request.Denied")
+		}
+	}
+	return request, nil
+}
+
+func runPostRequestActions(ctx context.Context, queriedActions []*query.Action, method, orgID string, ctxData authz.CtxData, request, response proto.Message) error {
+	if len(queriedActions) == 0 {
+		return nil
+	}
+	requestObject, err := messageToObject(request)
+	if err != nil {
+		return err
+	}
+	responseObject, err := messageToObject(response)
+	if err != nil {
+		return err
+	}
+	for _, action := range queriedActions {
+		ctxFields := append(requestContextFields(method, orgID, ctxData, requestObject),
+			actions.SetFields("v1",
+				actions.SetFields("response", responseObject),
+			),
+		)
+		if err = runRequestAction(ctx, action, domain.TriggerTypePostRequest, ctxFields, nil); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func requestContextFields(method, orgID string, ctxData authz.CtxData, request map[string]interface{}) []actions.FieldOption {
+	return []actions.FieldOption{
+		actions.SetFields("v1",
+			actions.SetFields("method", method),
+			actions.SetFields("orgId", orgID),
+			actions.SetFields("userId", ctxData.UserID),
+			actions.SetFields("request", request),
+		),
+	}
+}
+
+func runRequestAction(ctx context.Context, action *query.Action, triggerType domain.TriggerType, ctxFields, apiFields []actions.FieldOption) error {
+	actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+	defer cancel()
+	return actions.Run(
+		actionCtx,
+		actions.SetContextFields(ctxFields...),
+		actions.WithAPIFields(apiFields...),
+		action.Script,
+		action.Name,
+		append(actions.ActionToOptions(action), actions.WithTrigger(domain.FlowTypeAPIRequest, triggerType), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+	)
+}
+
+// messageToObject returns the json representation of the message as it's used by the REST API
+func messageToObject(message proto.Message) (map[string]interface{}, error) {
+	data, err := protojson.Marshal(message)
+	if err != nil {
+		return nil, err
+	}
+	object := make(map[string]interface{})
+	if err = json.Unmarshal(data, &object); err != nil {
+		return nil, err
+	}
+	return object, nil
+}
+
+// objectToMessage returns a new message of the same type as the message, filled with the object
+func objectToMessage(object interface{}, message proto.Message) (proto.Message, error) {
+	data, err := json.Marshal(object)
+	if err != nil {
+		return nil, err
+	}
+	changed := message.ProtoReflect().New().Interface()
+	if err = protojson.Unmarshal(data, changed); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "MIDDL-Ohx3a", "Errors.Action.Request.Invalid")
+	}
+	return changed, nil
+}
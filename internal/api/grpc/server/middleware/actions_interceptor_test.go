@@ -0,0 +1,224 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/known/structpb"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// mockActionsQueries returns the actions of org1 and the users of org2
+type mockActionsQueries struct {
+	actions map[domain.TriggerType][]*query.Action
+	queried int
+}
+
+func (q *mockActionsQueries) GetActiveActionsByFlowAndTriggerType(_ context.Context, flowType domain.FlowType, triggerType domain.TriggerType, orgID string) ([]*query.Action, error) {
+	q.queried++
+	if flowType != domain.FlowTypeAPIRequest || orgID != "org1" {
+		return nil, nil
+	}
+	return q.actions[triggerType], nil
+}
+
+func (q *mockActionsQueries) GetUserByID(_ context.Context, _ bool, userID string, _ ...query.SearchQuery) (*query.User, error) {
+	return &query.User{ID: userID, ResourceOwner: "org2"}, nil
+}
+
+func mockAction(name, script string) *query.Action {
+	return &query.Action{
+		Name:   name,
+		Script: script,
+	}
+}
+
+func TestActionsInterceptor(t *testing.T) {
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil))
+	type args struct {
+		ctx     context.Context
+		method  string
+		actions map[domain.TriggerType][]*query.Action
+		req     proto.Message
+		handler func(context.Context, interface{}) (interface{}, error)
+	}
+	type res struct {
+		want      proto.Message
+		wantErr   func(error) bool
+		noQueries bool
+	}
+	tests := []struct {
+		name string
+		args args
+		res  res
+	}{
+		{
+			name: "no ctx data, not called",
+			args: args{
+				ctx: context.Background(),
+				actions: map[domain.TriggerType][]*query.Action{
+					domain.TriggerTypePreRequest: {mockAction("deny", "function deny(ctx, api) { throw 'denied' }")},
+				},
+				req:     mustStruct(t, map[string]interface{}{"userName": "user"}),
+				handler: emptyMockHandler,
+			},
+			res: res{
+				want:      mustStruct(t, map[string]interface{}{"userName": "user"}),
+				noQueries: true,
+			},
+		},
+		{
+			name: "method not allowed, not called",
+			args: args{
+				ctx:    authz.SetCtxData(context.Background(), authz.CtxData{UserID: "user1", OrgID: "org1"}),
+				method: "/zitadel.management.v1.ManagementService/GetUserByID",
+				actions: map[domain.TriggerType][]*query.Action{
+					domain.TriggerTypePreRequest: {mockAction("deny", "function deny(ctx, api) { throw 'denied' }")},
+				},
+				req:     mustStruct(t, map[string]interface{}{"id": "user1"}),
+				handler: emptyMockHandler,
+			},
+			res: res{
+				want:      mustStruct(t, map[string]interface{}{"id": "user1"}),
+				noQueries: true,
+			},
+		},
+		{
+			name: "org of changed user, not called",
+			args: args{
+				ctx:    authz.SetCtxData(context.Background(), authz.CtxData{UserID: "user1", OrgID: "org1"}),
+				method: "/zitadel.user.v2beta.UserService/DeactivateUser",
+				actions: map[domain.TriggerType][]*query.Action{
+					domain.TriggerTypePreRequest: {mockAction("deny", "function deny(ctx, api) { throw 'denied' }")},
+				},
+				req:     mustStruct(t, map[string]interface{}{"userId": "user2"}),
+				handler: emptyMockHandler,
+			},
+			res: res{
+				want: mustStruct(t, map[string]interface{}{"userId": "user2"}),
+			},
+		},
+		{
+			name: "pre request denied, error",
+			args: args{
+				ctx: authz.SetCtxData(context.Background(), authz.CtxData{UserID: "user1", OrgID: "org1"}),
+				actions: map[domain.TriggerType][]*query.Action{
+					domain.TriggerTypePreRequest: {mockAction("deny", "function deny(ctx, api) { if (ctx.v1.request.userName == 'admin') { throw 'denied' } }")},
+				},
+				req:     mustStruct(t, map[string]interface{}{"userName": "admin"}),
+				handler: emptyMockHandler,
+			},
+			res: res{
+				wantErr: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "pre request changed",
+			args: args{
+				ctx: authz.SetCtxData(context.Background(), authz.CtxData{UserID: "user1", OrgID: "org1"}),
+				actions: map[domain.TriggerType][]*query.Action{
+					domain.TriggerTypePreRequest: {mockAction("enrich", `function enrich(ctx, api) {
+	let req = ctx.v1.request;
+	req.nickName = ctx.v1.userId + ':' + ctx.v1.method;
+	api.v1.setRequest(req);
+}`)},
+				},
+				req:     mustStruct(t, map[string]interface{}{"userName": "user"}),
+				handler: emptyMockHandler,
+			},
+			res: res{
+				want: mustStruct(t, map[string]interface{}{"userName": "user", "nickName": "user1:/zitadel.management.v1.ManagementService/AddHumanUser"}),
+			},
+		},
+		{
+			name: "pre request changed to invalid request, invalid argument error",
+			args: args{
+				ctx: authz.SetCtxData(context.Background(), authz.CtxData{UserID: "user1", OrgID: "org1"}),
+				actions: map[domain.TriggerType][]*query.Action{
+					domain.TriggerTypePreRequest: {mockAction("invalid", "function invalid(ctx, api) { api.v1.setRequest('invalid') }")},
+				},
+				req:     mustStruct(t, map[string]interface{}{"userName": "user"}),
+				handler: emptyMockHandler,
+			},
+			res: res{
+				wantErr: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "handler error, post request not called",
+			args: args{
+				ctx: authz.SetCtxData(context.Background(), authz.CtxData{UserID: "user1", OrgID: "org1"}),
+				actions: map[domain.TriggerType][]*query.Action{
+					domain.TriggerTypePostRequest: {mockAction("fail", "function fail(ctx, api) { throw 'failed' }")},
+				},
+				req:     mustStruct(t, map[string]interface{}{"userName": "user"}),
+				handler: errorMockHandler,
+			},
+			res: res{
+				wantErr: errors.IsInternal,
+			},
+		},
+		{
+			name: "post request with response",
+			args: args{
+				ctx: authz.SetCtxData(context.Background(), authz.CtxData{UserID: "user1", OrgID: "org1"}),
+				actions: map[domain.TriggerType][]*query.Action{
+					domain.TriggerTypePostRequest: {mockAction("check", "function check(ctx, api) { if (ctx.v1.response.userName != ctx.v1.request.userName) { throw 'wrong response' } }")},
+				},
+				req:     mustStruct(t, map[string]interface{}{"userName": "user"}),
+				handler: emptyMockHandler,
+			},
+			res: res{
+				want: mustStruct(t, map[string]interface{}{"userName": "user"}),
+			},
+		},
+		{
+			name: "post request failed, response returned",
+			args: args{
+				ctx: authz.SetCtxData(context.Background(), authz.CtxData{UserID: "user1", OrgID: "org1"}),
+				actions: map[domain.TriggerType][]*query.Action{
+					domain.TriggerTypePostRequest: {mockAction("fail", "function fail(ctx, api) { throw 'failed' }")},
+				},
+				req:     mustStruct(t, map[string]interface{}{"userName": "user"}),
+				handler: emptyMockHandler,
+			},
+			res: res{
+				want: mustStruct(t, map[string]interface{}{"userName": "user"}),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			method := tt.args.method
+			if method == "" {
+				method = "/zitadel.management.v1.ManagementService/AddHumanUser"
+			}
+			queries := &mockActionsQueries{actions: tt.args.actions}
+			got, err := ActionsInterceptor(queries)(tt.args.ctx, tt.args.req, mockInfo(method), tt.args.handler)
+			if tt.res.wantErr != nil {
+				assert.True(t, tt.res.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.True(t, proto.Equal(tt.res.want, got.(proto.Message)), "got: %v", got)
+			assert.Equal(t, tt.res.noQueries, queries.queried == 0)
+		})
+	}
+}
+
+func mustStruct(t *testing.T, fields map[string]interface{}) *structpb.Struct {
+	t.Helper()
+	s, err := structpb.NewStruct(fields)
+	require.NoError(t, err)
+	return s
+}
@@ -2,17 +2,76 @@ package middleware
 
 import (
 	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	net_http "net/http"
+	"strings"
 
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/status"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	grpc_util "github.com/zitadel/zitadel/internal/api/grpc"
 	"github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
 
+const (
+	// dpopMethod and dpopURI contain the http method and uri of the request to the gateway,
+	// which must match the DPoP proof of a DPoP bound access token.
+	// They are only trusted if dpopGateway contains the [dpopGatewayToken].
+	dpopMethod  = "x-zitadel-dpop-htm"
+	dpopURI     = "x-zitadel-dpop-htu"
+	dpopGateway = "x-zitadel-dpop-gateway"
+)
+
+// dpopGatewayToken is generated on the start of the process,
+// so only the gateway of the same process is able to pass the method and uri of the http request
+var dpopGatewayToken = newDPoPGatewayToken()
+
+func newDPoPGatewayToken() string {
+	token := make([]byte, 32)
+	if _, err := rand.Read(token); err != nil {
+		panic(err)
+	}
+	return base64.RawURLEncoding.EncodeToString(token)
+}
+
+type dpopGatewayRequestKey struct{}
+
+type dpopGatewayRequest struct {
+	method string
+	uri    string
+}
+
+// WithDPoPGatewayRequest is used by the gateway to pass the method and uri of the http request,
+// which are sent to the gRPC server by the [DPoPGatewayClientInterceptor]
+func WithDPoPGatewayRequest(ctx context.Context, method, uri string) context.Context {
+	return context.WithValue(ctx, dpopGatewayRequestKey{}, &dpopGatewayRequest{method: method, uri: uri})
+}
+
+// DPoPGatewayClientInterceptor sets the method and uri of the http request passed by [WithDPoPGatewayRequest]
+// on the calls of the gateway to the gRPC server. Values sent by the client are always overwritten.
+func DPoPGatewayClientInterceptor() grpc.UnaryClientInterceptor {
+	return func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, invoker grpc.UnaryInvoker, opts ...grpc.CallOption) error {
+		md, _ := metadata.FromOutgoingContext(ctx)
+		md = md.Copy()
+		md.Delete(dpopMethod)
+		md.Delete(dpopURI)
+		md.Delete(dpopGateway)
+		if request, ok := ctx.Value(dpopGatewayRequestKey{}).(*dpopGatewayRequest); ok {
+			md.Set(dpopMethod, request.method)
+			md.Set(dpopURI, request.uri)
+			md.Set(dpopGateway, dpopGatewayToken)
+		}
+		return invoker(metadata.NewOutgoingContext(ctx, md), method, req, reply, cc, opts...)
+	}
+}
+
 func AuthorizationInterceptor(verifier authz.APITokenVerifier, authConfig authz.Config) grpc.UnaryServerInterceptor {
 	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
 		return authorize(ctx, req, info, handler, verifier, authConfig)
@@ -20,6 +79,7 @@ func AuthorizationInterceptor(verifier authz.APITokenVerifier, authConfig authz.
 }
 
 func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, verifier authz.APITokenVerifier, authConfig authz.Config) (_ interface{}, err error) {
+	ctx, gatewayRequest := dpopGatewayRequestFromMetadata(ctx)
 	authOpt, needsToken := verifier.CheckAuthMethod(info.FullMethod)
 	if !needsToken {
 		return handler(ctx, req)
@@ -33,6 +93,7 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 		return nil, status.Error(codes.Unauthenticated, "auth header missing")
 	}
 
+	authCtx = dpop.WithRequest(authCtx, dpopRequest(authCtx, authToken, info.FullMethod, gatewayRequest))
 	orgID, orgDomain := orgIDAndDomainFromRequest(authCtx, req)
 	ctxSetter, err := authz.CheckUserAuthorization(authCtx, req, authToken, orgID, orgDomain, verifier, authConfig, authOpt, info.FullMethod)
 	if err != nil {
@@ -42,6 +103,60 @@ func authorize(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo,
 	return handler(ctxSetter(ctx), req)
 }
 
+// dpopRequest returns the information needed to verify a DPoP bound access token.
+// Requests passing the gateway contain the method and uri of the original http request,
+// proofs of direct gRPC calls must be issued for a POST to the full method.
+func dpopRequest(ctx context.Context, authToken, fullMethod string, gatewayRequest *dpopGatewayRequest) *dpop.Request {
+	scheme, _, _ := strings.Cut(authToken, " ")
+	proof := grpc_util.GetHeader(ctx, dpop.HeaderName)
+	if gatewayRequest != nil {
+		return &dpop.Request{
+			Scheme: scheme,
+			Proof:  proof,
+			Method: gatewayRequest.method,
+			URI:    gatewayRequest.uri,
+		}
+	}
+	return &dpop.Request{
+		Scheme: scheme,
+		Proof:  proof,
+		Method: net_http.MethodPost,
+		URI:    http.ComposedOrigin(ctx) + fullMethod,
+	}
+}
+
+// dpopGatewayRequestFromMetadata returns the method and uri of the http request, if they were sent by the gateway.
+// They are always removed from the incoming metadata, so the values of direct gRPC calls are never used.
+func dpopGatewayRequestFromMetadata(ctx context.Context) (context.Context, *dpopGatewayRequest) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ctx, nil
+	}
+	var request *dpopGatewayRequest
+	if token := firstMetadataValue(md, dpopGateway); token != "" && subtle.ConstantTimeCompare([]byte(token), []byte(dpopGatewayToken)) == 1 {
+		request = &dpopGatewayRequest{
+			method: firstMetadataValue(md, dpopMethod),
+			uri:    firstMetadataValue(md, dpopURI),
+		}
+	}
+	if len(md.Get(dpopMethod)) == 0 && len(md.Get(dpopURI)) == 0 && len(md.Get(dpopGateway)) == 0 {
+		return ctx, request
+	}
+	md = md.Copy()
+	md.Delete(dpopMethod)
+	md.Delete(dpopURI)
+	md.Delete(dpopGateway)
+	return metadata.NewIncomingContext(ctx, md), request
+}
+
+func firstMetadataValue(md metadata.MD, key string) string {
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0]
+}
+
 func orgIDAndDomainFromRequest(ctx context.Context, req interface{}) (id, domain string) {
 	orgID := grpc_util.GetHeader(ctx, http.ZitadelOrgID)
 	o, ok := req.(OrganizationFromRequest)
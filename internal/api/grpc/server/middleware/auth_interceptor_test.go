@@ -263,3 +263,81 @@ func Test_authorize(t *testing.T) {
 		})
 	}
 }
+
+func Test_dpopGatewayRequestFromMetadata(t *testing.T) {
+	tests := []struct {
+		name string
+		md   metadata.MD
+		want *dpopGatewayRequest
+	}{
+		{
+			name: "no metadata",
+			md:   metadata.Pairs(),
+		},
+		{
+			name: "direct call",
+			md:   metadata.Pairs(dpopMethod, "GET", dpopURI, "https://attacker.com/v2/users"),
+		},
+		{
+			name: "wrong gateway token",
+			md:   metadata.Pairs(dpopMethod, "GET", dpopURI, "https://attacker.com/v2/users", dpopGateway, "token"),
+		},
+		{
+			name: "gateway",
+			md:   metadata.Pairs(dpopMethod, "GET", dpopURI, "https://zitadel.cloud/v2/users", dpopGateway, dpopGatewayToken),
+			want: &dpopGatewayRequest{method: "GET", uri: "https://zitadel.cloud/v2/users"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, got := dpopGatewayRequestFromMetadata(metadata.NewIncomingContext(context.Background(), tt.md))
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("dpopGatewayRequestFromMetadata() = %v, want %v", got, tt.want)
+			}
+			md, _ := metadata.FromIncomingContext(ctx)
+			for _, key := range []string{dpopMethod, dpopURI, dpopGateway} {
+				if values := md.Get(key); len(values) > 0 {
+					t.Errorf("metadata %s not removed: %v", key, values)
+				}
+			}
+		})
+	}
+}
+
+func TestDPoPGatewayClientInterceptor(t *testing.T) {
+	tests := []struct {
+		name string
+		ctx  context.Context
+		want metadata.MD
+	}{
+		{
+			name: "without gateway request, client metadata removed",
+			ctx:  metadata.AppendToOutgoingContext(context.Background(), dpopMethod, "GET", dpopURI, "https://attacker.com", "key", "value"),
+			want: metadata.Pairs("key", "value"),
+		},
+		{
+			name: "gateway request overwrites client metadata",
+			ctx: WithDPoPGatewayRequest(
+				metadata.AppendToOutgoingContext(context.Background(), dpopMethod, "GET", dpopGateway, "token"),
+				"POST", "https://zitadel.cloud/v2/users",
+			),
+			want: metadata.Pairs(dpopMethod, "POST", dpopURI, "https://zitadel.cloud/v2/users", dpopGateway, dpopGatewayToken),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got metadata.MD
+			invoker := func(ctx context.Context, method string, req, reply interface{}, cc *grpc.ClientConn, opts ...grpc.CallOption) error {
+				got, _ = metadata.FromOutgoingContext(ctx)
+				return nil
+			}
+			err := DPoPGatewayClientInterceptor()(tt.ctx, "/zitadel.user.v2beta.UserService/ListUsers", nil, nil, nil, invoker)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("metadata = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
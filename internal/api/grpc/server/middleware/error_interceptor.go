@@ -16,6 +16,12 @@ func ErrorHandler() grpc.UnaryServerInterceptor {
 	}
 }
 
+func ErrorStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return errors.CaosToGRPCError(stream.Context(), handler(srv, stream))
+	}
+}
+
 func toGRPCError(ctx context.Context, req interface{}, handler grpc.UnaryHandler) (interface{}, error) {
 	resp, err := handler(ctx, req)
 	return resp, errors.CaosToGRPCError(ctx, err)
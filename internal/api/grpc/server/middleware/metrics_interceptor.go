@@ -30,6 +30,16 @@ func MetricsHandler(metricTypes []metrics.MetricType, ignoredMethodSuffixes ...s
 	}
 }
 
+// MetricsStreamHandler registers the same metrics as [MetricsHandler] as soon as the stream is closed
+func MetricsStreamHandler(metricTypes []metrics.MetricType, ignoredMethodSuffixes ...string) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		_, err := RegisterMetrics(stream.Context(), nil, &grpc.UnaryServerInfo{Server: srv, FullMethod: info.FullMethod}, func(context.Context, interface{}) (interface{}, error) {
+			return nil, handler(srv, stream)
+		}, metricTypes, ignoredMethodSuffixes...)
+		return err
+	}
+}
+
 func RegisterMetrics(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler, metricTypes []metrics.MetricType, ignoredMethodSuffixes ...string) (_ interface{}, err error) {
 	if len(metricTypes) == 0 {
 		return handler(ctx, req)
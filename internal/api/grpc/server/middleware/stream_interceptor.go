@@ -0,0 +1,63 @@
+package middleware
+
+import (
+	"context"
+
+	"google.golang.org/grpc"
+)
+
+// UnaryToStreamInterceptor runs the unary interceptor on the first message received on the stream.
+// The context passed to the unary handler is used as context of the stream.
+// It's only suitable for server streaming calls, where the client sends exactly one request.
+func UnaryToStreamInterceptor(interceptor grpc.UnaryServerInterceptor) grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		return handler(srv, &interceptedStream{
+			ServerStream: stream,
+			intercept: func(ctx context.Context, req interface{}) (context.Context, error) {
+				return interceptRequest(ctx, req, srv, info.FullMethod, interceptor)
+			},
+		})
+	}
+}
+
+func interceptRequest(ctx context.Context, req, srv interface{}, fullMethod string, interceptor grpc.UnaryServerInterceptor) (context.Context, error) {
+	handlerCtx := ctx
+	_, err := interceptor(ctx, req, &grpc.UnaryServerInfo{Server: srv, FullMethod: fullMethod}, func(ctx context.Context, _ interface{}) (interface{}, error) {
+		handlerCtx = ctx
+		return nil, nil
+	})
+	return handlerCtx, err
+}
+
+type interceptedStream struct {
+	grpc.ServerStream
+	intercept   func(ctx context.Context, req interface{}) (context.Context, error)
+	ctx         context.Context
+	intercepted bool
+}
+
+// Context returns the context set by the interceptor
+// as soon as the first message is received
+func (s *interceptedStream) Context() context.Context {
+	if s.ctx == nil {
+		return s.ServerStream.Context()
+	}
+	return s.ctx
+}
+
+func (s *interceptedStream) RecvMsg(m interface{}) error {
+	if err := s.ServerStream.RecvMsg(m); err != nil {
+		return err
+	}
+	if s.intercepted {
+		return nil
+	}
+	s.intercepted = true
+	// the context of the wrapped stream contains the values set by the previous interceptors
+	ctx, err := s.intercept(s.ServerStream.Context(), m)
+	if err != nil {
+		return err
+	}
+	s.ctx = ctx
+	return nil
+}
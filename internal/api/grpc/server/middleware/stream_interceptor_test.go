@@ -0,0 +1,73 @@
+package middleware
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type mockServerStream struct {
+	grpc.ServerStream
+	ctx context.Context
+}
+
+func (s *mockServerStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *mockServerStream) RecvMsg(interface{}) error {
+	return nil
+}
+
+type ctxKey struct{}
+
+func Test_UnaryToStreamInterceptor(t *testing.T) {
+	tests := []struct {
+		name        string
+		interceptor grpc.UnaryServerInterceptor
+		wantValue   interface{}
+		wantErr     bool
+	}{
+		{
+			name: "context of handler used",
+			interceptor: func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				return handler(context.WithValue(ctx, ctxKey{}, info.FullMethod), req)
+			},
+			wantValue: "/service/method",
+		},
+		{
+			name: "interceptor error",
+			interceptor: func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+				return nil, errors.ThrowPermissionDenied(nil, "test", "denied")
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var gotValue interface{}
+			err := UnaryToStreamInterceptor(tt.interceptor)(
+				nil,
+				&mockServerStream{ctx: context.Background()},
+				&grpc.StreamServerInfo{FullMethod: "/service/method", IsServerStream: true},
+				func(_ interface{}, stream grpc.ServerStream) error {
+					if err := stream.RecvMsg(&mockReq{}); err != nil {
+						return err
+					}
+					gotValue = stream.Context().Value(ctxKey{})
+					return nil
+				},
+			)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.wantValue, gotValue)
+		})
+	}
+}
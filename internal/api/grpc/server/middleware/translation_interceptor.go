@@ -7,6 +7,7 @@ import (
 	"google.golang.org/grpc"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/i18n"
 	_ "github.com/zitadel/zitadel/internal/statik"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
@@ -36,3 +37,46 @@ func TranslationHandler() func(ctx context.Context, req interface{}, info *grpc.
 		return resp, err
 	}
 }
+
+// TranslationStreamHandler translates the messages sent on the stream and the returned error.
+// It must be the last stream interceptor, so that the context of the stream contains the instance.
+func TranslationStreamHandler() grpc.StreamServerInterceptor {
+	return func(srv interface{}, stream grpc.ServerStream, info *grpc.StreamServerInfo, handler grpc.StreamHandler) error {
+		translated := &translatedStream{ServerStream: stream}
+		err := handler(srv, translated)
+		if err == nil {
+			return nil
+		}
+		translator, translatorError := translated.getTranslator()
+		if translatorError != nil {
+			logging.New().WithError(translatorError).Error("could not load translator")
+			return err
+		}
+		return translateError(translated.Context(), err, translator)
+	}
+}
+
+type translatedStream struct {
+	grpc.ServerStream
+	translator *i18n.Translator
+}
+
+func (s *translatedStream) SendMsg(m interface{}) error {
+	if loc, ok := m.(localizers); ok && m != nil {
+		translator, err := s.getTranslator()
+		if err != nil {
+			logging.New().WithError(err).Error("could not load translator")
+			return s.ServerStream.SendMsg(m)
+		}
+		translateFields(s.Context(), loc, translator)
+	}
+	return s.ServerStream.SendMsg(m)
+}
+
+func (s *translatedStream) getTranslator() (_ *i18n.Translator, err error) {
+	if s.translator != nil {
+		return s.translator, nil
+	}
+	s.translator, err = newZitadelTranslator(authz.GetInstance(s.Context()).DefaultLanguage())
+	return s.translator, err
+}
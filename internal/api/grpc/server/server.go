@@ -56,11 +56,27 @@ func CreateServer(
 				middleware.AuthorizationInterceptor(verifier, authConfig),
 				middleware.QuotaExhaustedInterceptor(accessSvc, system_pb.SystemService_ServiceDesc.ServiceName),
 				middleware.TranslationHandler(),
+				middleware.ActionsInterceptor(queries),
 				middleware.ValidationHandler(),
 				middleware.ServiceHandler(),
 				middleware.ActivityInterceptor(),
 			),
 		),
+		grpc.StreamInterceptor(
+			grpc_middleware.ChainStreamServer(
+				middleware.CallDurationStreamHandler(),
+				middleware.DefaultTracingStreamServer(),
+				middleware.MetricsStreamHandler(metricTypes, grpc_api.Probes...),
+				middleware.UnaryToStreamInterceptor(middleware.InstanceInterceptor(queries, hostHeaderName, system_pb.SystemService_ServiceDesc.ServiceName, healthpb.Health_ServiceDesc.ServiceName)),
+				middleware.AccessStorageStreamInterceptor(accessSvc),
+				middleware.ErrorStreamHandler(),
+				middleware.UnaryToStreamInterceptor(middleware.AuthorizationInterceptor(verifier, authConfig)),
+				middleware.UnaryToStreamInterceptor(middleware.QuotaExhaustedInterceptor(accessSvc, system_pb.SystemService_ServiceDesc.ServiceName)),
+				middleware.UnaryToStreamInterceptor(middleware.ValidationHandler()),
+				middleware.UnaryToStreamInterceptor(middleware.ServiceHandler()),
+				middleware.TranslationStreamHandler(),
+			),
+		),
 	}
 	if tlsConfig != nil {
 		serverOptions = append(serverOptions, grpc.Creds(credentials.NewTLS(tlsConfig)))
@@ -9,6 +9,7 @@ import (
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
 	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	settings "github.com/zitadel/zitadel/pkg/grpc/settings/v2beta"
 )
@@ -19,6 +20,7 @@ type Server struct {
 	settings.UnimplementedSettingsServiceServer
 	command         *command.Commands
 	query           *query.Queries
+	checkPermission domain.PermissionCheck
 	assetsAPIDomain func(context.Context) string
 }
 
@@ -27,11 +29,13 @@ type Config struct{}
 func CreateServer(
 	command *command.Commands,
 	query *query.Queries,
+	checkPermission domain.PermissionCheck,
 	externalSecure bool,
 ) *Server {
 	return &Server{
 		command:         command,
 		query:           query,
+		checkPermission: checkPermission,
 		assetsAPIDomain: assets.AssetAPI(externalSecure),
 	}
 }
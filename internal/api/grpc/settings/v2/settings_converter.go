@@ -3,6 +3,7 @@ package settings
 import (
 	"google.golang.org/protobuf/types/known/durationpb"
 
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	settings "github.com/zitadel/zitadel/pkg/grpc/settings/v2beta"
@@ -42,6 +43,60 @@ func loginSettingsToPb(current *query.LoginPolicy) *settings.LoginSettings {
 	}
 }
 
+func loginSettingsToCommand(req *settings.LoginSettings) *command.AddLoginPolicy {
+	multi := make([]domain.MultiFactorType, len(req.GetMultiFactors()))
+	for i, typ := range req.GetMultiFactors() {
+		multi[i] = multiFactorTypeToDomain(typ)
+	}
+	second := make([]domain.SecondFactorType, len(req.GetSecondFactors()))
+	for i, typ := range req.GetSecondFactors() {
+		second[i] = secondFactorTypeToDomain(typ)
+	}
+	return &command.AddLoginPolicy{
+		AllowUsernamePassword:      req.GetAllowUsernamePassword(),
+		AllowRegister:              req.GetAllowRegister(),
+		AllowExternalIDP:           req.GetAllowExternalIdp(),
+		ForceMFA:                   req.GetForceMfa(),
+		ForceMFALocalOnly:          req.GetForceMfaLocalOnly(),
+		SecondFactors:              second,
+		MultiFactors:               multi,
+		PasswordlessType:           passkeysTypeToDomain(req.GetPasskeysType()),
+		HidePasswordReset:          req.GetHidePasswordReset(),
+		IgnoreUnknownUsernames:     req.GetIgnoreUnknownUsernames(),
+		AllowDomainDiscovery:       req.GetAllowDomainDiscovery(),
+		DefaultRedirectURI:         req.GetDefaultRedirectUri(),
+		PasswordCheckLifetime:      req.GetPasswordCheckLifetime().AsDuration(),
+		ExternalLoginCheckLifetime: req.GetExternalLoginCheckLifetime().AsDuration(),
+		MFAInitSkipLifetime:        req.GetMfaInitSkipLifetime().AsDuration(),
+		SecondFactorCheckLifetime:  req.GetSecondFactorCheckLifetime().AsDuration(),
+		MultiFactorCheckLifetime:   req.GetMultiFactorCheckLifetime().AsDuration(),
+		DisableLoginWithEmail:      req.GetDisableLoginWithEmail(),
+		DisableLoginWithPhone:      req.GetDisableLoginWithPhone(),
+	}
+}
+
+func loginSettingsToChangeCommand(policy *command.AddLoginPolicy) *command.ChangeLoginPolicy {
+	return &command.ChangeLoginPolicy{
+		AllowUsernamePassword:      policy.AllowUsernamePassword,
+		AllowRegister:              policy.AllowRegister,
+		AllowExternalIDP:           policy.AllowExternalIDP,
+		ForceMFA:                   policy.ForceMFA,
+		ForceMFALocalOnly:          policy.ForceMFALocalOnly,
+		PasswordlessType:           policy.PasswordlessType,
+		HidePasswordReset:          policy.HidePasswordReset,
+		IgnoreUnknownUsernames:     policy.IgnoreUnknownUsernames,
+		AllowDomainDiscovery:       policy.AllowDomainDiscovery,
+		DefaultRedirectURI:         policy.DefaultRedirectURI,
+		PasswordCheckLifetime:      policy.PasswordCheckLifetime,
+		ExternalLoginCheckLifetime: policy.ExternalLoginCheckLifetime,
+		MFAInitSkipLifetime:        policy.MFAInitSkipLifetime,
+		SecondFactorCheckLifetime:  policy.SecondFactorCheckLifetime,
+		MultiFactorCheckLifetime:   policy.MultiFactorCheckLifetime,
+		DisableLoginWithEmail:      policy.DisableLoginWithEmail,
+		DisableLoginWithPhone:      policy.DisableLoginWithPhone,
+	}
+}
+
 func isDefaultToResourceOwnerTypePb(isDefault bool) settings.ResourceOwnerType {
 	if isDefault {
 		return settings.ResourceOwnerType_RESOURCE_OWNER_TYPE_INSTANCE
@@ -60,6 +115,17 @@ func passkeysTypeToPb(passwordlessType domain.PasswordlessType) settings.Passkey
 	}
 }
 
+func passkeysTypeToDomain(passkeysType settings.PasskeysType) domain.PasswordlessType {
+	switch passkeysType {
+	case settings.PasskeysType_PASSKEYS_TYPE_ALLOWED:
+		return domain.PasswordlessTypeAllowed
+	case settings.PasskeysType_PASSKEYS_TYPE_NOT_ALLOWED:
+		return domain.PasswordlessTypeNotAllowed
+	default:
+		return domain.PasswordlessTypeNotAllowed
+	}
+}
+
 func secondFactorTypeToPb(secondFactorType domain.SecondFactorType) settings.SecondFactorType {
 	switch secondFactorType {
 	case domain.SecondFactorTypeTOTP:
@@ -77,6 +143,23 @@ func secondFactorTypeToPb(secondFactorType domain.SecondFactorType) settings.Sec
 	}
 }
 
+func secondFactorTypeToDomain(secondFactorType settings.SecondFactorType) domain.SecondFactorType {
+	switch secondFactorType {
+	case settings.SecondFactorType_SECOND_FACTOR_TYPE_OTP:
+		return domain.SecondFactorTypeTOTP
+	case settings.SecondFactorType_SECOND_FACTOR_TYPE_U2F:
+		return domain.SecondFactorTypeU2F
+	case settings.SecondFactorType_SECOND_FACTOR_TYPE_OTP_EMAIL:
+		return domain.SecondFactorTypeOTPEmail
+	case settings.SecondFactorType_SECOND_FACTOR_TYPE_OTP_SMS:
+		return domain.SecondFactorTypeOTPSMS
+	case settings.SecondFactorType_SECOND_FACTOR_TYPE_UNSPECIFIED:
+		return domain.SecondFactorTypeUnspecified
+	default:
+		return domain.SecondFactorTypeUnspecified
+	}
+}
+
 func multiFactorTypeToPb(typ domain.MultiFactorType) settings.MultiFactorType {
 	switch typ {
 	case domain.MultiFactorTypeU2FWithPIN:
@@ -88,6 +171,17 @@ func multiFactorTypeToPb(typ domain.MultiFactorType) settings.MultiFactorType {
 	}
 }
 
+func multiFactorTypeToDomain(typ settings.MultiFactorType) domain.MultiFactorType {
+	switch typ {
+	case settings.MultiFactorType_MULTI_FACTOR_TYPE_U2F_WITH_VERIFICATION:
+		return domain.MultiFactorTypeU2FWithPIN
+	case settings.MultiFactorType_MULTI_FACTOR_TYPE_UNSPECIFIED:
+		return domain.MultiFactorTypeUnspecified
+	default:
+		return domain.MultiFactorTypeUnspecified
+	}
+}
+
 func passwordSettingsToPb(current *query.PasswordComplexityPolicy) *settings.PasswordComplexitySettings {
 	return &settings.PasswordComplexitySettings{
 		MinLength:         current.MinLength,
@@ -99,6 +193,16 @@ func passwordSettingsToPb(current *query.PasswordComplexityPolicy) *settings.Pas
 	}
 }
 
+func passwordSettingsToDomain(req *settings.PasswordComplexitySettings) *domain.PasswordComplexityPolicy {
+	return &domain.PasswordComplexityPolicy{
+		MinLength:    req.GetMinLength(),
+		HasUppercase: req.GetRequiresUppercase(),
+		HasLowercase: req.GetRequiresLowercase(),
+		HasNumber:    req.GetRequiresNumber(),
+		HasSymbol:    req.GetRequiresSymbol(),
+	}
+}
+
 func brandingSettingsToPb(current *query.LabelPolicy, assetPrefix string) *settings.BrandingSettings {
 	return &settings.BrandingSettings{
 		LightTheme:          themeToPb(current.Light, assetPrefix, current.ResourceOwner),
@@ -111,6 +215,25 @@ func brandingSettingsToPb(current *query.LabelPolicy, assetPrefix string) *setti
 	}
 }
 
+// brandingSettingsToDomain maps the colors and options of the branding settings,
+// the assets (logos, icons and font) are managed separately through the assets API.
+func brandingSettingsToDomain(req *settings.BrandingSettings, errorMsgPopup bool) *domain.LabelPolicy {
+	return &domain.LabelPolicy{
+		PrimaryColor:        req.GetLightTheme().GetPrimaryColor(),
+		BackgroundColor:     req.GetLightTheme().GetBackgroundColor(),
+		WarnColor:           req.GetLightTheme().GetWarnColor(),
+		FontColor:           req.GetLightTheme().GetFontColor(),
+		PrimaryColorDark:    req.GetDarkTheme().GetPrimaryColor(),
+		BackgroundColorDark: req.GetDarkTheme().GetBackgroundColor(),
+		WarnColorDark:       req.GetDarkTheme().GetWarnColor(),
+		FontColorDark:       req.GetDarkTheme().GetFontColor(),
+		HideLoginNameSuffix: req.GetHideLoginNameSuffix(),
+		ErrorMsgPopup:       errorMsgPopup,
+		DisableWatermark:    req.GetDisableWatermark(),
+		ThemeMode:           themeModeToDomain(req.GetThemeMode()),
+	}
+}
+
 func themeModeToPb(themeMode domain.LabelPolicyThemeMode) settings.ThemeMode {
 	switch themeMode {
 	case domain.LabelPolicyThemeAuto:
@@ -124,6 +247,21 @@ func themeModeToPb(themeMode domain.LabelPolicyThemeMode) settings.ThemeMode {
 	}
 }
 
+func themeModeToDomain(themeMode settings.ThemeMode) domain.LabelPolicyThemeMode {
+	switch themeMode {
+	case settings.ThemeMode_THEME_MODE_AUTO:
+		return domain.LabelPolicyThemeAuto
+	case settings.ThemeMode_THEME_MODE_LIGHT:
+		return domain.LabelPolicyThemeLight
+	case settings.ThemeMode_THEME_MODE_DARK:
+		return domain.LabelPolicyThemeDark
+	case settings.ThemeMode_THEME_MODE_UNSPECIFIED:
+		return domain.LabelPolicyThemeAuto
+	default:
+		return domain.LabelPolicyThemeAuto
+	}
+}
+
 func themeToPb(theme query.Theme, assetPrefix, resourceOwner string) *settings.Theme {
 	return &settings.Theme{
 		PrimaryColor:    theme.PrimaryColor,
@@ -161,6 +299,13 @@ func lockoutSettingsToPb(current *query.LockoutPolicy) *settings.LockoutSettings
 	}
 }
 
+func lockoutSettingsToDomain(req *settings.LockoutSettings, showLockOutFailures bool) *domain.LockoutPolicy {
+	return &domain.LockoutPolicy{
+		MaxPasswordAttempts: req.GetMaxPasswordAttempts(),
+		ShowLockOutFailures: showLockOutFailures,
+	}
+}
+
 func identityProvidersToPb(idps []*query.IDPLoginPolicyLink) []*settings.IdentityProvider {
 	providers := make([]*settings.IdentityProvider, len(idps))
 	for i, idp := range idps {
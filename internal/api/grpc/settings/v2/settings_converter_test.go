@@ -12,6 +12,7 @@ import (
 	"google.golang.org/protobuf/types/known/durationpb"
 
 	"github.com/zitadel/zitadel/internal/api/grpc"
+	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 	settings "github.com/zitadel/zitadel/pkg/grpc/settings/v2beta"
@@ -87,6 +88,59 @@ func Test_loginSettingsToPb(t *testing.T) {
 	}
 }
 
+func Test_loginSettingsToCommand(t *testing.T) {
+	arg := &settings.LoginSettings{
+		AllowUsernamePassword:      true,
+		AllowRegister:              true,
+		AllowExternalIdp:           true,
+		ForceMfa:                   true,
+		ForceMfaLocalOnly:          true,
+		PasskeysType:               settings.PasskeysType_PASSKEYS_TYPE_ALLOWED,
+		HidePasswordReset:          true,
+		IgnoreUnknownUsernames:     true,
+		AllowDomainDiscovery:       true,
+		DisableLoginWithEmail:      true,
+		DisableLoginWithPhone:      true,
+		DefaultRedirectUri:         "example.com",
+		PasswordCheckLifetime:      durationpb.New(time.Hour),
+		ExternalLoginCheckLifetime: durationpb.New(time.Minute),
+		MfaInitSkipLifetime:        durationpb.New(time.Millisecond),
+		SecondFactorCheckLifetime:  durationpb.New(time.Microsecond),
+		MultiFactorCheckLifetime:   durationpb.New(time.Nanosecond),
+		SecondFactors: []settings.SecondFactorType{
+			settings.SecondFactorType_SECOND_FACTOR_TYPE_OTP,
+			settings.SecondFactorType_SECOND_FACTOR_TYPE_U2F,
+		},
+		MultiFactors: []settings.MultiFactorType{
+			settings.MultiFactorType_MULTI_FACTOR_TYPE_U2F_WITH_VERIFICATION,
+		},
+		ResourceOwnerType: settings.ResourceOwnerType_RESOURCE_OWNER_TYPE_INSTANCE,
+	}
+	want := &command.AddLoginPolicy{
+		AllowUsernamePassword:      true,
+		AllowRegister:              true,
+		AllowExternalIDP:           true,
+		ForceMFA:                   true,
+		ForceMFALocalOnly:          true,
+		SecondFactors:              []domain.SecondFactorType{domain.SecondFactorTypeTOTP, domain.SecondFactorTypeU2F},
+		MultiFactors:               []domain.MultiFactorType{domain.MultiFactorTypeU2FWithPIN},
+		PasswordlessType:           domain.PasswordlessTypeAllowed,
+		HidePasswordReset:          true,
+		IgnoreUnknownUsernames:     true,
+		AllowDomainDiscovery:       true,
+		DefaultRedirectURI:         "example.com",
+		PasswordCheckLifetime:      time.Hour,
+		ExternalLoginCheckLifetime: time.Minute,
+		MFAInitSkipLifetime:        time.Millisecond,
+		SecondFactorCheckLifetime:  time.Microsecond,
+		MultiFactorCheckLifetime:   time.Nanosecond,
+		DisableLoginWithEmail:      true,
+		DisableLoginWithPhone:      true,
+	}
+	got := loginSettingsToCommand(arg)
+	assert.Equal(t, want, got)
+}
+
 func Test_isDefaultToResourceOwnerTypePb(t *testing.T) {
 	type args struct {
 		isDefault bool
@@ -292,6 +346,45 @@ func Test_brandingSettingsToPb(t *testing.T) {
 	}
 }
 
+func Test_brandingSettingsToDomain(t *testing.T) {
+	arg := &settings.BrandingSettings{
+		LightTheme: &settings.Theme{
+			PrimaryColor:    "red",
+			WarnColor:       "white",
+			BackgroundColor: "blue",
+			FontColor:       "orange",
+			LogoUrl:         "ignored",
+		},
+		DarkTheme: &settings.Theme{
+			PrimaryColor:    "magenta",
+			WarnColor:       "pink",
+			BackgroundColor: "black",
+			FontColor:       "white",
+			IconUrl:         "ignored",
+		},
+		FontUrl:             "ignored",
+		HideLoginNameSuffix: true,
+		DisableWatermark:    true,
+		ThemeMode:           settings.ThemeMode_THEME_MODE_DARK,
+	}
+	want := &domain.LabelPolicy{
+		PrimaryColor:        "red",
+		BackgroundColor:     "blue",
+		WarnColor:           "white",
+		FontColor:           "orange",
+		PrimaryColorDark:    "magenta",
+		BackgroundColorDark: "black",
+		WarnColorDark:       "pink",
+		FontColorDark:       "white",
+		HideLoginNameSuffix: true,
+		ErrorMsgPopup:       true,
+		DisableWatermark:    true,
+		ThemeMode:           domain.LabelPolicyThemeDark,
+	}
+	got := brandingSettingsToDomain(arg, true)
+	assert.Equal(t, want, got)
+}
+
 func Test_domainSettingsToPb(t *testing.T) {
 	arg := &query.DomainPolicy{
 		UserLoginMustBeDomain:                  true,
@@ -0,0 +1,212 @@
+package settings
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object/v2beta"
+	"github.com/zitadel/zitadel/pkg/grpc/settings/v2beta"
+)
+
+func (s *Server) SetLoginSettings(ctx context.Context, req *settings.SetLoginSettingsRequest) (*settings.SetLoginSettingsResponse, error) {
+	orgID, err := s.checkSetPermission(ctx, req.GetCtx())
+	if err != nil {
+		return nil, err
+	}
+	policy := loginSettingsToCommand(req.GetSettings())
+	var details *domain.ObjectDetails
+	if orgID == "" {
+		details, err = s.command.ChangeDefaultLoginPolicy(ctx, loginSettingsToChangeCommand(policy))
+	} else {
+		details, err = s.command.SetLoginPolicy(ctx, orgID, policy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings.SetLoginSettingsResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ResetLoginSettings(ctx context.Context, req *settings.ResetLoginSettingsRequest) (*settings.ResetLoginSettingsResponse, error) {
+	orgID, err := s.checkResetPermission(ctx, req.GetCtx())
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.RemoveLoginPolicy(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &settings.ResetLoginSettingsResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) SetPasswordComplexitySettings(ctx context.Context, req *settings.SetPasswordComplexitySettingsRequest) (*settings.SetPasswordComplexitySettingsResponse, error) {
+	orgID, err := s.checkSetPermission(ctx, req.GetCtx())
+	if err != nil {
+		return nil, err
+	}
+	policy := passwordSettingsToDomain(req.GetSettings())
+	var result *domain.PasswordComplexityPolicy
+	if orgID == "" {
+		result, err = s.command.ChangeDefaultPasswordComplexityPolicy(ctx, policy)
+	} else {
+		result, err = s.command.SetPasswordComplexityPolicy(ctx, orgID, policy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings.SetPasswordComplexitySettingsResponse{
+		Details: &object_pb.Details{
+			Sequence:      result.Sequence,
+			ChangeDate:    timestamppb.New(result.ChangeDate),
+			ResourceOwner: result.ResourceOwner,
+		},
+	}, nil
+}
+
+func (s *Server) ResetPasswordComplexitySettings(ctx context.Context, req *settings.ResetPasswordComplexitySettingsRequest) (*settings.ResetPasswordComplexitySettingsResponse, error) {
+	orgID, err := s.checkResetPermission(ctx, req.GetCtx())
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.RemovePasswordComplexityPolicy(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &settings.ResetPasswordComplexitySettingsResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) SetBrandingSettings(ctx context.Context, req *settings.SetBrandingSettingsRequest) (*settings.SetBrandingSettingsResponse, error) {
+	orgID, err := s.checkSetPermission(ctx, req.GetCtx())
+	if err != nil {
+		return nil, err
+	}
+	// the error popup is not part of the branding settings and must therefore be kept
+	current, err := s.query.PreviewLabelPolicyByOrg(ctx, object.ResourceOwnerFromReq(ctx, req.GetCtx()))
+	if err != nil {
+		return nil, err
+	}
+	policy := brandingSettingsToDomain(req.GetSettings(), current.ShouldErrorPopup)
+	var result *domain.LabelPolicy
+	if orgID == "" {
+		result, err = s.command.ChangeDefaultLabelPolicy(ctx, policy)
+	} else {
+		result, err = s.command.SetLabelPolicy(ctx, orgID, policy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings.SetBrandingSettingsResponse{
+		Details: &object_pb.Details{
+			Sequence:      result.Sequence,
+			ChangeDate:    timestamppb.New(result.ChangeDate),
+			ResourceOwner: result.ResourceOwner,
+		},
+	}, nil
+}
+
+func (s *Server) ActivateBrandingSettings(ctx context.Context, req *settings.ActivateBrandingSettingsRequest) (*settings.ActivateBrandingSettingsResponse, error) {
+	orgID, err := s.checkSetPermission(ctx, req.GetCtx())
+	if err != nil {
+		return nil, err
+	}
+	var details *domain.ObjectDetails
+	if orgID == "" {
+		details, err = s.command.ActivateDefaultLabelPolicy(ctx)
+	} else {
+		details, err = s.command.ActivateLabelPolicy(ctx, orgID)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings.ActivateBrandingSettingsResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ResetBrandingSettings(ctx context.Context, req *settings.ResetBrandingSettingsRequest) (*settings.ResetBrandingSettingsResponse, error) {
+	orgID, err := s.checkResetPermission(ctx, req.GetCtx())
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.RemoveLabelPolicy(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &settings.ResetBrandingSettingsResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) SetLockoutSettings(ctx context.Context, req *settings.SetLockoutSettingsRequest) (*settings.SetLockoutSettingsResponse, error) {
+	orgID, err := s.checkSetPermission(ctx, req.GetCtx())
+	if err != nil {
+		return nil, err
+	}
+	// showing the lockout failures is not part of the lockout settings and must therefore be kept
+	current, err := s.query.LockoutPolicyByOrg(ctx, true, object.ResourceOwnerFromReq(ctx, req.GetCtx()), false)
+	if err != nil {
+		return nil, err
+	}
+	policy := lockoutSettingsToDomain(req.GetSettings(), current.ShowFailures)
+	var result *domain.LockoutPolicy
+	if orgID == "" {
+		result, err = s.command.ChangeDefaultLockoutPolicy(ctx, policy)
+	} else {
+		result, err = s.command.SetLockoutPolicy(ctx, orgID, policy)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &settings.SetLockoutSettingsResponse{
+		Details: &object_pb.Details{
+			Sequence:      result.Sequence,
+			ChangeDate:    timestamppb.New(result.ChangeDate),
+			ResourceOwner: result.ResourceOwner,
+		},
+	}, nil
+}
+
+func (s *Server) ResetLockoutSettings(ctx context.Context, req *settings.ResetLockoutSettingsRequest) (*settings.ResetLockoutSettingsResponse, error) {
+	orgID, err := s.checkResetPermission(ctx, req.GetCtx())
+	if err != nil {
+		return nil, err
+	}
+	details, err := s.command.RemoveLockoutPolicy(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	return &settings.ResetLockoutSettingsResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+// checkSetPermission checks if the settings of the requested context can be written
+// and returns the id of the organization, or an empty string if the settings of the instance are requested.
+func (s *Server) checkSetPermission(ctx context.Context, reqCtx *object_pb.RequestContext) (string, error) {
+	if reqCtx.GetInstance() {
+		instanceID := authz.GetInstance(ctx).InstanceID()
+		return "", s.checkPermission(ctx, domain.PermissionIAMPolicyWrite, "", instanceID)
+	}
+	orgID := object.ResourceOwnerFromReq(ctx, reqCtx)
+	return orgID, s.checkPermission(ctx, domain.PermissionPolicyWrite, orgID, orgID)
+}
+
+// checkResetPermission checks if the settings of the requested organization can be removed.
+// The settings of the instance can not be reset, as there is nothing they could fall back to.
+func (s *Server) checkResetPermission(ctx context.Context, reqCtx *object_pb.RequestContext) (string, error) {
+	if reqCtx.GetInstance() {
+		return "", caos_errs.ThrowInvalidArgument(nil, "SETTINGSv2-Eip5o", "Errors.ResourceOwnerMissing")
+	}
+	orgID := object.ResourceOwnerFromReq(ctx, reqCtx)
+	return orgID, s.checkPermission(ctx, domain.PermissionPolicyDelete, orgID, orgID)
+}
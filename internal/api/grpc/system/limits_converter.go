@@ -12,5 +12,8 @@ func instanceLimitsPbToCommand(req *system.SetLimitsRequest) *command.SetLimits
 	if req.AuditLogRetention != nil {
 		setLimits.AuditLogRetention = gu.Ptr(req.AuditLogRetention.AsDuration())
 	}
+	if req.ActionExecutionRetention != nil {
+		setLimits.ActionExecutionRetention = gu.Ptr(req.ActionExecutionRetention.AsDuration())
+	}
 	return setLimits
 }
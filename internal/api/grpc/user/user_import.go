@@ -0,0 +1,105 @@
+package user
+
+import (
+	"github.com/zitadel/zitadel/internal/api/grpc/object"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	user_pb "github.com/zitadel/zitadel/pkg/grpc/user"
+)
+
+func UserImportFormatToDomain(format user_pb.UserImportFormat) domain.UserImportFormat {
+	switch format {
+	case user_pb.UserImportFormat_USER_IMPORT_FORMAT_CSV:
+		return domain.UserImportFormatCSV
+	case user_pb.UserImportFormat_USER_IMPORT_FORMAT_NDJSON:
+		return domain.UserImportFormatNDJSON
+	default:
+		return domain.UserImportFormatUnspecified
+	}
+}
+
+func userImportFormatToPb(format domain.UserImportFormat) user_pb.UserImportFormat {
+	switch format {
+	case domain.UserImportFormatCSV:
+		return user_pb.UserImportFormat_USER_IMPORT_FORMAT_CSV
+	case domain.UserImportFormatNDJSON:
+		return user_pb.UserImportFormat_USER_IMPORT_FORMAT_NDJSON
+	default:
+		return user_pb.UserImportFormat_USER_IMPORT_FORMAT_UNSPECIFIED
+	}
+}
+
+func userImportStateToPb(state domain.UserImportState) user_pb.UserImportState {
+	switch state {
+	case domain.UserImportStateQueued:
+		return user_pb.UserImportState_USER_IMPORT_STATE_QUEUED
+	case domain.UserImportStateRunning:
+		return user_pb.UserImportState_USER_IMPORT_STATE_RUNNING
+	case domain.UserImportStateDone:
+		return user_pb.UserImportState_USER_IMPORT_STATE_DONE
+	case domain.UserImportStateFailed:
+		return user_pb.UserImportState_USER_IMPORT_STATE_FAILED
+	default:
+		return user_pb.UserImportState_USER_IMPORT_STATE_UNSPECIFIED
+	}
+}
+
+func UserImportsToPb(imports []*query.UserImport) []*user_pb.UserImport {
+	converted := make([]*user_pb.UserImport, len(imports))
+	for i, userImport := range imports {
+		converted[i] = UserImportToPb(userImport)
+	}
+	return converted
+}
+
+func UserImportToPb(userImport *query.UserImport) *user_pb.UserImport {
+	return &user_pb.UserImport{
+		Id: userImport.ID,
+		Details: object.ToViewDetailsPb(
+			userImport.Sequence,
+			userImport.CreationDate,
+			userImport.ChangeDate,
+			userImport.ResourceOwner,
+		),
+		State:         userImportStateToPb(userImport.State),
+		Format:        userImportFormatToPb(userImport.Format),
+		DryRun:        userImport.DryRun,
+		ProcessedRows: userImport.ProcessedRows,
+		FailedRows:    userImport.FailedRows,
+		Error:         userImport.Error,
+	}
+}
+
+func UserImportRowsToPb(rows []*domain.UserImportRowResult) []*user_pb.UserImportRow {
+	converted := make([]*user_pb.UserImportRow, len(rows))
+	for i, row := range rows {
+		converted[i] = &user_pb.UserImportRow{
+			Row:      row.Row,
+			UserName: row.Username,
+			UserId:   row.UserID,
+			Error:    row.Error,
+		}
+	}
+	return converted
+}
+
+func UserImportRowQueriesToQuery(queries []*user_pb.UserImportRowQuery) (_ []query.SearchQuery, err error) {
+	q := make([]query.SearchQuery, len(queries))
+	for i, query := range queries {
+		q[i], err = UserImportRowQueryToQuery(query)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return q, nil
+}
+
+func UserImportRowQueryToQuery(req *user_pb.UserImportRowQuery) (query.SearchQuery, error) {
+	switch q := req.Query.(type) {
+	case *user_pb.UserImportRowQuery_SucceededQuery:
+		return query.NewUserImportRowSucceededSearchQuery(q.SucceededQuery.Succeeded)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "USER-Eeph4", "Errors.List.Query.Invalid")
+	}
+}
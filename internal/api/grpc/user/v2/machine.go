@@ -0,0 +1,343 @@
+package user
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/grpc/object/v2"
+	z_oidc "github.com/zitadel/zitadel/internal/api/oidc"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+	user "github.com/zitadel/zitadel/pkg/grpc/user/v2beta"
+)
+
+func (s *Server) AddMachineUser(ctx context.Context, req *user.AddMachineUserRequest) (_ *user.AddMachineUserResponse, err error) {
+	machine := addMachineUserRequestToCommand(req, authz.GetCtxData(ctx).OrgID)
+	details, err := s.command.AddMachine(ctx, machine)
+	if err != nil {
+		return nil, err
+	}
+	return &user.AddMachineUserResponse{
+		UserId:  machine.AggregateID,
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func addMachineUserRequestToCommand(req *user.AddMachineUserRequest, orgID string) *command.Machine {
+	return &command.Machine{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   req.GetUserId(),
+			ResourceOwner: orgID,
+		},
+		Username:        req.GetUsername(),
+		Name:            req.GetName(),
+		Description:     req.GetDescription(),
+		AccessTokenType: accessTokenTypeToDomain(req.GetAccessTokenType()),
+	}
+}
+
+func (s *Server) UpdateMachineUser(ctx context.Context, req *user.UpdateMachineUserRequest) (_ *user.UpdateMachineUserResponse, err error) {
+	var accessTokenType *domain.OIDCTokenType
+	if req.AccessTokenType != nil {
+		tokenType := accessTokenTypeToDomain(req.GetAccessTokenType())
+		accessTokenType = &tokenType
+	}
+	details, err := s.command.ChangeMachineV2(ctx, req.GetUserId(), req.Name, req.Description, accessTokenType)
+	if err != nil {
+		return nil, err
+	}
+	return &user.UpdateMachineUserResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddMachineKey(ctx context.Context, req *user.AddMachineKeyRequest) (_ *user.AddMachineKeyResponse, err error) {
+	machineKey := addMachineKeyRequestToCommand(req)
+	details, err := s.command.AddUserMachineKeyV2(ctx, machineKey)
+	if err != nil {
+		return nil, err
+	}
+	keyDetails, err := machineKey.Detail()
+	if err != nil {
+		return nil, err
+	}
+	return &user.AddMachineKeyResponse{
+		KeyId:      machineKey.KeyID,
+		KeyDetails: keyDetails,
+		Details:    object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func addMachineKeyRequestToCommand(req *user.AddMachineKeyRequest) *command.MachineKey {
+	var expirationDate time.Time
+	if req.GetExpirationDate() != nil {
+		expirationDate = req.GetExpirationDate().AsTime()
+	}
+	machineKey := &command.MachineKey{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.GetUserId(),
+		},
+		ExpirationDate: expirationDate,
+		Type:           keyTypeToDomain(req.GetType()),
+	}
+	if len(req.GetPublicKey()) > 0 {
+		machineKey.SetPublicKey(req.GetPublicKey())
+	}
+	return machineKey
+}
+
+func (s *Server) RemoveMachineKey(ctx context.Context, req *user.RemoveMachineKeyRequest) (_ *user.RemoveMachineKeyResponse, err error) {
+	details, err := s.command.RemoveUserMachineKeyV2(ctx, req.GetUserId(), req.GetKeyId())
+	if err != nil {
+		return nil, err
+	}
+	return &user.RemoveMachineKeyResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListMachineKeys(ctx context.Context, req *user.ListMachineKeysRequest) (_ *user.ListMachineKeysResponse, err error) {
+	machine, err := s.query.GetUserByIDWithPermission(ctx, true, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	queries, err := listMachineKeysRequestToQuery(req, machine.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchAuthNKeys(ctx, queries, false)
+	if err != nil {
+		return nil, err
+	}
+	return &user.ListMachineKeysResponse{
+		Result:  machineKeysToPb(result.AuthNKeys),
+		Details: object.ToListDetails(result.SearchResponse),
+	}, nil
+}
+
+func listMachineKeysRequestToQuery(req *user.ListMachineKeysRequest, resourceOwner string) (*query.AuthNKeySearchQueries, error) {
+	resourceOwnerQuery, err := query.NewAuthNKeyResourceOwnerQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := query.NewAuthNKeyAggregateIDQuery(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, asc := object.ListQueryToQuery(req.GetQuery())
+	return &query.AuthNKeySearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{
+			resourceOwnerQuery,
+			userIDQuery,
+		},
+	}, nil
+}
+
+func machineKeysToPb(keys []*query.AuthNKey) []*user.MachineKey {
+	k := make([]*user.MachineKey, len(keys))
+	for i, key := range keys {
+		k[i] = machineKeyToPb(key)
+	}
+	return k
+}
+
+func machineKeyToPb(key *query.AuthNKey) *user.MachineKey {
+	return &user.MachineKey{
+		KeyId: key.ID,
+		Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+			Sequence:      key.Sequence,
+			EventDate:     key.ChangeDate,
+			ResourceOwner: key.ResourceOwner,
+		}),
+		Type:           keyTypeToPb(key.Type),
+		ExpirationDate: timestamppb.New(key.Expiration),
+	}
+}
+
+func (s *Server) GenerateMachineSecret(ctx context.Context, req *user.GenerateMachineSecretRequest) (_ *user.GenerateMachineSecretResponse, err error) {
+	// use SecretGeneratorTypeAppSecret as the secrets will be used in the client_credentials grant like a client secret
+	secretGenerator, err := s.query.InitHashGenerator(ctx, domain.SecretGeneratorTypeAppSecret, s.passwordHashAlg)
+	if err != nil {
+		return nil, err
+	}
+	set := new(command.GenerateMachineSecret)
+	details, err := s.command.GenerateMachineSecretV2(ctx, req.GetUserId(), secretGenerator, set)
+	if err != nil {
+		return nil, err
+	}
+	// the permission was already checked by the command
+	machine, err := s.query.GetUserByID(ctx, true, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	return &user.GenerateMachineSecretResponse{
+		ClientId:     machine.PreferredLoginName,
+		ClientSecret: set.ClientSecret,
+		Details:      object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) RemoveMachineSecret(ctx context.Context, req *user.RemoveMachineSecretRequest) (_ *user.RemoveMachineSecretResponse, err error) {
+	details, err := s.command.RemoveMachineSecretV2(ctx, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	return &user.RemoveMachineSecretResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) AddPersonalAccessToken(ctx context.Context, req *user.AddPersonalAccessTokenRequest) (_ *user.AddPersonalAccessTokenResponse, err error) {
+	pat := addPersonalAccessTokenRequestToCommand(req)
+	details, err := s.command.AddPersonalAccessTokenV2(ctx, pat)
+	if err != nil {
+		return nil, err
+	}
+	return &user.AddPersonalAccessTokenResponse{
+		TokenId: pat.TokenID,
+		Token:   pat.Token,
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func addPersonalAccessTokenRequestToCommand(req *user.AddPersonalAccessTokenRequest) *command.PersonalAccessToken {
+	var expirationDate time.Time
+	if req.GetExpirationDate() != nil {
+		expirationDate = req.GetExpirationDate().AsTime()
+	}
+	return &command.PersonalAccessToken{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: req.GetUserId(),
+		},
+		ExpirationDate: expirationDate,
+		Scopes:         []string{oidc.ScopeOpenID, oidc.ScopeProfile, z_oidc.ScopeUserMetaData, z_oidc.ScopeResourceOwner},
+	}
+}
+
+func (s *Server) RemovePersonalAccessToken(ctx context.Context, req *user.RemovePersonalAccessTokenRequest) (_ *user.RemovePersonalAccessTokenResponse, err error) {
+	details, err := s.command.RemovePersonalAccessTokenV2(ctx, req.GetUserId(), req.GetTokenId())
+	if err != nil {
+		return nil, err
+	}
+	return &user.RemovePersonalAccessTokenResponse{
+		Details: object.DomainToDetailsPb(details),
+	}, nil
+}
+
+func (s *Server) ListPersonalAccessTokens(ctx context.Context, req *user.ListPersonalAccessTokensRequest) (_ *user.ListPersonalAccessTokensResponse, err error) {
+	machine, err := s.query.GetUserByIDWithPermission(ctx, true, req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	queries, err := listPersonalAccessTokensRequestToQuery(req, machine.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	result, err := s.query.SearchPersonalAccessTokens(ctx, queries, false)
+	if err != nil {
+		return nil, err
+	}
+	return &user.ListPersonalAccessTokensResponse{
+		Result:  personalAccessTokensToPb(result.PersonalAccessTokens),
+		Details: object.ToListDetails(result.SearchResponse),
+	}, nil
+}
+
+func listPersonalAccessTokensRequestToQuery(req *user.ListPersonalAccessTokensRequest, resourceOwner string) (*query.PersonalAccessTokenSearchQueries, error) {
+	resourceOwnerQuery, err := query.NewPersonalAccessTokenResourceOwnerSearchQuery(resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	userIDQuery, err := query.NewPersonalAccessTokenUserIDSearchQuery(req.GetUserId())
+	if err != nil {
+		return nil, err
+	}
+	offset, limit, asc := object.ListQueryToQuery(req.GetQuery())
+	return &query.PersonalAccessTokenSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset: offset,
+			Limit:  limit,
+			Asc:    asc,
+		},
+		Queries: []query.SearchQuery{
+			resourceOwnerQuery,
+			userIDQuery,
+		},
+	}, nil
+}
+
+func personalAccessTokensToPb(tokens []*query.PersonalAccessToken) []*user.PersonalAccessToken {
+	t := make([]*user.PersonalAccessToken, len(tokens))
+	for i, token := range tokens {
+		t[i] = personalAccessTokenToPb(token)
+	}
+	return t
+}
+
+func personalAccessTokenToPb(token *query.PersonalAccessToken) *user.PersonalAccessToken {
+	return &user.PersonalAccessToken{
+		TokenId: token.ID,
+		Details: object.DomainToDetailsPb(&domain.ObjectDetails{
+			Sequence:      token.Sequence,
+			EventDate:     token.ChangeDate,
+			ResourceOwner: token.ResourceOwner,
+		}),
+		ExpirationDate: timestamppb.New(token.Expiration),
+		Scopes:         token.Scopes,
+	}
+}
+
+func accessTokenTypeToDomain(accessTokenType user.AccessTokenType) domain.OIDCTokenType {
+	switch accessTokenType {
+	case user.AccessTokenType_ACCESS_TOKEN_TYPE_JWT:
+		return domain.OIDCTokenTypeJWT
+	case user.AccessTokenType_ACCESS_TOKEN_TYPE_BEARER:
+		return domain.OIDCTokenTypeBearer
+	default:
+		return domain.OIDCTokenTypeBearer
+	}
+}
+
+func accessTokenTypeToPb(accessTokenType domain.OIDCTokenType) user.AccessTokenType {
+	switch accessTokenType {
+	case domain.OIDCTokenTypeJWT:
+		return user.AccessTokenType_ACCESS_TOKEN_TYPE_JWT
+	case domain.OIDCTokenTypeBearer:
+		return user.AccessTokenType_ACCESS_TOKEN_TYPE_BEARER
+	default:
+		return user.AccessTokenType_ACCESS_TOKEN_TYPE_BEARER
+	}
+}
+
+func keyTypeToDomain(keyType user.KeyType) domain.AuthNKeyType {
+	switch keyType {
+	case user.KeyType_KEY_TYPE_JSON:
+		return domain.AuthNKeyTypeJSON
+	case user.KeyType_KEY_TYPE_UNSPECIFIED:
+		return domain.AuthNKeyTypeNONE
+	default:
+		return domain.AuthNKeyTypeNONE
+	}
+}
+
+func keyTypeToPb(keyType domain.AuthNKeyType) user.KeyType {
+	switch keyType {
+	case domain.AuthNKeyTypeJSON:
+		return user.KeyType_KEY_TYPE_JSON
+	case domain.AuthNKeyTypeNONE:
+		return user.KeyType_KEY_TYPE_UNSPECIFIED
+	default:
+		return user.KeyType_KEY_TYPE_UNSPECIFIED
+	}
+}
@@ -0,0 +1,161 @@
+package user
+
+import (
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+	"google.golang.org/protobuf/types/known/timestamppb"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	object_pb "github.com/zitadel/zitadel/pkg/grpc/object/v2beta"
+	user "github.com/zitadel/zitadel/pkg/grpc/user/v2beta"
+)
+
+func Test_userToPb(t *testing.T) {
+	changeDate := time.Date(2023, 10, 1, 12, 0, 0, 0, time.UTC)
+	tests := []struct {
+		name string
+		user *query.User
+		want *user.User
+	}{
+		{
+			name: "human",
+			user: &query.User{
+				ID:                 "user1",
+				ChangeDate:         changeDate,
+				ResourceOwner:      "org1",
+				Sequence:           2,
+				State:              domain.UserStateActive,
+				Username:           "username",
+				LoginNames:         []string{"username@org1.localhost"},
+				PreferredLoginName: "username@org1.localhost",
+				Human: &query.Human{
+					FirstName:         "first",
+					LastName:          "last",
+					NickName:          "nick",
+					DisplayName:       "display",
+					PreferredLanguage: language.German,
+					Gender:            domain.GenderFemale,
+					Email:             "email@test.ch",
+					IsEmailVerified:   true,
+					Phone:             "+41791234567",
+				},
+			},
+			want: &user.User{
+				UserId: "user1",
+				Details: &object_pb.Details{
+					Sequence:      2,
+					ChangeDate:    timestamppb.New(changeDate),
+					ResourceOwner: "org1",
+				},
+				State:              user.UserState_USER_STATE_ACTIVE,
+				Username:           "username",
+				LoginNames:         []string{"username@org1.localhost"},
+				PreferredLoginName: "username@org1.localhost",
+				Type: &user.User_Human{
+					Human: &user.HumanUser{
+						Profile: &user.HumanProfile{
+							GivenName:         "first",
+							FamilyName:        "last",
+							NickName:          gu.Ptr("nick"),
+							DisplayName:       gu.Ptr("display"),
+							PreferredLanguage: gu.Ptr("de"),
+							Gender:            gu.Ptr(user.Gender_GENDER_FEMALE),
+						},
+						Email: &user.HumanEmail{
+							Email:      "email@test.ch",
+							IsVerified: true,
+						},
+						Phone: &user.HumanPhone{
+							Phone: "+41791234567",
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "machine",
+			user: &query.User{
+				ID:            "user1",
+				ChangeDate:    changeDate,
+				ResourceOwner: "org1",
+				Sequence:      2,
+				State:         domain.UserStateLocked,
+				Username:      "machine",
+				Machine: &query.Machine{
+					Name:        "name",
+					Description: "description",
+					HasSecret:   true,
+				},
+			},
+			want: &user.User{
+				UserId: "user1",
+				Details: &object_pb.Details{
+					Sequence:      2,
+					ChangeDate:    timestamppb.New(changeDate),
+					ResourceOwner: "org1",
+				},
+				State:    user.UserState_USER_STATE_LOCKED,
+				Username: "machine",
+				Type: &user.User_Machine{
+					Machine: &user.MachineUser{
+						Name:        "name",
+						Description: "description",
+						HasSecret:   true,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := userToPb(tt.user, "")
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_userQueryToQuery(t *testing.T) {
+	tests := []struct {
+		name    string
+		query   *user.SearchQuery
+		wantErr error
+	}{
+		{
+			name: "state query",
+			query: &user.SearchQuery{
+				Query: &user.SearchQuery_StateQuery{
+					StateQuery: &user.StateQuery{State: user.UserState_USER_STATE_ACTIVE},
+				},
+			},
+		},
+		{
+			name: "organization query",
+			query: &user.SearchQuery{
+				Query: &user.SearchQuery_OrganizationIdQuery{
+					OrganizationIdQuery: &user.OrganizationIdQuery{OrganizationId: "org1"},
+				},
+			},
+		},
+		{
+			name:    "missing query",
+			query:   &user.SearchQuery{},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "GRPC-vR9nC", "List.Query.Invalid"),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := userQueryToQuery(tt.query)
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantErr == nil {
+				assert.NotNil(t, got)
+			}
+		})
+	}
+}
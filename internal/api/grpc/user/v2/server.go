@@ -5,6 +5,7 @@ import (
 
 	"google.golang.org/grpc"
 
+	"github.com/zitadel/zitadel/internal/api/assets"
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/grpc/server"
 	"github.com/zitadel/zitadel/internal/command"
@@ -23,6 +24,9 @@ type Server struct {
 	idpAlg      crypto.EncryptionAlgorithm
 	idpCallback func(ctx context.Context) string
 	samlRootURL func(ctx context.Context, idpID string) string
+
+	passwordHashAlg crypto.HashAlgorithm
+	assetAPIPrefix  func(context.Context) string
 }
 
 type Config struct{}
@@ -34,6 +38,8 @@ func CreateServer(
 	idpAlg crypto.EncryptionAlgorithm,
 	idpCallback func(ctx context.Context) string,
 	samlRootURL func(ctx context.Context, idpID string) string,
+	passwordHashAlg crypto.HashAlgorithm,
+	externalSecure bool,
 ) *Server {
 	return &Server{
 		command:     command,
@@ -42,6 +48,9 @@ func CreateServer(
 		idpAlg:      idpAlg,
 		idpCallback: idpCallback,
 		samlRootURL: samlRootURL,
+
+		passwordHashAlg: passwordHashAlg,
+		assetAPIPrefix:  assets.AssetAPI(externalSecure),
 	}
 }
 
@@ -6,6 +6,7 @@ import (
 	"net/http"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
@@ -62,6 +63,7 @@ func authorize(r *http.Request, verifier authz.APITokenVerifier, authConfig auth
 		return nil, errors.New("auth header missing")
 	}
 
+	authCtx = dpop.WithRequest(authCtx, dpop.NewRequest(r, http_util.ComposedOrigin(ctx)+r.URL.Path))
 	ctxSetter, err := authz.CheckUserAuthorization(authCtx, &httpReq{}, authToken, http_util.GetOrgID(r), "", verifier, authConfig, authOpt, r.RequestURI)
 	if err != nil {
 		return nil, err
@@ -0,0 +1,86 @@
+package scim
+
+import (
+	"encoding/json"
+	errs "errors"
+	"net/http"
+	"strconv"
+
+	"github.com/zitadel/logging"
+
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// scim types of RFC 7644, section 3.12
+const (
+	scimTypeInvalidFilter = "invalidFilter"
+	scimTypeInvalidSyntax = "invalidSyntax"
+	scimTypeInvalidPath   = "invalidPath"
+	scimTypeInvalidValue  = "invalidValue"
+	scimTypeMutability    = "mutability"
+	scimTypeNoTarget      = "noTarget"
+	scimTypeUniqueness    = "uniqueness"
+)
+
+type errorResponse struct {
+	Schemas  []string `json:"schemas"`
+	ScimType string   `json:"scimType,omitempty"`
+	Detail   string   `json:"detail,omitempty"`
+	Status   string   `json:"status"`
+}
+
+// scimTypeError adds the scim type to an error,
+// so that clients are able to distinguish bad requests
+type scimTypeError struct {
+	scimType string
+	err      error
+}
+
+func withScimType(scimType string, err error) error {
+	return &scimTypeError{scimType: scimType, err: err}
+}
+
+func (e *scimTypeError) Error() string {
+	return e.err.Error()
+}
+
+func (e *scimTypeError) Unwrap() error {
+	return e.err
+}
+
+func errorToResponse(err error) (int, *errorResponse) {
+	var scimType string
+	typeErr := new(scimTypeError)
+	if errs.As(err, &typeErr) {
+		scimType = typeErr.scimType
+		err = typeErr.err
+	}
+	status, ok := http_util.ZitadelErrorToHTTPStatusCode(err)
+	if !ok {
+		status = http.StatusInternalServerError
+	}
+	if scimType == "" && errors.IsErrorAlreadyExists(err) {
+		scimType = scimTypeUniqueness
+	}
+	detail := err.Error()
+	caosErr := new(errors.CaosError)
+	if errs.As(err, &caosErr) {
+		detail = caosErr.GetMessage()
+	}
+	return status, &errorResponse{
+		Schemas:  []string{schemaError},
+		ScimType: scimType,
+		Detail:   detail,
+		Status:   strconv.Itoa(status),
+	}
+}
+
+func writeError(w http.ResponseWriter, r *http.Request, err error) {
+	status, resp := errorToResponse(err)
+	logging.WithFields("uri", r.RequestURI, "status", status).WithError(err).Info("error occurred on scim api")
+	w.Header().Set("Content-Type", ContentTypeSCIM)
+	w.WriteHeader(status)
+	err = json.NewEncoder(w).Encode(resp)
+	logging.OnError(err).Warn("unable to write scim error")
+}
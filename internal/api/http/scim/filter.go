@@ -0,0 +1,284 @@
+package scim
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// filter operators of RFC 7644, section 3.4.2.2
+const (
+	filterOperatorEqual      = "eq"
+	filterOperatorNotEqual   = "ne"
+	filterOperatorContains   = "co"
+	filterOperatorStartsWith = "sw"
+	filterOperatorEndsWith   = "ew"
+	filterOperatorPresent    = "pr"
+	filterOperatorGreater    = "gt"
+	filterOperatorGreaterEq  = "ge"
+	filterOperatorLess       = "lt"
+	filterOperatorLessEq     = "le"
+
+	filterOperatorAnd = "and"
+	filterOperatorOr  = "or"
+	filterOperatorNot = "not"
+)
+
+// filter is either an *attributeFilter, a *logicalFilter or a *notFilter
+type filter interface {
+	isFilter()
+}
+
+// attributeFilter compares an attribute with a value,
+// the attribute is lower case and contains the sub attribute separated by a dot, e.g. name.givenname
+type attributeFilter struct {
+	attribute string
+	operator  string
+	// value is a string, bool, float64 or nil
+	value interface{}
+}
+
+type logicalFilter struct {
+	operator    string
+	left, right filter
+}
+
+type notFilter struct {
+	filter filter
+}
+
+func (*attributeFilter) isFilter() {}
+func (*logicalFilter) isFilter()   {}
+func (*notFilter) isFilter()       {}
+
+// stringValue returns the value of the filter if it's a string
+func (f *attributeFilter) stringValue() (string, error) {
+	value, ok := f.value.(string)
+	if !ok {
+		return "", invalidFilterError("SCIM-Fae3o")
+	}
+	return value, nil
+}
+
+type filterToken struct {
+	value string
+	// quoted tokens are string values
+	quoted bool
+}
+
+func (t filterToken) is(value string) bool {
+	return !t.quoted && strings.EqualFold(t.value, value)
+}
+
+// parseFilter parses a filter expression, e.g. userName eq "gigi" and (emails co "zitadel.com" or not (active eq false))
+func parseFilter(expression string) (filter, error) {
+	tokens, err := tokenizeFilter(expression)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	f, err := p.parseOr("")
+	if err != nil {
+		return nil, err
+	}
+	if p.pos < len(p.tokens) {
+		return nil, invalidFilterError("SCIM-Ohl5a")
+	}
+	return f, nil
+}
+
+func tokenizeFilter(expression string) ([]filterToken, error) {
+	tokens := make([]filterToken, 0)
+	for i := 0; i < len(expression); {
+		switch c := expression[i]; {
+		case c == ' ':
+			i++
+		case c == '(' || c == ')' || c == '[' || c == ']':
+			tokens = append(tokens, filterToken{value: string(c)})
+			i++
+		case c == '"':
+			end := i + 1
+			for ; end < len(expression) && expression[end] != '"'; end++ {
+				if expression[end] == '\\' {
+					end++
+				}
+			}
+			if end >= len(expression) {
+				return nil, invalidFilterError("SCIM-Eiz4u")
+			}
+			value, err := strconv.Unquote(expression[i : end+1])
+			if err != nil {
+				return nil, invalidFilterError("SCIM-aiN8o")
+			}
+			tokens = append(tokens, filterToken{value: value, quoted: true})
+			i = end + 1
+		default:
+			length := strings.IndexAny(expression[i:], " ()[]\"")
+			if length < 0 {
+				length = len(expression) - i
+			}
+			tokens = append(tokens, filterToken{value: expression[i : i+length]})
+			i += length
+		}
+	}
+	return tokens, nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	pos    int
+}
+
+func (p *filterParser) next() (filterToken, bool) {
+	if p.pos >= len(p.tokens) {
+		return filterToken{}, false
+	}
+	p.pos++
+	return p.tokens[p.pos-1], true
+}
+
+func (p *filterParser) peekIs(value string) bool {
+	return p.pos < len(p.tokens) && p.tokens[p.pos].is(value)
+}
+
+func (p *filterParser) expect(value string) error {
+	token, ok := p.next()
+	if !ok || !token.is(value) {
+		return invalidFilterError("SCIM-Aeng7")
+	}
+	return nil
+}
+
+// parseOr parses the expressions combined by or,
+// the attributes of the expressions are prefixed by the parent attribute of a value path, e.g. emails[type eq "work"]
+func (p *filterParser) parseOr(parent string) (filter, error) {
+	left, err := p.parseAnd(parent)
+	if err != nil {
+		return nil, err
+	}
+	for p.peekIs(filterOperatorOr) {
+		p.pos++
+		right, err := p.parseAnd(parent)
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{operator: filterOperatorOr, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd(parent string) (filter, error) {
+	left, err := p.parseExpression(parent)
+	if err != nil {
+		return nil, err
+	}
+	for p.peekIs(filterOperatorAnd) {
+		p.pos++
+		right, err := p.parseExpression(parent)
+		if err != nil {
+			return nil, err
+		}
+		left = &logicalFilter{operator: filterOperatorAnd, left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseExpression(parent string) (filter, error) {
+	token, ok := p.next()
+	if !ok || token.quoted {
+		return nil, invalidFilterError("SCIM-Uu9ai")
+	}
+	switch {
+	case token.is("("):
+		return p.parseGroup(parent)
+	case token.is(filterOperatorNot):
+		if err := p.expect("("); err != nil {
+			return nil, err
+		}
+		f, err := p.parseGroup(parent)
+		if err != nil {
+			return nil, err
+		}
+		return &notFilter{filter: f}, nil
+	}
+	attribute := normalizeAttribute(token.value)
+	if parent != "" {
+		attribute = parent + "." + attribute
+	}
+	if p.peekIs("[") {
+		p.pos++
+		f, err := p.parseOr(attribute)
+		if err != nil {
+			return nil, err
+		}
+		return f, p.expect("]")
+	}
+	return p.parseComparison(attribute)
+}
+
+func (p *filterParser) parseGroup(parent string) (filter, error) {
+	f, err := p.parseOr(parent)
+	if err != nil {
+		return nil, err
+	}
+	return f, p.expect(")")
+}
+
+func (p *filterParser) parseComparison(attribute string) (filter, error) {
+	token, ok := p.next()
+	if !ok || token.quoted {
+		return nil, invalidFilterError("SCIM-Ieb3e")
+	}
+	operator := strings.ToLower(token.value)
+	switch operator {
+	case filterOperatorPresent:
+		return &attributeFilter{attribute: attribute, operator: operator}, nil
+	case filterOperatorEqual, filterOperatorNotEqual, filterOperatorContains, filterOperatorStartsWith, filterOperatorEndsWith,
+		filterOperatorGreater, filterOperatorGreaterEq, filterOperatorLess, filterOperatorLessEq:
+	default:
+		return nil, invalidFilterError("SCIM-Ooy0i")
+	}
+	token, ok = p.next()
+	if !ok {
+		return nil, invalidFilterError("SCIM-Xoo6a")
+	}
+	value, err := filterValue(token)
+	if err != nil {
+		return nil, err
+	}
+	return &attributeFilter{attribute: attribute, operator: operator, value: value}, nil
+}
+
+func filterValue(token filterToken) (interface{}, error) {
+	if token.quoted {
+		return token.value, nil
+	}
+	switch strings.ToLower(token.value) {
+	case "true":
+		return true, nil
+	case "false":
+		return false, nil
+	case "null":
+		return nil, nil
+	}
+	number, err := strconv.ParseFloat(token.value, 64)
+	if err != nil {
+		return nil, invalidFilterError("SCIM-ooT4e")
+	}
+	return number, nil
+}
+
+// normalizeAttribute removes the schema of the attribute and returns it in lower case,
+// as attribute names are case insensitive
+func normalizeAttribute(attribute string) string {
+	attribute = strings.ToLower(attribute)
+	for _, schema := range []string{schemaUser, schemaGroup} {
+		attribute = strings.TrimPrefix(attribute, strings.ToLower(schema)+":")
+	}
+	return attribute
+}
+
+func invalidFilterError(id string) error {
+	return withScimType(scimTypeInvalidFilter, errors.ThrowInvalidArgument(nil, id, "Errors.Invalid.Argument"))
+}
@@ -0,0 +1,117 @@
+package scim
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_parseFilter(t *testing.T) {
+	tests := []struct {
+		name       string
+		expression string
+		want       filter
+		wantErr    bool
+	}{
+		{
+			name:       "equal",
+			expression: `userName eq "gigi"`,
+			want:       &attributeFilter{attribute: "username", operator: filterOperatorEqual, value: "gigi"},
+		},
+		{
+			name:       "schema and sub attribute",
+			expression: `urn:ietf:params:scim:schemas:core:2.0:User:name.givenName sw "Gi"`,
+			want:       &attributeFilter{attribute: "name.givenname", operator: filterOperatorStartsWith, value: "Gi"},
+		},
+		{
+			name:       "escaped quote",
+			expression: `displayName co "\"g"`,
+			want:       &attributeFilter{attribute: "displayname", operator: filterOperatorContains, value: `"g`},
+		},
+		{
+			name:       "present",
+			expression: `nickName pr`,
+			want:       &attributeFilter{attribute: "nickname", operator: filterOperatorPresent},
+		},
+		{
+			name:       "bool",
+			expression: `active EQ False`,
+			want:       &attributeFilter{attribute: "active", operator: filterOperatorEqual, value: false},
+		},
+		{
+			name:       "and binds stronger than or",
+			expression: `userName eq "a" or userName eq "b" and active eq true`,
+			want: &logicalFilter{
+				operator: filterOperatorOr,
+				left:     &attributeFilter{attribute: "username", operator: filterOperatorEqual, value: "a"},
+				right: &logicalFilter{
+					operator: filterOperatorAnd,
+					left:     &attributeFilter{attribute: "username", operator: filterOperatorEqual, value: "b"},
+					right:    &attributeFilter{attribute: "active", operator: filterOperatorEqual, value: true},
+				},
+			},
+		},
+		{
+			name:       "group and not",
+			expression: `(userName eq "a" or userName eq "b") and not (active eq true)`,
+			want: &logicalFilter{
+				operator: filterOperatorAnd,
+				left: &logicalFilter{
+					operator: filterOperatorOr,
+					left:     &attributeFilter{attribute: "username", operator: filterOperatorEqual, value: "a"},
+					right:    &attributeFilter{attribute: "username", operator: filterOperatorEqual, value: "b"},
+				},
+				right: &notFilter{filter: &attributeFilter{attribute: "active", operator: filterOperatorEqual, value: true}},
+			},
+		},
+		{
+			name:       "value path",
+			expression: `emails[type eq "work" and value co "@zitadel.com"]`,
+			want: &logicalFilter{
+				operator: filterOperatorAnd,
+				left:     &attributeFilter{attribute: "emails.type", operator: filterOperatorEqual, value: "work"},
+				right:    &attributeFilter{attribute: "emails.value", operator: filterOperatorContains, value: "@zitadel.com"},
+			},
+		},
+		{
+			name:       "unknown operator",
+			expression: `userName is "gigi"`,
+			wantErr:    true,
+		},
+		{
+			name:       "missing value",
+			expression: `userName eq`,
+			wantErr:    true,
+		},
+		{
+			name:       "unterminated string",
+			expression: `userName eq "gigi`,
+			wantErr:    true,
+		},
+		{
+			name:       "unclosed group",
+			expression: `(userName eq "gigi"`,
+			wantErr:    true,
+		},
+		{
+			name:       "trailing tokens",
+			expression: `userName eq "gigi" active`,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := parseFilter(tt.expression)
+			if tt.wantErr {
+				require.Error(t, err)
+				status, resp := errorToResponse(err)
+				assert.Equal(t, 400, status)
+				assert.Equal(t, scimTypeInvalidFilter, resp.ScimType)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,514 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// groupIDSeparator separates the project id from the role key in the id of a group,
+// project ids never contain the separator
+const groupIDSeparator = ":"
+
+// Group represents a role of a project of the organization,
+// the members are the users granted the role
+type Group struct {
+	Schemas     []string       `json:"schemas"`
+	ID          string         `json:"id,omitempty"`
+	DisplayName string         `json:"displayName"`
+	Members     []*GroupMember `json:"members,omitempty"`
+	Meta        *Meta          `json:"meta,omitempty"`
+}
+
+type GroupMember struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Ref     string `json:"$ref,omitempty"`
+	Type    string `json:"type,omitempty"`
+}
+
+func groupID(role *query.ProjectRole) string {
+	return role.ProjectID + groupIDSeparator + role.Key
+}
+
+func splitGroupID(id string) (projectID, roleKey string, err error) {
+	projectID, roleKey, ok := strings.Cut(id, groupIDSeparator)
+	if !ok || projectID == "" || roleKey == "" {
+		return "", "", errors.ThrowNotFound(nil, "SCIM-ieR8u", "Errors.Project.Role.NotExisting")
+	}
+	return projectID, roleKey, nil
+}
+
+func (h *Handler) groupToResource(ctx context.Context, role *query.ProjectRole, grants []*query.UserGrant) *Group {
+	group := &Group{
+		Schemas:     []string{schemaGroup},
+		ID:          groupID(role),
+		DisplayName: role.Key,
+		Meta: &Meta{
+			ResourceType: resourceTypeGroup,
+			Created:      role.CreationDate.UTC().Format(time.RFC3339),
+			LastModified: role.ChangeDate.UTC().Format(time.RFC3339),
+			Location:     h.resourceLocation(ctx, endpointGroups, groupID(role)),
+			Version:      versionFromSequence(role.Sequence),
+		},
+	}
+	for _, grant := range grants {
+		if !hasRole(grant, role.Key) {
+			continue
+		}
+		group.Members = append(group.Members, &GroupMember{
+			Value:   grant.UserID,
+			Display: grant.PreferredLoginName,
+			Ref:     h.resourceLocation(ctx, endpointUsers, grant.UserID),
+			Type:    resourceTypeUser,
+		})
+	}
+	return group
+}
+
+func hasRole(grant *query.UserGrant, roleKey string) bool {
+	for _, role := range grant.Roles {
+		if role == roleKey {
+			return true
+		}
+	}
+	return false
+}
+
+func (h *Handler) listGroups(r *http.Request) (interface{}, int, error) {
+	search, err := searchRequestFromQuery(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.searchGroupsResponse(r.Context(), search)
+}
+
+func (h *Handler) searchGroups(r *http.Request) (interface{}, int, error) {
+	search, err := searchRequestFromBody(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.searchGroupsResponse(r.Context(), search)
+}
+
+func (h *Handler) searchGroupsResponse(ctx context.Context, search *SearchRequest) (interface{}, int, error) {
+	queries, err := h.groupSearchQueries(ctx, search)
+	if err != nil {
+		return nil, 0, err
+	}
+	roles, err := h.query.SearchProjectRoles(ctx, true, queries)
+	if err != nil {
+		return nil, 0, err
+	}
+	if search.countOnly() {
+		return listResponse[*Group](roles.Count, search.StartIndex), http.StatusOK, nil
+	}
+	resources := make([]*Group, len(roles.ProjectRoles))
+	for i, role := range roles.ProjectRoles {
+		var grants []*query.UserGrant
+		if !search.excludes("members") {
+			grants, err = h.roleGrants(ctx, role)
+			if err != nil {
+				return nil, 0, err
+			}
+		}
+		resources[i] = h.groupToResource(ctx, role, grants)
+	}
+	return listResponse(roles.Count, search.StartIndex, resources...), http.StatusOK, nil
+}
+
+func (h *Handler) groupSearchQueries(ctx context.Context, search *SearchRequest) (*query.ProjectRoleSearchQueries, error) {
+	resourceOwnerQuery, err := query.NewProjectRoleResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	queries := &query.ProjectRoleSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        search.offset(),
+			Limit:         search.limit(h.maxResults()),
+			SortingColumn: query.ProjectRoleColumnCreationDate,
+			Asc:           true,
+		},
+		Queries: []query.SearchQuery{resourceOwnerQuery},
+	}
+	if search.Filter == "" {
+		return queries, nil
+	}
+	f, err := parseFilter(search.Filter)
+	if err != nil {
+		return nil, err
+	}
+	filterQuery, err := groupFilterToQuery(f)
+	if err != nil {
+		return nil, err
+	}
+	queries.Queries = append(queries.Queries, filterQuery)
+	return queries, nil
+}
+
+// groupFilterToQuery maps the filter to project role search queries,
+// only the id and the display name (the role key) are searchable
+func groupFilterToQuery(f filter) (query.SearchQuery, error) {
+	switch f := f.(type) {
+	case *logicalFilter:
+		left, err := groupFilterToQuery(f.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := groupFilterToQuery(f.right)
+		if err != nil {
+			return nil, err
+		}
+		if f.operator == filterOperatorOr {
+			return query.NewOrQuery(left, right)
+		}
+		return query.NewAndQuery(left, right)
+	case *notFilter:
+		q, err := groupFilterToQuery(f.filter)
+		if err != nil {
+			return nil, err
+		}
+		return query.NewNotQuery(q)
+	case *attributeFilter:
+		return groupAttributeFilterToQuery(f)
+	default:
+		return nil, invalidFilterError("SCIM-Xah8e")
+	}
+}
+
+func groupAttributeFilterToQuery(f *attributeFilter) (query.SearchQuery, error) {
+	value, err := f.stringValue()
+	if err != nil {
+		return nil, err
+	}
+	switch f.attribute {
+	case "displayname":
+		return textFilterToQuery(f.operator, func(comparison query.TextComparison) (query.SearchQuery, error) {
+			return query.NewProjectRoleKeySearchQuery(comparison, value)
+		})
+	case "id":
+		if f.operator != filterOperatorEqual {
+			return nil, invalidFilterError("SCIM-Lie5o")
+		}
+		projectID, roleKey, ok := strings.Cut(value, groupIDSeparator)
+		if !ok {
+			return nil, invalidFilterError("SCIM-Mei3u")
+		}
+		projectQuery, err := query.NewProjectRoleProjectIDSearchQuery(projectID)
+		if err != nil {
+			return nil, err
+		}
+		keyQuery, err := query.NewProjectRoleKeySearchQuery(query.TextEquals, roleKey)
+		if err != nil {
+			return nil, err
+		}
+		return query.NewAndQuery(projectQuery, keyQuery)
+	default:
+		return nil, invalidFilterError("SCIM-Vai2h")
+	}
+}
+
+func (h *Handler) getGroup(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	role, err := h.existingRole(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return nil, 0, err
+	}
+	search, err := searchRequestFromQuery(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	var grants []*query.UserGrant
+	if !search.excludes("members") {
+		grants, err = h.roleGrants(ctx, role)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	return h.groupToResource(ctx, role, grants), http.StatusOK, nil
+}
+
+// existingRole returns the project role of the organization identified by the group id
+func (h *Handler) existingRole(ctx context.Context, id string) (*query.ProjectRole, error) {
+	projectID, roleKey, err := splitGroupID(id)
+	if err != nil {
+		return nil, err
+	}
+	resourceOwnerQuery, err := query.NewProjectRoleResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	projectQuery, err := query.NewProjectRoleProjectIDSearchQuery(projectID)
+	if err != nil {
+		return nil, err
+	}
+	keyQuery, err := query.NewProjectRoleKeySearchQuery(query.TextEquals, roleKey)
+	if err != nil {
+		return nil, err
+	}
+	roles, err := h.query.SearchProjectRoles(ctx, true, &query.ProjectRoleSearchQueries{
+		Queries: []query.SearchQuery{resourceOwnerQuery, projectQuery, keyQuery},
+	})
+	if err != nil {
+		return nil, err
+	}
+	if len(roles.ProjectRoles) != 1 {
+		return nil, errors.ThrowNotFound(nil, "SCIM-ahX5o", "Errors.Project.Role.NotExisting")
+	}
+	return roles.ProjectRoles[0], nil
+}
+
+// roleGrants returns the grants of the organization containing the role
+func (h *Handler) roleGrants(ctx context.Context, role *query.ProjectRole) ([]*query.UserGrant, error) {
+	rolesQuery, err := query.NewUserGrantContainsRolesSearchQuery(role.Key)
+	if err != nil {
+		return nil, err
+	}
+	return h.projectGrants(ctx, role, rolesQuery)
+}
+
+// projectGrants returns the grants of the organization on the project of the role
+func (h *Handler) projectGrants(ctx context.Context, role *query.ProjectRole, queries ...query.SearchQuery) ([]*query.UserGrant, error) {
+	projectQuery, err := query.NewUserGrantProjectIDSearchQuery(role.ProjectID)
+	if err != nil {
+		return nil, err
+	}
+	resourceOwnerQuery, err := query.NewUserGrantResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := h.query.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: append(queries, projectQuery, resourceOwnerQuery),
+	}, true, false)
+	if err != nil {
+		return nil, err
+	}
+	return grants.UserGrants, nil
+}
+
+func (h *Handler) replaceGroup(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	role, err := h.existingRole(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return nil, 0, err
+	}
+	resource := new(Group)
+	if err = readResource(r, resource); err != nil {
+		return nil, 0, err
+	}
+	members := make(map[string]bool, len(resource.Members))
+	for _, member := range resource.Members {
+		members[member.Value] = true
+	}
+	return h.updateGroupMembers(ctx, role, members)
+}
+
+func (h *Handler) patchGroup(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	role, err := h.existingRole(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return nil, 0, err
+	}
+	patch := new(PatchRequest)
+	if err = readResource(r, patch); err != nil {
+		return nil, 0, err
+	}
+	if err = patch.validate(); err != nil {
+		return nil, 0, err
+	}
+	grants, err := h.roleGrants(ctx, role)
+	if err != nil {
+		return nil, 0, err
+	}
+	members := make(map[string]bool, len(grants))
+	for _, grant := range grants {
+		members[grant.UserID] = true
+	}
+	for _, operation := range patch.Operations {
+		if err = applyGroupPatchOperation(role, members, operation); err != nil {
+			return nil, 0, err
+		}
+	}
+	return h.updateGroupMembers(ctx, role, members)
+}
+
+// applyGroupPatchOperation applies the operation to the members,
+// as the display name is the role key it can't be changed
+func applyGroupPatchOperation(role *query.ProjectRole, members map[string]bool, operation *PatchOperation) error {
+	attribute, valueFilter, _ := splitPath(operation.Path)
+	switch attribute {
+	case "":
+		if operation.Op == patchOpRemove {
+			return withScimType(scimTypeNoTarget, errors.ThrowInvalidArgument(nil, "SCIM-Gie9o", "Errors.Invalid.Argument"))
+		}
+		attributes := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return invalidValueError("SCIM-Ohk3i", err)
+		}
+		for path, value := range attributes {
+			if err := applyGroupPatchOperation(role, members, &PatchOperation{Op: operation.Op, Path: path, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	case "displayname":
+		var displayName string
+		if err := json.Unmarshal(operation.Value, &displayName); err != nil || displayName != role.Key {
+			return mutabilityError("SCIM-Ieph3")
+		}
+		return nil
+	case "members":
+		return patchGroupMembers(members, operation, valueFilter)
+	case "schemas", "id", "meta", "externalid":
+		return nil
+	default:
+		return invalidPathError("SCIM-ooF8a")
+	}
+}
+
+func patchGroupMembers(members map[string]bool, operation *PatchOperation, valueFilter string) error {
+	if operation.Op == patchOpRemove && valueFilter != "" {
+		f, err := parseFilter(valueFilter)
+		if err != nil {
+			return err
+		}
+		userIDs, err := memberFilterValues(f)
+		if err != nil {
+			return err
+		}
+		for _, userID := range userIDs {
+			delete(members, userID)
+		}
+		return nil
+	}
+	var patched []*GroupMember
+	if len(operation.Value) > 0 {
+		if err := json.Unmarshal(operation.Value, &patched); err != nil {
+			return invalidValueError("SCIM-Kah2u", err)
+		}
+	}
+	switch operation.Op {
+	case patchOpAdd:
+		for _, member := range patched {
+			members[member.Value] = true
+		}
+	case patchOpReplace:
+		for userID := range members {
+			delete(members, userID)
+		}
+		for _, member := range patched {
+			members[member.Value] = true
+		}
+	case patchOpRemove:
+		// without values all members are removed
+		if len(patched) == 0 {
+			for userID := range members {
+				delete(members, userID)
+			}
+		}
+		for _, member := range patched {
+			delete(members, member.Value)
+		}
+	}
+	return nil
+}
+
+// memberFilterValues returns the user ids of a filter of a members value path,
+// e.g. members[value eq "id1" or value eq "id2"]
+func memberFilterValues(f filter) ([]string, error) {
+	switch f := f.(type) {
+	case *logicalFilter:
+		if f.operator != filterOperatorOr {
+			return nil, invalidFilterError("SCIM-Aem7i")
+		}
+		left, err := memberFilterValues(f.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := memberFilterValues(f.right)
+		if err != nil {
+			return nil, err
+		}
+		return append(left, right...), nil
+	case *attributeFilter:
+		if f.attribute != "value" || f.operator != filterOperatorEqual {
+			return nil, invalidFilterError("SCIM-Thoh4")
+		}
+		value, err := f.stringValue()
+		if err != nil {
+			return nil, err
+		}
+		return []string{value}, nil
+	default:
+		return nil, invalidFilterError("SCIM-Eik0f")
+	}
+}
+
+// updateGroupMembers grants the role to the added members and revokes it from the removed ones,
+// grants without any remaining role are removed
+func (h *Handler) updateGroupMembers(ctx context.Context, role *query.ProjectRole, members map[string]bool) (interface{}, int, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	grants, err := h.projectGrants(ctx, role)
+	if err != nil {
+		return nil, 0, err
+	}
+	grantsByUser := make(map[string]*query.UserGrant, len(grants))
+	for _, grant := range grants {
+		grantsByUser[grant.UserID] = grant
+		if members[grant.UserID] == hasRole(grant, role.Key) {
+			continue
+		}
+		if err = h.changeGrantRole(ctx, grant, role.Key, members[grant.UserID]); err != nil {
+			return nil, 0, err
+		}
+	}
+	for userID, member := range members {
+		if !member || grantsByUser[userID] != nil {
+			continue
+		}
+		_, err = h.commands.AddUserGrant(ctx, &domain.UserGrant{
+			UserID:    userID,
+			ProjectID: role.ProjectID,
+			RoleKeys:  []string{role.Key},
+		}, orgID)
+		if err != nil {
+			return nil, 0, err
+		}
+	}
+	grants, err = h.roleGrants(ctx, role)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.groupToResource(ctx, role, grants), http.StatusOK, nil
+}
+
+func (h *Handler) changeGrantRole(ctx context.Context, grant *query.UserGrant, roleKey string, granted bool) (err error) {
+	roleKeys := make([]string, 0, len(grant.Roles)+1)
+	for _, role := range grant.Roles {
+		if role != roleKey {
+			roleKeys = append(roleKeys, role)
+		}
+	}
+	if granted {
+		roleKeys = append(roleKeys, roleKey)
+	}
+	if len(roleKeys) == 0 {
+		_, err = h.commands.RemoveUserGrant(ctx, grant.ID, grant.ResourceOwner)
+		return err
+	}
+	_, err = h.commands.ChangeUserGrant(ctx, &domain.UserGrant{
+		ObjectRoot: es_models.ObjectRoot{AggregateID: grant.ID, ResourceOwner: grant.ResourceOwner},
+		RoleKeys:   roleKeys,
+	}, grant.ResourceOwner)
+	return err
+}
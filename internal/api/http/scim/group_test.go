@@ -0,0 +1,68 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_applyGroupPatchOperation(t *testing.T) {
+	tests := []struct {
+		name      string
+		operation *PatchOperation
+		want      map[string]bool
+		wantErr   bool
+	}{
+		{
+			name:      "add members",
+			operation: &PatchOperation{Op: patchOpAdd, Path: "members", Value: json.RawMessage(`[{"value":"user3"}]`)},
+			want:      map[string]bool{"user1": true, "user2": true, "user3": true},
+		},
+		{
+			name:      "remove member by filter",
+			operation: &PatchOperation{Op: patchOpRemove, Path: `members[value eq "user1"]`},
+			want:      map[string]bool{"user2": true},
+		},
+		{
+			name:      "remove members by value",
+			operation: &PatchOperation{Op: patchOpRemove, Path: "members", Value: json.RawMessage(`[{"value":"user2"}]`)},
+			want:      map[string]bool{"user1": true},
+		},
+		{
+			name:      "remove all members",
+			operation: &PatchOperation{Op: patchOpRemove, Path: "members"},
+			want:      map[string]bool{},
+		},
+		{
+			name:      "replace members without path",
+			operation: &PatchOperation{Op: patchOpReplace, Value: json.RawMessage(`{"displayName":"admin","members":[{"value":"user3"}]}`)},
+			want:      map[string]bool{"user3": true},
+		},
+		{
+			name:      "remove member by unsupported filter",
+			operation: &PatchOperation{Op: patchOpRemove, Path: `members[display eq "gigi"]`},
+			wantErr:   true,
+		},
+		{
+			name:      "change display name",
+			operation: &PatchOperation{Op: patchOpReplace, Path: "displayName", Value: json.RawMessage(`"owner"`)},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			members := map[string]bool{"user1": true, "user2": true}
+			err := applyGroupPatchOperation(&query.ProjectRole{ProjectID: "project", Key: "admin"}, members, tt.operation)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, members)
+		})
+	}
+}
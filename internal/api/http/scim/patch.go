@@ -0,0 +1,113 @@
+package scim
+
+import (
+	"encoding/json"
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	patchOpAdd     = "add"
+	patchOpReplace = "replace"
+	patchOpRemove  = "remove"
+)
+
+type PatchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*PatchOperation `json:"Operations"`
+}
+
+type PatchOperation struct {
+	Op    string          `json:"op"`
+	Path  string          `json:"path,omitempty"`
+	Value json.RawMessage `json:"value,omitempty"`
+}
+
+func (r *PatchRequest) validate() error {
+	if len(r.Operations) == 0 {
+		return withScimType(scimTypeInvalidSyntax, errors.ThrowInvalidArgument(nil, "SCIM-ooj4U", "Errors.Invalid.Argument"))
+	}
+	for _, operation := range r.Operations {
+		// the operations are case insensitive, as some clients send them capitalized
+		operation.Op = strings.ToLower(operation.Op)
+		switch operation.Op {
+		case patchOpAdd, patchOpReplace, patchOpRemove:
+		default:
+			return withScimType(scimTypeInvalidSyntax, errors.ThrowInvalidArgument(nil, "SCIM-ceiK9", "Errors.Invalid.Argument"))
+		}
+		if operation.Op != patchOpRemove && len(operation.Value) == 0 {
+			return withScimType(scimTypeInvalidValue, errors.ThrowInvalidArgument(nil, "SCIM-Ahzi7", "Errors.Invalid.Argument"))
+		}
+	}
+	return nil
+}
+
+// splitPath returns the normalized attribute, the filter of a value path and the sub attribute of the path,
+// e.g. emails[type eq "work"].value results in emails, type eq "work" and value
+func splitPath(path string) (attribute, valueFilter, subAttribute string) {
+	if start := strings.Index(path, "["); start >= 0 {
+		attribute = normalizeAttribute(path[:start])
+		end := strings.LastIndex(path, "]")
+		if end < start {
+			return attribute, path[start+1:], ""
+		}
+		return attribute, path[start+1 : end], strings.ToLower(strings.TrimPrefix(path[end+1:], "."))
+	}
+	attribute, subAttribute, _ = strings.Cut(normalizeAttribute(path), ".")
+	return attribute, "", subAttribute
+}
+
+// patchString sets the value of the operation to the target,
+// attributes which are not removable are required
+func patchString(operation *PatchOperation, target *string, removable bool) error {
+	if operation.Op == patchOpRemove {
+		if !removable {
+			return mutabilityError("SCIM-eeh0D")
+		}
+		*target = ""
+		return nil
+	}
+	if err := json.Unmarshal(operation.Value, target); err != nil {
+		return invalidValueError("SCIM-ahSh0", err)
+	}
+	return nil
+}
+
+// patchBool sets the value of the operation to the target,
+// string values are allowed as some clients send booleans as strings, e.g. "False"
+func patchBool(operation *PatchOperation, target **bool) error {
+	if operation.Op == patchOpRemove {
+		return mutabilityError("SCIM-Eesh4")
+	}
+	var value interface{}
+	if err := json.Unmarshal(operation.Value, &value); err != nil {
+		return invalidValueError("SCIM-Uw4ie", err)
+	}
+	switch v := value.(type) {
+	case bool:
+		*target = &v
+	case string:
+		parsed, err := strconv.ParseBool(v)
+		if err != nil {
+			return invalidValueError("SCIM-Oos2e", err)
+		}
+		*target = &parsed
+	default:
+		return invalidValueError("SCIM-oTh3a", nil)
+	}
+	return nil
+}
+
+func mutabilityError(id string) error {
+	return withScimType(scimTypeMutability, errors.ThrowInvalidArgument(nil, id, "Errors.Invalid.Argument"))
+}
+
+func invalidValueError(id string, parent error) error {
+	return withScimType(scimTypeInvalidValue, errors.ThrowInvalidArgument(parent, id, "Errors.Invalid.Argument"))
+}
+
+func invalidPathError(id string) error {
+	return withScimType(scimTypeInvalidPath, errors.ThrowInvalidArgument(nil, id, "Errors.Invalid.Argument"))
+}
@@ -0,0 +1,171 @@
+package scim
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_splitPath(t *testing.T) {
+	tests := []struct {
+		path             string
+		wantAttribute    string
+		wantValueFilter  string
+		wantSubAttribute string
+	}{
+		{
+			path:          "userName",
+			wantAttribute: "username",
+		},
+		{
+			path:             "name.givenName",
+			wantAttribute:    "name",
+			wantSubAttribute: "givenname",
+		},
+		{
+			path:             "urn:ietf:params:scim:schemas:core:2.0:User:name.familyName",
+			wantAttribute:    "name",
+			wantSubAttribute: "familyname",
+		},
+		{
+			path:            `members[value eq "ID"]`,
+			wantAttribute:   "members",
+			wantValueFilter: `value eq "ID"`,
+		},
+		{
+			path:             `emails[type eq "work"].value`,
+			wantAttribute:    "emails",
+			wantValueFilter:  `type eq "work"`,
+			wantSubAttribute: "value",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			attribute, valueFilter, subAttribute := splitPath(tt.path)
+			assert.Equal(t, tt.wantAttribute, attribute)
+			assert.Equal(t, tt.wantValueFilter, valueFilter)
+			assert.Equal(t, tt.wantSubAttribute, subAttribute)
+		})
+	}
+}
+
+func TestPatchRequest_validate(t *testing.T) {
+	tests := []struct {
+		name    string
+		request *PatchRequest
+		wantErr bool
+	}{
+		{
+			name:    "no operations",
+			request: &PatchRequest{},
+			wantErr: true,
+		},
+		{
+			name:    "unknown operation",
+			request: &PatchRequest{Operations: []*PatchOperation{{Op: "move", Path: "userName"}}},
+			wantErr: true,
+		},
+		{
+			name:    "missing value",
+			request: &PatchRequest{Operations: []*PatchOperation{{Op: "add", Path: "userName"}}},
+			wantErr: true,
+		},
+		{
+			name:    "capitalized operations",
+			request: &PatchRequest{Operations: []*PatchOperation{{Op: "Replace", Path: "userName", Value: json.RawMessage(`"gigi"`)}, {Op: "Remove", Path: "nickName"}}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.request.validate()
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
+
+func TestUser_applyPatchOperation(t *testing.T) {
+	active := true
+	inactive := false
+	tests := []struct {
+		name      string
+		operation *PatchOperation
+		want      *User
+		wantErr   bool
+	}{
+		{
+			name:      "replace username",
+			operation: &PatchOperation{Op: patchOpReplace, Path: "userName", Value: json.RawMessage(`"gigi"`)},
+			want:      &User{UserName: "gigi", NickName: "nick", Active: &active, Name: &Name{GivenName: "Gigi", FamilyName: "Giraffe"}},
+		},
+		{
+			name:      "remove nick name",
+			operation: &PatchOperation{Op: patchOpRemove, Path: "nickName"},
+			want:      &User{UserName: "user", Active: &active, Name: &Name{GivenName: "Gigi", FamilyName: "Giraffe"}},
+		},
+		{
+			name:      "remove username",
+			operation: &PatchOperation{Op: patchOpRemove, Path: "userName"},
+			wantErr:   true,
+		},
+		{
+			name:      "replace active as string",
+			operation: &PatchOperation{Op: patchOpReplace, Path: "active", Value: json.RawMessage(`"False"`)},
+			want:      &User{UserName: "user", NickName: "nick", Active: &inactive, Name: &Name{GivenName: "Gigi", FamilyName: "Giraffe"}},
+		},
+		{
+			name:      "replace given name",
+			operation: &PatchOperation{Op: patchOpReplace, Path: "name.givenName", Value: json.RawMessage(`"Gregor"`)},
+			want:      &User{UserName: "user", NickName: "nick", Active: &active, Name: &Name{GivenName: "Gregor", FamilyName: "Giraffe"}},
+		},
+		{
+			name:      "replace without path",
+			operation: &PatchOperation{Op: patchOpReplace, Value: json.RawMessage(`{"active":false,"name":{"familyName":"Gnu"},"emails":[{"value":"gigi@zitadel.com","primary":true}]}`)},
+			want: &User{
+				UserName: "user",
+				NickName: "nick",
+				Active:   &inactive,
+				Name:     &Name{GivenName: "Gigi", FamilyName: "Gnu"},
+				Emails:   []*MultiValued{{Value: "gigi@zitadel.com", Primary: true}},
+			},
+		},
+		{
+			name:      "replace email value",
+			operation: &PatchOperation{Op: patchOpReplace, Path: `emails[type eq "work"].value`, Value: json.RawMessage(`"gigi@zitadel.com"`)},
+			want: &User{
+				UserName: "user",
+				NickName: "nick",
+				Active:   &active,
+				Name:     &Name{GivenName: "Gigi", FamilyName: "Giraffe"},
+				Emails:   []*MultiValued{{Value: "gigi@zitadel.com", Primary: true}},
+			},
+		},
+		{
+			name:      "remove emails",
+			operation: &PatchOperation{Op: patchOpRemove, Path: "emails"},
+			wantErr:   true,
+		},
+		{
+			name:      "unknown attribute",
+			operation: &PatchOperation{Op: patchOpReplace, Path: "title", Value: json.RawMessage(`"Dr."`)},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			user := &User{UserName: "user", NickName: "nick", Active: &active, Name: &Name{GivenName: "Gigi", FamilyName: "Giraffe"}}
+			err := user.applyPatchOperation(tt.operation)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, user)
+		})
+	}
+}
@@ -0,0 +1,262 @@
+package scim
+
+import (
+	"net/http"
+)
+
+const (
+	schemaUser                  = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaGroup                 = "urn:ietf:params:scim:schemas:core:2.0:Group"
+	schemaListResponse          = "urn:ietf:params:scim:api:messages:2.0:ListResponse"
+	schemaSearchRequest         = "urn:ietf:params:scim:api:messages:2.0:SearchRequest"
+	schemaPatchOp               = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	schemaError                 = "urn:ietf:params:scim:api:messages:2.0:Error"
+	schemaServiceProviderConfig = "urn:ietf:params:scim:schemas:core:2.0:ServiceProviderConfig"
+	schemaResourceType          = "urn:ietf:params:scim:schemas:core:2.0:ResourceType"
+	schemaSchema                = "urn:ietf:params:scim:schemas:core:2.0:Schema"
+
+	resourceTypeUser  = "User"
+	resourceTypeGroup = "Group"
+	endpointUsers     = "Users"
+	endpointGroups    = "Groups"
+
+	defaultMaxResults = 100
+)
+
+type Meta struct {
+	ResourceType string `json:"resourceType"`
+	Created      string `json:"created,omitempty"`
+	LastModified string `json:"lastModified,omitempty"`
+	Location     string `json:"location,omitempty"`
+	Version      string `json:"version,omitempty"`
+}
+
+type ListResponse struct {
+	Schemas      []string      `json:"schemas"`
+	TotalResults uint64        `json:"totalResults"`
+	StartIndex   uint64        `json:"startIndex"`
+	ItemsPerPage uint64        `json:"itemsPerPage"`
+	Resources    []interface{} `json:"Resources"`
+}
+
+type supported struct {
+	Supported bool `json:"supported"`
+}
+
+type ServiceProviderConfig struct {
+	Schemas               []string              `json:"schemas"`
+	DocumentationURI      string                `json:"documentationUri,omitempty"`
+	Patch                 supported             `json:"patch"`
+	Bulk                  bulkConfig            `json:"bulk"`
+	Filter                filterConfig          `json:"filter"`
+	ChangePassword        supported             `json:"changePassword"`
+	Sort                  supported             `json:"sort"`
+	ETag                  supported             `json:"etag"`
+	AuthenticationSchemes []*authenticationType `json:"authenticationSchemes"`
+	Meta                  *Meta                 `json:"meta"`
+}
+
+type bulkConfig struct {
+	Supported      bool `json:"supported"`
+	MaxOperations  int  `json:"maxOperations"`
+	MaxPayloadSize int  `json:"maxPayloadSize"`
+}
+
+type filterConfig struct {
+	Supported  bool   `json:"supported"`
+	MaxResults uint64 `json:"maxResults"`
+}
+
+type authenticationType struct {
+	Type        string `json:"type"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	Primary     bool   `json:"primary"`
+}
+
+type ResourceType struct {
+	Schemas     []string `json:"schemas"`
+	ID          string   `json:"id"`
+	Name        string   `json:"name"`
+	Endpoint    string   `json:"endpoint"`
+	Description string   `json:"description"`
+	Schema      string   `json:"schema"`
+	Meta        *Meta    `json:"meta"`
+}
+
+type Schema struct {
+	Schemas     []string           `json:"schemas"`
+	ID          string             `json:"id"`
+	Name        string             `json:"name"`
+	Description string             `json:"description"`
+	Attributes  []*SchemaAttribute `json:"attributes"`
+	Meta        *Meta              `json:"meta"`
+}
+
+type SchemaAttribute struct {
+	Name          string             `json:"name"`
+	Type          string             `json:"type"`
+	MultiValued   bool               `json:"multiValued"`
+	Required      bool               `json:"required"`
+	CaseExact     bool               `json:"caseExact"`
+	Mutability    string             `json:"mutability"`
+	Returned      string             `json:"returned"`
+	Uniqueness    string             `json:"uniqueness"`
+	SubAttributes []*SchemaAttribute `json:"subAttributes,omitempty"`
+}
+
+func (h *Handler) maxResults() uint64 {
+	if h.config.MaxResults == 0 {
+		return defaultMaxResults
+	}
+	return h.config.MaxResults
+}
+
+func (h *Handler) serviceProviderConfig(r *http.Request) (interface{}, int, error) {
+	return &ServiceProviderConfig{
+		Schemas:          []string{schemaServiceProviderConfig},
+		DocumentationURI: "https://zitadel.com/docs",
+		Patch:            supported{Supported: true},
+		Filter:           filterConfig{Supported: true, MaxResults: h.maxResults()},
+		ChangePassword:   supported{Supported: true},
+		AuthenticationSchemes: []*authenticationType{
+			{
+				Type:        "oauthbearertoken",
+				Name:        "OAuth Bearer Token",
+				Description: "Authentication with an access token or personal access token of a service user",
+				Primary:     true,
+			},
+		},
+		Meta: &Meta{
+			ResourceType: "ServiceProviderConfig",
+			Location:     h.resourceLocation(r.Context(), "ServiceProviderConfig", ""),
+		},
+	}, http.StatusOK, nil
+}
+
+func (h *Handler) resourceTypes(r *http.Request) (interface{}, int, error) {
+	types := []*ResourceType{
+		{
+			Schemas:     []string{schemaResourceType},
+			ID:          resourceTypeUser,
+			Name:        resourceTypeUser,
+			Endpoint:    "/" + endpointUsers,
+			Description: "Human users of the organization",
+			Schema:      schemaUser,
+			Meta:        &Meta{ResourceType: "ResourceType", Location: h.resourceLocation(r.Context(), "ResourceTypes", resourceTypeUser)},
+		},
+		{
+			Schemas:     []string{schemaResourceType},
+			ID:          resourceTypeGroup,
+			Name:        resourceTypeGroup,
+			Endpoint:    "/" + endpointGroups,
+			Description: "Roles of the projects of the organization, the members are the users granted the role",
+			Schema:      schemaGroup,
+			Meta:        &Meta{ResourceType: "ResourceType", Location: h.resourceLocation(r.Context(), "ResourceTypes", resourceTypeGroup)},
+		},
+	}
+	return listResponse(uint64(len(types)), 1, types...), http.StatusOK, nil
+}
+
+func (h *Handler) schemas(r *http.Request) (interface{}, int, error) {
+	schemas := []*Schema{
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          schemaUser,
+			Name:        resourceTypeUser,
+			Description: "User Account",
+			Attributes: []*SchemaAttribute{
+				stringAttribute("userName", true, "server"),
+				{
+					Name:       "name",
+					Type:       "complex",
+					Required:   true,
+					Mutability: "readWrite",
+					Returned:   "default",
+					Uniqueness: "none",
+					SubAttributes: []*SchemaAttribute{
+						stringAttribute("formatted", false, "none"),
+						stringAttribute("familyName", true, "none"),
+						stringAttribute("givenName", true, "none"),
+					},
+				},
+				stringAttribute("displayName", false, "none"),
+				stringAttribute("nickName", false, "none"),
+				stringAttribute("preferredLanguage", false, "none"),
+				{Name: "active", Type: "boolean", Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+				{Name: "password", Type: "string", Mutability: "writeOnly", Returned: "never", Uniqueness: "none"},
+				multiValuedAttribute("emails", true),
+				multiValuedAttribute("phoneNumbers", false),
+			},
+			Meta: &Meta{ResourceType: "Schema", Location: h.resourceLocation(r.Context(), "Schemas", schemaUser)},
+		},
+		{
+			Schemas:     []string{schemaSchema},
+			ID:          schemaGroup,
+			Name:        resourceTypeGroup,
+			Description: "Project role",
+			Attributes: []*SchemaAttribute{
+				{Name: "displayName", Type: "string", Required: true, Mutability: "readOnly", Returned: "default", Uniqueness: "none"},
+				{
+					Name:        "members",
+					Type:        "complex",
+					MultiValued: true,
+					Mutability:  "readWrite",
+					Returned:    "default",
+					Uniqueness:  "none",
+					SubAttributes: []*SchemaAttribute{
+						{Name: "value", Type: "string", Mutability: "immutable", Returned: "default", Uniqueness: "none"},
+						{Name: "display", Type: "string", Mutability: "readOnly", Returned: "default", Uniqueness: "none"},
+						{Name: "$ref", Type: "reference", Mutability: "immutable", Returned: "default", Uniqueness: "none"},
+					},
+				},
+			},
+			Meta: &Meta{ResourceType: "Schema", Location: h.resourceLocation(r.Context(), "Schemas", schemaGroup)},
+		},
+	}
+	return listResponse(uint64(len(schemas)), 1, schemas...), http.StatusOK, nil
+}
+
+func stringAttribute(name string, required bool, uniqueness string) *SchemaAttribute {
+	return &SchemaAttribute{
+		Name:       name,
+		Type:       "string",
+		Required:   required,
+		Mutability: "readWrite",
+		Returned:   "default",
+		Uniqueness: uniqueness,
+	}
+}
+
+// multiValuedAttribute describes emails and phone numbers,
+// of which ZITADEL stores only the primary value
+func multiValuedAttribute(name string, required bool) *SchemaAttribute {
+	return &SchemaAttribute{
+		Name:        name,
+		Type:        "complex",
+		MultiValued: true,
+		Required:    required,
+		Mutability:  "readWrite",
+		Returned:    "default",
+		Uniqueness:  "none",
+		SubAttributes: []*SchemaAttribute{
+			stringAttribute("value", false, "none"),
+			stringAttribute("type", false, "none"),
+			{Name: "primary", Type: "boolean", Mutability: "readWrite", Returned: "default", Uniqueness: "none"},
+		},
+	}
+}
+
+func listResponse[T any](totalResults, startIndex uint64, resources ...T) *ListResponse {
+	list := &ListResponse{
+		Schemas:      []string{schemaListResponse},
+		TotalResults: totalResults,
+		StartIndex:   startIndex,
+		ItemsPerPage: uint64(len(resources)),
+		Resources:    make([]interface{}, len(resources)),
+	}
+	for i, resource := range resources {
+		list.Resources[i] = resource
+	}
+	return list
+}
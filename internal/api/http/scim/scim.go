@@ -0,0 +1,193 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	http_util "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// HandlerPrefix is followed by the id of the organization the resources belong to,
+	// e.g. /scim/v2/{orgId}/Users
+	HandlerPrefix = "/scim/v2"
+
+	ContentTypeSCIM = "application/scim+json"
+
+	// permissionAuthenticated only requires a valid token
+	permissionAuthenticated = "authenticated"
+
+	maxRequestBodySize = 1 << 20
+)
+
+type Config struct {
+	// EmailVerified marks the emails of provisioned users as verified,
+	// otherwise a verification code is sent to the user
+	EmailVerified bool
+	// PhoneVerified marks the phone numbers of provisioned users as verified,
+	// otherwise a verification code is sent to the user
+	PhoneVerified bool
+	// MaxResults is the maximum number of resources returned by a list request
+	MaxResults uint64
+}
+
+type Commands interface {
+	AddHuman(ctx context.Context, resourceOwner string, human *command.AddHuman, allowInitMail bool) error
+	ChangeUsername(ctx context.Context, orgID, userID, userName string) (*domain.ObjectDetails, error)
+	ChangeHumanProfile(ctx context.Context, profile *domain.Profile) (*domain.Profile, error)
+	ChangeUserEmail(ctx context.Context, userID, resourceOwner, email string, alg crypto.EncryptionAlgorithm) (*domain.Email, error)
+	ChangeUserEmailVerified(ctx context.Context, userID, resourceOwner, email string) (*domain.Email, error)
+	ChangeUserPhone(ctx context.Context, userID, resourceOwner, phone string, alg crypto.EncryptionAlgorithm) (*domain.Phone, error)
+	ChangeUserPhoneVerified(ctx context.Context, userID, resourceOwner, phone string) (*domain.Phone, error)
+	RemoveHumanPhone(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error)
+	SetPassword(ctx context.Context, orgID, userID, password string, oneTime bool) (*domain.ObjectDetails, error)
+	DeactivateUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error)
+	ReactivateUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error)
+	RemoveUser(ctx context.Context, userID, resourceOwner string, cascadingUserMemberships []*command.CascadingMembership, cascadingGrantIDs ...string) (*domain.ObjectDetails, error)
+	AddUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (*domain.UserGrant, error)
+	ChangeUserGrant(ctx context.Context, userGrant *domain.UserGrant, resourceOwner string) (*domain.UserGrant, error)
+	RemoveUserGrant(ctx context.Context, grantID, resourceOwner string) (*domain.ObjectDetails, error)
+}
+
+type Queries interface {
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string, queries ...query.SearchQuery) (*query.User, error)
+	SearchUsers(ctx context.Context, queries *query.UserSearchQueries) (*query.Users, error)
+	Memberships(ctx context.Context, queries *query.MembershipSearchQuery, shouldTrigger bool) (*query.Memberships, error)
+	UserGrants(ctx context.Context, queries *query.UserGrantsQueries, shouldTriggerBulk, withOwnerRemoved bool) (*query.UserGrants, error)
+	SearchProjectRoles(ctx context.Context, shouldTriggerBulk bool, queries *query.ProjectRoleSearchQueries) (*query.ProjectRoles, error)
+}
+
+type Handler struct {
+	config         Config
+	commands       Commands
+	query          Queries
+	verifier       authz.APITokenVerifier
+	authConfig     authz.Config
+	userCodeAlg    crypto.EncryptionAlgorithm
+	externalSecure bool
+}
+
+// resourceHandler handles a request to a resource and returns the resource to be written with the status code
+type resourceHandler func(r *http.Request) (resource interface{}, status int, err error)
+
+func NewHandler(
+	config Config,
+	commands Commands,
+	queries Queries,
+	verifier authz.APITokenVerifier,
+	authConfig authz.Config,
+	userCodeAlg crypto.EncryptionAlgorithm,
+	externalSecure bool,
+	interceptors ...func(http.Handler) http.Handler,
+) http.Handler {
+	h := &Handler{
+		config:         config,
+		commands:       commands,
+		query:          queries,
+		verifier:       verifier,
+		authConfig:     authConfig,
+		userCodeAlg:    userCodeAlg,
+		externalSecure: externalSecure,
+	}
+	router := mux.NewRouter()
+	for _, interceptor := range interceptors {
+		router.Use(interceptor)
+	}
+	h.registerRoutes(router.PathPrefix("/{orgId}").Subrouter())
+	return http_util.CopyHeadersToContext(router)
+}
+
+func (h *Handler) registerRoutes(router *mux.Router) {
+	router.Handle("/ServiceProviderConfig", h.handle(permissionAuthenticated, h.serviceProviderConfig)).Methods(http.MethodGet)
+	router.Handle("/ResourceTypes", h.handle(permissionAuthenticated, h.resourceTypes)).Methods(http.MethodGet)
+	router.Handle("/Schemas", h.handle(permissionAuthenticated, h.schemas)).Methods(http.MethodGet)
+
+	router.Handle("/Users", h.handle(domain.PermissionUserRead, h.listUsers)).Methods(http.MethodGet)
+	router.Handle("/Users/.search", h.handle(domain.PermissionUserRead, h.searchUsers)).Methods(http.MethodPost)
+	router.Handle("/Users", h.handle(domain.PermissionUserWrite, h.createUser)).Methods(http.MethodPost)
+	router.Handle("/Users/{id}", h.handle(domain.PermissionUserRead, h.getUser)).Methods(http.MethodGet)
+	router.Handle("/Users/{id}", h.handle(domain.PermissionUserWrite, h.replaceUser)).Methods(http.MethodPut)
+	router.Handle("/Users/{id}", h.handle(domain.PermissionUserWrite, h.patchUser)).Methods(http.MethodPatch)
+	router.Handle("/Users/{id}", h.handle(domain.PermissionUserDelete, h.deleteUser)).Methods(http.MethodDelete)
+
+	router.Handle("/Groups", h.handle(domain.PermissionUserGrantRead, h.listGroups)).Methods(http.MethodGet)
+	router.Handle("/Groups/.search", h.handle(domain.PermissionUserGrantRead, h.searchGroups)).Methods(http.MethodPost)
+	router.Handle("/Groups/{id}", h.handle(domain.PermissionUserGrantRead, h.getGroup)).Methods(http.MethodGet)
+	router.Handle("/Groups/{id}", h.handle(domain.PermissionUserGrantWrite, h.replaceGroup)).Methods(http.MethodPut)
+	router.Handle("/Groups/{id}", h.handle(domain.PermissionUserGrantWrite, h.patchGroup)).Methods(http.MethodPatch)
+	// groups are mapped to project roles, which are managed on the projects
+	router.Handle("/Groups", h.handle(domain.PermissionUserGrantWrite, unsupported)).Methods(http.MethodPost)
+	router.Handle("/Groups/{id}", h.handle(domain.PermissionUserGrantWrite, unsupported)).Methods(http.MethodDelete)
+}
+
+func (h *Handler) handle(permission string, handler resourceHandler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx, err := h.authorize(r, permission)
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		r.Body = http.MaxBytesReader(w, r.Body, maxRequestBodySize)
+		resource, status, err := handler(r.WithContext(ctx))
+		if err != nil {
+			writeError(w, r, err)
+			return
+		}
+		writeResource(w, status, resource)
+	})
+}
+
+// authorize checks the token and the permission of the caller on the organization of the path
+func (h *Handler) authorize(r *http.Request, permission string) (context.Context, error) {
+	ctx := r.Context()
+	authToken := http_util.GetAuthorization(r)
+	if authToken == "" {
+		return nil, errors.ThrowUnauthenticated(nil, "SCIM-Ohd4i", "Errors.Token.Invalid")
+	}
+	ctxSetter, err := authz.CheckUserAuthorization(ctx, r, authToken, mux.Vars(r)["orgId"], "", h.verifier, h.authConfig, authz.Option{Permission: permission}, r.Method+":"+r.URL.Path)
+	if err != nil {
+		return nil, err
+	}
+	return ctxSetter(ctx), nil
+}
+
+// resourceLocation returns the url of the resource, the id is omitted for singleton resources
+func (h *Handler) resourceLocation(ctx context.Context, resourceType, id string) string {
+	location := http_util.BuildOrigin(authz.GetInstance(ctx).RequestedHost(), h.externalSecure) + HandlerPrefix + "/" + authz.GetCtxData(ctx).OrgID + "/" + resourceType
+	if id == "" {
+		return location
+	}
+	return location + "/" + id
+}
+
+func unsupported(*http.Request) (interface{}, int, error) {
+	return nil, 0, errors.ThrowUnimplemented(nil, "SCIM-Ahr4e", "Errors.Unimplemented")
+}
+
+func writeResource(w http.ResponseWriter, status int, resource interface{}) {
+	if resource == nil {
+		w.WriteHeader(status)
+		return
+	}
+	w.Header().Set("Content-Type", ContentTypeSCIM)
+	w.WriteHeader(status)
+	err := json.NewEncoder(w).Encode(resource)
+	logging.OnError(err).Warn("unable to write scim resource")
+}
+
+func readResource(r *http.Request, resource interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(resource); err != nil {
+		return withScimType(scimTypeInvalidSyntax, errors.ThrowInvalidArgument(err, "SCIM-eeB4a", "Errors.Invalid.Argument"))
+	}
+	return nil
+}
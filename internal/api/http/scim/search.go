@@ -0,0 +1,90 @@
+package scim
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// SearchRequest contains the query parameters of a list request or the body of a .search request,
+// RFC 7644, section 3.4.2 and 3.4.3
+type SearchRequest struct {
+	Schemas            []string `json:"schemas"`
+	Filter             string   `json:"filter,omitempty"`
+	ExcludedAttributes []string `json:"excludedAttributes,omitempty"`
+	// StartIndex is 1-based
+	StartIndex uint64 `json:"startIndex,omitempty"`
+	// Count of 0 only returns the total results
+	Count *uint64 `json:"count,omitempty"`
+}
+
+func searchRequestFromQuery(r *http.Request) (_ *SearchRequest, err error) {
+	values := r.URL.Query()
+	search := &SearchRequest{
+		Filter: values.Get("filter"),
+	}
+	if excluded := values.Get("excludedAttributes"); excluded != "" {
+		search.ExcludedAttributes = strings.Split(excluded, ",")
+	}
+	if startIndex := values.Get("startIndex"); startIndex != "" {
+		search.StartIndex, err = strconv.ParseUint(startIndex, 10, 64)
+		if err != nil {
+			return nil, invalidValueError("SCIM-Quo4u", err)
+		}
+	}
+	if count := values.Get("count"); count != "" {
+		parsed, err := strconv.ParseUint(count, 10, 64)
+		if err != nil {
+			return nil, invalidValueError("SCIM-Yoo8i", err)
+		}
+		search.Count = &parsed
+	}
+	search.normalize()
+	return search, nil
+}
+
+func searchRequestFromBody(r *http.Request) (*SearchRequest, error) {
+	search := new(SearchRequest)
+	if err := readResource(r, search); err != nil {
+		return nil, err
+	}
+	search.normalize()
+	return search, nil
+}
+
+// normalize sets the start index to 1 if it's lower, as required by the RFC
+func (s *SearchRequest) normalize() {
+	if s.StartIndex < 1 {
+		s.StartIndex = 1
+	}
+}
+
+func (s *SearchRequest) offset() uint64 {
+	return s.StartIndex - 1
+}
+
+// limit returns the requested count, restricted to maxResults
+func (s *SearchRequest) limit(maxResults uint64) uint64 {
+	if s.Count == nil || *s.Count > maxResults {
+		return maxResults
+	}
+	// a limit of 0 would return all resources, the single result is dropped
+	if *s.Count == 0 {
+		return 1
+	}
+	return *s.Count
+}
+
+func (s *SearchRequest) countOnly() bool {
+	return s.Count != nil && *s.Count == 0
+}
+
+// excludes reports if the (lower case) attribute must not be returned
+func (s *SearchRequest) excludes(attribute string) bool {
+	for _, excluded := range s.ExcludedAttributes {
+		if normalizeAttribute(strings.TrimSpace(excluded)) == attribute {
+			return true
+		}
+	}
+	return false
+}
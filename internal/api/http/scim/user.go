@@ -0,0 +1,714 @@
+package scim
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/zitadel/logging"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	es_models "github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type User struct {
+	Schemas           []string       `json:"schemas"`
+	ID                string         `json:"id,omitempty"`
+	UserName          string         `json:"userName"`
+	Name              *Name          `json:"name,omitempty"`
+	DisplayName       string         `json:"displayName,omitempty"`
+	NickName          string         `json:"nickName,omitempty"`
+	PreferredLanguage string         `json:"preferredLanguage,omitempty"`
+	Active            *bool          `json:"active,omitempty"`
+	Emails            []*MultiValued `json:"emails,omitempty"`
+	PhoneNumbers      []*MultiValued `json:"phoneNumbers,omitempty"`
+	Password          string         `json:"password,omitempty"`
+	Meta              *Meta          `json:"meta,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+type MultiValued struct {
+	Value   string `json:"value"`
+	Display string `json:"display,omitempty"`
+	Type    string `json:"type,omitempty"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+// primaryValue returns the value marked as primary or the first value,
+// as ZITADEL stores a single email and phone number
+func primaryValue(values []*MultiValued) string {
+	for _, value := range values {
+		if value.Primary {
+			return value.Value
+		}
+	}
+	if len(values) == 0 {
+		return ""
+	}
+	return values[0].Value
+}
+
+func (u *User) givenName() string {
+	if u.Name == nil {
+		return ""
+	}
+	return u.Name.GivenName
+}
+
+func (u *User) familyName() string {
+	if u.Name == nil {
+		return ""
+	}
+	return u.Name.FamilyName
+}
+
+func (u *User) language() language.Tag {
+	if u.PreferredLanguage == "" {
+		return language.Und
+	}
+	tag, err := language.Parse(strings.Replace(u.PreferredLanguage, "_", "-", 1))
+	logging.OnError(err).Debug("unable to parse language")
+	return tag
+}
+
+func (h *Handler) userToResource(ctx context.Context, user *query.User) *User {
+	active := user.State != domain.UserStateInactive
+	resource := &User{
+		Schemas:  []string{schemaUser},
+		ID:       user.ID,
+		UserName: user.Username,
+		Active:   &active,
+		Meta: &Meta{
+			ResourceType: resourceTypeUser,
+			Created:      user.CreationDate.UTC().Format(time.RFC3339),
+			LastModified: user.ChangeDate.UTC().Format(time.RFC3339),
+			Location:     h.resourceLocation(ctx, endpointUsers, user.ID),
+			Version:      versionFromSequence(user.Sequence),
+		},
+	}
+	if user.Human == nil {
+		return resource
+	}
+	resource.Name = &Name{
+		Formatted:  strings.TrimSpace(user.Human.FirstName + " " + user.Human.LastName),
+		FamilyName: user.Human.LastName,
+		GivenName:  user.Human.FirstName,
+	}
+	resource.DisplayName = user.Human.DisplayName
+	resource.NickName = user.Human.NickName
+	if !user.Human.PreferredLanguage.IsRoot() {
+		resource.PreferredLanguage = user.Human.PreferredLanguage.String()
+	}
+	if user.Human.Email != "" {
+		resource.Emails = []*MultiValued{{Value: string(user.Human.Email), Primary: true}}
+	}
+	if user.Human.Phone != "" {
+		resource.PhoneNumbers = []*MultiValued{{Value: string(user.Human.Phone), Primary: true}}
+	}
+	return resource
+}
+
+func (h *Handler) userToAddHuman(user *User) *command.AddHuman {
+	return &command.AddHuman{
+		Username:          user.UserName,
+		FirstName:         user.givenName(),
+		LastName:          user.familyName(),
+		NickName:          user.NickName,
+		DisplayName:       user.DisplayName,
+		PreferredLanguage: user.language(),
+		Email: command.Email{
+			Address:  domain.EmailAddress(primaryValue(user.Emails)),
+			Verified: h.config.EmailVerified,
+		},
+		Phone: command.Phone{
+			Number:   domain.PhoneNumber(primaryValue(user.PhoneNumbers)),
+			Verified: h.config.PhoneVerified,
+		},
+		Password: user.Password,
+		// the user is created in the requested state, so it's never active in between
+		Inactive: user.Active != nil && !*user.Active,
+	}
+}
+
+func versionFromSequence(sequence uint64) string {
+	return `W/"` + strconv.FormatUint(sequence, 10) + `"`
+}
+
+func (h *Handler) listUsers(r *http.Request) (interface{}, int, error) {
+	search, err := searchRequestFromQuery(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.searchUsersResponse(r.Context(), search)
+}
+
+func (h *Handler) searchUsers(r *http.Request) (interface{}, int, error) {
+	search, err := searchRequestFromBody(r)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.searchUsersResponse(r.Context(), search)
+}
+
+func (h *Handler) searchUsersResponse(ctx context.Context, search *SearchRequest) (interface{}, int, error) {
+	queries, err := h.userSearchQueries(ctx, search)
+	if err != nil {
+		return nil, 0, err
+	}
+	users, err := h.query.SearchUsers(ctx, queries)
+	if err != nil {
+		return nil, 0, err
+	}
+	if search.countOnly() {
+		return listResponse[*User](users.Count, search.StartIndex), http.StatusOK, nil
+	}
+	resources := make([]*User, len(users.Users))
+	for i, user := range users.Users {
+		resources[i] = h.userToResource(ctx, user)
+	}
+	return listResponse(users.Count, search.StartIndex, resources...), http.StatusOK, nil
+}
+
+func (h *Handler) userSearchQueries(ctx context.Context, search *SearchRequest) (*query.UserSearchQueries, error) {
+	resourceOwnerQuery, err := query.NewUserResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	typeQuery, err := query.NewUserTypeSearchQuery(int32(domain.UserTypeHuman))
+	if err != nil {
+		return nil, err
+	}
+	queries := &query.UserSearchQueries{
+		SearchRequest: query.SearchRequest{
+			Offset:        search.offset(),
+			Limit:         search.limit(h.maxResults()),
+			SortingColumn: query.UserCreationDateCol,
+			Asc:           true,
+		},
+		Queries: []query.SearchQuery{resourceOwnerQuery, typeQuery},
+	}
+	if search.Filter == "" {
+		return queries, nil
+	}
+	f, err := parseFilter(search.Filter)
+	if err != nil {
+		return nil, err
+	}
+	filterQuery, err := userFilterToQuery(f)
+	if err != nil {
+		return nil, err
+	}
+	queries.Queries = append(queries.Queries, filterQuery)
+	return queries, nil
+}
+
+func (h *Handler) getUser(r *http.Request) (interface{}, int, error) {
+	user, err := h.existingUser(r.Context(), mux.Vars(r)["id"])
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.userToResource(r.Context(), user), http.StatusOK, nil
+}
+
+// existingUser returns the human user of the organization
+func (h *Handler) existingUser(ctx context.Context, id string) (*query.User, error) {
+	resourceOwnerQuery, err := query.NewUserResourceOwnerSearchQuery(authz.GetCtxData(ctx).OrgID, query.TextEquals)
+	if err != nil {
+		return nil, err
+	}
+	user, err := h.query.GetUserByID(ctx, true, id, resourceOwnerQuery)
+	if err != nil {
+		return nil, err
+	}
+	if user.Human == nil {
+		return nil, errors.ThrowNotFound(nil, "SCIM-Oph3u", "Errors.User.NotHuman")
+	}
+	return user, nil
+}
+
+func (h *Handler) createUser(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	resource := new(User)
+	if err := readResource(r, resource); err != nil {
+		return nil, 0, err
+	}
+	orgID := authz.GetCtxData(ctx).OrgID
+	human := h.userToAddHuman(resource)
+	if err := h.commands.AddHuman(ctx, orgID, human, false); err != nil {
+		return nil, 0, err
+	}
+	user, err := h.existingUser(ctx, human.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.userToResource(ctx, user), http.StatusCreated, nil
+}
+
+func (h *Handler) replaceUser(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	existing, err := h.existingUser(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return nil, 0, err
+	}
+	resource := new(User)
+	if err = readResource(r, resource); err != nil {
+		return nil, 0, err
+	}
+	return h.updateUser(ctx, existing, resource)
+}
+
+func (h *Handler) patchUser(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	existing, err := h.existingUser(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return nil, 0, err
+	}
+	patch := new(PatchRequest)
+	if err = readResource(r, patch); err != nil {
+		return nil, 0, err
+	}
+	if err = patch.validate(); err != nil {
+		return nil, 0, err
+	}
+	resource := h.userToResource(ctx, existing)
+	for _, operation := range patch.Operations {
+		if err = resource.applyPatchOperation(operation); err != nil {
+			return nil, 0, err
+		}
+	}
+	return h.updateUser(ctx, existing, resource)
+}
+
+// updateUser changes the existing user to match the resource
+func (h *Handler) updateUser(ctx context.Context, existing *query.User, resource *User) (interface{}, int, error) {
+	orgID := authz.GetCtxData(ctx).OrgID
+	if resource.UserName != "" && resource.UserName != existing.Username {
+		if _, err := h.commands.ChangeUsername(ctx, orgID, existing.ID, resource.UserName); err != nil {
+			return nil, 0, err
+		}
+	}
+	if profile := changedProfile(existing, resource); profile != nil {
+		profile.ObjectRoot = es_models.ObjectRoot{AggregateID: existing.ID, ResourceOwner: orgID}
+		if _, err := h.commands.ChangeHumanProfile(ctx, profile); err != nil {
+			return nil, 0, err
+		}
+	}
+	if err := h.updateEmail(ctx, existing, primaryValue(resource.Emails)); err != nil {
+		return nil, 0, err
+	}
+	if err := h.updatePhone(ctx, existing, primaryValue(resource.PhoneNumbers)); err != nil {
+		return nil, 0, err
+	}
+	if resource.Password != "" {
+		if _, err := h.commands.SetPassword(ctx, orgID, existing.ID, resource.Password, false); err != nil {
+			return nil, 0, err
+		}
+	}
+	if err := h.updateActive(ctx, existing, resource.Active); err != nil {
+		return nil, 0, err
+	}
+	user, err := h.existingUser(ctx, existing.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	return h.userToResource(ctx, user), http.StatusOK, nil
+}
+
+// changedProfile returns the profile if it's changed by the resource,
+// required attributes which are not part of the resource keep their values
+func changedProfile(existing *query.User, resource *User) *domain.Profile {
+	profile := &domain.Profile{
+		FirstName:         existing.Human.FirstName,
+		LastName:          existing.Human.LastName,
+		NickName:          resource.NickName,
+		DisplayName:       existing.Human.DisplayName,
+		PreferredLanguage: existing.Human.PreferredLanguage,
+		Gender:            existing.Human.Gender,
+	}
+	if givenName := resource.givenName(); givenName != "" {
+		profile.FirstName = givenName
+	}
+	if familyName := resource.familyName(); familyName != "" {
+		profile.LastName = familyName
+	}
+	if resource.DisplayName != "" {
+		profile.DisplayName = resource.DisplayName
+	}
+	if resource.PreferredLanguage != "" {
+		profile.PreferredLanguage = resource.language()
+	}
+	if profile.FirstName == existing.Human.FirstName &&
+		profile.LastName == existing.Human.LastName &&
+		profile.NickName == existing.Human.NickName &&
+		profile.DisplayName == existing.Human.DisplayName &&
+		profile.PreferredLanguage == existing.Human.PreferredLanguage {
+		return nil
+	}
+	return profile
+}
+
+func (h *Handler) updateEmail(ctx context.Context, existing *query.User, email string) (err error) {
+	if email == "" || domain.EmailAddress(email).Normalize() == existing.Human.Email {
+		return nil
+	}
+	if h.config.EmailVerified {
+		_, err = h.commands.ChangeUserEmailVerified(ctx, existing.ID, existing.ResourceOwner, email)
+		return err
+	}
+	_, err = h.commands.ChangeUserEmail(ctx, existing.ID, existing.ResourceOwner, email, h.userCodeAlg)
+	return err
+}
+
+func (h *Handler) updatePhone(ctx context.Context, existing *query.User, phone string) (err error) {
+	if phone == "" {
+		if existing.Human.Phone == "" {
+			return nil
+		}
+		_, err = h.commands.RemoveHumanPhone(ctx, existing.ID, existing.ResourceOwner)
+		return err
+	}
+	number, err := domain.PhoneNumber(phone).Normalize()
+	if err != nil {
+		return err
+	}
+	if number == existing.Human.Phone {
+		return nil
+	}
+	if h.config.PhoneVerified {
+		_, err = h.commands.ChangeUserPhoneVerified(ctx, existing.ID, existing.ResourceOwner, string(number))
+		return err
+	}
+	_, err = h.commands.ChangeUserPhone(ctx, existing.ID, existing.ResourceOwner, string(number), h.userCodeAlg)
+	return err
+}
+
+func (h *Handler) updateActive(ctx context.Context, existing *query.User, active *bool) (err error) {
+	if active == nil {
+		return nil
+	}
+	inactive := existing.State == domain.UserStateInactive
+	switch {
+	case *active && inactive:
+		_, err = h.commands.ReactivateUser(ctx, existing.ID, existing.ResourceOwner)
+	case !*active && !inactive:
+		_, err = h.commands.DeactivateUser(ctx, existing.ID, existing.ResourceOwner)
+	}
+	return err
+}
+
+func (h *Handler) deleteUser(r *http.Request) (interface{}, int, error) {
+	ctx := r.Context()
+	user, err := h.existingUser(ctx, mux.Vars(r)["id"])
+	if err != nil {
+		return nil, 0, err
+	}
+	memberships, grants, err := h.removeUserDependencies(ctx, user.ID)
+	if err != nil {
+		return nil, 0, err
+	}
+	if _, err = h.commands.RemoveUser(ctx, user.ID, user.ResourceOwner, memberships, grants...); err != nil {
+		return nil, 0, err
+	}
+	return nil, http.StatusNoContent, nil
+}
+
+func (h *Handler) removeUserDependencies(ctx context.Context, userID string) ([]*command.CascadingMembership, []string, error) {
+	userGrantUserQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	grants, err := h.query.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{userGrantUserQuery},
+	}, true, true)
+	if err != nil {
+		return nil, nil, err
+	}
+	membershipsUserQuery, err := query.NewMembershipUserIDQuery(userID)
+	if err != nil {
+		return nil, nil, err
+	}
+	memberships, err := h.query.Memberships(ctx, &query.MembershipSearchQuery{
+		Queries: []query.SearchQuery{membershipsUserQuery},
+	}, false)
+	if err != nil {
+		return nil, nil, err
+	}
+	grantIDs := make([]string, len(grants.UserGrants))
+	for i, grant := range grants.UserGrants {
+		grantIDs[i] = grant.ID
+	}
+	return cascadingMemberships(memberships.Memberships), grantIDs, nil
+}
+
+func cascadingMemberships(memberships []*query.Membership) []*command.CascadingMembership {
+	cascades := make([]*command.CascadingMembership, len(memberships))
+	for i, membership := range memberships {
+		cascades[i] = &command.CascadingMembership{
+			UserID:        membership.UserID,
+			ResourceOwner: membership.ResourceOwner,
+		}
+		if membership.IAM != nil {
+			cascades[i].IAM = &command.CascadingIAMMembership{IAMID: membership.IAM.IAMID}
+		}
+		if membership.Org != nil {
+			cascades[i].Org = &command.CascadingOrgMembership{OrgID: membership.Org.OrgID}
+		}
+		if membership.Project != nil {
+			cascades[i].Project = &command.CascadingProjectMembership{ProjectID: membership.Project.ProjectID}
+		}
+		if membership.ProjectGrant != nil {
+			cascades[i].ProjectGrant = &command.CascadingProjectGrantMembership{ProjectID: membership.ProjectGrant.ProjectID, GrantID: membership.ProjectGrant.GrantID}
+		}
+	}
+	return cascades
+}
+
+// applyPatchOperation applies the operation to the resource,
+// operations without path contain the attributes to be patched as value
+func (u *User) applyPatchOperation(operation *PatchOperation) error {
+	if operation.Path == "" {
+		if operation.Op == patchOpRemove {
+			return withScimType(scimTypeNoTarget, errors.ThrowInvalidArgument(nil, "SCIM-ieX4a", "Errors.Invalid.Argument"))
+		}
+		attributes := make(map[string]json.RawMessage)
+		if err := json.Unmarshal(operation.Value, &attributes); err != nil {
+			return invalidValueError("SCIM-Aeph5", err)
+		}
+		for path, value := range attributes {
+			if err := u.applyPatchOperation(&PatchOperation{Op: operation.Op, Path: path, Value: value}); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+	attribute, _, subAttribute := splitPath(operation.Path)
+	switch attribute {
+	case "username":
+		return patchString(operation, &u.UserName, false)
+	case "displayname":
+		return patchString(operation, &u.DisplayName, false)
+	case "nickname":
+		return patchString(operation, &u.NickName, true)
+	case "preferredlanguage":
+		return patchString(operation, &u.PreferredLanguage, false)
+	case "password":
+		return patchString(operation, &u.Password, false)
+	case "active":
+		return patchBool(operation, &u.Active)
+	case "name":
+		return u.patchName(operation, subAttribute)
+	case "emails":
+		return patchMultiValued(operation, subAttribute, &u.Emails, false)
+	case "phonenumbers":
+		return patchMultiValued(operation, subAttribute, &u.PhoneNumbers, true)
+	case "schemas", "id", "meta", "externalid":
+		// read only or not stored attributes are ignored
+		return nil
+	default:
+		return invalidPathError("SCIM-Eeb2o")
+	}
+}
+
+func (u *User) patchName(operation *PatchOperation, subAttribute string) error {
+	if u.Name == nil {
+		u.Name = new(Name)
+	}
+	switch subAttribute {
+	case "":
+		if operation.Op == patchOpRemove {
+			return mutabilityError("SCIM-Iek1o")
+		}
+		name := new(Name)
+		if err := json.Unmarshal(operation.Value, name); err != nil {
+			return invalidValueError("SCIM-Cai3u", err)
+		}
+		if name.GivenName != "" {
+			u.Name.GivenName = name.GivenName
+		}
+		if name.FamilyName != "" {
+			u.Name.FamilyName = name.FamilyName
+		}
+		return nil
+	case "givenname":
+		return patchString(operation, &u.Name.GivenName, false)
+	case "familyname":
+		return patchString(operation, &u.Name.FamilyName, false)
+	case "formatted":
+		// the formatted name is derived from the given and family name
+		return nil
+	default:
+		return invalidPathError("SCIM-Ook7e")
+	}
+}
+
+// patchMultiValued patches the primary value of emails and phone numbers,
+// values of other types are ignored, as ZITADEL stores a single value
+func patchMultiValued(operation *PatchOperation, subAttribute string, values *[]*MultiValued, removable bool) error {
+	if operation.Op == patchOpRemove {
+		if !removable {
+			return mutabilityError("SCIM-Wai7a")
+		}
+		*values = nil
+		return nil
+	}
+	switch subAttribute {
+	case "":
+		patched := make([]*MultiValued, 0, 1)
+		if err := json.Unmarshal(operation.Value, &patched); err != nil {
+			value := new(MultiValued)
+			if err := json.Unmarshal(operation.Value, value); err != nil {
+				return invalidValueError("SCIM-Ha5ie", err)
+			}
+			patched = append(patched, value)
+		}
+		if primary := primaryValue(patched); primary != "" {
+			*values = []*MultiValued{{Value: primary, Primary: true}}
+		}
+		return nil
+	case "value":
+		var value string
+		if err := json.Unmarshal(operation.Value, &value); err != nil {
+			return invalidValueError("SCIM-Ohn8i", err)
+		}
+		*values = []*MultiValued{{Value: value, Primary: true}}
+		return nil
+	default:
+		return nil
+	}
+}
+
+// userFilterToQuery maps the filter to user search queries,
+// string attributes of the user schema are case insensitive
+func userFilterToQuery(f filter) (query.SearchQuery, error) {
+	switch f := f.(type) {
+	case *logicalFilter:
+		left, err := userFilterToQuery(f.left)
+		if err != nil {
+			return nil, err
+		}
+		right, err := userFilterToQuery(f.right)
+		if err != nil {
+			return nil, err
+		}
+		if f.operator == filterOperatorOr {
+			return query.NewUserOrSearchQuery([]query.SearchQuery{left, right})
+		}
+		return query.NewUserAndSearchQuery([]query.SearchQuery{left, right})
+	case *notFilter:
+		q, err := userFilterToQuery(f.filter)
+		if err != nil {
+			return nil, err
+		}
+		return query.NewUserNotSearchQuery(q)
+	case *attributeFilter:
+		return userAttributeFilterToQuery(f)
+	default:
+		return nil, invalidFilterError("SCIM-eiT7i")
+	}
+}
+
+func userAttributeFilterToQuery(f *attributeFilter) (query.SearchQuery, error) {
+	switch f.attribute {
+	case "id":
+		return userIDFilterToQuery(f)
+	case "active":
+		return userActiveFilterToQuery(f)
+	}
+	newQuery, ok := userTextQueries[f.attribute]
+	if !ok {
+		return nil, invalidFilterError("SCIM-ahH4o")
+	}
+	if f.operator == filterOperatorPresent {
+		return newQuery("", query.TextNotEquals)
+	}
+	value, err := f.stringValue()
+	if err != nil {
+		return nil, err
+	}
+	return textFilterToQuery(f.operator, func(comparison query.TextComparison) (query.SearchQuery, error) {
+		return newQuery(value, comparison)
+	})
+}
+
+var userTextQueries = map[string]func(string, query.TextComparison) (query.SearchQuery, error){
+	"username":           query.NewUserUsernameSearchQuery,
+	"name.givenname":     query.NewUserFirstNameSearchQuery,
+	"name.familyname":    query.NewUserLastNameSearchQuery,
+	"nickname":           query.NewUserNickNameSearchQuery,
+	"displayname":        query.NewUserDisplayNameSearchQuery,
+	"emails":             query.NewUserEmailSearchQuery,
+	"emails.value":       query.NewUserEmailSearchQuery,
+	"phonenumbers":       query.NewUserPhoneSearchQuery,
+	"phonenumbers.value": query.NewUserPhoneSearchQuery,
+}
+
+func userIDFilterToQuery(f *attributeFilter) (query.SearchQuery, error) {
+	value, err := f.stringValue()
+	if err != nil {
+		return nil, err
+	}
+	switch f.operator {
+	case filterOperatorEqual:
+		return query.NewUserInUserIdsSearchQuery([]string{value})
+	case filterOperatorNotEqual:
+		q, err := query.NewUserInUserIdsSearchQuery([]string{value})
+		if err != nil {
+			return nil, err
+		}
+		return query.NewUserNotSearchQuery(q)
+	default:
+		return nil, invalidFilterError("SCIM-ohB3i")
+	}
+}
+
+func userActiveFilterToQuery(f *attributeFilter) (query.SearchQuery, error) {
+	active, ok := f.value.(bool)
+	if !ok || (f.operator != filterOperatorEqual && f.operator != filterOperatorNotEqual) {
+		return nil, invalidFilterError("SCIM-Oozi9")
+	}
+	inactiveQuery, err := query.NewUserStateSearchQuery(int32(domain.UserStateInactive))
+	if err != nil {
+		return nil, err
+	}
+	if active == (f.operator == filterOperatorEqual) {
+		return query.NewUserNotSearchQuery(inactiveQuery)
+	}
+	return inactiveQuery, nil
+}
+
+// textFilterToQuery maps the string operators of the filter to text queries,
+// which are all case insensitive, ne is the negation of eq
+func textFilterToQuery(operator string, newQuery func(query.TextComparison) (query.SearchQuery, error)) (query.SearchQuery, error) {
+	var comparison query.TextComparison
+	switch operator {
+	case filterOperatorEqual, filterOperatorNotEqual:
+		comparison = query.TextEqualsIgnoreCase
+	case filterOperatorContains:
+		comparison = query.TextContainsIgnoreCase
+	case filterOperatorStartsWith:
+		comparison = query.TextStartsWithIgnoreCase
+	case filterOperatorEndsWith:
+		comparison = query.TextEndsWithIgnoreCase
+	default:
+		return nil, invalidFilterError("SCIM-Iew3a")
+	}
+	q, err := newQuery(comparison)
+	if err != nil || operator != filterOperatorNotEqual {
+		return q, err
+	}
+	return query.NewNotQuery(q)
+}
@@ -0,0 +1,396 @@
+package scim
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// mockUsers stores the users in memory and records the called commands
+type mockUsers struct {
+	users map[string]*query.User
+	calls []string
+}
+
+func newMockUsers(users ...*query.User) *mockUsers {
+	m := &mockUsers{users: make(map[string]*query.User, len(users))}
+	for _, user := range users {
+		m.users[user.ID] = user
+	}
+	return m
+}
+
+func (m *mockUsers) AddHuman(_ context.Context, resourceOwner string, human *command.AddHuman, _ bool) error {
+	m.calls = append(m.calls, "AddHuman")
+	if human.Username == "" {
+		return errors.ThrowInvalidArgument(nil, "V2-zzad3", "Errors.Invalid.Argument")
+	}
+	human.ID = "user1"
+	state := domain.UserStateActive
+	if human.Inactive {
+		state = domain.UserStateInactive
+	}
+	m.users[human.ID] = &query.User{
+		ID:            human.ID,
+		ResourceOwner: resourceOwner,
+		State:         state,
+		Username:      human.Username,
+		Human: &query.Human{
+			FirstName: human.FirstName,
+			LastName:  human.LastName,
+			Email:     human.Email.Address,
+			Phone:     human.Phone.Number,
+		},
+	}
+	return nil
+}
+
+func (m *mockUsers) ChangeUsername(_ context.Context, _, userID, userName string) (*domain.ObjectDetails, error) {
+	m.calls = append(m.calls, "ChangeUsername")
+	m.users[userID].Username = userName
+	return nil, nil
+}
+
+func (m *mockUsers) ChangeHumanProfile(_ context.Context, profile *domain.Profile) (*domain.Profile, error) {
+	m.calls = append(m.calls, "ChangeHumanProfile")
+	human := m.users[profile.AggregateID].Human
+	human.FirstName, human.LastName, human.NickName, human.DisplayName = profile.FirstName, profile.LastName, profile.NickName, profile.DisplayName
+	return profile, nil
+}
+
+func (m *mockUsers) ChangeUserEmail(_ context.Context, userID, _, email string, _ crypto.EncryptionAlgorithm) (*domain.Email, error) {
+	m.calls = append(m.calls, "ChangeUserEmail")
+	m.users[userID].Human.Email = domain.EmailAddress(email)
+	return nil, nil
+}
+
+func (m *mockUsers) ChangeUserEmailVerified(_ context.Context, userID, _, email string) (*domain.Email, error) {
+	m.calls = append(m.calls, "ChangeUserEmailVerified")
+	m.users[userID].Human.Email = domain.EmailAddress(email)
+	return nil, nil
+}
+
+func (m *mockUsers) ChangeUserPhone(_ context.Context, userID, _, phone string, _ crypto.EncryptionAlgorithm) (*domain.Phone, error) {
+	m.calls = append(m.calls, "ChangeUserPhone")
+	m.users[userID].Human.Phone = domain.PhoneNumber(phone)
+	return nil, nil
+}
+
+func (m *mockUsers) ChangeUserPhoneVerified(_ context.Context, userID, _, phone string) (*domain.Phone, error) {
+	m.calls = append(m.calls, "ChangeUserPhoneVerified")
+	m.users[userID].Human.Phone = domain.PhoneNumber(phone)
+	return nil, nil
+}
+
+func (m *mockUsers) RemoveHumanPhone(_ context.Context, userID, _ string) (*domain.ObjectDetails, error) {
+	m.calls = append(m.calls, "RemoveHumanPhone")
+	m.users[userID].Human.Phone = ""
+	return nil, nil
+}
+
+func (m *mockUsers) SetPassword(context.Context, string, string, string, bool) (*domain.ObjectDetails, error) {
+	m.calls = append(m.calls, "SetPassword")
+	return nil, nil
+}
+
+func (m *mockUsers) DeactivateUser(_ context.Context, userID, _ string) (*domain.ObjectDetails, error) {
+	m.calls = append(m.calls, "DeactivateUser")
+	m.users[userID].State = domain.UserStateInactive
+	return nil, nil
+}
+
+func (m *mockUsers) ReactivateUser(_ context.Context, userID, _ string) (*domain.ObjectDetails, error) {
+	m.calls = append(m.calls, "ReactivateUser")
+	m.users[userID].State = domain.UserStateActive
+	return nil, nil
+}
+
+func (m *mockUsers) RemoveUser(_ context.Context, userID, _ string, _ []*command.CascadingMembership, _ ...string) (*domain.ObjectDetails, error) {
+	m.calls = append(m.calls, "RemoveUser")
+	delete(m.users, userID)
+	return nil, nil
+}
+
+func (m *mockUsers) AddUserGrant(context.Context, *domain.UserGrant, string) (*domain.UserGrant, error) {
+	return nil, nil
+}
+
+func (m *mockUsers) ChangeUserGrant(context.Context, *domain.UserGrant, string) (*domain.UserGrant, error) {
+	return nil, nil
+}
+
+func (m *mockUsers) RemoveUserGrant(context.Context, string, string) (*domain.ObjectDetails, error) {
+	return nil, nil
+}
+
+func (m *mockUsers) GetUserByID(_ context.Context, _ bool, userID string, _ ...query.SearchQuery) (*query.User, error) {
+	user, ok := m.users[userID]
+	if !ok {
+		return nil, errors.ThrowNotFound(nil, "QUERY-Dfbg2", "Errors.User.NotFound")
+	}
+	// the handler must not change the stored user
+	copied := *user
+	human := *user.Human
+	copied.Human = &human
+	return &copied, nil
+}
+
+func (m *mockUsers) SearchUsers(context.Context, *query.UserSearchQueries) (*query.Users, error) {
+	return &query.Users{}, nil
+}
+
+func (m *mockUsers) Memberships(context.Context, *query.MembershipSearchQuery, bool) (*query.Memberships, error) {
+	return &query.Memberships{}, nil
+}
+
+func (m *mockUsers) UserGrants(context.Context, *query.UserGrantsQueries, bool, bool) (*query.UserGrants, error) {
+	return &query.UserGrants{}, nil
+}
+
+func (m *mockUsers) SearchProjectRoles(context.Context, bool, *query.ProjectRoleSearchQueries) (*query.ProjectRoles, error) {
+	return &query.ProjectRoles{}, nil
+}
+
+func existingUser() *query.User {
+	return &query.User{
+		ID:            "user1",
+		ResourceOwner: "org1",
+		State:         domain.UserStateActive,
+		Username:      "gigi",
+		Human: &query.Human{
+			FirstName:   "Gigi",
+			LastName:    "Giraffe",
+			DisplayName: "Gigi Giraffe",
+			Email:       "gigi@zitadel.com",
+			Phone:       "+41791234567",
+		},
+	}
+}
+
+func newUserRequest(method, id, body string) *http.Request {
+	r := httptest.NewRequest(method, "/scim/v2/org1/Users", strings.NewReader(body))
+	r = r.WithContext(authz.NewMockContext("instance1", "org1", "admin"))
+	if id == "" {
+		return r
+	}
+	return mux.SetURLVars(r, map[string]string{"id": id})
+}
+
+func TestHandler_createUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantActive    bool
+		wantCalls     []string
+		wantErrStatus int
+	}{
+		{
+			name:       "active, ok",
+			body:       `{"schemas":["urn:ietf:params:scim:schemas:core:2.0:User"],"userName":"gigi","name":{"givenName":"Gigi","familyName":"Giraffe"},"emails":[{"value":"gigi@zitadel.com","primary":true}]}`,
+			wantActive: true,
+			wantCalls:  []string{"AddHuman"},
+		},
+		{
+			name:      "inactive, created in a single command",
+			body:      `{"userName":"gigi","name":{"givenName":"Gigi","familyName":"Giraffe"},"emails":[{"value":"gigi@zitadel.com"}],"active":false}`,
+			wantCalls: []string{"AddHuman"},
+		},
+		{
+			name:          "invalid json, invalid argument error",
+			body:          `{"userName":`,
+			wantErrStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "invalid user, invalid argument error",
+			body:          `{"name":{"givenName":"Gigi"}}`,
+			wantCalls:     []string{"AddHuman"},
+			wantErrStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newMockUsers()
+			h := &Handler{commands: users, query: users}
+
+			resource, status, err := h.createUser(newUserRequest(http.MethodPost, "", tt.body))
+			assert.Equal(t, tt.wantCalls, users.calls)
+			if tt.wantErrStatus != 0 {
+				errStatus, _ := errorToResponse(err)
+				assert.Equal(t, tt.wantErrStatus, errStatus, "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusCreated, status)
+			user := resource.(*User)
+			assert.Equal(t, "user1", user.ID)
+			assert.Equal(t, "gigi", user.UserName)
+			require.NotNil(t, user.Active)
+			assert.Equal(t, tt.wantActive, *user.Active)
+		})
+	}
+}
+
+func TestHandler_replaceUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		body          string
+		wantCalls     []string
+		wantUser      func(t *testing.T, user *User)
+		wantErrStatus int
+	}{
+		{
+			name:      "unchanged, ok",
+			id:        "user1",
+			body:      `{"userName":"gigi","name":{"givenName":"Gigi","familyName":"Giraffe"},"displayName":"Gigi Giraffe","emails":[{"value":"gigi@zitadel.com"}],"phoneNumbers":[{"value":"+41791234567"}],"active":true}`,
+			wantCalls: nil,
+			wantUser: func(t *testing.T, user *User) {
+				assert.Equal(t, "gigi", user.UserName)
+				assert.True(t, *user.Active)
+			},
+		},
+		{
+			name:      "changed and deactivated, ok",
+			id:        "user1",
+			body:      `{"userName":"gigi2","name":{"givenName":"Gigi","familyName":"Zebra"},"emails":[{"value":"gigi2@zitadel.com"}],"active":false}`,
+			wantCalls: []string{"ChangeUsername", "ChangeHumanProfile", "ChangeUserEmail", "RemoveHumanPhone", "DeactivateUser"},
+			wantUser: func(t *testing.T, user *User) {
+				assert.Equal(t, "gigi2", user.UserName)
+				assert.Equal(t, "Zebra", user.Name.FamilyName)
+				assert.Equal(t, "gigi2@zitadel.com", user.Emails[0].Value)
+				assert.Empty(t, user.PhoneNumbers)
+				assert.False(t, *user.Active)
+			},
+		},
+		{
+			name:          "not existing, not found error",
+			id:            "user2",
+			body:          `{"userName":"gigi"}`,
+			wantErrStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newMockUsers(existingUser())
+			h := &Handler{commands: users, query: users}
+
+			resource, status, err := h.replaceUser(newUserRequest(http.MethodPut, tt.id, tt.body))
+			assert.Equal(t, tt.wantCalls, users.calls)
+			if tt.wantErrStatus != 0 {
+				errStatus, _ := errorToResponse(err)
+				assert.Equal(t, tt.wantErrStatus, errStatus, "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, status)
+			tt.wantUser(t, resource.(*User))
+		})
+	}
+}
+
+func TestHandler_patchUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		body          string
+		wantCalls     []string
+		wantUser      func(t *testing.T, user *User)
+		wantErrStatus int
+	}{
+		{
+			name:      "replace given name and remove phone, ok",
+			body:      `{"schemas":["urn:ietf:params:scim:api:messages:2.0:PatchOp"],"Operations":[{"op":"Replace","path":"name.givenName","value":"Gigo"},{"op":"remove","path":"phoneNumbers"}]}`,
+			wantCalls: []string{"ChangeHumanProfile", "RemoveHumanPhone"},
+			wantUser: func(t *testing.T, user *User) {
+				assert.Equal(t, "Gigo", user.Name.GivenName)
+				assert.Equal(t, "Giraffe", user.Name.FamilyName)
+				assert.Empty(t, user.PhoneNumbers)
+			},
+		},
+		{
+			name:      "deactivate without path, ok",
+			body:      `{"Operations":[{"op":"replace","value":{"active":false}}]}`,
+			wantCalls: []string{"DeactivateUser"},
+			wantUser: func(t *testing.T, user *User) {
+				assert.False(t, *user.Active)
+			},
+		},
+		{
+			name:          "remove email, invalid argument error",
+			body:          `{"Operations":[{"op":"remove","path":"emails"}]}`,
+			wantErrStatus: http.StatusBadRequest,
+		},
+		{
+			name:          "unknown operation, invalid argument error",
+			body:          `{"Operations":[{"op":"move","path":"userName","value":"gigi2"}]}`,
+			wantErrStatus: http.StatusBadRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newMockUsers(existingUser())
+			h := &Handler{commands: users, query: users}
+
+			resource, status, err := h.patchUser(newUserRequest(http.MethodPatch, "user1", tt.body))
+			assert.Equal(t, tt.wantCalls, users.calls)
+			if tt.wantErrStatus != 0 {
+				errStatus, _ := errorToResponse(err)
+				assert.Equal(t, tt.wantErrStatus, errStatus, "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusOK, status)
+			tt.wantUser(t, resource.(*User))
+		})
+	}
+}
+
+func TestHandler_deleteUser(t *testing.T) {
+	tests := []struct {
+		name          string
+		id            string
+		wantCalls     []string
+		wantErrStatus int
+	}{
+		{
+			name:      "delete, ok",
+			id:        "user1",
+			wantCalls: []string{"RemoveUser"},
+		},
+		{
+			name:          "not existing, not found error",
+			id:            "user2",
+			wantErrStatus: http.StatusNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			users := newMockUsers(existingUser())
+			h := &Handler{commands: users, query: users}
+
+			resource, status, err := h.deleteUser(newUserRequest(http.MethodDelete, tt.id, ""))
+			assert.Equal(t, tt.wantCalls, users.calls)
+			if tt.wantErrStatus != 0 {
+				errStatus, _ := errorToResponse(err)
+				assert.Equal(t, tt.wantErrStatus, errStatus, "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, http.StatusNoContent, status)
+			assert.Nil(t, resource)
+			assert.NotContains(t, users.users, tt.id)
+		})
+	}
+}
@@ -0,0 +1,24 @@
+package http
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"strconv"
+	"time"
+)
+
+// SignatureHeader contains the timestamp and the HMAC-SHA256 signature of a request sent by ZITADEL,
+// e.g. to the target of an action or the webhook of the event forwarding,
+// in the form t=<unix timestamp>,v1=<hex encoded signature>.
+// The signature is calculated over "<timestamp>.<body>" with the signing key of the receiver.
+const SignatureHeader = "ZITADEL-Signature"
+
+// Sign returns the value of the SignatureHeader for the body
+func Sign(key []byte, timestamp time.Time, body []byte) string {
+	t := strconv.FormatInt(timestamp.Unix(), 10)
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(t + "."))
+	mac.Write(body)
+	return "t=" + t + ",v1=" + hex.EncodeToString(mac.Sum(nil))
+}
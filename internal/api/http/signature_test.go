@@ -0,0 +1,32 @@
+package http
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSign(t *testing.T) {
+	tests := []struct {
+		name string
+		body string
+		want string
+	}{
+		{
+			name: "action target",
+			body: `{"function":"testFunc"}`,
+			want: "t=1700000000,v1=c48922f3cb16e66ab1f93efeebebff5153db95a4e6698985ab6f4fcfd150c4c6",
+		},
+		{
+			name: "webhook",
+			body: `{"eventType":"user.human.added"}`,
+			want: "t=1700000000,v1=1c48263af8f14c03e1133e1025b60c4639ab00c30014e184b6c08a55145716af",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, Sign([]byte("key"), time.Unix(1700000000, 0), []byte(tt.body)))
+		})
+	}
+}
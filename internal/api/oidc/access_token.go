@@ -25,6 +25,7 @@ type accessToken struct {
 	tokenCreation   time.Time
 	tokenExpiration time.Time
 	isPAT           bool
+	dpopJKT         string
 }
 
 func (s *Server) verifyAccessToken(ctx context.Context, tkn string) (*accessToken, error) {
@@ -71,6 +72,7 @@ func accessTokenV1(tokenID, subject string, token *model.TokenView) *accessToken
 		tokenCreation:   token.CreationDate,
 		tokenExpiration: token.Expiration,
 		isPAT:           token.IsPAT,
+		dpopJKT:         token.DPoPJKT,
 	}
 }
 
@@ -84,6 +86,7 @@ func accessTokenV2(tokenID, subject string, token *query.OIDCSessionAccessTokenR
 		scope:           token.Scope,
 		tokenCreation:   token.AccessTokenCreation,
 		tokenExpiration: token.AccessTokenExpiration,
+		dpopJKT:         token.DPoPJKT,
 	}
 }
 
@@ -199,15 +199,30 @@ func (o *OPStorage) CreateAccessToken(ctx context.Context, req op.TokenRequest)
 	case *AuthRequestV2:
 		// trigger activity log for authentication for user
 		activity.TriggerHTTP(ctx, "", authReq.CurrentAuthRequest.UserID, activity.OIDCAccessToken)
-		return o.command.AddOIDCSessionAccessToken(setContextUserSystem(ctx), authReq.GetID())
+		return o.command.AddOIDCSessionAccessToken(setContextUserSystem(ctx), authReq.GetID(), dpopJKTFromContext(ctx), op.IssuerFromContext(ctx))
+	case *tokenExchangeRequest:
+		applicationID = authReq.clientID
+		userOrgID = authReq.subject.resourceOwner
+	case *backChannelAuthTokenRequest:
+		applicationID = authReq.GetClientID()
+		userOrgID = authReq.resourceOwner
+		// as for refresh tokens, the project roles are asserted when the tokens are issued
+		scopes, err := o.assertProjectRoleScopes(ctx, applicationID, authReq.GetScopes())
+		if err != nil {
+			return "", time.Time{}, errors.ThrowPreconditionFailed(err, "OIDC-Ahx3o", "Errors.Internal")
+		}
+		authReq.SetCurrentScopes(scopes)
 	}
 
 	accessTokenLifetime, _, _, _, err := o.getOIDCSettings(ctx)
 	if err != nil {
 		return "", time.Time{}, err
 	}
+	if exchange, ok := req.(*tokenExchangeRequest); ok {
+		accessTokenLifetime = exchange.lifetime(accessTokenLifetime)
+	}
 
-	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime) //PLANNED: lifetime from client
+	resp, err := o.command.AddUserToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(), req.GetAudience(), req.GetScopes(), accessTokenLifetime, dpopJKTFromContext(ctx), op.IssuerFromContext(ctx)) //PLANNED: lifetime from client
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -226,11 +241,15 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 	case *AuthRequestV2:
 		// trigger activity log for authentication for user
 		activity.TriggerHTTP(ctx, "", tokenReq.GetSubject(), activity.OIDCRefreshToken)
-		return o.command.AddOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.GetID())
+		return o.command.AddOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.GetID(), dpopJKTFromContext(ctx), op.IssuerFromContext(ctx))
 	case *RefreshTokenRequestV2:
 		// trigger activity log for authentication for user
 		activity.TriggerHTTP(ctx, "", tokenReq.GetSubject(), activity.OIDCRefreshToken)
-		return o.command.ExchangeOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.OIDCSessionWriteModel.AggregateID, refreshToken, tokenReq.RequestedScopes)
+		accessTokenID, newRefreshToken, expiration, err := o.command.ExchangeOIDCSessionRefreshAndAccessToken(setContextUserSystem(ctx), tokenReq.OIDCSessionWriteModel.AggregateID, refreshToken, tokenReq.RequestedScopes, dpopJKTFromContext(ctx))
+		if errors.IsPreconditionFailed(err) {
+			err = oidc.ErrInvalidGrant().WithParent(err)
+		}
+		return accessTokenID, newRefreshToken, expiration, err
 	}
 
 	userAgentID, applicationID, userOrgID, authTime, authMethodsReferences := getInfoFromRequest(req)
@@ -249,7 +268,7 @@ func (o *OPStorage) CreateAccessAndRefreshTokens(ctx context.Context, req op.Tok
 
 	resp, token, err := o.command.AddAccessAndRefreshToken(setContextUserSystem(ctx), userOrgID, userAgentID, applicationID, req.GetSubject(),
 		refreshToken, req.GetAudience(), scopes, authMethodsReferences, accessTokenLifetime,
-		refreshTokenIdleExpiration, refreshTokenExpiration, authTime) //PLANNED: lifetime from client
+		refreshTokenIdleExpiration, refreshTokenExpiration, authTime, dpopJKTFromContext(ctx), op.IssuerFromContext(ctx)) //PLANNED: lifetime from client
 	if err != nil {
 		if errors.IsErrorInvalidArgument(err) {
 			err = oidc.ErrInvalidGrant().WithParent(err)
@@ -334,7 +353,11 @@ func (o *OPStorage) TerminateSessionFromRequest(ctx context.Context, endSessionR
 	// and if not provided, terminate the session using the V1 method
 	headers, _ := http_utils.HeadersFromCtx(ctx)
 	if loginClient := headers.Get(LoginClientHeader); loginClient == "" {
-		return endSessionRequest.RedirectURI, o.TerminateSession(ctx, endSessionRequest.UserID, endSessionRequest.ClientID)
+		logouts := o.userAgentSessionLogouts(ctx)
+		if err = o.TerminateSession(ctx, endSessionRequest.UserID, endSessionRequest.ClientID); err != nil {
+			return endSessionRequest.RedirectURI, err
+		}
+		return o.frontChannelLogoutRedirect(ctx, logouts, endSessionRequest.RedirectURI)
 	}
 
 	// in case there are not id_token_hint, redirect to the UI and let it decide which session to terminate
@@ -343,11 +366,29 @@ func (o *OPStorage) TerminateSessionFromRequest(ctx context.Context, endSessionR
 	}
 
 	// terminate the session of the id_token_hint
+	logouts, err := sessionLogouts(ctx, o.eventstore, endSessionRequest.IDTokenHintClaims.SessionID)
+	logging.OnError(err).Warn("unable to get clients of the session for the front-channel logout")
 	_, err = o.command.TerminateSessionWithoutTokenCheck(ctx, endSessionRequest.IDTokenHintClaims.SessionID)
 	if err != nil {
 		return "", err
 	}
-	return endSessionRequest.RedirectURI, nil
+	return o.frontChannelLogoutRedirect(ctx, logouts, endSessionRequest.RedirectURI)
+}
+
+// userAgentSessionLogouts returns the clients of all user sessions of the user agent (V1),
+// which need to be informed by the front-channel logout
+func (o *OPStorage) userAgentSessionLogouts(ctx context.Context) []*logoutSession {
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return nil
+	}
+	userIDs, err := o.repo.UserSessionUserIDsByAgentID(ctx, userAgentID)
+	if err != nil || len(userIDs) == 0 {
+		return nil
+	}
+	logouts, err := userAgentLogouts(ctx, o.eventstore, userAgentID, userIDs...)
+	logging.OnError(err).Warn("unable to get clients of the user agent for the front-channel logout")
+	return logouts
 }
 
 func (o *OPStorage) RevokeToken(ctx context.Context, token, userID, clientID string) (err *oidc.Error) {
@@ -0,0 +1,573 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/delivery"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// BackChannelAuthProjectionName is used to store the position of the ping notifications in the current states
+	// and the events which could not be reduced in the failed events.
+	BackChannelAuthProjectionName = "projections.oidc_backchannel_auth_ping"
+
+	BackChannelAuthDefaultLifetime     = 5 * time.Minute
+	BackChannelAuthDefaultPollInterval = 5 * time.Second
+
+	backChannelAuthEndpoint = "/oauth/v2/bc-authorize"
+	// grantTypeCIBA is the grant type to request the tokens of a backchannel authentication request
+	// https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.10.1
+	grantTypeCIBA oidc.GrantType = "urn:openid:params:grant-type:ciba"
+
+	backChannelTokenDeliveryModePoll = "poll"
+	backChannelTokenDeliveryModePing = "ping"
+	backChannelBindingMessageMaxLen  = 200
+	authReqIDParam                   = "auth_req_id"
+)
+
+// BackChannelAuthConfig of the backchannel authentication.
+// Failed pings are retried by the handler, see RetryFailedAfter and MaxFailureCount
+// of the projection customization.
+type BackChannelAuthConfig struct {
+	Enabled bool
+	// Lifetime is the default and maximum lifetime of a request, the client can request a shorter one
+	Lifetime time.Duration
+	// PollInterval is the minimum time the client has to wait between two token requests
+	PollInterval time.Duration
+	// Notification is the channel the user is asked to approve the request on (email, sms or webhook)
+	Notification string
+	// WebhookURL is called for the webhook notification
+	WebhookURL string
+	// Timeout of a single request to the application
+	Timeout time.Duration
+}
+
+func (c *BackChannelAuthConfig) lifetime() time.Duration {
+	if c.Lifetime == 0 {
+		return BackChannelAuthDefaultLifetime
+	}
+	return c.Lifetime
+}
+
+func (c *BackChannelAuthConfig) pollInterval() time.Duration {
+	if c.PollInterval == 0 {
+		return BackChannelAuthDefaultPollInterval
+	}
+	return c.PollInterval
+}
+
+func (c *BackChannelAuthConfig) notification() (domain.BackChannelAuthNotification, error) {
+	switch strings.ToLower(c.Notification) {
+	case "", "email":
+		return domain.BackChannelAuthNotificationEmail, nil
+	case "sms":
+		return domain.BackChannelAuthNotificationSMS, nil
+	case "webhook":
+		return domain.BackChannelAuthNotificationWebhook, nil
+	default:
+		return 0, errors.ThrowInternalf(nil, "OIDC-Eek6u", "backchannel authentication notification %s is not supported", c.Notification)
+	}
+}
+
+func (s *Server) backChannelAuthEnabled() bool {
+	return s.backChannelAuth != nil && s.backChannelAuth.Enabled
+}
+
+// backChannelAuthenticationRequest are the parameters of the backchannel authentication endpoint
+// https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.7.1
+type backChannelAuthenticationRequest struct {
+	Scopes                  oidc.SpaceDelimitedArray `schema:"scope"`
+	ClientNotificationToken string                   `schema:"client_notification_token"`
+	LoginHintToken          string                   `schema:"login_hint_token"`
+	IDTokenHint             string                   `schema:"id_token_hint"`
+	LoginHint               string                   `schema:"login_hint"`
+	BindingMessage          string                   `schema:"binding_message"`
+	UserCode                string                   `schema:"user_code"`
+	RequestedExpiry         int64                    `schema:"requested_expiry"`
+	Request                 string                   `schema:"request"`
+}
+
+// backChannelAuthenticationResponse is the response of the backchannel authentication endpoint
+// https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.7.3
+type backChannelAuthenticationResponse struct {
+	AuthReqID string `json:"auth_req_id"`
+	ExpiresIn uint64 `json:"expires_in"`
+	Interval  uint64 `json:"interval,omitempty"`
+}
+
+// backChannelAuthHandler implements the backchannel authentication endpoint of the
+// OpenID Connect Client-Initiated Backchannel Authentication (CIBA).
+// The client authenticates itself as on the token endpoint and identifies the user by a login_hint or id_token_hint.
+// The user is notified to approve the request in the login UI or through the session API,
+// while the client polls the token endpoint with the returned auth_req_id (poll mode)
+// or waits for the callback to its client notification endpoint (ping mode).
+func (s *Server) backChannelAuthHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	resp, err := s.backChannelAuthenticate(ctx, r)
+	if err != nil {
+		op.RequestError(w, r, err, s.getLogger(ctx))
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+func (s *Server) backChannelAuthenticate(ctx context.Context, r *http.Request) (_ *backChannelAuthenticationResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	credentials, err := s.parseClientCredentials(r)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.VerifyClient(ctx, &op.Request[op.ClientCredentials]{
+		Method:   r.Method,
+		URL:      r.URL,
+		Header:   r.Header,
+		Form:     r.Form,
+		PostForm: r.PostForm,
+		Data:     credentials,
+	})
+	if err != nil {
+		return nil, err
+	}
+	c, ok := client.(*Client)
+	if !ok || !op.ValidateGrantType(c, grantTypeCIBA) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("the client is not allowed to use the backchannel authentication")
+	}
+	request := new(backChannelAuthenticationRequest)
+	if err = s.Provider().Decoder().Decode(request, r.PostForm); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding form").WithParent(err)
+	}
+	if err = validateBackChannelAuthRequest(c, request); err != nil {
+		return nil, err
+	}
+	// only the scopes the client is allowed to request are stored
+	request.Scopes, err = op.ValidateAuthReqScopes(c, request.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.backChannelAuthUser(ctx, request)
+	if err != nil {
+		return nil, err
+	}
+	notification, err := s.backChannelAuth.notification()
+	if err != nil {
+		return nil, err
+	}
+	authReqID, err := op.NewDeviceCode(op.RecommendedDeviceCodeBytes)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	lifetime := s.backChannelAuth.lifetime()
+	if requested := time.Duration(request.RequestedExpiry) * time.Second; requested > 0 && requested < lifetime {
+		lifetime = requested
+	}
+	_, _, err = s.command.AddBackChannelAuth(setContextUserSystem(ctx), &domain.BackChannelAuth{
+		ClientID:                c.GetID(),
+		AuthReqID:               authReqID,
+		UserID:                  user.ID,
+		Scopes:                  request.Scopes,
+		BindingMessage:          request.BindingMessage,
+		ClientNotificationToken: request.ClientNotificationToken,
+		Expires:                 time.Now().Add(lifetime),
+		Notification:            notification,
+		WebhookURL:              s.backChannelAuth.WebhookURL,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &backChannelAuthenticationResponse{
+		AuthReqID: authReqID,
+		ExpiresIn: uint64(lifetime / time.Second),
+		Interval:  uint64(s.backChannelAuth.pollInterval() / time.Second),
+	}, nil
+}
+
+// validateBackChannelAuthRequest checks the parameters of the request against the spec and the client configuration.
+// Clients with a client notification endpoint use the ping mode and must send a client_notification_token.
+func validateBackChannelAuthRequest(client *Client, request *backChannelAuthenticationRequest) error {
+	if request.Request != "" {
+		return oidc.ErrRequestNotSupported().WithDescription("signed authentication requests are not supported")
+	}
+	if !slices.Contains(request.Scopes, oidc.ScopeOpenID) {
+		return oidc.ErrInvalidScope().WithDescription("scope openid is required")
+	}
+	if request.LoginHintToken != "" {
+		return oidc.ErrInvalidRequest().WithDescription("login_hint_token is not supported")
+	}
+	if request.UserCode != "" {
+		return oidc.ErrInvalidRequest().WithDescription("user_code is not supported")
+	}
+	if (request.LoginHint == "") == (request.IDTokenHint == "") {
+		return oidc.ErrInvalidRequest().WithDescription("exactly one of login_hint or id_token_hint is required")
+	}
+	if len(request.BindingMessage) > backChannelBindingMessageMaxLen {
+		return &oidc.Error{ErrorType: "invalid_binding_message", Description: "binding_message is too long"}
+	}
+	if request.RequestedExpiry < 0 {
+		return oidc.ErrInvalidRequest().WithDescription("requested_expiry must be positive")
+	}
+	if client.app.OIDCConfig != nil && client.app.OIDCConfig.BackChannelClientNotificationURI != "" && request.ClientNotificationToken == "" {
+		return oidc.ErrInvalidRequest().WithDescription("client_notification_token is required for the ping mode")
+	}
+	return nil
+}
+
+// backChannelAuthUser returns the active human user identified by the login_hint or id_token_hint
+func (s *Server) backChannelAuthUser(ctx context.Context, request *backChannelAuthenticationRequest) (_ *query.User, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	var user *query.User
+	if request.IDTokenHint != "" {
+		claims, verifyErr := op.VerifyIDTokenHint[*oidc.IDTokenClaims](ctx, request.IDTokenHint, s.Provider().IDTokenHintVerifier(ctx))
+		if verifyErr != nil {
+			return nil, oidc.ErrInvalidRequest().WithDescription("id_token_hint is invalid").WithParent(verifyErr)
+		}
+		user, err = s.query.GetUserByID(ctx, false, claims.Subject)
+	} else {
+		loginName, queryErr := query.NewUserLoginNamesSearchQuery(request.LoginHint)
+		if queryErr != nil {
+			return nil, queryErr
+		}
+		user, err = s.query.GetUser(ctx, false, loginName)
+	}
+	if errors.IsNotFound(err) {
+		return nil, &oidc.Error{ErrorType: "unknown_user_id", Description: "the user could not be identified", Parent: err}
+	}
+	if err != nil {
+		return nil, err
+	}
+	if user.Type != domain.UserTypeHuman || user.State != domain.UserStateActive {
+		return nil, &oidc.Error{ErrorType: "unknown_user_id", Description: "the user could not be identified"}
+	}
+	return user, nil
+}
+
+// backChannelAuthTokenHandler handles the token requests of the CIBA grant,
+// all other requests are passed to the token endpoint of the library, which doesn't support the grant type.
+// As the handler is called before the issuer interceptor of the library, the issuer is set to the context.
+func (s *Server) backChannelAuthTokenHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost || r.URL.Path != s.Endpoints().Token.Relative() ||
+			r.PostFormValue("grant_type") != string(grantTypeCIBA) || !s.backChannelAuthEnabled() {
+			next.ServeHTTP(w, r)
+			return
+		}
+		ctx := op.ContextWithIssuer(r.Context(), s.IssuerFromRequest(r))
+		resp, err := s.backChannelAuthToken(ctx, r)
+		if err != nil {
+			op.RequestError(w, r, err, s.getLogger(ctx))
+			return
+		}
+		httphelper.MarshalJSON(w, resp.Data)
+	})
+}
+
+func (s *Server) backChannelAuthToken(ctx context.Context, r *http.Request) (_ *op.Response, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	credentials, err := s.parseClientCredentials(r)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.VerifyClient(ctx, &op.Request[op.ClientCredentials]{
+		Method:   r.Method,
+		URL:      r.URL,
+		Header:   r.Header,
+		Form:     r.Form,
+		PostForm: r.PostForm,
+		Data:     credentials,
+	})
+	if err != nil {
+		return nil, err
+	}
+	c, ok := client.(*Client)
+	if !ok || !op.ValidateGrantType(c, grantTypeCIBA) {
+		return nil, oidc.ErrUnauthorizedClient().WithDescription("the client is not allowed to use the backchannel authentication")
+	}
+	authReqID := r.PostForm.Get(authReqIDParam)
+	if authReqID == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("auth_req_id missing")
+	}
+	ctx, err = s.verifyTokenRequestDPoP(ctx, r.Header, c)
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkBackChannelAuthPollInterval(ctx, c.GetID(), authReqID); err != nil {
+		return nil, err
+	}
+	auth, err := s.query.BackChannelAuthByAuthReqID(ctx, c.GetID(), authReqID)
+	if errors.IsNotFound(err) {
+		return nil, oidc.ErrInvalidGrant().WithDescription("auth_req_id is invalid").WithParent(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err = s.checkBackChannelAuthState(ctx, auth); err != nil {
+		return nil, err
+	}
+	// the approved request is removed before the tokens are issued,
+	// so concurrent token requests can't both receive them
+	auth, err = s.command.ConsumeBackChannelAuth(setContextUserSystem(ctx), auth.AggregateID)
+	if errors.IsNotFound(err) || errors.IsPreconditionFailed(err) || errors.IsErrorAlreadyExists(err) {
+		return nil, oidc.ErrInvalidGrant().WithDescription("auth_req_id is invalid").WithParent(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	user, err := s.query.GetUserByID(ctx, false, auth.UserID)
+	if err != nil {
+		return nil, err
+	}
+	request := &backChannelAuthTokenRequest{
+		auth:          auth,
+		resourceOwner: user.ResourceOwner,
+		audience:      []string{c.app.ProjectID, c.GetID()},
+		scopes:        auth.Scopes,
+	}
+	resp, err := s.createBackChannelAuthTokens(ctx, request, c)
+	if err != nil {
+		return nil, err
+	}
+	return dpopTokenResponse(ctx, resp), nil
+}
+
+// checkBackChannelAuthPollInterval returns the slow_down error,
+// if the client requests the tokens faster than the poll interval allows.
+func (s *Server) checkBackChannelAuthPollInterval(ctx context.Context, clientID, authReqID string) error {
+	ok, err := s.backChannelAuthPolls.poll(ctx, clientID, authReqID, s.backChannelAuth.pollInterval(), time.Now())
+	if err != nil {
+		return oidc.ErrServerError().WithParent(err)
+	}
+	if !ok {
+		return oidc.ErrSlowDown().WithDescription("the client must wait for the interval between the token requests")
+	}
+	return nil
+}
+
+// checkBackChannelAuthState returns the error of the token endpoint, as long as the request isn't approved.
+// Pending requests which passed their lifetime are canceled.
+func (s *Server) checkBackChannelAuthState(ctx context.Context, auth *domain.BackChannelAuth) error {
+	switch auth.State {
+	case domain.BackChannelAuthStateApproved:
+		return nil
+	case domain.BackChannelAuthStateInitiated:
+		if auth.Expires.After(time.Now()) {
+			return oidc.ErrAuthorizationPending()
+		}
+		_, err := s.command.CancelBackChannelAuth(setContextUserSystem(ctx), auth.AggregateID, domain.BackChannelAuthCanceledExpired)
+		logging.WithFields("id", auth.AggregateID).OnError(err).Warn("unable to cancel expired backchannel authentication")
+		return oidc.ErrExpiredDeviceCode().WithDescription("auth_req_id is expired")
+	case domain.BackChannelAuthStateDenied:
+		return oidc.ErrAccessDenied().WithDescription("the user denied the request")
+	case domain.BackChannelAuthStateExpired:
+		return oidc.ErrExpiredDeviceCode().WithDescription("auth_req_id is expired")
+	default:
+		return oidc.ErrInvalidGrant().WithDescription("auth_req_id is invalid")
+	}
+}
+
+// createBackChannelAuthTokens issues the access and id token of an approved request
+func (s *Server) createBackChannelAuthTokens(ctx context.Context, request *backChannelAuthTokenRequest, client *Client) (_ *op.Response, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	accessToken, _, validity, err := op.CreateAccessToken(ctx, request, client.AccessTokenType(), s.Provider(), client, "")
+	if err != nil {
+		return nil, err
+	}
+	idToken, err := op.CreateIDToken(ctx, op.IssuerFromContext(ctx), request, client.IDTokenLifetime(), accessToken, "", s.Provider().Storage(), client)
+	if err != nil {
+		return nil, err
+	}
+	return op.NewResponse(&oidc.AccessTokenResponse{
+		AccessToken: accessToken,
+		IDToken:     idToken,
+		TokenType:   oidc.BearerToken,
+		ExpiresIn:   uint64(validity.Seconds()),
+	}), nil
+}
+
+var (
+	_ op.TokenRequest   = (*backChannelAuthTokenRequest)(nil)
+	_ op.IDTokenRequest = (*backChannelAuthTokenRequest)(nil)
+)
+
+// backChannelAuthTokenRequest implements [op.TokenRequest] and [op.IDTokenRequest] for the tokens
+// issued for an approved backchannel authentication request
+type backChannelAuthTokenRequest struct {
+	auth          *domain.BackChannelAuth
+	resourceOwner string
+	audience      []string
+	scopes        []string
+}
+
+func (r *backChannelAuthTokenRequest) GetAMR() []string {
+	return AuthMethodTypesToAMR(r.auth.AuthMethods)
+}
+
+func (r *backChannelAuthTokenRequest) GetAudience() []string {
+	return r.audience
+}
+
+func (r *backChannelAuthTokenRequest) GetAuthTime() time.Time {
+	return r.auth.AuthTime
+}
+
+func (r *backChannelAuthTokenRequest) GetClientID() string {
+	return r.auth.ClientID
+}
+
+func (r *backChannelAuthTokenRequest) GetScopes() []string {
+	return r.scopes
+}
+
+func (r *backChannelAuthTokenRequest) GetSubject() string {
+	return r.auth.UserID
+}
+
+// SetCurrentScopes sets the scopes including the asserted project roles
+func (r *backChannelAuthTokenRequest) SetCurrentScopes(scopes []string) {
+	r.scopes = scopes
+}
+
+type backChannelAuthQueries interface {
+	AppByOIDCClientID(ctx context.Context, clientID string) (*query.App, error)
+}
+
+// StartBackChannelAuthPing starts the ping mode of the backchannel authentication, which calls
+// the client notification endpoint of the application as soon as the user approved or denied the request.
+// It also starts the cleanup of the stored polls.
+func (s *Server) StartBackChannelAuthPing(ctx context.Context, handlerCustomConfig projection.CustomConfig) {
+	if !s.backChannelAuthEnabled() {
+		return
+	}
+	s.backChannelAuthPolls.startCleanup(ctx, s.backChannelAuth.lifetime())
+	handlerConfig := projection.ApplyCustomConfig(handlerCustomConfig)
+	handler.NewHandler(ctx, &handlerConfig, &backChannelAuthPingNotifier{
+		queries:         s.query,
+		es:              s.eventstore,
+		client:          &http.Client{Timeout: s.backChannelAuth.Timeout},
+		maxFailureCount: handlerConfig.MaxFailureCount,
+	}).Start(ctx)
+	logging.Info("oidc backchannel authentication ping started")
+}
+
+type backChannelAuthPingNotifier struct {
+	queries         backChannelAuthQueries
+	es              eventFilter
+	client          *http.Client
+	maxFailureCount uint8
+}
+
+func (n *backChannelAuthPingNotifier) Name() string {
+	return BackChannelAuthProjectionName
+}
+
+func (n *backChannelAuthPingNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: backchannelauth.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  backchannelauth.ApprovedEventType,
+					Reduce: n.reduceDone,
+				},
+				{
+					Event:  backchannelauth.CanceledEventType,
+					Reduce: n.reduceDone,
+				},
+			},
+		},
+	}
+}
+
+// reduceDone pings the client notification endpoint of the application, if the client uses the ping mode.
+// The ping is sent when the statement is executed. If it still fails on the last attempt,
+// it's only logged, as the client can still poll the token endpoint.
+func (n *backChannelAuthPingNotifier) reduceDone(event eventstore.Event) (*handler.Statement, error) {
+	switch event.(type) {
+	case *backchannelauth.ApprovedEvent, *backchannelauth.CanceledEvent:
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "OIDC-ieN4o", "reduce.wrong.event.type %v", []eventstore.EventType{backchannelauth.ApprovedEventType, backchannelauth.CanceledEventType})
+	}
+	ctx := eventContext(event)
+	added, err := n.addedEvent(ctx, event.Aggregate().ID)
+	if err != nil || added == nil || added.ClientNotificationToken == "" {
+		return handler.NewNoOpStatement(event), err
+	}
+	app, err := n.queries.AppByOIDCClientID(ctx, added.ClientID)
+	if errors.IsNotFound(err) {
+		return handler.NewNoOpStatement(event), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if app.OIDCConfig == nil || app.OIDCConfig.BackChannelClientNotificationURI == "" {
+		return handler.NewNoOpStatement(event), nil
+	}
+	uri := app.OIDCConfig.BackChannelClientNotificationURI
+	return delivery.NewStatement(ctx, event, n.maxFailureCount, "", &delivery.Target{
+		Fields: []interface{}{"client", added.ClientID, "id", event.Aggregate().ID},
+		Send: func(ctx context.Context) error {
+			return n.send(ctx, uri, added.ClientNotificationToken, added.AuthReqID)
+		},
+	}), nil
+}
+
+// addedEvent returns the event which started the backchannel authentication request
+func (n *backChannelAuthPingNotifier) addedEvent(ctx context.Context, id string) (*backchannelauth.AddedEvent, error) {
+	events, err := n.es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		AddQuery().
+		AggregateTypes(backchannelauth.AggregateType).
+		AggregateIDs(id).
+		EventTypes(backchannelauth.AddedEventType).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if added, ok := event.(*backchannelauth.AddedEvent); ok {
+			return added, nil
+		}
+	}
+	return nil, nil
+}
+
+// send posts the auth_req_id to the client notification endpoint, authenticated by the client notification token
+// https://openid.net/specs/openid-client-initiated-backchannel-authentication-core-1_0.html#rfc.section.10.2
+func (n *backChannelAuthPingNotifier) send(ctx context.Context, uri, token, authReqID string) error {
+	body, err := json.Marshal(map[string]string{authReqIDParam: authReqID})
+	if err != nil {
+		return err
+	}
+	header := http.Header{
+		"Content-Type":  {"application/json"},
+		"Authorization": {oidc.PrefixBearer + token},
+	}
+	_, err = delivery.Post(ctx, n.client, uri, header, body)
+	return err
+}
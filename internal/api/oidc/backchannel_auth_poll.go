@@ -0,0 +1,81 @@
+package oidc
+
+import (
+	"context"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+const (
+	backChannelAuthPollTable       = "auth.backchannel_auth_polls"
+	backChannelAuthPollInstanceCol = "instance_id"
+	backChannelAuthPollClientCol   = "client_id"
+	backChannelAuthPollAuthReqCol  = "auth_req_id"
+	backChannelAuthPollNextCol     = "next_poll"
+)
+
+// backChannelAuthPolls stores the earliest time a client is allowed to poll for a request again.
+// It's stored in the database, so the poll interval is enforced on all ZITADEL processes.
+type backChannelAuthPolls struct {
+	dbClient *database.DB
+}
+
+// poll returns false if the client polls the request before the interval passed since its last poll
+func (p *backChannelAuthPolls) poll(ctx context.Context, clientID, authReqID string, interval time.Duration, now time.Time) (bool, error) {
+	stmt, args, err := sq.Insert(backChannelAuthPollTable).
+		Columns(backChannelAuthPollInstanceCol, backChannelAuthPollClientCol, backChannelAuthPollAuthReqCol, backChannelAuthPollNextCol).
+		Values(authz.GetInstance(ctx).InstanceID(), clientID, authReqID, now.Add(interval)).
+		Suffix("ON CONFLICT ("+backChannelAuthPollInstanceCol+", "+backChannelAuthPollClientCol+", "+backChannelAuthPollAuthReqCol+") "+
+			"DO UPDATE SET "+backChannelAuthPollNextCol+" = EXCLUDED."+backChannelAuthPollNextCol+
+			" WHERE "+backChannelAuthPollTable+"."+backChannelAuthPollNextCol+" <= ?", now).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return false, err
+	}
+	result, err := p.dbClient.ExecContext(ctx, stmt, args...)
+	if err != nil {
+		return false, err
+	}
+	// no row is affected if the client polled within the interval
+	affected, err := result.RowsAffected()
+	if err != nil {
+		return false, err
+	}
+	return affected > 0, nil
+}
+
+// startCleanup periodically removes the polls of all instances, whose request passed the lifetime
+func (p *backChannelAuthPolls) startCleanup(ctx context.Context, lifetime time.Duration) {
+	go p.scheduleCleanup(ctx, lifetime)
+}
+
+func (p *backChannelAuthPolls) scheduleCleanup(ctx context.Context, lifetime time.Duration) {
+	ticker := time.NewTicker(lifetime)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			logging.OnError(p.cleanup(ctx, now.Add(-lifetime))).Warn("unable to remove backchannel authentication polls")
+		}
+	}
+}
+
+func (p *backChannelAuthPolls) cleanup(ctx context.Context, before time.Time) error {
+	stmt, args, err := sq.Delete(backChannelAuthPollTable).
+		Where(sq.Lt{backChannelAuthPollNextCol: before}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = p.dbClient.ExecContext(ctx, stmt, args...)
+	return err
+}
@@ -0,0 +1,52 @@
+package oidc
+
+import (
+	"context"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+)
+
+func Test_backChannelAuthPolls_poll(t *testing.T) {
+	expectedStmt := regexp.QuoteMeta("INSERT INTO auth.backchannel_auth_polls (instance_id,client_id,auth_req_id,next_poll) VALUES ($1,$2,$3,$4) ON CONFLICT (instance_id, client_id, auth_req_id) DO UPDATE SET next_poll = EXCLUDED.next_poll WHERE auth.backchannel_auth_polls.next_poll <= $5")
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name     string
+		affected int64
+		want     bool
+	}{
+		{
+			name:     "first poll",
+			affected: 1,
+			want:     true,
+		},
+		{
+			name:     "poll within interval",
+			affected: 0,
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock, err := sqlmock.New()
+			require.NoError(t, err)
+			defer db.Close()
+			mock.ExpectExec(expectedStmt).
+				WithArgs("instance1", "clientID", "authReqID", now.Add(5*time.Second), now).
+				WillReturnResult(sqlmock.NewResult(0, tt.affected))
+
+			polls := &backChannelAuthPolls{dbClient: &database.DB{DB: db}}
+			got, err := polls.poll(authz.WithInstanceID(context.Background(), "instance1"), "clientID", "authReqID", 5*time.Second, now)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+			assert.NoError(t, mock.ExpectationsWereMet())
+		})
+	}
+}
@@ -0,0 +1,114 @@
+package oidc
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_validateBackChannelAuthRequest(t *testing.T) {
+	pollClient := &Client{app: &query.App{OIDCConfig: &query.OIDCApp{}}}
+	pingClient := &Client{app: &query.App{OIDCConfig: &query.OIDCApp{BackChannelClientNotificationURI: "https://app.example.com/ciba"}}}
+	tests := []struct {
+		name    string
+		client  *Client
+		request *backChannelAuthenticationRequest
+		wantErr bool
+	}{
+		{
+			name:   "login hint",
+			client: pollClient,
+			request: &backChannelAuthenticationRequest{
+				Scopes:         oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHint:      "user@example.com",
+				BindingMessage: "W4SCT",
+			},
+		},
+		{
+			name:   "id token hint",
+			client: pollClient,
+			request: &backChannelAuthenticationRequest{
+				Scopes:      oidc.SpaceDelimitedArray{oidc.ScopeOpenID, oidc.ScopeProfile},
+				IDTokenHint: "idToken",
+			},
+		},
+		{
+			name:   "missing openid scope, error",
+			client: pollClient,
+			request: &backChannelAuthenticationRequest{
+				Scopes:    oidc.SpaceDelimitedArray{oidc.ScopeProfile},
+				LoginHint: "user@example.com",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "missing hint, error",
+			client: pollClient,
+			request: &backChannelAuthenticationRequest{
+				Scopes: oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+			},
+			wantErr: true,
+		},
+		{
+			name:   "multiple hints, error",
+			client: pollClient,
+			request: &backChannelAuthenticationRequest{
+				Scopes:      oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHint:   "user@example.com",
+				IDTokenHint: "idToken",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "login hint token, error",
+			client: pollClient,
+			request: &backChannelAuthenticationRequest{
+				Scopes:         oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHintToken: "token",
+			},
+			wantErr: true,
+		},
+		{
+			name:   "binding message too long, error",
+			client: pollClient,
+			request: &backChannelAuthenticationRequest{
+				Scopes:         oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHint:      "user@example.com",
+				BindingMessage: strings.Repeat("a", backChannelBindingMessageMaxLen+1),
+			},
+			wantErr: true,
+		},
+		{
+			name:   "ping mode",
+			client: pingClient,
+			request: &backChannelAuthenticationRequest{
+				Scopes:                  oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHint:               "user@example.com",
+				ClientNotificationToken: "token",
+			},
+		},
+		{
+			name:   "ping mode without client notification token, error",
+			client: pingClient,
+			request: &backChannelAuthenticationRequest{
+				Scopes:    oidc.SpaceDelimitedArray{oidc.ScopeOpenID},
+				LoginHint: "user@example.com",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := validateBackChannelAuthRequest(tt.client, tt.request)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,228 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+	"time"
+
+	"github.com/go-jose/go-jose/v3"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/crypto"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/delivery"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/session"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	// BackChannelLogoutProjectionName is used to store the position of the back-channel logout in the current states
+	// and the events which could not be reduced in the failed events.
+	BackChannelLogoutProjectionName = "projections.oidc_backchannel_logout"
+
+	backChannelLogoutEvent = "http://schemas.openid.net/event/backchannel-logout"
+	logoutTokenParam       = "logout_token"
+	logoutTokenLifetime    = 2 * time.Minute
+)
+
+// BackChannelLogoutConfig of the back-channel logout.
+// Failed deliveries are retried by the handler, see RetryFailedAfter and MaxFailureCount
+// of the projection customization.
+type BackChannelLogoutConfig struct {
+	Enabled bool
+	// Timeout of a single request to the application
+	Timeout time.Duration
+}
+
+// logoutToken are the claims of the logout token as defined in
+// https://openid.net/specs/openid-connect-backchannel-1_0.html#LogoutToken
+type logoutToken struct {
+	Issuer     string         `json:"iss"`
+	Subject    string         `json:"sub,omitempty"`
+	Audience   oidc.Audience  `json:"aud"`
+	IssuedAt   oidc.Time      `json:"iat"`
+	Expiration oidc.Time      `json:"exp"`
+	JWTID      string         `json:"jti"`
+	SessionID  string         `json:"sid,omitempty"`
+	Events     map[string]any `json:"events"`
+}
+
+func newLogoutToken(logout *logoutSession, tokenID string, now time.Time) *logoutToken {
+	return &logoutToken{
+		Issuer:     logout.issuer,
+		Subject:    logout.userID,
+		Audience:   oidc.Audience{logout.clientID},
+		IssuedAt:   oidc.FromTime(now),
+		Expiration: oidc.FromTime(now.Add(logoutTokenLifetime)),
+		JWTID:      tokenID,
+		SessionID:  logout.sessionID,
+		Events: map[string]any{
+			backChannelLogoutEvent: struct{}{},
+		},
+	}
+}
+
+type backChannelLogoutQueries interface {
+	AppByOIDCClientID(ctx context.Context, clientID string) (*query.App, error)
+}
+
+// StartBackChannelLogout starts the back-channel logout, which sends a logout token
+// to the back-channel logout URI of every application the user received tokens for,
+// as soon as the session (V2) or the user agent session (V1) of the user is terminated.
+func (s *Server) StartBackChannelLogout(ctx context.Context, handlerCustomConfig projection.CustomConfig) {
+	if s.backChannelLogout == nil || !s.backChannelLogout.Enabled {
+		return
+	}
+	handlerConfig := projection.ApplyCustomConfig(handlerCustomConfig)
+	handler.NewHandler(ctx, &handlerConfig, &backChannelLogoutNotifier{
+		queries:         s.query,
+		es:              s.eventstore,
+		signingKey:      s.Provider().Storage().SigningKey,
+		client:          &http.Client{Timeout: s.backChannelLogout.Timeout},
+		maxFailureCount: handlerConfig.MaxFailureCount,
+	}).Start(ctx)
+	logging.Info("oidc back-channel logout started")
+}
+
+type backChannelLogoutNotifier struct {
+	queries         backChannelLogoutQueries
+	es              eventFilter
+	signingKey      func(ctx context.Context) (op.SigningKey, error)
+	client          *http.Client
+	maxFailureCount uint8
+}
+
+func (n *backChannelLogoutNotifier) Name() string {
+	return BackChannelLogoutProjectionName
+}
+
+func (n *backChannelLogoutNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: session.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  session.TerminateType,
+					Reduce: n.reduceSessionTerminated,
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: n.reduceHumanSignedOut,
+				},
+			},
+		},
+	}
+}
+
+func (n *backChannelLogoutNotifier) reduceSessionTerminated(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*session.TerminateEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "OIDC-Oa5ie", "reduce.wrong.event.type %s", session.TerminateType)
+	}
+	ctx := eventContext(e)
+	logouts, err := sessionLogouts(ctx, n.es, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	return n.logout(ctx, e, logouts)
+}
+
+func (n *backChannelLogoutNotifier) reduceHumanSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "OIDC-ahj5N", "reduce.wrong.event.type %s", user.HumanSignedOutType)
+	}
+	ctx := eventContext(e)
+	logouts, err := userAgentLogouts(ctx, n.es, e.UserAgentID, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	return n.logout(ctx, e, logouts)
+}
+
+// logout sends the logout token to every application with a back-channel logout URI,
+// when the statement is executed.
+// Sessions without a stored issuer (tokens issued before it was stored) are skipped,
+// as the logout token would not be accepted by the application.
+func (n *backChannelLogoutNotifier) logout(ctx context.Context, event eventstore.Event, logouts []*logoutSession) (*handler.Statement, error) {
+	signers := make(map[bool]jose.Signer, 2)
+	targets := make([]*delivery.Target, 0, len(logouts))
+	for _, logout := range logouts {
+		app, err := n.queries.AppByOIDCClientID(ctx, logout.clientID)
+		if errors.IsNotFound(err) {
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if app.OIDCConfig == nil || app.OIDCConfig.BackChannelLogoutURI == "" {
+			continue
+		}
+		if logout.issuer == "" {
+			logging.WithFields("client", logout.clientID, "user", logout.userID).Info("back-channel logout skipped, issuer of the tokens unknown")
+			continue
+		}
+		forceRSA := app.OIDCConfig.ForceRSASignedTokens
+		signer, ok := signers[forceRSA]
+		if !ok {
+			if signer, err = n.signer(withClientForceRSA(ctx, forceRSA)); err != nil {
+				return nil, err
+			}
+			signers[forceRSA] = signer
+		}
+		logout, uri := logout, app.OIDCConfig.BackChannelLogoutURI
+		targets = append(targets, &delivery.Target{
+			Fields: []interface{}{"client", logout.clientID, "user", logout.userID},
+			Send: func(ctx context.Context) error {
+				// the token is created on every attempt, as it is only valid for a short time
+				token, err := n.logoutToken(signer, logout)
+				if err != nil {
+					return err
+				}
+				return n.send(ctx, uri, token)
+			},
+		})
+	}
+	return delivery.NewStatement(ctx, event, n.maxFailureCount, "", targets...), nil
+}
+
+func (n *backChannelLogoutNotifier) signer(ctx context.Context) (jose.Signer, error) {
+	signingKey, err := n.signingKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return op.SignerFromKey(signingKey)
+}
+
+func (n *backChannelLogoutNotifier) logoutToken(signer jose.Signer, logout *logoutSession) (string, error) {
+	tokenID, err := id.SonyFlakeGenerator().Next()
+	if err != nil {
+		return "", err
+	}
+	return crypto.Sign(newLogoutToken(logout, tokenID, time.Now()), signer)
+}
+
+func (n *backChannelLogoutNotifier) send(ctx context.Context, uri, token string) error {
+	header := http.Header{"Content-Type": {"application/x-www-form-urlencoded"}}
+	_, err := delivery.Post(ctx, n.client, uri, header, []byte(url.Values{logoutTokenParam: {token}}.Encode()))
+	return err
+}
+
+func eventContext(event eventstore.Event) context.Context {
+	return authz.WithInstanceID(call.WithTimestamp(context.Background()), event.Aggregate().InstanceID)
+}
@@ -50,6 +50,9 @@ func (o *OPStorage) GetClientByClientID(ctx context.Context, id string) (_ op.Cl
 	if client.State != domain.AppStateActive {
 		return nil, errors.ThrowPreconditionFailed(nil, "OIDC-sdaGg", "client is not active")
 	}
+	if client.OIDCConfig != nil {
+		setClientForceRSA(ctx, client.OIDCConfig.ForceRSASignedTokens)
+	}
 	projectIDQuery, err := query.NewProjectRoleProjectIDSearchQuery(client.ProjectID)
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "OIDC-mPxqP", "Errors.Internal")
@@ -130,6 +133,9 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 		if err = o.isOriginAllowed(ctx, token.ClientID, origin); err != nil {
 			return err
 		}
+		if err = o.checkUserinfoDPoPBinding(ctx, token.DPoPJKT); err != nil {
+			return err
+		}
 		return o.setUserinfo(ctx, userInfo, token.UserID, token.ClientID, token.Scope, nil)
 	}
 
@@ -142,6 +148,9 @@ func (o *OPStorage) SetUserinfoFromToken(ctx context.Context, userInfo *oidc.Use
 			return err
 		}
 	}
+	if err = o.checkUserinfoDPoPBinding(ctx, token.DPoPJKT); err != nil {
+		return err
+	}
 	return o.setUserinfo(ctx, userInfo, token.UserID, token.ApplicationID, token.Scopes, nil)
 }
 
@@ -164,9 +173,15 @@ func (o *OPStorage) SetUserinfoFromScopes(ctx context.Context, userInfo *oidc.Us
 }
 
 // SetUserinfoFromRequest extends the SetUserinfoFromScopes during the id_token generation.
-// This is required for V2 tokens to be able to set the sessionID (`sid`) claim.
+// This is required to be able to set the sessionID (`sid`) claim,
+// which is the session for V2 tokens and the user agent for V1 tokens.
+// The `sid` is used to identify the session in the back- and front-channel logout.
 func (o *OPStorage) SetUserinfoFromRequest(ctx context.Context, userinfo *oidc.UserInfo, request op.IDTokenRequest, _ []string) error {
 	switch t := request.(type) {
+	case *AuthRequest:
+		userinfo.AppendClaims("sid", t.AgentID)
+	case *RefreshTokenRequest:
+		userinfo.AppendClaims("sid", t.UserAgentID)
 	case *AuthRequestV2:
 		userinfo.AppendClaims("sid", t.SessionID)
 	case *RefreshTokenRequestV2:
@@ -564,7 +579,7 @@ func (o *OPStorage) userinfoFlows(ctx context.Context, user *query.User, userGra
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(actions.ActionToOptions(action), actions.WithTrigger(domain.FlowTypeCustomiseToken, domain.TriggerTypePreUserinfoCreation), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
 		)
 		cancel()
 		if err != nil {
@@ -641,7 +656,15 @@ func (o *OPStorage) GetPrivateClaimsFromScopes(ctx context.Context, userID, clie
 		}
 	}
 
-	return o.privateClaimsFlows(ctx, userID, userGrants, claims)
+	claims, err = o.privateClaimsFlows(ctx, userID, userGrants, claims)
+	if err != nil {
+		return nil, err
+	}
+	// the confirmation of a DPoP bound token can't be changed by actions
+	if jkt := dpopJKTFromContext(ctx); jkt != "" {
+		claims = appendClaim(claims, ClaimConfirmation, dpopConfirmation(jkt))
+	}
+	return claims, nil
 }
 
 func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userGrants *query.UserGrants, claims map[string]interface{}) (map[string]interface{}, error) {
@@ -745,7 +768,7 @@ func (o *OPStorage) privateClaimsFlows(ctx context.Context, userID string, userG
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
+			append(actions.ActionToOptions(action), actions.WithTrigger(domain.FlowTypeCustomiseToken, domain.TriggerTypePreAccessTokenCreation), actions.WithHTTP(actionCtx), actions.WithUUID(actionCtx))...,
 		)
 		cancel()
 		if err != nil {
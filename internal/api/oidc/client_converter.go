@@ -208,6 +208,10 @@ func grantTypeToOIDC(grantType domain.OIDCGrantType) oidc.GrantType {
 		return oidc.GrantTypeRefreshToken
 	case domain.OIDCGrantTypeDeviceCode:
 		return oidc.GrantTypeDeviceCode
+	case domain.OIDCGrantTypeTokenExchange:
+		return oidc.GrantTypeTokenExchange
+	case domain.OIDCGrantTypeCIBA:
+		return grantTypeCIBA
 	default:
 		return oidc.GrantTypeCode
 	}
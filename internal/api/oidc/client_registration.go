@@ -0,0 +1,449 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	errs "errors"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+
+	"github.com/go-chi/chi/v5"
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/dpop"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	clientRegistrationEndpoint       = "/oauth/v2/register"
+	clientConfigurationEndpoint      = clientRegistrationEndpoint + "/{" + clientConfigurationClientIDParam + "}"
+	clientConfigurationClientIDParam = "client_id"
+
+	// defaultRegisteredClientName is used if the client does not send a client_name,
+	// as every application requires a name
+	defaultRegisteredClientName = "Dynamically registered client"
+
+	applicationTypeWeb    = "web"
+	applicationTypeNative = "native"
+
+	errorTypeInvalidClientMetadata = "invalid_client_metadata"
+	errorTypeInvalidToken          = "invalid_token"
+)
+
+// clientMetadata are the client metadata of the dynamic client registration
+// https://www.rfc-editor.org/rfc/rfc7591.html#section-2
+// https://openid.net/specs/openid-connect-registration-1_0.html#ClientMetadata
+type clientMetadata struct {
+	RedirectURIs                       []string            `json:"redirect_uris,omitempty"`
+	TokenEndpointAuthMethod            oidc.AuthMethod     `json:"token_endpoint_auth_method,omitempty"`
+	GrantTypes                         []oidc.GrantType    `json:"grant_types,omitempty"`
+	ResponseTypes                      []oidc.ResponseType `json:"response_types,omitempty"`
+	ClientName                         string              `json:"client_name,omitempty"`
+	ApplicationType                    string              `json:"application_type,omitempty"`
+	PostLogoutRedirectURIs             []string            `json:"post_logout_redirect_uris,omitempty"`
+	BackChannelLogoutURI               string              `json:"backchannel_logout_uri,omitempty"`
+	BackChannelLogoutSessionRequired   bool                `json:"backchannel_logout_session_required,omitempty"`
+	FrontChannelLogoutURI              string              `json:"frontchannel_logout_uri,omitempty"`
+	FrontChannelLogoutSessionRequired  bool                `json:"frontchannel_logout_session_required,omitempty"`
+	RequirePushedAuthorizationRequests bool                `json:"require_pushed_authorization_requests,omitempty"`
+	RequireSignedRequestObject         bool                `json:"require_signed_request_object,omitempty"`
+	DPoPBoundAccessTokens              bool                `json:"dpop_bound_access_tokens,omitempty"`
+	BackChannelTokenDeliveryMode       string              `json:"backchannel_token_delivery_mode,omitempty"`
+	BackChannelClientNotificationURI   string              `json:"backchannel_client_notification_endpoint,omitempty"`
+}
+
+// clientInformation is the response of the client registration and configuration endpoints
+// https://www.rfc-editor.org/rfc/rfc7591.html#section-3.2.1
+// https://www.rfc-editor.org/rfc/rfc7592.html#section-3
+type clientInformation struct {
+	ClientID                string  `json:"client_id"`
+	ClientSecret            string  `json:"client_secret,omitempty"`
+	ClientSecretExpiresAt   *uint64 `json:"client_secret_expires_at,omitempty"`
+	RegistrationAccessToken string  `json:"registration_access_token,omitempty"`
+	RegistrationClientURI   string  `json:"registration_client_uri,omitempty"`
+	*clientMetadata
+}
+
+// registerClientHandler implements the client registration endpoint (RFC 7591).
+// The client must authenticate with an initial access token issued for the project the app is registered in.
+func (s *Server) registerClientHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	resp, err := s.registerClient(ctx, r)
+	if err != nil {
+		s.clientRegistrationError(w, r, err)
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, resp, http.StatusCreated)
+}
+
+func (s *Server) registerClient(ctx context.Context, r *http.Request) (_ *clientInformation, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	initialAccessToken, err := clientRegistrationToken(r)
+	if err != nil {
+		return nil, err
+	}
+	metadata, err := decodeClientMetadata(r)
+	if err != nil {
+		return nil, err
+	}
+	app, err := metadata.toOIDCApp()
+	if err != nil {
+		return nil, err
+	}
+	appSecretGenerator, err := s.query.InitHashGenerator(ctx, domain.SecretGeneratorTypeAppSecret, s.hashAlg)
+	if err != nil {
+		return nil, err
+	}
+	registered, registrationAccessToken, err := s.command.RegisterOIDCClient(ctx, initialAccessToken, app, appSecretGenerator)
+	if err != nil {
+		return nil, err
+	}
+	return newClientInformation(ctx, registered, registrationAccessToken), nil
+}
+
+// clientConfigurationHandler implements the client configuration endpoint (RFC 7592).
+// The client authenticates with the registration access token returned on its registration
+// and is able to read, replace and remove its configuration.
+func (s *Server) clientConfigurationHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	var (
+		resp *clientInformation
+		err  error
+	)
+	switch r.Method {
+	case http.MethodGet:
+		resp, err = s.readClientConfiguration(ctx, r)
+	case http.MethodPut:
+		resp, err = s.changeClientConfiguration(ctx, r)
+	case http.MethodDelete:
+		err = s.removeClientConfiguration(ctx, r)
+	}
+	if err != nil {
+		s.clientRegistrationError(w, r, err)
+		return
+	}
+	if resp == nil {
+		w.WriteHeader(http.StatusNoContent)
+		return
+	}
+	httphelper.MarshalJSON(w, resp)
+}
+
+func (s *Server) readClientConfiguration(ctx context.Context, r *http.Request) (_ *clientInformation, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	registrationAccessToken, err := clientRegistrationToken(r)
+	if err != nil {
+		return nil, err
+	}
+	_, appID, err := s.command.CheckClientRegistrationAccessToken(ctx, registrationAccessToken, chi.URLParam(r, clientConfigurationClientIDParam))
+	if err != nil {
+		return nil, err
+	}
+	app, err := s.query.AppByID(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	return newClientInformation(ctx, oidcAppFromQuery(app), registrationAccessToken), nil
+}
+
+func (s *Server) changeClientConfiguration(ctx context.Context, r *http.Request) (_ *clientInformation, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	registrationAccessToken, err := clientRegistrationToken(r)
+	if err != nil {
+		return nil, err
+	}
+	request := new(struct {
+		ClientID string `json:"client_id"`
+		clientMetadata
+	})
+	if err = json.NewDecoder(r.Body).Decode(request); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding client metadata").WithParent(err)
+	}
+	clientID := chi.URLParam(r, clientConfigurationClientIDParam)
+	if request.ClientID != clientID {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id does not match the client configuration endpoint")
+	}
+	app, err := request.toOIDCApp()
+	if err != nil {
+		return nil, err
+	}
+	app.ClientID = clientID
+	changed, err := s.command.ChangeRegisteredOIDCClient(ctx, registrationAccessToken, app)
+	if err != nil {
+		return nil, err
+	}
+	return newClientInformation(ctx, changed, registrationAccessToken), nil
+}
+
+func (s *Server) removeClientConfiguration(ctx context.Context, r *http.Request) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	registrationAccessToken, err := clientRegistrationToken(r)
+	if err != nil {
+		return err
+	}
+	_, err = s.command.RemoveRegisteredOIDCClient(ctx, registrationAccessToken, chi.URLParam(r, clientConfigurationClientIDParam))
+	return err
+}
+
+// clientRegistrationToken returns the initial or registration access token sent as bearer token
+func clientRegistrationToken(r *http.Request) (string, error) {
+	scheme, token, ok := dpop.SplitAuthorization(r.Header.Get("Authorization"))
+	if !ok || !strings.EqualFold(scheme, oidc.BearerToken) || token == "" {
+		return "", &oidc.Error{ErrorType: errorTypeInvalidToken, Description: "bearer token is missing"}
+	}
+	return token, nil
+}
+
+func decodeClientMetadata(r *http.Request) (*clientMetadata, error) {
+	metadata := new(clientMetadata)
+	if err := json.NewDecoder(r.Body).Decode(metadata); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding client metadata").WithParent(err)
+	}
+	return metadata, nil
+}
+
+// clientRegistrationError writes the error response of the client registration (RFC 7591)
+// and configuration endpoints (RFC 7592)
+func (s *Server) clientRegistrationError(w http.ResponseWriter, r *http.Request, err error) {
+	oauthErr := &oidc.Error{}
+	status := http.StatusBadRequest
+	switch {
+	case errors.IsUnauthenticated(err), errors.IsNotFound(err):
+		oauthErr.ErrorType = errorTypeInvalidToken
+		status = http.StatusUnauthorized
+	case errors.IsErrorInvalidArgument(err):
+		oauthErr.ErrorType = errorTypeInvalidClientMetadata
+	case errors.IsPreconditionFailed(err), errors.IsPermissionDenied(err):
+		oauthErr.ErrorType = oidc.AccessDenied
+		status = http.StatusForbidden
+	default:
+		oauthErr = oidc.DefaultToServerError(err, err.Error())
+		if oauthErr.ErrorType == errorTypeInvalidToken {
+			status = http.StatusUnauthorized
+		}
+		if oauthErr.ErrorType == oidc.ServerError {
+			status = http.StatusInternalServerError
+		}
+	}
+	if oauthErr.Description == "" {
+		var zitadelErr errors.Error
+		if errs.As(err, &zitadelErr) {
+			oauthErr.Description = zitadelErr.GetMessage()
+		}
+	}
+	oauthErr.Parent = err
+	s.getLogger(r.Context()).Log(r.Context(), oauthErr.LogLevel(), "client registration error", "oidc_error", oauthErr)
+	httphelper.MarshalJSONWithStatus(w, oauthErr, status)
+}
+
+func (m *clientMetadata) toOIDCApp() (*domain.OIDCApp, error) {
+	app := &domain.OIDCApp{
+		AppName:                  m.ClientName,
+		OIDCVersion:              domain.OIDCVersionV1,
+		RedirectUris:             m.RedirectURIs,
+		PostLogoutRedirectUris:   m.PostLogoutRedirectURIs,
+		AccessTokenType:          domain.OIDCTokenTypeBearer,
+		BackChannelLogoutURI:     m.BackChannelLogoutURI,
+		FrontChannelLogoutURI:    m.FrontChannelLogoutURI,
+		RequirePushedAuthRequest: m.RequirePushedAuthorizationRequests,
+		RequireSignedRequest:     m.RequireSignedRequestObject,
+		DPoPBoundAccessTokens:    m.DPoPBoundAccessTokens,
+	}
+	if app.AppName == "" {
+		app.AppName = defaultRegisteredClientName
+	}
+	var ok bool
+	if app.AuthMethodType, ok = authMethodFromOIDC(m.TokenEndpointAuthMethod); !ok {
+		return nil, invalidClientMetadata("token_endpoint_auth_method is not supported")
+	}
+	if app.ApplicationType, ok = applicationTypeFromOIDC(m.ApplicationType); !ok {
+		return nil, invalidClientMetadata("application_type is not supported")
+	}
+	if app.GrantTypes, ok = grantTypesFromOIDC(m.GrantTypes); !ok {
+		return nil, invalidClientMetadata("grant_types are not supported")
+	}
+	if app.ResponseTypes, ok = responseTypesFromOIDC(m.ResponseTypes); !ok {
+		return nil, invalidClientMetadata("response_types are not supported")
+	}
+	switch m.BackChannelTokenDeliveryMode {
+	case "", backChannelTokenDeliveryModePoll:
+	case backChannelTokenDeliveryModePing:
+		if m.BackChannelClientNotificationURI == "" {
+			return nil, invalidClientMetadata("backchannel_client_notification_endpoint is required for the ping mode")
+		}
+		app.BackChannelClientNotificationURI = m.BackChannelClientNotificationURI
+	default:
+		return nil, invalidClientMetadata("backchannel_token_delivery_mode is not supported")
+	}
+	return app, nil
+}
+
+func invalidClientMetadata(description string) error {
+	return &oidc.Error{ErrorType: errorTypeInvalidClientMetadata, Description: description}
+}
+
+func newClientInformation(ctx context.Context, app *domain.OIDCApp, registrationAccessToken string) *clientInformation {
+	info := &clientInformation{
+		ClientID:                app.ClientID,
+		ClientSecret:            app.ClientSecretString,
+		RegistrationAccessToken: registrationAccessToken,
+		RegistrationClientURI:   op.NewEndpoint(clientRegistrationEndpoint + "/" + url.PathEscape(app.ClientID)).Absolute(op.IssuerFromContext(ctx)),
+		clientMetadata: &clientMetadata{
+			RedirectURIs:                       app.RedirectUris,
+			TokenEndpointAuthMethod:            authMethodToOIDC(app.AuthMethodType),
+			GrantTypes:                         grantTypesToOIDC(app.GrantTypes),
+			ResponseTypes:                      responseTypesToOIDC(app.ResponseTypes),
+			ClientName:                         app.AppName,
+			ApplicationType:                    applicationTypeToOIDC(app.ApplicationType),
+			PostLogoutRedirectURIs:             app.PostLogoutRedirectUris,
+			BackChannelLogoutURI:               app.BackChannelLogoutURI,
+			BackChannelLogoutSessionRequired:   app.BackChannelLogoutURI != "",
+			FrontChannelLogoutURI:              app.FrontChannelLogoutURI,
+			FrontChannelLogoutSessionRequired:  app.FrontChannelLogoutURI != "",
+			RequirePushedAuthorizationRequests: app.RequirePushedAuthRequest,
+			RequireSignedRequestObject:         app.RequireSignedRequest,
+			DPoPBoundAccessTokens:              app.DPoPBoundAccessTokens,
+			BackChannelTokenDeliveryMode:       backChannelTokenDeliveryMode(app),
+			BackChannelClientNotificationURI:   app.BackChannelClientNotificationURI,
+		},
+	}
+	if info.ClientSecret != "" {
+		// the secret does not expire
+		info.ClientSecretExpiresAt = new(uint64)
+	}
+	return info
+}
+
+func oidcAppFromQuery(app *query.App) *domain.OIDCApp {
+	oidcApp := &domain.OIDCApp{
+		AppID:   app.ID,
+		AppName: app.Name,
+		State:   app.State,
+	}
+	if app.OIDCConfig == nil {
+		return oidcApp
+	}
+	oidcApp.ClientID = app.OIDCConfig.ClientID
+	oidcApp.RedirectUris = app.OIDCConfig.RedirectURIs
+	oidcApp.ResponseTypes = app.OIDCConfig.ResponseTypes
+	oidcApp.GrantTypes = app.OIDCConfig.GrantTypes
+	oidcApp.ApplicationType = app.OIDCConfig.AppType
+	oidcApp.AuthMethodType = app.OIDCConfig.AuthMethodType
+	oidcApp.PostLogoutRedirectUris = app.OIDCConfig.PostLogoutRedirectURIs
+	oidcApp.BackChannelLogoutURI = app.OIDCConfig.BackChannelLogoutURI
+	oidcApp.FrontChannelLogoutURI = app.OIDCConfig.FrontChannelLogoutURI
+	oidcApp.RequirePushedAuthRequest = app.OIDCConfig.RequirePushedAuthRequest
+	oidcApp.RequireSignedRequest = app.OIDCConfig.RequireSignedRequest
+	oidcApp.DPoPBoundAccessTokens = app.OIDCConfig.DPoPBoundAccessTokens
+	oidcApp.BackChannelClientNotificationURI = app.OIDCConfig.BackChannelClientNotificationURI
+	return oidcApp
+}
+
+// backChannelTokenDeliveryMode returns the ping mode for clients with a client notification endpoint
+// and the poll mode for all other clients allowed to use the backchannel authentication
+func backChannelTokenDeliveryMode(app *domain.OIDCApp) string {
+	if !slices.Contains(app.GrantTypes, domain.OIDCGrantTypeCIBA) {
+		return ""
+	}
+	if app.BackChannelClientNotificationURI != "" {
+		return backChannelTokenDeliveryModePing
+	}
+	return backChannelTokenDeliveryModePoll
+}
+
+func authMethodFromOIDC(authMethod oidc.AuthMethod) (domain.OIDCAuthMethodType, bool) {
+	switch authMethod {
+	// client_secret_basic is the default of RFC 7591
+	case "", oidc.AuthMethodBasic:
+		return domain.OIDCAuthMethodTypeBasic, true
+	case oidc.AuthMethodPost:
+		return domain.OIDCAuthMethodTypePost, true
+	case oidc.AuthMethodNone:
+		return domain.OIDCAuthMethodTypeNone, true
+	case oidc.AuthMethodPrivateKeyJWT:
+		return domain.OIDCAuthMethodTypePrivateKeyJWT, true
+	default:
+		return 0, false
+	}
+}
+
+func applicationTypeFromOIDC(applicationType string) (domain.OIDCApplicationType, bool) {
+	switch applicationType {
+	case "", applicationTypeWeb:
+		return domain.OIDCApplicationTypeWeb, true
+	case applicationTypeNative:
+		return domain.OIDCApplicationTypeNative, true
+	default:
+		return 0, false
+	}
+}
+
+func applicationTypeToOIDC(applicationType domain.OIDCApplicationType) string {
+	if applicationType == domain.OIDCApplicationTypeNative {
+		return applicationTypeNative
+	}
+	return applicationTypeWeb
+}
+
+func grantTypesFromOIDC(grantTypes []oidc.GrantType) ([]domain.OIDCGrantType, bool) {
+	// authorization_code is the default of RFC 7591
+	if len(grantTypes) == 0 {
+		return []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode}, true
+	}
+	types := make([]domain.OIDCGrantType, len(grantTypes))
+	for i, grantType := range grantTypes {
+		switch grantType {
+		case oidc.GrantTypeCode:
+			types[i] = domain.OIDCGrantTypeAuthorizationCode
+		case oidc.GrantTypeImplicit:
+			types[i] = domain.OIDCGrantTypeImplicit
+		case oidc.GrantTypeRefreshToken:
+			types[i] = domain.OIDCGrantTypeRefreshToken
+		case oidc.GrantTypeDeviceCode:
+			types[i] = domain.OIDCGrantTypeDeviceCode
+		case oidc.GrantTypeTokenExchange:
+			types[i] = domain.OIDCGrantTypeTokenExchange
+		case grantTypeCIBA:
+			types[i] = domain.OIDCGrantTypeCIBA
+		default:
+			return nil, false
+		}
+	}
+	return types, true
+}
+
+func responseTypesFromOIDC(responseTypes []oidc.ResponseType) ([]domain.OIDCResponseType, bool) {
+	// code is the default of RFC 7591
+	if len(responseTypes) == 0 {
+		return []domain.OIDCResponseType{domain.OIDCResponseTypeCode}, true
+	}
+	types := make([]domain.OIDCResponseType, len(responseTypes))
+	for i, responseType := range responseTypes {
+		switch responseType {
+		case oidc.ResponseTypeCode:
+			types[i] = domain.OIDCResponseTypeCode
+		case oidc.ResponseTypeIDTokenOnly:
+			types[i] = domain.OIDCResponseTypeIDToken
+		case oidc.ResponseTypeIDToken:
+			types[i] = domain.OIDCResponseTypeIDTokenToken
+		default:
+			return nil, false
+		}
+	}
+	return types, true
+}
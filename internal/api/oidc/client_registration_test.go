@@ -0,0 +1,120 @@
+package oidc
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+func Test_clientMetadata_toOIDCApp(t *testing.T) {
+	tests := []struct {
+		name     string
+		metadata *clientMetadata
+		want     *domain.OIDCApp
+		wantErr  bool
+	}{
+		{
+			name: "defaults",
+			metadata: &clientMetadata{
+				RedirectURIs: []string{"https://app.example.com/callback"},
+			},
+			want: &domain.OIDCApp{
+				AppName:         defaultRegisteredClientName,
+				OIDCVersion:     domain.OIDCVersionV1,
+				RedirectUris:    []string{"https://app.example.com/callback"},
+				ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+				ApplicationType: domain.OIDCApplicationTypeWeb,
+				AuthMethodType:  domain.OIDCAuthMethodTypeBasic,
+				AccessTokenType: domain.OIDCTokenTypeBearer,
+			},
+		},
+		{
+			name: "native public client",
+			metadata: &clientMetadata{
+				RedirectURIs:            []string{"com.example.app:/callback"},
+				TokenEndpointAuthMethod: oidc.AuthMethodNone,
+				GrantTypes:              []oidc.GrantType{oidc.GrantTypeCode, oidc.GrantTypeRefreshToken},
+				ResponseTypes:           []oidc.ResponseType{oidc.ResponseTypeCode},
+				ClientName:              "app",
+				ApplicationType:         applicationTypeNative,
+				DPoPBoundAccessTokens:   true,
+			},
+			want: &domain.OIDCApp{
+				AppName:               "app",
+				OIDCVersion:           domain.OIDCVersionV1,
+				RedirectUris:          []string{"com.example.app:/callback"},
+				ResponseTypes:         []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:            []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+				ApplicationType:       domain.OIDCApplicationTypeNative,
+				AuthMethodType:        domain.OIDCAuthMethodTypeNone,
+				AccessTokenType:       domain.OIDCTokenTypeBearer,
+				DPoPBoundAccessTokens: true,
+			},
+		},
+		{
+			name: "backchannel authentication ping mode",
+			metadata: &clientMetadata{
+				GrantTypes:                       []oidc.GrantType{grantTypeCIBA},
+				ResponseTypes:                    []oidc.ResponseType{oidc.ResponseTypeCode},
+				BackChannelTokenDeliveryMode:     backChannelTokenDeliveryModePing,
+				BackChannelClientNotificationURI: "https://app.example.com/ciba",
+			},
+			want: &domain.OIDCApp{
+				AppName:                          defaultRegisteredClientName,
+				OIDCVersion:                      domain.OIDCVersionV1,
+				ResponseTypes:                    []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				GrantTypes:                       []domain.OIDCGrantType{domain.OIDCGrantTypeCIBA},
+				ApplicationType:                  domain.OIDCApplicationTypeWeb,
+				AuthMethodType:                   domain.OIDCAuthMethodTypeBasic,
+				AccessTokenType:                  domain.OIDCTokenTypeBearer,
+				BackChannelClientNotificationURI: "https://app.example.com/ciba",
+			},
+		},
+		{
+			name: "backchannel authentication ping mode without endpoint, error",
+			metadata: &clientMetadata{
+				GrantTypes:                   []oidc.GrantType{grantTypeCIBA},
+				BackChannelTokenDeliveryMode: backChannelTokenDeliveryModePing,
+			},
+			wantErr: true,
+		},
+		{
+			name: "backchannel authentication push mode, error",
+			metadata: &clientMetadata{
+				GrantTypes:                   []oidc.GrantType{grantTypeCIBA},
+				BackChannelTokenDeliveryMode: "push",
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported grant type, error",
+			metadata: &clientMetadata{
+				GrantTypes: []oidc.GrantType{oidc.GrantTypeClientCredentials},
+			},
+			wantErr: true,
+		},
+		{
+			name: "unsupported auth method, error",
+			metadata: &clientMetadata{
+				TokenEndpointAuthMethod: "tls_client_auth",
+			},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.metadata.toOIDCApp()
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,137 @@
+package oidc
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/dpop"
+)
+
+const ClaimConfirmation = "cnf"
+
+type dpopJKTKey struct{}
+
+type userinfoAccessTokenKey struct{}
+
+// withDPoPJKT stores the thumbprint of the key of the verified DPoP proof of the token request,
+// so the issued tokens can be bound to it
+func withDPoPJKT(ctx context.Context, jkt string) context.Context {
+	return context.WithValue(ctx, dpopJKTKey{}, jkt)
+}
+
+func dpopJKTFromContext(ctx context.Context) string {
+	jkt, _ := ctx.Value(dpopJKTKey{}).(string)
+	return jkt
+}
+
+// dpopConfirmation returns the `cnf` claim of tokens bound to the key with the thumbprint jkt
+// https://www.rfc-editor.org/rfc/rfc9449.html#section-6
+func dpopConfirmation(jkt string) map[string]string {
+	return map[string]string{"jkt": jkt}
+}
+
+// dpopHandler prepares the requests for the verification of DPoP bound access tokens:
+// The DPoP scheme of the Authorization header is replaced by the Bearer scheme, so the token can be parsed,
+// and the proof is stored in the context to check the binding of the token.
+// If nonces are required, a fresh nonce is returned on every request containing a proof.
+func (s *Server) dpopHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if s.dpop.NonceRequired() && r.Header.Get(dpop.HeaderName) != "" {
+			w.Header().Set(dpop.NonceHeaderName, s.dpop.Nonce())
+		}
+		scheme, token, ok := dpop.SplitAuthorization(r.Header.Get("Authorization"))
+		if !ok {
+			next.ServeHTTP(w, r)
+			return
+		}
+		r = r.WithContext(dpop.WithRequest(r.Context(), dpop.NewRequest(r, "")))
+		if strings.EqualFold(scheme, dpop.TokenType) {
+			r.Header.Set("Authorization", oidc.PrefixBearer+token)
+		}
+		next.ServeHTTP(w, r)
+	})
+}
+
+// verifyTokenRequestDPoP verifies the DPoP proof sent to the token endpoint
+// and stores the thumbprint of its key in the context, so the issued tokens are bound to it.
+// Clients requiring DPoP bound access tokens must send a proof.
+func (s *Server) verifyTokenRequestDPoP(ctx context.Context, header http.Header, client op.Client) (context.Context, error) {
+	proof := header.Get(dpop.HeaderName)
+	if proof == "" {
+		if c, ok := client.(*Client); ok && c.app.OIDCConfig != nil && c.app.OIDCConfig.DPoPBoundAccessTokens {
+			return nil, dpopError(&dpop.Error{Type: dpop.ErrorTypeInvalidProof, Description: "the client requires DPoP bound access tokens"})
+		}
+		return ctx, nil
+	}
+	if s.dpop == nil {
+		return nil, dpopError(&dpop.Error{Type: dpop.ErrorTypeInvalidProof, Description: "DPoP is not supported"})
+	}
+	jkt, err := s.dpop.Verify(ctx, proof, http.MethodPost, s.Endpoints().Token.Absolute(op.IssuerFromContext(ctx)), "")
+	if err != nil {
+		return nil, dpopError(err)
+	}
+	return withDPoPJKT(ctx, jkt), nil
+}
+
+// dpopTokenResponse sets the token_type of the response to DPoP, if the access token is bound to a key
+func dpopTokenResponse(ctx context.Context, resp *op.Response) *op.Response {
+	if dpopJKTFromContext(ctx) == "" {
+		return resp
+	}
+	switch data := resp.Data.(type) {
+	case *oidc.AccessTokenResponse:
+		data.TokenType = dpop.TokenType
+	case *oidc.TokenExchangeResponse:
+		if data.TokenType == oidc.BearerToken {
+			data.TokenType = dpop.TokenType
+		}
+	}
+	return resp
+}
+
+// withUserinfoDPoPRequest completes the DPoP request of the userinfo endpoint with the method and uri
+// and stores the access token in the context, so the binding can be checked when the token is loaded
+func (s *Server) withUserinfoDPoPRequest(ctx context.Context, r *op.Request[oidc.UserInfoRequest]) context.Context {
+	if request := dpop.RequestFromContext(ctx); request != nil {
+		request.Method = r.Method
+		request.URI = s.Endpoints().Userinfo.Absolute(op.IssuerFromContext(ctx))
+	}
+	return context.WithValue(ctx, userinfoAccessTokenKey{}, r.Data.AccessToken)
+}
+
+// checkUserinfoDPoPBinding checks if the access token of the userinfo request is presented
+// with a valid proof of the key it's bound to
+func (o *OPStorage) checkUserinfoDPoPBinding(ctx context.Context, jkt string) error {
+	accessToken, _ := ctx.Value(userinfoAccessTokenKey{}).(string)
+	if err := o.dpop.CheckBinding(ctx, jkt, accessToken); err != nil {
+		return op.NewStatusError(dpopError(err), http.StatusUnauthorized)
+	}
+	return nil
+}
+
+// dpopError maps the error of the DPoP verification to an OAuth error
+// https://www.rfc-editor.org/rfc/rfc9449.html#section-12.2
+func dpopError(err error) error {
+	target := new(dpop.Error)
+	if !errors.As(err, &target) {
+		return oidc.ErrServerError().WithParent(err)
+	}
+	oauthErr := &oidc.Error{
+		Description: target.Description,
+		Parent:      target.Parent,
+	}
+	switch target.Type {
+	case dpop.ErrorTypeUseNonce:
+		oauthErr.ErrorType = "use_dpop_nonce"
+	case dpop.ErrorTypeInvalidToken:
+		oauthErr.ErrorType = "invalid_token"
+	default:
+		oauthErr.ErrorType = "invalid_dpop_proof"
+	}
+	return oauthErr
+}
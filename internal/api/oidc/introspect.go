@@ -10,6 +10,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"github.com/zitadel/oidc/v3/pkg/op"
 
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	"github.com/zitadel/zitadel/internal/crypto"
 	errz "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query"
@@ -113,6 +114,10 @@ func (s *Server) Introspect(ctx context.Context, r *op.Request[op.IntrospectionR
 		JWTID:      token.tokenID,
 	}
 	introspectionResp.SetUserInfo(userInfo)
+	if token.dpopJKT != "" {
+		introspectionResp.TokenType = dpop.TokenType
+		introspectionResp.Claims = appendClaim(introspectionResp.Claims, ClaimConfirmation, dpopConfirmation(token.dpopJKT))
+	}
 	return op.NewResponse(introspectionResp), nil
 }
 
@@ -232,12 +232,12 @@ func (o *OPStorage) KeySet(ctx context.Context) (keys []op.Key, err error) {
 
 // SignatureAlgorithms implements the op.Storage interface
 func (o *OPStorage) SignatureAlgorithms(ctx context.Context) ([]jose.SignatureAlgorithm, error) {
-	key, err := o.SigningKey(ctx)
+	algorithms, err := o.signatureAlgorithms(ctx)
 	if err != nil {
-		logging.WithError(err).Warn("unable to fetch signing key")
+		logging.WithError(err).Warn("unable to fetch signature algorithms")
 		return nil, err
 	}
-	return []jose.SignatureAlgorithm{key.SignatureAlgorithm()}, nil
+	return algorithms, nil
 }
 
 // SigningKey implements the op.Storage interface
@@ -256,18 +256,22 @@ func (o *OPStorage) SigningKey(ctx context.Context) (key op.SigningKey, err erro
 }
 
 func (o *OPStorage) getSigningKey(ctx context.Context) (op.SigningKey, error) {
+	algorithm, err := o.signingAlgorithm(ctx)
+	if err != nil {
+		return nil, err
+	}
 	keys, err := o.query.ActivePrivateSigningKey(ctx, time.Now().Add(gracefulPeriod))
 	if err != nil {
 		return nil, err
 	}
-	if len(keys.Keys) > 0 {
-		return o.privateKeyToSigningKey(selectSigningKey(keys.Keys))
+	if key := selectSigningKey(keys.Keys, algorithm); key != nil {
+		return o.privateKeyToSigningKey(key)
 	}
 	var position float64
 	if keys.State != nil {
 		position = keys.State.Position
 	}
-	return nil, o.refreshSigningKey(ctx, o.signingKeyAlgorithm, position)
+	return nil, o.refreshSigningKey(ctx, algorithm, position)
 }
 
 func (o *OPStorage) refreshSigningKey(ctx context.Context, algorithm string, position float64) error {
@@ -295,7 +299,7 @@ func (o *OPStorage) privateKeyToSigningKey(key query.PrivateKey) (_ op.SigningKe
 	if err != nil {
 		return nil, err
 	}
-	privateKey, err := crypto.BytesToPrivateKey(keyData)
+	privateKey, err := crypto.BytesToSigningPrivateKey(keyData)
 	if err != nil {
 		return nil, err
 	}
@@ -343,8 +347,15 @@ func (o *OPStorage) getMaxKeySequence(ctx context.Context) (float64, error) {
 	)
 }
 
-func selectSigningKey(keys []query.PrivateKey) query.PrivateKey {
-	return keys[len(keys)-1]
+// selectSigningKey returns the key of the algorithm with the latest expiration
+// or nil if there is none
+func selectSigningKey(keys []query.PrivateKey, algorithm string) query.PrivateKey {
+	for i := len(keys) - 1; i >= 0; i-- {
+		if keys[i].Algorithm() == algorithm {
+			return keys[i]
+		}
+	}
+	return nil
 }
 
 func setOIDCCtx(ctx context.Context) context.Context {
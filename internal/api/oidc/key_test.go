@@ -12,6 +12,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/query"
 )
@@ -242,3 +243,78 @@ func Test_keySetMap_VerifySignature(t *testing.T) {
 		})
 	}
 }
+
+type privateKey struct {
+	id  string
+	alg string
+}
+
+func (k *privateKey) ID() string {
+	return k.id
+}
+
+func (k *privateKey) Algorithm() string {
+	return k.alg
+}
+
+func (k *privateKey) Use() domain.KeyUsage {
+	return domain.KeyUsageSigning
+}
+
+func (k *privateKey) Sequence() uint64 {
+	return 0
+}
+
+func (k *privateKey) Expiry() time.Time {
+	return time.Time{}
+}
+
+func (k *privateKey) Key() *crypto.CryptoValue {
+	return nil
+}
+
+func Test_selectSigningKey(t *testing.T) {
+	keys := []query.PrivateKey{
+		&privateKey{id: "rsa1", alg: "RS256"},
+		&privateKey{id: "ec", alg: "ES256"},
+		&privateKey{id: "rsa2", alg: "RS256"},
+	}
+	tests := []struct {
+		name      string
+		algorithm string
+		want      query.PrivateKey
+	}{
+		{
+			name:      "latest key of algorithm",
+			algorithm: "RS256",
+			want:      keys[2],
+		},
+		{
+			name:      "single key of algorithm",
+			algorithm: "ES256",
+			want:      keys[1],
+		},
+		{
+			name:      "no key of algorithm",
+			algorithm: "EdDSA",
+			want:      nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, selectSigningKey(keys, tt.algorithm))
+		})
+	}
+}
+
+func Test_clientForcesRSA(t *testing.T) {
+	ctx := context.WithValue(context.Background(), clientSigningAlgorithmKey{}, new(clientSigningAlgorithm))
+	assert.False(t, clientForcesRSA(ctx))
+	setClientForceRSA(ctx, false)
+	assert.False(t, clientForcesRSA(ctx))
+	setClientForceRSA(ctx, true)
+	assert.True(t, clientForcesRSA(ctx))
+
+	assert.False(t, clientForcesRSA(context.Background()))
+	assert.True(t, clientForcesRSA(withClientForceRSA(context.Background(), true)))
+}
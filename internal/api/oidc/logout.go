@@ -0,0 +1,224 @@
+package oidc
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"html/template"
+	"net/http"
+	"net/url"
+
+	"github.com/zitadel/logging"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/oidcsession"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	frontChannelLogoutEndpoint = "/oidc/v1/frontchannel_logout"
+	frontChannelLogoutState    = "state"
+	// frontChannelLogoutDelay is the time in seconds the iframes of the applications get to load,
+	// before the user agent is redirected to the post_logout_redirect_uri
+	frontChannelLogoutDelay = 2
+)
+
+type eventFilter interface {
+	Filter(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder) ([]eventstore.Event, error)
+}
+
+type appByClientID func(ctx context.Context, clientID string) (*query.App, error)
+
+// logoutSession is the session of a user on a client, which ends on the logout.
+// The sessionID is used as `sid` claim and is the session for V2 tokens and the user agent for V1 tokens.
+// The issuer is the one the tokens were issued by and is empty for tokens issued before it was stored.
+type logoutSession struct {
+	clientID  string
+	userID    string
+	sessionID string
+	issuer    string
+}
+
+// sessionLogouts returns a logoutSession for every client, which received tokens of the (V2) session
+func sessionLogouts(ctx context.Context, es eventFilter, sessionID string) ([]*logoutSession, error) {
+	events, err := es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		AddQuery().
+		AggregateTypes(oidcsession.AggregateType).
+		EventTypes(oidcsession.AddedType).
+		EventData(map[string]interface{}{"sessionID": sessionID}).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	logouts := make([]*logoutSession, 0, len(events))
+	for _, event := range events {
+		added, ok := event.(*oidcsession.AddedEvent)
+		if !ok {
+			continue
+		}
+		logouts = appendLogoutSession(logouts, added.ClientID, added.UserID, sessionID, added.Issuer)
+	}
+	return logouts, nil
+}
+
+// userAgentLogouts returns a logoutSession for every client, which received tokens of the users on the (V1) user agent
+func userAgentLogouts(ctx context.Context, es eventFilter, userAgentID string, userIDs ...string) ([]*logoutSession, error) {
+	events, err := es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(userIDs...).
+		EventTypes(user.UserTokenAddedType).
+		EventData(map[string]interface{}{"userAgentId": userAgentID}).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	logouts := make([]*logoutSession, 0, len(events))
+	for _, event := range events {
+		added, ok := event.(*user.UserTokenAddedEvent)
+		if !ok {
+			continue
+		}
+		logouts = appendLogoutSession(logouts, added.ApplicationID, added.Aggregate().ID, userAgentID, added.Issuer)
+	}
+	return logouts, nil
+}
+
+func appendLogoutSession(logouts []*logoutSession, clientID, userID, sessionID, issuer string) []*logoutSession {
+	for _, logout := range logouts {
+		if logout.clientID == clientID && logout.userID == userID {
+			if logout.issuer == "" {
+				logout.issuer = issuer
+			}
+			return logouts
+		}
+	}
+	return append(logouts, &logoutSession{
+		clientID:  clientID,
+		userID:    userID,
+		sessionID: sessionID,
+		issuer:    issuer,
+	})
+}
+
+// frontChannelLogout is the (encrypted) state of the front-channel logout page
+type frontChannelLogout struct {
+	URIs        []string `json:"uris"`
+	RedirectURI string   `json:"redirectURI"`
+}
+
+// frontChannelLogoutRedirect returns the front-channel logout page,
+// if any of the clients of the logouts has a front-channel logout URI configured.
+// Otherwise the redirectURI is returned.
+func (o *OPStorage) frontChannelLogoutRedirect(ctx context.Context, logouts []*logoutSession, redirectURI string) (string, error) {
+	uris := frontChannelLogoutURIs(ctx, o.query.AppByOIDCClientID, op.IssuerFromContext(ctx), logouts)
+	if len(uris) == 0 {
+		return redirectURI, nil
+	}
+	state, err := json.Marshal(&frontChannelLogout{
+		URIs:        uris,
+		RedirectURI: redirectURI,
+	})
+	if err != nil {
+		return "", err
+	}
+	encrypted, err := crypto.Encrypt(state, o.encAlg)
+	if err != nil {
+		return "", err
+	}
+	encryptedState, err := json.Marshal(encrypted)
+	if err != nil {
+		return "", err
+	}
+	return frontChannelLogoutEndpoint + "?" + url.Values{
+		frontChannelLogoutState: {base64.RawURLEncoding.EncodeToString(encryptedState)},
+	}.Encode(), nil
+}
+
+// frontChannelLogoutURIs returns the front-channel logout URIs of the clients
+// with the `iss` and `sid` query parameters of the logout
+func frontChannelLogoutURIs(ctx context.Context, appByClientID appByClientID, issuer string, logouts []*logoutSession) []string {
+	uris := make([]string, 0, len(logouts))
+	for _, logout := range logouts {
+		app, err := appByClientID(ctx, logout.clientID)
+		if err != nil {
+			logging.WithFields("client", logout.clientID).WithError(err).Warn("unable to get client for front-channel logout")
+			continue
+		}
+		if app.OIDCConfig == nil || app.OIDCConfig.FrontChannelLogoutURI == "" {
+			continue
+		}
+		uri, err := url.Parse(app.OIDCConfig.FrontChannelLogoutURI)
+		if err != nil {
+			continue
+		}
+		query := uri.Query()
+		query.Set("iss", issuer)
+		query.Set("sid", logout.sessionID)
+		uri.RawQuery = query.Encode()
+		uris = append(uris, uri.String())
+	}
+	return uris
+}
+
+func (o *OPStorage) frontChannelLogoutFromState(state string) (*frontChannelLogout, error) {
+	encryptedState, err := base64.RawURLEncoding.DecodeString(state)
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "OIDC-ieK4o", "Errors.Internal")
+	}
+	encrypted := new(crypto.CryptoValue)
+	if err = json.Unmarshal(encryptedState, encrypted); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "OIDC-ohh3U", "Errors.Internal")
+	}
+	decrypted, err := crypto.Decrypt(encrypted, o.encAlg)
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "OIDC-Ooj0u", "Errors.Internal")
+	}
+	logout := new(frontChannelLogout)
+	if err = json.Unmarshal(decrypted, logout); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "OIDC-chai7", "Errors.Internal")
+	}
+	return logout, nil
+}
+
+var frontChannelLogoutTemplate = template.Must(template.New("frontchannel_logout").Parse(`<!DOCTYPE html>
+<html>
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.Delay}};url={{.RedirectURI}}">
+<title>Logout</title>
+</head>
+<body>
+{{range .URIs}}<iframe src="{{.}}" style="display:none"></iframe>
+{{end}}<a href="{{.RedirectURI}}">Continue</a>
+</body>
+</html>
+`))
+
+// frontChannelLogoutHandler renders the front-channel logout URIs of the clients in iframes
+// and redirects the user agent to the post_logout_redirect_uri afterwards.
+func (o *OPStorage) frontChannelLogoutHandler(w http.ResponseWriter, r *http.Request) {
+	logout, err := o.frontChannelLogoutFromState(r.URL.Query().Get(frontChannelLogoutState))
+	if err != nil {
+		http.Error(w, "invalid logout state", http.StatusBadRequest)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	err = frontChannelLogoutTemplate.Execute(w, struct {
+		*frontChannelLogout
+		Delay int
+	}{
+		frontChannelLogout: logout,
+		Delay:              frontChannelLogoutDelay,
+	})
+	logging.OnError(err).Warn("unable to render front-channel logout")
+}
@@ -0,0 +1,74 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_appendLogoutSession(t *testing.T) {
+	logouts := appendLogoutSession(nil, "client1", "user1", "session1", "")
+	logouts = appendLogoutSession(logouts, "client1", "user1", "session1", "https://custom.com")
+	logouts = appendLogoutSession(logouts, "client2", "user1", "session1", "https://issuer.com")
+	logouts = appendLogoutSession(logouts, "client1", "user2", "session1", "https://issuer.com")
+	assert.Equal(t, []*logoutSession{
+		{clientID: "client1", userID: "user1", sessionID: "session1", issuer: "https://custom.com"},
+		{clientID: "client2", userID: "user1", sessionID: "session1", issuer: "https://issuer.com"},
+		{clientID: "client1", userID: "user2", sessionID: "session1", issuer: "https://issuer.com"},
+	}, logouts)
+}
+
+func Test_newLogoutToken(t *testing.T) {
+	now := time.Unix(1700000000, 0)
+	token := newLogoutToken(&logoutSession{
+		clientID:  "client1",
+		userID:    "user1",
+		sessionID: "session1",
+		issuer:    "https://issuer.com",
+	}, "token1", now)
+
+	payload, err := json.Marshal(token)
+	require.NoError(t, err)
+	assert.JSONEq(t, `{
+		"iss": "https://issuer.com",
+		"sub": "user1",
+		"aud": ["client1"],
+		"iat": 1700000000,
+		"exp": 1700000120,
+		"jti": "token1",
+		"sid": "session1",
+		"events": {"http://schemas.openid.net/event/backchannel-logout": {}}
+	}`, string(payload))
+}
+
+func Test_frontChannelLogoutURIs(t *testing.T) {
+	apps := map[string]*query.App{
+		"client1": {OIDCConfig: &query.OIDCApp{FrontChannelLogoutURI: "https://client1.com/logout"}},
+		"client2": {OIDCConfig: &query.OIDCApp{}},
+		"client3": {OIDCConfig: &query.OIDCApp{FrontChannelLogoutURI: "https://client3.com/logout?tenant=1"}},
+	}
+	appByClientID := func(_ context.Context, clientID string) (*query.App, error) {
+		app, ok := apps[clientID]
+		if !ok {
+			return nil, errors.ThrowNotFound(nil, "TEST-Oot5a", "Errors.App.NotFound")
+		}
+		return app, nil
+	}
+	got := frontChannelLogoutURIs(context.Background(), appByClientID, "https://issuer.com", []*logoutSession{
+		{clientID: "client1", userID: "user1", sessionID: "session1"},
+		{clientID: "client2", userID: "user1", sessionID: "session1"},
+		{clientID: "client3", userID: "user1", sessionID: "session1"},
+		{clientID: "unknown", userID: "user1", sessionID: "session1"},
+	})
+	assert.Equal(t, []string{
+		"https://client1.com/logout?iss=https%3A%2F%2Fissuer.com&sid=session1",
+		"https://client3.com/logout?iss=https%3A%2F%2Fissuer.com&sid=session1&tenant=1",
+	}, got)
+}
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"time"
 
+	"github.com/go-chi/chi/v5"
 	"github.com/rakyll/statik/fs"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"github.com/zitadel/oidc/v3/pkg/op"
@@ -13,6 +14,7 @@ import (
 	"golang.org/x/text/language"
 
 	"github.com/zitadel/zitadel/internal/api/assets"
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	http_utils "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/api/ui/login"
@@ -46,6 +48,10 @@ type Config struct {
 	DefaultLoginURLV2                 string
 	DefaultLogoutURLV2                string
 	Features                          Features
+	BackChannelLogout                 *BackChannelLogoutConfig
+	PushedAuthRequestLifetime         time.Duration
+	DPoP                              dpop.Config
+	BackChannelAuth                   *BackChannelAuthConfig
 }
 
 type EndpointConfig struct {
@@ -85,6 +91,7 @@ type OPStorage struct {
 	encAlg                            crypto.EncryptionAlgorithm
 	locker                            crdb.Locker
 	assetAPIPrefix                    func(ctx context.Context) string
+	dpop                              *dpop.Verifier
 }
 
 func NewServer(
@@ -101,12 +108,13 @@ func NewServer(
 	userAgentCookie, instanceHandler func(http.Handler) http.Handler,
 	accessHandler *middleware.AccessInterceptor,
 	fallbackLogger *slog.Logger,
+	dpopVerifier *dpop.Verifier,
 ) (*Server, error) {
 	opConfig, err := createOPConfig(config, defaultLogoutRedirectURI, cryptoKey)
 	if err != nil {
 		return nil, caos_errs.ThrowInternal(err, "OIDC-EGrqd", "cannot create op config: %w")
 	}
-	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure)
+	storage := newStorage(config, command, query, repo, encryptionAlg, es, projections, externalSecure, dpopVerifier)
 	var options []op.Option
 	if !externalSecure {
 		options = append(options, op.WithAllowInsecure())
@@ -125,27 +133,51 @@ func NewServer(
 	}
 
 	server := &Server{
-		LegacyServer:        op.NewLegacyServer(provider, endpoints(config.CustomEndpoints)),
-		features:            config.Features,
-		repo:                repo,
-		query:               query,
-		command:             command,
-		keySet:              newKeySet(context.TODO(), time.Hour, query.GetActivePublicKeyByID),
-		fallbackLogger:      fallbackLogger,
-		hashAlg:             crypto.NewBCrypt(10), // as we are only verifying in oidc, the cost is already part of the hash string and the config here is irrelevant.
-		signingKeyAlgorithm: config.SigningKeyAlgorithm,
-		assetAPIPrefix:      assets.AssetAPI(externalSecure),
+		LegacyServer:         op.NewLegacyServer(provider, endpoints(config.CustomEndpoints)),
+		features:             config.Features,
+		repo:                 repo,
+		query:                query,
+		command:              command,
+		keySet:               newKeySet(context.TODO(), time.Hour, query.GetActivePublicKeyByID),
+		fallbackLogger:       fallbackLogger,
+		hashAlg:              crypto.NewBCrypt(10), // when verifying, the cost is already part of the hash string. It's only used to hash the secrets of dynamically registered clients.
+		signingKeyAlgorithm:  config.SigningKeyAlgorithm,
+		assetAPIPrefix:       assets.AssetAPI(externalSecure),
+		eventstore:           es,
+		externalSecure:       externalSecure,
+		backChannelLogout:    config.BackChannelLogout,
+		backChannelAuth:      config.BackChannelAuth,
+		backChannelAuthPolls: &backChannelAuthPolls{dbClient: projections},
+
+		pushedAuthRequestLifetime: config.PushedAuthRequestLifetime,
+		dpop:                      dpopVerifier,
 	}
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
-	server.Handler = op.RegisterLegacyServer(server, op.WithHTTPMiddleware(
-		middleware.MetricsHandler(metricTypes),
-		middleware.TelemetryHandler(),
-		middleware.NoCacheInterceptor().Handler,
-		instanceHandler,
-		userAgentCookie,
-		http_utils.CopyHeadersToContext,
-		accessHandler.HandleIgnorePathPrefixes(ignoredQuotaLimitEndpoint(config.CustomEndpoints)),
-	))
+	server.Handler = op.RegisterLegacyServer(server,
+		op.WithHTTPMiddleware(
+			middleware.MetricsHandler(metricTypes),
+			middleware.TelemetryHandler(),
+			middleware.NoCacheInterceptor().Handler,
+			instanceHandler,
+			userAgentCookie,
+			http_utils.CopyHeadersToContext,
+			server.dpopHandler,
+			signingAlgorithmHandler,
+			accessHandler.HandleIgnorePathPrefixes(ignoredQuotaLimitEndpoint(config.CustomEndpoints)),
+			server.backChannelAuthTokenHandler,
+		),
+		op.WithSetRouter(func(router chi.Router) {
+			router.Get(frontChannelLogoutEndpoint, storage.frontChannelLogoutHandler)
+			router.Post(pushedAuthRequestEndpoint, server.pushedAuthRequestHandler)
+			router.Post(clientRegistrationEndpoint, server.registerClientHandler)
+			router.Get(clientConfigurationEndpoint, server.clientConfigurationHandler)
+			router.Put(clientConfigurationEndpoint, server.clientConfigurationHandler)
+			router.Delete(clientConfigurationEndpoint, server.clientConfigurationHandler)
+			if server.backChannelAuthEnabled() {
+				router.Post(backChannelAuthEndpoint, server.backChannelAuthHandler)
+			}
+		}),
+	)
 
 	return server, nil
 }
@@ -187,7 +219,7 @@ func createOPConfig(config Config, defaultLogoutRedirectURI string, cryptoKey []
 	return opConfig, nil
 }
 
-func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, db *database.DB, externalSecure bool) *OPStorage {
+func newStorage(config Config, command *command.Commands, query *query.Queries, repo repository.Repository, encAlg crypto.EncryptionAlgorithm, es *eventstore.Eventstore, db *database.DB, externalSecure bool, dpopVerifier *dpop.Verifier) *OPStorage {
 	return &OPStorage{
 		repo:                              repo,
 		command:                           command,
@@ -204,6 +236,7 @@ func newStorage(config Config, command *command.Commands, query *query.Queries,
 		encAlg:                            encAlg,
 		locker:                            crdb.NewLocker(db.DB, locksTable, signingKey),
 		assetAPIPrefix:                    assets.AssetAPI(externalSecure),
+		dpop:                              dpopVerifier,
 	}
 }
 
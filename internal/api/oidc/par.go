@@ -0,0 +1,198 @@
+package oidc
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	httphelper "github.com/zitadel/oidc/v3/pkg/http"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	pushedAuthRequestEndpoint = "/oauth/v2/par"
+	// requestURIPrefix is the prefix of the request_uri returned by the pushed authorization request endpoint
+	// https://www.rfc-editor.org/rfc/rfc9126.html#section-2.2
+	requestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+	requestURIParam  = "request_uri"
+)
+
+// pushedAuthRequestResponse is the response of the pushed authorization request endpoint
+// https://www.rfc-editor.org/rfc/rfc9126.html#section-2.2
+type pushedAuthRequestResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  uint64 `json:"expires_in"`
+}
+
+// pushedAuthRequestHandler implements the pushed authorization request endpoint (RFC 9126).
+// The client authenticates itself as on the token endpoint and pushes the parameters of the authorization request,
+// optionally as signed request object (RFC 9101). The validated request is stored and can be referenced
+// in the authorization request by the returned request_uri.
+func (s *Server) pushedAuthRequestHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	resp, err := s.pushAuthRequest(ctx, r)
+	if err != nil {
+		op.RequestError(w, r, err, s.getLogger(ctx))
+		return
+	}
+	httphelper.MarshalJSONWithStatus(w, resp, http.StatusCreated)
+}
+
+func (s *Server) pushAuthRequest(ctx context.Context, r *http.Request) (_ *pushedAuthRequestResponse, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	credentials, err := s.parseClientCredentials(r)
+	if err != nil {
+		return nil, err
+	}
+	client, err := s.VerifyClient(ctx, &op.Request[op.ClientCredentials]{
+		Method:   r.Method,
+		URL:      r.URL,
+		Header:   r.Header,
+		Form:     r.Form,
+		PostForm: r.PostForm,
+		Data:     credentials,
+	})
+	if err != nil {
+		return nil, err
+	}
+	authReq := new(oidc.AuthRequest)
+	if err = s.Provider().Decoder().Decode(authReq, r.PostForm); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding form").WithParent(err)
+	}
+	if r.PostForm.Has(requestURIParam) {
+		return nil, oidc.ErrInvalidRequest().WithDescription("request_uri must not be pushed")
+	}
+	if authReq.ClientID != "" && authReq.ClientID != client.GetID() {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id does not match the authenticated client")
+	}
+	authReq.ClientID = client.GetID()
+
+	signed := authReq.RequestParam != ""
+	if signed {
+		if !s.Provider().RequestObjectSupported() {
+			return nil, oidc.ErrRequestNotSupported()
+		}
+		if err = op.ParseRequestObject(ctx, authReq, s.Provider().Storage(), op.IssuerFromContext(ctx)); err != nil {
+			return nil, err
+		}
+	}
+	if err = checkAuthRequestObject(client, true, signed); err != nil {
+		return nil, err
+	}
+	if authReq.RedirectURI == "" {
+		return nil, op.ErrAuthReqMissingRedirectURI
+	}
+	if _, err = op.ValidateAuthRequest(ctx, authReq, s.Provider().Storage(), s.Provider().IDTokenHintVerifier(ctx)); err != nil {
+		return nil, err
+	}
+	request, err := json.Marshal(authReq)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	id, err := s.command.AddPushedAuthRequest(ctx, authReq.ClientID, request, time.Now().Add(s.pushedAuthRequestLifetime))
+	if err != nil {
+		return nil, err
+	}
+	return &pushedAuthRequestResponse{
+		RequestURI: requestURIPrefix + id,
+		ExpiresIn:  uint64(s.pushedAuthRequestLifetime / time.Second),
+	}, nil
+}
+
+// parseClientCredentials reads the client authentication from the form or the basic auth header,
+// the same way as the token endpoint does
+func (s *Server) parseClientCredentials(r *http.Request) (_ *op.ClientCredentials, err error) {
+	if err = r.ParseForm(); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error parsing form").WithParent(err)
+	}
+	credentials := new(op.ClientCredentials)
+	if err = s.Provider().Decoder().Decode(credentials, r.PostForm); err != nil {
+		return nil, oidc.ErrInvalidRequest().WithDescription("error decoding form").WithParent(err)
+	}
+	if clientID, clientSecret, ok := r.BasicAuth(); ok {
+		if credentials.ClientID, err = url.QueryUnescape(clientID); err != nil {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+		if credentials.ClientSecret, err = url.QueryUnescape(clientSecret); err != nil {
+			return nil, oidc.ErrInvalidClient().WithDescription("invalid basic auth header").WithParent(err)
+		}
+	}
+	if credentials.ClientID == "" && credentials.ClientAssertion == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("client_id or client_assertion must be provided")
+	}
+	if credentials.ClientAssertion != "" && credentials.ClientAssertionType != oidc.ClientAssertionTypeJWTAssertion {
+		return nil, oidc.ErrInvalidRequest().WithDescription("invalid client_assertion_type %s", credentials.ClientAssertionType)
+	}
+	return credentials, nil
+}
+
+// pushedAuthRequest returns the pushed authorization request referenced by the request_uri.
+// Only request_uri values issued by the pushed authorization request endpoint are accepted,
+// request objects are never fetched from remote locations.
+func (s *Server) pushedAuthRequest(ctx context.Context, requestURI, clientID string) (*oidc.AuthRequest, error) {
+	id, ok := strings.CutPrefix(requestURI, requestURIPrefix)
+	if !ok || id == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("request_uri is not supported")
+	}
+	if clientID == "" {
+		return nil, op.ErrAuthReqMissingClientID
+	}
+	request, err := s.command.UsePushedAuthRequest(ctx, id, clientID)
+	if errors.IsNotFound(err) || errors.IsPreconditionFailed(err) {
+		return nil, oidc.ErrInvalidRequest().WithDescription("request_uri is invalid or expired").WithParent(err)
+	}
+	if err != nil {
+		return nil, err
+	}
+	authReq, err := unmarshalPushedAuthRequest(request)
+	if err != nil {
+		return nil, oidc.ErrServerError().WithParent(err)
+	}
+	return authReq, nil
+}
+
+// unmarshalPushedAuthRequest restores the stored authorization request.
+// Empty space delimited arrays are marshalled as empty string and need to be reset.
+func unmarshalPushedAuthRequest(request []byte) (*oidc.AuthRequest, error) {
+	authReq := new(oidc.AuthRequest)
+	if err := json.Unmarshal(request, authReq); err != nil {
+		return nil, err
+	}
+	authReq.Scopes = emptyDelimitedArray(authReq.Scopes)
+	authReq.Prompt = emptyDelimitedArray(authReq.Prompt)
+	authReq.ACRValues = emptyDelimitedArray(authReq.ACRValues)
+	return authReq, nil
+}
+
+func emptyDelimitedArray(array oidc.SpaceDelimitedArray) oidc.SpaceDelimitedArray {
+	if len(array) == 1 && array[0] == "" {
+		return nil
+	}
+	return array
+}
+
+// checkAuthRequestObject checks if the authorization request fulfills the requirements of the client:
+// If pushed authorization requests are required, the request must have been pushed.
+// If signed request objects are required, the request must have been passed as request object.
+func checkAuthRequestObject(client op.Client, pushed, signed bool) error {
+	c, ok := client.(*Client)
+	if !ok || c.app.OIDCConfig == nil {
+		return nil
+	}
+	if c.app.OIDCConfig.RequirePushedAuthRequest && !pushed {
+		return oidc.ErrInvalidRequest().WithDescription("the client requires pushed authorization requests")
+	}
+	if c.app.OIDCConfig.RequireSignedRequest && !signed {
+		return oidc.ErrInvalidRequest().WithDescription("the client requires signed request objects")
+	}
+	return nil
+}
@@ -0,0 +1,92 @@
+package oidc
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func Test_checkAuthRequestObject(t *testing.T) {
+	client := func(requirePushed, requireSigned bool) *Client {
+		return &Client{
+			app: &query.App{
+				OIDCConfig: &query.OIDCApp{
+					RequirePushedAuthRequest: requirePushed,
+					RequireSignedRequest:     requireSigned,
+				},
+			},
+		}
+	}
+	tests := []struct {
+		name    string
+		client  *Client
+		pushed  bool
+		signed  bool
+		wantErr bool
+	}{
+		{
+			name:   "no requirements",
+			client: client(false, false),
+		},
+		{
+			name:    "pushed required, error",
+			client:  client(true, false),
+			signed:  true,
+			wantErr: true,
+		},
+		{
+			name:   "pushed required",
+			client: client(true, false),
+			pushed: true,
+		},
+		{
+			name:    "signed required, error",
+			client:  client(false, true),
+			pushed:  true,
+			wantErr: true,
+		},
+		{
+			name:   "signed required",
+			client: client(false, true),
+			signed: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkAuthRequestObject(tt.client, tt.pushed, tt.signed)
+			if tt.wantErr {
+				require.ErrorIs(t, err, oidc.ErrInvalidRequest())
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_unmarshalPushedAuthRequest(t *testing.T) {
+	want := &oidc.AuthRequest{
+		Scopes:              oidc.SpaceDelimitedArray{oidc.ScopeOpenID, oidc.ScopeProfile},
+		ResponseType:        oidc.ResponseTypeCode,
+		ClientID:            "client1",
+		RedirectURI:         "https://client1.com/callback",
+		State:               "state",
+		Nonce:               "nonce",
+		Prompt:              oidc.SpaceDelimitedArray{oidc.PromptLogin},
+		MaxAge:              oidc.NewMaxAge(0),
+		UILocales:           oidc.Locales{language.German, language.English},
+		LoginHint:           "user@example.com",
+		CodeChallenge:       "challenge",
+		CodeChallengeMethod: oidc.CodeChallengeMethodS256,
+	}
+	payload, err := json.Marshal(want)
+	require.NoError(t, err)
+	got, err := unmarshalPushedAuthRequest(payload)
+	require.NoError(t, err)
+	assert.Equal(t, want, got)
+}
@@ -3,15 +3,18 @@ package oidc
 import (
 	"context"
 	"net/http"
+	"time"
 
 	"github.com/zitadel/logging"
 	"github.com/zitadel/oidc/v3/pkg/oidc"
 	"github.com/zitadel/oidc/v3/pkg/op"
 	"golang.org/x/exp/slog"
 
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	"github.com/zitadel/zitadel/internal/auth/repository"
 	"github.com/zitadel/zitadel/internal/command"
 	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/query"
 	"github.com/zitadel/zitadel/internal/telemetry/tracing"
 )
@@ -30,6 +33,16 @@ type Server struct {
 	hashAlg             crypto.HashAlgorithm
 	signingKeyAlgorithm string
 	assetAPIPrefix      func(ctx context.Context) string
+
+	eventstore        *eventstore.Eventstore
+	externalSecure    bool
+	backChannelLogout *BackChannelLogoutConfig
+	backChannelAuth   *BackChannelAuthConfig
+	// backChannelAuthPolls enforces the poll interval of the backchannel authentication
+	backChannelAuthPolls *backChannelAuthPolls
+
+	pushedAuthRequestLifetime time.Duration
+	dpop                      *dpop.Verifier
 }
 
 func endpoints(endpointConfig *EndpointConfig) op.Endpoints {
@@ -118,7 +131,24 @@ func (s *Server) VerifyAuthRequest(ctx context.Context, r *op.Request[oidc.AuthR
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.VerifyAuthRequest(ctx, r)
+	pushed := r.Form.Has(requestURIParam)
+	if pushed {
+		r.Data, err = s.pushedAuthRequest(ctx, r.Form.Get(requestURIParam), r.Data.ClientID)
+		if err != nil {
+			return nil, err
+		}
+	}
+	// pushed requests are stored after the request object was verified,
+	// the signature requirement of the client was already checked when it was pushed
+	signed := pushed || r.Data.RequestParam != ""
+	clientRequest, err := s.LegacyServer.VerifyAuthRequest(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkAuthRequestObject(clientRequest.Client, pushed, signed); err != nil {
+		return nil, err
+	}
+	return clientRequest, nil
 }
 
 func (s *Server) Authorize(ctx context.Context, r *op.ClientRequest[oidc.AuthRequest]) (_ *op.Redirect, err error) {
@@ -146,14 +176,30 @@ func (s *Server) CodeExchange(ctx context.Context, r *op.ClientRequest[oidc.Acce
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.CodeExchange(ctx, r)
+	ctx, err = s.verifyTokenRequestDPoP(ctx, r.Header, r.Client)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.LegacyServer.CodeExchange(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return dpopTokenResponse(ctx, resp), nil
 }
 
 func (s *Server) RefreshToken(ctx context.Context, r *op.ClientRequest[oidc.RefreshTokenRequest]) (_ *op.Response, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.RefreshToken(ctx, r)
+	ctx, err = s.verifyTokenRequestDPoP(ctx, r.Header, r.Client)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.LegacyServer.RefreshToken(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return dpopTokenResponse(ctx, resp), nil
 }
 
 func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGrantRequest]) (_ *op.Response, err error) {
@@ -163,32 +209,41 @@ func (s *Server) JWTProfile(ctx context.Context, r *op.Request[oidc.JWTProfileGr
 	return s.LegacyServer.JWTProfile(ctx, r)
 }
 
-func (s *Server) TokenExchange(ctx context.Context, r *op.ClientRequest[oidc.TokenExchangeRequest]) (_ *op.Response, err error) {
-	ctx, span := tracing.NewSpan(ctx)
-	defer func() { span.EndWithError(err) }()
-
-	return s.LegacyServer.TokenExchange(ctx, r)
-}
-
 func (s *Server) ClientCredentialsExchange(ctx context.Context, r *op.ClientRequest[oidc.ClientCredentialsRequest]) (_ *op.Response, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.ClientCredentialsExchange(ctx, r)
+	ctx, err = s.verifyTokenRequestDPoP(ctx, r.Header, r.Client)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.LegacyServer.ClientCredentialsExchange(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return dpopTokenResponse(ctx, resp), nil
 }
 
 func (s *Server) DeviceToken(ctx context.Context, r *op.ClientRequest[oidc.DeviceAccessTokenRequest]) (_ *op.Response, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.DeviceToken(ctx, r)
+	ctx, err = s.verifyTokenRequestDPoP(ctx, r.Header, r.Client)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.LegacyServer.DeviceToken(ctx, r)
+	if err != nil {
+		return nil, err
+	}
+	return dpopTokenResponse(ctx, resp), nil
 }
 
 func (s *Server) UserInfo(ctx context.Context, r *op.Request[oidc.UserInfoRequest]) (_ *op.Response, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
-	return s.LegacyServer.UserInfo(ctx, r)
+	return s.LegacyServer.UserInfo(s.withUserinfoDPoPRequest(ctx, r), r)
 }
 
 func (s *Server) Revocation(ctx context.Context, r *op.ClientRequest[oidc.RevocationRequest]) (_ *op.Response, err error) {
@@ -205,7 +260,63 @@ func (s *Server) EndSession(ctx context.Context, r *op.Request[oidc.EndSessionRe
 	return s.LegacyServer.EndSession(ctx, r)
 }
 
-func (s *Server) createDiscoveryConfig(ctx context.Context) *oidc.DiscoveryConfiguration {
+// discoveryConfiguration extends the [oidc.DiscoveryConfiguration] with the metadata
+// of the OpenID Connect Back-Channel and Front-Channel Logout, the Pushed Authorization Requests (RFC 9126),
+// the DPoP proofs (RFC 9449), the Dynamic Client Registration (RFC 7591)
+// and the Client-Initiated Backchannel Authentication (CIBA).
+type discoveryConfiguration struct {
+	*oidc.DiscoveryConfiguration
+	BackChannelLogoutSupported         bool     `json:"backchannel_logout_supported"`
+	BackChannelLogoutSessionSupported  bool     `json:"backchannel_logout_session_supported"`
+	FrontChannelLogoutSupported        bool     `json:"frontchannel_logout_supported"`
+	FrontChannelLogoutSessionSupported bool     `json:"frontchannel_logout_session_supported"`
+	PushedAuthorizationRequestEndpoint string   `json:"pushed_authorization_request_endpoint"`
+	RequirePushedAuthorizationRequests bool     `json:"require_pushed_authorization_requests"`
+	DPoPSigningAlgValuesSupported      []string `json:"dpop_signing_alg_values_supported"`
+	BackChannelAuthenticationEndpoint  string   `json:"backchannel_authentication_endpoint,omitempty"`
+	BackChannelTokenDeliveryModes      []string `json:"backchannel_token_delivery_modes_supported,omitempty"`
+	BackChannelUserCodeSupported       bool     `json:"backchannel_user_code_parameter_supported,omitempty"`
+}
+
+func (s *Server) createDiscoveryConfig(ctx context.Context) *discoveryConfiguration {
+	backChannelLogout := s.backChannelLogout != nil && s.backChannelLogout.Enabled
+	config := &discoveryConfiguration{
+		DiscoveryConfiguration:             s.createOIDCDiscoveryConfig(ctx),
+		BackChannelLogoutSupported:         backChannelLogout,
+		BackChannelLogoutSessionSupported:  backChannelLogout,
+		FrontChannelLogoutSupported:        true,
+		FrontChannelLogoutSessionSupported: true,
+		PushedAuthorizationRequestEndpoint: op.NewEndpoint(pushedAuthRequestEndpoint).Absolute(op.IssuerFromContext(ctx)),
+		DPoPSigningAlgValuesSupported:      dpop.SupportedAlgorithms(),
+	}
+	if s.backChannelAuthEnabled() {
+		config.BackChannelAuthenticationEndpoint = op.NewEndpoint(backChannelAuthEndpoint).Absolute(op.IssuerFromContext(ctx))
+		config.BackChannelTokenDeliveryModes = []string{backChannelTokenDeliveryModePoll, backChannelTokenDeliveryModePing}
+		config.GrantTypesSupported = append(config.GrantTypesSupported, grantTypeCIBA)
+	}
+	return config
+}
+
+// idTokenSigningAlgorithms returns the signing algorithm of the instance
+// and the RSA fallback for clients forcing RSA signed tokens.
+// The system default is returned if the algorithms can't be determined.
+func (s *Server) idTokenSigningAlgorithms(ctx context.Context) []string {
+	storage := s.Provider().Storage()
+	if storage == nil {
+		return []string{s.signingKeyAlgorithm}
+	}
+	algorithms, err := storage.SignatureAlgorithms(ctx)
+	if err != nil || len(algorithms) == 0 {
+		return []string{s.signingKeyAlgorithm}
+	}
+	supported := make([]string, len(algorithms))
+	for i, algorithm := range algorithms {
+		supported[i] = string(algorithm)
+	}
+	return supported
+}
+
+func (s *Server) createOIDCDiscoveryConfig(ctx context.Context) *oidc.DiscoveryConfiguration {
 	issuer := op.IssuerFromContext(ctx)
 	return &oidc.DiscoveryConfiguration{
 		Issuer:                                     issuer,
@@ -217,11 +328,12 @@ func (s *Server) createDiscoveryConfig(ctx context.Context) *oidc.DiscoveryConfi
 		EndSessionEndpoint:                         s.Endpoints().EndSession.Absolute(issuer),
 		JwksURI:                                    s.Endpoints().JwksURI.Absolute(issuer),
 		DeviceAuthorizationEndpoint:                s.Endpoints().DeviceAuthorization.Absolute(issuer),
+		RegistrationEndpoint:                       op.NewEndpoint(clientRegistrationEndpoint).Absolute(issuer),
 		ScopesSupported:                            op.Scopes(s.Provider()),
 		ResponseTypesSupported:                     op.ResponseTypes(s.Provider()),
 		GrantTypesSupported:                        op.GrantTypes(s.Provider()),
 		SubjectTypesSupported:                      op.SubjectTypes(s.Provider()),
-		IDTokenSigningAlgValuesSupported:           []string{s.signingKeyAlgorithm},
+		IDTokenSigningAlgValuesSupported:           s.idTokenSigningAlgorithms(ctx),
 		RequestObjectSigningAlgValuesSupported:     op.RequestObjectSigAlgorithms(s.Provider()),
 		TokenEndpointAuthMethodsSupported:          op.AuthMethodsTokenEndpoint(s.Provider()),
 		TokenEndpointAuthSigningAlgValuesSupported: op.TokenSigAlgorithms(s.Provider()),
@@ -14,6 +14,7 @@ func TestServer_createDiscoveryConfig(t *testing.T) {
 	type fields struct {
 		LegacyServer        *op.LegacyServer
 		signingKeyAlgorithm string
+		backChannelLogout   *BackChannelLogoutConfig
 	}
 	type args struct {
 		ctx context.Context
@@ -22,7 +23,7 @@ func TestServer_createDiscoveryConfig(t *testing.T) {
 		name   string
 		fields fields
 		args   args
-		want   *oidc.DiscoveryConfiguration
+		want   *discoveryConfiguration
 	}{
 		{
 			"config",
@@ -54,56 +55,65 @@ func TestServer_createDiscoveryConfig(t *testing.T) {
 					},
 				),
 				signingKeyAlgorithm: "RS256",
+				backChannelLogout:   &BackChannelLogoutConfig{Enabled: true},
 			},
 			args{
 				ctx: op.ContextWithIssuer(context.Background(), "https://issuer.com"),
 			},
-			&oidc.DiscoveryConfiguration{
-				Issuer:                                             "https://issuer.com",
-				AuthorizationEndpoint:                              "https://issuer.com/auth",
-				TokenEndpoint:                                      "https://issuer.com/token",
-				IntrospectionEndpoint:                              "https://issuer.com/introspect",
-				UserinfoEndpoint:                                   "https://issuer.com/userinfo",
-				RevocationEndpoint:                                 "https://issuer.com/revoke",
-				EndSessionEndpoint:                                 "https://issuer.com/logout",
-				DeviceAuthorizationEndpoint:                        "https://issuer.com/device",
-				CheckSessionIframe:                                 "",
-				JwksURI:                                            "https://issuer.com/keys",
-				RegistrationEndpoint:                               "",
-				ScopesSupported:                                    []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopePhone, oidc.ScopeAddress, oidc.ScopeOfflineAccess},
-				ResponseTypesSupported:                             []string{string(oidc.ResponseTypeCode), string(oidc.ResponseTypeIDTokenOnly), string(oidc.ResponseTypeIDToken)},
-				ResponseModesSupported:                             nil,
-				GrantTypesSupported:                                []oidc.GrantType{oidc.GrantTypeCode, oidc.GrantTypeImplicit, oidc.GrantTypeRefreshToken, oidc.GrantTypeBearer},
-				ACRValuesSupported:                                 nil,
-				SubjectTypesSupported:                              []string{"public"},
-				IDTokenSigningAlgValuesSupported:                   []string{"RS256"},
-				IDTokenEncryptionAlgValuesSupported:                nil,
-				IDTokenEncryptionEncValuesSupported:                nil,
-				UserinfoSigningAlgValuesSupported:                  nil,
-				UserinfoEncryptionAlgValuesSupported:               nil,
-				UserinfoEncryptionEncValuesSupported:               nil,
-				RequestObjectSigningAlgValuesSupported:             []string{"RS256"},
-				RequestObjectEncryptionAlgValuesSupported:          nil,
-				RequestObjectEncryptionEncValuesSupported:          nil,
-				TokenEndpointAuthMethodsSupported:                  []oidc.AuthMethod{oidc.AuthMethodNone, oidc.AuthMethodBasic, oidc.AuthMethodPost, oidc.AuthMethodPrivateKeyJWT},
-				TokenEndpointAuthSigningAlgValuesSupported:         []string{"RS256"},
-				RevocationEndpointAuthMethodsSupported:             []oidc.AuthMethod{oidc.AuthMethodNone, oidc.AuthMethodBasic, oidc.AuthMethodPost, oidc.AuthMethodPrivateKeyJWT},
-				RevocationEndpointAuthSigningAlgValuesSupported:    []string{"RS256"},
-				IntrospectionEndpointAuthMethodsSupported:          []oidc.AuthMethod{oidc.AuthMethodBasic, oidc.AuthMethodPrivateKeyJWT},
-				IntrospectionEndpointAuthSigningAlgValuesSupported: []string{"RS256"},
-				DisplayValuesSupported:                             nil,
-				ClaimTypesSupported:                                nil,
-				ClaimsSupported:                                    []string{"sub", "aud", "exp", "iat", "iss", "auth_time", "nonce", "acr", "amr", "c_hash", "at_hash", "act", "scopes", "client_id", "azp", "preferred_username", "name", "family_name", "given_name", "locale", "email", "email_verified", "phone_number", "phone_number_verified"},
-				ClaimsParameterSupported:                           false,
-				CodeChallengeMethodsSupported:                      []oidc.CodeChallengeMethod{"S256"},
-				ServiceDocumentation:                               "",
-				ClaimsLocalesSupported:                             nil,
-				UILocalesSupported:                                 []language.Tag{language.English, language.German},
-				RequestParameterSupported:                          true,
-				RequestURIParameterSupported:                       false,
-				RequireRequestURIRegistration:                      false,
-				OPPolicyURI:                                        "",
-				OPTermsOfServiceURI:                                "",
+			&discoveryConfiguration{
+				DiscoveryConfiguration: &oidc.DiscoveryConfiguration{
+					Issuer:                                             "https://issuer.com",
+					AuthorizationEndpoint:                              "https://issuer.com/auth",
+					TokenEndpoint:                                      "https://issuer.com/token",
+					IntrospectionEndpoint:                              "https://issuer.com/introspect",
+					UserinfoEndpoint:                                   "https://issuer.com/userinfo",
+					RevocationEndpoint:                                 "https://issuer.com/revoke",
+					EndSessionEndpoint:                                 "https://issuer.com/logout",
+					DeviceAuthorizationEndpoint:                        "https://issuer.com/device",
+					CheckSessionIframe:                                 "",
+					JwksURI:                                            "https://issuer.com/keys",
+					RegistrationEndpoint:                               "https://issuer.com/oauth/v2/register",
+					ScopesSupported:                                    []string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopePhone, oidc.ScopeAddress, oidc.ScopeOfflineAccess},
+					ResponseTypesSupported:                             []string{string(oidc.ResponseTypeCode), string(oidc.ResponseTypeIDTokenOnly), string(oidc.ResponseTypeIDToken)},
+					ResponseModesSupported:                             nil,
+					GrantTypesSupported:                                []oidc.GrantType{oidc.GrantTypeCode, oidc.GrantTypeImplicit, oidc.GrantTypeRefreshToken, oidc.GrantTypeBearer},
+					ACRValuesSupported:                                 nil,
+					SubjectTypesSupported:                              []string{"public"},
+					IDTokenSigningAlgValuesSupported:                   []string{"RS256"},
+					IDTokenEncryptionAlgValuesSupported:                nil,
+					IDTokenEncryptionEncValuesSupported:                nil,
+					UserinfoSigningAlgValuesSupported:                  nil,
+					UserinfoEncryptionAlgValuesSupported:               nil,
+					UserinfoEncryptionEncValuesSupported:               nil,
+					RequestObjectSigningAlgValuesSupported:             []string{"RS256"},
+					RequestObjectEncryptionAlgValuesSupported:          nil,
+					RequestObjectEncryptionEncValuesSupported:          nil,
+					TokenEndpointAuthMethodsSupported:                  []oidc.AuthMethod{oidc.AuthMethodNone, oidc.AuthMethodBasic, oidc.AuthMethodPost, oidc.AuthMethodPrivateKeyJWT},
+					TokenEndpointAuthSigningAlgValuesSupported:         []string{"RS256"},
+					RevocationEndpointAuthMethodsSupported:             []oidc.AuthMethod{oidc.AuthMethodNone, oidc.AuthMethodBasic, oidc.AuthMethodPost, oidc.AuthMethodPrivateKeyJWT},
+					RevocationEndpointAuthSigningAlgValuesSupported:    []string{"RS256"},
+					IntrospectionEndpointAuthMethodsSupported:          []oidc.AuthMethod{oidc.AuthMethodBasic, oidc.AuthMethodPrivateKeyJWT},
+					IntrospectionEndpointAuthSigningAlgValuesSupported: []string{"RS256"},
+					DisplayValuesSupported:                             nil,
+					ClaimTypesSupported:                                nil,
+					ClaimsSupported:                                    []string{"sub", "aud", "exp", "iat", "iss", "auth_time", "nonce", "acr", "amr", "c_hash", "at_hash", "act", "scopes", "client_id", "azp", "preferred_username", "name", "family_name", "given_name", "locale", "email", "email_verified", "phone_number", "phone_number_verified"},
+					ClaimsParameterSupported:                           false,
+					CodeChallengeMethodsSupported:                      []oidc.CodeChallengeMethod{"S256"},
+					ServiceDocumentation:                               "",
+					ClaimsLocalesSupported:                             nil,
+					UILocalesSupported:                                 []language.Tag{language.English, language.German},
+					RequestParameterSupported:                          true,
+					RequestURIParameterSupported:                       false,
+					RequireRequestURIRegistration:                      false,
+					OPPolicyURI:                                        "",
+					OPTermsOfServiceURI:                                "",
+				},
+				BackChannelLogoutSupported:         true,
+				BackChannelLogoutSessionSupported:  true,
+				FrontChannelLogoutSupported:        true,
+				FrontChannelLogoutSessionSupported: true,
+				PushedAuthorizationRequestEndpoint: "https://issuer.com/oauth/v2/par",
+				DPoPSigningAlgValuesSupported:      []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"},
 			},
 		},
 	}
@@ -112,6 +122,7 @@ func TestServer_createDiscoveryConfig(t *testing.T) {
 			s := &Server{
 				LegacyServer:        tt.fields.LegacyServer,
 				signingKeyAlgorithm: tt.fields.signingKeyAlgorithm,
+				backChannelLogout:   tt.fields.backChannelLogout,
 			}
 			assert.Equalf(t, tt.want, s.createDiscoveryConfig(tt.args.ctx), "createDiscoveryConfig(%v)", tt.args.ctx)
 		})
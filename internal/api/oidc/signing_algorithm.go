@@ -0,0 +1,95 @@
+package oidc
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/go-jose/go-jose/v3"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type clientSigningAlgorithmKey struct{}
+
+// clientSigningAlgorithm is stored in the context of every request by the [signingAlgorithmHandler].
+// The oidc library doesn't pass the client when it requests the signing key,
+// so [OPStorage.GetClientByClientID] records the requirements of the loaded client on it,
+// which are then respected by [OPStorage.SigningKey].
+type clientSigningAlgorithm struct {
+	forceRSA bool
+}
+
+func signingAlgorithmHandler(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		next.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), clientSigningAlgorithmKey{}, new(clientSigningAlgorithm))))
+	})
+}
+
+// setClientForceRSA marks the request to sign the tokens with an RSA key,
+// because the client is not able to verify other signatures.
+func setClientForceRSA(ctx context.Context, forceRSA bool) {
+	if signing, ok := ctx.Value(clientSigningAlgorithmKey{}).(*clientSigningAlgorithm); ok && forceRSA {
+		signing.forceRSA = true
+	}
+}
+
+// withClientForceRSA returns a context requiring the tokens to be signed with an RSA key,
+// used outside of http requests, e.g. for the back-channel logout.
+func withClientForceRSA(ctx context.Context, forceRSA bool) context.Context {
+	return context.WithValue(ctx, clientSigningAlgorithmKey{}, &clientSigningAlgorithm{forceRSA: forceRSA})
+}
+
+func clientForcesRSA(ctx context.Context) bool {
+	signing, ok := ctx.Value(clientSigningAlgorithmKey{}).(*clientSigningAlgorithm)
+	return ok && signing.forceRSA
+}
+
+// instanceSigningAlgorithm returns the signing algorithm configured in the OIDC settings of the instance
+// or the system default if none is set
+func (o *OPStorage) instanceSigningAlgorithm(ctx context.Context) (string, error) {
+	oidcSettings, err := o.query.OIDCSettingsByAggID(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil && !errors.IsNotFound(err) {
+		return "", err
+	}
+	if oidcSettings != nil && oidcSettings.SigningAlgorithm != "" {
+		return oidcSettings.SigningAlgorithm, nil
+	}
+	return o.signingKeyAlgorithm, nil
+}
+
+// signingAlgorithm returns the algorithm the tokens of the current request have to be signed with.
+// Clients forcing RSA signed tokens fall back to RS256 (or the system default if it is RSA based),
+// if the instance uses an elliptic curve or EdDSA algorithm.
+func (o *OPStorage) signingAlgorithm(ctx context.Context) (string, error) {
+	algorithm, err := o.instanceSigningAlgorithm(ctx)
+	if err != nil {
+		return "", err
+	}
+	if !clientForcesRSA(ctx) || crypto.IsRSASigningAlgorithm(algorithm) {
+		return algorithm, nil
+	}
+	return o.rsaSigningAlgorithm(), nil
+}
+
+func (o *OPStorage) rsaSigningAlgorithm() string {
+	if crypto.IsRSASigningAlgorithm(o.signingKeyAlgorithm) {
+		return o.signingKeyAlgorithm
+	}
+	return crypto.SigningAlgorithmRS256
+}
+
+// signatureAlgorithms returns the algorithm of the instance
+// and the RSA fallback for clients forcing RSA signed tokens
+func (o *OPStorage) signatureAlgorithms(ctx context.Context) ([]jose.SignatureAlgorithm, error) {
+	algorithm, err := o.instanceSigningAlgorithm(ctx)
+	if err != nil {
+		return nil, err
+	}
+	algorithms := []jose.SignatureAlgorithm{jose.SignatureAlgorithm(algorithm)}
+	if !crypto.IsRSASigningAlgorithm(algorithm) {
+		algorithms = append(algorithms, jose.SignatureAlgorithm(o.rsaSigningAlgorithm()))
+	}
+	return algorithms, nil
+}
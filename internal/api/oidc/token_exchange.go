@@ -0,0 +1,498 @@
+package oidc
+
+import (
+	"context"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+	"github.com/zitadel/oidc/v3/pkg/op"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// UserIDTokenType is the subject_token_type to request a token for a user by its id (impersonation).
+	// The actor_token is required for this type.
+	UserIDTokenType oidc.TokenType = "urn:zitadel:params:oauth:token-type:user_id"
+	// ClaimActor is the claim of the acting party as defined in RFC 8693, section 4.1
+	ClaimActor = "act"
+
+	tokenTypeNotApplicable = "N_A"
+)
+
+func init() {
+	oidc.AllTokenTypes = append(oidc.AllTokenTypes, UserIDTokenType)
+}
+
+// actor represents the `act` claim of a token issued by a token exchange with an actor token.
+// Nested actors of the actor token are kept as the chain of delegation.
+type actor struct {
+	Subject string `json:"sub"`
+	Actor   any    `json:"act,omitempty"`
+}
+
+// exchangeToken is a verified subject or actor token of a token exchange request
+type exchangeToken struct {
+	tokenType      oidc.TokenType
+	tokenIDOrToken string
+	userID         string
+	resourceOwner  string
+	audience       []string
+	scopes         []string
+	authTime       time.Time
+	expiration     time.Time
+	amr            []string
+	claims         map[string]any
+	dpopJKT        string
+}
+
+var _ op.TokenExchangeRequest = (*tokenExchangeRequest)(nil)
+
+// tokenExchangeRequest implements [op.TokenExchangeRequest] for the tokens to be created by the token exchange
+type tokenExchangeRequest struct {
+	subject            *exchangeToken
+	actor              *exchangeToken
+	clientID           string
+	audience           []string
+	scopes             []string
+	requestedTokenType oidc.TokenType
+}
+
+func (r *tokenExchangeRequest) GetAMR() []string {
+	return r.subject.amr
+}
+
+func (r *tokenExchangeRequest) GetAudience() []string {
+	return r.audience
+}
+
+// GetResourses returns nil as resource indicators are mapped to the audience
+func (r *tokenExchangeRequest) GetResourses() []string {
+	return nil
+}
+
+func (r *tokenExchangeRequest) GetAuthTime() time.Time {
+	return r.subject.authTime
+}
+
+func (r *tokenExchangeRequest) GetClientID() string {
+	return r.clientID
+}
+
+func (r *tokenExchangeRequest) GetScopes() []string {
+	return r.scopes
+}
+
+func (r *tokenExchangeRequest) GetSubject() string {
+	return r.subject.userID
+}
+
+func (r *tokenExchangeRequest) GetRequestedTokenType() oidc.TokenType {
+	return r.requestedTokenType
+}
+
+func (r *tokenExchangeRequest) GetExchangeSubject() string {
+	return r.subject.userID
+}
+
+func (r *tokenExchangeRequest) GetExchangeSubjectTokenType() oidc.TokenType {
+	return r.subject.tokenType
+}
+
+func (r *tokenExchangeRequest) GetExchangeSubjectTokenIDOrToken() string {
+	return r.subject.tokenIDOrToken
+}
+
+func (r *tokenExchangeRequest) GetExchangeSubjectTokenClaims() map[string]any {
+	return r.subject.claims
+}
+
+func (r *tokenExchangeRequest) GetExchangeActor() string {
+	if r.actor == nil {
+		return ""
+	}
+	return r.actor.userID
+}
+
+func (r *tokenExchangeRequest) GetExchangeActorTokenType() oidc.TokenType {
+	if r.actor == nil {
+		return ""
+	}
+	return r.actor.tokenType
+}
+
+func (r *tokenExchangeRequest) GetExchangeActorTokenIDOrToken() string {
+	if r.actor == nil {
+		return ""
+	}
+	return r.actor.tokenIDOrToken
+}
+
+func (r *tokenExchangeRequest) GetExchangeActorTokenClaims() map[string]any {
+	if r.actor == nil {
+		return nil
+	}
+	return r.actor.claims
+}
+
+func (r *tokenExchangeRequest) SetCurrentScopes(scopes []string) {
+	r.scopes = scopes
+}
+
+func (r *tokenExchangeRequest) SetRequestedTokenType(tokenType oidc.TokenType) {
+	r.requestedTokenType = tokenType
+}
+
+// SetSubject is a no-op, as the subject is always the user of the verified subject token
+func (r *tokenExchangeRequest) SetSubject(string) {}
+
+// lifetime caps the lifetime of the issued token at the expiration of the subject token,
+// so that exchanging a token (even repeatedly) can never extend its validity.
+func (r *tokenExchangeRequest) lifetime(lifetime time.Duration) time.Duration {
+	if r.subject.expiration.IsZero() {
+		return lifetime
+	}
+	if remaining := time.Until(r.subject.expiration); remaining < lifetime {
+		return remaining
+	}
+	return lifetime
+}
+
+// impersonation is true if the actor requested a token for the user without presenting a token of the user
+func (r *tokenExchangeRequest) impersonation() bool {
+	return r.subject.tokenType == UserIDTokenType
+}
+
+// actorFromTokenExchangeRequest returns the `act` claim for the tokens issued by the exchange or nil if there was no actor token
+func actorFromTokenExchangeRequest(request op.TokenExchangeRequest) *actor {
+	if request.GetExchangeActor() == "" {
+		return nil
+	}
+	return &actor{
+		Subject: request.GetExchangeActor(),
+		Actor:   request.GetExchangeActorTokenClaims()[ClaimActor],
+	}
+}
+
+// TokenExchange implements the token exchange grant (RFC 8693).
+// The subject_token can be an access_token, id_token or jwt (signed by a key of the user) of the user.
+// If an actor_token is provided, the issued tokens will contain the `act` claim with the actor (delegation).
+// Using the [UserIDTokenType] the actor can request a token for a user by its id (impersonation),
+// which requires the impersonation policy to allow it and the actor to be granted the impersonation permission.
+func (s *Server) TokenExchange(ctx context.Context, r *op.ClientRequest[oidc.TokenExchangeRequest]) (_ *op.Response, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	client, ok := r.Client.(*Client)
+	if !ok {
+		return nil, oidc.ErrInvalidClient().WithDescription("client must be an oidc application")
+	}
+	ctx, err = s.verifyTokenRequestDPoP(ctx, r.Header, client)
+	if err != nil {
+		return nil, err
+	}
+	requestedTokenType := r.Data.RequestedTokenType
+	switch requestedTokenType {
+	case "":
+		requestedTokenType = oidc.AccessTokenType
+	case oidc.AccessTokenType, oidc.JWTTokenType, oidc.IDTokenType:
+	default:
+		return nil, oidc.ErrInvalidRequest().WithDescription("requested_token_type is not supported")
+	}
+	if r.Data.SubjectTokenType == UserIDTokenType && r.Data.ActorToken == "" {
+		return nil, oidc.ErrInvalidRequest().WithDescription("actor_token is required for subject_token_type %s", UserIDTokenType)
+	}
+	subject, err := s.verifyExchangeToken(ctx, r.Data.SubjectToken, r.Data.SubjectTokenType, true)
+	if err != nil {
+		return nil, oidc.ErrInvalidGrant().WithDescription("subject_token is invalid").WithParent(err)
+	}
+	if err = verifyExchangeAudience(subject, client); err != nil {
+		return nil, oidc.ErrInvalidGrant().WithDescription("subject_token is not issued for the client").WithParent(err)
+	}
+	// a DPoP bound subject_token can only be exchanged with a proof of the same key
+	if subject.dpopJKT != "" && subject.dpopJKT != dpopJKTFromContext(ctx) {
+		return nil, oidc.ErrInvalidGrant().WithDescription("subject_token is bound to another DPoP key")
+	}
+	var actorToken *exchangeToken
+	if r.Data.ActorToken != "" {
+		if r.Data.ActorTokenType == "" {
+			return nil, oidc.ErrInvalidRequest().WithDescription("actor_token_type missing")
+		}
+		actorToken, err = s.verifyExchangeToken(ctx, r.Data.ActorToken, r.Data.ActorTokenType, false)
+		if err != nil {
+			return nil, oidc.ErrInvalidGrant().WithDescription("actor_token is invalid").WithParent(err)
+		}
+		if err = verifyExchangeAudience(actorToken, client); err != nil {
+			return nil, oidc.ErrInvalidGrant().WithDescription("actor_token is not issued for the client").WithParent(err)
+		}
+	}
+	audience, err := exchangeAudience(subject, client, append(r.Data.Audience, r.Data.Resource...))
+	if err != nil {
+		return nil, err
+	}
+	scopes, err := exchangeScopes(subject, client, r.Data.Scopes)
+	if err != nil {
+		return nil, err
+	}
+	request := &tokenExchangeRequest{
+		subject:            subject,
+		actor:              actorToken,
+		clientID:           client.GetID(),
+		audience:           audience,
+		scopes:             scopes,
+		requestedTokenType: requestedTokenType,
+	}
+	if err = s.addTokenExchange(ctx, request); err != nil {
+		return nil, err
+	}
+	resp, err := s.createExchangeTokens(ctx, request, client)
+	if err != nil {
+		return nil, err
+	}
+	return dpopTokenResponse(ctx, resp), nil
+}
+
+// verifyExchangeToken verifies the subject or actor token and returns the user it was issued for.
+// The [UserIDTokenType] is only accepted for the subject.
+func (s *Server) verifyExchangeToken(ctx context.Context, token string, tokenType oidc.TokenType, isSubject bool) (_ *exchangeToken, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	exchange := &exchangeToken{
+		tokenType: tokenType,
+	}
+	switch tokenType {
+	case oidc.AccessTokenType:
+		accessToken, err := s.verifyAccessToken(ctx, token)
+		if err != nil {
+			return nil, err
+		}
+		exchange.tokenIDOrToken = accessToken.tokenID
+		exchange.userID = accessToken.userID
+		exchange.audience = accessToken.audience
+		exchange.scopes = accessToken.scope
+		exchange.authTime = accessToken.tokenCreation
+		exchange.expiration = accessToken.tokenExpiration
+		exchange.dpopJKT = accessToken.dpopJKT
+	case oidc.IDTokenType:
+		claims, err := op.VerifyIDTokenHint[*oidc.IDTokenClaims](ctx, token, s.Provider().IDTokenHintVerifier(ctx))
+		if err != nil {
+			return nil, err
+		}
+		exchange.tokenIDOrToken = token
+		exchange.userID = claims.Subject
+		exchange.audience = claims.Audience
+		exchange.authTime = claims.GetAuthTime()
+		exchange.expiration = claims.GetExpiration()
+		exchange.amr = claims.AuthenticationMethodsReferences
+		exchange.claims = claims.Claims
+	case oidc.JWTTokenType:
+		verifier := op.NewJWTProfileVerifier(s.Provider().Storage(), op.IssuerFromContext(ctx), time.Hour, time.Second)
+		assertion, err := op.VerifyJWTAssertion(ctx, token, verifier)
+		if err != nil {
+			return nil, err
+		}
+		exchange.tokenIDOrToken = token
+		exchange.userID = assertion.Subject
+		exchange.scopes = assertion.Scopes
+		exchange.authTime = assertion.IssuedAt.AsTime()
+		exchange.expiration = assertion.ExpiresAt.AsTime()
+	case UserIDTokenType:
+		if !isSubject {
+			return nil, errors.ThrowInvalidArgument(nil, "OIDC-uu5Ei", "user_id token type is only allowed as subject_token_type")
+		}
+		exchange.tokenIDOrToken = token
+		exchange.userID = token
+		exchange.authTime = time.Now()
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "OIDC-Aes9i", "token type %s is not supported", tokenType)
+	}
+	user, err := s.query.GetUserByID(ctx, false, exchange.userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.State != domain.UserStateActive {
+		return nil, errors.ThrowPreconditionFailed(nil, "OIDC-Xah5o", "Errors.User.NotActive")
+	}
+	exchange.resourceOwner = user.ResourceOwner
+	return exchange, nil
+}
+
+// verifyExchangeAudience checks that a token, which was issued for an audience,
+// contains the calling client or its project, so a client can't exchange tokens of other clients.
+// Tokens without audience (JWT assertions of the user and user ids) are not issued to any client.
+func verifyExchangeAudience(token *exchangeToken, client *Client) error {
+	if len(token.audience) == 0 {
+		return nil
+	}
+	if slices.Contains(token.audience, client.GetID()) || slices.Contains(token.audience, client.app.ProjectID) {
+		return nil
+	}
+	return errors.ThrowPermissionDenied(nil, "OIDC-Ich7o", "token is not issued for the client")
+}
+
+// exchangeAudience returns the audience for the issued tokens.
+// If the subject token contains an audience, the requested audience must be a subset of it,
+// otherwise it must be the project or the id of the client.
+func exchangeAudience(subject *exchangeToken, client *Client, requested []string) ([]string, error) {
+	allowed := subject.audience
+	if len(allowed) == 0 {
+		allowed = []string{client.app.ProjectID, client.GetID()}
+	}
+	if len(requested) == 0 {
+		return allowed, nil
+	}
+	for _, aud := range requested {
+		if !slices.Contains(allowed, aud) {
+			return nil, oidc.ErrInvalidRequest().WithDescription("audience %s is not allowed", aud)
+		}
+	}
+	return requested, nil
+}
+
+// exchangeScopes returns the scopes for the issued tokens.
+// If the subject token contains scopes, the requested scopes must be a subset of them,
+// otherwise they must be allowed for the client.
+func exchangeScopes(subject *exchangeToken, client *Client, requested []string) ([]string, error) {
+	if len(subject.scopes) > 0 {
+		if len(requested) == 0 {
+			return subject.scopes, nil
+		}
+		for _, scope := range requested {
+			if !slices.Contains(subject.scopes, scope) {
+				return nil, oidc.ErrInvalidScope().WithDescription("scope %s is not allowed", scope)
+			}
+		}
+		return requested, nil
+	}
+	if len(requested) == 0 {
+		return []string{oidc.ScopeOpenID}, nil
+	}
+	for _, scope := range requested {
+		switch scope {
+		case oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopePhone, oidc.ScopeAddress:
+			continue
+		}
+		if strings.HasPrefix(scope, ScopeProjectRolePrefix) || client.IsScopeAllowed(scope) {
+			continue
+		}
+		return nil, oidc.ErrInvalidScope().WithDescription("scope %s is not allowed", scope)
+	}
+	return requested, nil
+}
+
+// addTokenExchange records the exchange on the subject.
+// For impersonation the command is executed in the context of the actor,
+// so the impersonation permission is checked for the actor.
+func (s *Server) addTokenExchange(ctx context.Context, request *tokenExchangeRequest) error {
+	cmdCtx := setContextUserSystem(ctx)
+	if request.impersonation() {
+		cmdCtx = authz.SetCtxData(ctx, authz.CtxData{
+			UserID: request.actor.userID,
+			OrgID:  request.actor.resourceOwner,
+		})
+	}
+	_, err := s.command.AddTokenExchange(cmdCtx, &command.TokenExchange{
+		UserID:             request.subject.userID,
+		ResourceOwner:      request.subject.resourceOwner,
+		ClientID:           request.clientID,
+		SubjectTokenType:   string(request.subject.tokenType),
+		RequestedTokenType: string(request.requestedTokenType),
+		Actor:              request.GetExchangeActor(),
+		Impersonation:      request.impersonation(),
+		Audience:           request.audience,
+		Scopes:             request.scopes,
+	})
+	if errors.IsPermissionDenied(err) || errors.IsNotFound(err) {
+		return oidc.ErrAccessDenied().WithParent(err)
+	}
+	if errors.IsErrorInvalidArgument(err) {
+		return oidc.ErrInvalidRequest().WithParent(err)
+	}
+	return err
+}
+
+// createExchangeTokens issues the requested token.
+// Access tokens with an actor are always issued as JWT, so the `act` claim is available to the resource server.
+func (s *Server) createExchangeTokens(ctx context.Context, request *tokenExchangeRequest, client *Client) (_ *op.Response, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if request.requestedTokenType == oidc.IDTokenType {
+		lifetime := request.lifetime(client.IDTokenLifetime())
+		idToken, err := op.CreateIDToken(ctx, op.IssuerFromContext(ctx), request, lifetime, "", "", s.Provider().Storage(), client)
+		if err != nil {
+			return nil, err
+		}
+		return op.NewResponse(&oidc.TokenExchangeResponse{
+			AccessToken:     idToken,
+			IssuedTokenType: oidc.IDTokenType,
+			TokenType:       tokenTypeNotApplicable,
+			ExpiresIn:       uint64(lifetime.Seconds()),
+			Scopes:          request.scopes,
+		}), nil
+	}
+	accessTokenType := client.AccessTokenType()
+	if request.actor != nil || request.requestedTokenType == oidc.JWTTokenType {
+		accessTokenType = op.AccessTokenTypeJWT
+	}
+	accessToken, _, validity, err := op.CreateAccessToken(ctx, request, accessTokenType, s.Provider(), client, "")
+	if err != nil {
+		return nil, err
+	}
+	return op.NewResponse(&oidc.TokenExchangeResponse{
+		AccessToken:     accessToken,
+		IssuedTokenType: request.requestedTokenType,
+		TokenType:       oidc.BearerToken,
+		ExpiresIn:       uint64(validity.Seconds()),
+		Scopes:          request.scopes,
+	}), nil
+}
+
+// ValidateTokenExchangeRequest is not called, as the token exchange is validated by [Server.TokenExchange].
+// It's implemented to satisfy [op.TokenExchangeStorage], which enables the grant in the discovery.
+func (o *OPStorage) ValidateTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) error {
+	return errors.ThrowUnimplemented(nil, "OIDC-ohJ6e", "token exchange is handled by the server")
+}
+
+// CreateTokenExchangeRequest is not called, as the token exchange is recorded by [Server.TokenExchange].
+func (o *OPStorage) CreateTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) error {
+	return errors.ThrowUnimplemented(nil, "OIDC-Ahc4i", "token exchange is handled by the server")
+}
+
+// GetPrivateClaimsFromTokenExchangeRequest returns the private claims of the requested scopes
+// and the `act` claim of the actor for the access token created by a token exchange.
+func (o *OPStorage) GetPrivateClaimsFromTokenExchangeRequest(ctx context.Context, request op.TokenExchangeRequest) (claims map[string]any, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	claims, err = o.GetPrivateClaimsFromScopes(ctx, request.GetSubject(), request.GetClientID(), request.GetScopes())
+	if err != nil {
+		return nil, err
+	}
+	if act := actorFromTokenExchangeRequest(request); act != nil {
+		claims = appendClaim(claims, ClaimActor, act)
+	}
+	return claims, nil
+}
+
+// SetUserinfoFromTokenExchangeRequest sets the userinfo of the requested scopes
+// and the `act` claim of the actor for the id_token created by a token exchange.
+func (o *OPStorage) SetUserinfoFromTokenExchangeRequest(ctx context.Context, userinfo *oidc.UserInfo, request op.TokenExchangeRequest) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if err = o.SetUserinfoFromScopes(ctx, userinfo, request.GetSubject(), request.GetClientID(), request.GetScopes()); err != nil {
+		return err
+	}
+	if act := actorFromTokenExchangeRequest(request); act != nil {
+		userinfo.AppendClaims(ClaimActor, act)
+	}
+	return nil
+}
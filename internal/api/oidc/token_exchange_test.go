@@ -0,0 +1,245 @@
+package oidc
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/oidc/v3/pkg/oidc"
+
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func testExchangeClient() *Client {
+	return &Client{
+		app: &query.App{
+			ProjectID: "project1",
+			OIDCConfig: &query.OIDCApp{
+				ClientID: "client1",
+			},
+		},
+	}
+}
+
+func Test_verifyExchangeAudience(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   *exchangeToken
+		wantErr bool
+	}{
+		{
+			name:  "client audience",
+			token: &exchangeToken{audience: []string{"client1"}},
+		},
+		{
+			name:  "project audience",
+			token: &exchangeToken{audience: []string{"project2", "project1"}},
+		},
+		{
+			name:  "no audience",
+			token: &exchangeToken{},
+		},
+		{
+			name:    "foreign audience, error",
+			token:   &exchangeToken{audience: []string{"project2", "client2"}},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := verifyExchangeAudience(tt.token, testExchangeClient())
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+func Test_tokenExchangeRequest_lifetime(t *testing.T) {
+	tests := []struct {
+		name       string
+		expiration time.Time
+		lifetime   time.Duration
+		want       time.Duration
+	}{
+		{
+			name:     "no expiration",
+			lifetime: time.Hour,
+			want:     time.Hour,
+		},
+		{
+			name:       "expiration after lifetime",
+			expiration: time.Now().Add(2 * time.Hour),
+			lifetime:   time.Hour,
+			want:       time.Hour,
+		},
+		{
+			name:       "expiration before lifetime",
+			expiration: time.Now().Add(time.Minute),
+			lifetime:   time.Hour,
+			want:       time.Minute,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := &tokenExchangeRequest{
+				subject: &exchangeToken{expiration: tt.expiration},
+			}
+			assert.InDelta(t, tt.want, request.lifetime(tt.lifetime), float64(time.Second))
+		})
+	}
+}
+
+func Test_tokenExchange_chained(t *testing.T) {
+	client := testExchangeClient()
+	expiration := time.Now().Add(10 * time.Minute)
+	subject := &exchangeToken{
+		audience:   []string{"project1", "client1", "project2"},
+		expiration: expiration,
+	}
+
+	// the first exchange narrows the audience to another project
+	audience, err := exchangeAudience(subject, client, []string{"project2"})
+	require.NoError(t, err)
+	first := &tokenExchangeRequest{subject: subject, audience: audience}
+	exchanged := &exchangeToken{
+		audience:   first.GetAudience(),
+		expiration: time.Now().Add(first.lifetime(12 * time.Hour)),
+	}
+	assert.False(t, exchanged.expiration.After(expiration.Add(time.Second)))
+
+	// the exchanged token is not issued for the client anymore
+	require.Error(t, verifyExchangeAudience(exchanged, client))
+
+	// a client of the narrowed audience can exchange it again, but not beyond the original expiration
+	other := testExchangeClient()
+	other.app.ProjectID = "project2"
+	require.NoError(t, verifyExchangeAudience(exchanged, other))
+	second := &tokenExchangeRequest{subject: exchanged}
+	assert.False(t, time.Now().Add(second.lifetime(12*time.Hour)).After(expiration.Add(time.Second)))
+}
+
+func Test_exchangeAudience(t *testing.T) {
+	tests := []struct {
+		name      string
+		subject   *exchangeToken
+		requested []string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:    "subject audience",
+			subject: &exchangeToken{audience: []string{"project1", "client1", "project2"}},
+			want:    []string{"project1", "client1", "project2"},
+		},
+		{
+			name:      "narrowed subject audience",
+			subject:   &exchangeToken{audience: []string{"project1", "client1", "project2"}},
+			requested: []string{"project2"},
+			want:      []string{"project2"},
+		},
+		{
+			name:      "widened subject audience, error",
+			subject:   &exchangeToken{audience: []string{"project1"}},
+			requested: []string{"project2"},
+			wantErr:   true,
+		},
+		{
+			name:    "client audience",
+			subject: &exchangeToken{},
+			want:    []string{"project1", "client1"},
+		},
+		{
+			name:      "other than client audience, error",
+			subject:   &exchangeToken{},
+			requested: []string{"project2"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := exchangeAudience(tt.subject, testExchangeClient(), tt.requested)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_exchangeScopes(t *testing.T) {
+	tests := []struct {
+		name      string
+		subject   *exchangeToken
+		requested []string
+		want      []string
+		wantErr   bool
+	}{
+		{
+			name:    "subject scopes",
+			subject: &exchangeToken{scopes: []string{oidc.ScopeOpenID, oidc.ScopeProfile}},
+			want:    []string{oidc.ScopeOpenID, oidc.ScopeProfile},
+		},
+		{
+			name:      "narrowed subject scopes",
+			subject:   &exchangeToken{scopes: []string{oidc.ScopeOpenID, oidc.ScopeProfile}},
+			requested: []string{oidc.ScopeOpenID},
+			want:      []string{oidc.ScopeOpenID},
+		},
+		{
+			name:      "widened subject scopes, error",
+			subject:   &exchangeToken{scopes: []string{oidc.ScopeOpenID}},
+			requested: []string{oidc.ScopeOpenID, oidc.ScopeEmail},
+			wantErr:   true,
+		},
+		{
+			name:    "default scopes",
+			subject: &exchangeToken{},
+			want:    []string{oidc.ScopeOpenID},
+		},
+		{
+			name:      "allowed scopes",
+			subject:   &exchangeToken{},
+			requested: []string{oidc.ScopeOpenID, ScopeResourceOwner, ScopeProjectRolePrefix + "role"},
+			want:      []string{oidc.ScopeOpenID, ScopeResourceOwner, ScopeProjectRolePrefix + "role"},
+		},
+		{
+			name:      "not allowed scope, error",
+			subject:   &exchangeToken{},
+			requested: []string{"custom"},
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := exchangeScopes(tt.subject, testExchangeClient(), tt.requested)
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func Test_actorFromTokenExchangeRequest(t *testing.T) {
+	request := &tokenExchangeRequest{
+		subject: &exchangeToken{userID: "user1"},
+	}
+	assert.Nil(t, actorFromTokenExchangeRequest(request))
+
+	request.actor = &exchangeToken{
+		userID: "actor1",
+		claims: map[string]any{ClaimActor: map[string]any{"sub": "actor2"}},
+	}
+	assert.Equal(t, &actor{
+		Subject: "actor1",
+		Actor:   map[string]any{"sub": "actor2"},
+	}, actorFromTokenExchangeRequest(request))
+}
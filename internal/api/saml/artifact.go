@@ -0,0 +1,427 @@
+package saml
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha1"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/hex"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/beevik/etree"
+	crewjam_saml "github.com/crewjam/saml"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/serviceprovider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	artifactTypeCode            uint16 = 0x0004
+	artifactSourceIDLength             = sha1.Size
+	artifactMessageHandleLength        = 20
+	artifactLength                     = 4 + artifactSourceIDLength + artifactMessageHandleLength
+
+	soapEnvelopeNamespace = "http://schemas.xmlsoap.org/soap/envelope/"
+	maxSOAPBodySize       = 1 << 20
+)
+
+// newArtifact creates a SAML 2.0 artifact (type code 0x0004) for the identity provider.
+// The message handle is returned separately as it references the stored response.
+func newArtifact(entityID string, endpointIndex uint16) (artifact string, messageHandle []byte, err error) {
+	messageHandle = make([]byte, artifactMessageHandleLength)
+	if _, err = rand.Read(messageHandle); err != nil {
+		return "", nil, err
+	}
+	sourceID := sha1.Sum([]byte(entityID))
+	data := make([]byte, 0, artifactLength)
+	data = binary.BigEndian.AppendUint16(data, artifactTypeCode)
+	data = binary.BigEndian.AppendUint16(data, endpointIndex)
+	data = append(data, sourceID[:]...)
+	data = append(data, messageHandle...)
+	return base64.StdEncoding.EncodeToString(data), messageHandle, nil
+}
+
+// parseArtifact returns the message handle of the artifact
+// after checking that it was issued by the identity provider with the entityID.
+func parseArtifact(artifact, entityID string) ([]byte, error) {
+	data, err := base64.StdEncoding.DecodeString(artifact)
+	if err != nil || len(data) != artifactLength {
+		return nil, errors.ThrowInvalidArgument(err, "SAML-ieY4o", "invalid artifact")
+	}
+	if binary.BigEndian.Uint16(data[:2]) != artifactTypeCode {
+		return nil, errors.ThrowInvalidArgument(nil, "SAML-Ohng8", "unsupported artifact type")
+	}
+	sourceID := sha1.Sum([]byte(entityID))
+	if !bytes.Equal(data[4:4+artifactSourceIDLength], sourceID[:]) {
+		return nil, errors.ThrowInvalidArgument(nil, "SAML-quo4E", "artifact was not issued by this identity provider")
+	}
+	return data[4+artifactSourceIDLength:], nil
+}
+
+func artifactSessionID(messageHandle []byte) string {
+	return hex.EncodeToString(messageHandle)
+}
+
+// metadataHandler extends the metadata of the library with the artifact resolution service
+func (p *Provider) metadataHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	metadata, err := p.GetMetadata(ctx)
+	if err != nil {
+		logging.WithError(err).Error("unable to get saml metadata")
+		http.Error(w, fmt.Errorf("error while getting metadata: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	if metadata.IDPSSODescriptor != nil {
+		metadata.Signature = nil
+		metadata.IDPSSODescriptor.ArtifactResolutionService = append(metadata.IDPSSODescriptor.ArtifactResolutionService,
+			md.IndexedEndpointType{
+				Binding:   crewjam_saml.SOAPBinding,
+				Location:  p.endpoints.artifactResolution.Absolute(provider.IssuerFromContext(ctx)),
+				Index:     "0",
+				IsDefault: "true",
+			},
+		)
+		if p.metadataConfig != nil && p.metadataConfig.SignatureAlgorithm != "" {
+			certAndKey, err := p.storage.GetMetadataSigningKey(ctx)
+			if err != nil {
+				http.Error(w, fmt.Errorf("error while getting metadata: %w", err).Error(), http.StatusInternalServerError)
+				return
+			}
+			signer, err := signature.GetSigner(certAndKey.Certificate, certAndKey.Key, p.metadataConfig.SignatureAlgorithm)
+			if err != nil {
+				http.Error(w, fmt.Errorf("error while getting metadata: %w", err).Error(), http.StatusInternalServerError)
+				return
+			}
+			if metadata.Signature, err = signature.Create(signer, metadata); err != nil {
+				http.Error(w, fmt.Errorf("error while getting metadata: %w", err).Error(), http.StatusInternalServerError)
+				return
+			}
+		}
+	}
+	if err = saml_xml.WriteXMLMarshalled(w, metadata); err != nil {
+		http.Error(w, fmt.Errorf("failed to respond with metadata").Error(), http.StatusInternalServerError)
+	}
+}
+
+// singleSignOnHandler accepts authentication requests, which ask for the response using the HTTP-Artifact binding.
+// All other requests are handled by the library.
+func (p *Provider) singleSignOnHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	form, err := parseRequestForm(r)
+	if err != nil || form.request == "" {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	authNRequest, err := saml_xml.DecodeAuthNRequest(form.encoding, form.request)
+	// requests without ID are rejected by the library, as they're reserved for IdP-initiated SSO
+	if err != nil || authNRequest.Issuer == nil || authNRequest.Id == "" {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	sp, err := p.storage.GetEntityByID(ctx, authNRequest.Issuer.Text)
+	if err != nil {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	acsURL, binding := assertionConsumerService(sp.Metadata, authNRequest.ProtocolBinding)
+	if binding != crewjam_saml.HTTPArtifactBinding {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	app, err := p.storage.query.AppByID(ctx, sp.ID)
+	if err != nil || app.SAMLConfig == nil || !app.SAMLConfig.ArtifactBinding {
+		http.Error(w, "HTTP-Artifact binding is not enabled for the service provider", http.StatusBadRequest)
+		return
+	}
+	if p.wantRequestsSigned || sp.Metadata.SPSSODescriptor.AuthnRequestsSigned == "true" || form.sig != "" || authNRequest.Signature != nil {
+		if err = verifyRequestSignature(sp, form); err != nil {
+			http.Error(w, fmt.Errorf("failed to verify signature: %w", err).Error(), http.StatusBadRequest)
+			return
+		}
+	}
+	authRequest, err := p.storage.CreateAuthRequest(ctx, authNRequest, acsURL, binding, form.relayState, sp.ID)
+	if err != nil {
+		logging.WithError(err).Error("unable to create saml auth request")
+		http.Error(w, fmt.Errorf("failed to persist request: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, sp.LoginURL(authRequest.GetID()), http.StatusSeeOther)
+}
+
+// assertionConsumerService selects the endpoint the response is sent to the same way as the library does:
+// the endpoint of the requested binding, the default endpoint or the one with the lowest index
+func assertionConsumerService(metadata *md.EntityDescriptorType, requestedBinding string) (location, binding string) {
+	if metadata == nil || metadata.SPSSODescriptor == nil {
+		return "", ""
+	}
+	services := metadata.SPSSODescriptor.AssertionConsumerService
+	for _, acs := range services {
+		if acs.Binding == requestedBinding {
+			return acs.Location, acs.Binding
+		}
+	}
+	for _, acs := range services {
+		if acs.IsDefault == "true" {
+			return acs.Location, acs.Binding
+		}
+	}
+	index := 0
+	for _, acs := range services {
+		i, _ := strconv.Atoi(acs.Index)
+		if index == 0 || i < index {
+			location, binding, index = acs.Location, acs.Binding, i
+		}
+	}
+	return location, binding
+}
+
+// sendArtifact stores the response, which can be resolved once by the service provider, and sends the artifact referencing it.
+func (p *Provider) sendArtifact(w http.ResponseWriter, r *http.Request, authRequest *AuthRequest, app *query.App) error {
+	ctx := r.Context()
+	artifact, messageHandle, err := newArtifact(p.entityID(ctx), 0)
+	if err != nil {
+		return err
+	}
+	sessionID := artifactSessionID(messageHandle)
+	response, err := p.makeResponse(ctx, authRequest, app, sessionID)
+	if err != nil {
+		return err
+	}
+	doc := etree.NewDocument()
+	doc.SetRoot(response.element)
+	data, err := doc.WriteToBytes()
+	if err != nil {
+		return err
+	}
+	encrypted, err := crypto.Encrypt(data, p.storage.encAlg)
+	if err != nil {
+		return err
+	}
+	if _, err = p.addSession(ctx, authRequest, app, sessionID, response.nameID.Value, response.nameID.Format, encrypted); err != nil {
+		return err
+	}
+	location, err := url.Parse(authRequest.GetAccessConsumerServiceURL())
+	if err != nil {
+		return err
+	}
+	query := location.Query()
+	query.Set("SAMLart", artifact)
+	if relayState := authRequest.GetRelayState(); relayState != "" {
+		query.Set("RelayState", relayState)
+	}
+	location.RawQuery = query.Encode()
+	http.Redirect(w, r, location.String(), http.StatusFound)
+	return nil
+}
+
+// artifactResolutionHandler returns the response referenced by the artifact using the SOAP binding.
+func (p *Provider) artifactResolutionHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if r.Method != http.MethodPost {
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	data, err := io.ReadAll(http.MaxBytesReader(w, r.Body, maxSOAPBodySize))
+	if err != nil {
+		http.Error(w, fmt.Errorf("failed to read request: %w", err).Error(), http.StatusBadRequest)
+		return
+	}
+	resolveEl, err := soapMessage(data, "ArtifactResolve")
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	resolve := new(crewjam_saml.ArtifactResolve)
+	if err := unmarshalElement(resolveEl, resolve); err != nil {
+		http.Error(w, fmt.Errorf("failed to parse ArtifactResolve: %w", err).Error(), http.StatusBadRequest)
+		return
+	}
+
+	artifactResponse := &crewjam_saml.ArtifactResponse{
+		ID:           provider.NewID(),
+		InResponseTo: resolve.ID,
+		Version:      "2.0",
+		IssueInstant: time.Now().UTC(),
+		Issuer: &crewjam_saml.Issuer{
+			Format: nameIDFormatEntity,
+			Value:  p.entityID(ctx),
+		},
+		Status: crewjam_saml.Status{
+			StatusCode: crewjam_saml.StatusCode{Value: crewjam_saml.StatusSuccess},
+		},
+	}
+	response, err := p.resolveArtifact(ctx, resolveEl, resolve)
+	if err != nil {
+		logging.WithError(err).Warn("unable to resolve saml artifact")
+		artifactResponse.Status.StatusCode.Value = crewjam_saml.StatusRequester
+	}
+	responseEl := artifactResponse.Element()
+	// the library always adds an (empty) response, which is replaced by the stored one
+	responseEl.RemoveChildAt(len(responseEl.Child) - 1)
+	if response != nil {
+		responseEl.AddChild(response)
+	}
+	signed, err := p.signEnveloped(ctx, responseEl)
+	if err != nil {
+		logging.WithError(err).Error("unable to sign saml artifact response")
+		http.Error(w, fmt.Errorf("failed to sign response: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	if err = writeSOAP(w, signed); err != nil {
+		logging.WithError(err).Warn("unable to write saml artifact response")
+	}
+}
+
+// resolveArtifact checks the signature of the ArtifactResolve message and returns the referenced response,
+// which can only be resolved once and only by the service provider it was issued to.
+func (p *Provider) resolveArtifact(ctx context.Context, resolveEl *etree.Element, resolve *crewjam_saml.ArtifactResolve) (*etree.Element, error) {
+	if resolve.Issuer == nil {
+		return nil, errors.ThrowInvalidArgument(nil, "SAML-Ahng4", "issuer is missing in request")
+	}
+	sp, err := p.storage.GetEntityByID(ctx, resolve.Issuer.Value)
+	if err != nil {
+		return nil, err
+	}
+	if err = verifyElementSignature(sp, resolveEl); err != nil {
+		return nil, err
+	}
+	messageHandle, err := parseArtifact(resolve.Artifact, p.entityID(ctx))
+	if err != nil {
+		return nil, err
+	}
+	encrypted, err := p.storage.command.ResolveSAMLArtifact(setSAMLCtx(ctx), artifactSessionID(messageHandle), sp.GetEntityID())
+	if err != nil {
+		return nil, err
+	}
+	data, err := crypto.Decrypt(encrypted, p.storage.encAlg)
+	if err != nil {
+		return nil, err
+	}
+	doc := etree.NewDocument()
+	if err = doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+	return doc.Root(), nil
+}
+
+type requestForm struct {
+	request    string
+	encoding   string
+	relayState string
+	sigAlg     string
+	sig        string
+	binding    string
+}
+
+// parseRequestForm parses the SAML request sent using the HTTP-Redirect or HTTP-POST binding
+func parseRequestForm(r *http.Request) (*requestForm, error) {
+	if err := r.ParseForm(); err != nil {
+		return nil, err
+	}
+	binding := crewjam_saml.HTTPPostBinding
+	if _, ok := r.URL.Query()["SAMLRequest"]; ok {
+		binding = crewjam_saml.HTTPRedirectBinding
+	}
+	return &requestForm{
+		request:    r.FormValue("SAMLRequest"),
+		encoding:   r.FormValue("SAMLEncoding"),
+		relayState: r.FormValue("RelayState"),
+		sigAlg:     r.FormValue("SigAlg"),
+		sig:        r.FormValue("Signature"),
+		binding:    binding,
+	}, nil
+}
+
+// verifyRequestSignature verifies the signature of the query (HTTP-Redirect binding)
+// or the signature contained in the request (HTTP-POST binding)
+func verifyRequestSignature(sp *serviceprovider.ServiceProvider, form *requestForm) error {
+	if form.binding == crewjam_saml.HTTPRedirectBinding {
+		if form.sig == "" || form.sigAlg == "" {
+			return errors.ThrowInvalidArgument(nil, "SAML-eiB3o", "signature is missing in request")
+		}
+		return sp.ValidateRedirectSignature(form.request, form.relayState, form.sigAlg, form.sig)
+	}
+	data, err := base64.StdEncoding.DecodeString(form.request)
+	if err != nil {
+		return err
+	}
+	doc := etree.NewDocument()
+	if err = doc.ReadFromBytes(data); err != nil {
+		return err
+	}
+	if doc.Root() == nil {
+		return errors.ThrowInvalidArgument(nil, "SAML-Oe0ch", "request is empty")
+	}
+	return verifyElementSignature(sp, doc.Root())
+}
+
+// verifyElementSignature verifies the enveloped signature of the element with the certificates of the service provider
+func verifyElementSignature(sp *serviceprovider.ServiceProvider, el *etree.Element) error {
+	if el.SelectElement("Signature") == nil {
+		return errors.ThrowInvalidArgument(nil, "SAML-yoo5J", "signature is missing in request")
+	}
+	if sp.Metadata == nil || sp.Metadata.SPSSODescriptor == nil {
+		return errors.ThrowPreconditionFailed(nil, "SAML-Pei7u", "no certificate known from the service provider")
+	}
+	certs, err := signature.ParseCertificates(saml_xml.GetCertsFromKeyDescriptors(sp.Metadata.SPSSODescriptor.KeyDescriptor))
+	if err != nil {
+		return err
+	}
+	return signature.ValidatePost(certs, el)
+}
+
+func unmarshalElement(el *etree.Element, v interface{}) error {
+	doc := etree.NewDocument()
+	doc.SetRoot(el.Copy())
+	data, err := doc.WriteToBytes()
+	if err != nil {
+		return err
+	}
+	return xml.Unmarshal(data, v)
+}
+
+// soapEnvelope wraps the SAML message into a SOAP envelope
+func soapEnvelope(message *etree.Element) *etree.Document {
+	envelope := etree.NewElement("soapenv:Envelope")
+	envelope.CreateAttr("xmlns:soapenv", soapEnvelopeNamespace)
+	body := envelope.CreateElement("soapenv:Body")
+	body.AddChild(message)
+	doc := etree.NewDocument()
+	doc.CreateProcInst("xml", `version="1.0" encoding="UTF-8"`)
+	doc.SetRoot(envelope)
+	return doc
+}
+
+func writeSOAP(w http.ResponseWriter, message *etree.Element) error {
+	w.Header().Set("Content-Type", "text/xml; charset=utf-8")
+	_, err := soapEnvelope(message).WriteTo(w)
+	return err
+}
+
+// soapMessage returns the SAML message of the SOAP envelope with the tag name
+func soapMessage(data []byte, tag string) (*etree.Element, error) {
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, err
+	}
+	el := doc.FindElement("//" + tag)
+	if el == nil {
+		return nil, errors.ThrowInvalidArgument(nil, "SAML-Eer4a", "no "+tag+" in SOAP message")
+	}
+	return el, nil
+}
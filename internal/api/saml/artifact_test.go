@@ -0,0 +1,105 @@
+package saml
+
+import (
+	"encoding/base64"
+	"testing"
+
+	crewjam_saml "github.com/crewjam/saml"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+)
+
+func Test_newArtifact_parseArtifact(t *testing.T) {
+	entityID := "https://issuer.com/saml/v2/metadata"
+	artifact, messageHandle, err := newArtifact(entityID, 0)
+	require.NoError(t, err)
+	assert.Len(t, messageHandle, artifactMessageHandleLength)
+
+	data, err := base64.StdEncoding.DecodeString(artifact)
+	require.NoError(t, err)
+	assert.Len(t, data, artifactLength)
+	assert.Equal(t, []byte{0x00, 0x04, 0x00, 0x00}, data[:4])
+
+	got, err := parseArtifact(artifact, entityID)
+	require.NoError(t, err)
+	assert.Equal(t, messageHandle, got)
+
+	_, err = parseArtifact(artifact, "https://other.com/saml/v2/metadata")
+	assert.Error(t, err)
+	_, err = parseArtifact(base64.StdEncoding.EncodeToString([]byte("invalid")), entityID)
+	assert.Error(t, err)
+}
+
+func Test_assertionConsumerService(t *testing.T) {
+	metadata := &md.EntityDescriptorType{
+		SPSSODescriptor: &md.SPSSODescriptorType{
+			AssertionConsumerService: []md.IndexedEndpointType{
+				{Index: "1", Binding: crewjam_saml.HTTPPostBinding, Location: "https://sp.com/post"},
+				{Index: "2", Binding: crewjam_saml.HTTPArtifactBinding, Location: "https://sp.com/artifact", IsDefault: "true"},
+			},
+		},
+	}
+	tests := []struct {
+		name         string
+		binding      string
+		wantLocation string
+		wantBinding  string
+	}{
+		{
+			"requested binding",
+			crewjam_saml.HTTPPostBinding,
+			"https://sp.com/post",
+			crewjam_saml.HTTPPostBinding,
+		},
+		{
+			"default binding",
+			"",
+			"https://sp.com/artifact",
+			crewjam_saml.HTTPArtifactBinding,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			location, binding := assertionConsumerService(metadata, tt.binding)
+			assert.Equal(t, tt.wantLocation, location)
+			assert.Equal(t, tt.wantBinding, binding)
+		})
+	}
+}
+
+func Test_encryptionDescriptor(t *testing.T) {
+	cert := func(data string) crewjam_saml.KeyInfo {
+		info := crewjam_saml.KeyInfo{}
+		if data != "" {
+			info.X509Data.X509Certificates = []crewjam_saml.X509Certificate{{Data: data}}
+		}
+		return info
+	}
+	metadata := &crewjam_saml.EntityDescriptor{
+		SPSSODescriptors: []crewjam_saml.SPSSODescriptor{{
+			SSODescriptor: crewjam_saml.SSODescriptor{
+				RoleDescriptor: crewjam_saml.RoleDescriptor{
+					KeyDescriptors: []crewjam_saml.KeyDescriptor{
+						{Use: "signing", KeyInfo: cert("signing")},
+						{Use: "encryption", KeyInfo: cert("")},
+						{Use: "encryption", KeyInfo: cert("encryption")},
+					},
+				},
+			},
+		}},
+	}
+
+	descriptor, err := encryptionDescriptor(metadata, false)
+	require.NoError(t, err)
+	assert.Empty(t, descriptor.KeyDescriptors)
+
+	descriptor, err = encryptionDescriptor(metadata, true)
+	require.NoError(t, err)
+	require.Len(t, descriptor.KeyDescriptors, 1)
+	assert.Equal(t, "encryption", descriptor.KeyDescriptors[0].KeyInfo.X509Data.X509Certificates[0].Data)
+
+	metadata.SPSSODescriptors[0].KeyDescriptors = metadata.SPSSODescriptors[0].KeyDescriptors[:2]
+	_, err = encryptionDescriptor(metadata, true)
+	assert.Error(t, err)
+}
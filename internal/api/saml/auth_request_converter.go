@@ -42,6 +42,12 @@ func (a *AuthRequest) saml() *domain.AuthRequestSAML {
 func (a *AuthRequest) GetAuthRequestID() string {
 	return a.saml().ID
 }
+
+// isIdPInitiated returns true if the request was not sent by the service provider,
+// but started by the identity provider for an unsolicited response
+func (a *AuthRequest) isIdPInitiated() bool {
+	return a.GetAuthRequestID() == ""
+}
 func (a *AuthRequest) GetBindingType() string {
 	return a.saml().BindingType
 }
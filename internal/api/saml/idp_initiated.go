@@ -0,0 +1,89 @@
+package saml
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	crewjam_saml "github.com/crewjam/saml"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/xml/saml"
+	"github.com/zitadel/saml/pkg/provider/xml/samlp"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	idpInitiatedAppIDParam      = "app_id"
+	idpInitiatedRelayStateParam = "RelayState"
+)
+
+// IdPInitiatedURL returns the URL starting an unsolicited response to the SAML app on the current issuer.
+func (p *Provider) IdPInitiatedURL() func(ctx context.Context, appID string) string {
+	return func(ctx context.Context, appID string) string {
+		return provider.IssuerFromContext(ctx) + idpInitiatedEndpoint + "?" + idpInitiatedAppIDParam + "=" + url.QueryEscape(appID)
+	}
+}
+
+// idpInitiatedHandler starts the IdP-initiated SSO for service providers, which are not able to send an authentication request.
+// An auth request without request ID is created for the default assertion consumer service of the app
+// and the user is sent to the login, which checks the session of the user agent.
+// After the login, the callbackHandler issues the unsolicited response if the user is granted the project of the app.
+func (p *Provider) idpInitiatedHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	appID := r.URL.Query().Get(idpInitiatedAppIDParam)
+	if appID == "" {
+		http.Error(w, idpInitiatedAppIDParam+" is missing", http.StatusBadRequest)
+		return
+	}
+	app, err := p.storage.query.AppByID(ctx, appID)
+	if err != nil || app.SAMLConfig == nil || app.State != domain.AppStateActive {
+		http.Error(w, "saml app not found", http.StatusNotFound)
+		return
+	}
+	sp, err := p.storage.GetEntityByID(ctx, app.SAMLConfig.EntityID)
+	if err != nil {
+		http.Error(w, "saml app not found", http.StatusNotFound)
+		return
+	}
+	acsURL, binding := assertionConsumerService(sp.Metadata, "")
+	switch binding {
+	case crewjam_saml.HTTPPostBinding, crewjam_saml.HTTPRedirectBinding:
+	case crewjam_saml.HTTPArtifactBinding:
+		if !app.SAMLConfig.ArtifactBinding {
+			http.Error(w, "HTTP-Artifact binding is not enabled for the service provider", http.StatusBadRequest)
+			return
+		}
+	default:
+		http.Error(w, "no supported assertion consumer service of the service provider found", http.StatusBadRequest)
+		return
+	}
+	authNRequest := &samlp.AuthnRequestType{
+		Issuer: &saml.NameIDType{Text: app.SAMLConfig.EntityID},
+	}
+	authRequest, err := p.storage.CreateAuthRequest(ctx, authNRequest, acsURL, binding, r.URL.Query().Get(idpInitiatedRelayStateParam), app.ID)
+	if err != nil {
+		logging.WithError(err).Error("unable to create saml auth request")
+		http.Error(w, "failed to persist request", http.StatusInternalServerError)
+		return
+	}
+	http.Redirect(w, r, sp.LoginURL(authRequest.GetID()), http.StatusSeeOther)
+}
+
+// checkUserGrant returns an error if the user has no active grant on the project of the app.
+// As an unsolicited response is not requested by the service provider,
+// it's only issued to users who are explicitly granted access to the app.
+func (p *Provider) checkUserGrant(ctx context.Context, userID, appID string) error {
+	grants, err := p.storage.getGrants(ctx, userID, appID)
+	if err != nil {
+		return err
+	}
+	for _, grant := range grants.UserGrants {
+		if grant.State == domain.UserGrantStateActive {
+			return nil
+		}
+	}
+	return errors.ThrowPermissionDenied(nil, "SAML-Quo2e", "Errors.User.GrantRequired")
+}
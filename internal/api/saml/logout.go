@@ -0,0 +1,241 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"context"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"time"
+
+	crewjam_saml "github.com/crewjam/saml"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/xml/md"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/samlsession"
+)
+
+type eventFilter interface {
+	Filter(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder) ([]eventstore.Event, error)
+}
+
+// singleLogoutHandler handles the logout requests of service providers with single logout enabled:
+// the user agent is signed out, which will notify all other service providers of the session.
+// All other logout requests are handled by the library.
+func (p *Provider) singleLogoutHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	form, err := parseRequestForm(r)
+	if err != nil || form.request == "" {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	logoutRequest, err := decodeLogoutRequest(form)
+	if err != nil || logoutRequest.Issuer == nil {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	sp, err := p.storage.GetEntityByID(ctx, logoutRequest.Issuer.Value)
+	if err != nil {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	app, err := p.storage.query.AppByID(ctx, sp.ID)
+	if err != nil || app.SAMLConfig == nil || !app.SAMLConfig.SingleLogout {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	location, binding := singleLogoutService(sp.Metadata, form.binding)
+	if location == "" {
+		http.Error(w, "no single logout service of the service provider found", http.StatusBadRequest)
+		return
+	}
+
+	status := crewjam_saml.StatusSuccess
+	if err = verifyLogoutRequest(logoutRequest, func() error { return verifyRequestSignature(sp, form) }); err != nil {
+		logging.WithError(err).Warn("invalid saml logout request")
+		status = crewjam_saml.StatusRequester
+	} else if err = p.terminateUserAgent(ctx, app.ID); err != nil {
+		logging.WithError(err).Error("unable to terminate saml sessions")
+		status = crewjam_saml.StatusResponder
+	}
+
+	response := (&crewjam_saml.LogoutResponse{
+		ID:           provider.NewID(),
+		InResponseTo: logoutRequest.ID,
+		Version:      "2.0",
+		IssueInstant: time.Now().UTC(),
+		Destination:  location,
+		Issuer: &crewjam_saml.Issuer{
+			Format: nameIDFormatEntity,
+			Value:  p.entityID(ctx),
+		},
+		Status: crewjam_saml.Status{
+			StatusCode: crewjam_saml.StatusCode{Value: status},
+		},
+	}).Element()
+	// messages of the HTTP-Redirect binding are signed as part of the query
+	if binding == crewjam_saml.HTTPPostBinding {
+		if response, err = p.signEnveloped(ctx, response); err != nil {
+			http.Error(w, fmt.Errorf("failed to sign response: %w", err).Error(), http.StatusInternalServerError)
+			return
+		}
+	}
+	if err = p.sendMessage(ctx, w, r, binding, location, "SAMLResponse", form.relayState, response); err != nil {
+		logging.WithError(err).Error("unable to send saml logout response")
+		http.Error(w, fmt.Errorf("failed to send response: %w", err).Error(), http.StatusInternalServerError)
+	}
+}
+
+func decodeLogoutRequest(form *requestForm) (*crewjam_saml.LogoutRequest, error) {
+	data, err := base64.StdEncoding.DecodeString(form.request)
+	if err != nil {
+		return nil, err
+	}
+	if form.binding == crewjam_saml.HTTPRedirectBinding {
+		if data, err = io.ReadAll(flate.NewReader(bytes.NewReader(data))); err != nil {
+			return nil, err
+		}
+	}
+	logoutRequest := new(crewjam_saml.LogoutRequest)
+	if err = xml.Unmarshal(data, logoutRequest); err != nil {
+		return nil, err
+	}
+	return logoutRequest, nil
+}
+
+// verifyLogoutRequest checks the content of the logout request,
+// which always needs to be signed by the service provider
+func verifyLogoutRequest(logoutRequest *crewjam_saml.LogoutRequest, verifySignature func() error) error {
+	if logoutRequest.ID == "" {
+		return errors.ThrowInvalidArgument(nil, "SAML-ohH8u", "ID is missing in request")
+	}
+	if logoutRequest.NotOnOrAfter != nil && time.Now().After(*logoutRequest.NotOnOrAfter) {
+		return errors.ThrowInvalidArgument(nil, "SAML-Thah3", "request is expired")
+	}
+	return verifySignature()
+}
+
+// singleLogoutService returns the single logout endpoint of the service provider for the binding.
+// If the service provider doesn't support the binding, any other browser binding is used.
+func singleLogoutService(metadata *md.EntityDescriptorType, requestedBinding string) (location, binding string) {
+	if metadata == nil || metadata.SPSSODescriptor == nil {
+		return "", ""
+	}
+	var fallback md.EndpointType
+	for _, service := range metadata.SPSSODescriptor.SingleLogoutService {
+		if service.Binding != crewjam_saml.HTTPPostBinding && service.Binding != crewjam_saml.HTTPRedirectBinding {
+			continue
+		}
+		if service.Binding == requestedBinding {
+			return endpointResponseLocation(service), service.Binding
+		}
+		if fallback.Binding == "" {
+			fallback = service
+		}
+	}
+	if fallback.Binding == "" {
+		return "", ""
+	}
+	return endpointResponseLocation(fallback), fallback.Binding
+}
+
+func endpointResponseLocation(endpoint md.EndpointType) string {
+	if endpoint.ResponseLocation != "" {
+		return endpoint.ResponseLocation
+	}
+	return endpoint.Location
+}
+
+// terminateUserAgent terminates the sessions of the requesting service provider on the user agent
+// and signs out all users of the user agent, which notifies all other service providers of the user agent.
+func (p *Provider) terminateUserAgent(ctx context.Context, appID string) error {
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		return errors.ThrowPreconditionFailed(nil, "SAML-ooJ0a", "no user agent id")
+	}
+	sessions, err := activeSessions(ctx, p.storage.eventstore, userAgentID)
+	if err != nil {
+		return err
+	}
+	for _, session := range sessions {
+		if session.AppID != appID {
+			continue
+		}
+		if _, err = p.storage.command.TerminateSAMLSession(setSAMLCtx(ctx), session.Aggregate().ID); err != nil {
+			return err
+		}
+	}
+	userIDs, err := p.storage.repo.UserSessionUserIDsByAgentID(ctx, userAgentID)
+	if err != nil {
+		return err
+	}
+	if len(userIDs) == 0 {
+		return nil
+	}
+	return p.storage.command.HumansSignOut(authz.SetCtxData(ctx, authz.CtxData{UserID: userIDs[0]}), userAgentID, userIDs)
+}
+
+// activeSessions returns the sessions on the user agent, which are not terminated yet.
+// If userIDs are provided, only their sessions are returned.
+func activeSessions(ctx context.Context, es eventFilter, userAgentID string, userIDs ...string) ([]*samlsession.AddedEvent, error) {
+	events, err := es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		AddQuery().
+		AggregateTypes(samlsession.AggregateType).
+		EventTypes(samlsession.AddedType).
+		EventData(map[string]interface{}{"userAgentID": userAgentID}).
+		Builder(),
+	)
+	if err != nil || len(events) == 0 {
+		return nil, err
+	}
+	sessions := make([]*samlsession.AddedEvent, 0, len(events))
+	sessionIDs := make([]string, 0, len(events))
+	for _, event := range events {
+		added, ok := event.(*samlsession.AddedEvent)
+		if !ok || (len(userIDs) > 0 && !slices.Contains(userIDs, added.UserID)) {
+			continue
+		}
+		sessions = append(sessions, added)
+		sessionIDs = append(sessionIDs, added.Aggregate().ID)
+	}
+	if len(sessions) == 0 {
+		return nil, nil
+	}
+	terminated, err := es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(authz.GetInstance(ctx).InstanceID()).
+		AddQuery().
+		AggregateTypes(samlsession.AggregateType).
+		AggregateIDs(sessionIDs...).
+		EventTypes(samlsession.TerminatedType).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	active := sessions[:0]
+	for _, session := range sessions {
+		if !isTerminated(terminated, session.Aggregate().ID) {
+			active = append(active, session)
+		}
+	}
+	return active, nil
+}
+
+func isTerminated(terminated []eventstore.Event, sessionID string) bool {
+	for _, event := range terminated {
+		if event.Aggregate().ID == sessionID {
+			return true
+		}
+	}
+	return false
+}
@@ -1,9 +1,12 @@
 package saml
 
 import (
+	"context"
 	"fmt"
 	"net/http"
+	"time"
 
+	"github.com/gorilla/mux"
 	"github.com/zitadel/saml/pkg/provider"
 
 	http_utils "github.com/zitadel/zitadel/internal/api/http"
@@ -21,10 +24,38 @@ import (
 
 const (
 	HandlerPrefix = "/saml/v2"
+
+	artifactResolutionEndpoint = "/artifact"
+	idpInitiatedEndpoint       = "/idp-initiated"
 )
 
 type Config struct {
 	ProviderConfig *provider.Config
+	// ArtifactLifetime is the time a service provider has to resolve the artifact
+	// of a response sent using the HTTP-Artifact binding
+	ArtifactLifetime time.Duration
+	SingleLogout     *SingleLogoutConfig
+}
+
+// Provider extends the SAML identity provider of the library with encrypted assertions,
+// the HTTP-Artifact binding, the single logout and IdP-initiated SSO.
+// Requests, which don't use any of them, are handled by the library.
+type Provider struct {
+	*provider.Provider
+
+	httpHandler        http.Handler
+	storage            *Storage
+	endpoints          *endpoints
+	metadataConfig     *provider.MetadataConfig
+	signatureAlgorithm string
+	wantRequestsSigned bool
+	artifactLifetime   time.Duration
+	singleLogout       *SingleLogoutConfig
+	externalSecure     bool
+}
+
+func (p *Provider) HttpHandler() http.Handler {
+	return p.httpHandler
 }
 
 func NewProvider(
@@ -40,7 +71,7 @@ func NewProvider(
 	instanceHandler,
 	userAgentCookie func(http.Handler) http.Handler,
 	accessHandler *middleware.AccessInterceptor,
-) (*provider.Provider, error) {
+) (*Provider, error) {
 	metricTypes := []metrics.MetricType{metrics.MetricTypeRequestCount, metrics.MetricTypeStatusCode, metrics.MetricTypeTotalCount}
 
 	provStorage, err := newStorage(
@@ -56,28 +87,107 @@ func NewProvider(
 		return nil, err
 	}
 
+	// the interceptors are not passed to the library, so they're only applied once,
+	// even if a request is passed on to the library
+	interceptors := []provider.HttpInterceptor{
+		middleware.MetricsHandler(metricTypes),
+		middleware.TelemetryHandler(),
+		middleware.NoCacheInterceptor().Handler,
+		instanceHandler,
+		userAgentCookie,
+		accessHandler.HandleIgnorePathPrefixes(ignoredQuotaLimitEndpoint(conf.ProviderConfig)),
+		http_utils.CopyHeadersToContext,
+	}
 	options := []provider.Option{
-		provider.WithHttpInterceptors(
-			middleware.MetricsHandler(metricTypes),
-			middleware.TelemetryHandler(),
-			middleware.NoCacheInterceptor().Handler,
-			instanceHandler,
-			userAgentCookie,
-			accessHandler.HandleIgnorePathPrefixes(ignoredQuotaLimitEndpoint(conf.ProviderConfig)),
-			http_utils.CopyHeadersToContext,
-		),
 		provider.WithCustomTimeFormat("2006-01-02T15:04:05.999Z"),
 	}
 	if !externalSecure {
 		options = append(options, provider.WithAllowInsecure())
 	}
 
-	return provider.NewProvider(
+	samlProvider, err := provider.NewProvider(
 		provStorage,
 		HandlerPrefix,
 		conf.ProviderConfig,
 		options...,
 	)
+	if err != nil {
+		return nil, err
+	}
+	p := &Provider{
+		Provider:         samlProvider,
+		storage:          provStorage,
+		endpoints:        newEndpoints(conf.ProviderConfig),
+		metadataConfig:   conf.ProviderConfig.MetadataConfig,
+		artifactLifetime: conf.ArtifactLifetime,
+		singleLogout:     conf.SingleLogout,
+		externalSecure:   externalSecure,
+	}
+	if conf.ProviderConfig.IDPConfig != nil {
+		p.signatureAlgorithm = conf.ProviderConfig.IDPConfig.SignatureAlgorithm
+		p.wantRequestsSigned = conf.ProviderConfig.IDPConfig.WantAuthRequestsSigned == "true"
+	}
+	var handler http.Handler = p.createRouter()
+	for i := len(interceptors) - 1; i >= 0; i-- {
+		handler = interceptors[i](handler)
+	}
+	p.httpHandler = handler
+	return p, nil
+}
+
+// createRouter handles the endpoints extended by the provider,
+// all other requests are passed on to the library
+func (p *Provider) createRouter() *mux.Router {
+	router := mux.NewRouter()
+	router.Use(provider.NewIssuerInterceptor(p.IssuerFromRequest).Handler)
+	router.HandleFunc(p.endpoints.metadata.Relative(), p.metadataHandler)
+	router.HandleFunc(p.endpoints.singleSignOn.Relative(), p.singleSignOnHandler)
+	router.HandleFunc(p.endpoints.callback.Relative(), p.callbackHandler)
+	router.HandleFunc(p.endpoints.singleLogout.Relative(), p.singleLogoutHandler)
+	router.HandleFunc(artifactResolutionEndpoint, p.artifactResolutionHandler)
+	router.HandleFunc(idpInitiatedEndpoint, p.idpInitiatedHandler).Methods(http.MethodGet)
+	router.NotFoundHandler = p.Provider.HttpHandler()
+	return router
+}
+
+type endpoints struct {
+	metadata           provider.Endpoint
+	singleSignOn       provider.Endpoint
+	callback           provider.Endpoint
+	singleLogout       provider.Endpoint
+	artifactResolution provider.Endpoint
+}
+
+// newEndpoints returns the endpoints of the provider with the same defaults as the library
+func newEndpoints(config *provider.Config) *endpoints {
+	e := &endpoints{
+		metadata:           provider.NewEndpoint(provider.DefaultMetadataEndpoint),
+		singleSignOn:       provider.NewEndpoint(provider.DefaultSingleSignOnEndpoint),
+		callback:           provider.NewEndpoint(provider.DefaultCallbackEndpoint),
+		singleLogout:       provider.NewEndpoint(provider.DefaultSingleLogOutEndpoint),
+		artifactResolution: provider.NewEndpoint(artifactResolutionEndpoint),
+	}
+	if config.Metadata != nil {
+		e.metadata = *config.Metadata
+	}
+	if config.IDPConfig == nil || config.IDPConfig.Endpoints == nil {
+		return e
+	}
+	if config.IDPConfig.Endpoints.SingleSignOn != nil {
+		e.singleSignOn = *config.IDPConfig.Endpoints.SingleSignOn
+	}
+	if config.IDPConfig.Endpoints.Callback != nil {
+		e.callback = *config.IDPConfig.Endpoints.Callback
+	}
+	if config.IDPConfig.Endpoints.SingleLogOut != nil {
+		e.singleLogout = *config.IDPConfig.Endpoints.SingleLogOut
+	}
+	return e
+}
+
+// entityID returns the entity ID of the identity provider, which is the absolute URL of the metadata
+func (p *Provider) entityID(ctx context.Context) string {
+	return p.endpoints.metadata.Absolute(provider.IssuerFromContext(ctx))
 }
 
 func newStorage(
@@ -0,0 +1,380 @@
+package saml
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/xml"
+	"fmt"
+	"html/template"
+	"net/http"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	crewjam_saml "github.com/crewjam/saml"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	"github.com/zitadel/saml/pkg/provider/signature"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	responseValidity    = 5 * time.Minute
+	nameIDFormatEntity  = "urn:oasis:names:tc:SAML:2.0:nameid-format:entity"
+	confirmationBearer  = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	authnContextDefault = "urn:oasis:names:tc:SAML:2.0:ac:classes:PasswordProtectedTransport"
+)
+
+var postTemplate = template.Must(template.New("post").Parse(`<!DOCTYPE html>
+<html>
+<body onload="document.forms[0].submit()">
+<noscript><p><strong>Note:</strong> Since your browser does not support JavaScript, you must press the Continue button once to proceed.</p></noscript>
+<form action="{{ .URL }}" method="post">
+<input type="hidden" name="{{ .Name }}" value="{{ .Message }}"/>
+{{- if .RelayState }}
+<input type="hidden" name="RelayState" value="{{ .RelayState }}"/>
+{{- end }}
+<noscript><input type="submit" value="Continue"/></noscript>
+</form>
+</body>
+</html>`))
+
+type postForm struct {
+	URL        string
+	Name       string
+	Message    string
+	RelayState string
+}
+
+// callbackHandler issues the response after the user authenticated and records the session for the single logout.
+// Responses to requests of apps without encrypted assertions, single logout or the HTTP-Artifact binding
+// are issued by the library, unsolicited responses (IdP-initiated SSO) are always issued here.
+func (p *Provider) callbackHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	authRequest, err := p.authRequestByID(ctx, r.Form.Get("id"))
+	if err != nil || !authRequest.Done() {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	app, err := p.storage.query.AppByID(ctx, authRequest.GetApplicationID())
+	if err != nil || app.SAMLConfig == nil {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	if !authRequest.isIdPInitiated() && !app.SAMLConfig.EncryptAssertions && !app.SAMLConfig.SingleLogout &&
+		authRequest.GetBindingType() != crewjam_saml.HTTPArtifactBinding {
+		p.Provider.HttpHandler().ServeHTTP(w, r)
+		return
+	}
+	if authRequest.isIdPInitiated() {
+		if err = p.checkUserGrant(ctx, authRequest.GetUserID(), app.ID); err != nil {
+			http.Error(w, err.Error(), http.StatusForbidden)
+			return
+		}
+	}
+	if authRequest.GetBindingType() == crewjam_saml.HTTPArtifactBinding {
+		if err = p.sendArtifact(w, r, authRequest, app); err != nil {
+			logging.WithError(err).Error("unable to send saml artifact")
+			http.Error(w, fmt.Errorf("failed to send artifact: %w", err).Error(), http.StatusInternalServerError)
+		}
+		return
+	}
+	sessionID, err := id.SonyFlakeGenerator().Next()
+	if err != nil {
+		http.Error(w, fmt.Errorf("failed to create session: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	response, err := p.makeResponse(ctx, authRequest, app, sessionID)
+	if err != nil {
+		logging.WithError(err).Error("unable to create saml response")
+		http.Error(w, fmt.Errorf("failed to create response: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	if _, err = p.addSession(ctx, authRequest, app, sessionID, response.nameID.Value, response.nameID.Format, nil); err != nil {
+		logging.WithError(err).Error("unable to record saml session")
+		http.Error(w, fmt.Errorf("failed to create session: %w", err).Error(), http.StatusInternalServerError)
+		return
+	}
+	if err = p.sendMessage(ctx, w, r, authRequest.GetBindingType(), authRequest.GetAccessConsumerServiceURL(), "SAMLResponse", authRequest.GetRelayState(), response.element); err != nil {
+		logging.WithError(err).Error("unable to send saml response")
+		http.Error(w, fmt.Errorf("failed to send response: %w", err).Error(), http.StatusInternalServerError)
+	}
+}
+
+func (p *Provider) authRequestByID(ctx context.Context, id string) (*AuthRequest, error) {
+	if id == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "SAML-ieT5u", "no auth request id provided")
+	}
+	authRequest, err := p.storage.AuthRequestByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	return authRequest.(*AuthRequest), nil
+}
+
+func (p *Provider) addSession(ctx context.Context, authRequest *AuthRequest, app *query.App, sessionID, nameID, nameIDFormat string, response *crypto.CryptoValue) (*domain.ObjectDetails, error) {
+	session := &domain.SAMLSession{
+		ID:                sessionID,
+		UserID:            authRequest.UserID,
+		UserResourceOwner: authRequest.UserOrgID,
+		UserAgentID:       authRequest.AgentID,
+		AppID:             app.ID,
+		EntityID:          app.SAMLConfig.EntityID,
+		NameID:            nameID,
+		NameIDFormat:      nameIDFormat,
+		AuthTime:          authRequest.AuthTime,
+		Response:          response,
+	}
+	if response != nil {
+		session.ArtifactExpiration = time.Now().Add(p.artifactLifetime)
+	}
+	return p.storage.command.AddSAMLSession(setSAMLCtx(ctx), session)
+}
+
+type samlResponse struct {
+	element *etree.Element
+	nameID  *crewjam_saml.NameID
+}
+
+// makeResponse creates the signed response for the authenticated user.
+// The assertion is encrypted with the certificate of the service provider if configured on the app.
+func (p *Provider) makeResponse(ctx context.Context, authRequest *AuthRequest, app *query.App, sessionID string) (*samlResponse, error) {
+	spMetadata := new(crewjam_saml.EntityDescriptor)
+	if err := xml.Unmarshal(app.SAMLConfig.Metadata, spMetadata); err != nil {
+		return nil, err
+	}
+	spDescriptor, err := encryptionDescriptor(spMetadata, app.SAMLConfig.EncryptAssertions)
+	if err != nil {
+		return nil, err
+	}
+	certAndKey, err := p.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	certificate, err := x509.ParseCertificate(certAndKey.Certificate)
+	if err != nil {
+		return nil, err
+	}
+	entityID := p.entityID(ctx)
+	metadataURL, err := url.Parse(entityID)
+	if err != nil {
+		return nil, err
+	}
+	attrs := &provider.Attributes{}
+	if err := p.storage.SetUserinfoWithUserID(ctx, app.ID, attrs, authRequest.GetUserID(), []int{}); err != nil {
+		return nil, err
+	}
+	nameID := &crewjam_saml.NameID{
+		Format: attrs.GetNameID().Format,
+		Value:  attrs.GetNameID().Text,
+	}
+	now := time.Now().UTC()
+	request := &crewjam_saml.IdpAuthnRequest{
+		IDP: &crewjam_saml.IdentityProvider{
+			Key:             certAndKey.Key,
+			Certificate:     certificate,
+			MetadataURL:     *metadataURL,
+			SignatureMethod: p.signatureAlgorithm,
+		},
+		RelayState:              authRequest.GetRelayState(),
+		Request:                 crewjam_saml.AuthnRequest{ID: authRequest.GetAuthRequestID()},
+		ServiceProviderMetadata: spMetadata,
+		SPSSODescriptor:         spDescriptor,
+		ACSEndpoint: &crewjam_saml.IndexedEndpoint{
+			Binding:  authRequest.GetBindingType(),
+			Location: authRequest.GetAccessConsumerServiceURL(),
+		},
+		Assertion: &crewjam_saml.Assertion{
+			ID:           provider.NewID(),
+			IssueInstant: now,
+			Version:      "2.0",
+			Issuer: crewjam_saml.Issuer{
+				Format: nameIDFormatEntity,
+				Value:  entityID,
+			},
+			Subject: &crewjam_saml.Subject{
+				NameID: nameID,
+				SubjectConfirmations: []crewjam_saml.SubjectConfirmation{{
+					Method: confirmationBearer,
+					SubjectConfirmationData: &crewjam_saml.SubjectConfirmationData{
+						InResponseTo: authRequest.GetAuthRequestID(),
+						NotOnOrAfter: now.Add(responseValidity),
+						Recipient:    authRequest.GetAccessConsumerServiceURL(),
+					},
+				}},
+			},
+			Conditions: &crewjam_saml.Conditions{
+				NotBefore:    now,
+				NotOnOrAfter: now.Add(responseValidity),
+				AudienceRestrictions: []crewjam_saml.AudienceRestriction{{
+					Audience: crewjam_saml.Audience{Value: app.SAMLConfig.EntityID},
+				}},
+			},
+			AuthnStatements: []crewjam_saml.AuthnStatement{{
+				AuthnInstant: authRequest.AuthTime.UTC(),
+				SessionIndex: sessionID,
+				AuthnContext: crewjam_saml.AuthnContext{
+					AuthnContextClassRef: &crewjam_saml.AuthnContextClassRef{Value: authnContextDefault},
+				},
+			}},
+			AttributeStatements: []crewjam_saml.AttributeStatement{{
+				Attributes: attributesToCrewjam(attrs),
+			}},
+		},
+		Now: now,
+	}
+	if err = request.MakeResponse(); err != nil {
+		return nil, err
+	}
+	return &samlResponse{
+		element: request.ResponseEl,
+		nameID:  nameID,
+	}, nil
+}
+
+func attributesToCrewjam(attrs *provider.Attributes) []crewjam_saml.Attribute {
+	samlAttributes := attrs.GetSAML()
+	attributes := make([]crewjam_saml.Attribute, len(samlAttributes))
+	for i, attribute := range samlAttributes {
+		values := make([]crewjam_saml.AttributeValue, len(attribute.AttributeValue))
+		for j, value := range attribute.AttributeValue {
+			values[j] = crewjam_saml.AttributeValue{
+				Type:  "xs:string",
+				Value: value,
+			}
+		}
+		attributes[i] = crewjam_saml.Attribute{
+			FriendlyName: attribute.FriendlyName,
+			Name:         attribute.Name,
+			NameFormat:   attribute.NameFormat,
+			Values:       values,
+		}
+	}
+	return attributes
+}
+
+// encryptionDescriptor returns the service provider descriptor containing only the certificates usable for encryption.
+// If the assertions must not be encrypted, the descriptor doesn't contain any certificate.
+func encryptionDescriptor(spMetadata *crewjam_saml.EntityDescriptor, encrypt bool) (*crewjam_saml.SPSSODescriptor, error) {
+	if len(spMetadata.SPSSODescriptors) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "SAML-Ahp4u", "service provider metadata contains no SPSSODescriptor")
+	}
+	descriptor := spMetadata.SPSSODescriptors[0]
+	keyDescriptors := descriptor.KeyDescriptors
+	descriptor.KeyDescriptors = nil
+	if !encrypt {
+		return &descriptor, nil
+	}
+	for _, keyDescriptor := range keyDescriptors {
+		if keyDescriptor.Use != "encryption" && keyDescriptor.Use != "" {
+			continue
+		}
+		if len(keyDescriptor.KeyInfo.X509Data.X509Certificates) == 0 || keyDescriptor.KeyInfo.X509Data.X509Certificates[0].Data == "" {
+			continue
+		}
+		descriptor.KeyDescriptors = append(descriptor.KeyDescriptors, keyDescriptor)
+	}
+	if len(descriptor.KeyDescriptors) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "SAML-Eix3a", "service provider metadata contains no encryption certificate")
+	}
+	return &descriptor, nil
+}
+
+// sendMessage sends the (signed) SAML message to the service provider using the HTTP-POST or HTTP-Redirect binding
+func (p *Provider) sendMessage(ctx context.Context, w http.ResponseWriter, r *http.Request, binding, location, name, relayState string, message *etree.Element) error {
+	doc := etree.NewDocument()
+	doc.SetRoot(message)
+	data, err := doc.WriteToBytes()
+	if err != nil {
+		return err
+	}
+	switch binding {
+	case crewjam_saml.HTTPPostBinding:
+		return postTemplate.Execute(w, &postForm{
+			URL:        location,
+			Name:       name,
+			Message:    base64.StdEncoding.EncodeToString(data),
+			RelayState: relayState,
+		})
+	case crewjam_saml.HTTPRedirectBinding:
+		redirectURL, err := p.redirectURL(ctx, location, name, relayState, data)
+		if err != nil {
+			return err
+		}
+		http.Redirect(w, r, redirectURL, http.StatusFound)
+		return nil
+	default:
+		return errors.ThrowInvalidArgument(nil, "SAML-Ue4ie", "unsupported binding")
+	}
+}
+
+// redirectURL returns the location with the deflated message, which is signed according to the HTTP-Redirect binding
+func (p *Provider) redirectURL(ctx context.Context, location, name, relayState string, message []byte) (string, error) {
+	deflated, err := saml_xml.DeflateAndBase64(message)
+	if err != nil {
+		return "", err
+	}
+	query := name + "=" + url.QueryEscape(string(deflated))
+	if relayState != "" {
+		query += "&RelayState=" + url.QueryEscape(relayState)
+	}
+	query += "&SigAlg=" + url.QueryEscape(p.signatureAlgorithm)
+	signingContext, err := p.signingContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	sig, err := signature.CreateRedirect(signingContext, query)
+	if err != nil {
+		return "", err
+	}
+	query += "&Signature=" + url.QueryEscape(base64.StdEncoding.EncodeToString(sig))
+	separator := "?"
+	if strings.Contains(location, "?") {
+		separator = "&"
+	}
+	return location + separator + query, nil
+}
+
+func (p *Provider) signingContext(ctx context.Context) (*dsig.SigningContext, error) {
+	certAndKey, err := p.storage.GetResponseSigningKey(ctx)
+	if err != nil {
+		return nil, err
+	}
+	tlsCert, err := signature.ParseTlsKeyPair(certAndKey.Certificate, certAndKey.Key)
+	if err != nil {
+		return nil, err
+	}
+	return signature.GetSigningContext(tlsCert, p.signatureAlgorithm)
+}
+
+// signEnveloped signs the SAML message and places the signature after the issuer as required by the schema
+func (p *Provider) signEnveloped(ctx context.Context, el *etree.Element) (*etree.Element, error) {
+	signingContext, err := p.signingContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	signed, err := signingContext.SignEnveloped(el)
+	if err != nil {
+		return nil, err
+	}
+	sig := signed.RemoveChildAt(len(signed.Child) - 1)
+	index := 0
+	if issuer := signed.SelectElement("Issuer"); issuer != nil {
+		index = issuer.Index() + 1
+	}
+	signed.InsertChildAt(index, sig)
+	return signed, nil
+}
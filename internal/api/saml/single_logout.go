@@ -0,0 +1,232 @@
+package saml
+
+import (
+	"context"
+	"net/http"
+	"time"
+
+	crewjam_saml "github.com/crewjam/saml"
+	"github.com/zitadel/logging"
+	"github.com/zitadel/saml/pkg/provider"
+	saml_xml "github.com/zitadel/saml/pkg/provider/xml"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/delivery"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/samlsession"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+const (
+	// SingleLogoutProjectionName is used to store the position of the single logout in the current states
+	// and the events which could not be reduced in the failed events.
+	SingleLogoutProjectionName = "projections.saml_single_logout"
+
+	logoutRequestLifetime = 2 * time.Minute
+	soapAction            = "http://www.oasis-open.org/committees/security"
+)
+
+// SingleLogoutConfig of the single logout.
+// Failed deliveries are retried by the handler, see RetryFailedAfter and MaxFailureCount
+// of the projection customization.
+type SingleLogoutConfig struct {
+	Enabled bool
+	// Timeout of a single request to the service provider
+	Timeout time.Duration
+}
+
+// StartSingleLogout starts the identity provider initiated single logout, which sends a logout request
+// using the SOAP binding to every service provider with single logout enabled the user received a response for,
+// as soon as the user agent session of the user is terminated.
+func (p *Provider) StartSingleLogout(ctx context.Context, handlerCustomConfig projection.CustomConfig, externalPort uint16) {
+	if p.singleLogout == nil || !p.singleLogout.Enabled {
+		return
+	}
+	handlerConfig := projection.ApplyCustomConfig(handlerCustomConfig)
+	handler.NewHandler(ctx, &handlerConfig, &singleLogoutNotifier{
+		provider:        p,
+		es:              p.storage.eventstore,
+		client:          &http.Client{Timeout: p.singleLogout.Timeout},
+		externalPort:    externalPort,
+		maxFailureCount: handlerConfig.MaxFailureCount,
+	}).Start(ctx)
+	logging.Info("saml single logout started")
+}
+
+type singleLogoutNotifier struct {
+	provider        *Provider
+	es              eventFilter
+	client          *http.Client
+	externalPort    uint16
+	maxFailureCount uint8
+}
+
+func (n *singleLogoutNotifier) Name() string {
+	return SingleLogoutProjectionName
+}
+
+func (n *singleLogoutNotifier) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.HumanSignedOutType,
+					Reduce: n.reduceHumanSignedOut,
+				},
+			},
+		},
+	}
+}
+
+func (n *singleLogoutNotifier) reduceHumanSignedOut(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*user.HumanSignedOutEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "SAML-Ieth0", "reduce.wrong.event.type %s", user.HumanSignedOutType)
+	}
+	ctx := eventContext(e)
+	sessions, err := activeSessions(ctx, n.es, e.UserAgentID, e.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	return n.logout(ctx, e, sessions)
+}
+
+// logout sends the logout request to every service provider with single logout and a SOAP single logout service
+// and terminates the sessions, when the statement is executed.
+// A session is terminated as soon as its logout request succeeded or failed on the last attempt,
+// so a retry only sends the logout requests of the sessions which are still active.
+func (n *singleLogoutNotifier) logout(ctx context.Context, event eventstore.Event, sessions []*samlsession.AddedEvent) (*handler.Statement, error) {
+	var entityID string
+	targets := make([]*delivery.Target, len(sessions))
+	for i, session := range sessions {
+		location, err := n.singleLogoutService(ctx, session.AppID)
+		if err != nil {
+			return nil, err
+		}
+		if location != "" && entityID == "" {
+			if entityID, err = n.entityID(ctx); err != nil {
+				return nil, err
+			}
+		}
+		session := session
+		targets[i] = &delivery.Target{
+			Fields: []interface{}{"app", session.AppID, "user", session.UserID},
+			Send: func(ctx context.Context) error {
+				if location == "" {
+					return nil
+				}
+				return n.deliver(ctx, location, entityID, session)
+			},
+			Done: func(ctx context.Context, _ uint8, _ error) (handler.Exec, error) {
+				_, err := n.provider.storage.command.TerminateSAMLSession(setSAMLCtx(ctx), session.Aggregate().ID)
+				return nil, err
+			},
+		}
+	}
+	return delivery.NewStatement(ctx, event, n.maxFailureCount, "", targets...), nil
+}
+
+// singleLogoutService returns the location of the SOAP single logout service of the service provider
+// if single logout is enabled on the app
+func (n *singleLogoutNotifier) singleLogoutService(ctx context.Context, appID string) (string, error) {
+	app, err := n.provider.storage.query.AppByID(ctx, appID)
+	if errors.IsNotFound(err) {
+		return "", nil
+	}
+	if err != nil {
+		return "", err
+	}
+	if app.SAMLConfig == nil || !app.SAMLConfig.SingleLogout {
+		return "", nil
+	}
+	metadata, err := saml_xml.ParseMetadataXmlIntoStruct(app.SAMLConfig.Metadata)
+	if err != nil || metadata.SPSSODescriptor == nil {
+		logging.WithFields("app", appID).OnError(err).Warn("unable to parse saml metadata")
+		return "", nil
+	}
+	for _, service := range metadata.SPSSODescriptor.SingleLogoutService {
+		if service.Binding == crewjam_saml.SOAPBinding {
+			return service.Location, nil
+		}
+	}
+	return "", nil
+}
+
+// entityID returns the entity ID of the identity provider on the primary domain of the instance
+func (n *singleLogoutNotifier) entityID(ctx context.Context) (string, error) {
+	primary, err := query.NewInstanceDomainPrimarySearchQuery(true)
+	if err != nil {
+		return "", err
+	}
+	domains, err := n.provider.storage.query.SearchInstanceDomains(ctx, &query.InstanceDomainSearchQueries{
+		Queries: []query.SearchQuery{primary},
+	})
+	if err != nil {
+		return "", err
+	}
+	if len(domains.Domains) < 1 {
+		return "", errors.ThrowInternal(nil, "SAML-ax7Oo", "Errors.Internal")
+	}
+	issuer := http_utils.BuildHTTP(domains.Domains[0].Domain, n.externalPort, n.provider.externalSecure) + HandlerPrefix
+	return n.provider.endpoints.metadata.Absolute(issuer), nil
+}
+
+// deliver sends the logout request to the SOAP single logout service of the service provider
+func (n *singleLogoutNotifier) deliver(ctx context.Context, location, entityID string, session *samlsession.AddedEvent) error {
+	now := time.Now().UTC()
+	notOnOrAfter := now.Add(logoutRequestLifetime)
+	logoutRequest, err := n.provider.signEnveloped(ctx, (&crewjam_saml.LogoutRequest{
+		ID:           provider.NewID(),
+		Version:      "2.0",
+		IssueInstant: now,
+		NotOnOrAfter: &notOnOrAfter,
+		Destination:  location,
+		Issuer: &crewjam_saml.Issuer{
+			Format: nameIDFormatEntity,
+			Value:  entityID,
+		},
+		NameID: &crewjam_saml.NameID{
+			Format: session.NameIDFormat,
+			Value:  session.NameID,
+		},
+		SessionIndex: &crewjam_saml.SessionIndex{Value: session.Aggregate().ID},
+	}).Element())
+	if err != nil {
+		return err
+	}
+	body, err := soapEnvelope(logoutRequest).WriteToBytes()
+	if err != nil {
+		return err
+	}
+	return n.send(ctx, location, body)
+}
+
+func (n *singleLogoutNotifier) send(ctx context.Context, location string, body []byte) error {
+	header := http.Header{
+		"Content-Type": {"text/xml; charset=utf-8"},
+		"SOAPAction":   {soapAction},
+	}
+	data, err := delivery.Post(ctx, n.client, location, header, body)
+	if err != nil {
+		return err
+	}
+	logoutResponse, err := soapMessage(data, "LogoutResponse")
+	if err != nil {
+		return err
+	}
+	if status := logoutResponse.FindElement("./Status/StatusCode"); status == nil || status.SelectAttrValue("Value", "") != crewjam_saml.StatusSuccess {
+		return errors.ThrowUnavailable(nil, "SAML-ooP5e", "single logout was not successful")
+	}
+	return nil
+}
+
+func eventContext(event eventstore.Event) context.Context {
+	return authz.WithInstanceID(call.WithTimestamp(context.Background()), event.Aggregate().InstanceID)
+}
@@ -303,7 +303,7 @@ func (p *Storage) getCustomAttributes(ctx context.Context, user *query.User, use
 			apiFields,
 			action.Script,
 			action.Name,
-			append(actions.ActionToOptions(action), actions.WithHTTP(actionCtx))...,
+			append(actions.ActionToOptions(action), actions.WithTrigger(domain.FlowTypeCustomizeSAMLResponse, domain.TriggerTypePreSAMLResponseCreation), actions.WithHTTP(actionCtx))...,
 		)
 		cancel()
 		if err != nil {
@@ -0,0 +1,153 @@
+package login
+
+import (
+	"context"
+	"net/http"
+	"net/url"
+
+	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	tmplApps = "apps"
+)
+
+type appsData struct {
+	baseData
+	Users []*appsUser
+}
+
+type appsUser struct {
+	profileData
+	ResourceOwner string
+	Apps          []*launchApp
+}
+
+type launchApp struct {
+	Name        string
+	ProjectName string
+	LaunchURL   string
+}
+
+// handleApps renders the application portal, which lists the OIDC and SAML apps
+// the users of the user agent are granted.
+// Without an active session, the user is redirected the same way as the login without auth request.
+func (l *Login) handleApps(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	userAgentID, _ := http_mw.UserAgentIDFromCtx(ctx)
+	userIDs, err := l.authRepo.UserSessionUserIDsByAgentID(ctx, userAgentID)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	if len(userIDs) == 0 {
+		l.defaultRedirect(w, r)
+		return
+	}
+	data := &appsData{
+		baseData: l.getBaseData(r, nil, "Apps.Title", "Apps.Description", "", ""),
+		Users:    make([]*appsUser, 0, len(userIDs)),
+	}
+	for _, userID := range userIDs {
+		user, err := l.appsUser(ctx, userID)
+		if err != nil {
+			l.renderError(w, r, nil, err)
+			return
+		}
+		data.Users = append(data.Users, user)
+	}
+	l.renderer.RenderTemplate(w, r, l.getTranslator(ctx, nil), l.renderer.Templates[tmplApps], data, nil)
+}
+
+func (l *Login) appsUser(ctx context.Context, userID string) (*appsUser, error) {
+	user, err := l.query.GetUserByID(ctx, false, userID)
+	if err != nil {
+		return nil, err
+	}
+	appsUser := &appsUser{
+		profileData: profileData{
+			LoginName: user.PreferredLoginName,
+			UserName:  user.Username,
+		},
+		ResourceOwner: user.ResourceOwner,
+	}
+	if user.Human != nil {
+		appsUser.DisplayName = user.Human.DisplayName
+		appsUser.AvatarKey = user.Human.AvatarKey
+	}
+	appsUser.Apps, err = l.grantedApps(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return appsUser, nil
+}
+
+// grantedApps returns the active apps of the projects the user has an active grant on,
+// which can be launched from the browser
+func (l *Login) grantedApps(ctx context.Context, userID string) ([]*launchApp, error) {
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := l.query.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, true, false)
+	if err != nil {
+		return nil, err
+	}
+	apps := make([]*launchApp, 0)
+	projectIDs := make(map[string]bool, len(grants.UserGrants))
+	for _, grant := range grants.UserGrants {
+		if grant.State != domain.UserGrantStateActive || projectIDs[grant.ProjectID] {
+			continue
+		}
+		projectIDs[grant.ProjectID] = true
+		projectQuery, err := query.NewAppProjectIDSearchQuery(grant.ProjectID)
+		if err != nil {
+			return nil, err
+		}
+		projectApps, err := l.query.SearchApps(ctx, &query.AppSearchQueries{Queries: []query.SearchQuery{projectQuery}}, false)
+		if err != nil {
+			return nil, err
+		}
+		for _, app := range projectApps.Apps {
+			if app.State != domain.AppStateActive {
+				continue
+			}
+			if launchURL := l.launchURL(ctx, app); launchURL != "" {
+				apps = append(apps, &launchApp{
+					Name:        app.Name,
+					ProjectName: grant.ProjectName,
+					LaunchURL:   launchURL,
+				})
+			}
+		}
+	}
+	return apps, nil
+}
+
+// launchURL returns the URL to open the app:
+// SAML apps receive an unsolicited response (IdP-initiated SSO),
+// OIDC apps are opened on the origin of their first web redirect URI and start the login on their own.
+// Apps which can't be launched from the browser (e.g. native and API apps) return an empty URL.
+func (l *Login) launchURL(ctx context.Context, app *query.App) string {
+	switch {
+	case app.SAMLConfig != nil:
+		return l.samlIdPInitiatedURL(ctx, app.ID)
+	case app.OIDCConfig != nil && app.OIDCConfig.AppType != domain.OIDCApplicationTypeNative:
+		return oidcLaunchURL(app.OIDCConfig.RedirectURIs)
+	default:
+		return ""
+	}
+}
+
+func oidcLaunchURL(redirectURIs []string) string {
+	for _, redirectURI := range redirectURIs {
+		uri, err := url.Parse(redirectURI)
+		if err != nil || uri.Host == "" || (uri.Scheme != "https" && uri.Scheme != "http") {
+			continue
+		}
+		return uri.Scheme + "://" + uri.Host
+	}
+	return ""
+}
@@ -0,0 +1,164 @@
+package login
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/http/middleware"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	tmplBackChannelAuthAction = "backchannel-action"
+
+	queryBackChannelAuthID = "id"
+)
+
+// BackChannelAuthLink is the link sent to the user to approve a backchannel authentication request.
+func BackChannelAuthLink(origin, id string) string {
+	return externalLink(origin) + EndpointBackChannelAuth + "?" + queryBackChannelAuthID + "=" + id
+}
+
+func (l *Login) renderBackChannelAuthAction(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, request *domain.AuthRequestBackChannel) {
+	data := &struct {
+		baseData
+		AuthRequestID  string
+		Username       string
+		ClientID       string
+		Scopes         []string
+		BindingMessage string
+	}{
+		baseData:       l.getBaseData(r, authReq, "BackChannelAuth.Title", "BackChannelAuth.Action.Description", "", ""),
+		AuthRequestID:  authReq.ID,
+		Username:       authReq.UserName,
+		ClientID:       authReq.ApplicationID,
+		Scopes:         request.Scopes,
+		BindingMessage: request.BindingMessage,
+	}
+
+	translator := l.getTranslator(r.Context(), authReq)
+	l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplBackChannelAuthAction], data, nil)
+}
+
+const (
+	backChannelAuthAllowed = "allowed"
+	backChannelAuthDenied  = "denied"
+)
+
+// renderBackChannelAuthDone renders success.html when the action was allowed and error.html when it was denied.
+func (l *Login) renderBackChannelAuthDone(w http.ResponseWriter, r *http.Request, authReq *domain.AuthRequest, action string) {
+	data := &struct {
+		baseData
+		Message string
+	}{
+		baseData: l.getBaseData(r, authReq, "BackChannelAuth.Title", "BackChannelAuth.Done.Description", "", ""),
+	}
+
+	translator := l.getTranslator(r.Context(), authReq)
+	switch action {
+	case backChannelAuthAllowed:
+		data.Message = translator.LocalizeFromRequest(r, "BackChannelAuth.Done.Approved", nil)
+		l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplSuccess], data, nil)
+	case backChannelAuthDenied:
+		data.ErrMessage = translator.LocalizeFromRequest(r, "BackChannelAuth.Done.Denied", nil)
+		l.renderer.RenderTemplate(w, r, translator, l.renderer.Templates[tmplError], data, nil)
+	}
+}
+
+// handleBackChannelAuth is the entry point of the link the user received to approve
+// a Client-Initiated Backchannel Authentication (CIBA) request.
+// A new AuthRequest is created in the repository with the requested user as login hint
+// and the user is redirected to the /login endpoint to authenticate.
+func (l *Login) handleBackChannelAuth(w http.ResponseWriter, r *http.Request) {
+	ctx := r.Context()
+	if err := r.ParseForm(); err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	backChannelAuth, err := l.query.BackChannelAuthByID(ctx, r.Form.Get(queryBackChannelAuthID))
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	if backChannelAuth.State != domain.BackChannelAuthStateInitiated || backChannelAuth.Expires.Before(time.Now()) {
+		l.renderError(w, r, nil, errors.ThrowPreconditionFailed(nil, "LOGIN-Ahp5i", "Errors.BackChannelAuth.AlreadyHandled"))
+		return
+	}
+	user, err := l.query.GetUserByID(ctx, false, backChannelAuth.UserID)
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+	userAgentID, ok := middleware.UserAgentIDFromCtx(ctx)
+	if !ok {
+		l.renderError(w, r, nil, errors.ThrowInternal(nil, "LOGIN-eeT3u", "internal error: agent ID missing"))
+		return
+	}
+	authRequest, err := l.authRepo.CreateAuthRequest(ctx, &domain.AuthRequest{
+		CreationDate:  time.Now(),
+		AgentID:       userAgentID,
+		ApplicationID: backChannelAuth.ClientID,
+		InstanceID:    authz.GetInstance(ctx).InstanceID(),
+		LoginHint:     user.PreferredLoginName,
+		Request: &domain.AuthRequestBackChannel{
+			ID:             backChannelAuth.AggregateID,
+			Scopes:         backChannelAuth.Scopes,
+			BindingMessage: backChannelAuth.BindingMessage,
+		},
+	})
+	if err != nil {
+		l.renderError(w, r, nil, err)
+		return
+	}
+
+	http.Redirect(w, r, l.renderer.pathPrefix+EndpointLogin+"?authRequestID="+authRequest.ID, http.StatusFound)
+}
+
+// handleBackChannelAuthAction is the handler where the user is redirected after login.
+// The authRequest is checked if the login was indeed completed.
+// When the action of "allowed" or "denied", the backchannel authentication is updated accordingly,
+// which also checks that the authenticated user is the requested one.
+// Else the user is presented with a page where they can compare the binding message and choose either action.
+func (l *Login) handleBackChannelAuthAction(w http.ResponseWriter, r *http.Request) {
+	authReq, err := l.getAuthRequest(r)
+	if authReq == nil {
+		l.renderError(w, r, nil, errors.ThrowInvalidArgument(err, "LOGIN-Ko8oh", "invalid or missing auth request"))
+		return
+	}
+	if !authReq.Done() {
+		l.renderError(w, r, authReq, errors.ThrowPreconditionFailed(nil, "LOGIN-ie3Ah", "authentication not completed"))
+		return
+	}
+	request, ok := authReq.Request.(*domain.AuthRequestBackChannel)
+	if !ok {
+		l.renderError(w, r, authReq, errors.ThrowInvalidArgumentf(nil, "LOGIN-ooP6a", "wrong auth request type: %T", authReq.Request))
+		return
+	}
+
+	action := mux.Vars(r)["action"]
+	switch action {
+	case backChannelAuthAllowed:
+		_, err = l.command.ApproveBackChannelAuth(r.Context(), request.ID, authReq.UserID, authReq.AuthTime, authReq.AuthMethodTypes())
+	case backChannelAuthDenied:
+		_, err = l.command.DenyBackChannelAuth(r.Context(), request.ID, authReq.UserID)
+	default:
+		l.renderBackChannelAuthAction(w, r, authReq, request)
+		return
+	}
+	if err != nil {
+		l.renderError(w, r, authReq, err)
+		return
+	}
+
+	l.renderBackChannelAuthDone(w, r, authReq, action)
+}
+
+// backChannelAuthCallbackURL creates the callback URL with which the user
+// is redirected back to the backchannel authentication flow.
+func (l *Login) backChannelAuthCallbackURL(authRequestID string) string {
+	return l.renderer.pathPrefix + EndpointBackChannelAuthAction + "?authRequestID=" + authRequestID
+}
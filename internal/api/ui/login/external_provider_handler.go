@@ -31,6 +31,7 @@ import (
 	openid "github.com/zitadel/zitadel/internal/idp/providers/oidc"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml"
 	"github.com/zitadel/zitadel/internal/idp/providers/saml/requesttracker"
+	"github.com/zitadel/zitadel/internal/ldapsync"
 	"github.com/zitadel/zitadel/internal/query"
 )
 
@@ -789,48 +790,13 @@ func (l *Login) ldapProvider(ctx context.Context, identityProvider *query.IDPTem
 	if err != nil {
 		return nil, err
 	}
-	var opts []ldap.ProviderOpts
-	if !identityProvider.LDAPIDPTemplate.StartTLS {
-		opts = append(opts, ldap.WithoutStartTLS())
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.IDAttribute != "" {
-		opts = append(opts, ldap.WithCustomIDAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.IDAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.FirstNameAttribute != "" {
-		opts = append(opts, ldap.WithFirstNameAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.FirstNameAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.LastNameAttribute != "" {
-		opts = append(opts, ldap.WithLastNameAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.LastNameAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.DisplayNameAttribute != "" {
-		opts = append(opts, ldap.WithDisplayNameAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.DisplayNameAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.NickNameAttribute != "" {
-		opts = append(opts, ldap.WithNickNameAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.NickNameAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.PreferredUsernameAttribute != "" {
-		opts = append(opts, ldap.WithPreferredUsernameAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.PreferredUsernameAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.EmailAttribute != "" {
-		opts = append(opts, ldap.WithEmailAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.EmailAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.EmailVerifiedAttribute != "" {
-		opts = append(opts, ldap.WithEmailVerifiedAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.EmailVerifiedAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.PhoneAttribute != "" {
-		opts = append(opts, ldap.WithPhoneAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.PhoneAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.PhoneVerifiedAttribute != "" {
-		opts = append(opts, ldap.WithPhoneVerifiedAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.PhoneVerifiedAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.PreferredLanguageAttribute != "" {
-		opts = append(opts, ldap.WithPreferredLanguageAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.PreferredLanguageAttribute))
-	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.AvatarURLAttribute != "" {
-		opts = append(opts, ldap.WithAvatarURLAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.AvatarURLAttribute))
+	opts := ldapsync.ProviderOptions(identityProvider.LDAPIDPTemplate)
+	sync, err := l.query.LDAPSyncByIDPID(ctx, false, identityProvider.ID)
+	if err != nil && !errors.IsNotFound(err) {
+		return nil, err
 	}
-	if identityProvider.LDAPIDPTemplate.LDAPAttributes.ProfileAttribute != "" {
-		opts = append(opts, ldap.WithProfileAttribute(identityProvider.LDAPIDPTemplate.LDAPAttributes.ProfileAttribute))
+	if sync != nil && sync.GroupAttribute != "" {
+		opts = append(opts, ldap.WithGroupAttribute(sync.GroupAttribute))
 	}
 	return ldap.New(
 		identityProvider.Name,
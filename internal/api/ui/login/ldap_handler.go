@@ -1,6 +1,7 @@
 package login
 
 import (
+	"context"
 	"net/http"
 
 	"github.com/zitadel/logging"
@@ -8,6 +9,8 @@ import (
 	http_mw "github.com/zitadel/zitadel/internal/api/http/middleware"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/ldapsync"
+	"github.com/zitadel/zitadel/internal/query"
 )
 
 const (
@@ -79,5 +82,37 @@ func (l *Login) handleLDAPCallback(w http.ResponseWriter, r *http.Request) {
 		l.renderLDAPLogin(w, r, authReq, err)
 		return
 	}
+	if ldapUser, ok := user.(*ldap.User); ok {
+		l.setLDAPUserGrants(r.Context(), identityProvider.ID, ldapUser)
+	}
 	l.handleExternalUserAuthenticated(w, r, authReq, identityProvider, session, user, l.renderNextStep)
 }
+
+// setLDAPUserGrants reconciles the grants of an already linked user with the group mappings of the identity provider.
+// Users created on this login will receive their grants on their next login or the next sync.
+func (l *Login) setLDAPUserGrants(ctx context.Context, idpID string, user *ldap.User) {
+	sync, err := l.query.LDAPSyncByIDPID(ctx, false, idpID)
+	if err != nil || len(sync.GroupMappings) == 0 {
+		return
+	}
+	idpQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(idpID)
+	if err != nil {
+		return
+	}
+	externalIDQuery, err := query.NewIDPUserLinksExternalIDSearchQuery(user.GetID())
+	if err != nil {
+		return
+	}
+	links, err := l.query.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{idpQuery, externalIDQuery}}, false)
+	if err != nil || len(links.Links) != 1 {
+		return
+	}
+	userID := links.Links[0].UserID
+	grants, err := ldapsync.UserGrants(ctx, l.query, userID, sync.GroupMappings, user.GetGroups())
+	if err != nil {
+		logging.WithFields("idp", idpID, "user", userID).WithError(err).Error("unable to get ldap user grants")
+		return
+	}
+	_, err = l.command.SetLDAPUserGrants(setContext(ctx, links.Links[0].ResourceOwner), userID, grants)
+	logging.WithFields("idp", idpID, "user", userID).OnError(err).Error("unable to set ldap user grants")
+}
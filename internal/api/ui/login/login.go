@@ -39,6 +39,7 @@ type Login struct {
 	consolePath         string
 	oidcAuthCallbackURL func(context.Context, string) string
 	samlAuthCallbackURL func(context.Context, string) string
+	samlIdPInitiatedURL func(context.Context, string) string
 	idpConfigAlg        crypto.EncryptionAlgorithm
 	userCodeAlg         crypto.EncryptionAlgorithm
 	featureCheck        feature.Checker
@@ -68,6 +69,7 @@ func CreateLogin(config Config,
 	consolePath string,
 	oidcAuthCallbackURL func(context.Context, string) string,
 	samlAuthCallbackURL func(context.Context, string) string,
+	samlIdPInitiatedURL func(context.Context, string) string,
 	externalSecure bool,
 	userAgentCookie,
 	issuerInterceptor,
@@ -83,6 +85,7 @@ func CreateLogin(config Config,
 	login := &Login{
 		oidcAuthCallbackURL: oidcAuthCallbackURL,
 		samlAuthCallbackURL: samlAuthCallbackURL,
+		samlIdPInitiatedURL: samlIdPInitiatedURL,
 		externalSecure:      externalSecure,
 		consolePath:         consolePath,
 		command:             command,
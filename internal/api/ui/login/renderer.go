@@ -83,6 +83,8 @@ func CreateRenderer(pathPrefix string, staticDir http.FileSystem, staticStorage
 		tmplLDAPLogin:                    "ldap_login.html",
 		tmplDeviceAuthUserCode:           "device_usercode.html",
 		tmplDeviceAuthAction:             "device_action.html",
+		tmplBackChannelAuthAction:        "backchannel_action.html",
+		tmplApps:                         "apps.html",
 	}
 	funcs := map[string]interface{}{
 		"resourceUrl": func(file string) string {
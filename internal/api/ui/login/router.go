@@ -47,12 +47,16 @@ const (
 	EndpointLogoutDone                    = "/logout/done"
 	EndpointLoginSuccess                  = "/login/success"
 	EndpointExternalNotFoundOption        = "/externaluser/option"
+	EndpointApps                          = "/apps"
 
 	EndpointResources        = "/resources"
 	EndpointDynamicResources = "/resources/dynamic"
 
 	EndpointDeviceAuth       = "/device"
 	EndpointDeviceAuthAction = "/device/{action}"
+
+	EndpointBackChannelAuth       = "/backchannel"
+	EndpointBackChannelAuthAction = "/backchannel/{action}"
 )
 
 var (
@@ -117,10 +121,13 @@ func CreateRouter(login *Login, staticDir http.FileSystem, interceptors ...mux.M
 	router.HandleFunc(EndpointRegisterOrg, login.handleRegisterOrg).Methods(http.MethodGet)
 	router.HandleFunc(EndpointRegisterOrg, login.handleRegisterOrgCheck).Methods(http.MethodPost)
 	router.HandleFunc(EndpointLoginSuccess, login.handleLoginSuccess).Methods(http.MethodGet)
+	router.HandleFunc(EndpointApps, login.handleApps).Methods(http.MethodGet)
 	router.HandleFunc(EndpointLDAPLogin, login.handleLDAP).Methods(http.MethodGet)
 	router.HandleFunc(EndpointLDAPCallback, login.handleLDAPCallback).Methods(http.MethodPost)
 	router.SkipClean(true).Handle("", http.RedirectHandler(HandlerPrefix+"/", http.StatusMovedPermanently))
 	router.HandleFunc(EndpointDeviceAuth, login.handleDeviceAuthUserCode).Methods(http.MethodGet, http.MethodPost)
 	router.HandleFunc(EndpointDeviceAuthAction, login.handleDeviceAuthAction).Methods(http.MethodGet, http.MethodPost)
+	router.HandleFunc(EndpointBackChannelAuth, login.handleBackChannelAuth).Methods(http.MethodGet)
+	router.HandleFunc(EndpointBackChannelAuthAction, login.handleBackChannelAuthAction).Methods(http.MethodGet, http.MethodPost)
 	return router
 }
@@ -13,6 +13,7 @@ import (
 	"github.com/zitadel/oidc/v3/pkg/op"
 
 	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	http_util "github.com/zitadel/zitadel/internal/api/http"
 	"github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/view"
 	"github.com/zitadel/zitadel/internal/command"
@@ -33,6 +34,7 @@ type TokenVerifierRepo struct {
 	View                 *view.View
 	Query                *query.Queries
 	ExternalSecure       bool
+	DPoP                 *dpop.Verifier
 }
 
 func (repo *TokenVerifierRepo) Health() error {
@@ -96,17 +98,17 @@ func (repo *TokenVerifierRepo) VerifyAccessToken(ctx context.Context, tokenStrin
 		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(nil, "APP-Reb32", "invalid token")
 	}
 	if strings.HasPrefix(tokenID, command.IDPrefixV2) {
-		userID, clientID, resourceOwner, err = repo.verifyAccessTokenV2(ctx, tokenID, verifierClientID, projectID)
+		userID, clientID, resourceOwner, err = repo.verifyAccessTokenV2(ctx, tokenID, tokenString, verifierClientID, projectID)
 		return
 	}
 	if sessionID, ok := strings.CutPrefix(tokenID, authz.SessionTokenPrefix); ok {
 		userID, clientID, resourceOwner, err = repo.verifySessionToken(ctx, sessionID, tokenString)
 		return
 	}
-	return repo.verifyAccessTokenV1(ctx, tokenID, subject, verifierClientID, projectID)
+	return repo.verifyAccessTokenV1(ctx, tokenID, subject, tokenString, verifierClientID, projectID)
 }
 
-func (repo *TokenVerifierRepo) verifyAccessTokenV1(ctx context.Context, tokenID, subject, verifierClientID, projectID string) (userID string, agentID string, clientID, prefLang, resourceOwner string, err error) {
+func (repo *TokenVerifierRepo) verifyAccessTokenV1(ctx context.Context, tokenID, subject, tokenString, verifierClientID, projectID string) (userID string, agentID string, clientID, prefLang, resourceOwner string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -119,6 +121,9 @@ func (repo *TokenVerifierRepo) verifyAccessTokenV1(ctx context.Context, tokenID,
 	if !token.Expiration.After(time.Now().UTC()) {
 		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(err, "APP-k9KS0", "invalid token")
 	}
+	if err = repo.DPoP.CheckBinding(ctx, token.DPoPJKT, tokenString); err != nil {
+		return "", "", "", "", "", caos_errs.ThrowUnauthenticated(err, "APP-eeL8a", "invalid token")
+	}
 	if token.IsPAT {
 		return token.UserID, "", "", "", token.ResourceOwner, nil
 	}
@@ -128,7 +133,7 @@ func (repo *TokenVerifierRepo) verifyAccessTokenV1(ctx context.Context, tokenID,
 	return token.UserID, token.UserAgentID, token.ApplicationID, token.PreferredLanguage, token.ResourceOwner, nil
 }
 
-func (repo *TokenVerifierRepo) verifyAccessTokenV2(ctx context.Context, token, verifierClientID, projectID string) (userID, clientID, resourceOwner string, err error) {
+func (repo *TokenVerifierRepo) verifyAccessTokenV2(ctx context.Context, token, tokenString, verifierClientID, projectID string) (userID, clientID, resourceOwner string, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
 
@@ -136,6 +141,9 @@ func (repo *TokenVerifierRepo) verifyAccessTokenV2(ctx context.Context, token, v
 	if err != nil {
 		return "", "", "", err
 	}
+	if err = repo.DPoP.CheckBinding(ctx, activeToken.DPoPJKT, tokenString); err != nil {
+		return "", "", "", caos_errs.ThrowUnauthenticated(err, "APP-Eek5o", "invalid token")
+	}
 	if err = verifyAudience(activeToken.Audience, verifierClientID, projectID); err != nil {
 		return "", "", "", err
 	}
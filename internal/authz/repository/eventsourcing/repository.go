@@ -3,6 +3,7 @@ package eventsourcing
 import (
 	"context"
 
+	"github.com/zitadel/zitadel/internal/api/dpop"
 	"github.com/zitadel/zitadel/internal/authz/repository"
 	authz_es "github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/eventstore"
 	authz_view "github.com/zitadel/zitadel/internal/authz/repository/eventsourcing/view"
@@ -17,7 +18,7 @@ type EsRepository struct {
 	authz_es.TokenVerifierRepo
 }
 
-func Start(queries *query.Queries, es *eventstore.Eventstore, dbClient *database.DB, keyEncryptionAlgorithm crypto.EncryptionAlgorithm, externalSecure bool) (repository.Repository, error) {
+func Start(queries *query.Queries, es *eventstore.Eventstore, dbClient *database.DB, keyEncryptionAlgorithm crypto.EncryptionAlgorithm, externalSecure bool, dpopVerifier *dpop.Verifier) (repository.Repository, error) {
 	view, err := authz_view.StartView(dbClient, queries)
 	if err != nil {
 		return nil, err
@@ -33,6 +34,7 @@ func Start(queries *query.Queries, es *eventstore.Eventstore, dbClient *database
 			View:                 view,
 			Query:                queries,
 			ExternalSecure:       externalSecure,
+			DPoP:                 dpopVerifier,
 		},
 	}, nil
 }
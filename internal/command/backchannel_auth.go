@@ -0,0 +1,205 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+)
+
+// AddBackChannelAuth stores a new Client-Initiated Backchannel Authentication (CIBA) request,
+// the user will be notified on the requested channel to approve it.
+func (c *Commands) AddBackChannelAuth(ctx context.Context, auth *domain.BackChannelAuth) (string, *domain.ObjectDetails, error) {
+	if auth.ClientID == "" || auth.AuthReqID == "" || auth.UserID == "" || !auth.Notification.Valid() {
+		return "", nil, errors.ThrowInvalidArgument(nil, "COMMAND-Oot6e", "Errors.Invalid.Argument")
+	}
+	if auth.Notification == domain.BackChannelAuthNotificationWebhook && auth.WebhookURL == "" {
+		return "", nil, errors.ThrowInvalidArgument(nil, "COMMAND-aeN1j", "Errors.Invalid.Argument")
+	}
+	aggrID, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+
+	aggr := backchannelauth.NewAggregate(aggrID, authz.GetInstance(ctx).InstanceID())
+	model := NewBackChannelAuthWriteModel(aggrID, aggr.ResourceOwner)
+
+	pushedEvents, err := c.eventstore.Push(ctx, backchannelauth.NewAddedEvent(
+		ctx,
+		aggr,
+		auth.ClientID,
+		auth.AuthReqID,
+		auth.UserID,
+		auth.Scopes,
+		auth.BindingMessage,
+		auth.ClientNotificationToken,
+		auth.Expires,
+		auth.Notification,
+		auth.WebhookURL,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(model, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return model.AggregateID, writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// BackChannelAuthUserNotified records that the user was asked to approve the request.
+func (c *Commands) BackChannelAuthUserNotified(ctx context.Context, id string) error {
+	model, err := c.getBackChannelAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return err
+	}
+	if !model.State.Exists() {
+		return errors.ThrowNotFound(nil, "COMMAND-ieB4u", "Errors.BackChannelAuth.NotExisting")
+	}
+	return c.pushAppendAndReduce(ctx, model, backchannelauth.NewUserNotifiedEvent(ctx, backchannelauth.NewAggregate(model.AggregateID, model.InstanceID)))
+}
+
+// ApproveBackChannelAuth approves the request for the user, who authenticated at the authTime using the authMethods.
+// The user must be the one the client requested the authentication for.
+func (c *Commands) ApproveBackChannelAuth(ctx context.Context, id, userID string, authTime time.Time, authMethods []domain.UserAuthMethodType) (*domain.ObjectDetails, error) {
+	model, err := c.getBackChannelAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = model.checkPending(userID); err != nil {
+		return nil, err
+	}
+	if err = c.pushAppendAndReduce(ctx, model, backchannelauth.NewApprovedEvent(
+		ctx,
+		backchannelauth.NewAggregate(model.AggregateID, model.InstanceID),
+		userID,
+		authTime,
+		authMethods,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// ApproveBackChannelAuthWithSession approves the request with the authentication of an active session.
+func (c *Commands) ApproveBackChannelAuthWithSession(ctx context.Context, id, sessionID, sessionToken string) (*domain.ObjectDetails, error) {
+	sessionWriteModel, err := c.verifiedSession(ctx, sessionID, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	return c.ApproveBackChannelAuth(ctx, id, sessionWriteModel.UserID, sessionWriteModel.AuthenticationTime(), sessionWriteModel.AuthMethodTypes())
+}
+
+// DenyBackChannelAuthWithSession denies the request by the user of an active session.
+func (c *Commands) DenyBackChannelAuthWithSession(ctx context.Context, id, sessionID, sessionToken string) (*domain.ObjectDetails, error) {
+	sessionWriteModel, err := c.verifiedSession(ctx, sessionID, sessionToken)
+	if err != nil {
+		return nil, err
+	}
+	return c.DenyBackChannelAuth(ctx, id, sessionWriteModel.UserID)
+}
+
+// DenyBackChannelAuth cancels the request, because the user denied it.
+func (c *Commands) DenyBackChannelAuth(ctx context.Context, id, userID string) (*domain.ObjectDetails, error) {
+	model, err := c.getBackChannelAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = model.checkPending(userID); err != nil {
+		return nil, err
+	}
+	return c.cancelBackChannelAuth(ctx, model, domain.BackChannelAuthCanceledDenied)
+}
+
+// CancelBackChannelAuth cancels the request for the given reason, e.g. because it is expired.
+func (c *Commands) CancelBackChannelAuth(ctx context.Context, id string, reason domain.BackChannelAuthCanceled) (*domain.ObjectDetails, error) {
+	model, err := c.getBackChannelAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !model.State.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Ga2ae", "Errors.BackChannelAuth.NotExisting")
+	}
+	return c.cancelBackChannelAuth(ctx, model, reason)
+}
+
+func (c *Commands) cancelBackChannelAuth(ctx context.Context, model *BackChannelAuthWriteModel, reason domain.BackChannelAuthCanceled) (*domain.ObjectDetails, error) {
+	if err := c.pushAppendAndReduce(ctx, model, backchannelauth.NewCanceledEvent(
+		ctx,
+		backchannelauth.NewAggregate(model.AggregateID, model.InstanceID),
+		reason,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// ConsumeBackChannelAuth removes the approved request and returns it, so the client receives the tokens only once.
+// If the request is consumed concurrently, the push fails on the unique constraint of the removed event.
+func (c *Commands) ConsumeBackChannelAuth(ctx context.Context, id string) (*domain.BackChannelAuth, error) {
+	model, err := c.getBackChannelAuthWriteModelByID(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if !model.State.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Ohw3i", "Errors.BackChannelAuth.NotExisting")
+	}
+	if model.State != domain.BackChannelAuthStateApproved {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Eeph3", "Errors.BackChannelAuth.NotApproved")
+	}
+	if err = c.pushAppendAndReduce(ctx, model, backchannelauth.NewRemovedEvent(
+		ctx,
+		backchannelauth.NewAggregate(model.AggregateID, model.InstanceID),
+		model.ClientID,
+		model.AuthReqID,
+	)); err != nil {
+		return nil, err
+	}
+	return &domain.BackChannelAuth{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID:   model.AggregateID,
+			ResourceOwner: model.ResourceOwner,
+			InstanceID:    model.InstanceID,
+			Sequence:      model.ProcessedSequence,
+			ChangeDate:    model.ChangeDate,
+		},
+		ClientID:    model.ClientID,
+		AuthReqID:   model.AuthReqID,
+		UserID:      model.UserID,
+		Scopes:      model.Scopes,
+		Expires:     model.Expires,
+		State:       domain.BackChannelAuthStateApproved,
+		AuthTime:    model.AuthTime,
+		AuthMethods: model.AuthMethods,
+	}, nil
+}
+
+func (c *Commands) verifiedSession(ctx context.Context, sessionID, sessionToken string) (*SessionWriteModel, error) {
+	sessionWriteModel := NewSessionWriteModel(sessionID, authz.GetInstance(ctx).InstanceID())
+	err := c.eventstore.FilterToQueryReducer(ctx, sessionWriteModel)
+	if err != nil {
+		return nil, err
+	}
+	if err = sessionWriteModel.CheckIsActive(); err != nil {
+		return nil, err
+	}
+	if err = c.sessionTokenVerifier(ctx, sessionToken, sessionWriteModel.AggregateID, sessionWriteModel.TokenID); err != nil {
+		return nil, err
+	}
+	return sessionWriteModel, nil
+}
+
+func (c *Commands) getBackChannelAuthWriteModelByID(ctx context.Context, id string) (*BackChannelAuthWriteModel, error) {
+	model := &BackChannelAuthWriteModel{WriteModel: eventstore.WriteModel{AggregateID: id}}
+	err := c.eventstore.FilterToQueryReducer(ctx, model)
+	if err != nil {
+		return nil, err
+	}
+	return model, nil
+}
@@ -0,0 +1,81 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+)
+
+type BackChannelAuthWriteModel struct {
+	eventstore.WriteModel
+
+	ClientID    string
+	AuthReqID   string
+	UserID      string
+	Scopes      []string
+	Expires     time.Time
+	State       domain.BackChannelAuthState
+	AuthTime    time.Time
+	AuthMethods []domain.UserAuthMethodType
+}
+
+func NewBackChannelAuthWriteModel(aggrID, resourceOwner string) *BackChannelAuthWriteModel {
+	return &BackChannelAuthWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   aggrID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (m *BackChannelAuthWriteModel) Reduce() error {
+	for _, event := range m.Events {
+		switch e := event.(type) {
+		case *backchannelauth.AddedEvent:
+			m.ClientID = e.ClientID
+			m.AuthReqID = e.AuthReqID
+			m.UserID = e.UserID
+			m.Scopes = e.Scopes
+			m.Expires = e.Expires
+			m.State = e.State
+		case *backchannelauth.ApprovedEvent:
+			m.AuthTime = e.AuthTime
+			m.AuthMethods = e.AuthMethods
+			m.State = domain.BackChannelAuthStateApproved
+		case *backchannelauth.CanceledEvent:
+			m.State = e.Reason.State()
+		case *backchannelauth.RemovedEvent:
+			m.State = domain.BackChannelAuthStateRemoved
+		}
+	}
+
+	return m.WriteModel.Reduce()
+}
+
+func (m *BackChannelAuthWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(backchannelauth.AggregateType).
+		AggregateIDs(m.AggregateID).
+		Builder()
+}
+
+// checkPending ensures that the request is still waiting for the decision of the requested user
+func (m *BackChannelAuthWriteModel) checkPending(userID string) error {
+	if !m.State.Exists() {
+		return errors.ThrowNotFound(nil, "COMMAND-Ieb2e", "Errors.BackChannelAuth.NotExisting")
+	}
+	if m.State != domain.BackChannelAuthStateInitiated {
+		return errors.ThrowPreconditionFailed(nil, "COMMAND-wu5Ei", "Errors.BackChannelAuth.AlreadyHandled")
+	}
+	if m.Expires.Before(time.Now()) {
+		return errors.ThrowPreconditionFailed(nil, "COMMAND-Aim0e", "Errors.BackChannelAuth.Expired")
+	}
+	if m.UserID != userID {
+		return errors.ThrowPermissionDenied(nil, "COMMAND-zo5Ie", "Errors.BackChannelAuth.UserMismatch")
+	}
+	return nil
+}
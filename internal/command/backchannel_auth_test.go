@@ -0,0 +1,490 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/muhlemmer/gu"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+	"github.com/zitadel/zitadel/internal/repository/session"
+)
+
+func TestCommands_AddBackChannelAuth(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	pushErr := errors.New("pushErr")
+	expires := time.Now().Add(time.Minute)
+
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		auth        *domain.BackChannelAuth
+		wantID      string
+		wantDetails *domain.ObjectDetails
+		wantErr     error
+	}{
+		{
+			name: "missing user, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			auth: &domain.BackChannelAuth{
+				ClientID:  "clientID",
+				AuthReqID: "authReqID",
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "COMMAND-Oot6e", "Errors.Invalid.Argument"),
+		},
+		{
+			name: "webhook without url, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			auth: &domain.BackChannelAuth{
+				ClientID:     "clientID",
+				AuthReqID:    "authReqID",
+				UserID:       "userID",
+				Notification: domain.BackChannelAuthNotificationWebhook,
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "COMMAND-aeN1j", "Errors.Invalid.Argument"),
+		},
+		{
+			name: "push error",
+			fields: fields{
+				eventstore: eventstoreExpect(t, expectPushFailed(pushErr,
+					backchannelauth.NewAddedEvent(
+						ctx,
+						backchannelauth.NewAggregate("1999", "instance1"),
+						"clientID", "authReqID", "userID", []string{"openid"}, "binding", "", expires,
+						domain.BackChannelAuthNotificationEmail, "",
+					)),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "1999"),
+			},
+			auth: &domain.BackChannelAuth{
+				ClientID:       "clientID",
+				AuthReqID:      "authReqID",
+				UserID:         "userID",
+				Scopes:         []string{"openid"},
+				BindingMessage: "binding",
+				Expires:        expires,
+			},
+			wantErr: pushErr,
+		},
+		{
+			name: "success",
+			fields: fields{
+				eventstore: eventstoreExpect(t, expectPush(
+					backchannelauth.NewAddedEvent(
+						ctx,
+						backchannelauth.NewAggregate("1999", "instance1"),
+						"clientID", "authReqID", "userID", []string{"openid"}, "binding", "token", expires,
+						domain.BackChannelAuthNotificationWebhook, "https://push.example.com",
+					),
+				)),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "1999"),
+			},
+			auth: &domain.BackChannelAuth{
+				ClientID:                "clientID",
+				AuthReqID:               "authReqID",
+				UserID:                  "userID",
+				Scopes:                  []string{"openid"},
+				BindingMessage:          "binding",
+				ClientNotificationToken: "token",
+				Expires:                 expires,
+				Notification:            domain.BackChannelAuthNotificationWebhook,
+				WebhookURL:              "https://push.example.com",
+			},
+			wantID: "1999",
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			gotID, gotDetails, err := c.AddBackChannelAuth(ctx, tt.auth)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantID, gotID)
+			assert.Equal(t, tt.wantDetails, gotDetails)
+		})
+	}
+}
+
+func TestCommands_ApproveBackChannelAuth(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	expires := time.Now().Add(time.Minute)
+	authTime := time.Now()
+
+	addedEvent := func(expires time.Time) eventstore.Event {
+		return eventFromEventPusherWithInstanceID("instance1",
+			backchannelauth.NewAddedEvent(
+				ctx,
+				backchannelauth.NewAggregate("1999", "instance1"),
+				"clientID", "authReqID", "userID", []string{"openid"}, "binding", "", expires,
+				domain.BackChannelAuthNotificationEmail, "",
+			),
+		)
+	}
+
+	type args struct {
+		id          string
+		userID      string
+		authMethods []domain.UserAuthMethodType
+	}
+	tests := []struct {
+		name        string
+		eventstore  *eventstore.Eventstore
+		args        args
+		wantDetails *domain.ObjectDetails
+		wantErr     error
+	}{
+		{
+			name:       "not found error",
+			eventstore: eventstoreExpect(t, expectFilter()),
+			args:       args{"1999", "userID", nil},
+			wantErr:    caos_errs.ThrowNotFound(nil, "COMMAND-Ieb2e", "Errors.BackChannelAuth.NotExisting"),
+		},
+		{
+			name: "already handled error",
+			eventstore: eventstoreExpect(t, expectFilter(
+				addedEvent(expires),
+				eventFromEventPusherWithInstanceID("instance1",
+					backchannelauth.NewCanceledEvent(ctx, backchannelauth.NewAggregate("1999", "instance1"), domain.BackChannelAuthCanceledDenied),
+				),
+			)),
+			args:    args{"1999", "userID", nil},
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-wu5Ei", "Errors.BackChannelAuth.AlreadyHandled"),
+		},
+		{
+			name:       "expired error",
+			eventstore: eventstoreExpect(t, expectFilter(addedEvent(time.Now().Add(-time.Minute)))),
+			args:       args{"1999", "userID", nil},
+			wantErr:    caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Aim0e", "Errors.BackChannelAuth.Expired"),
+		},
+		{
+			name:       "other user error",
+			eventstore: eventstoreExpect(t, expectFilter(addedEvent(expires))),
+			args:       args{"1999", "otherUserID", nil},
+			wantErr:    caos_errs.ThrowPermissionDenied(nil, "COMMAND-zo5Ie", "Errors.BackChannelAuth.UserMismatch"),
+		},
+		{
+			name: "success",
+			eventstore: eventstoreExpect(t,
+				expectFilter(addedEvent(expires)),
+				expectPush(
+					backchannelauth.NewApprovedEvent(ctx, backchannelauth.NewAggregate("1999", "instance1"),
+						"userID", authTime, []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+					),
+				),
+			),
+			args: args{"1999", "userID", []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword}},
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore,
+			}
+			gotDetails, err := c.ApproveBackChannelAuth(ctx, tt.args.id, tt.args.userID, authTime, tt.args.authMethods)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantDetails, gotDetails)
+		})
+	}
+}
+
+func TestCommands_ApproveBackChannelAuthWithSession(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	expires := time.Now().Add(time.Minute)
+
+	sessionEvents := func(userID string) []eventstore.Event {
+		return []eventstore.Event{
+			eventFromEventPusher(
+				session.NewAddedEvent(ctx,
+					&session.NewAggregate("sessionID", "instance1").Aggregate,
+					&domain.UserAgent{
+						FingerprintID: gu.Ptr("fp1"),
+						IP:            net.ParseIP("1.2.3.4"),
+						Description:   gu.Ptr("firefox"),
+						Header:        http.Header{"foo": []string{"bar"}},
+					},
+				),
+			),
+			eventFromEventPusher(
+				session.NewUserCheckedEvent(ctx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+					userID, "org1", testNow),
+			),
+			eventFromEventPusher(
+				session.NewPasswordCheckedEvent(ctx, &session.NewAggregate("sessionID", "instance1").Aggregate,
+					testNow),
+			),
+		}
+	}
+
+	tests := []struct {
+		name          string
+		eventstore    *eventstore.Eventstore
+		tokenVerifier func(ctx context.Context, sessionToken, sessionID, tokenID string) (err error)
+		wantDetails   *domain.ObjectDetails
+		wantErr       error
+	}{
+		{
+			name: "invalid session token error",
+			eventstore: eventstoreExpect(t,
+				expectFilter(sessionEvents("userID")...),
+			),
+			tokenVerifier: newMockTokenVerifierInvalid(),
+			wantErr:       caos_errs.ThrowPermissionDenied(nil, "COMMAND-sGr42", "Errors.Session.Token.Invalid"),
+		},
+		{
+			name: "session of other user error",
+			eventstore: eventstoreExpect(t,
+				expectFilter(sessionEvents("otherUserID")...),
+				expectFilter(
+					eventFromEventPusherWithInstanceID("instance1",
+						backchannelauth.NewAddedEvent(
+							ctx,
+							backchannelauth.NewAggregate("1999", "instance1"),
+							"clientID", "authReqID", "userID", []string{"openid"}, "binding", "", expires,
+							domain.BackChannelAuthNotificationEmail, "",
+						),
+					),
+				),
+			),
+			tokenVerifier: newMockTokenVerifierValid(),
+			wantErr:       caos_errs.ThrowPermissionDenied(nil, "COMMAND-zo5Ie", "Errors.BackChannelAuth.UserMismatch"),
+		},
+		{
+			name: "success",
+			eventstore: eventstoreExpect(t,
+				expectFilter(sessionEvents("userID")...),
+				expectFilter(
+					eventFromEventPusherWithInstanceID("instance1",
+						backchannelauth.NewAddedEvent(
+							ctx,
+							backchannelauth.NewAggregate("1999", "instance1"),
+							"clientID", "authReqID", "userID", []string{"openid"}, "binding", "", expires,
+							domain.BackChannelAuthNotificationEmail, "",
+						),
+					),
+				),
+				expectPush(
+					backchannelauth.NewApprovedEvent(ctx, backchannelauth.NewAggregate("1999", "instance1"),
+						"userID", testNow, []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+					),
+				),
+			),
+			tokenVerifier: newMockTokenVerifierValid(),
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:           tt.eventstore,
+				sessionTokenVerifier: tt.tokenVerifier,
+			}
+			gotDetails, err := c.ApproveBackChannelAuthWithSession(ctx, "1999", "sessionID", "sessionToken")
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantDetails, gotDetails)
+		})
+	}
+}
+
+func TestCommands_DenyBackChannelAuth(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	expires := time.Now().Add(time.Minute)
+
+	tests := []struct {
+		name        string
+		eventstore  *eventstore.Eventstore
+		userID      string
+		wantDetails *domain.ObjectDetails
+		wantErr     error
+	}{
+		{
+			name: "other user error",
+			eventstore: eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusherWithInstanceID("instance1",
+						backchannelauth.NewAddedEvent(
+							ctx,
+							backchannelauth.NewAggregate("1999", "instance1"),
+							"clientID", "authReqID", "userID", []string{"openid"}, "binding", "", expires,
+							domain.BackChannelAuthNotificationEmail, "",
+						),
+					),
+				),
+			),
+			userID:  "otherUserID",
+			wantErr: caos_errs.ThrowPermissionDenied(nil, "COMMAND-zo5Ie", "Errors.BackChannelAuth.UserMismatch"),
+		},
+		{
+			name: "success",
+			eventstore: eventstoreExpect(t,
+				expectFilter(
+					eventFromEventPusherWithInstanceID("instance1",
+						backchannelauth.NewAddedEvent(
+							ctx,
+							backchannelauth.NewAggregate("1999", "instance1"),
+							"clientID", "authReqID", "userID", []string{"openid"}, "binding", "", expires,
+							domain.BackChannelAuthNotificationEmail, "",
+						),
+					),
+				),
+				expectPush(
+					backchannelauth.NewCanceledEvent(ctx, backchannelauth.NewAggregate("1999", "instance1"), domain.BackChannelAuthCanceledDenied),
+				),
+			),
+			userID: "userID",
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore,
+			}
+			gotDetails, err := c.DenyBackChannelAuth(ctx, "1999", tt.userID)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantDetails, gotDetails)
+		})
+	}
+}
+
+func TestCommands_ConsumeBackChannelAuth(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	expires := time.Now().Add(time.Minute)
+	authTime := time.Now()
+
+	addedEvent := func() eventstore.Event {
+		return eventFromEventPusherWithInstanceID("instance1",
+			backchannelauth.NewAddedEvent(
+				ctx,
+				backchannelauth.NewAggregate("1999", "instance1"),
+				"clientID", "authReqID", "userID", []string{"openid"}, "binding", "", expires,
+				domain.BackChannelAuthNotificationEmail, "",
+			),
+		)
+	}
+	approvedEvent := func() eventstore.Event {
+		return eventFromEventPusherWithInstanceID("instance1",
+			backchannelauth.NewApprovedEvent(
+				ctx,
+				backchannelauth.NewAggregate("1999", "instance1"),
+				"userID", authTime, []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+			),
+		)
+	}
+
+	tests := []struct {
+		name       string
+		eventstore *eventstore.Eventstore
+		wantAuth   *domain.BackChannelAuth
+		wantErr    error
+	}{
+		{
+			name:       "not found error",
+			eventstore: eventstoreExpect(t, expectFilter()),
+			wantErr:    caos_errs.ThrowNotFound(nil, "COMMAND-Ohw3i", "Errors.BackChannelAuth.NotExisting"),
+		},
+		{
+			name: "pending, precondition error",
+			eventstore: eventstoreExpect(t,
+				expectFilter(addedEvent()),
+			),
+			wantErr: caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Eeph3", "Errors.BackChannelAuth.NotApproved"),
+		},
+		{
+			name: "already consumed, not found error",
+			eventstore: eventstoreExpect(t,
+				expectFilter(
+					addedEvent(),
+					approvedEvent(),
+					eventFromEventPusherWithInstanceID("instance1",
+						backchannelauth.NewRemovedEvent(ctx, backchannelauth.NewAggregate("1999", "instance1"), "clientID", "authReqID"),
+					),
+				),
+			),
+			wantErr: caos_errs.ThrowNotFound(nil, "COMMAND-Ohw3i", "Errors.BackChannelAuth.NotExisting"),
+		},
+		{
+			name: "consumed concurrently, already exists error",
+			eventstore: eventstoreExpect(t,
+				expectFilter(addedEvent(), approvedEvent()),
+				expectPushFailed(
+					caos_errs.ThrowAlreadyExists(nil, "V3-DKcYh", "Errors.BackChannelAuth.AlreadyHandled"),
+					backchannelauth.NewRemovedEvent(ctx, backchannelauth.NewAggregate("1999", "instance1"), "clientID", "authReqID"),
+				),
+			),
+			wantErr: caos_errs.ThrowAlreadyExists(nil, "V3-DKcYh", "Errors.BackChannelAuth.AlreadyHandled"),
+		},
+		{
+			name: "success",
+			eventstore: eventstoreExpect(t,
+				expectFilter(addedEvent(), approvedEvent()),
+				expectPush(
+					backchannelauth.NewRemovedEvent(ctx, backchannelauth.NewAggregate("1999", "instance1"), "clientID", "authReqID"),
+				),
+			),
+			wantAuth: &domain.BackChannelAuth{
+				ClientID:    "clientID",
+				AuthReqID:   "authReqID",
+				UserID:      "userID",
+				Scopes:      []string{"openid"},
+				Expires:     expires,
+				State:       domain.BackChannelAuthStateApproved,
+				AuthTime:    authTime,
+				AuthMethods: []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore,
+			}
+			gotAuth, err := c.ConsumeBackChannelAuth(ctx, "1999")
+			require.ErrorIs(t, err, tt.wantErr)
+			if tt.wantAuth == nil {
+				assert.Nil(t, gotAuth)
+				return
+			}
+			assert.Equal(t, "1999", gotAuth.AggregateID)
+			assert.Equal(t, tt.wantAuth.ClientID, gotAuth.ClientID)
+			assert.Equal(t, tt.wantAuth.AuthReqID, gotAuth.AuthReqID)
+			assert.Equal(t, tt.wantAuth.UserID, gotAuth.UserID)
+			assert.Equal(t, tt.wantAuth.Scopes, gotAuth.Scopes)
+			assert.Equal(t, tt.wantAuth.State, gotAuth.State)
+			assert.Equal(t, tt.wantAuth.AuthMethods, gotAuth.AuthMethods)
+			assert.WithinDuration(t, tt.wantAuth.Expires, gotAuth.Expires, time.Second)
+			assert.WithinDuration(t, tt.wantAuth.AuthTime, gotAuth.AuthTime, time.Second)
+		})
+	}
+}
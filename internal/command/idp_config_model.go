@@ -13,6 +13,7 @@ type IDPConfigWriteModel struct {
 
 	ConfigID     string
 	Name         string
+	Type         domain.IDPConfigType
 	AutoRegister bool
 	StylingType  domain.IDPConfigStylingType
 }
@@ -42,6 +43,7 @@ func (rm *IDPConfigWriteModel) Reduce() error {
 func (rm *IDPConfigWriteModel) reduceConfigAddedEvent(e *idpconfig.IDPConfigAddedEvent) {
 	rm.ConfigID = e.ConfigID
 	rm.Name = e.Name
+	rm.Type = e.Typ
 	rm.StylingType = e.StylingType
 	rm.AutoRegister = e.AutoRegister
 	rm.State = domain.IDPConfigStateActive
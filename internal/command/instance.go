@@ -80,6 +80,9 @@ type InstanceSetup struct {
 	NotificationPolicy struct {
 		PasswordChange bool
 	}
+	ImpersonationPolicy struct {
+		AllowImpersonation bool
+	}
 	PrivacyPolicy struct {
 		TOSLink      string
 		PrivacyLink  string
@@ -112,6 +115,7 @@ type InstanceSetup struct {
 		IdTokenLifetime            time.Duration
 		RefreshTokenIdleExpiration time.Duration
 		RefreshTokenExpiration     time.Duration
+		SigningAlgorithm           string
 	}
 	Quotas *struct {
 		Items []*SetQuota
@@ -268,6 +272,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 
 		prepareAddDefaultPrivacyPolicy(instanceAgg, setup.PrivacyPolicy.TOSLink, setup.PrivacyPolicy.PrivacyLink, setup.PrivacyPolicy.HelpLink, setup.PrivacyPolicy.SupportEmail),
 		prepareAddDefaultNotificationPolicy(instanceAgg, setup.NotificationPolicy.PasswordChange),
+		prepareAddDefaultImpersonationPolicy(instanceAgg, setup.ImpersonationPolicy.AllowImpersonation),
 		prepareAddDefaultLockoutPolicy(instanceAgg, setup.LockoutPolicy.MaxAttempts, setup.LockoutPolicy.ShouldShowLockoutFailure),
 
 		prepareAddDefaultLabelPolicy(
@@ -441,6 +446,7 @@ func (c *Commands) SetUpInstance(ctx context.Context, setup *InstanceSetup) (str
 				setup.OIDCSettings.IdTokenLifetime,
 				setup.OIDCSettings.RefreshTokenIdleExpiration,
 				setup.OIDCSettings.RefreshTokenExpiration,
+				setup.OIDCSettings.SigningAlgorithm,
 			),
 		)
 	}
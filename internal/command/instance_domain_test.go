@@ -161,6 +161,13 @@ func TestCommandSide_AddInstanceDomain(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								"",
+								"",
+								false,
+								false,
+								false,
+								false,
+								"",
 							),
 						),
 					),
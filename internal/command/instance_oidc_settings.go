@@ -6,13 +6,14 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/repository/instance"
 )
 
-func (c *Commands) prepareAddOIDCSettings(a *instance.Aggregate, accessTokenLifetime, idTokenLifetime, refreshTokenIdleExpiration, refreshTokenExpiration time.Duration) preparation.Validation {
+func (c *Commands) prepareAddOIDCSettings(a *instance.Aggregate, accessTokenLifetime, idTokenLifetime, refreshTokenIdleExpiration, refreshTokenExpiration time.Duration, signingAlgorithm string) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if accessTokenLifetime == time.Duration(0) ||
 			idTokenLifetime == time.Duration(0) ||
@@ -20,6 +21,9 @@ func (c *Commands) prepareAddOIDCSettings(a *instance.Aggregate, accessTokenLife
 			refreshTokenExpiration == time.Duration(0) {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-10s82j", "Errors.Invalid.Argument")
 		}
+		if signingAlgorithm != "" && !crypto.IsSigningAlgorithmSupported(signingAlgorithm) {
+			return nil, errors.ThrowInvalidArgument(nil, "INST-ohX3a", "Errors.Key.AlgorithmNotSupported")
+		}
 
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			writeModel, err := c.getOIDCSettingsWriteModel(ctx, filter)
@@ -37,13 +41,14 @@ func (c *Commands) prepareAddOIDCSettings(a *instance.Aggregate, accessTokenLife
 					idTokenLifetime,
 					refreshTokenIdleExpiration,
 					refreshTokenExpiration,
+					signingAlgorithm,
 				),
 			}, nil
 		}, nil
 	}
 }
 
-func (c *Commands) prepareUpdateOIDCSettings(a *instance.Aggregate, accessTokenLifetime, idTokenLifetime, refreshTokenIdleExpiration, refreshTokenExpiration time.Duration) preparation.Validation {
+func (c *Commands) prepareUpdateOIDCSettings(a *instance.Aggregate, accessTokenLifetime, idTokenLifetime, refreshTokenIdleExpiration, refreshTokenExpiration time.Duration, signingAlgorithm string) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
 		if accessTokenLifetime == time.Duration(0) ||
 			idTokenLifetime == time.Duration(0) ||
@@ -51,6 +56,9 @@ func (c *Commands) prepareUpdateOIDCSettings(a *instance.Aggregate, accessTokenL
 			refreshTokenExpiration == time.Duration(0) {
 			return nil, errors.ThrowInvalidArgument(nil, "INST-10sxks", "Errors.Invalid.Argument")
 		}
+		if signingAlgorithm != "" && !crypto.IsSigningAlgorithmSupported(signingAlgorithm) {
+			return nil, errors.ThrowInvalidArgument(nil, "INST-Ieth1", "Errors.Key.AlgorithmNotSupported")
+		}
 
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			writeModel, err := c.getOIDCSettingsWriteModel(ctx, filter)
@@ -67,6 +75,7 @@ func (c *Commands) prepareUpdateOIDCSettings(a *instance.Aggregate, accessTokenL
 				idTokenLifetime,
 				refreshTokenIdleExpiration,
 				refreshTokenExpiration,
+				signingAlgorithm,
 			)
 			if err != nil {
 				return nil, err
@@ -83,7 +92,7 @@ func (c *Commands) prepareUpdateOIDCSettings(a *instance.Aggregate, accessTokenL
 
 func (c *Commands) AddOIDCSettings(ctx context.Context, settings *domain.OIDCSettings) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareAddOIDCSettings(instanceAgg, settings.AccessTokenLifetime, settings.IdTokenLifetime, settings.RefreshTokenIdleExpiration, settings.RefreshTokenExpiration)
+	validation := c.prepareAddOIDCSettings(instanceAgg, settings.AccessTokenLifetime, settings.IdTokenLifetime, settings.RefreshTokenIdleExpiration, settings.RefreshTokenExpiration, settings.SigningAlgorithm)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
@@ -101,7 +110,7 @@ func (c *Commands) AddOIDCSettings(ctx context.Context, settings *domain.OIDCSet
 
 func (c *Commands) ChangeOIDCSettings(ctx context.Context, settings *domain.OIDCSettings) (*domain.ObjectDetails, error) {
 	instanceAgg := instance.NewAggregate(authz.GetInstance(ctx).InstanceID())
-	validation := c.prepareUpdateOIDCSettings(instanceAgg, settings.AccessTokenLifetime, settings.IdTokenLifetime, settings.RefreshTokenIdleExpiration, settings.RefreshTokenExpiration)
+	validation := c.prepareUpdateOIDCSettings(instanceAgg, settings.AccessTokenLifetime, settings.IdTokenLifetime, settings.RefreshTokenIdleExpiration, settings.RefreshTokenExpiration, settings.SigningAlgorithm)
 	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validation)
 	if err != nil {
 		return nil, err
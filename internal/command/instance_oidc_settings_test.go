@@ -45,6 +45,7 @@ func TestCommandSide_AddOIDCConfig(t *testing.T) {
 								time.Hour*1,
 								time.Hour*1,
 								time.Hour*1,
+								"",
 							),
 						),
 					),
@@ -77,6 +78,7 @@ func TestCommandSide_AddOIDCConfig(t *testing.T) {
 							time.Hour*1,
 							time.Hour*1,
 							time.Hour*1,
+							"",
 						),
 					),
 				),
@@ -96,6 +98,27 @@ func TestCommandSide_AddOIDCConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "add oidc settings, unsupported signing algorithm",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				oidcConfig: &domain.OIDCSettings{
+					AccessTokenLifetime:        1 * time.Hour,
+					IdTokenLifetime:            1 * time.Hour,
+					RefreshTokenIdleExpiration: 1 * time.Hour,
+					RefreshTokenExpiration:     1 * time.Hour,
+					SigningAlgorithm:           "HS256",
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "add oidc settings, invalid argument 1",
 			fields: fields{
@@ -329,6 +352,7 @@ func TestCommandSide_ChangeOIDCConfig(t *testing.T) {
 								time.Hour*1,
 								time.Hour*1,
 								time.Hour*1,
+								"",
 							),
 						),
 					),
@@ -361,6 +385,7 @@ func TestCommandSide_ChangeOIDCConfig(t *testing.T) {
 								time.Hour*1,
 								time.Hour*1,
 								time.Hour*1,
+								"",
 							),
 						),
 					),
@@ -389,6 +414,53 @@ func TestCommandSide_ChangeOIDCConfig(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "oidc settings change signing algorithm, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewOIDCSettingsAddedEvent(
+								context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								time.Hour*1,
+								time.Hour*1,
+								time.Hour*1,
+								time.Hour*1,
+								"RS256",
+							),
+						),
+					),
+					expectPush(
+						func() *instance.OIDCSettingsChangedEvent {
+							event, _ := instance.NewOIDCSettingsChangeEvent(context.Background(),
+								&instance.NewAggregate("INSTANCE").Aggregate,
+								[]instance.OIDCSettingsChanges{
+									instance.ChangeOIDCSettingsSigningAlgorithm("ES256"),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithInstanceID(context.Background(), "INSTANCE"),
+				oidcConfig: &domain.OIDCSettings{
+					AccessTokenLifetime:        1 * time.Hour,
+					IdTokenLifetime:            1 * time.Hour,
+					RefreshTokenIdleExpiration: 1 * time.Hour,
+					RefreshTokenExpiration:     1 * time.Hour,
+					SigningAlgorithm:           "ES256",
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "INSTANCE",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -0,0 +1,72 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type InstanceImpersonationPolicyWriteModel struct {
+	ImpersonationPolicyWriteModel
+}
+
+func NewInstanceImpersonationPolicyWriteModel(ctx context.Context) *InstanceImpersonationPolicyWriteModel {
+	return &InstanceImpersonationPolicyWriteModel{
+		ImpersonationPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   authz.GetInstance(ctx).InstanceID(),
+				ResourceOwner: authz.GetInstance(ctx).InstanceID(),
+			},
+		},
+	}
+}
+
+func (wm *InstanceImpersonationPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *instance.ImpersonationPolicyAddedEvent:
+			wm.ImpersonationPolicyWriteModel.AppendEvents(&e.ImpersonationPolicyAddedEvent)
+		case *instance.ImpersonationPolicyChangedEvent:
+			wm.ImpersonationPolicyWriteModel.AppendEvents(&e.ImpersonationPolicyChangedEvent)
+		}
+	}
+}
+
+func (wm *InstanceImpersonationPolicyWriteModel) Reduce() error {
+	return wm.ImpersonationPolicyWriteModel.Reduce()
+}
+
+func (wm *InstanceImpersonationPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(instance.AggregateType).
+		AggregateIDs(wm.ImpersonationPolicyWriteModel.AggregateID).
+		EventTypes(
+			instance.ImpersonationPolicyAddedEventType,
+			instance.ImpersonationPolicyChangedEventType).
+		Builder()
+}
+
+func (wm *InstanceImpersonationPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	allowImpersonation bool,
+) (*instance.ImpersonationPolicyChangedEvent, bool) {
+
+	changes := make([]policy.ImpersonationPolicyChanges, 0)
+	if wm.AllowImpersonation != allowImpersonation {
+		changes = append(changes, policy.ChangeAllowImpersonation(allowImpersonation))
+	}
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := instance.NewImpersonationPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
@@ -14,8 +14,10 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/keypair"
 )
 
+// GenerateSigningKeyPair generates a key pair for the JOSE signature algorithm (e.g. RS256, ES256 or EdDSA).
+// The key size is only used for RSA keys.
 func (c *Commands) GenerateSigningKeyPair(ctx context.Context, algorithm string) error {
-	privateCrypto, publicCrypto, err := crypto.GenerateEncryptedKeyPair(c.keySize, c.keyAlgorithm)
+	privateCrypto, publicCrypto, err := crypto.GenerateEncryptedSigningKeyPair(algorithm, c.keySize, c.keyAlgorithm)
 	if err != nil {
 		return err
 	}
@@ -0,0 +1,163 @@
+package command
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/ldapsync"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// SetLDAPSync sets the mapping of LDAP groups to project roles and the schedule of the user synchronisation
+// of the LDAP identity provider of the resource owner (instance or organization).
+// Projects of organization providers must be owned by the organization,
+// instance providers can map to projects of any organization.
+func (c *Commands) SetLDAPSync(ctx context.Context, sync *domain.LDAPSync, resourceOwner string) (*domain.ObjectDetails, error) {
+	if !sync.IsValid() {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ooP7a", "Errors.LDAPSync.Invalid")
+	}
+	if err := c.checkLDAPProviderExists(ctx, sync.AggregateID, resourceOwner); err != nil {
+		return nil, err
+	}
+	projectResourceOwner := resourceOwner
+	if resourceOwner == authz.GetInstance(ctx).InstanceID() {
+		projectResourceOwner = ""
+	}
+	checked := make(map[string]bool, len(sync.GroupMappings))
+	for _, mapping := range sync.GroupMappings {
+		if checked[mapping.ProjectID] {
+			continue
+		}
+		if err := c.checkProjectExists(ctx, mapping.ProjectID, projectResourceOwner); err != nil {
+			return nil, err
+		}
+		checked[mapping.ProjectID] = true
+	}
+	writeModel, err := c.ldapSyncWriteModelByID(ctx, sync.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.isEqual(sync) {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Eiv5a", "Errors.NoChangesFound")
+	}
+	return c.pushLDAPSync(ctx, writeModel, ldapsync.NewSetEvent(
+		ctx,
+		ldapSyncAggregateFromWriteModel(ctx, writeModel),
+		sync.GroupAttribute,
+		sync.GroupMappings,
+		sync.SyncEnabled,
+		sync.SyncInterval,
+	))
+}
+
+func (c *Commands) RemoveLDAPSync(ctx context.Context, idpID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if idpID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Xah0i", "Errors.IDMissing")
+	}
+	writeModel, err := c.ldapSyncWriteModelByID(ctx, idpID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.Exists {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Ohy4e", "Errors.LDAPSync.NotFound")
+	}
+	return c.pushLDAPSync(ctx, writeModel, ldapsync.NewRemovedEvent(
+		ctx,
+		ldapSyncAggregateFromWriteModel(ctx, writeModel),
+	))
+}
+
+// StartLDAPSync marks the start of a synchronisation run.
+// It fails if the synchronisation is not enabled, the last run was started less than the interval ago
+// or another instance of ZITADEL started the run in the meantime.
+func (c *Commands) StartLDAPSync(ctx context.Context, idpID, resourceOwner string) (*domain.ObjectDetails, error) {
+	writeModel, err := c.ldapSyncWriteModelByID(ctx, idpID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.Exists || !writeModel.SyncEnabled {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ahx1e", "Errors.LDAPSync.NotEnabled")
+	}
+	now := time.Now()
+	if now.Sub(writeModel.LastStarted) < writeModel.SyncInterval {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Iep6u", "Errors.LDAPSync.NotDue")
+	}
+	return c.pushLDAPSync(ctx, writeModel, ldapsync.NewStartedEvent(
+		ctx,
+		ldapSyncAggregateFromWriteModel(ctx, writeModel),
+		now.Truncate(writeModel.SyncInterval).Unix(),
+	))
+}
+
+// FinishLDAPSync stores the report of the started synchronisation run.
+func (c *Commands) FinishLDAPSync(ctx context.Context, idpID, resourceOwner string, report *domain.LDAPSyncReport) (*domain.ObjectDetails, error) {
+	writeModel, err := c.ldapSyncWriteModelByID(ctx, idpID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.RunningSlot == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-ahS4o", "Errors.LDAPSync.NotRunning")
+	}
+	return c.pushLDAPSync(ctx, writeModel, ldapsync.NewFinishedEvent(
+		ctx,
+		ldapSyncAggregateFromWriteModel(ctx, writeModel),
+		writeModel.RunningSlot,
+		report,
+	))
+}
+
+func (c *Commands) pushLDAPSync(ctx context.Context, writeModel *LDAPSyncWriteModel, event eventstore.Command) (*domain.ObjectDetails, error) {
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// checkLDAPProviderExists checks the LDAP identity provider of the instance or the organization
+func (c *Commands) checkLDAPProviderExists(ctx context.Context, idpID, resourceOwner string) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	var state domain.IDPState
+	if instanceID := authz.GetInstance(ctx).InstanceID(); resourceOwner == instanceID {
+		writeModel := NewLDAPInstanceIDPWriteModel(instanceID, idpID)
+		err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+		state = writeModel.State
+	} else {
+		writeModel := NewLDAPOrgIDPWriteModel(resourceOwner, idpID)
+		err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+		state = writeModel.State
+	}
+	if err != nil {
+		return err
+	}
+	if state != domain.IDPStateActive {
+		return errors.ThrowPreconditionFailed(nil, "COMMAND-ke9Ae", "Errors.IDPConfig.NotExisting")
+	}
+	return nil
+}
+
+func (c *Commands) ldapSyncWriteModelByID(ctx context.Context, idpID, resourceOwner string) (writeModel *LDAPSyncWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewLDAPSyncWriteModel(idpID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
+
+func ldapSyncAggregateFromWriteModel(ctx context.Context, wm *LDAPSyncWriteModel) *eventstore.Aggregate {
+	return ldapsync.NewAggregate(wm.AggregateID, wm.ResourceOwner, authz.GetInstance(ctx).InstanceID())
+}
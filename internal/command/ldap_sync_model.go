@@ -0,0 +1,82 @@
+package command
+
+import (
+	"slices"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/ldapsync"
+)
+
+type LDAPSyncWriteModel struct {
+	eventstore.WriteModel
+
+	GroupAttribute string
+	GroupMappings  []*domain.LDAPGroupMapping
+	SyncEnabled    bool
+	SyncInterval   time.Duration
+	Exists         bool
+
+	LastStarted time.Time
+	// RunningSlot is the slot of the started run, it's 0 if no run is started
+	RunningSlot int64
+}
+
+func NewLDAPSyncWriteModel(idpID, resourceOwner string) *LDAPSyncWriteModel {
+	return &LDAPSyncWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   idpID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *LDAPSyncWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *ldapsync.SetEvent:
+			wm.GroupAttribute = e.GroupAttribute
+			wm.GroupMappings = e.GroupMappings
+			wm.SyncEnabled = e.SyncEnabled
+			wm.SyncInterval = e.SyncInterval
+			wm.Exists = true
+		case *ldapsync.RemovedEvent:
+			wm.GroupAttribute = ""
+			wm.GroupMappings = nil
+			wm.SyncEnabled = false
+			wm.SyncInterval = 0
+			wm.Exists = false
+		case *ldapsync.StartedEvent:
+			wm.LastStarted = e.CreationDate()
+			wm.RunningSlot = e.Slot
+		case *ldapsync.FinishedEvent:
+			wm.RunningSlot = 0
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *LDAPSyncWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(ldapsync.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			ldapsync.SetEventType,
+			ldapsync.RemovedEventType,
+			ldapsync.StartedEventType,
+			ldapsync.FinishedEventType).
+		Builder()
+}
+
+func (wm *LDAPSyncWriteModel) isEqual(sync *domain.LDAPSync) bool {
+	return wm.Exists &&
+		wm.GroupAttribute == sync.GroupAttribute &&
+		wm.SyncEnabled == sync.SyncEnabled &&
+		wm.SyncInterval == sync.SyncInterval &&
+		slices.EqualFunc(wm.GroupMappings, sync.GroupMappings, func(a, b *domain.LDAPGroupMapping) bool {
+			return a.Group == b.Group && a.ProjectID == b.ProjectID && slices.Equal(a.Roles, b.Roles)
+		})
+}
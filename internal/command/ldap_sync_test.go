@@ -0,0 +1,402 @@
+package command
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/ldapsync"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommandSide_SetLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		sync          *domain.LDAPSync
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	mappings := []*domain.LDAPGroupMapping{
+		{Group: "cn=admins,dc=example,dc=com", ProjectID: "project1", Roles: []string{"admin"}},
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "mappings without group attribute, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: ctx,
+				sync: &domain.LDAPSync{
+					ObjectRoot:    models.ObjectRoot{AggregateID: "idp1"},
+					GroupMappings: mappings,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "idp not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: ctx,
+				sync: &domain.LDAPSync{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "idp1"},
+					GroupAttribute: "memberOf",
+					GroupMappings:  mappings,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "project not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(orgLDAPIDPAddedEvent()),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: ctx,
+				sync: &domain.LDAPSync{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "idp1"},
+					GroupAttribute: "memberOf",
+					GroupMappings:  mappings,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "unchanged, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(orgLDAPIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(projectAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							ldapsync.NewSetEvent(ctx,
+								ldapsync.NewAggregate("idp1", "org1", "instance1"),
+								"memberOf",
+								mappings,
+								true,
+								time.Hour,
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: ctx,
+				sync: &domain.LDAPSync{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "idp1"},
+					GroupAttribute: "memberOf",
+					GroupMappings:  mappings,
+					SyncEnabled:    true,
+					SyncInterval:   time.Hour,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "set sync, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(orgLDAPIDPAddedEvent()),
+					),
+					expectFilter(
+						eventFromEventPusher(projectAddedEvent()),
+					),
+					expectFilter(),
+					expectPush(
+						ldapsync.NewSetEvent(ctx,
+							ldapsync.NewAggregate("idp1", "org1", "instance1"),
+							"memberOf",
+							mappings,
+							true,
+							time.Hour,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: ctx,
+				sync: &domain.LDAPSync{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "idp1"},
+					GroupAttribute: "memberOf",
+					GroupMappings:  mappings,
+					SyncEnabled:    true,
+					SyncInterval:   time.Hour,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetLDAPSync(tt.args.ctx, tt.args.sync, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_StartLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	aggregate := ldapsync.NewAggregate("idp1", "org1", "instance1")
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "sync not enabled, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							ldapsync.NewSetEvent(ctx, aggregate, "memberOf", nil, false, 0),
+						),
+					),
+				),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "last run within interval, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							ldapsync.NewSetEvent(ctx, aggregate, "memberOf", nil, true, time.Hour),
+						),
+						eventFromEventPusherWithCreationDateNow(
+							ldapsync.NewStartedEvent(ctx, aggregate, 1),
+						),
+					),
+				),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "start sync, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							ldapsync.NewSetEvent(ctx, aggregate, "memberOf", nil, true, time.Hour),
+						),
+					),
+					expectPush(
+						ldapsync.NewStartedEvent(ctx, aggregate, time.Now().Truncate(time.Hour).Unix()),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.StartLDAPSync(ctx, "idp1", "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_FinishLDAPSync(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	aggregate := ldapsync.NewAggregate("idp1", "org1", "instance1")
+	report := &domain.LDAPSyncReport{UsersFound: 1}
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "not running, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							ldapsync.NewSetEvent(ctx, aggregate, "memberOf", nil, true, time.Hour),
+						),
+						eventFromEventPusher(
+							ldapsync.NewStartedEvent(ctx, aggregate, 1),
+						),
+						eventFromEventPusher(
+							ldapsync.NewFinishedEvent(ctx, aggregate, 1, report),
+						),
+					),
+				),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "finish sync, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							ldapsync.NewSetEvent(ctx, aggregate, "memberOf", nil, true, time.Hour),
+						),
+						eventFromEventPusher(
+							ldapsync.NewStartedEvent(ctx, aggregate, 1),
+						),
+					),
+					expectPush(
+						ldapsync.NewFinishedEvent(ctx, aggregate, 1, report),
+					),
+				),
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.FinishLDAPSync(ctx, "idp1", "org1", report)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func orgLDAPIDPAddedEvent() *org.LDAPIDPAddedEvent {
+	return org.NewLDAPIDPAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate,
+		"idp1",
+		"name",
+		[]string{"server"},
+		false,
+		"baseDN",
+		"dn",
+		&crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte("password"),
+		},
+		"user",
+		[]string{"object"},
+		[]string{"filter"},
+		time.Second*30,
+		idp.LDAPAttributes{},
+		idp.Options{},
+	)
+}
+
+func projectAddedEvent() *project.ProjectAddedEvent {
+	return project.NewProjectAddedEvent(context.Background(),
+		&project.NewAggregate("project1", "org1").Aggregate,
+		"project",
+		false,
+		false,
+		false,
+		domain.PrivateLabelingSettingUnspecified,
+	)
+}
@@ -0,0 +1,105 @@
+package command
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+// LDAPUserGrant is the desired and the existing grant of a user on a project of the LDAP group mappings
+type LDAPUserGrant struct {
+	ProjectID            string
+	ProjectResourceOwner string
+	// Roles are the roles granted by the groups of the user, nil if the user isn't member of a mapped group of the project
+	Roles []string
+
+	// GrantID is the id of the existing grant of the user on the project (without project grant), if any
+	GrantID string
+}
+
+type LDAPUserGrantChanges struct {
+	Added   uint32
+	Changed uint32
+	Removed uint32
+}
+
+// SetLDAPUserGrants adds, changes and removes the grants of the user, so they match the LDAP groups of the user.
+// The grants are managed by the LDAP identity provider, therefore no permission of the caller is checked.
+func (c *Commands) SetLDAPUserGrants(ctx context.Context, userID string, grants []*LDAPUserGrant) (*LDAPUserGrantChanges, error) {
+	changes := new(LDAPUserGrantChanges)
+	cmds := make([]eventstore.Command, 0, len(grants))
+	for _, grant := range grants {
+		cmd, err := c.setLDAPUserGrant(ctx, userID, grant, changes)
+		if err != nil {
+			return nil, err
+		}
+		if cmd != nil {
+			cmds = append(cmds, cmd)
+		}
+	}
+	if len(cmds) == 0 {
+		return changes, nil
+	}
+	if _, err := c.eventstore.Push(ctx, cmds...); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+func (c *Commands) setLDAPUserGrant(ctx context.Context, userID string, grant *LDAPUserGrant, changes *LDAPUserGrantChanges) (eventstore.Command, error) {
+	switch {
+	case grant.Roles == nil && grant.GrantID == "":
+		return nil, nil
+	case grant.GrantID == "":
+		cmd, _, err := c.addUserGrant(ctx, &domain.UserGrant{
+			UserID:    userID,
+			ProjectID: grant.ProjectID,
+			RoleKeys:  grant.Roles,
+		}, grant.ProjectResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		changes.Added++
+		return cmd, nil
+	}
+	existing, err := c.userGrantWriteModelByID(ctx, grant.GrantID, "")
+	if err != nil {
+		return nil, err
+	}
+	if existing.State == domain.UserGrantStateUnspecified || existing.State == domain.UserGrantStateRemoved {
+		return nil, nil
+	}
+	aggregate := UserGrantAggregateFromWriteModel(&existing.WriteModel)
+	if grant.Roles == nil {
+		changes.Removed++
+		return usergrant.NewUserGrantRemovedEvent(ctx, aggregate, existing.UserID, existing.ProjectID, existing.ProjectGrantID), nil
+	}
+	if equalRoles(existing.RoleKeys, grant.Roles) {
+		return nil, nil
+	}
+	err = c.checkUserGrantPreCondition(ctx, &domain.UserGrant{
+		UserID:    userID,
+		ProjectID: existing.ProjectID,
+		RoleKeys:  grant.Roles,
+	}, existing.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	changes.Changed++
+	return usergrant.NewUserGrantChangedEvent(ctx, aggregate, grant.Roles), nil
+}
+
+func equalRoles(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for _, role := range a {
+		if !slices.Contains(b, role) {
+			return false
+		}
+	}
+	return true
+}
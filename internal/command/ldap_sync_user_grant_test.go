@@ -0,0 +1,222 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+func TestCommandSide_SetLDAPUserGrants(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		grants []*LDAPUserGrant
+	}
+	type res struct {
+		want *LDAPUserGrantChanges
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no groups and no grant, no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				grants: []*LDAPUserGrant{
+					{ProjectID: "project1", ProjectResourceOwner: "org1"},
+				},
+			},
+			res: res{
+				want: &LDAPUserGrantChanges{},
+			},
+		},
+		{
+			name: "role not existing, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username1",
+								"firstname1",
+								"lastname1",
+								"nickname1",
+								"displayname1",
+								language.German,
+								domain.GenderMale,
+								"email1",
+								true,
+							),
+						),
+						eventFromEventPusher(projectAddedEvent()),
+					),
+				),
+			},
+			args: args{
+				grants: []*LDAPUserGrant{
+					{ProjectID: "project1", ProjectResourceOwner: "org1", Roles: []string{"rolekey1"}},
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "add grant, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							user.NewHumanAddedEvent(context.Background(),
+								&user.NewAggregate("user1", "org1").Aggregate,
+								"username1",
+								"firstname1",
+								"lastname1",
+								"nickname1",
+								"displayname1",
+								language.German,
+								domain.GenderMale,
+								"email1",
+								true,
+							),
+						),
+						eventFromEventPusher(projectAddedEvent()),
+						eventFromEventPusher(
+							project.NewRoleAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"rolekey1",
+								"rolekey",
+								"",
+							),
+						),
+					),
+					expectPush(
+						usergrant.NewUserGrantAddedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+							[]string{"rolekey1"},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "usergrant1"),
+			},
+			args: args{
+				grants: []*LDAPUserGrant{
+					{ProjectID: "project1", ProjectResourceOwner: "org1", Roles: []string{"rolekey1"}},
+				},
+			},
+			res: res{
+				want: &LDAPUserGrantChanges{Added: 1},
+			},
+		},
+		{
+			name: "same roles, no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"",
+								[]string{"rolekey1", "rolekey2"},
+							),
+						),
+					),
+				),
+			},
+			args: args{
+				grants: []*LDAPUserGrant{
+					{
+						ProjectID:            "project1",
+						ProjectResourceOwner: "org1",
+						Roles:                []string{"rolekey2", "rolekey1"},
+						GrantID:              "usergrant1",
+					},
+				},
+			},
+			res: res{
+				want: &LDAPUserGrantChanges{},
+			},
+		},
+		{
+			name: "not member of group anymore, remove grant, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"",
+								[]string{"rolekey1"},
+							),
+						),
+					),
+					expectPush(
+						usergrant.NewUserGrantRemovedEvent(context.Background(),
+							&usergrant.NewAggregate("usergrant1", "org1").Aggregate,
+							"user1",
+							"project1",
+							"",
+						),
+					),
+				),
+			},
+			args: args{
+				grants: []*LDAPUserGrant{
+					{
+						ProjectID:            "project1",
+						ProjectResourceOwner: "org1",
+						GrantID:              "usergrant1",
+					},
+				},
+			},
+			res: res{
+				want: &LDAPUserGrantChanges{Removed: 1},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := r.SetLDAPUserGrants(context.Background(), "user1", tt.args.grants)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
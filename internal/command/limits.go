@@ -13,7 +13,8 @@ import (
 )
 
 type SetLimits struct {
-	AuditLogRetention *time.Duration
+	AuditLogRetention        *time.Duration
+	ActionExecutionRetention *time.Duration
 }
 
 // SetLimits creates new limits or updates existing limits.
@@ -84,7 +85,7 @@ func (c *Commands) getLimitsWriteModel(ctx context.Context, instanceId, resource
 
 func (c *Commands) SetLimitsCommand(a *limits.Aggregate, wm *limitsWriteModel, setLimits *SetLimits) preparation.Validation {
 	return func() (preparation.CreateCommands, error) {
-		if setLimits == nil || setLimits.AuditLogRetention == nil {
+		if setLimits == nil || (setLimits.AuditLogRetention == nil && setLimits.ActionExecutionRetention == nil) {
 			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-4M9vs", "Errors.Limits.NoneSpecified")
 		}
 		return func(ctx context.Context, _ preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
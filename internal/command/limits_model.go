@@ -9,8 +9,9 @@ import (
 
 type limitsWriteModel struct {
 	eventstore.WriteModel
-	rollingAggregateID string
-	auditLogRetention  *time.Duration
+	rollingAggregateID       string
+	auditLogRetention        *time.Duration
+	actionExecutionRetention *time.Duration
 }
 
 // newLimitsWriteModel aggregateId is filled by reducing unit matching events
@@ -46,9 +47,13 @@ func (wm *limitsWriteModel) Reduce() error {
 			if e.AuditLogRetention != nil {
 				wm.auditLogRetention = e.AuditLogRetention
 			}
+			if e.ActionExecutionRetention != nil {
+				wm.actionExecutionRetention = e.ActionExecutionRetention
+			}
 		case *limits.ResetEvent:
 			wm.rollingAggregateID = ""
 			wm.auditLogRetention = nil
+			wm.actionExecutionRetention = nil
 		}
 	}
 	if err := wm.WriteModel.Reduce(); err != nil {
@@ -65,9 +70,12 @@ func (wm *limitsWriteModel) NewChanges(setLimits *SetLimits) (changes []limits.L
 	if setLimits == nil {
 		return nil
 	}
-	changes = make([]limits.LimitsChange, 0, 1)
+	changes = make([]limits.LimitsChange, 0, 2)
 	if setLimits.AuditLogRetention != nil && (wm.auditLogRetention == nil || *wm.auditLogRetention != *setLimits.AuditLogRetention) {
 		changes = append(changes, limits.ChangeAuditLogRetention(setLimits.AuditLogRetention))
 	}
+	if setLimits.ActionExecutionRetention != nil && (wm.actionExecutionRetention == nil || *wm.actionExecutionRetention != *setLimits.ActionExecutionRetention) {
+		changes = append(changes, limits.ChangeActionExecutionRetention(setLimits.ActionExecutionRetention))
+	}
 	return changes
 }
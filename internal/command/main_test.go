@@ -20,19 +20,23 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore/repository/mock"
 	action_repo "github.com/zitadel/zitadel/internal/repository/action"
 	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
 	"github.com/zitadel/zitadel/internal/repository/feature"
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
 	key_repo "github.com/zitadel/zitadel/internal/repository/keypair"
+	"github.com/zitadel/zitadel/internal/repository/ldapsync"
 	"github.com/zitadel/zitadel/internal/repository/limits"
 	"github.com/zitadel/zitadel/internal/repository/oidcsession"
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
 	quota_repo "github.com/zitadel/zitadel/internal/repository/quota"
 	"github.com/zitadel/zitadel/internal/repository/restrictions"
+	"github.com/zitadel/zitadel/internal/repository/samlsession"
 	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/repository/userimport"
 )
 
 type expect func(mockRepository *mock.MockRepository)
@@ -58,7 +62,11 @@ func eventstoreExpect(t *testing.T, expects ...expect) *eventstore.Eventstore {
 	session.RegisterEventMappers(es)
 	idpintent.RegisterEventMappers(es)
 	authrequest.RegisterEventMappers(es)
+	backchannelauth.RegisterEventMappers(es)
+	ldapsync.RegisterEventMappers(es)
 	oidcsession.RegisterEventMappers(es)
+	samlsession.RegisterEventMappers(es)
+	userimport.RegisterEventMappers(es)
 	quota_repo.RegisterEventMappers(es)
 	limits.RegisterEventMappers(es)
 	restrictions.RegisterEventMappers(es)
@@ -29,8 +29,10 @@ const (
 
 // AddOIDCSessionAccessToken creates a new OIDC Session, creates an access token and returns its id and expiration.
 // If the underlying [AuthRequest] is a OIDC Auth Code Flow, it will set the code as exchanged.
-func (c *Commands) AddOIDCSessionAccessToken(ctx context.Context, authRequestID string) (string, time.Time, error) {
-	cmd, err := c.newOIDCSessionAddEvents(ctx, authRequestID)
+// If a dpopJKT is provided, the session and its tokens are bound to the DPoP key.
+// The issuer is stored on the session for the logout tokens of the back-channel logout.
+func (c *Commands) AddOIDCSessionAccessToken(ctx context.Context, authRequestID, dpopJKT, issuer string) (string, time.Time, error) {
+	cmd, err := c.newOIDCSessionAddEvents(ctx, authRequestID, dpopJKT, issuer)
 	if err != nil {
 		return "", time.Time{}, err
 	}
@@ -46,8 +48,10 @@ func (c *Commands) AddOIDCSessionAccessToken(ctx context.Context, authRequestID
 // AddOIDCSessionRefreshAndAccessToken creates a new OIDC Session, creates an access token and refresh token.
 // It returns the access token id, expiration and the refresh token.
 // If the underlying [AuthRequest] is a OIDC Auth Code Flow, it will set the code as exchanged.
-func (c *Commands) AddOIDCSessionRefreshAndAccessToken(ctx context.Context, authRequestID string) (tokenID, refreshToken string, tokenExpiration time.Time, err error) {
-	cmd, err := c.newOIDCSessionAddEvents(ctx, authRequestID)
+// If a dpopJKT is provided, the session and its tokens are bound to the DPoP key.
+// The issuer is stored on the session for the logout tokens of the back-channel logout.
+func (c *Commands) AddOIDCSessionRefreshAndAccessToken(ctx context.Context, authRequestID, dpopJKT, issuer string) (tokenID, refreshToken string, tokenExpiration time.Time, err error) {
+	cmd, err := c.newOIDCSessionAddEvents(ctx, authRequestID, dpopJKT, issuer)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
@@ -64,8 +68,9 @@ func (c *Commands) AddOIDCSessionRefreshAndAccessToken(ctx context.Context, auth
 
 // ExchangeOIDCSessionRefreshAndAccessToken updates an existing OIDC Session, creates a new access and refresh token.
 // It returns the access token id and expiration and the new refresh token.
-func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, oidcSessionID, refreshToken string, scope []string) (tokenID, newRefreshToken string, tokenExpiration time.Time, err error) {
-	cmd, err := c.newOIDCSessionUpdateEvents(ctx, oidcSessionID, refreshToken)
+// If the session is bound to a DPoP key, the dpopJKT must match it.
+func (c *Commands) ExchangeOIDCSessionRefreshAndAccessToken(ctx context.Context, oidcSessionID, refreshToken string, scope []string, dpopJKT string) (tokenID, newRefreshToken string, tokenExpiration time.Time, err error) {
+	cmd, err := c.newOIDCSessionUpdateEvents(ctx, oidcSessionID, refreshToken, dpopJKT)
 	if err != nil {
 		return "", "", time.Time{}, err
 	}
@@ -145,7 +150,7 @@ func (c *Commands) RevokeOIDCSessionToken(ctx context.Context, token, clientID s
 	return c.pushAppendAndReduce(ctx, writeModel, oidcsession.NewAccessTokenRevokedEvent(ctx, writeModel.aggregate))
 }
 
-func (c *Commands) newOIDCSessionAddEvents(ctx context.Context, authRequestID string) (*OIDCSessionEvents, error) {
+func (c *Commands) newOIDCSessionAddEvents(ctx context.Context, authRequestID, dpopJKT, issuer string) (*OIDCSessionEvents, error) {
 	authRequestWriteModel, err := c.getAuthRequestWriteModel(ctx, authRequestID)
 	if err != nil {
 		return nil, err
@@ -184,6 +189,8 @@ func (c *Commands) newOIDCSessionAddEvents(ctx context.Context, authRequestID st
 		accessTokenLifetime:      accessTokenLifetime,
 		refreshTokenLifeTime:     refreshTokenLifeTime,
 		refreshTokenIdleLifetime: refreshTokenIdleLifetime,
+		dpopJKT:                  dpopJKT,
+		issuer:                   issuer,
 	}, nil
 }
 
@@ -226,7 +233,7 @@ func parseRefreshToken(refreshToken string) (oidcSessionID, refreshTokenID strin
 	return split[0], strings.Split(split[1], oidcTokenSubjectDelimiter)[0], nil
 }
 
-func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, oidcSessionID, refreshToken string) (*OIDCSessionEvents, error) {
+func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, oidcSessionID, refreshToken, dpopJKT string) (*OIDCSessionEvents, error) {
 	refreshTokenID, err := c.decryptRefreshToken(refreshToken)
 	if err != nil {
 		return nil, err
@@ -238,6 +245,9 @@ func (c *Commands) newOIDCSessionUpdateEvents(ctx context.Context, oidcSessionID
 	if err = sessionWriteModel.CheckRefreshToken(refreshTokenID); err != nil {
 		return nil, err
 	}
+	if err = sessionWriteModel.CheckDPoPJKT(dpopJKT); err != nil {
+		return nil, err
+	}
 	accessTokenLifetime, refreshTokenLifeTime, refreshTokenIdleLifetime, err := c.tokenTokenLifetimes(ctx)
 	if err != nil {
 		return nil, err
@@ -264,6 +274,8 @@ type OIDCSessionEvents struct {
 	accessTokenLifetime      time.Duration
 	refreshTokenLifeTime     time.Duration
 	refreshTokenIdleLifetime time.Duration
+	dpopJKT                  string
+	issuer                   string
 
 	// accessTokenID is set by the command
 	accessTokenID string
@@ -283,6 +295,8 @@ func (c *OIDCSessionEvents) AddSession(ctx context.Context) {
 		c.authRequestWriteModel.Scope,
 		c.sessionWriteModel.AuthMethodTypes(),
 		c.sessionWriteModel.AuthenticationTime(),
+		c.dpopJKT,
+		c.issuer,
 	))
 }
 
@@ -27,6 +27,7 @@ type OIDCSessionWriteModel struct {
 	RefreshToken               string
 	RefreshTokenExpiration     time.Time
 	RefreshTokenIdleExpiration time.Time
+	DPoPJKT                    string
 
 	aggregate *eventstore.Aggregate
 }
@@ -89,6 +90,7 @@ func (wm *OIDCSessionWriteModel) reduceAdded(e *oidcsession.AddedEvent) {
 	wm.Scope = e.Scope
 	wm.AuthMethods = e.AuthMethods
 	wm.AuthTime = e.AuthTime
+	wm.DPoPJKT = e.DPoPJKT
 	wm.State = domain.OIDCSessionStateActive
 	// the write model might be initialized without resource owner,
 	// so update the aggregate
@@ -153,6 +155,15 @@ func (wm *OIDCSessionWriteModel) CheckAccessToken(accessTokenID string) error {
 	return nil
 }
 
+// CheckDPoPJKT checks that the thumbprint of the DPoP proof matches the key the session is bound to.
+// Sessions not bound to a key accept any (or no) proof.
+func (wm *OIDCSessionWriteModel) CheckDPoPJKT(jkt string) error {
+	if wm.DPoPJKT != "" && wm.DPoPJKT != jkt {
+		return caos_errs.ThrowPreconditionFailed(nil, "OIDCS-ooz4E", "Errors.OIDCSession.RefreshTokenInvalid")
+	}
+	return nil
+}
+
 func (wm *OIDCSessionWriteModel) CheckClient(clientID string) error {
 	for _, aud := range wm.Audience {
 		if aud == clientID {
@@ -4,6 +4,7 @@ import (
 	"context"
 	"sort"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	caos_errs "github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -41,14 +42,32 @@ func (c *Commands) addActionWithID(ctx context.Context, addAction *domain.Action
 	actionModel := NewActionWriteModel(addAction.AggregateID, resourceOwner)
 	actionAgg := ActionAggregateFromWriteModel(&actionModel.WriteModel)
 
-	pushedEvents, err := c.eventstore.Push(ctx, action.NewAddedEvent(
+	addedEvent := action.NewAddedEvent(
 		ctx,
 		actionAgg,
 		addAction.Name,
 		addAction.Script,
 		addAction.Timeout,
 		addAction.AllowedToFail,
-	))
+	)
+	if addAction.Type == domain.ActionTypeTarget {
+		signingKey, plainKey, err := c.newActionSigningKey(c.actionEncryption)
+		if err != nil {
+			return "", nil, err
+		}
+		addAction.SigningKey = plainKey
+		addedEvent = action.NewTargetAddedEvent(
+			ctx,
+			actionAgg,
+			addAction.Name,
+			addAction.TargetURL,
+			addAction.Timeout,
+			addAction.AllowedToFail,
+			addAction.MaxRetries,
+			signingKey,
+		)
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, addedEvent)
 	if err != nil {
 		return "", nil, err
 	}
@@ -71,6 +90,9 @@ func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action
 	if !existingAction.State.Exists() {
 		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Sfg2t", "Errors.Action.NotFound")
 	}
+	if existingAction.Type != actionChange.Type {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Iep4o", "Errors.Action.TypeNotChangeable")
+	}
 
 	actionAgg := ActionAggregateFromWriteModel(&existingAction.WriteModel)
 	changedEvent, err := existingAction.NewChangedEvent(
@@ -79,7 +101,9 @@ func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action
 		actionChange.Name,
 		actionChange.Script,
 		actionChange.Timeout,
-		actionChange.AllowedToFail)
+		actionChange.AllowedToFail,
+		actionChange.TargetURL,
+		actionChange.MaxRetries)
 	if err != nil {
 		return nil, err
 	}
@@ -94,6 +118,39 @@ func (c *Commands) ChangeAction(ctx context.Context, actionChange *domain.Action
 	return writeModelToObjectDetails(&existingAction.WriteModel), nil
 }
 
+// RegenerateActionSigningKey replaces the key the payload of the calls to the target of the action are signed with.
+// The new key is returned in plain and can't be retrieved afterwards.
+func (c *Commands) RegenerateActionSigningKey(ctx context.Context, actionID string, resourceOwner string) (string, *domain.ObjectDetails, error) {
+	if actionID == "" || resourceOwner == "" {
+		return "", nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-ieL4u", "Errors.IDMissing")
+	}
+
+	existingAction, err := c.getActionWriteModelByID(ctx, actionID, resourceOwner)
+	if err != nil {
+		return "", nil, err
+	}
+	if !existingAction.State.Exists() {
+		return "", nil, caos_errs.ThrowNotFound(nil, "COMMAND-Ohv8a", "Errors.Action.NotFound")
+	}
+	if existingAction.Type != domain.ActionTypeTarget {
+		return "", nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Gie5a", "Errors.Action.NotTarget")
+	}
+	signingKey, plainKey, err := c.newActionSigningKey(c.actionEncryption)
+	if err != nil {
+		return "", nil, err
+	}
+	actionAgg := ActionAggregateFromWriteModel(&existingAction.WriteModel)
+	pushedEvents, err := c.eventstore.Push(ctx, action.NewSigningKeyChangedEvent(ctx, actionAgg, signingKey))
+	if err != nil {
+		return "", nil, err
+	}
+	err = AppendAndReduce(existingAction, pushedEvents...)
+	if err != nil {
+		return "", nil, err
+	}
+	return plainKey, writeModelToObjectDetails(&existingAction.WriteModel), nil
+}
+
 func (c *Commands) DeactivateAction(ctx context.Context, actionID string, resourceOwner string) (*domain.ObjectDetails, error) {
 	if actionID == "" || resourceOwner == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-DAhk5", "Errors.IDMissing")
@@ -244,3 +301,14 @@ func (c *Commands) getActionsByOrgWriteModelByID(ctx context.Context, resourceOw
 	}
 	return actionWriteModel, nil
 }
+
+var actionSigningKeyConfig = crypto.GeneratorConfig{
+	Length:              32,
+	IncludeLowerLetters: true,
+	IncludeUpperLetters: true,
+	IncludeDigits:       true,
+}
+
+func newActionSigningKey(alg crypto.EncryptionAlgorithm) (*crypto.CryptoValue, string, error) {
+	return crypto.NewCode(crypto.NewEncryptionGenerator(actionSigningKeyConfig, alg))
+}
@@ -17,6 +17,9 @@ type ActionWriteModel struct {
 	Timeout       time.Duration
 	AllowedToFail bool
 	State         domain.ActionState
+	Type          domain.ActionType
+	TargetURL     string
+	MaxRetries    uint32
 }
 
 func NewActionWriteModel(actionID string, resourceOwner string) *ActionWriteModel {
@@ -36,6 +39,9 @@ func (wm *ActionWriteModel) Reduce() error {
 			wm.Script = e.Script
 			wm.Timeout = e.Timeout
 			wm.AllowedToFail = e.AllowedToFail
+			wm.Type = e.ActionType
+			wm.TargetURL = e.TargetURL
+			wm.MaxRetries = e.MaxRetries
 			wm.State = domain.ActionStateActive
 		case *action.ChangedEvent:
 			if e.Name != nil {
@@ -50,6 +56,12 @@ func (wm *ActionWriteModel) Reduce() error {
 			if e.AllowedToFail != nil {
 				wm.AllowedToFail = *e.AllowedToFail
 			}
+			if e.TargetURL != nil {
+				wm.TargetURL = *e.TargetURL
+			}
+			if e.MaxRetries != nil {
+				wm.MaxRetries = *e.MaxRetries
+			}
 		case *action.DeactivatedEvent:
 			wm.State = domain.ActionStateInactive
 		case *action.ReactivatedEvent:
@@ -71,7 +83,8 @@ func (wm *ActionWriteModel) Query() *eventstore.SearchQueryBuilder {
 			action.ChangedEventType,
 			action.DeactivatedEventType,
 			action.ReactivatedEventType,
-			action.RemovedEventType).
+			action.RemovedEventType,
+			action.SigningKeyChangedEventType).
 		Builder()
 }
 
@@ -82,6 +95,8 @@ func (wm *ActionWriteModel) NewChangedEvent(
 	script string,
 	timeout time.Duration,
 	allowedToFail bool,
+	targetURL string,
+	maxRetries uint32,
 ) (*action.ChangedEvent, error) {
 	changes := make([]action.ActionChanges, 0)
 	if wm.Name != name {
@@ -96,6 +111,12 @@ func (wm *ActionWriteModel) NewChangedEvent(
 	if wm.AllowedToFail != allowedToFail {
 		changes = append(changes, action.ChangeAllowedToFail(allowedToFail))
 	}
+	if wm.TargetURL != targetURL {
+		changes = append(changes, action.ChangeTargetURL(targetURL))
+	}
+	if wm.MaxRetries != maxRetries {
+		changes = append(changes, action.ChangeMaxRetries(maxRetries))
+	}
 	return action.NewChangedEvent(ctx, agg, changes)
 }
 
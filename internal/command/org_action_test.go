@@ -6,6 +6,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
@@ -16,10 +17,22 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/org"
 )
 
+func mockActionSigningKey(key string) func(crypto.EncryptionAlgorithm) (*crypto.CryptoValue, string, error) {
+	return func(crypto.EncryptionAlgorithm) (*crypto.CryptoValue, string, error) {
+		return &crypto.CryptoValue{
+			CryptoType: crypto.TypeEncryption,
+			Algorithm:  "enc",
+			KeyID:      "id",
+			Crypted:    []byte(key),
+		}, key, nil
+	}
+}
+
 func TestCommands_AddAction(t *testing.T) {
 	type fields struct {
-		eventstore  *eventstore.Eventstore
-		idGenerator id.Generator
+		eventstore          *eventstore.Eventstore
+		idGenerator         id.Generator
+		newActionSigningKey func(crypto.EncryptionAlgorithm) (*crypto.CryptoValue, string, error)
 	}
 	type args struct {
 		ctx           context.Context
@@ -27,9 +40,10 @@ func TestCommands_AddAction(t *testing.T) {
 		resourceOwner string
 	}
 	type res struct {
-		id      string
-		details *domain.ObjectDetails
-		err     func(error) bool
+		id         string
+		details    *domain.ObjectDetails
+		signingKey string
+		err        func(error) bool
 	}
 	tests := []struct {
 		name   string
@@ -113,12 +127,73 @@ func TestCommands_AddAction(t *testing.T) {
 				},
 			},
 		},
+		{
+			"target invalid url, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx: context.Background(),
+				addAction: &domain.Action{
+					Name:      "name",
+					Type:      domain.ActionTypeTarget,
+					TargetURL: "ftp://example.com",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"target push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						action.NewTargetAddedEvent(context.Background(),
+							&action.NewAggregate("id3", "org1").Aggregate,
+							"name3",
+							"https://example.com/hook",
+							0,
+							false,
+							3,
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("key"),
+							},
+						),
+					),
+				),
+				idGenerator:         mock.ExpectID(t, "id3"),
+				newActionSigningKey: mockActionSigningKey("key"),
+			},
+			args{
+				ctx: context.Background(),
+				addAction: &domain.Action{
+					Name:       "name3",
+					Type:       domain.ActionTypeTarget,
+					TargetURL:  "https://example.com/hook",
+					MaxRetries: 3,
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				id: "id3",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+				signingKey: "key",
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			c := &Commands{
-				eventstore:  tt.fields.eventstore,
-				idGenerator: tt.fields.idGenerator,
+				eventstore:          tt.fields.eventstore,
+				idGenerator:         tt.fields.idGenerator,
+				newActionSigningKey: tt.fields.newActionSigningKey,
 			}
 			id, details, err := c.AddAction(tt.args.ctx, tt.args.addAction, tt.args.resourceOwner)
 			if tt.res.err == nil {
@@ -130,6 +205,7 @@ func TestCommands_AddAction(t *testing.T) {
 			if tt.res.err == nil {
 				assert.Equal(t, tt.res.id, id)
 				assert.Equal(t, tt.res.details, details)
+				assert.Equal(t, tt.res.signingKey, tt.args.addAction.SigningKey)
 			}
 		})
 	}
@@ -193,6 +269,39 @@ func TestCommands_ChangeAction(t *testing.T) {
 				err: errors.IsNotFound,
 			},
 		},
+		{
+			"change type, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"name() {};",
+								0,
+								false,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeAction: &domain.Action{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:      "name",
+					Type:      domain.ActionTypeTarget,
+					TargetURL: "https://example.com/hook",
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
 		{
 			"no changes, error",
 			fields{
@@ -316,6 +425,61 @@ func TestCommands_ChangeAction(t *testing.T) {
 				},
 			},
 		},
+		{
+			"target push ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							action.NewTargetAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								0,
+								false,
+								0,
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("key"),
+								},
+							),
+						),
+					),
+					expectPush(
+						func() *action.ChangedEvent {
+							event, _ := action.NewChangedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								[]action.ActionChanges{
+									action.ChangeTargetURL("https://example.com/hook2"),
+									action.ChangeMaxRetries(3),
+								},
+							)
+							return event
+						}(),
+					),
+				),
+			},
+			args{
+				ctx: context.Background(),
+				changeAction: &domain.Action{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID: "id1",
+					},
+					Name:       "name",
+					Type:       domain.ActionTypeTarget,
+					TargetURL:  "https://example.com/hook2",
+					MaxRetries: 3,
+				},
+				resourceOwner: "org1",
+			},
+			res{
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -336,6 +500,153 @@ func TestCommands_ChangeAction(t *testing.T) {
 	}
 }
 
+func TestCommands_RegenerateActionSigningKey(t *testing.T) {
+	type fields struct {
+		eventstore          *eventstore.Eventstore
+		newActionSigningKey func(crypto.EncryptionAlgorithm) (*crypto.CryptoValue, string, error)
+	}
+	type args struct {
+		ctx           context.Context
+		actionID      string
+		resourceOwner string
+	}
+	type res struct {
+		signingKey string
+		details    *domain.ObjectDetails
+		err        func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			"id missing, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsErrorInvalidArgument,
+			},
+		},
+		{
+			"not found, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsNotFound,
+			},
+		},
+		{
+			"no target, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							action.NewAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"name() {};",
+								0,
+								false,
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				err: errors.IsPreconditionFailed,
+			},
+		},
+		{
+			"regenerate ok",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							action.NewTargetAddedEvent(context.Background(),
+								&action.NewAggregate("id1", "org1").Aggregate,
+								"name",
+								"https://example.com/hook",
+								0,
+								false,
+								0,
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("key"),
+								},
+							),
+						),
+					),
+					expectPush(
+						action.NewSigningKeyChangedEvent(context.Background(),
+							&action.NewAggregate("id1", "org1").Aggregate,
+							&crypto.CryptoValue{
+								CryptoType: crypto.TypeEncryption,
+								Algorithm:  "enc",
+								KeyID:      "id",
+								Crypted:    []byte("key2"),
+							},
+						),
+					),
+				),
+				newActionSigningKey: mockActionSigningKey("key2"),
+			},
+			args{
+				ctx:           context.Background(),
+				actionID:      "id1",
+				resourceOwner: "org1",
+			},
+			res{
+				signingKey: "key2",
+				details: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:          tt.fields.eventstore,
+				newActionSigningKey: tt.fields.newActionSigningKey,
+			}
+			signingKey, details, err := c.RegenerateActionSigningKey(tt.args.ctx, tt.args.actionID, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.signingKey, signingKey)
+				assert.Equal(t, tt.res.details, details)
+			}
+		})
+	}
+}
+
 func TestCommands_DeactivateAction(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
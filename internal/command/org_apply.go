@@ -0,0 +1,554 @@
+package command
+
+import (
+	"context"
+	"reflect"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/action"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+// ApplyOrg is the desired state of an organization and its resources.
+// Resources which are not part of the desired state are left untouched.
+type ApplyOrg struct {
+	ID   string
+	Name string
+
+	Domains []string
+
+	DomainPolicy             *domain.DomainPolicy
+	LabelPolicy              *domain.LabelPolicy
+	LoginPolicy              *AddLoginPolicy
+	PasswordComplexityPolicy *domain.PasswordComplexityPolicy
+	LockoutPolicy            *domain.LockoutPolicy
+	PrivacyPolicy            *domain.PrivacyPolicy
+
+	IDPConfigs     []*domain.IDPConfig
+	Projects       []*domain.Project
+	ProjectRoles   []*domain.ProjectRole
+	OIDCApps       []*domain.OIDCApp
+	APIApps        []*domain.APIApp
+	Actions        []*domain.Action
+	TriggerActions []*ApplyTriggerActions
+}
+
+type ApplyTriggerActions struct {
+	FlowType    domain.FlowType
+	TriggerType domain.TriggerType
+	ActionIDs   []string
+}
+
+// ApplyChange is a single change, which is needed to reach the desired state.
+type ApplyChange struct {
+	AggregateType eventstore.AggregateType
+	AggregateID   string
+	EventType     eventstore.EventType
+	// ClientID and ClientSecret are set for the configuration of an added application.
+	// They are not returned in a dry run, as the application is not created.
+	ClientID     string
+	ClientSecret string
+}
+
+// ApplyOrgs compares the desired state of the organizations with the current state
+// and only creates the events which are needed to reach it.
+// If dryRun is set, the events are not pushed and only the planned changes are returned.
+func (c *Commands) ApplyOrgs(ctx context.Context, orgs []*ApplyOrg, ownerID string, dryRun bool) ([]*ApplyChange, error) {
+	if len(orgs) == 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Oog4e", "Errors.Invalid.Argument")
+	}
+	validations := make([]preparation.Validation, 0, len(orgs))
+	for _, applyOrg := range orgs {
+		validations = append(validations, c.prepareApplyOrg(ctx, applyOrg, ownerID)...)
+	}
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validations...)
+	if err != nil {
+		return nil, err
+	}
+	changes := make([]*ApplyChange, len(cmds))
+	for i, cmd := range cmds {
+		changes[i] = &ApplyChange{
+			AggregateType: cmd.Aggregate().Type,
+			AggregateID:   cmd.Aggregate().ID,
+			EventType:     cmd.Type(),
+		}
+		if !dryRun {
+			changes[i].ClientID, changes[i].ClientSecret = addedAppCredentials(orgs, cmd)
+		}
+	}
+	if dryRun || len(cmds) == 0 {
+		return changes, nil
+	}
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return nil, err
+	}
+	return changes, nil
+}
+
+// addedAppCredentials returns the client id and secret, if the command adds the configuration of an application
+func addedAppCredentials(orgs []*ApplyOrg, cmd eventstore.Command) (clientID, clientSecret string) {
+	var appID string
+	switch e := cmd.(type) {
+	case *project.OIDCConfigAddedEvent:
+		appID, clientID = e.AppID, e.ClientID
+	case *project.APIConfigAddedEvent:
+		appID, clientID = e.AppID, e.ClientID
+	default:
+		return "", ""
+	}
+	for _, applyOrg := range orgs {
+		for _, app := range applyOrg.OIDCApps {
+			if app.AppID == appID && app.AggregateID == cmd.Aggregate().ID {
+				return clientID, app.ClientSecretString
+			}
+		}
+		for _, app := range applyOrg.APIApps {
+			if app.AppID == appID && app.AggregateID == cmd.Aggregate().ID {
+				return clientID, app.ClientSecretString
+			}
+		}
+	}
+	return clientID, ""
+}
+
+func (c *Commands) prepareApplyOrg(ctx context.Context, applyOrg *ApplyOrg, ownerID string) []preparation.Validation {
+	orgAgg := org.NewAggregate(applyOrg.ID)
+	validations := []preparation.Validation{c.prepareApplyOrgName(ctx, orgAgg, applyOrg.Name, ownerID)}
+	for _, orgDomain := range applyOrg.Domains {
+		validations = append(validations, c.prepareApplyOrgDomain(orgAgg, orgDomain))
+	}
+	if applyOrg.DomainPolicy != nil {
+		validations = append(validations, prepareApplyDomainPolicy(orgAgg, applyOrg.DomainPolicy))
+	}
+	if applyOrg.LabelPolicy != nil {
+		validations = append(validations, prepareApplyLabelPolicy(orgAgg, applyOrg.LabelPolicy))
+	}
+	for _, config := range applyOrg.IDPConfigs {
+		validations = append(validations, c.prepareApplyIDPConfig(orgAgg, config))
+	}
+	if applyOrg.LoginPolicy != nil {
+		validations = append(validations, prepareApplyLoginPolicy(orgAgg, applyOrg.LoginPolicy))
+	}
+	if applyOrg.PasswordComplexityPolicy != nil {
+		validations = append(validations, prepareApplyPasswordComplexityPolicy(orgAgg, applyOrg.PasswordComplexityPolicy))
+	}
+	if applyOrg.LockoutPolicy != nil {
+		validations = append(validations, prepareApplyLockoutPolicy(orgAgg, applyOrg.LockoutPolicy))
+	}
+	if applyOrg.PrivacyPolicy != nil {
+		validations = append(validations, prepareApplyPrivacyPolicy(orgAgg, applyOrg.PrivacyPolicy))
+	}
+	for _, applyProject := range applyOrg.Projects {
+		validations = append(validations, prepareApplyProject(project.NewAggregate(applyProject.AggregateID, applyOrg.ID), applyProject, ownerID))
+	}
+	for _, role := range applyOrg.ProjectRoles {
+		validations = append(validations, prepareApplyProjectRole(project.NewAggregate(role.AggregateID, applyOrg.ID), role))
+	}
+	for _, app := range applyOrg.OIDCApps {
+		validations = append(validations, c.prepareApplyOIDCApp(project.NewAggregate(app.AggregateID, applyOrg.ID), app))
+	}
+	for _, app := range applyOrg.APIApps {
+		validations = append(validations, c.prepareApplyAPIApp(project.NewAggregate(app.AggregateID, applyOrg.ID), app))
+	}
+	for _, applyAction := range applyOrg.Actions {
+		validations = append(validations, prepareApplyAction(NewActionAggregate(applyAction.AggregateID, applyOrg.ID), applyAction))
+	}
+	for _, trigger := range applyOrg.TriggerActions {
+		validations = append(validations, prepareApplyTriggerActions(orgAgg, trigger))
+	}
+	return validations
+}
+
+func (c *Commands) prepareApplyOrgName(ctx context.Context, a *org.Aggregate, name, ownerID string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if a.ID == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ooD2i", "Errors.IDMissing")
+		}
+		if name = strings.TrimSpace(name); name == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Wie3u", "Errors.Org.Invalid")
+		}
+		addOrg, err := AddOrgCommand(ctx, a, name)()
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewOrgWriteModel(a.ID)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			switch wm.State {
+			case domain.OrgStateUnspecified:
+				cmds, err := addOrg(ctx, filter)
+				if err != nil {
+					return nil, err
+				}
+				return append(cmds, org.NewMemberAddedEvent(ctx, &a.Aggregate, ownerID, domain.RoleOrgOwner)), nil
+			case domain.OrgStateRemoved:
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-ahY5o", "Errors.Org.NotFound")
+			case domain.OrgStateActive, domain.OrgStateInactive:
+				if wm.Name == name {
+					return nil, nil
+				}
+				domainCmds, err := c.changeDefaultDomain(ctx, a.ID, name)
+				if err != nil {
+					return nil, err
+				}
+				return append([]eventstore.Command{org.NewOrgChangedEvent(ctx, &a.Aggregate, wm.Name, name)}, domainCmds...), nil
+			default:
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Quai1", "Errors.Org.NotFound")
+			}
+		}, nil
+	}
+}
+
+func (c *Commands) prepareApplyOrgDomain(a *org.Aggregate, orgDomain string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		addDomain, err := c.prepareAddOrgDomain(a, orgDomain, nil)()
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewOrgDomainWriteModel(a.ID, strings.TrimSpace(orgDomain))
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if wm.State == domain.OrgDomainStateActive {
+				return nil, nil
+			}
+			return addDomain(ctx, filter)
+		}, nil
+	}
+}
+
+func prepareApplyDomainPolicy(a *org.Aggregate, policy *domain.DomainPolicy) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		addPolicy, err := prepareAddOrgDomainPolicy(a, policy.UserLoginMustBeDomain, policy.ValidateOrgDomains, policy.SMTPSenderAddressMatchesInstanceDomain)()
+		if err != nil {
+			return nil, err
+		}
+		changePolicy, err := prepareChangeOrgDomainPolicy(a, policy.UserLoginMustBeDomain, policy.ValidateOrgDomains, policy.SMTPSenderAddressMatchesInstanceDomain)()
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm, err := orgDomainPolicy(ctx, filter, a.ID)
+			if err != nil {
+				return nil, err
+			}
+			if !wm.State.Exists() {
+				return addPolicy(ctx, filter)
+			}
+			if wm.UserLoginMustBeDomain == policy.UserLoginMustBeDomain &&
+				wm.ValidateOrgDomains == policy.ValidateOrgDomains &&
+				wm.SMTPSenderAddressMatchesInstanceDomain == policy.SMTPSenderAddressMatchesInstanceDomain {
+				return nil, nil
+			}
+			// the usernames of the organization are changed as well, if the UserLoginMustBeDomain setting changes
+			return changePolicy(ctx, filter)
+		}, nil
+	}
+}
+
+// prepareApplyLabelPolicy adds or changes the label policy of the organization and activates it.
+// The assets (logos, icons and font) are not part of the desired state and are left untouched.
+func prepareApplyLabelPolicy(a *org.Aggregate, policy *domain.LabelPolicy) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if err := policy.IsValid(); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewOrgLabelPolicyWriteModel(a.ID)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if wm.State != domain.PolicyStateActive {
+				return []eventstore.Command{
+					org.NewLabelPolicyAddedEvent(ctx, &a.Aggregate,
+						policy.PrimaryColor,
+						policy.BackgroundColor,
+						policy.WarnColor,
+						policy.FontColor,
+						policy.PrimaryColorDark,
+						policy.BackgroundColorDark,
+						policy.WarnColorDark,
+						policy.FontColorDark,
+						policy.HideLoginNameSuffix,
+						policy.ErrorMsgPopup,
+						policy.DisableWatermark,
+						policy.ThemeMode,
+					),
+					org.NewLabelPolicyActivatedEvent(ctx, &a.Aggregate),
+				}, nil
+			}
+			changedEvent, hasChanged := wm.NewChangedEvent(ctx, &a.Aggregate,
+				policy.PrimaryColor,
+				policy.BackgroundColor,
+				policy.WarnColor,
+				policy.FontColor,
+				policy.PrimaryColorDark,
+				policy.BackgroundColorDark,
+				policy.WarnColorDark,
+				policy.FontColorDark,
+				policy.HideLoginNameSuffix,
+				policy.ErrorMsgPopup,
+				policy.DisableWatermark,
+				policy.ThemeMode,
+			)
+			if !hasChanged {
+				return nil, nil
+			}
+			return []eventstore.Command{changedEvent, org.NewLabelPolicyActivatedEvent(ctx, &a.Aggregate)}, nil
+		}, nil
+	}
+}
+
+func prepareApplyLoginPolicy(a *org.Aggregate, policy *AddLoginPolicy) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		addPolicy, err := prepareAddLoginPolicy(a, policy)()
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewOrgLoginPolicyWriteModel(a.ID)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if !wm.State.Exists() {
+				return addPolicy(ctx, filter)
+			}
+			changedEvent, hasChanged := wm.NewChangedEvent(ctx, &a.Aggregate,
+				policy.AllowUsernamePassword,
+				policy.AllowRegister,
+				policy.AllowExternalIDP,
+				policy.ForceMFA,
+				policy.ForceMFALocalOnly,
+				policy.HidePasswordReset,
+				policy.IgnoreUnknownUsernames,
+				policy.AllowDomainDiscovery,
+				policy.DisableLoginWithEmail,
+				policy.DisableLoginWithPhone,
+				policy.PasswordlessType,
+				policy.DefaultRedirectURI,
+				policy.PasswordCheckLifetime,
+				policy.ExternalLoginCheckLifetime,
+				policy.MFAInitSkipLifetime,
+				policy.SecondFactorCheckLifetime,
+				policy.MultiFactorCheckLifetime)
+			if !hasChanged {
+				return nil, nil
+			}
+			return []eventstore.Command{changedEvent}, nil
+		}, nil
+	}
+}
+
+func prepareApplyPasswordComplexityPolicy(a *org.Aggregate, policy *domain.PasswordComplexityPolicy) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if err := policy.IsValid(); err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewOrgPasswordComplexityPolicyWriteModel(a.ID)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if !wm.State.Exists() {
+				return []eventstore.Command{
+					org.NewPasswordComplexityPolicyAddedEvent(ctx, &a.Aggregate,
+						policy.MinLength,
+						policy.HasLowercase,
+						policy.HasUppercase,
+						policy.HasNumber,
+						policy.HasSymbol,
+					),
+				}, nil
+			}
+			changedEvent, hasChanged := wm.NewChangedEvent(ctx, &a.Aggregate, policy.MinLength, policy.HasLowercase, policy.HasUppercase, policy.HasNumber, policy.HasSymbol)
+			if !hasChanged {
+				return nil, nil
+			}
+			return []eventstore.Command{changedEvent}, nil
+		}, nil
+	}
+}
+
+func prepareApplyLockoutPolicy(a *org.Aggregate, policy *domain.LockoutPolicy) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewOrgLockoutPolicyWriteModel(a.ID)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if !wm.State.Exists() {
+				return []eventstore.Command{
+					org.NewLockoutPolicyAddedEvent(ctx, &a.Aggregate, policy.MaxPasswordAttempts, policy.ShowLockOutFailures),
+				}, nil
+			}
+			changedEvent, hasChanged := wm.NewChangedEvent(ctx, &a.Aggregate, policy.MaxPasswordAttempts, policy.ShowLockOutFailures)
+			if !hasChanged {
+				return nil, nil
+			}
+			return []eventstore.Command{changedEvent}, nil
+		}, nil
+	}
+}
+
+func prepareApplyPrivacyPolicy(a *org.Aggregate, policy *domain.PrivacyPolicy) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewOrgPrivacyPolicyWriteModel(a.ID)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if !wm.State.Exists() {
+				return []eventstore.Command{
+					org.NewPrivacyPolicyAddedEvent(ctx, &a.Aggregate, policy.TOSLink, policy.PrivacyLink, policy.HelpLink, policy.SupportEmail),
+				}, nil
+			}
+			changedEvent, hasChanged := wm.NewChangedEvent(ctx, &a.Aggregate, policy.TOSLink, policy.PrivacyLink, policy.HelpLink, policy.SupportEmail)
+			if !hasChanged {
+				return nil, nil
+			}
+			return []eventstore.Command{changedEvent}, nil
+		}, nil
+	}
+}
+
+func prepareApplyProject(a *project.Aggregate, applyProject *domain.Project, ownerID string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if a.ID == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Eeh8u", "Errors.IDMissing")
+		}
+		addProject, err := AddProjectCommand(a,
+			applyProject.Name,
+			ownerID,
+			applyProject.ProjectRoleAssertion,
+			applyProject.ProjectRoleCheck,
+			applyProject.HasProjectCheck,
+			applyProject.PrivateLabelingSetting,
+		)()
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm, err := projectWriteModel(ctx, filter, a.ID, a.ResourceOwner)
+			if err != nil {
+				return nil, err
+			}
+			if wm.State == domain.ProjectStateRemoved {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-iu6Ah", "Errors.Project.NotFound")
+			}
+			if wm.State == domain.ProjectStateUnspecified {
+				return addProject(ctx, filter)
+			}
+			changedEvent, hasChanged, err := wm.NewChangedEvent(ctx, &a.Aggregate,
+				applyProject.Name,
+				applyProject.ProjectRoleAssertion,
+				applyProject.ProjectRoleCheck,
+				applyProject.HasProjectCheck,
+				applyProject.PrivateLabelingSetting,
+			)
+			if err != nil || !hasChanged {
+				return nil, err
+			}
+			return []eventstore.Command{changedEvent}, nil
+		}, nil
+	}
+}
+
+func prepareApplyProjectRole(a *project.Aggregate, role *domain.ProjectRole) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if a.ID == "" || !role.IsValid() {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ieT0a", "Errors.Project.Role.Invalid")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			projectModel, err := projectWriteModel(ctx, filter, a.ID, a.ResourceOwner)
+			if err != nil {
+				return nil, err
+			}
+			if projectModel.State == domain.ProjectStateUnspecified || projectModel.State == domain.ProjectStateRemoved {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ooz4a", "Errors.Project.NotFound")
+			}
+			wm := NewProjectRoleWriteModelWithKey(role.Key, a.ID, a.ResourceOwner)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if wm.State != domain.ProjectRoleStateActive {
+				return []eventstore.Command{project.NewRoleAddedEvent(ctx, &a.Aggregate, role.Key, role.DisplayName, role.Group)}, nil
+			}
+			changedEvent, hasChanged, err := wm.NewProjectRoleChangedEvent(ctx, &a.Aggregate, role.Key, role.DisplayName, role.Group)
+			if err != nil || !hasChanged {
+				return nil, err
+			}
+			return []eventstore.Command{changedEvent}, nil
+		}, nil
+	}
+}
+
+func prepareApplyAction(a *eventstore.Aggregate, applyAction *domain.Action) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if a.ID == "" || !applyAction.IsValid() {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Thae5", "Errors.Action.Invalid")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewActionWriteModel(a.ID, a.ResourceOwner)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if wm.State == domain.ActionStateRemoved {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Gai3u", "Errors.Action.NotFound")
+			}
+			if !wm.State.Exists() {
+				return []eventstore.Command{
+					action.NewAddedEvent(ctx, a, applyAction.Name, applyAction.Script, applyAction.Timeout, applyAction.AllowedToFail),
+				}, nil
+			}
+			if wm.Name == applyAction.Name &&
+				wm.Script == applyAction.Script &&
+				wm.Timeout == applyAction.Timeout &&
+				wm.AllowedToFail == applyAction.AllowedToFail {
+				return nil, nil
+			}
+			changedEvent, err := wm.NewChangedEvent(ctx, a, applyAction.Name, applyAction.Script, applyAction.Timeout, applyAction.AllowedToFail, wm.TargetURL, wm.MaxRetries)
+			if err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{changedEvent}, nil
+		}, nil
+	}
+}
+
+func prepareApplyTriggerActions(a *org.Aggregate, trigger *ApplyTriggerActions) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if !trigger.FlowType.Valid() || !trigger.TriggerType.Valid() {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ahj7e", "Errors.Flow.FlowTypeMissing")
+		}
+		if !trigger.FlowType.HasTrigger(trigger.TriggerType) {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ohB0u", "Errors.Flow.WrongTriggerType")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewOrgFlowWriteModel(trigger.FlowType, a.ID)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			if reflect.DeepEqual(wm.Triggers[trigger.TriggerType], trigger.ActionIDs) {
+				return nil, nil
+			}
+			if len(trigger.ActionIDs) > 0 {
+				actionsModel := NewActionsExistModel(trigger.ActionIDs, a.ID)
+				if err := queryAndReduce(ctx, filter, actionsModel); err != nil {
+					return nil, err
+				}
+				if len(actionsModel.actionIDs) != len(actionsModel.checkedIDs) {
+					return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Pah0i", "Errors.Flow.ActionIDsNotExist")
+				}
+			}
+			return []eventstore.Command{org.NewTriggerActionsSetEvent(ctx, &a.Aggregate, trigger.FlowType, trigger.TriggerType, trigger.ActionIDs)}, nil
+		}, nil
+	}
+}
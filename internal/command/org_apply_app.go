@@ -0,0 +1,174 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+// prepareApplyOIDCApp adds the oidc application or changes its name and configuration.
+// The client id and secret of an added application are set on the app.
+func (c *Commands) prepareApplyOIDCApp(a *project.Aggregate, app *domain.OIDCApp) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if a.ID == "" || app.AppID == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ahN3o", "Errors.IDMissing")
+		}
+		if app.AppName = strings.TrimSpace(app.AppName); app.AppName == "" || !app.IsValid() {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ie6ch", "Errors.Project.App.OIDCConfigInvalid")
+		}
+		addApp := &addOIDCApp{
+			AddApp: AddApp{
+				Aggregate: *a,
+				ID:        app.AppID,
+				Name:      app.AppName,
+			},
+			Version:                          app.OIDCVersion,
+			RedirectUris:                     app.RedirectUris,
+			ResponseTypes:                    app.ResponseTypes,
+			GrantTypes:                       app.GrantTypes,
+			ApplicationType:                  app.ApplicationType,
+			AuthMethodType:                   app.AuthMethodType,
+			PostLogoutRedirectUris:           app.PostLogoutRedirectUris,
+			DevMode:                          app.DevMode,
+			AccessTokenType:                  app.AccessTokenType,
+			AccessTokenRoleAssertion:         app.AccessTokenRoleAssertion,
+			IDTokenRoleAssertion:             app.IDTokenRoleAssertion,
+			IDTokenUserinfoAssertion:         app.IDTokenUserinfoAssertion,
+			ClockSkew:                        app.ClockSkew,
+			AdditionalOrigins:                app.AdditionalOrigins,
+			SkipSuccessPageForNativeApp:      app.SkipNativeAppSuccessPage,
+			BackChannelLogoutURI:             app.BackChannelLogoutURI,
+			FrontChannelLogoutURI:            app.FrontChannelLogoutURI,
+			RequirePushedAuthRequest:         app.RequirePushedAuthRequest,
+			RequireSignedRequest:             app.RequireSignedRequest,
+			DPoPBoundAccessTokens:            app.DPoPBoundAccessTokens,
+			ForceRSASignedTokens:             app.ForceRSASignedTokens,
+			BackChannelClientNotificationURI: app.BackChannelClientNotificationURI,
+		}
+		addOIDCApp, err := c.AddOIDCAppCommand(addApp, c.codeAlg)()
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm, err := getOIDCAppWriteModel(ctx, filter, a.ID, app.AppID, a.ResourceOwner)
+			if err != nil {
+				return nil, err
+			}
+			switch wm.State {
+			case domain.AppStateUnspecified:
+				cmds, err := addOIDCApp(ctx, filter)
+				if err != nil {
+					return nil, err
+				}
+				app.ClientID, app.ClientSecretString = addApp.ClientID, addApp.ClientSecretPlain
+				return cmds, nil
+			case domain.AppStateRemoved:
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Kie8u", "Errors.Project.App.NotExisting")
+			}
+			if !wm.IsOIDC() {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-aiT5e", "Errors.Project.App.IsNotOIDC")
+			}
+			cmds := applyAppNameCommands(ctx, a, app.AppID, wm.AppName, app.AppName)
+			changedEvent, hasChanged, err := wm.NewChangedEvent(
+				ctx,
+				&a.Aggregate,
+				app.AppID,
+				app.RedirectUris,
+				app.PostLogoutRedirectUris,
+				app.ResponseTypes,
+				app.GrantTypes,
+				app.ApplicationType,
+				app.AuthMethodType,
+				app.OIDCVersion,
+				app.AccessTokenType,
+				app.DevMode,
+				app.AccessTokenRoleAssertion,
+				app.IDTokenRoleAssertion,
+				app.IDTokenUserinfoAssertion,
+				app.ClockSkew,
+				app.AdditionalOrigins,
+				app.SkipNativeAppSuccessPage,
+				app.BackChannelLogoutURI,
+				app.FrontChannelLogoutURI,
+				app.RequirePushedAuthRequest,
+				app.RequireSignedRequest,
+				app.DPoPBoundAccessTokens,
+				app.ForceRSASignedTokens,
+				app.BackChannelClientNotificationURI,
+			)
+			if err != nil {
+				return nil, err
+			}
+			if hasChanged {
+				cmds = append(cmds, changedEvent)
+			}
+			return cmds, nil
+		}, nil
+	}
+}
+
+// prepareApplyAPIApp adds the api application or changes its name and configuration.
+// The client id and secret of an added application are set on the app.
+func (c *Commands) prepareApplyAPIApp(a *project.Aggregate, app *domain.APIApp) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if a.ID == "" || app.AppID == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ohr9i", "Errors.IDMissing")
+		}
+		if app.AppName = strings.TrimSpace(app.AppName); app.AppName == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Eeg6o", "Errors.Project.App.APIConfigInvalid")
+		}
+		addApp := &addAPIApp{
+			AddApp: AddApp{
+				Aggregate: *a,
+				ID:        app.AppID,
+				Name:      app.AppName,
+			},
+			AuthMethodType: app.AuthMethodType,
+		}
+		addAPIApp, err := c.AddAPIAppCommand(addApp, c.codeAlg)()
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewAPIApplicationWriteModelWithAppID(a.ID, app.AppID, a.ResourceOwner)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			switch wm.State {
+			case domain.AppStateUnspecified:
+				cmds, err := addAPIApp(ctx, filter)
+				if err != nil {
+					return nil, err
+				}
+				app.ClientID, app.ClientSecretString = addApp.ClientID, addApp.ClientSecretPlain
+				return cmds, nil
+			case domain.AppStateRemoved:
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-ooK2a", "Errors.Project.App.NotExisting")
+			}
+			if !wm.IsAPI() {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ahz1u", "Errors.Project.App.IsNotAPI")
+			}
+			cmds := applyAppNameCommands(ctx, a, app.AppID, wm.AppName, app.AppName)
+			changedEvent, hasChanged, err := wm.NewChangedEvent(ctx, &a.Aggregate, app.AppID, app.AuthMethodType)
+			if err != nil {
+				return nil, err
+			}
+			if hasChanged {
+				cmds = append(cmds, changedEvent)
+			}
+			return cmds, nil
+		}, nil
+	}
+}
+
+func applyAppNameCommands(ctx context.Context, a *project.Aggregate, appID, currentName, name string) []eventstore.Command {
+	if currentName == name {
+		return nil
+	}
+	return []eventstore.Command{project.NewApplicationChangedEvent(ctx, &a.Aggregate, appID, currentName, name)}
+}
@@ -0,0 +1,113 @@
+package command
+
+import (
+	"context"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+// prepareApplyIDPConfig adds the identity provider of the organization
+// or changes its configuration, the type of an existing identity provider can't be changed.
+func (c *Commands) prepareApplyIDPConfig(a *org.Aggregate, config *domain.IDPConfig) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if config.IDPConfigID == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ruo4u", "Errors.IDMissing")
+		}
+		if config.Name = strings.TrimSpace(config.Name); config.Name == "" || (config.OIDCConfig == nil) == (config.JWTConfig == nil) {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-quoo7", "Errors.IDPConfig.Invalid")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			wm := NewOrgIDPConfigWriteModel(config.IDPConfigID, a.ID)
+			if err := queryAndReduce(ctx, filter, wm); err != nil {
+				return nil, err
+			}
+			switch wm.State {
+			case domain.IDPConfigStateUnspecified:
+				return c.idpConfigAddedEvents(ctx, &a.Aggregate, config, config.IDPConfigID)
+			case domain.IDPConfigStateRemoved:
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-ahG9o", "Errors.Org.IDPConfig.NotExisting")
+			}
+			if wm.Type != config.Type {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Xoh2e", "Errors.IDPConfig.Invalid")
+			}
+			var cmds []eventstore.Command
+			if changedEvent, hasChanged := wm.NewChangedEvent(ctx, &a.Aggregate, config.IDPConfigID, config.Name, config.StylingType, config.AutoRegister); hasChanged {
+				cmds = append(cmds, changedEvent)
+			}
+			var configCmd eventstore.Command
+			var err error
+			if config.OIDCConfig != nil {
+				configCmd, err = c.applyIDPOIDCConfig(ctx, filter, a, config.IDPConfigID, config.OIDCConfig)
+			} else {
+				configCmd, err = applyIDPJWTConfig(ctx, filter, a, config.IDPConfigID, config.JWTConfig)
+			}
+			if err != nil {
+				return nil, err
+			}
+			if configCmd != nil {
+				cmds = append(cmds, configCmd)
+			}
+			return cmds, nil
+		}, nil
+	}
+}
+
+// applyIDPOIDCConfig returns the changed event of the oidc configuration, if it differs from the desired one.
+// The client secret is only changed, if it's not the current one.
+func (c *Commands) applyIDPOIDCConfig(ctx context.Context, filter preparation.FilterToQueryReducer, a *org.Aggregate, idpConfigID string, config *domain.OIDCIDPConfig) (eventstore.Command, error) {
+	wm := NewOrgIDPOIDCConfigWriteModel(idpConfigID, a.ID)
+	if err := queryAndReduce(ctx, filter, wm); err != nil {
+		return nil, err
+	}
+	clientSecret := config.ClientSecretString
+	if wm.ClientSecret != nil {
+		if current, err := crypto.DecryptString(wm.ClientSecret, c.idpConfigEncryption); err == nil && current == clientSecret {
+			clientSecret = ""
+		}
+	}
+	changedEvent, hasChanged, err := wm.NewChangedEvent(
+		ctx,
+		&a.Aggregate,
+		idpConfigID,
+		config.ClientID,
+		config.Issuer,
+		config.AuthorizationEndpoint,
+		config.TokenEndpoint,
+		clientSecret,
+		c.idpConfigEncryption,
+		config.IDPDisplayNameMapping,
+		config.UsernameMapping,
+		config.Scopes...,
+	)
+	if err != nil || !hasChanged {
+		return nil, err
+	}
+	return changedEvent, nil
+}
+
+// applyIDPJWTConfig returns the changed event of the jwt configuration, if it differs from the desired one.
+func applyIDPJWTConfig(ctx context.Context, filter preparation.FilterToQueryReducer, a *org.Aggregate, idpConfigID string, config *domain.JWTIDPConfig) (eventstore.Command, error) {
+	wm := NewOrgIDPJWTConfigWriteModel(idpConfigID, a.ID)
+	if err := queryAndReduce(ctx, filter, wm); err != nil {
+		return nil, err
+	}
+	changedEvent, hasChanged, err := wm.NewChangedEvent(
+		ctx,
+		&a.Aggregate,
+		idpConfigID,
+		config.JWTEndpoint,
+		config.Issuer,
+		config.KeysEndpoint,
+		config.HeaderName,
+	)
+	if err != nil || !hasChanged {
+		return nil, err
+	}
+	return changedEvent, nil
+}
@@ -0,0 +1,425 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommandSide_ApplyOrgs(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx    context.Context
+		orgs   []*ApplyOrg
+		dryRun bool
+	}
+	type res struct {
+		want []*ApplyChange
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "no orgs, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: context.Background(),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: authz.WithRequestedDomain(context.Background(), "zitadel.ch"),
+				orgs: []*ApplyOrg{
+					{
+						Name: "org",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "removed org, precondition failed error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+						eventFromEventPusher(
+							org.NewOrgRemovedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org", nil, false, nil, nil, nil),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithRequestedDomain(context.Background(), "zitadel.ch"),
+				orgs: []*ApplyOrg{
+					{
+						ID:   "org1",
+						Name: "org",
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "unchanged org and policy, no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLockoutPolicyAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, 10, true),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithRequestedDomain(context.Background(), "zitadel.ch"),
+				orgs: []*ApplyOrg{
+					{
+						ID:   "org1",
+						Name: "org",
+						LockoutPolicy: &domain.LockoutPolicy{
+							MaxPasswordAttempts: 10,
+							ShowLockOutFailures: true,
+						},
+					},
+				},
+			},
+			res: res{
+				want: []*ApplyChange{},
+			},
+		},
+		{
+			name: "changed policy, dry run, not pushed",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLockoutPolicyAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, 10, true),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithRequestedDomain(context.Background(), "zitadel.ch"),
+				orgs: []*ApplyOrg{
+					{
+						ID:   "org1",
+						Name: "org",
+						LockoutPolicy: &domain.LockoutPolicy{
+							MaxPasswordAttempts: 5,
+							ShowLockOutFailures: true,
+						},
+					},
+				},
+				dryRun: true,
+			},
+			res: res{
+				want: []*ApplyChange{
+					{
+						AggregateType: org.AggregateType,
+						AggregateID:   "org1",
+						EventType:     org.LockoutPolicyChangedEventType,
+					},
+				},
+			},
+		},
+		{
+			name: "changed policy, pushed",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLockoutPolicyAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, 10, false),
+						),
+					),
+					expectPush(
+						newPasswordLockoutPolicyChangedEvent(context.Background(), "org1", 5, true),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithRequestedDomain(context.Background(), "zitadel.ch"),
+				orgs: []*ApplyOrg{
+					{
+						ID:   "org1",
+						Name: "org",
+						LockoutPolicy: &domain.LockoutPolicy{
+							MaxPasswordAttempts: 5,
+							ShowLockOutFailures: true,
+						},
+					},
+				},
+			},
+			res: res{
+				want: []*ApplyChange{
+					{
+						AggregateType: org.AggregateType,
+						AggregateID:   "org1",
+						EventType:     org.LockoutPolicyChangedEventType,
+					},
+				},
+			},
+		},
+		{
+			name: "added label policy, activated, dry run",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: authz.WithRequestedDomain(context.Background(), "zitadel.ch"),
+				orgs: []*ApplyOrg{
+					{
+						ID:   "org1",
+						Name: "org",
+						LabelPolicy: &domain.LabelPolicy{
+							PrimaryColor: "#ffffff",
+						},
+					},
+				},
+				dryRun: true,
+			},
+			res: res{
+				want: []*ApplyChange{
+					{
+						AggregateType: org.AggregateType,
+						AggregateID:   "org1",
+						EventType:     org.LabelPolicyAddedEventType,
+					},
+					{
+						AggregateType: org.AggregateType,
+						AggregateID:   "org1",
+						EventType:     org.LabelPolicyActivatedEventType,
+					},
+				},
+			},
+		},
+		{
+			name: "changed jwt idp, dry run",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPConfigAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "idp1", "jwt", domain.IDPConfigTypeJWT, domain.IDPConfigStylingTypeUnspecified, false),
+						),
+						eventFromEventPusher(
+							org.NewIDPJWTConfigAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "idp1", "https://jwt.endpoint", "https://issuer", "https://keys.endpoint", "x-auth"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewIDPConfigAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "idp1", "jwt", domain.IDPConfigTypeJWT, domain.IDPConfigStylingTypeUnspecified, false),
+						),
+						eventFromEventPusher(
+							org.NewIDPJWTConfigAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "idp1", "https://jwt.endpoint", "https://issuer", "https://keys.endpoint", "x-auth"),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithRequestedDomain(context.Background(), "zitadel.ch"),
+				orgs: []*ApplyOrg{
+					{
+						ID:   "org1",
+						Name: "org",
+						IDPConfigs: []*domain.IDPConfig{
+							{
+								IDPConfigID: "idp1",
+								Name:        "jwt",
+								Type:        domain.IDPConfigTypeJWT,
+								JWTConfig: &domain.JWTIDPConfig{
+									JWTEndpoint:  "https://jwt.endpoint",
+									Issuer:       "https://issuer",
+									KeysEndpoint: "https://keys.endpoint",
+									HeaderName:   "authorization",
+								},
+							},
+						},
+					},
+				},
+				dryRun: true,
+			},
+			res: res{
+				want: []*ApplyChange{
+					{
+						AggregateType: org.AggregateType,
+						AggregateID:   "org1",
+						EventType:     org.IDPJWTConfigChangedEventType,
+					},
+				},
+			},
+		},
+		{
+			name: "unchanged api app, no changes",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "app1", "api"),
+						),
+						eventFromEventPusher(
+							project.NewAPIConfigAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "app1", "client1@project", nil, domain.APIAuthMethodTypePrivateKeyJWT),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: authz.WithRequestedDomain(context.Background(), "zitadel.ch"),
+				orgs: []*ApplyOrg{
+					{
+						ID:   "org1",
+						Name: "org",
+						APIApps: []*domain.APIApp{
+							{
+								ObjectRoot:     models.ObjectRoot{AggregateID: "project1"},
+								AppID:          "app1",
+								AppName:        "api",
+								AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+							},
+						},
+					},
+				},
+			},
+			res: res{
+				want: []*ApplyChange{},
+			},
+		},
+		{
+			name: "added api app, pushed with client id",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewOrgAddedEvent(context.Background(), &org.NewAggregate("org1").Aggregate, "org"),
+						),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "project", true, true, true, domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						project.NewApplicationAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "app1", "api"),
+						project.NewAPIConfigAddedEvent(context.Background(), &project.NewAggregate("project1", "org1").Aggregate, "app1", "client1@project", nil, domain.APIAuthMethodTypePrivateKeyJWT),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "client1"),
+			},
+			args: args{
+				ctx: authz.WithRequestedDomain(context.Background(), "zitadel.ch"),
+				orgs: []*ApplyOrg{
+					{
+						ID:   "org1",
+						Name: "org",
+						APIApps: []*domain.APIApp{
+							{
+								ObjectRoot:     models.ObjectRoot{AggregateID: "project1"},
+								AppID:          "app1",
+								AppName:        "api",
+								AuthMethodType: domain.APIAuthMethodTypePrivateKeyJWT,
+							},
+						},
+					},
+				},
+			},
+			res: res{
+				want: []*ApplyChange{
+					{
+						AggregateType: project.AggregateType,
+						AggregateID:   "project1",
+						EventType:     project.ApplicationAddedType,
+					},
+					{
+						AggregateType: project.AggregateType,
+						AggregateID:   "project1",
+						EventType:     project.APIConfigAddedType,
+						ClientID:      "client1@project",
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := r.ApplyOrgs(tt.args.ctx, tt.args.orgs, "user1", tt.args.dryRun)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -42,6 +42,22 @@ func (c *Commands) addIDPConfig(ctx context.Context, config *domain.IDPConfig, i
 	addedConfig := NewOrgIDPConfigWriteModel(idpConfigID, resourceOwner)
 
 	orgAgg := OrgAggregateFromWriteModel(&addedConfig.WriteModel)
+	events, err := c.idpConfigAddedEvents(ctx, orgAgg, config, idpConfigID)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(addedConfig, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToIDPConfig(&addedConfig.IDPConfigWriteModel), nil
+}
+
+func (c *Commands) idpConfigAddedEvents(ctx context.Context, orgAgg *eventstore.Aggregate, config *domain.IDPConfig, idpConfigID string) ([]eventstore.Command, error) {
 	events := []eventstore.Command{
 		org_repo.NewIDPConfigAddedEvent(
 			ctx,
@@ -81,15 +97,7 @@ func (c *Commands) addIDPConfig(ctx context.Context, config *domain.IDPConfig, i
 			config.JWTConfig.HeaderName,
 		))
 	}
-	pushedEvents, err := c.eventstore.Push(ctx, events...)
-	if err != nil {
-		return nil, err
-	}
-	err = AppendAndReduce(addedConfig, pushedEvents...)
-	if err != nil {
-		return nil, err
-	}
-	return writeModelToIDPConfig(&addedConfig.IDPConfigWriteModel), nil
+	return events, nil
 }
 
 func (c *Commands) ChangeIDPConfig(ctx context.Context, config *domain.IDPConfig, resourceOwner string) (*domain.IDPConfig, error) {
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type OrgImpersonationPolicyWriteModel struct {
+	ImpersonationPolicyWriteModel
+}
+
+func NewOrgImpersonationPolicyWriteModel(orgID string) *OrgImpersonationPolicyWriteModel {
+	return &OrgImpersonationPolicyWriteModel{
+		ImpersonationPolicyWriteModel{
+			WriteModel: eventstore.WriteModel{
+				AggregateID:   orgID,
+				ResourceOwner: orgID,
+			},
+		},
+	}
+}
+
+func (wm *OrgImpersonationPolicyWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *org.ImpersonationPolicyAddedEvent:
+			wm.ImpersonationPolicyWriteModel.AppendEvents(&e.ImpersonationPolicyAddedEvent)
+		case *org.ImpersonationPolicyChangedEvent:
+			wm.ImpersonationPolicyWriteModel.AppendEvents(&e.ImpersonationPolicyChangedEvent)
+		case *org.ImpersonationPolicyRemovedEvent:
+			wm.ImpersonationPolicyWriteModel.AppendEvents(&e.ImpersonationPolicyRemovedEvent)
+		}
+	}
+}
+
+func (wm *OrgImpersonationPolicyWriteModel) Reduce() error {
+	return wm.ImpersonationPolicyWriteModel.Reduce()
+}
+
+func (wm *OrgImpersonationPolicyWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateIDs(wm.ImpersonationPolicyWriteModel.AggregateID).
+		AggregateTypes(org.AggregateType).
+		EventTypes(org.ImpersonationPolicyAddedEventType,
+			org.ImpersonationPolicyChangedEventType,
+			org.ImpersonationPolicyRemovedEventType).
+		Builder()
+}
+
+func (wm *OrgImpersonationPolicyWriteModel) NewChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	allowImpersonation bool,
+) (*org.ImpersonationPolicyChangedEvent, bool) {
+
+	changes := make([]policy.ImpersonationPolicyChanges, 0)
+	if wm.AllowImpersonation != allowImpersonation {
+		changes = append(changes, policy.ChangeAllowImpersonation(allowImpersonation))
+	}
+	if len(changes) == 0 {
+		return nil, false
+	}
+	changedEvent, err := org.NewImpersonationPolicyChangedEvent(ctx, aggregate, changes)
+	if err != nil {
+		return nil, false
+	}
+	return changedEvent, true
+}
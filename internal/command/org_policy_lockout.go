@@ -61,6 +61,22 @@ func (c *Commands) ChangeLockoutPolicy(ctx context.Context, resourceOwner string
 	return writeModelToLockoutPolicy(&existingPolicy.LockoutPolicyWriteModel), nil
 }
 
+// SetLockoutPolicy adds the lockout policy of the organization
+// or changes it, if the organization already has its own policy.
+func (c *Commands) SetLockoutPolicy(ctx context.Context, resourceOwner string, policy *domain.LockoutPolicy) (*domain.LockoutPolicy, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Ahx8o", "Errors.ResourceOwnerMissing")
+	}
+	existingPolicy, err := c.orgLockoutPolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State != domain.PolicyStateActive {
+		return c.AddLockoutPolicy(ctx, resourceOwner, policy)
+	}
+	return c.ChangeLockoutPolicy(ctx, resourceOwner, policy)
+}
+
 func (c *Commands) RemoveLockoutPolicy(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
 	if orgID == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-4J9fs", "Errors.ResourceOwnerMissing")
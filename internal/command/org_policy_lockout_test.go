@@ -276,6 +276,206 @@ func TestCommandSide_ChangePasswordLockoutPolicy(t *testing.T) {
 	}
 }
 
+func TestCommandSide_SetPasswordLockoutPolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx    context.Context
+		orgID  string
+		policy *domain.LockoutPolicy
+	}
+	type res struct {
+		want *domain.LockoutPolicy
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "org id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 10,
+					ShowLockOutFailures: true,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "no existing policy, added",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(),
+					expectFilter(),
+					expectPush(
+						org.NewLockoutPolicyAddedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							10,
+							true,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 10,
+					ShowLockOutFailures: true,
+				},
+			},
+			res: res{
+				want: &domain.LockoutPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "org1",
+						ResourceOwner: "org1",
+					},
+					MaxPasswordAttempts: 10,
+					ShowLockOutFailures: true,
+				},
+			},
+		},
+		{
+			name: "removed policy, added",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLockoutPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								10,
+								true,
+							),
+						),
+						eventFromEventPusher(
+							org.NewLockoutPolicyRemovedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLockoutPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								10,
+								true,
+							),
+						),
+						eventFromEventPusher(
+							org.NewLockoutPolicyRemovedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+							),
+						),
+					),
+					expectPush(
+						org.NewLockoutPolicyAddedEvent(context.Background(),
+							&org.NewAggregate("org1").Aggregate,
+							5,
+							false,
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 5,
+					ShowLockOutFailures: false,
+				},
+			},
+			res: res{
+				want: &domain.LockoutPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "org1",
+						ResourceOwner: "org1",
+					},
+					MaxPasswordAttempts: 5,
+					ShowLockOutFailures: false,
+				},
+			},
+		},
+		{
+			name: "existing policy, changed",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLockoutPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								10,
+								true,
+							),
+						),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewLockoutPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								10,
+								true,
+							),
+						),
+					),
+					expectPush(
+						newPasswordLockoutPolicyChangedEvent(context.Background(), "org1", 5, false),
+					),
+				),
+			},
+			args: args{
+				ctx:   context.Background(),
+				orgID: "org1",
+				policy: &domain.LockoutPolicy{
+					MaxPasswordAttempts: 5,
+					ShowLockOutFailures: false,
+				},
+			},
+			res: res{
+				want: &domain.LockoutPolicy{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "org1",
+						ResourceOwner: "org1",
+					},
+					MaxPasswordAttempts: 5,
+					ShowLockOutFailures: false,
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetLockoutPolicy(tt.args.ctx, tt.args.orgID, tt.args.policy)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
 func TestCommandSide_RemovePasswordLockoutPolicy(t *testing.T) {
 	type fields struct {
 		eventstore *eventstore.Eventstore
@@ -99,6 +99,22 @@ func (c *Commands) ChangePasswordComplexityPolicy(ctx context.Context, resourceO
 	return writeModelToPasswordComplexityPolicy(&existingPolicy.PasswordComplexityPolicyWriteModel), nil
 }
 
+// SetPasswordComplexityPolicy adds the password complexity policy of the organization
+// or changes it, if the organization already has its own policy.
+func (c *Commands) SetPasswordComplexityPolicy(ctx context.Context, resourceOwner string, policy *domain.PasswordComplexityPolicy) (*domain.PasswordComplexityPolicy, error) {
+	if resourceOwner == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-Ohg3i", "Errors.ResourceOwnerMissing")
+	}
+	existingPolicy, err := c.orgPasswordComplexityPolicyWriteModelByID(ctx, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingPolicy.State != domain.PolicyStateActive {
+		return c.AddPasswordComplexityPolicy(ctx, resourceOwner, policy)
+	}
+	return c.ChangePasswordComplexityPolicy(ctx, resourceOwner, policy)
+}
+
 func (c *Commands) RemovePasswordComplexityPolicy(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
 	if orgID == "" {
 		return nil, caos_errs.ThrowInvalidArgument(nil, "Org-J8fsf", "Errors.ResourceOwnerMissing")
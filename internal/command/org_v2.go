@@ -0,0 +1,88 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+// ChangeOrgV2 changes the name of the organization.
+// The caller must have the org.write permission on the organization.
+func (c *Commands) ChangeOrgV2(ctx context.Context, orgID, name string) (*domain.ObjectDetails, error) {
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, orgID, orgID); err != nil {
+		return nil, err
+	}
+	return c.ChangeOrg(ctx, orgID, name)
+}
+
+// DeactivateOrgV2 sets the state of the organization to inactive.
+// The caller must have the org.write permission on the organization.
+func (c *Commands) DeactivateOrgV2(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, orgID, orgID); err != nil {
+		return nil, err
+	}
+	return c.DeactivateOrg(ctx, orgID)
+}
+
+// ReactivateOrgV2 sets the state of an inactive organization back to active.
+// The caller must have the org.write permission on the organization.
+func (c *Commands) ReactivateOrgV2(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, orgID, orgID); err != nil {
+		return nil, err
+	}
+	return c.ReactivateOrg(ctx, orgID)
+}
+
+// RemoveOrgV2 removes the organization.
+// The caller must have the org.delete permission on the organization.
+func (c *Commands) RemoveOrgV2(ctx context.Context, orgID string) (*domain.ObjectDetails, error) {
+	if err := c.checkPermission(ctx, domain.PermissionOrgDelete, orgID, orgID); err != nil {
+		return nil, err
+	}
+	return c.RemoveOrg(ctx, orgID)
+}
+
+// AddOrgDomainV2 adds the domain to the organization and claims the passed users.
+// The caller must have the org.write permission on the organization.
+func (c *Commands) AddOrgDomainV2(ctx context.Context, orgID, orgDomain string, claimedUserIDs []string) (*domain.ObjectDetails, error) {
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, orgID, orgID); err != nil {
+		return nil, err
+	}
+	return c.AddOrgDomain(ctx, orgID, orgDomain, claimedUserIDs)
+}
+
+// RemoveOrgDomainV2 removes the domain of the organization.
+// The caller must have the org.write permission on the organization.
+func (c *Commands) RemoveOrgDomainV2(ctx context.Context, orgDomain *domain.OrgDomain) (*domain.ObjectDetails, error) {
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, orgDomain.AggregateID, orgDomain.AggregateID); err != nil {
+		return nil, err
+	}
+	return c.RemoveOrgDomain(ctx, orgDomain)
+}
+
+// GenerateOrgDomainValidationV2 returns the token and url to validate the domain of the organization.
+// The caller must have the org.write permission on the organization.
+func (c *Commands) GenerateOrgDomainValidationV2(ctx context.Context, orgDomain *domain.OrgDomain) (token, url string, err error) {
+	if err = c.checkPermission(ctx, domain.PermissionOrgWrite, orgDomain.AggregateID, orgDomain.AggregateID); err != nil {
+		return "", "", err
+	}
+	return c.GenerateOrgDomainValidation(ctx, orgDomain)
+}
+
+// ValidateOrgDomainV2 validates the domain of the organization and claims the passed users.
+// The caller must have the org.write permission on the organization.
+func (c *Commands) ValidateOrgDomainV2(ctx context.Context, orgDomain *domain.OrgDomain, claimedUserIDs []string) (*domain.ObjectDetails, error) {
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, orgDomain.AggregateID, orgDomain.AggregateID); err != nil {
+		return nil, err
+	}
+	return c.ValidateOrgDomain(ctx, orgDomain, claimedUserIDs)
+}
+
+// SetPrimaryOrgDomainV2 sets the verified domain as primary domain of the organization.
+// The caller must have the org.write permission on the organization.
+func (c *Commands) SetPrimaryOrgDomainV2(ctx context.Context, orgDomain *domain.OrgDomain) (*domain.ObjectDetails, error) {
+	if err := c.checkPermission(ctx, domain.PermissionOrgWrite, orgDomain.AggregateID, orgDomain.AggregateID); err != nil {
+		return nil, err
+	}
+	return c.SetPrimaryOrgDomain(ctx, orgDomain)
+}
@@ -0,0 +1,73 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+func TestCommands_OrgV2(t *testing.T) {
+	orgDomain := &domain.OrgDomain{ObjectRoot: models.ObjectRoot{AggregateID: "org1"}, Domain: "zitadel.ch"}
+	run := map[string]func(c *Commands, ctx context.Context) error{
+		"ChangeOrgV2": func(c *Commands, ctx context.Context) error {
+			_, err := c.ChangeOrgV2(ctx, "org1", "")
+			return err
+		},
+		"DeactivateOrgV2": func(c *Commands, ctx context.Context) error {
+			_, err := c.DeactivateOrgV2(ctx, "org1")
+			return err
+		},
+		"ReactivateOrgV2": func(c *Commands, ctx context.Context) error {
+			_, err := c.ReactivateOrgV2(ctx, "org1")
+			return err
+		},
+		"RemoveOrgV2": func(c *Commands, ctx context.Context) error {
+			_, err := c.RemoveOrgV2(ctx, "org1")
+			return err
+		},
+		"AddOrgDomainV2": func(c *Commands, ctx context.Context) error {
+			_, err := c.AddOrgDomainV2(ctx, "org1", "zitadel.ch", nil)
+			return err
+		},
+		"RemoveOrgDomainV2": func(c *Commands, ctx context.Context) error {
+			_, err := c.RemoveOrgDomainV2(ctx, orgDomain)
+			return err
+		},
+		"GenerateOrgDomainValidationV2": func(c *Commands, ctx context.Context) error {
+			_, _, err := c.GenerateOrgDomainValidationV2(ctx, orgDomain)
+			return err
+		},
+		"ValidateOrgDomainV2": func(c *Commands, ctx context.Context) error {
+			_, err := c.ValidateOrgDomainV2(ctx, orgDomain, nil)
+			return err
+		},
+		"SetPrimaryOrgDomainV2": func(c *Commands, ctx context.Context) error {
+			_, err := c.SetPrimaryOrgDomainV2(ctx, orgDomain)
+			return err
+		},
+	}
+	for name, command := range run {
+		t.Run(name+", permission denied", func(t *testing.T) {
+			// the eventstore must not be called without permission
+			c := &Commands{
+				eventstore:      eventstoreExpect(t),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			}
+			err := command(c, context.Background())
+			assert.True(t, caos_errs.IsPermissionDenied(err), "unexpected error: %v", err)
+		})
+	}
+	t.Run("ChangeOrgV2, allowed, name missing", func(t *testing.T) {
+		c := &Commands{
+			eventstore:      eventstoreExpect(t),
+			checkPermission: newMockPermissionCheckAllowed(),
+		}
+		err := run["ChangeOrgV2"](c, context.Background())
+		assert.True(t, caos_errs.IsErrorInvalidArgument(err), "unexpected error: %v", err)
+	})
+}
@@ -0,0 +1,31 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+type ImpersonationPolicyWriteModel struct {
+	eventstore.WriteModel
+
+	AllowImpersonation bool
+	State              domain.PolicyState
+}
+
+func (wm *ImpersonationPolicyWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *policy.ImpersonationPolicyAddedEvent:
+			wm.AllowImpersonation = e.AllowImpersonation
+			wm.State = domain.PolicyStateActive
+		case *policy.ImpersonationPolicyChangedEvent:
+			if e.AllowImpersonation != nil {
+				wm.AllowImpersonation = *e.AllowImpersonation
+			}
+		case *policy.ImpersonationPolicyRemovedEvent:
+			wm.State = domain.PolicyStateRemoved
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
@@ -19,21 +19,28 @@ import (
 
 type addOIDCApp struct {
 	AddApp
-	Version                     domain.OIDCVersion
-	RedirectUris                []string
-	ResponseTypes               []domain.OIDCResponseType
-	GrantTypes                  []domain.OIDCGrantType
-	ApplicationType             domain.OIDCApplicationType
-	AuthMethodType              domain.OIDCAuthMethodType
-	PostLogoutRedirectUris      []string
-	DevMode                     bool
-	AccessTokenType             domain.OIDCTokenType
-	AccessTokenRoleAssertion    bool
-	IDTokenRoleAssertion        bool
-	IDTokenUserinfoAssertion    bool
-	ClockSkew                   time.Duration
-	AdditionalOrigins           []string
-	SkipSuccessPageForNativeApp bool
+	Version                          domain.OIDCVersion
+	RedirectUris                     []string
+	ResponseTypes                    []domain.OIDCResponseType
+	GrantTypes                       []domain.OIDCGrantType
+	ApplicationType                  domain.OIDCApplicationType
+	AuthMethodType                   domain.OIDCAuthMethodType
+	PostLogoutRedirectUris           []string
+	DevMode                          bool
+	AccessTokenType                  domain.OIDCTokenType
+	AccessTokenRoleAssertion         bool
+	IDTokenRoleAssertion             bool
+	IDTokenUserinfoAssertion         bool
+	ClockSkew                        time.Duration
+	AdditionalOrigins                []string
+	SkipSuccessPageForNativeApp      bool
+	BackChannelLogoutURI             string
+	FrontChannelLogoutURI            string
+	RequirePushedAuthRequest         bool
+	RequireSignedRequest             bool
+	DPoPBoundAccessTokens            bool
+	ForceRSASignedTokens             bool
+	BackChannelClientNotificationURI string
 
 	ClientID          string
 	ClientSecret      *crypto.CryptoValue
@@ -61,6 +68,14 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 			}
 		}
 
+		if !(&domain.OIDCApp{BackChannelLogoutURI: app.BackChannelLogoutURI, FrontChannelLogoutURI: app.FrontChannelLogoutURI}).LogoutURIsValid() {
+			return nil, errors.ThrowInvalidArgument(nil, "V2-Aeph3", "Errors.Invalid.Argument")
+		}
+
+		if !(&domain.OIDCApp{BackChannelClientNotificationURI: app.BackChannelClientNotificationURI}).BackChannelClientNotificationURIValid() {
+			return nil, errors.ThrowInvalidArgument(nil, "V2-ohG4e", "Errors.Invalid.Argument")
+		}
+
 		if !domain.ContainsRequiredGrantTypes(app.ResponseTypes, app.GrantTypes) {
 			return nil, errors.ThrowInvalidArgument(nil, "V2-sLpW1", "Errors.Invalid.Argument")
 		}
@@ -112,6 +127,13 @@ func (c *Commands) AddOIDCAppCommand(app *addOIDCApp, clientSecretAlg crypto.Has
 					app.ClockSkew,
 					app.AdditionalOrigins,
 					app.SkipSuccessPageForNativeApp,
+					app.BackChannelLogoutURI,
+					app.FrontChannelLogoutURI,
+					app.RequirePushedAuthRequest,
+					app.RequireSignedRequest,
+					app.DPoPBoundAccessTokens,
+					app.ForceRSASignedTokens,
+					app.BackChannelClientNotificationURI,
 				),
 			}, nil
 		}, nil
@@ -156,7 +178,8 @@ func (c *Commands) AddOIDCApplication(ctx context.Context, oidcApp *domain.OIDCA
 	return c.addOIDCApplicationWithID(ctx, oidcApp, resourceOwner, project, appID, appSecretGenerator)
 }
 
-func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain.OIDCApp, resourceOwner string, project *domain.Project, appID string, appSecretGenerator crypto.Generator) (_ *domain.OIDCApp, err error) {
+// addOIDCApplicationWithID adds the app and pushes the additionalEvents in the same transaction
+func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain.OIDCApp, resourceOwner string, project *domain.Project, appID string, appSecretGenerator crypto.Generator, additionalEvents ...eventstore.Command) (_ *domain.OIDCApp, err error) {
 
 	addedApplication := NewOIDCApplicationWriteModel(oidcApp.AggregateID, resourceOwner)
 	projectAgg := ProjectAggregateFromWriteModel(&addedApplication.WriteModel)
@@ -196,7 +219,15 @@ func (c *Commands) addOIDCApplicationWithID(ctx context.Context, oidcApp *domain
 		oidcApp.ClockSkew,
 		oidcApp.AdditionalOrigins,
 		oidcApp.SkipNativeAppSuccessPage,
+		oidcApp.BackChannelLogoutURI,
+		oidcApp.FrontChannelLogoutURI,
+		oidcApp.RequirePushedAuthRequest,
+		oidcApp.RequireSignedRequest,
+		oidcApp.DPoPBoundAccessTokens,
+		oidcApp.ForceRSASignedTokens,
+		oidcApp.BackChannelClientNotificationURI,
 	))
+	events = append(events, additionalEvents...)
 
 	addedApplication.AppID = oidcApp.AppID
 	pushedEvents, err := c.eventstore.Push(ctx, events...)
@@ -248,6 +279,13 @@ func (c *Commands) ChangeOIDCApplication(ctx context.Context, oidc *domain.OIDCA
 		oidc.ClockSkew,
 		oidc.AdditionalOrigins,
 		oidc.SkipNativeAppSuccessPage,
+		oidc.BackChannelLogoutURI,
+		oidc.FrontChannelLogoutURI,
+		oidc.RequirePushedAuthRequest,
+		oidc.RequireSignedRequest,
+		oidc.DPoPBoundAccessTokens,
+		oidc.ForceRSASignedTokens,
+		oidc.BackChannelClientNotificationURI,
 	)
 	if err != nil {
 		return nil, err
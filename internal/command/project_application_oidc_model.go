@@ -14,29 +14,36 @@ import (
 type OIDCApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID                    string
-	AppName                  string
-	ClientID                 string
-	ClientSecret             *crypto.CryptoValue
-	ClientSecretString       string
-	RedirectUris             []string
-	ResponseTypes            []domain.OIDCResponseType
-	GrantTypes               []domain.OIDCGrantType
-	ApplicationType          domain.OIDCApplicationType
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectUris   []string
-	OIDCVersion              domain.OIDCVersion
-	Compliance               *domain.Compliance
-	DevMode                  bool
-	AccessTokenType          domain.OIDCTokenType
-	AccessTokenRoleAssertion bool
-	IDTokenRoleAssertion     bool
-	IDTokenUserinfoAssertion bool
-	ClockSkew                time.Duration
-	State                    domain.AppState
-	AdditionalOrigins        []string
-	SkipNativeAppSuccessPage bool
-	oidc                     bool
+	AppID                            string
+	AppName                          string
+	ClientID                         string
+	ClientSecret                     *crypto.CryptoValue
+	ClientSecretString               string
+	RedirectUris                     []string
+	ResponseTypes                    []domain.OIDCResponseType
+	GrantTypes                       []domain.OIDCGrantType
+	ApplicationType                  domain.OIDCApplicationType
+	AuthMethodType                   domain.OIDCAuthMethodType
+	PostLogoutRedirectUris           []string
+	OIDCVersion                      domain.OIDCVersion
+	Compliance                       *domain.Compliance
+	DevMode                          bool
+	AccessTokenType                  domain.OIDCTokenType
+	AccessTokenRoleAssertion         bool
+	IDTokenRoleAssertion             bool
+	IDTokenUserinfoAssertion         bool
+	ClockSkew                        time.Duration
+	State                            domain.AppState
+	AdditionalOrigins                []string
+	SkipNativeAppSuccessPage         bool
+	BackChannelLogoutURI             string
+	FrontChannelLogoutURI            string
+	RequirePushedAuthRequest         bool
+	RequireSignedRequest             bool
+	DPoPBoundAccessTokens            bool
+	ForceRSASignedTokens             bool
+	BackChannelClientNotificationURI string
+	oidc                             bool
 }
 
 func NewOIDCApplicationWriteModelWithAppID(projectID, appID, resourceOwner string) *OIDCApplicationWriteModel {
@@ -158,6 +165,13 @@ func (wm *OIDCApplicationWriteModel) appendAddOIDCEvent(e *project.OIDCConfigAdd
 	wm.ClockSkew = e.ClockSkew
 	wm.AdditionalOrigins = e.AdditionalOrigins
 	wm.SkipNativeAppSuccessPage = e.SkipNativeAppSuccessPage
+	wm.BackChannelLogoutURI = e.BackChannelLogoutURI
+	wm.FrontChannelLogoutURI = e.FrontChannelLogoutURI
+	wm.RequirePushedAuthRequest = e.RequirePushedAuthRequest
+	wm.RequireSignedRequest = e.RequireSignedRequest
+	wm.DPoPBoundAccessTokens = e.DPoPBoundAccessTokens
+	wm.ForceRSASignedTokens = e.ForceRSASignedTokens
+	wm.BackChannelClientNotificationURI = e.BackChannelClientNotificationURI
 }
 
 func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfigChangedEvent) {
@@ -206,6 +220,27 @@ func (wm *OIDCApplicationWriteModel) appendChangeOIDCEvent(e *project.OIDCConfig
 	if e.SkipNativeAppSuccessPage != nil {
 		wm.SkipNativeAppSuccessPage = *e.SkipNativeAppSuccessPage
 	}
+	if e.BackChannelLogoutURI != nil {
+		wm.BackChannelLogoutURI = *e.BackChannelLogoutURI
+	}
+	if e.FrontChannelLogoutURI != nil {
+		wm.FrontChannelLogoutURI = *e.FrontChannelLogoutURI
+	}
+	if e.RequirePushedAuthRequest != nil {
+		wm.RequirePushedAuthRequest = *e.RequirePushedAuthRequest
+	}
+	if e.RequireSignedRequest != nil {
+		wm.RequireSignedRequest = *e.RequireSignedRequest
+	}
+	if e.DPoPBoundAccessTokens != nil {
+		wm.DPoPBoundAccessTokens = *e.DPoPBoundAccessTokens
+	}
+	if e.ForceRSASignedTokens != nil {
+		wm.ForceRSASignedTokens = *e.ForceRSASignedTokens
+	}
+	if e.BackChannelClientNotificationURI != nil {
+		wm.BackChannelClientNotificationURI = *e.BackChannelClientNotificationURI
+	}
 }
 
 func (wm *OIDCApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -246,6 +281,13 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	clockSkew time.Duration,
 	additionalOrigins []string,
 	skipNativeAppSuccessPage bool,
+	backChannelLogoutURI,
+	frontChannelLogoutURI string,
+	requirePushedAuthRequest,
+	requireSignedRequest,
+	dpopBoundAccessTokens,
+	forceRSASignedTokens bool,
+	backChannelClientNotificationURI string,
 ) (*project.OIDCConfigChangedEvent, bool, error) {
 	changes := make([]project.OIDCConfigChanges, 0)
 	var err error
@@ -295,6 +337,27 @@ func (wm *OIDCApplicationWriteModel) NewChangedEvent(
 	if wm.SkipNativeAppSuccessPage != skipNativeAppSuccessPage {
 		changes = append(changes, project.ChangeSkipNativeAppSuccessPage(skipNativeAppSuccessPage))
 	}
+	if wm.BackChannelLogoutURI != backChannelLogoutURI {
+		changes = append(changes, project.ChangeBackChannelLogoutURI(backChannelLogoutURI))
+	}
+	if wm.FrontChannelLogoutURI != frontChannelLogoutURI {
+		changes = append(changes, project.ChangeFrontChannelLogoutURI(frontChannelLogoutURI))
+	}
+	if wm.RequirePushedAuthRequest != requirePushedAuthRequest {
+		changes = append(changes, project.ChangeRequirePushedAuthRequest(requirePushedAuthRequest))
+	}
+	if wm.RequireSignedRequest != requireSignedRequest {
+		changes = append(changes, project.ChangeRequireSignedRequest(requireSignedRequest))
+	}
+	if wm.DPoPBoundAccessTokens != dpopBoundAccessTokens {
+		changes = append(changes, project.ChangeDPoPBoundAccessTokens(dpopBoundAccessTokens))
+	}
+	if wm.ForceRSASignedTokens != forceRSASignedTokens {
+		changes = append(changes, project.ChangeForceRSASignedTokens(forceRSASignedTokens))
+	}
+	if wm.BackChannelClientNotificationURI != backChannelClientNotificationURI {
+		changes = append(changes, project.ChangeBackChannelClientNotificationURI(backChannelClientNotificationURI))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
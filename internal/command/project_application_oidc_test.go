@@ -169,6 +169,13 @@ func TestAddOIDCApp(t *testing.T) {
 						0,
 						nil,
 						false,
+						"",
+						"",
+						false,
+						false,
+						false,
+						false,
+						"",
 					),
 				},
 			},
@@ -323,6 +330,13 @@ func TestCommandSide_AddOIDCApplication(t *testing.T) {
 							time.Second*1,
 							[]string{"https://sub.test.ch"},
 							true,
+							"",
+							"",
+							false,
+							false,
+							false,
+							false,
+							"",
 						),
 					),
 				),
@@ -557,6 +571,13 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								"",
+								"",
+								false,
+								false,
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -631,6 +652,13 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								true,
+								"",
+								"",
+								false,
+								false,
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -665,6 +693,10 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 					ClockSkew:                time.Second * 2,
 					AdditionalOrigins:        []string{"https://sub.test.ch"},
 					SkipNativeAppSuccessPage: true,
+					BackChannelLogoutURI:     "https://test-change.ch/logout/backchannel",
+					FrontChannelLogoutURI:    "https://test-change.ch/logout/frontchannel",
+					RequirePushedAuthRequest: true,
+					RequireSignedRequest:     true,
 				},
 				resourceOwner: "org1",
 			},
@@ -692,6 +724,10 @@ func TestCommandSide_ChangeOIDCApplication(t *testing.T) {
 					ClockSkew:                time.Second * 2,
 					AdditionalOrigins:        []string{"https://sub.test.ch"},
 					SkipNativeAppSuccessPage: true,
+					BackChannelLogoutURI:     "https://test-change.ch/logout/backchannel",
+					FrontChannelLogoutURI:    "https://test-change.ch/logout/frontchannel",
+					RequirePushedAuthRequest: true,
+					RequireSignedRequest:     true,
 					Compliance:               &domain.Compliance{},
 					State:                    domain.AppStateActive,
 				},
@@ -828,6 +864,13 @@ func TestCommandSide_ChangeOIDCApplicationSecret(t *testing.T) {
 								time.Second*1,
 								[]string{"https://sub.test.ch"},
 								false,
+								"",
+								"",
+								false,
+								false,
+								false,
+								false,
+								"",
 							),
 						),
 					),
@@ -911,6 +954,10 @@ func newOIDCAppChangedEvent(ctx context.Context, appID, projectID, resourceOwner
 		project.ChangeIDTokenRoleAssertion(false),
 		project.ChangeIDTokenUserinfoAssertion(false),
 		project.ChangeClockSkew(time.Second * 2),
+		project.ChangeBackChannelLogoutURI("https://test-change.ch/logout/backchannel"),
+		project.ChangeFrontChannelLogoutURI("https://test-change.ch/logout/frontchannel"),
+		project.ChangeRequirePushedAuthRequest(true),
+		project.ChangeRequireSignedRequest(true),
 	}
 	event, _ := project.NewOIDCConfigChangedEvent(ctx,
 		&project.NewAggregate(projectID, resourceOwner).Aggregate,
@@ -0,0 +1,92 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// SetApplicationProvisioning sets the SCIM service provider the users granted on the project are provisioned to.
+// The token is encrypted and kept if it's omitted on a change of the endpoint.
+func (c *Commands) SetApplicationProvisioning(ctx context.Context, provisioning *domain.AppProvisioning, resourceOwner string) (*domain.ObjectDetails, error) {
+	if !provisioning.IsValid() {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ahm3o", "Errors.Project.App.Provisioning.Invalid")
+	}
+	writeModel, err := c.applicationProvisioningWriteModelByID(ctx, provisioning.AggregateID, provisioning.AppID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.AppState.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Fai5o", "Errors.Project.App.NotFound")
+	}
+	if provisioning.Token == "" && !writeModel.ProvisioningSet {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Que1a", "Errors.Project.App.Provisioning.Invalid")
+	}
+	if provisioning.Token == "" && provisioning.Endpoint == writeModel.Endpoint {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-ohT9a", "Errors.NoChangesFound")
+	}
+	var token *crypto.CryptoValue
+	if provisioning.Token != "" {
+		token, err = crypto.Encrypt([]byte(provisioning.Token), c.idpConfigEncryption)
+		if err != nil {
+			return nil, err
+		}
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewApplicationProvisioningSetEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		provisioning.AppID,
+		provisioning.Endpoint,
+		token,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) RemoveApplicationProvisioning(ctx context.Context, projectID, appID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if projectID == "" || appID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ieh9u", "Errors.IDMissing")
+	}
+	writeModel, err := c.applicationProvisioningWriteModelByID(ctx, projectID, appID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.ProvisioningSet {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Aib4u", "Errors.Project.App.Provisioning.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewApplicationProvisioningRemovedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		appID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) applicationProvisioningWriteModelByID(ctx context.Context, projectID, appID, resourceOwner string) (writeModel *ApplicationProvisioningWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewApplicationProvisioningWriteModel(projectID, appID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,103 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type ApplicationProvisioningWriteModel struct {
+	eventstore.WriteModel
+
+	AppID    string
+	AppState domain.AppState
+
+	Endpoint        string
+	Token           *crypto.CryptoValue
+	ProvisioningSet bool
+}
+
+func NewApplicationProvisioningWriteModel(projectID, appID, resourceOwner string) *ApplicationProvisioningWriteModel {
+	return &ApplicationProvisioningWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		AppID: appID,
+	}
+}
+
+func (wm *ApplicationProvisioningWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationProvisioningSetEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationProvisioningRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *ApplicationProvisioningWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			wm.AppState = domain.AppStateActive
+		case *project.ApplicationRemovedEvent:
+			wm.AppState = domain.AppStateRemoved
+			wm.removeProvisioning()
+		case *project.ApplicationProvisioningSetEvent:
+			wm.Endpoint = e.Endpoint
+			if e.Token != nil {
+				wm.Token = e.Token
+			}
+			wm.ProvisioningSet = true
+		case *project.ApplicationProvisioningRemovedEvent:
+			wm.removeProvisioning()
+		case *project.ProjectRemovedEvent:
+			wm.AppState = domain.AppStateRemoved
+			wm.removeProvisioning()
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ApplicationProvisioningWriteModel) removeProvisioning() {
+	wm.Endpoint = ""
+	wm.Token = nil
+	wm.ProvisioningSet = false
+}
+
+func (wm *ApplicationProvisioningWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ApplicationAddedType,
+			project.ApplicationRemovedType,
+			project.ApplicationProvisioningSetType,
+			project.ApplicationProvisioningRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+}
@@ -76,6 +76,9 @@ func (c *Commands) addSAMLApplication(ctx context.Context, projectAgg *eventstor
 			string(entity.EntityID),
 			samlApp.Metadata,
 			samlApp.MetadataURL,
+			samlApp.EncryptAssertions,
+			samlApp.ArtifactBinding,
+			samlApp.SingleLogout,
 		),
 	}, nil
 }
@@ -116,7 +119,11 @@ func (c *Commands) ChangeSAMLApplication(ctx context.Context, samlApp *domain.SA
 		samlApp.AppID,
 		string(entity.EntityID),
 		samlApp.Metadata,
-		samlApp.MetadataURL)
+		samlApp.MetadataURL,
+		samlApp.EncryptAssertions,
+		samlApp.ArtifactBinding,
+		samlApp.SingleLogout,
+	)
 	if err != nil {
 		return nil, err
 	}
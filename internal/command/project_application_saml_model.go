@@ -12,11 +12,14 @@ import (
 type SAMLApplicationWriteModel struct {
 	eventstore.WriteModel
 
-	AppID       string
-	AppName     string
-	EntityID    string
-	Metadata    []byte
-	MetadataURL string
+	AppID             string
+	AppName           string
+	EntityID          string
+	Metadata          []byte
+	MetadataURL       string
+	EncryptAssertions bool
+	ArtifactBinding   bool
+	SingleLogout      bool
 
 	State domain.AppState
 	saml  bool
@@ -121,6 +124,9 @@ func (wm *SAMLApplicationWriteModel) appendAddSAMLEvent(e *project.SAMLConfigAdd
 	wm.Metadata = e.Metadata
 	wm.MetadataURL = e.MetadataURL
 	wm.EntityID = e.EntityID
+	wm.EncryptAssertions = e.EncryptAssertions
+	wm.ArtifactBinding = e.ArtifactBinding
+	wm.SingleLogout = e.SingleLogout
 }
 
 func (wm *SAMLApplicationWriteModel) appendChangeSAMLEvent(e *project.SAMLConfigChangedEvent) {
@@ -134,6 +140,15 @@ func (wm *SAMLApplicationWriteModel) appendChangeSAMLEvent(e *project.SAMLConfig
 	if e.EntityID != "" {
 		wm.EntityID = e.EntityID
 	}
+	if e.EncryptAssertions != nil {
+		wm.EncryptAssertions = *e.EncryptAssertions
+	}
+	if e.ArtifactBinding != nil {
+		wm.ArtifactBinding = *e.ArtifactBinding
+	}
+	if e.SingleLogout != nil {
+		wm.SingleLogout = *e.SingleLogout
+	}
 }
 
 func (wm *SAMLApplicationWriteModel) Query() *eventstore.SearchQueryBuilder {
@@ -161,6 +176,9 @@ func (wm *SAMLApplicationWriteModel) NewChangedEvent(
 	entityID string,
 	metadata []byte,
 	metadataURL string,
+	encryptAssertions bool,
+	artifactBinding bool,
+	singleLogout bool,
 ) (*project.SAMLConfigChangedEvent, bool, error) {
 	changes := make([]project.SAMLConfigChanges, 0)
 	var err error
@@ -173,6 +191,15 @@ func (wm *SAMLApplicationWriteModel) NewChangedEvent(
 	if wm.EntityID != entityID {
 		changes = append(changes, project.ChangeEntityID(entityID))
 	}
+	if wm.EncryptAssertions != encryptAssertions {
+		changes = append(changes, project.ChangeEncryptAssertions(encryptAssertions))
+	}
+	if wm.ArtifactBinding != artifactBinding {
+		changes = append(changes, project.ChangeArtifactBinding(artifactBinding))
+	}
+	if wm.SingleLogout != singleLogout {
+		changes = append(changes, project.ChangeSingleLogout(singleLogout))
+	}
 
 	if len(changes) == 0 {
 		return nil, false, nil
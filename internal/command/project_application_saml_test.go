@@ -195,6 +195,9 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							"https://test.com/saml/metadata",
 							testMetadata,
 							"",
+							false,
+							false,
+							false,
 						),
 					),
 				),
@@ -253,6 +256,9 @@ func TestCommandSide_AddSAMLApplication(t *testing.T) {
 							"https://test.com/saml/metadata",
 							testMetadata,
 							"http://localhost:8080/saml/metadata",
+							false,
+							false,
+							false,
 						),
 					),
 				),
@@ -473,6 +479,9 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"http://localhost:8080/saml/metadata",
+								false,
+								false,
+								false,
 							),
 						),
 					),
@@ -518,6 +527,9 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"",
+								false,
+								false,
+								false,
 							),
 						),
 					),
@@ -563,6 +575,9 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"http://localhost:8080/saml/metadata",
+								false,
+								false,
+								false,
 							),
 						),
 					),
@@ -629,6 +644,9 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 								"https://test.com/saml/metadata",
 								testMetadata,
 								"",
+								false,
+								false,
+								false,
 							),
 						),
 					),
@@ -675,6 +693,79 @@ func TestCommandSide_ChangeSAMLApplication(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "change saml app, ok, settings",
+			fields: fields{
+				eventstore: eventstoreExpect(
+					t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewApplicationAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"app",
+							),
+						),
+						eventFromEventPusher(
+							project.NewSAMLConfigAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"app1",
+								"https://test.com/saml/metadata",
+								testMetadata,
+								"",
+								false,
+								true,
+								false,
+							),
+						),
+					),
+					expectPush(
+						newSAMLAppChangedEventSettings(context.Background(),
+							"app1",
+							"project1",
+							"org1",
+							"https://test.com/saml/metadata",
+						),
+					),
+				),
+				httpClient: nil,
+			},
+			args: args{
+				ctx: context.Background(),
+				samlApp: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:             "app1",
+					AppName:           "app",
+					EntityID:          "https://test.com/saml/metadata",
+					Metadata:          testMetadata,
+					MetadataURL:       "",
+					EncryptAssertions: true,
+					ArtifactBinding:   false,
+					SingleLogout:      true,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.SAMLApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:             "app1",
+					AppName:           "app",
+					EntityID:          "https://test.com/saml/metadata",
+					Metadata:          testMetadata,
+					MetadataURL:       "",
+					EncryptAssertions: true,
+					ArtifactBinding:   false,
+					SingleLogout:      true,
+					State:             domain.AppStateActive,
+				},
+			},
+		},
 	}
 
 	for _, tt := range tests {
@@ -745,3 +836,18 @@ func newTestClient(httpStatus int, metadata []byte) *http.Client {
 		Transport: fn,
 	}
 }
+
+func newSAMLAppChangedEventSettings(ctx context.Context, appID, projectID, resourceOwner, entityID string) *project.SAMLConfigChangedEvent {
+	changes := []project.SAMLConfigChanges{
+		project.ChangeEncryptAssertions(true),
+		project.ChangeArtifactBinding(false),
+		project.ChangeSingleLogout(true),
+	}
+	event, _ := project.NewSAMLConfigChangedEvent(ctx,
+		&project.NewAggregate(projectID, resourceOwner).Aggregate,
+		appID,
+		entityID,
+		changes,
+	)
+	return event
+}
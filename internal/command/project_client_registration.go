@@ -0,0 +1,321 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// SetClientRegistrationPolicy sets the restrictions of the OIDC applications,
+// which can be registered dynamically in the project
+func (c *Commands) SetClientRegistrationPolicy(ctx context.Context, policy *domain.ClientRegistrationPolicy, resourceOwner string) (*domain.ObjectDetails, error) {
+	if !policy.IsValid() {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ohW2e", "Errors.Project.ClientRegistration.PolicyInvalid")
+	}
+	writeModel, err := c.clientRegistrationWriteModelByID(ctx, policy.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.ProjectState.Valid() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Iej3i", "Errors.Project.NotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewClientRegistrationPolicySetEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		policy.AllowedGrantTypes,
+		policy.RedirectURIPatterns,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RemoveClientRegistrationPolicy removes the policy, which prevents any further registration in the project
+func (c *Commands) RemoveClientRegistrationPolicy(ctx context.Context, projectID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if projectID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ree0u", "Errors.IDMissing")
+	}
+	writeModel, err := c.clientRegistrationWriteModelByID(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.PolicySet {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Ahb7o", "Errors.Project.ClientRegistration.PolicyNotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewClientRegistrationPolicyRemovedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// AddClientRegistrationToken adds an initial access token, which allows to register clients in the project.
+// The token is only returned once.
+func (c *Commands) AddClientRegistrationToken(ctx context.Context, token *domain.ClientRegistrationToken, resourceOwner string) (_ *domain.ClientRegistrationToken, err error) {
+	if token.AggregateID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-eeG6x", "Errors.IDMissing")
+	}
+	if !token.ExpirationDate.After(time.Now()) {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Lah4u", "Errors.Project.ClientRegistration.TokenExpirationInvalid")
+	}
+	writeModel, err := c.clientRegistrationWriteModelByID(ctx, token.AggregateID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.ProjectState.Valid() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-aiT5u", "Errors.Project.NotFound")
+	}
+	if !writeModel.PolicySet {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ou6sh", "Errors.Project.ClientRegistration.PolicyNotFound")
+	}
+	token.TokenID, err = c.idGenerator.Next()
+	if err != nil {
+		return nil, err
+	}
+	token.Token, err = createClientRegistrationToken(c.keyAlgorithm, initialAccessTokenPrefix, token.TokenID, token.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewClientRegistrationTokenAddedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		token.TokenID,
+		token.ExpirationDate,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	token.ObjectRoot = writeModelToObjectRoot(writeModel.WriteModel)
+	return token, nil
+}
+
+func (c *Commands) RemoveClientRegistrationToken(ctx context.Context, projectID, tokenID, resourceOwner string) (*domain.ObjectDetails, error) {
+	if projectID == "" || tokenID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-oph4E", "Errors.IDMissing")
+	}
+	writeModel, err := c.clientRegistrationWriteModelByID(ctx, projectID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if _, ok := writeModel.Tokens[tokenID]; !ok {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Eih5o", "Errors.Project.ClientRegistration.TokenNotFound")
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, project.NewClientRegistrationTokenRemovedEvent(
+		ctx,
+		ProjectAggregateFromWriteModel(&writeModel.WriteModel),
+		tokenID,
+	))
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+// RegisterOIDCClient registers the app (RFC 7591) in the project of the initial access token,
+// if it complies with the client registration policy of the project.
+// Additionally to the app, the registration access token (RFC 7592) is returned,
+// which allows the client to read, change and remove its registration.
+func (c *Commands) RegisterOIDCClient(ctx context.Context, initialAccessToken string, app *domain.OIDCApp, appSecretGenerator crypto.Generator) (_ *domain.OIDCApp, registrationAccessToken string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	tokenID, projectID, err := c.parseClientRegistrationToken(initialAccessToken, initialAccessTokenPrefix)
+	if err != nil {
+		return nil, "", err
+	}
+	writeModel, err := c.clientRegistrationWriteModelByID(ctx, projectID, "")
+	if err != nil {
+		return nil, "", err
+	}
+	expiration, ok := writeModel.Tokens[tokenID]
+	if !ok || !expiration.After(time.Now()) || !writeModel.ProjectState.Valid() {
+		return nil, "", errors.ThrowUnauthenticated(nil, "COMMAND-Thai3", "Errors.Project.ClientRegistration.TokenInvalid")
+	}
+	if !writeModel.PolicySet {
+		return nil, "", errors.ThrowPreconditionFailed(nil, "COMMAND-Jee5u", "Errors.Project.ClientRegistration.PolicyNotFound")
+	}
+	app.AggregateID = projectID
+	if app.AppName == "" || !app.IsValid() {
+		return nil, "", errors.ThrowInvalidArgument(nil, "COMMAND-ieX2a", "Errors.Project.App.Invalid")
+	}
+	if err = writeModel.policy().Check(app); err != nil {
+		return nil, "", err
+	}
+	projectInfo, err := c.getProjectByID(ctx, projectID, writeModel.ResourceOwner)
+	if err != nil {
+		return nil, "", err
+	}
+	appID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	registrationTokenID, err := c.idGenerator.Next()
+	if err != nil {
+		return nil, "", err
+	}
+	registrationAccessToken, err = createClientRegistrationToken(c.keyAlgorithm, registrationAccessTokenPrefix, registrationTokenID, projectID+tokenPartDelimiter+appID)
+	if err != nil {
+		return nil, "", err
+	}
+	result, err := c.addOIDCApplicationWithID(ctx, app, writeModel.ResourceOwner, projectInfo, appID, appSecretGenerator,
+		project.NewApplicationRegistrationTokenSetEvent(ctx, ProjectAggregateFromWriteModel(&writeModel.WriteModel), appID, registrationTokenID),
+	)
+	if err != nil {
+		return nil, "", err
+	}
+	return result, registrationAccessToken, nil
+}
+
+// CheckClientRegistrationAccessToken checks if the registration access token (RFC 7592) is valid for the client
+// and returns the ids of the project and the app of the client
+func (c *Commands) CheckClientRegistrationAccessToken(ctx context.Context, registrationAccessToken, clientID string) (projectID, appID string, err error) {
+	writeModel, err := c.registeredClientWriteModelByToken(ctx, registrationAccessToken, clientID)
+	if err != nil {
+		return "", "", err
+	}
+	return writeModel.AggregateID, writeModel.AppID, nil
+}
+
+// ChangeRegisteredOIDCClient replaces the configuration of a dynamically registered client (RFC 7592).
+// The new configuration must comply with the current client registration policy of the project.
+func (c *Commands) ChangeRegisteredOIDCClient(ctx context.Context, registrationAccessToken string, app *domain.OIDCApp) (_ *domain.OIDCApp, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	client, err := c.registeredClientWriteModelByToken(ctx, registrationAccessToken, app.ClientID)
+	if err != nil {
+		return nil, err
+	}
+	writeModel, err := c.clientRegistrationWriteModelByID(ctx, client.AggregateID, client.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.PolicySet {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ro3ai", "Errors.Project.ClientRegistration.PolicyNotFound")
+	}
+	if err = writeModel.policy().Check(app); err != nil {
+		return nil, err
+	}
+	app.AggregateID = client.AggregateID
+	app.AppID = client.AppID
+	changed, err := c.ChangeOIDCApplication(ctx, app, client.ResourceOwner)
+	// the client always sends its complete configuration, which might not contain any changes
+	if errors.IsPreconditionFailed(err) {
+		existing, err := c.getOIDCAppWriteModel(ctx, client.AggregateID, client.AppID, client.ResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		return oidcWriteModelToOIDCConfig(existing), nil
+	}
+	return changed, err
+}
+
+// RemoveRegisteredOIDCClient removes a dynamically registered client (RFC 7592)
+func (c *Commands) RemoveRegisteredOIDCClient(ctx context.Context, registrationAccessToken, clientID string) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	client, err := c.registeredClientWriteModelByToken(ctx, registrationAccessToken, clientID)
+	if err != nil {
+		return nil, err
+	}
+	return c.RemoveApplication(ctx, client.AggregateID, client.AppID, client.ResourceOwner)
+}
+
+func (c *Commands) registeredClientWriteModelByToken(ctx context.Context, registrationAccessToken, clientID string) (writeModel *RegisteredClientWriteModel, err error) {
+	tokenID, subject, err := c.parseClientRegistrationToken(registrationAccessToken, registrationAccessTokenPrefix)
+	if err != nil {
+		return nil, err
+	}
+	projectID, appID, ok := strings.Cut(subject, tokenPartDelimiter)
+	if !ok {
+		return nil, errors.ThrowUnauthenticated(nil, "COMMAND-ahY3e", "Errors.Project.ClientRegistration.TokenInvalid")
+	}
+	writeModel = NewRegisteredClientWriteModel(projectID, appID, "")
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.AppState.Exists() || writeModel.RegistrationTokenID != tokenID || writeModel.ClientID != clientID {
+		return nil, errors.ThrowUnauthenticated(nil, "COMMAND-Eik4r", "Errors.Project.ClientRegistration.TokenInvalid")
+	}
+	return writeModel, nil
+}
+
+const (
+	tokenPartDelimiter = ":"
+
+	// initialAccessTokenPrefix and registrationAccessTokenPrefix distinguish the tokens from each other
+	// and from personal access tokens, so none of them can be used in place of another
+	initialAccessTokenPrefix      = "iat_"
+	registrationAccessTokenPrefix = "rat_"
+)
+
+// createClientRegistrationToken creates an initial or registration access token: `<prefix><encrypted tokenID:subject>`
+func createClientRegistrationToken(algorithm crypto.EncryptionAlgorithm, prefix, tokenID, subject string) (string, error) {
+	encrypted, err := algorithm.Encrypt([]byte(tokenID + tokenPartDelimiter + subject))
+	if err != nil {
+		return "", err
+	}
+	return prefix + base64.RawURLEncoding.EncodeToString(encrypted), nil
+}
+
+// parseClientRegistrationToken decrypts an initial or registration access token created by [createClientRegistrationToken]
+func (c *Commands) parseClientRegistrationToken(token, prefix string) (tokenID, subject string, err error) {
+	token, ok := strings.CutPrefix(token, prefix)
+	if !ok {
+		return "", "", errors.ThrowUnauthenticated(nil, "COMMAND-Zoo9e", "Errors.Project.ClientRegistration.TokenInvalid")
+	}
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return "", "", errors.ThrowUnauthenticated(err, "COMMAND-Ohx8i", "Errors.Project.ClientRegistration.TokenInvalid")
+	}
+	decrypted, err := c.keyAlgorithm.DecryptString(decoded, c.keyAlgorithm.EncryptionKeyID())
+	if err != nil {
+		return "", "", errors.ThrowUnauthenticated(err, "COMMAND-ue4Ai", "Errors.Project.ClientRegistration.TokenInvalid")
+	}
+	tokenID, subject, ok = strings.Cut(decrypted, tokenPartDelimiter)
+	if !ok || tokenID == "" || subject == "" {
+		return "", "", errors.ThrowUnauthenticated(nil, "COMMAND-Aeb6i", "Errors.Project.ClientRegistration.TokenInvalid")
+	}
+	return tokenID, subject, nil
+}
+
+func (c *Commands) clientRegistrationWriteModelByID(ctx context.Context, projectID, resourceOwner string) (writeModel *ClientRegistrationWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewClientRegistrationWriteModel(projectID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,171 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+type ClientRegistrationWriteModel struct {
+	eventstore.WriteModel
+
+	ProjectState domain.ProjectState
+
+	AllowedGrantTypes   []domain.OIDCGrantType
+	RedirectURIPatterns []string
+	PolicySet           bool
+
+	// Tokens are the expiration dates of the initial access tokens by their id
+	Tokens map[string]time.Time
+}
+
+func NewClientRegistrationWriteModel(projectID, resourceOwner string) *ClientRegistrationWriteModel {
+	return &ClientRegistrationWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		Tokens: make(map[string]time.Time),
+	}
+}
+
+func (wm *ClientRegistrationWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ProjectAddedEvent:
+			wm.ProjectState = domain.ProjectStateActive
+		case *project.ProjectRemovedEvent:
+			wm.ProjectState = domain.ProjectStateRemoved
+			wm.removePolicy()
+			wm.Tokens = make(map[string]time.Time)
+		case *project.ClientRegistrationPolicySetEvent:
+			wm.AllowedGrantTypes = e.AllowedGrantTypes
+			wm.RedirectURIPatterns = e.RedirectURIPatterns
+			wm.PolicySet = true
+		case *project.ClientRegistrationPolicyRemovedEvent:
+			wm.removePolicy()
+		case *project.ClientRegistrationTokenAddedEvent:
+			wm.Tokens[e.TokenID] = e.Expiration
+		case *project.ClientRegistrationTokenRemovedEvent:
+			delete(wm.Tokens, e.TokenID)
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *ClientRegistrationWriteModel) removePolicy() {
+	wm.AllowedGrantTypes = nil
+	wm.RedirectURIPatterns = nil
+	wm.PolicySet = false
+}
+
+func (wm *ClientRegistrationWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ProjectAddedType,
+			project.ProjectRemovedType,
+			project.ClientRegistrationPolicySetType,
+			project.ClientRegistrationPolicyRemovedType,
+			project.ClientRegistrationTokenAddedType,
+			project.ClientRegistrationTokenRemovedType).
+		Builder()
+}
+
+func (wm *ClientRegistrationWriteModel) policy() *domain.ClientRegistrationPolicy {
+	return &domain.ClientRegistrationPolicy{
+		ObjectRoot:          writeModelToObjectRoot(wm.WriteModel),
+		AllowedGrantTypes:   wm.AllowedGrantTypes,
+		RedirectURIPatterns: wm.RedirectURIPatterns,
+	}
+}
+
+// RegisteredClientWriteModel is the application registered dynamically
+// and the id of its registration access token
+type RegisteredClientWriteModel struct {
+	eventstore.WriteModel
+
+	AppID               string
+	AppState            domain.AppState
+	ClientID            string
+	RegistrationTokenID string
+}
+
+func NewRegisteredClientWriteModel(projectID, appID, resourceOwner string) *RegisteredClientWriteModel {
+	return &RegisteredClientWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+		AppID: appID,
+	}
+}
+
+func (wm *RegisteredClientWriteModel) AppendEvents(events ...eventstore.Event) {
+	for _, event := range events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationRemovedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.OIDCConfigAddedEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ApplicationRegistrationTokenSetEvent:
+			if e.AppID != wm.AppID {
+				continue
+			}
+			wm.WriteModel.AppendEvents(e)
+		case *project.ProjectRemovedEvent:
+			wm.WriteModel.AppendEvents(e)
+		}
+	}
+}
+
+func (wm *RegisteredClientWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *project.ApplicationAddedEvent:
+			wm.AppState = domain.AppStateActive
+		case *project.OIDCConfigAddedEvent:
+			wm.ClientID = e.ClientID
+		case *project.ApplicationRegistrationTokenSetEvent:
+			wm.RegistrationTokenID = e.TokenID
+		case *project.ApplicationRemovedEvent:
+			wm.AppState = domain.AppStateRemoved
+			wm.RegistrationTokenID = ""
+		case *project.ProjectRemovedEvent:
+			wm.AppState = domain.AppStateRemoved
+			wm.RegistrationTokenID = ""
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *RegisteredClientWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			project.ApplicationAddedType,
+			project.ApplicationRemovedType,
+			project.OIDCConfigAddedType,
+			project.ApplicationRegistrationTokenSetType,
+			project.ProjectRemovedType).
+		Builder()
+}
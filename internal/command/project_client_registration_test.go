@@ -0,0 +1,649 @@
+package command
+
+import (
+	"context"
+	"encoding/base64"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestCommandSide_SetClientRegistrationPolicy(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx           context.Context
+		policy        *domain.ClientRegistrationPolicy
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid pattern, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				policy: &domain.ClientRegistrationPolicy{
+					ObjectRoot:          models.ObjectRoot{AggregateID: "project1"},
+					AllowedGrantTypes:   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					RedirectURIPatterns: []string{"https://[.example.com/*"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				policy: &domain.ClientRegistrationPolicy{
+					ObjectRoot:          models.ObjectRoot{AggregateID: "project1"},
+					AllowedGrantTypes:   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					RedirectURIPatterns: []string{"https://*.example.com/callback"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "set policy, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						project.NewClientRegistrationPolicySetEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+							[]string{"https://*.example.com/callback"},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				policy: &domain.ClientRegistrationPolicy{
+					ObjectRoot:          models.ObjectRoot{AggregateID: "project1"},
+					AllowedGrantTypes:   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					RedirectURIPatterns: []string{"https://*.example.com/callback"},
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.SetClientRegistrationPolicy(tt.args.ctx, tt.args.policy, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_AddClientRegistrationToken(t *testing.T) {
+	expiration := time.Now().Add(time.Hour)
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx           context.Context
+		token         *domain.ClientRegistrationToken
+		resourceOwner string
+	}
+	type res struct {
+		want *domain.ClientRegistrationToken
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "expiration in the past, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx: context.Background(),
+				token: &domain.ClientRegistrationToken{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "project1"},
+					ExpirationDate: time.Now().Add(-time.Hour),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "policy not set, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				token: &domain.ClientRegistrationToken{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "project1"},
+					ExpirationDate: expiration,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "add token, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+						eventFromEventPusher(
+							project.NewClientRegistrationPolicySetEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+								[]string{"https://*.example.com/callback"},
+							),
+						),
+					),
+					expectPush(
+						project.NewClientRegistrationTokenAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"token1",
+							expiration,
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "token1"),
+			},
+			args: args{
+				ctx: context.Background(),
+				token: &domain.ClientRegistrationToken{
+					ObjectRoot:     models.ObjectRoot{AggregateID: "project1"},
+					ExpirationDate: expiration,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				want: &domain.ClientRegistrationToken{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					TokenID:        "token1",
+					ExpirationDate: expiration,
+					Token:          "iat_" + base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := r.AddClientRegistrationToken(tt.args.ctx, tt.args.token, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RegisterOIDCClient(t *testing.T) {
+	initialAccessToken := "iat_" + base64.RawURLEncoding.EncodeToString([]byte("token1:project1"))
+	clientRegistrationEvents := func(expiration time.Time) []eventstore.Event {
+		return []eventstore.Event{
+			eventFromEventPusher(
+				project.NewProjectAddedEvent(context.Background(),
+					&project.NewAggregate("project1", "org1").Aggregate,
+					"project", true, true, true,
+					domain.PrivateLabelingSettingUnspecified),
+			),
+			eventFromEventPusher(
+				project.NewClientRegistrationPolicySetEvent(context.Background(),
+					&project.NewAggregate("project1", "org1").Aggregate,
+					[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					[]string{"https://*.example.com/callback"},
+				),
+			),
+			eventFromEventPusher(
+				project.NewClientRegistrationTokenAddedEvent(context.Background(),
+					&project.NewAggregate("project1", "org1").Aggregate,
+					"token1",
+					expiration,
+				),
+			),
+		}
+	}
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx                context.Context
+		initialAccessToken string
+		app                *domain.OIDCApp
+	}
+	type res struct {
+		want                    *domain.OIDCApp
+		registrationAccessToken string
+		err                     func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "invalid token, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:                context.Background(),
+				initialAccessToken: "invalid",
+				app:                &domain.OIDCApp{},
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "expired token, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(clientRegistrationEvents(time.Now().Add(-time.Minute))...),
+				),
+			},
+			args: args{
+				ctx:                context.Background(),
+				initialAccessToken: initialAccessToken,
+				app:                &domain.OIDCApp{},
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "redirect uri not allowed, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(clientRegistrationEvents(time.Now().Add(time.Hour))...),
+				),
+			},
+			args: args{
+				ctx:                context.Background(),
+				initialAccessToken: initialAccessToken,
+				app: &domain.OIDCApp{
+					AppName:         "app",
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					RedirectUris:    []string{"https://app.attacker.com/callback"},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType: domain.OIDCApplicationTypeWeb,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "grant type not allowed, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(clientRegistrationEvents(time.Now().Add(time.Hour))...),
+				),
+			},
+			args: args{
+				ctx:                context.Background(),
+				initialAccessToken: initialAccessToken,
+				app: &domain.OIDCApp{
+					AppName:         "app",
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					RedirectUris:    []string{"https://app.example.com/callback"},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode, domain.OIDCGrantTypeRefreshToken},
+					ApplicationType: domain.OIDCApplicationTypeWeb,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "register client, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(clientRegistrationEvents(time.Now().Add(time.Hour))...),
+					expectFilter(
+						eventFromEventPusher(
+							project.NewProjectAddedEvent(context.Background(),
+								&project.NewAggregate("project1", "org1").Aggregate,
+								"project", true, true, true,
+								domain.PrivateLabelingSettingUnspecified),
+						),
+					),
+					expectPush(
+						project.NewApplicationAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"app",
+						),
+						project.NewOIDCConfigAddedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							domain.OIDCVersionV1,
+							"app1",
+							"client1@project",
+							nil,
+							[]string{"https://app.example.com/callback"},
+							[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+							[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+							domain.OIDCApplicationTypeWeb,
+							domain.OIDCAuthMethodTypeNone,
+							nil,
+							false,
+							domain.OIDCTokenTypeBearer,
+							false,
+							false,
+							false,
+							0,
+							nil,
+							false,
+							"",
+							"",
+							false,
+							false,
+							false,
+							false,
+							"",
+						),
+						project.NewApplicationRegistrationTokenSetEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"registration1",
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "app1", "registration1", "client1"),
+			},
+			args: args{
+				ctx:                context.Background(),
+				initialAccessToken: initialAccessToken,
+				app: &domain.OIDCApp{
+					AppName:         "app",
+					OIDCVersion:     domain.OIDCVersionV1,
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					RedirectUris:    []string{"https://app.example.com/callback"},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType: domain.OIDCApplicationTypeWeb,
+				},
+			},
+			res: res{
+				want: &domain.OIDCApp{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "project1",
+						ResourceOwner: "org1",
+					},
+					AppID:           "app1",
+					AppName:         "app",
+					ClientID:        "client1@project",
+					OIDCVersion:     domain.OIDCVersionV1,
+					AuthMethodType:  domain.OIDCAuthMethodTypeNone,
+					RedirectUris:    []string{"https://app.example.com/callback"},
+					ResponseTypes:   []domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+					GrantTypes:      []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+					ApplicationType: domain.OIDCApplicationTypeWeb,
+					AccessTokenType: domain.OIDCTokenTypeBearer,
+					State:           domain.AppStateActive,
+					Compliance:      &domain.Compliance{},
+				},
+				registrationAccessToken: "rat_" + base64.RawURLEncoding.EncodeToString([]byte("registration1:project1:app1")),
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				idGenerator:  tt.fields.idGenerator,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, registrationAccessToken, err := r.RegisterOIDCClient(tt.args.ctx, tt.args.initialAccessToken, tt.args.app, GetMockSecretGenerator(t))
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+				assert.Equal(t, tt.res.registrationAccessToken, registrationAccessToken)
+			}
+		})
+	}
+}
+
+func TestCommandSide_RemoveRegisteredOIDCClient(t *testing.T) {
+	registrationAccessToken := "rat_" + base64.RawURLEncoding.EncodeToString([]byte("registration1:project1:app1"))
+	registeredClientEvents := []eventstore.Event{
+		eventFromEventPusher(
+			project.NewApplicationAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"app1",
+				"app",
+			),
+		),
+		eventFromEventPusher(
+			project.NewOIDCConfigAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				domain.OIDCVersionV1,
+				"app1",
+				"client1@project",
+				nil,
+				[]string{"https://app.example.com/callback"},
+				[]domain.OIDCResponseType{domain.OIDCResponseTypeCode},
+				[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+				domain.OIDCApplicationTypeWeb,
+				domain.OIDCAuthMethodTypeNone,
+				nil,
+				false,
+				domain.OIDCTokenTypeBearer,
+				false,
+				false,
+				false,
+				0,
+				nil,
+				false,
+				"",
+				"",
+				false,
+				false,
+				false,
+				false,
+				"",
+			),
+		),
+		eventFromEventPusher(
+			project.NewApplicationRegistrationTokenSetEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"app1",
+				"registration1",
+			),
+		),
+	}
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx                     context.Context
+		registrationAccessToken string
+		clientID                string
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "other client, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(registeredClientEvents...),
+				),
+			},
+			args: args{
+				ctx:                     context.Background(),
+				registrationAccessToken: registrationAccessToken,
+				clientID:                "client2@project",
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "initial access token, unauthenticated error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:                     context.Background(),
+				registrationAccessToken: "iat_" + base64.RawURLEncoding.EncodeToString([]byte("token1:project1")),
+				clientID:                "client1@project",
+			},
+			res: res{
+				err: caos_errs.IsUnauthenticated,
+			},
+		},
+		{
+			name: "remove client, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(registeredClientEvents...),
+					expectFilter(registeredClientEvents[0]),
+					expectFilter(),
+					expectPush(
+						project.NewApplicationRemovedEvent(context.Background(),
+							&project.NewAggregate("project1", "org1").Aggregate,
+							"app1",
+							"app",
+							"",
+						),
+					),
+				),
+			},
+			args: args{
+				ctx:                     context.Background(),
+				registrationAccessToken: registrationAccessToken,
+				clientID:                "client1@project",
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:   tt.fields.eventstore,
+				keyAlgorithm: crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := r.RemoveRegisteredOIDCClient(tt.args.ctx, tt.args.registrationAccessToken, tt.args.clientID)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
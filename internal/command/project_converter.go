@@ -27,38 +27,48 @@ func projectGrantWriteModelToProjectGrant(writeModel *ProjectGrantWriteModel) *d
 
 func oidcWriteModelToOIDCConfig(writeModel *OIDCApplicationWriteModel) *domain.OIDCApp {
 	return &domain.OIDCApp{
-		ObjectRoot:               writeModelToObjectRoot(writeModel.WriteModel),
-		AppID:                    writeModel.AppID,
-		AppName:                  writeModel.AppName,
-		State:                    writeModel.State,
-		ClientID:                 writeModel.ClientID,
-		RedirectUris:             writeModel.RedirectUris,
-		ResponseTypes:            writeModel.ResponseTypes,
-		GrantTypes:               writeModel.GrantTypes,
-		ApplicationType:          writeModel.ApplicationType,
-		AuthMethodType:           writeModel.AuthMethodType,
-		PostLogoutRedirectUris:   writeModel.PostLogoutRedirectUris,
-		OIDCVersion:              writeModel.OIDCVersion,
-		DevMode:                  writeModel.DevMode,
-		AccessTokenType:          writeModel.AccessTokenType,
-		AccessTokenRoleAssertion: writeModel.AccessTokenRoleAssertion,
-		IDTokenRoleAssertion:     writeModel.IDTokenRoleAssertion,
-		IDTokenUserinfoAssertion: writeModel.IDTokenUserinfoAssertion,
-		ClockSkew:                writeModel.ClockSkew,
-		AdditionalOrigins:        writeModel.AdditionalOrigins,
-		SkipNativeAppSuccessPage: writeModel.SkipNativeAppSuccessPage,
+		ObjectRoot:                       writeModelToObjectRoot(writeModel.WriteModel),
+		AppID:                            writeModel.AppID,
+		AppName:                          writeModel.AppName,
+		State:                            writeModel.State,
+		ClientID:                         writeModel.ClientID,
+		RedirectUris:                     writeModel.RedirectUris,
+		ResponseTypes:                    writeModel.ResponseTypes,
+		GrantTypes:                       writeModel.GrantTypes,
+		ApplicationType:                  writeModel.ApplicationType,
+		AuthMethodType:                   writeModel.AuthMethodType,
+		PostLogoutRedirectUris:           writeModel.PostLogoutRedirectUris,
+		OIDCVersion:                      writeModel.OIDCVersion,
+		DevMode:                          writeModel.DevMode,
+		AccessTokenType:                  writeModel.AccessTokenType,
+		AccessTokenRoleAssertion:         writeModel.AccessTokenRoleAssertion,
+		IDTokenRoleAssertion:             writeModel.IDTokenRoleAssertion,
+		IDTokenUserinfoAssertion:         writeModel.IDTokenUserinfoAssertion,
+		ClockSkew:                        writeModel.ClockSkew,
+		AdditionalOrigins:                writeModel.AdditionalOrigins,
+		SkipNativeAppSuccessPage:         writeModel.SkipNativeAppSuccessPage,
+		BackChannelLogoutURI:             writeModel.BackChannelLogoutURI,
+		FrontChannelLogoutURI:            writeModel.FrontChannelLogoutURI,
+		RequirePushedAuthRequest:         writeModel.RequirePushedAuthRequest,
+		RequireSignedRequest:             writeModel.RequireSignedRequest,
+		DPoPBoundAccessTokens:            writeModel.DPoPBoundAccessTokens,
+		ForceRSASignedTokens:             writeModel.ForceRSASignedTokens,
+		BackChannelClientNotificationURI: writeModel.BackChannelClientNotificationURI,
 	}
 }
 
 func samlWriteModelToSAMLConfig(writeModel *SAMLApplicationWriteModel) *domain.SAMLApp {
 	return &domain.SAMLApp{
-		ObjectRoot:  writeModelToObjectRoot(writeModel.WriteModel),
-		AppID:       writeModel.AppID,
-		AppName:     writeModel.AppName,
-		State:       writeModel.State,
-		Metadata:    writeModel.Metadata,
-		MetadataURL: writeModel.MetadataURL,
-		EntityID:    writeModel.EntityID,
+		ObjectRoot:        writeModelToObjectRoot(writeModel.WriteModel),
+		AppID:             writeModel.AppID,
+		AppName:           writeModel.AppName,
+		State:             writeModel.State,
+		Metadata:          writeModel.Metadata,
+		MetadataURL:       writeModel.MetadataURL,
+		EntityID:          writeModel.EntityID,
+		EncryptAssertions: writeModel.EncryptAssertions,
+		ArtifactBinding:   writeModel.ArtifactBinding,
+		SingleLogout:      writeModel.SingleLogout,
 	}
 }
 
@@ -0,0 +1,69 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// AddPushedAuthRequest stores the (already validated) parameters of an authorization request
+// pushed by the client (RFC 9126) and returns the id to be used in the request_uri.
+func (c *Commands) AddPushedAuthRequest(ctx context.Context, clientID string, request json.RawMessage, expiration time.Time) (string, error) {
+	if clientID == "" || len(request) == 0 {
+		return "", errors.ThrowInvalidArgument(nil, "COMMAND-ooN4a", "Errors.AuthRequest.PushedInvalid")
+	}
+	id, err := c.idGenerator.Next()
+	if err != nil {
+		return "", err
+	}
+	writeModel, err := c.getPushedAuthRequestWriteModel(ctx, id)
+	if err != nil {
+		return "", err
+	}
+	if writeModel.State != domain.PushedAuthRequestStateUnspecified {
+		return "", errors.ThrowPreconditionFailed(nil, "COMMAND-Eeph9", "Errors.AuthRequest.AlreadyExisting")
+	}
+	if err = c.pushAppendAndReduce(ctx, writeModel, authrequest.NewPushedEvent(ctx, writeModel.aggregate, clientID, request, expiration)); err != nil {
+		return "", err
+	}
+	return id, nil
+}
+
+// UsePushedAuthRequest returns the parameters of the pushed authorization request and marks it as used,
+// so it can only be used once by the client, which pushed it.
+func (c *Commands) UsePushedAuthRequest(ctx context.Context, id, clientID string) (json.RawMessage, error) {
+	writeModel, err := c.getPushedAuthRequestWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State == domain.PushedAuthRequestStateUnspecified || writeModel.ClientID != clientID {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Dah3b", "Errors.AuthRequest.PushedNotExisting")
+	}
+	if writeModel.State != domain.PushedAuthRequestStatePushed {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Quo5i", "Errors.AuthRequest.AlreadyHandled")
+	}
+	if !writeModel.Expiration.After(time.Now()) {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-aiw6E", "Errors.AuthRequest.PushedExpired")
+	}
+	if err = c.pushAppendAndReduce(ctx, writeModel, authrequest.NewPushedUsedEvent(ctx, writeModel.aggregate)); err != nil {
+		return nil, err
+	}
+	return writeModel.Request, nil
+}
+
+func (c *Commands) getPushedAuthRequestWriteModel(ctx context.Context, id string) (writeModel *PushedAuthRequestWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewPushedAuthRequestWriteModel(ctx, id)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	return writeModel, nil
+}
@@ -0,0 +1,257 @@
+package command
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	"github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/authrequest"
+)
+
+func TestCommands_AddPushedAuthRequest(t *testing.T) {
+	mockCtx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	expiration := time.Now().Add(time.Minute)
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		ctx        context.Context
+		clientID   string
+		request    json.RawMessage
+		expiration time.Time
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    string
+		wantErr error
+	}{
+		{
+			"missing request, error",
+			fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args{
+				ctx:        mockCtx,
+				clientID:   "clientID",
+				expiration: expiration,
+			},
+			"",
+			caos_errs.ThrowInvalidArgument(nil, "COMMAND-ooN4a", "Errors.AuthRequest.PushedInvalid"),
+		},
+		{
+			"already exists, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate,
+								"clientID",
+								json.RawMessage(`{"client_id":"clientID"}`),
+								expiration,
+							),
+						),
+					),
+				),
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "id"),
+			},
+			args{
+				ctx:        mockCtx,
+				clientID:   "clientID",
+				request:    json.RawMessage(`{"client_id":"clientID"}`),
+				expiration: expiration,
+			},
+			"",
+			caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Eeph9", "Errors.AuthRequest.AlreadyExisting"),
+		},
+		{
+			"pushed",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectPush(
+						authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate,
+							"clientID",
+							json.RawMessage(`{"client_id":"clientID"}`),
+							expiration,
+						),
+					),
+				),
+				idGenerator: mock.NewIDGeneratorExpectIDs(t, "id"),
+			},
+			args{
+				ctx:        mockCtx,
+				clientID:   "clientID",
+				request:    json.RawMessage(`{"client_id":"clientID"}`),
+				expiration: expiration,
+			},
+			"id",
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			got, err := c.AddPushedAuthRequest(tt.args.ctx, tt.args.clientID, tt.args.request, tt.args.expiration)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestCommands_UsePushedAuthRequest(t *testing.T) {
+	mockCtx := authz.NewMockContext("instanceID", "orgID", "loginClient")
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type args struct {
+		ctx      context.Context
+		id       string
+		clientID string
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		args    args
+		want    json.RawMessage
+		wantErr error
+	}{
+		{
+			"not existing, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "clientID",
+			},
+			nil,
+			caos_errs.ThrowNotFound(nil, "COMMAND-Dah3b", "Errors.AuthRequest.PushedNotExisting"),
+		},
+		{
+			"other client, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate,
+								"clientID",
+								json.RawMessage(`{"client_id":"clientID"}`),
+								time.Now().Add(time.Minute),
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "otherClientID",
+			},
+			nil,
+			caos_errs.ThrowNotFound(nil, "COMMAND-Dah3b", "Errors.AuthRequest.PushedNotExisting"),
+		},
+		{
+			"already used, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate,
+								"clientID",
+								json.RawMessage(`{"client_id":"clientID"}`),
+								time.Now().Add(time.Minute),
+							),
+						),
+						eventFromEventPusher(
+							authrequest.NewPushedUsedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate),
+						),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "clientID",
+			},
+			nil,
+			caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Quo5i", "Errors.AuthRequest.AlreadyHandled"),
+		},
+		{
+			"expired, error",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate,
+								"clientID",
+								json.RawMessage(`{"client_id":"clientID"}`),
+								time.Now().Add(-time.Minute),
+							),
+						),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "clientID",
+			},
+			nil,
+			caos_errs.ThrowPreconditionFailed(nil, "COMMAND-aiw6E", "Errors.AuthRequest.PushedExpired"),
+		},
+		{
+			"used",
+			fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							authrequest.NewPushedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate,
+								"clientID",
+								json.RawMessage(`{"client_id":"clientID"}`),
+								time.Now().Add(time.Minute),
+							),
+						),
+					),
+					expectPush(
+						authrequest.NewPushedUsedEvent(mockCtx, &authrequest.NewAggregate("id", "instanceID").Aggregate),
+					),
+				),
+			},
+			args{
+				ctx:      mockCtx,
+				id:       "id",
+				clientID: "clientID",
+			},
+			json.RawMessage(`{"client_id":"clientID"}`),
+			nil,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := c.UsePushedAuthRequest(tt.args.ctx, tt.args.id, tt.args.clientID)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
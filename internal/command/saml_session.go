@@ -0,0 +1,94 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/samlsession"
+)
+
+// AddSAMLSession records the session of a user on a SAML service provider after a response was issued.
+// If no ID is provided, a new one will be generated.
+func (c *Commands) AddSAMLSession(ctx context.Context, session *domain.SAMLSession) (_ *domain.ObjectDetails, err error) {
+	if session.UserID == "" || session.AppID == "" || session.EntityID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-iK4ah", "Errors.Invalid.Argument")
+	}
+	if session.ID == "" {
+		session.ID, err = c.idGenerator.Next()
+		if err != nil {
+			return nil, err
+		}
+	}
+	model := NewSAMLSessionWriteModel(session.ID, authz.GetInstance(ctx).InstanceID())
+	if err = c.eventstore.FilterToQueryReducer(ctx, model); err != nil {
+		return nil, err
+	}
+	if model.State != domain.SAMLSessionStateUnspecified {
+		return nil, errors.ThrowAlreadyExists(nil, "COMMAND-Oog4a", "Errors.SAMLSession.AlreadyExists")
+	}
+	if err = c.pushAppendAndReduce(ctx, model, samlsession.NewAddedEvent(
+		ctx,
+		model.aggregate,
+		session.UserID,
+		session.UserResourceOwner,
+		session.UserAgentID,
+		session.AppID,
+		session.EntityID,
+		session.NameID,
+		session.NameIDFormat,
+		session.AuthTime,
+		session.Response,
+		session.ArtifactExpiration,
+	)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+// ResolveSAMLArtifact returns the (encrypted) response stored for the artifact of the session.
+// The artifact can only be resolved once and only by the service provider the response was issued to.
+func (c *Commands) ResolveSAMLArtifact(ctx context.Context, id, entityID string) (*crypto.CryptoValue, error) {
+	model, err := c.getSAMLSessionWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if err = model.checkArtifact(entityID); err != nil {
+		return nil, err
+	}
+	response := model.Response
+	if err = c.pushAppendAndReduce(ctx, model, samlsession.NewArtifactResolvedEvent(ctx, model.aggregate)); err != nil {
+		return nil, err
+	}
+	return response, nil
+}
+
+// TerminateSAMLSession ends the session of the user on the service provider,
+// e.g. after the service provider was notified about the single logout.
+// Already terminated sessions are ignored.
+func (c *Commands) TerminateSAMLSession(ctx context.Context, id string) (*domain.ObjectDetails, error) {
+	model, err := c.getSAMLSessionWriteModel(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+	if model.State == domain.SAMLSessionStateUnspecified {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-eeL4u", "Errors.SAMLSession.NotExisting")
+	}
+	if model.State == domain.SAMLSessionStateTerminated {
+		return writeModelToObjectDetails(&model.WriteModel), nil
+	}
+	if err = c.pushAppendAndReduce(ctx, model, samlsession.NewTerminatedEvent(ctx, model.aggregate)); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&model.WriteModel), nil
+}
+
+func (c *Commands) getSAMLSessionWriteModel(ctx context.Context, id string) (*SAMLSessionWriteModel, error) {
+	model := NewSAMLSessionWriteModel(id, authz.GetInstance(ctx).InstanceID())
+	if err := c.eventstore.FilterToQueryReducer(ctx, model); err != nil {
+		return nil, err
+	}
+	return model, nil
+}
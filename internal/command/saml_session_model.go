@@ -0,0 +1,86 @@
+package command
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/samlsession"
+)
+
+type SAMLSessionWriteModel struct {
+	eventstore.WriteModel
+
+	UserID             string
+	UserAgentID        string
+	AppID              string
+	EntityID           string
+	Response           *crypto.CryptoValue
+	ArtifactExpiration time.Time
+	ArtifactResolved   bool
+	State              domain.SAMLSessionState
+
+	aggregate *eventstore.Aggregate
+}
+
+func NewSAMLSessionWriteModel(id, resourceOwner string) *SAMLSessionWriteModel {
+	return &SAMLSessionWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   id,
+			ResourceOwner: resourceOwner,
+		},
+		aggregate: samlsession.NewAggregate(id, resourceOwner),
+	}
+}
+
+func (wm *SAMLSessionWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *samlsession.AddedEvent:
+			wm.UserID = e.UserID
+			wm.UserAgentID = e.UserAgentID
+			wm.AppID = e.AppID
+			wm.EntityID = e.EntityID
+			wm.Response = e.Response
+			wm.ArtifactExpiration = e.ArtifactExpiration
+			wm.State = domain.SAMLSessionStateActive
+		case *samlsession.ArtifactResolvedEvent:
+			wm.Response = nil
+			wm.ArtifactResolved = true
+		case *samlsession.TerminatedEvent:
+			wm.Response = nil
+			wm.State = domain.SAMLSessionStateTerminated
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *SAMLSessionWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AddQuery().
+		AggregateTypes(samlsession.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			samlsession.AddedType,
+			samlsession.ArtifactResolvedType,
+			samlsession.TerminatedType,
+		).
+		Builder()
+}
+
+// checkArtifact ensures that the artifact of the session was not resolved yet, is not expired
+// and is resolved by the service provider it was issued to.
+func (wm *SAMLSessionWriteModel) checkArtifact(entityID string) error {
+	if wm.State != domain.SAMLSessionStateActive || wm.ArtifactResolved || wm.Response == nil {
+		return errors.ThrowNotFound(nil, "COMMAND-ahM5o", "Errors.SAMLSession.Artifact.NotExisting")
+	}
+	if wm.ArtifactExpiration.Before(time.Now()) {
+		return errors.ThrowPreconditionFailed(nil, "COMMAND-Ohc4e", "Errors.SAMLSession.Artifact.Expired")
+	}
+	if wm.EntityID != entityID {
+		return errors.ThrowPermissionDenied(nil, "COMMAND-Eim3o", "Errors.SAMLSession.Artifact.EntityMismatch")
+	}
+	return nil
+}
@@ -0,0 +1,266 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/samlsession"
+)
+
+func TestCommands_AddSAMLSession(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	pushErr := errors.New("pushErr")
+	authTime := time.Now()
+
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	tests := []struct {
+		name        string
+		fields      fields
+		session     *domain.SAMLSession
+		wantDetails *domain.ObjectDetails
+		wantErr     error
+	}{
+		{
+			name: "missing entity, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			session: &domain.SAMLSession{
+				UserID: "userID",
+				AppID:  "appID",
+			},
+			wantErr: caos_errs.ThrowInvalidArgument(nil, "COMMAND-iK4ah", "Errors.Invalid.Argument"),
+		},
+		{
+			name: "already exists, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithInstanceID("instance1",
+							samlsession.NewAddedEvent(ctx, samlsession.NewAggregate("artifact", "instance1"),
+								"userID", "org1", "agentID", "appID", "https://sp.example.com", "user@example.com", "format", authTime, nil, time.Time{},
+							),
+						),
+					),
+				),
+			},
+			session: &domain.SAMLSession{
+				ID:       "artifact",
+				UserID:   "userID",
+				AppID:    "appID",
+				EntityID: "https://sp.example.com",
+			},
+			wantErr: caos_errs.ThrowAlreadyExists(nil, "COMMAND-Oog4a", "Errors.SAMLSession.AlreadyExists"),
+		},
+		{
+			name: "push error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectPushFailed(pushErr,
+						samlsession.NewAddedEvent(ctx, samlsession.NewAggregate("1999", "instance1"),
+							"userID", "org1", "agentID", "appID", "https://sp.example.com", "user@example.com", "format", authTime, nil, time.Time{},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "1999"),
+			},
+			session: &domain.SAMLSession{
+				UserID:            "userID",
+				UserResourceOwner: "org1",
+				UserAgentID:       "agentID",
+				AppID:             "appID",
+				EntityID:          "https://sp.example.com",
+				NameID:            "user@example.com",
+				NameIDFormat:      "format",
+				AuthTime:          authTime,
+			},
+			wantErr: pushErr,
+		},
+		{
+			name: "success",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+					expectPush(
+						samlsession.NewAddedEvent(ctx, samlsession.NewAggregate("1999", "instance1"),
+							"userID", "org1", "agentID", "appID", "https://sp.example.com", "user@example.com", "format", authTime, nil, time.Time{},
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "1999"),
+			},
+			session: &domain.SAMLSession{
+				UserID:            "userID",
+				UserResourceOwner: "org1",
+				UserAgentID:       "agentID",
+				AppID:             "appID",
+				EntityID:          "https://sp.example.com",
+				NameID:            "user@example.com",
+				NameIDFormat:      "format",
+				AuthTime:          authTime,
+			},
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			gotDetails, err := c.AddSAMLSession(ctx, tt.session)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantDetails, gotDetails)
+		})
+	}
+}
+
+func TestCommands_ResolveSAMLArtifact(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	response := &crypto.CryptoValue{
+		CryptoType: crypto.TypeEncryption,
+		Algorithm:  "enc",
+		KeyID:      "id",
+		Crypted:    []byte("response"),
+	}
+	addedEvent := func(expiration time.Time) eventstore.Event {
+		return eventFromEventPusherWithInstanceID("instance1",
+			samlsession.NewAddedEvent(ctx, samlsession.NewAggregate("artifact", "instance1"),
+				"userID", "org1", "agentID", "appID", "https://sp.example.com", "user@example.com", "format", time.Now(), response, expiration,
+			),
+		)
+	}
+
+	tests := []struct {
+		name         string
+		eventstore   *eventstore.Eventstore
+		entityID     string
+		wantResponse *crypto.CryptoValue
+		wantErr      error
+	}{
+		{
+			name:       "not existing, not found error",
+			eventstore: eventstoreExpect(t, expectFilter()),
+			entityID:   "https://sp.example.com",
+			wantErr:    caos_errs.ThrowNotFound(nil, "COMMAND-ahM5o", "Errors.SAMLSession.Artifact.NotExisting"),
+		},
+		{
+			name: "already resolved, not found error",
+			eventstore: eventstoreExpect(t, expectFilter(
+				addedEvent(time.Now().Add(time.Minute)),
+				eventFromEventPusherWithInstanceID("instance1",
+					samlsession.NewArtifactResolvedEvent(ctx, samlsession.NewAggregate("artifact", "instance1")),
+				),
+			)),
+			entityID: "https://sp.example.com",
+			wantErr:  caos_errs.ThrowNotFound(nil, "COMMAND-ahM5o", "Errors.SAMLSession.Artifact.NotExisting"),
+		},
+		{
+			name:       "expired, precondition error",
+			eventstore: eventstoreExpect(t, expectFilter(addedEvent(time.Now().Add(-time.Minute)))),
+			entityID:   "https://sp.example.com",
+			wantErr:    caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ohc4e", "Errors.SAMLSession.Artifact.Expired"),
+		},
+		{
+			name:       "other service provider, permission denied error",
+			eventstore: eventstoreExpect(t, expectFilter(addedEvent(time.Now().Add(time.Minute)))),
+			entityID:   "https://other.example.com",
+			wantErr:    caos_errs.ThrowPermissionDenied(nil, "COMMAND-Eim3o", "Errors.SAMLSession.Artifact.EntityMismatch"),
+		},
+		{
+			name: "success",
+			eventstore: eventstoreExpect(t,
+				expectFilter(addedEvent(time.Now().Add(time.Minute))),
+				expectPush(
+					samlsession.NewArtifactResolvedEvent(ctx, samlsession.NewAggregate("artifact", "instance1")),
+				),
+			),
+			entityID:     "https://sp.example.com",
+			wantResponse: response,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore,
+			}
+			gotResponse, err := c.ResolveSAMLArtifact(ctx, "artifact", tt.entityID)
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantResponse, gotResponse)
+		})
+	}
+}
+
+func TestCommands_TerminateSAMLSession(t *testing.T) {
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	addedEvent := eventFromEventPusherWithInstanceID("instance1",
+		samlsession.NewAddedEvent(ctx, samlsession.NewAggregate("sessionID", "instance1"),
+			"userID", "org1", "agentID", "appID", "https://sp.example.com", "user@example.com", "format", time.Now(), nil, time.Time{},
+		),
+	)
+
+	tests := []struct {
+		name        string
+		eventstore  *eventstore.Eventstore
+		wantDetails *domain.ObjectDetails
+		wantErr     error
+	}{
+		{
+			name:       "not existing, not found error",
+			eventstore: eventstoreExpect(t, expectFilter()),
+			wantErr:    caos_errs.ThrowNotFound(nil, "COMMAND-eeL4u", "Errors.SAMLSession.NotExisting"),
+		},
+		{
+			name: "already terminated, ok",
+			eventstore: eventstoreExpect(t, expectFilter(
+				addedEvent,
+				eventFromEventPusherWithInstanceID("instance1",
+					samlsession.NewTerminatedEvent(ctx, samlsession.NewAggregate("sessionID", "instance1")),
+				),
+			)),
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+		{
+			name: "success",
+			eventstore: eventstoreExpect(t,
+				expectFilter(addedEvent),
+				expectPush(
+					samlsession.NewTerminatedEvent(ctx, samlsession.NewAggregate("sessionID", "instance1")),
+				),
+			),
+			wantDetails: &domain.ObjectDetails{
+				ResourceOwner: "instance1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &Commands{
+				eventstore: tt.eventstore,
+			}
+			gotDetails, err := c.TerminateSAMLSession(ctx, "sessionID")
+			require.ErrorIs(t, err, tt.wantErr)
+			assert.Equal(t, tt.wantDetails, gotDetails)
+		})
+	}
+}
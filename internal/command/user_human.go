@@ -56,7 +56,9 @@ type AddHuman struct {
 	Passwordless           bool
 	ExternalIDP            bool
 	Register               bool
-	Metadata               []*AddMetadataEntry
+	// Inactive adds the user deactivated, it can't be combined with an initialisation code
+	Inactive bool
+	Metadata []*AddMetadataEntry
 
 	// Links are optional
 	Links []*AddLink
@@ -168,6 +170,9 @@ func (c *Commands) AddHumanCommand(human *AddHuman, orgID string, hasher *crypto
 		if err := human.Validate(hasher); err != nil {
 			return nil, err
 		}
+		if human.Inactive && allowInitMail && human.shouldAddInitCode() {
+			return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ohg5a", "Errors.User.CantDeactivateInitial")
+		}
 
 		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
 			if err := c.addHumanCommandCheckID(ctx, filter, human, orgID); err != nil {
@@ -236,6 +241,10 @@ func (c *Commands) AddHumanCommand(human *AddHuman, orgID string, hasher *crypto
 				return nil, err
 			}
 
+			if human.Inactive {
+				cmds = append(cmds, user.NewUserDeactivatedEvent(ctx, &a.Aggregate))
+			}
+
 			for _, metadataEntry := range human.Metadata {
 				cmds = append(cmds, user.NewMetadataSetEvent(
 					ctx,
@@ -24,11 +24,13 @@ func (c *Commands) AddAccessAndRefreshToken(
 	refreshIdleExpiration,
 	refreshExpiration time.Duration,
 	authTime time.Time,
+	dpopJKT,
+	issuer string,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if refreshToken == "" {
-		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime)
+		return c.AddNewRefreshTokenAndAccessToken(ctx, userID, orgID, agentID, clientID, audience, scopes, authMethodsReferences, refreshExpiration, accessLifetime, refreshIdleExpiration, authTime, dpopJKT, issuer)
 	}
-	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime)
+	return c.RenewRefreshTokenAndAccessToken(ctx, userID, orgID, refreshToken, agentID, clientID, audience, scopes, refreshIdleExpiration, accessLifetime, dpopJKT, issuer)
 }
 
 func (c *Commands) AddNewRefreshTokenAndAccessToken(
@@ -44,6 +46,8 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	accessLifetime,
 	refreshIdleExpiration time.Duration,
 	authTime time.Time,
+	dpopJKT,
+	issuer string,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
 	if userID == "" || agentID == "" || clientID == "" {
 		return nil, "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-adg4r", "Errors.IDMissing")
@@ -53,7 +57,7 @@ func (c *Commands) AddNewRefreshTokenAndAccessToken(
 	if err != nil {
 		return nil, "", err
 	}
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime, dpopJKT, issuer)
 	if err != nil {
 		return nil, "", err
 	}
@@ -79,13 +83,15 @@ func (c *Commands) RenewRefreshTokenAndAccessToken(
 	scopes []string,
 	idleExpiration,
 	accessLifetime time.Duration,
+	dpopJKT,
+	issuer string,
 ) (accessToken *domain.Token, newRefreshToken string, err error) {
-	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration)
+	refreshTokenEvent, refreshTokenID, newRefreshToken, err := c.renewRefreshToken(ctx, userID, orgID, refreshToken, idleExpiration, dpopJKT)
 	if err != nil {
 		return nil, "", err
 	}
 	userWriteModel := NewUserWriteModel(userID, orgID)
-	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime)
+	accessTokenEvent, accessToken, err := c.addUserToken(ctx, userWriteModel, agentID, clientID, refreshTokenID, audience, scopes, accessLifetime, dpopJKT, issuer)
 	if err != nil {
 		return nil, "", err
 	}
@@ -136,11 +142,11 @@ func (c *Commands) addRefreshToken(ctx context.Context, accessToken *domain.Toke
 	refreshTokenWriteModel := NewHumanRefreshTokenWriteModel(accessToken.AggregateID, accessToken.ResourceOwner, accessToken.RefreshTokenID)
 	userAgg := UserAggregateFromWriteModel(&refreshTokenWriteModel.WriteModel)
 	return user.NewHumanRefreshTokenAddedEvent(ctx, userAgg, accessToken.RefreshTokenID, accessToken.ApplicationID, accessToken.UserAgentID,
-			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration),
+			accessToken.PreferredLanguage, accessToken.Audience, accessToken.Scopes, authMethodsReferences, authTime, idleExpiration, expiration, accessToken.DPoPJKT),
 		refreshToken, nil
 }
 
-func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken string, idleExpiration time.Duration) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
+func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refreshToken string, idleExpiration time.Duration, dpopJKT string) (event *user.HumanRefreshTokenRenewedEvent, refreshTokenID, newRefreshToken string, err error) {
 	if refreshToken == "" {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-DHrr3", "Errors.IDMissing")
 	}
@@ -165,6 +171,10 @@ func (c *Commands) renewRefreshToken(ctx context.Context, userID, orgID, refresh
 		refreshTokenWriteModel.Expiration.Before(time.Now()) {
 		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-Vr43e", "Errors.User.RefreshToken.Invalid")
 	}
+	// refresh tokens bound to a DPoP key can only be used with a proof of the same key
+	if refreshTokenWriteModel.DPoPJKT != "" && refreshTokenWriteModel.DPoPJKT != dpopJKT {
+		return nil, "", "", caos_errs.ThrowInvalidArgument(nil, "COMMAND-ieC3o", "Errors.User.RefreshToken.Invalid")
+	}
 
 	newToken, err := c.idGenerator.Next()
 	if err != nil {
@@ -19,6 +19,7 @@ type HumanRefreshTokenWriteModel struct {
 	IdleExpiration time.Time
 	Expiration     time.Time
 	UserAgentID    string
+	DPoPJKT        string
 }
 
 func NewHumanRefreshTokenWriteModel(userID, resourceOwner, tokenID string) *HumanRefreshTokenWriteModel {
@@ -65,6 +66,7 @@ func (wm *HumanRefreshTokenWriteModel) Reduce() error {
 			wm.Expiration = e.CreationDate().Add(e.Expiration)
 			wm.UserState = domain.UserStateActive
 			wm.UserAgentID = e.UserAgentID
+			wm.DPoPJKT = e.DPoPJKT
 		case *user.HumanRefreshTokenRenewedEvent:
 			if wm.UserState == domain.UserStateActive {
 				wm.RefreshToken = e.RefreshToken
@@ -135,6 +135,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
 							context.Background(),
@@ -173,6 +174,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 							time.Now(),
 							-1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 					),
 				),
@@ -237,7 +239,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		//					time.Now(),
 		//					1*time.Hour,
 		//					24*time.Hour,
-		//				)),
+		//				, "")),
 		//			),
 		//			expectPushFailed(
 		//				caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -252,7 +254,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 		//						[]string{"clientID1"},
 		//						[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
 		//						time.Now().Add(5*time.Minute),
-		//					)),
+		//					, "")),
 		//					eventFromEventPusher(user.NewHumanRefreshTokenRenewedEvent(
 		//						context.Background(),
 		//						&user.NewAggregate("userID", "orgID").Aggregate,
@@ -292,7 +294,7 @@ func TestCommands_AddAccessAndRefreshToken(t *testing.T) {
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
 			got, gotRefresh, err := c.AddAccessAndRefreshToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.refreshToken,
-				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, tt.args.authTime)
+				tt.args.audience, tt.args.scopes, tt.args.authMethodsReferences, tt.args.lifetime, tt.args.refreshIdleExpiration, tt.args.refreshExpiration, tt.args.authTime, "", "")
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -372,6 +374,7 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectPushFailed(caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -411,6 +414,7 @@ func TestCommands_RevokeRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectPush(
@@ -506,6 +510,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectFilter(),
@@ -539,6 +544,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectFilter(
@@ -555,6 +561,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectPushFailed(caos_errs.ThrowInternal(nil, "ERROR", "internal"),
@@ -599,6 +606,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectFilter(
@@ -615,6 +623,7 @@ func TestCommands_RevokeRefreshTokens(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							10*time.Hour,
+							"",
 						)),
 					),
 					expectPush(
@@ -745,6 +754,7 @@ func TestCommands_addRefreshToken(t *testing.T) {
 					authTime,
 					1*time.Hour,
 					10*time.Hour,
+					"",
 				),
 				refreshToken: base64.RawURLEncoding.EncodeToString([]byte("userID:refreshTokenID:refreshTokenID")),
 			},
@@ -783,6 +793,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 		orgID          string
 		refreshToken   string
 		idleExpiration time.Duration
+		dpopJKT        string
 	}
 	type res struct {
 		event           *user.HumanRefreshTokenRenewedEvent
@@ -856,6 +867,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 						eventFromEventPusher(user.NewHumanRefreshTokenRemovedEvent(
 							context.Background(),
@@ -894,6 +906,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 					),
 				),
@@ -927,6 +940,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 						eventFromEventPusher(
 							user.NewUserDeactivatedEvent(
@@ -967,6 +981,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 						eventFromEventPusher(
 							user.NewHumanSignedOutEvent(
@@ -990,6 +1005,42 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				err: caos_errs.IsErrorInvalidArgument,
 			},
 		},
+		{
+			name: "dpop key mismatch, error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusherWithCreationDateNow(user.NewHumanRefreshTokenAddedEvent(
+							context.Background(),
+							&user.NewAggregate("userID", "orgID").Aggregate,
+							"tokenID",
+							"applicationID",
+							"userAgentID",
+							"de",
+							[]string{"clientID1"},
+							[]string{oidc.ScopeOpenID, oidc.ScopeProfile, oidc.ScopeEmail, oidc.ScopeOfflineAccess},
+							[]string{"password"},
+							time.Now(),
+							1*time.Hour,
+							24*time.Hour,
+							"jkt",
+						)),
+					),
+				),
+				keyAlgorithm: refreshTokenEncryptionAlgorithm(gomock.NewController(t)),
+			},
+			args: args{
+				ctx:            context.Background(),
+				userID:         "userID",
+				orgID:          "orgID",
+				refreshToken:   base64.RawURLEncoding.EncodeToString([]byte("userID:tokenID:tokenID")),
+				idleExpiration: 1 * time.Hour,
+				dpopJKT:        "otherJKT",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
 		{
 			name: "token renewed, ok",
 			fields: fields{
@@ -1008,6 +1059,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 							time.Now(),
 							1*time.Hour,
 							24*time.Hour,
+							"",
 						)),
 					),
 				),
@@ -1041,7 +1093,7 @@ func TestCommands_renewRefreshToken(t *testing.T) {
 				idGenerator:  tt.fields.idGenerator,
 				keyAlgorithm: tt.fields.keyAlgorithm,
 			}
-			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.idleExpiration)
+			gotEvent, gotRefreshTokenID, gotNewRefreshToken, err := c.renewRefreshToken(tt.args.ctx, tt.args.userID, tt.args.orgID, tt.args.refreshToken, tt.args.idleExpiration, tt.args.dpopJKT)
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -0,0 +1,219 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command/preparation"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/repository/userimport"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// AddUserImport queues the import of the users of the data into the organization (resource owner).
+// The import is processed asynchronously by the user import job.
+func (c *Commands) AddUserImport(ctx context.Context, userImport *domain.UserImport, resourceOwner string) (string, *domain.ObjectDetails, error) {
+	if resourceOwner == "" {
+		return "", nil, errors.ThrowInvalidArgument(nil, "COMMAND-Gai4e", "Errors.ResourceOwnerMissing")
+	}
+	if !userImport.IsValid() {
+		return "", nil, errors.ThrowInvalidArgument(nil, "COMMAND-aeG6u", "Errors.UserImport.Invalid")
+	}
+	importID, err := c.idGenerator.Next()
+	if err != nil {
+		return "", nil, err
+	}
+	writeModel := NewUserImportWriteModel(importID, resourceOwner)
+	details, err := c.pushUserImport(ctx, writeModel, userimport.NewAddedEvent(
+		ctx,
+		userImportAggregateFromWriteModel(ctx, writeModel),
+		userImport.Format,
+		userImport.DryRun,
+		userImport.Data,
+	))
+	if err != nil {
+		return "", nil, err
+	}
+	return importID, details, nil
+}
+
+// StartUserImport marks the start of the processing of a queued import and returns its data.
+// It fails if the import is not queued anymore, e.g. because another instance of ZITADEL started it in the meantime.
+func (c *Commands) StartUserImport(ctx context.Context, importID, resourceOwner string) (*domain.UserImport, error) {
+	writeModel, err := c.userImportWriteModelByID(ctx, importID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.UserImportStateQueued {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Ohz3u", "Errors.UserImport.NotQueued")
+	}
+	if _, err = c.pushUserImport(ctx, writeModel, userimport.NewStartedEvent(
+		ctx,
+		userImportAggregateFromWriteModel(ctx, writeModel),
+	)); err != nil {
+		return nil, err
+	}
+	return &domain.UserImport{
+		ObjectRoot: writeModelToObjectRoot(writeModel.WriteModel),
+		Format:     writeModel.Format,
+		DryRun:     writeModel.DryRun,
+		Data:       writeModel.Data,
+	}, nil
+}
+
+// ProcessUserImportRows stores the results of a batch of processed rows of the running import.
+func (c *Commands) ProcessUserImportRows(ctx context.Context, importID, resourceOwner string, results []*domain.UserImportRowResult) (*domain.ObjectDetails, error) {
+	if len(results) == 0 {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-ieX4a", "Errors.UserImport.Row.Invalid")
+	}
+	writeModel, err := c.userImportWriteModelByID(ctx, importID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.UserImportStateRunning {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-Thae1", "Errors.UserImport.NotRunning")
+	}
+	return c.pushUserImport(ctx, writeModel, userimport.NewRowsProcessedEvent(
+		ctx,
+		userImportAggregateFromWriteModel(ctx, writeModel),
+		results,
+	))
+}
+
+// FinishUserImport marks the running import as done,
+// or as failed if the reason of the abortion is passed.
+func (c *Commands) FinishUserImport(ctx context.Context, importID, resourceOwner string, importErr error) (*domain.ObjectDetails, error) {
+	writeModel, err := c.userImportWriteModelByID(ctx, importID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if writeModel.State != domain.UserImportStateRunning {
+		return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-eiR1o", "Errors.UserImport.NotRunning")
+	}
+	var reason string
+	if importErr != nil {
+		reason = importErr.Error()
+	}
+	return c.pushUserImport(ctx, writeModel, userimport.NewFinishedEvent(
+		ctx,
+		userImportAggregateFromWriteModel(ctx, writeModel),
+		reason,
+	))
+}
+
+// ImportUser is a single row of a user import
+type ImportUser struct {
+	Human *AddHuman
+	// Grants are optional, the UserID is set by the command
+	Grants []*domain.UserGrant
+}
+
+// ImportUserRow creates the human user including the grants in the organization in a single transaction.
+// The user is validated against the policies of the organization, but no initialisation mail is sent.
+// On a dry run the user is only validated and nothing is stored, the returned ID is the one the user would have received.
+func (c *Commands) ImportUserRow(ctx context.Context, orgID string, user *ImportUser, dryRun bool) (_ string, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if orgID == "" || user.Human == nil {
+		return "", errors.ThrowInvalidArgument(nil, "COMMAND-ooC5i", "Errors.UserImport.Row.Invalid")
+	}
+	validations := make([]preparation.Validation, 0, len(user.Grants)+1)
+	validations = append(validations, c.AddHumanCommand(user.Human, orgID, c.userPasswordHasher, c.userEncryption, false))
+	for _, grant := range user.Grants {
+		validations = append(validations, c.addImportedUserGrantCommand(user.Human, grant, orgID))
+	}
+	cmds, err := preparation.PrepareCommands(ctx, c.eventstore.Filter, validations...)
+	if err != nil {
+		return "", err
+	}
+	if dryRun {
+		return user.Human.ID, nil
+	}
+	if _, err = c.eventstore.Push(ctx, cmds...); err != nil {
+		return "", err
+	}
+	return user.Human.ID, nil
+}
+
+// addImportedUserGrantCommand grants the imported human on the project (grant) of the organization.
+// The id of the human is only known after the creation of the human, therefore it's read when creating the command.
+func (c *Commands) addImportedUserGrantCommand(human *AddHuman, grant *domain.UserGrant, orgID string) preparation.Validation {
+	return func() (preparation.CreateCommands, error) {
+		if grant.ProjectID == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ju3ae", "Errors.UserGrant.Invalid")
+		}
+		return func(ctx context.Context, filter preparation.FilterToQueryReducer) ([]eventstore.Command, error) {
+			grant.UserID = human.ID
+			preConditions := NewUserGrantPreConditionReadModel(grant.UserID, grant.ProjectID, grant.ProjectGrantID, orgID)
+			events, err := filter(ctx, preConditions.Query())
+			if err != nil {
+				return nil, err
+			}
+			preConditions.AppendEvents(events...)
+			if err = preConditions.Reduce(); err != nil {
+				return nil, err
+			}
+			if !preConditions.UserExists {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-4f8sg", "Errors.User.NotFound")
+			}
+			if grant.ProjectGrantID == "" && !preConditions.ProjectExists {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-3n77S", "Errors.Project.NotFound")
+			}
+			if grant.ProjectGrantID != "" && !preConditions.ProjectGrantExists {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-4m9ff", "Errors.Project.Grant.NotFound")
+			}
+			if grant.HasInvalidRoles(preConditions.ExistingRoleKeys) {
+				return nil, errors.ThrowPreconditionFailed(nil, "COMMAND-mm9F4", "Errors.Project.Role.NotFound")
+			}
+			grant.AggregateID, err = c.idGenerator.Next()
+			if err != nil {
+				return nil, err
+			}
+			return []eventstore.Command{
+				usergrant.NewUserGrantAddedEvent(
+					ctx,
+					&usergrant.NewAggregate(grant.AggregateID, orgID).Aggregate,
+					grant.UserID,
+					grant.ProjectID,
+					grant.ProjectGrantID,
+					grant.RoleKeys,
+				),
+			}, nil
+		}, nil
+	}
+}
+
+func (c *Commands) pushUserImport(ctx context.Context, writeModel *UserImportWriteModel, event eventstore.Command) (*domain.ObjectDetails, error) {
+	pushedEvents, err := c.eventstore.Push(ctx, event)
+	if err != nil {
+		return nil, err
+	}
+	err = AppendAndReduce(writeModel, pushedEvents...)
+	if err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&writeModel.WriteModel), nil
+}
+
+func (c *Commands) userImportWriteModelByID(ctx context.Context, importID, resourceOwner string) (writeModel *UserImportWriteModel, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	writeModel = NewUserImportWriteModel(importID, resourceOwner)
+	err = c.eventstore.FilterToQueryReducer(ctx, writeModel)
+	if err != nil {
+		return nil, err
+	}
+	if !writeModel.State.Exists() {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Cie0o", "Errors.UserImport.NotFound")
+	}
+	return writeModel, nil
+}
+
+func userImportAggregateFromWriteModel(ctx context.Context, wm *UserImportWriteModel) *eventstore.Aggregate {
+	return userimport.NewAggregate(wm.AggregateID, wm.ResourceOwner, authz.GetInstance(ctx).InstanceID())
+}
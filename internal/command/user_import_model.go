@@ -0,0 +1,58 @@
+package command
+
+import (
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/userimport"
+)
+
+type UserImportWriteModel struct {
+	eventstore.WriteModel
+
+	Format domain.UserImportFormat
+	DryRun bool
+	Data   []byte
+	State  domain.UserImportState
+}
+
+func NewUserImportWriteModel(importID, resourceOwner string) *UserImportWriteModel {
+	return &UserImportWriteModel{
+		WriteModel: eventstore.WriteModel{
+			AggregateID:   importID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (wm *UserImportWriteModel) Reduce() error {
+	for _, event := range wm.Events {
+		switch e := event.(type) {
+		case *userimport.AddedEvent:
+			wm.Format = e.Format
+			wm.DryRun = e.DryRun
+			wm.Data = e.Data
+			wm.State = domain.UserImportStateQueued
+		case *userimport.StartedEvent:
+			wm.State = domain.UserImportStateRunning
+		case *userimport.FinishedEvent:
+			wm.State = domain.UserImportStateDone
+			if e.Error != "" {
+				wm.State = domain.UserImportStateFailed
+			}
+		}
+	}
+	return wm.WriteModel.Reduce()
+}
+
+func (wm *UserImportWriteModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		ResourceOwner(wm.ResourceOwner).
+		AddQuery().
+		AggregateTypes(userimport.AggregateType).
+		AggregateIDs(wm.AggregateID).
+		EventTypes(
+			userimport.AddedEventType,
+			userimport.StartedEventType,
+			userimport.FinishedEventType).
+		Builder()
+}
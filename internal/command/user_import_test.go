@@ -0,0 +1,575 @@
+package command
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/id"
+	id_mock "github.com/zitadel/zitadel/internal/id/mock"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+	"github.com/zitadel/zitadel/internal/repository/userimport"
+)
+
+func TestCommandSide_AddUserImport(t *testing.T) {
+	type fields struct {
+		eventstore  *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		userImport    *domain.UserImport
+		resourceOwner string
+	}
+	type res struct {
+		id   string
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing data, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				userImport: &domain.UserImport{
+					Format: domain.UserImportFormatCSV,
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "unspecified format, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				userImport: &domain.UserImport{
+					Data: []byte("username\nuser1"),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "add import, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectPush(
+						userimport.NewAddedEvent(ctx,
+							userimport.NewAggregate("import1", "org1", "instance1"),
+							domain.UserImportFormatCSV,
+							true,
+							[]byte("username\nuser1"),
+						),
+					),
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "import1"),
+			},
+			args: args{
+				userImport: &domain.UserImport{
+					Format: domain.UserImportFormatCSV,
+					DryRun: true,
+					Data:   []byte("username\nuser1"),
+				},
+				resourceOwner: "org1",
+			},
+			res: res{
+				id: "import1",
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:  tt.fields.eventstore,
+				idGenerator: tt.fields.idGenerator,
+			}
+			gotID, got, err := r.AddUserImport(ctx, tt.args.userImport, tt.args.resourceOwner)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, gotID)
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_StartUserImport(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.UserImport
+		err  func(error) bool
+	}
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	aggregate := userimport.NewAggregate("import1", "org1", "instance1")
+	tests := []struct {
+		name   string
+		fields fields
+		res    res
+	}{
+		{
+			name: "import not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "import already started, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							userimport.NewAddedEvent(ctx, aggregate, domain.UserImportFormatNDJSON, false, []byte(`{"username":"user1"}`)),
+						),
+						eventFromEventPusher(
+							userimport.NewStartedEvent(ctx, aggregate),
+						),
+					),
+				),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "start import, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							userimport.NewAddedEvent(ctx, aggregate, domain.UserImportFormatNDJSON, false, []byte(`{"username":"user1"}`)),
+						),
+					),
+					expectPush(
+						userimport.NewStartedEvent(ctx, aggregate),
+					),
+				),
+			},
+			res: res{
+				want: &domain.UserImport{
+					ObjectRoot: models.ObjectRoot{
+						AggregateID:   "import1",
+						ResourceOwner: "org1",
+						InstanceID:    "instance1",
+					},
+					Format: domain.UserImportFormatNDJSON,
+					Data:   []byte(`{"username":"user1"}`),
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.StartUserImport(ctx, "import1", "org1")
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ProcessUserImportRows(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	aggregate := userimport.NewAggregate("import1", "org1", "instance1")
+	results := []*domain.UserImportRowResult{
+		{Row: 1, Username: "user1", UserID: "user1"},
+		{Row: 2, Username: "user2", Error: "Errors.User.AlreadyExisting"},
+	}
+	tests := []struct {
+		name    string
+		fields  fields
+		results []*domain.UserImportRowResult
+		res     res
+	}{
+		{
+			name: "no results, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "import not running, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							userimport.NewAddedEvent(ctx, aggregate, domain.UserImportFormatCSV, false, []byte("username\nuser1")),
+						),
+					),
+				),
+			},
+			results: results,
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "process rows, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							userimport.NewAddedEvent(ctx, aggregate, domain.UserImportFormatCSV, false, []byte("username\nuser1")),
+						),
+						eventFromEventPusher(
+							userimport.NewStartedEvent(ctx, aggregate),
+						),
+					),
+					expectPush(
+						userimport.NewRowsProcessedEvent(ctx, aggregate, results),
+					),
+				),
+			},
+			results: results,
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.ProcessUserImportRows(ctx, "import1", "org1", tt.results)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_FinishUserImport(t *testing.T) {
+	type fields struct {
+		eventstore *eventstore.Eventstore
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	ctx := authz.WithInstanceID(context.Background(), "instance1")
+	aggregate := userimport.NewAggregate("import1", "org1", "instance1")
+	tests := []struct {
+		name      string
+		fields    fields
+		importErr error
+		res       res
+	}{
+		{
+			name: "import already finished, precondition error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							userimport.NewAddedEvent(ctx, aggregate, domain.UserImportFormatCSV, false, []byte("username\nuser1")),
+						),
+						eventFromEventPusher(
+							userimport.NewStartedEvent(ctx, aggregate),
+						),
+						eventFromEventPusher(
+							userimport.NewFinishedEvent(ctx, aggregate, ""),
+						),
+					),
+				),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "finish import with error, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						eventFromEventPusher(
+							userimport.NewAddedEvent(ctx, aggregate, domain.UserImportFormatCSV, false, []byte("username\nuser1")),
+						),
+						eventFromEventPusher(
+							userimport.NewStartedEvent(ctx, aggregate),
+						),
+					),
+					expectPush(
+						userimport.NewFinishedEvent(ctx, aggregate, "invalid header"),
+					),
+				),
+			},
+			importErr: errors.New("invalid header"),
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore: tt.fields.eventstore,
+			}
+			got, err := r.FinishUserImport(ctx, "import1", "org1", tt.importErr)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
+
+func TestCommandSide_ImportUserRow(t *testing.T) {
+	type fields struct {
+		eventstore  func(t *testing.T) *eventstore.Eventstore
+		idGenerator id.Generator
+	}
+	type args struct {
+		user   *ImportUser
+		dryRun bool
+	}
+	type res struct {
+		id  string
+		err func(error) bool
+	}
+	userAgg := user.NewAggregate("user1", "org1")
+	importUser := func(grants ...*domain.UserGrant) *ImportUser {
+		return &ImportUser{
+			Human: &AddHuman{
+				Username:  "username",
+				Password:  "password",
+				FirstName: "firstname",
+				LastName:  "lastname",
+				Email: Email{
+					Address:  "email@test.ch",
+					Verified: true,
+				},
+				PreferredLanguage:      language.English,
+				PasswordChangeRequired: true,
+			},
+			Grants: grants,
+		}
+	}
+	policyFilters := []expect{
+		expectFilter(),
+		expectFilter(
+			eventFromEventPusher(
+				org.NewDomainPolicyAddedEvent(context.Background(),
+					&userAgg.Aggregate,
+					true,
+					true,
+					true,
+				),
+			),
+		),
+		expectFilter(
+			eventFromEventPusher(
+				org.NewPasswordComplexityPolicyAddedEvent(context.Background(),
+					&userAgg.Aggregate,
+					1,
+					false,
+					false,
+					false,
+					false,
+				),
+			),
+		),
+	}
+	projectFilter := expectFilter(
+		eventFromEventPusher(projectAddedEvent()),
+		eventFromEventPusher(
+			project.NewRoleAddedEvent(context.Background(),
+				&project.NewAggregate("project1", "org1").Aggregate,
+				"admin",
+				"admin",
+				"",
+			),
+		),
+	)
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "missing username, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				user: &ImportUser{
+					Human: &AddHuman{
+						FirstName: "firstname",
+						LastName:  "lastname",
+						Email:     Email{Address: "email@test.ch"},
+					},
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "grant without project, invalid argument error",
+			fields: fields{
+				eventstore: expectEventstore(),
+			},
+			args: args{
+				user: importUser(&domain.UserGrant{RoleKeys: []string{"admin"}}),
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "project role not existing, precondition error",
+			fields: fields{
+				eventstore: expectEventstore(
+					append(policyFilters, projectFilter)...,
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "user1"),
+			},
+			args: args{
+				user: importUser(&domain.UserGrant{ProjectID: "project1", RoleKeys: []string{"owner"}}),
+			},
+			res: res{
+				err: caos_errs.IsPreconditionFailed,
+			},
+		},
+		{
+			name: "dry run, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					append(policyFilters, projectFilter)...,
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "user1", "grant1"),
+			},
+			args: args{
+				user:   importUser(&domain.UserGrant{ProjectID: "project1", RoleKeys: []string{"admin"}}),
+				dryRun: true,
+			},
+			res: res{
+				id: "user1",
+			},
+		},
+		{
+			name: "import user with grant, ok",
+			fields: fields{
+				eventstore: expectEventstore(
+					append(policyFilters,
+						projectFilter,
+						expectPush(
+							newAddHumanEvent("$plain$x$password", true, true, ""),
+							user.NewHumanEmailVerifiedEvent(context.Background(),
+								&userAgg.Aggregate,
+							),
+							usergrant.NewUserGrantAddedEvent(context.Background(),
+								&usergrant.NewAggregate("grant1", "org1").Aggregate,
+								"user1",
+								"project1",
+								"",
+								[]string{"admin"},
+							),
+						),
+					)...,
+				),
+				idGenerator: id_mock.NewIDGeneratorExpectIDs(t, "user1", "grant1"),
+			},
+			args: args{
+				user: importUser(&domain.UserGrant{ProjectID: "project1", RoleKeys: []string{"admin"}}),
+			},
+			res: res{
+				id: "user1",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:         tt.fields.eventstore(t),
+				idGenerator:        tt.fields.idGenerator,
+				userPasswordHasher: mockPasswordHasher("x"),
+				userEncryption:     crypto.CreateMockEncryptionAlg(gomock.NewController(t)),
+			}
+			got, err := r.ImportUserRow(context.Background(), "org1", tt.args.user, tt.args.dryRun)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.id, got)
+			}
+		})
+	}
+}
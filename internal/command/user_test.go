@@ -1500,7 +1500,7 @@ func TestCommandSide_AddUserToken(t *testing.T) {
 				eventstore:  tt.fields.eventstore,
 				idGenerator: tt.fields.idGenerator,
 			}
-			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, tt.args.lifetime)
+			got, err := r.AddUserToken(tt.args.ctx, tt.args.orgID, tt.args.agentID, tt.args.clientID, tt.args.userID, tt.args.audience, tt.args.scopes, tt.args.lifetime, "", "")
 			if tt.res.err == nil {
 				assert.NoError(t, err)
 			}
@@ -1566,6 +1566,8 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								[]string{"clientID"},
 								[]string{"openid"},
 								time.Now(),
+								"",
+								"",
 							),
 						),
 					),
@@ -1598,6 +1600,8 @@ func TestCommands_RevokeAccessToken(t *testing.T) {
 								[]string{"clientID"},
 								[]string{"openid"},
 								time.Now().Add(5*time.Hour),
+								"",
+								"",
 							),
 						),
 					),
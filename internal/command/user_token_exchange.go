@@ -0,0 +1,74 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type TokenExchange struct {
+	UserID             string
+	ResourceOwner      string
+	ClientID           string
+	SubjectTokenType   string
+	RequestedTokenType string
+	// Actor is the id of the user acting on behalf of the user
+	Actor string
+	// Impersonation is set if the actor did not present a token of the user,
+	// but requests a token for the user by its id
+	Impersonation bool
+	Audience      []string
+	Scopes        []string
+}
+
+// AddTokenExchange records the exchange of a token (RFC 8693) on the user.
+// Impersonation is only allowed if the impersonation policy of the organisation of the user allows it
+// and the actor, which must be the authenticated user of the context, is granted the impersonation permission.
+func (c *Commands) AddTokenExchange(ctx context.Context, exchange *TokenExchange) (*domain.ObjectDetails, error) {
+	if exchange.UserID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "COMMAND-Ohr8j", "Errors.IDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, exchange.UserID, exchange.ResourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if existingUser.UserState != domain.UserStateActive {
+		return nil, errors.ThrowNotFound(nil, "COMMAND-Iex2a", "Errors.User.NotFound")
+	}
+	if exchange.Impersonation {
+		if err = c.checkImpersonation(ctx, existingUser.ResourceOwner, existingUser.AggregateID, exchange.Actor); err != nil {
+			return nil, err
+		}
+	}
+	pushedEvents, err := c.eventstore.Push(ctx, user.NewTokenExchangedEvent(
+		ctx,
+		UserAggregateFromWriteModel(&existingUser.WriteModel),
+		exchange.ClientID,
+		exchange.SubjectTokenType,
+		exchange.RequestedTokenType,
+		exchange.Actor,
+		exchange.Impersonation,
+		exchange.Audience,
+		exchange.Scopes,
+	))
+	if err != nil {
+		return nil, err
+	}
+	return pushedEventsToObjectDetails(pushedEvents), nil
+}
+
+func (c *Commands) checkImpersonation(ctx context.Context, resourceOwner, userID, actor string) error {
+	if actor == "" || actor == userID {
+		return errors.ThrowInvalidArgument(nil, "COMMAND-Aeb4u", "Errors.User.TokenExchange.ActorInvalid")
+	}
+	allowed, err := c.impersonationAllowed(ctx, resourceOwner)
+	if err != nil {
+		return err
+	}
+	if !allowed {
+		return errors.ThrowPermissionDenied(nil, "COMMAND-ioP9e", "Errors.User.TokenExchange.ImpersonationNotAllowed")
+	}
+	return c.checkPermission(ctx, domain.PermissionImpersonation, resourceOwner, userID)
+}
@@ -0,0 +1,252 @@
+package command
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+func TestCommandSide_AddTokenExchange(t *testing.T) {
+	type fields struct {
+		eventstore      *eventstore.Eventstore
+		checkPermission domain.PermissionCheck
+	}
+	type args struct {
+		ctx      context.Context
+		exchange *TokenExchange
+	}
+	type res struct {
+		want *domain.ObjectDetails
+		err  func(error) bool
+	}
+	tests := []struct {
+		name   string
+		fields fields
+		args   args
+		res    res
+	}{
+		{
+			name: "user id missing, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t),
+			},
+			args: args{
+				ctx:      context.Background(),
+				exchange: &TokenExchange{},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "user not existing, not found error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				exchange: &TokenExchange{
+					UserID: "user1",
+				},
+			},
+			res: res{
+				err: caos_errs.IsNotFound,
+			},
+		},
+		{
+			name: "delegation, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userV2HumanAddedEvent(),
+					),
+					expectPush(
+						user.NewTokenExchangedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"client1",
+							"urn:ietf:params:oauth:token-type:access_token",
+							"urn:ietf:params:oauth:token-type:access_token",
+							"actor1",
+							false,
+							[]string{"project1"},
+							[]string{"openid"},
+						),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				exchange: &TokenExchange{
+					UserID:             "user1",
+					ClientID:           "client1",
+					SubjectTokenType:   "urn:ietf:params:oauth:token-type:access_token",
+					RequestedTokenType: "urn:ietf:params:oauth:token-type:access_token",
+					Actor:              "actor1",
+					Audience:           []string{"project1"},
+					Scopes:             []string{"openid"},
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+		{
+			name: "impersonation without actor, invalid argument error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userV2HumanAddedEvent(),
+					),
+				),
+			},
+			args: args{
+				ctx: context.Background(),
+				exchange: &TokenExchange{
+					UserID:        "user1",
+					Impersonation: true,
+				},
+			},
+			res: res{
+				err: caos_errs.IsErrorInvalidArgument,
+			},
+		},
+		{
+			name: "impersonation not allowed by default policy, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userV2HumanAddedEvent(),
+					),
+					expectFilter(),
+					expectFilter(
+						eventFromEventPusher(
+							instance.NewImpersonationPolicyAddedEvent(context.Background(),
+								&instance.NewAggregate("instance1").Aggregate,
+								false,
+							),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+				exchange: &TokenExchange{
+					UserID:        "user1",
+					Actor:         "actor1",
+					Impersonation: true,
+				},
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "impersonation missing permission, permission denied error",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userV2HumanAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewImpersonationPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								true,
+							),
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckNotAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+				exchange: &TokenExchange{
+					UserID:        "user1",
+					Actor:         "actor1",
+					Impersonation: true,
+				},
+			},
+			res: res{
+				err: caos_errs.IsPermissionDenied,
+			},
+		},
+		{
+			name: "impersonation allowed by org policy, ok",
+			fields: fields{
+				eventstore: eventstoreExpect(t,
+					expectFilter(
+						userV2HumanAddedEvent(),
+					),
+					expectFilter(
+						eventFromEventPusher(
+							org.NewImpersonationPolicyAddedEvent(context.Background(),
+								&org.NewAggregate("org1").Aggregate,
+								true,
+							),
+						),
+					),
+					expectPush(
+						user.NewTokenExchangedEvent(context.Background(),
+							&user.NewAggregate("user1", "org1").Aggregate,
+							"client1",
+							"urn:zitadel:params:oauth:token-type:user_id",
+							"urn:ietf:params:oauth:token-type:id_token",
+							"actor1",
+							true,
+							nil,
+							nil,
+						),
+					),
+				),
+				checkPermission: newMockPermissionCheckAllowed(),
+			},
+			args: args{
+				ctx: context.Background(),
+				exchange: &TokenExchange{
+					UserID:             "user1",
+					ClientID:           "client1",
+					SubjectTokenType:   "urn:zitadel:params:oauth:token-type:user_id",
+					RequestedTokenType: "urn:ietf:params:oauth:token-type:id_token",
+					Actor:              "actor1",
+					Impersonation:      true,
+				},
+			},
+			res: res{
+				want: &domain.ObjectDetails{
+					ResourceOwner: "org1",
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := &Commands{
+				eventstore:      tt.fields.eventstore,
+				checkPermission: tt.fields.checkPermission,
+			}
+			got, err := r.AddTokenExchange(tt.args.ctx, tt.args.exchange)
+			if tt.res.err == nil {
+				assert.NoError(t, err)
+			}
+			if tt.res.err != nil && !tt.res.err(err) {
+				t.Errorf("got wrong err: %v ", err)
+			}
+			if tt.res.err == nil {
+				assert.Equal(t, tt.res.want, got)
+			}
+		})
+	}
+}
@@ -0,0 +1,365 @@
+package command
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ChangeHuman contains the changes of a human user, only the fields which are set are changed.
+type ChangeHuman struct {
+	ID       string
+	Username *string
+	Profile  *domain.Profile
+	Email    *Email
+	Phone    *Phone
+	Password *Password
+
+	// EmailCode and PhoneCode are set after the update, if the codes were requested to be returned
+	EmailCode *string
+	PhoneCode *string
+}
+
+// Password is the new password of a user.
+// To change the own password either the current password or a verification code is required.
+type Password struct {
+	Password         string
+	ChangeRequired   bool
+	CurrentPassword  string
+	VerificationCode string
+}
+
+// UpdateHumanUser changes the username, profile, email, phone and password of a human user
+// and pushes all events at once, so the user is either changed completely or not at all.
+// The caller must either be the user itself or have the user.write permission
+// on the organization of the user. Setting a verified email or phone
+// or a password without verification always requires the permission.
+func (c *Commands) UpdateHumanUser(ctx context.Context, human *ChangeHuman, alg crypto.EncryptionAlgorithm) (_ *domain.ObjectDetails, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	existingUser, err := c.existingUserWriteModelV2(ctx, human.ID)
+	if err != nil {
+		return nil, err
+	}
+	if existingUser.UserType != domain.UserTypeHuman {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ohm7e", "Errors.User.NotHuman")
+	}
+	if err = c.checkPermissionUpdateUser(ctx, existingUser.ResourceOwner, human.ID); err != nil {
+		return nil, err
+	}
+	if human.requiresWritePermission() {
+		if err = c.checkPermission(ctx, domain.PermissionUserWrite, existingUser.ResourceOwner, human.ID); err != nil {
+			return nil, err
+		}
+	}
+
+	events := make([]eventstore.Command, 0, 6)
+	if human.Username != nil {
+		usernameChanged, err := c.changeUsernameEvent(ctx, existingUser, strings.TrimSpace(*human.Username))
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, usernameChanged)
+	}
+	if human.Profile != nil {
+		profileChanged, err := c.changeHumanProfileEvent(ctx, existingUser.ResourceOwner, human.ID, human.Profile)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, profileChanged)
+	}
+	if human.Email != nil {
+		emailEvents, err := c.changeHumanEmailEvents(ctx, existingUser.ResourceOwner, human, alg)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, emailEvents...)
+	}
+	if human.Phone != nil {
+		phoneEvents, err := c.changeHumanPhoneEvents(ctx, existingUser.ResourceOwner, human, alg)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, phoneEvents...)
+	}
+	if human.Password != nil {
+		passwordChanged, err := c.changeHumanPasswordEvent(ctx, existingUser.ResourceOwner, human.ID, human.Password)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, passwordChanged)
+	}
+	if len(events) == 0 {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Eid4a", "Errors.User.NoChanges")
+	}
+	return c.pushUserStateChange(ctx, existingUser, events...)
+}
+
+func (h *ChangeHuman) requiresWritePermission() bool {
+	if h.Email != nil && h.Email.Verified {
+		return true
+	}
+	if h.Phone != nil && h.Phone.Verified {
+		return true
+	}
+	return h.Password != nil && h.Password.CurrentPassword == "" && h.Password.VerificationCode == ""
+}
+
+func (c *Commands) changeHumanProfileEvent(ctx context.Context, resourceOwner, userID string, profile *domain.Profile) (eventstore.Command, error) {
+	if err := profile.Validate(); err != nil {
+		return nil, err
+	}
+	existingProfile, err := c.profileWriteModelByID(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	userAgg := UserAggregateFromWriteModel(&existingProfile.WriteModel)
+	changedEvent, hasChanged, err := existingProfile.NewChangedEvent(ctx, userAgg, profile.FirstName, profile.LastName, profile.NickName, profile.DisplayName, profile.PreferredLanguage, profile.Gender)
+	if err != nil {
+		return nil, err
+	}
+	if !hasChanged {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Uu6ai", "Errors.User.Profile.NotChanged")
+	}
+	return changedEvent, nil
+}
+
+func (c *Commands) changeHumanEmailEvents(ctx context.Context, resourceOwner string, human *ChangeHuman, alg crypto.EncryptionAlgorithm) ([]eventstore.Command, error) {
+	cmd, err := c.NewUserEmailEvents(ctx, human.ID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Change(ctx, human.Email.Address); err != nil {
+		return nil, err
+	}
+	if human.Email.Verified {
+		cmd.SetVerified(ctx)
+		return cmd.events, nil
+	}
+	if human.Email.URLTemplate != "" {
+		if err = domain.RenderConfirmURLTemplate(io.Discard, human.Email.URLTemplate, human.ID, "code", "orgID"); err != nil {
+			return nil, err
+		}
+	}
+	config, err := secretGeneratorConfig(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeVerifyEmailCode)
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.AddGeneratedCode(ctx, crypto.NewEncryptionGenerator(*config, alg), human.Email.URLTemplate, human.Email.ReturnCode); err != nil {
+		return nil, err
+	}
+	human.EmailCode = cmd.plainCode
+	return cmd.events, nil
+}
+
+func (c *Commands) changeHumanPhoneEvents(ctx context.Context, resourceOwner string, human *ChangeHuman, alg crypto.EncryptionAlgorithm) ([]eventstore.Command, error) {
+	cmd, err := c.NewUserPhoneEvents(ctx, human.ID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.Change(ctx, human.Phone.Number); err != nil {
+		return nil, err
+	}
+	if human.Phone.Verified {
+		cmd.SetVerified(ctx)
+		return cmd.events, nil
+	}
+	config, err := secretGeneratorConfig(ctx, c.eventstore.Filter, domain.SecretGeneratorTypeVerifyPhoneCode)
+	if err != nil {
+		return nil, err
+	}
+	if err = cmd.AddGeneratedCode(ctx, crypto.NewEncryptionGenerator(*config, alg), human.Phone.ReturnCode); err != nil {
+		return nil, err
+	}
+	human.PhoneCode = cmd.plainCode
+	return cmd.events, nil
+}
+
+func (c *Commands) changeHumanPasswordEvent(ctx context.Context, resourceOwner, userID string, password *Password) (eventstore.Command, error) {
+	if password.Password == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Aeb2u", "Errors.User.Password.Empty")
+	}
+	wm, err := c.passwordWriteModel(ctx, userID, resourceOwner)
+	if err != nil {
+		return nil, err
+	}
+	switch {
+	case password.CurrentPassword != "":
+		if wm.EncodedHash == "" {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ooG8e", "Errors.User.Password.Empty")
+		}
+		if err = c.canUpdatePassword(ctx, password.Password, wm); err != nil {
+			return nil, err
+		}
+		ctx, spanPasswap := tracing.NewNamedSpan(ctx, "passwap.VerifyAndUpdate")
+		updated, err := c.userPasswordHasher.VerifyAndUpdate(wm.EncodedHash, password.CurrentPassword, password.Password)
+		spanPasswap.EndWithError(err)
+		if err = convertPasswapErr(err); err != nil {
+			return nil, err
+		}
+		return user.NewHumanPasswordChangedEvent(ctx, UserAggregateFromWriteModel(&wm.WriteModel), updated, false, ""), nil
+	case password.VerificationCode != "":
+		if wm.Code == nil {
+			return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Fae4i", "Errors.User.Code.NotFound")
+		}
+		if err = crypto.VerifyCodeWithAlgorithm(wm.CodeCreationDate, wm.CodeExpiry, wm.Code, password.VerificationCode, c.userEncryption); err != nil {
+			return nil, err
+		}
+		return c.setPasswordCommand(ctx, wm, password.Password, false)
+	default:
+		return c.setPasswordCommand(ctx, wm, password.Password, password.ChangeRequired)
+	}
+}
+
+// DeactivateUserV2 sets the state of the user to inactive.
+// The caller must have the user.write permission on the organization of the user.
+func (c *Commands) DeactivateUserV2(ctx context.Context, userID string) (*domain.ObjectDetails, error) {
+	existingUser, err := c.existingUserWriteModelV2(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if isUserStateInitial(existingUser.UserState) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ohk4e", "Errors.User.CantDeactivateInitial")
+	}
+	if isUserStateInactive(existingUser.UserState) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ees3g", "Errors.User.AlreadyInactive")
+	}
+	if err = c.checkPermission(ctx, domain.PermissionUserWrite, existingUser.ResourceOwner, userID); err != nil {
+		return nil, err
+	}
+	return c.pushUserStateChange(ctx, existingUser, user.NewUserDeactivatedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+}
+
+// ReactivateUserV2 sets the state of an inactive user back to active.
+// The caller must have the user.write permission on the organization of the user.
+func (c *Commands) ReactivateUserV2(ctx context.Context, userID string) (*domain.ObjectDetails, error) {
+	existingUser, err := c.existingUserWriteModelV2(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateInactive(existingUser.UserState) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Ahj3o", "Errors.User.NotInactive")
+	}
+	if err = c.checkPermission(ctx, domain.PermissionUserWrite, existingUser.ResourceOwner, userID); err != nil {
+		return nil, err
+	}
+	return c.pushUserStateChange(ctx, existingUser, user.NewUserReactivatedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+}
+
+// LockUserV2 locks an active or initial user.
+// The caller must have the user.write permission on the organization of the user.
+func (c *Commands) LockUserV2(ctx context.Context, userID string) (*domain.ObjectDetails, error) {
+	existingUser, err := c.existingUserWriteModelV2(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasUserState(existingUser.UserState, domain.UserStateActive, domain.UserStateInitial) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Gei1o", "Errors.User.ShouldBeActiveOrInitial")
+	}
+	if err = c.checkPermission(ctx, domain.PermissionUserWrite, existingUser.ResourceOwner, userID); err != nil {
+		return nil, err
+	}
+	return c.pushUserStateChange(ctx, existingUser, user.NewUserLockedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+}
+
+// UnlockUserV2 unlocks a locked user.
+// The caller must have the user.write permission on the organization of the user.
+func (c *Commands) UnlockUserV2(ctx context.Context, userID string) (*domain.ObjectDetails, error) {
+	existingUser, err := c.existingUserWriteModelV2(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if !hasUserState(existingUser.UserState, domain.UserStateLocked) {
+		return nil, caos_errs.ThrowPreconditionFailed(nil, "COMMAND-ooW1u", "Errors.User.NotLocked")
+	}
+	if err = c.checkPermission(ctx, domain.PermissionUserWrite, existingUser.ResourceOwner, userID); err != nil {
+		return nil, err
+	}
+	return c.pushUserStateChange(ctx, existingUser, user.NewUserUnlockedEvent(ctx, UserAggregateFromWriteModel(&existingUser.WriteModel)))
+}
+
+// RemoveUserV2 removes the user including the passed memberships and user grants.
+// The caller must either be the user itself or have the user.delete permission
+// on the organization of the user.
+func (c *Commands) RemoveUserV2(ctx context.Context, userID string, cascadingUserMemberships []*CascadingMembership, cascadingGrantIDs ...string) (*domain.ObjectDetails, error) {
+	existingUser, err := c.existingUserWriteModelV2(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if authz.GetCtxData(ctx).UserID != userID {
+		if err = c.checkPermission(ctx, domain.PermissionUserDelete, existingUser.ResourceOwner, userID); err != nil {
+			return nil, err
+		}
+	}
+	domainPolicy, err := c.getOrgDomainPolicy(ctx, existingUser.ResourceOwner)
+	if err != nil {
+		return nil, caos_errs.ThrowPreconditionFailed(err, "COMMAND-Ux0ai", "Errors.Org.DomainPolicy.NotExisting")
+	}
+	userAgg := UserAggregateFromWriteModel(&existingUser.WriteModel)
+	events := []eventstore.Command{
+		user.NewUserRemovedEvent(ctx, userAgg, existingUser.UserName, existingUser.IDPLinks, domainPolicy.UserLoginMustBeDomain),
+	}
+	for _, grantID := range cascadingGrantIDs {
+		removeEvent, _, err := c.removeUserGrant(ctx, grantID, "", true)
+		if err != nil {
+			logging.WithFields("usergrantid", grantID).WithError(err).Warn("could not cascade remove role on user grant")
+			continue
+		}
+		events = append(events, removeEvent)
+	}
+	if len(cascadingUserMemberships) > 0 {
+		membershipEvents, err := c.removeUserMemberships(ctx, cascadingUserMemberships)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, membershipEvents...)
+	}
+	return c.pushUserStateChange(ctx, existingUser, events...)
+}
+
+// existingUserWriteModelV2 returns the write model of the user independent of its organization,
+// as the v2 API does not require the organization to be passed.
+func (c *Commands) existingUserWriteModelV2(ctx context.Context, userID string) (*UserWriteModel, error) {
+	if userID == "" {
+		return nil, caos_errs.ThrowInvalidArgument(nil, "COMMAND-Weeb4", "Errors.User.UserIDMissing")
+	}
+	existingUser, err := c.userWriteModelByID(ctx, userID, "")
+	if err != nil {
+		return nil, err
+	}
+	if !isUserStateExists(existingUser.UserState) {
+		return nil, caos_errs.ThrowNotFound(nil, "COMMAND-Eu9ie", "Errors.User.NotFound")
+	}
+	return existingUser, nil
+}
+
+// checkPermissionUpdateUser allows users to change their own data,
+// otherwise the user.write permission on the organization of the user is required.
+func (c *Commands) checkPermissionUpdateUser(ctx context.Context, resourceOwner, userID string) error {
+	if authz.GetCtxData(ctx).UserID == userID {
+		return nil
+	}
+	return c.checkPermission(ctx, domain.PermissionUserWrite, resourceOwner, userID)
+}
+
+func (c *Commands) pushUserStateChange(ctx context.Context, existingUser *UserWriteModel, events ...eventstore.Command) (*domain.ObjectDetails, error) {
+	pushedEvents, err := c.eventstore.Push(ctx, events...)
+	if err != nil {
+		return nil, err
+	}
+	if err = AppendAndReduce(existingUser, pushedEvents...); err != nil {
+		return nil, err
+	}
+	return writeModelToObjectDetails(&existingUser.WriteModel), nil
+}
@@ -0,0 +1,129 @@
+package command
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	caos_errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// ChangeMachineV2 changes the name, description and access token type of a machine user.
+// Unset fields keep their current value.
+// The caller must have the user.write permission on the organization of the user.
+func (c *Commands) ChangeMachineV2(ctx context.Context, userID string, name, description *string, accessTokenType *domain.OIDCTokenType) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateMachine(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	existingMachine, err := getMachineWriteModel(ctx, userID, resourceOwner, c.eventstore.Filter)
+	if err != nil {
+		return nil, err
+	}
+	machine := &Machine{
+		ObjectRoot:      models.ObjectRoot{AggregateID: userID, ResourceOwner: resourceOwner},
+		Name:            existingMachine.Name,
+		Description:     existingMachine.Description,
+		AccessTokenType: existingMachine.AccessTokenType,
+	}
+	if name != nil {
+		machine.Name = *name
+	}
+	if description != nil {
+		machine.Description = *description
+	}
+	if accessTokenType != nil {
+		machine.AccessTokenType = *accessTokenType
+	}
+	return c.ChangeMachine(ctx, machine)
+}
+
+// AddUserMachineKeyV2 adds a key to a machine user.
+// The resource owner of the key is taken from the user,
+// the caller must have the user.write permission on the organization of the user.
+func (c *Commands) AddUserMachineKeyV2(ctx context.Context, machineKey *MachineKey) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateMachine(ctx, machineKey.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+	machineKey.ResourceOwner = resourceOwner
+	return c.AddUserMachineKey(ctx, machineKey)
+}
+
+// RemoveUserMachineKeyV2 removes a key of a machine user.
+// The caller must have the user.write permission on the organization of the user.
+func (c *Commands) RemoveUserMachineKeyV2(ctx context.Context, userID, keyID string) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateMachine(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return c.RemoveUserMachineKey(ctx, &MachineKey{
+		ObjectRoot: models.ObjectRoot{AggregateID: userID, ResourceOwner: resourceOwner},
+		KeyID:      keyID,
+	})
+}
+
+// GenerateMachineSecretV2 generates a new client secret for a machine user.
+// The plain secret will be set on the passed [GenerateMachineSecret].
+// The caller must have the user.write permission on the organization of the user.
+func (c *Commands) GenerateMachineSecretV2(ctx context.Context, userID string, generator crypto.Generator, set *GenerateMachineSecret) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateMachine(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return c.GenerateMachineSecret(ctx, userID, resourceOwner, generator, set)
+}
+
+// RemoveMachineSecretV2 removes the client secret of a machine user.
+// The caller must have the user.write permission on the organization of the user.
+func (c *Commands) RemoveMachineSecretV2(ctx context.Context, userID string) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateMachine(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return c.RemoveMachineSecret(ctx, userID, resourceOwner)
+}
+
+// AddPersonalAccessTokenV2 adds a personal access token to a machine user.
+// The resource owner of the token is taken from the user,
+// the caller must have the user.write permission on the organization of the user.
+func (c *Commands) AddPersonalAccessTokenV2(ctx context.Context, pat *PersonalAccessToken) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateMachine(ctx, pat.AggregateID)
+	if err != nil {
+		return nil, err
+	}
+	pat.ResourceOwner = resourceOwner
+	pat.AllowedUserType = domain.UserTypeMachine
+	return c.AddPersonalAccessToken(ctx, pat)
+}
+
+// RemovePersonalAccessTokenV2 removes a personal access token of a machine user.
+// The caller must have the user.write permission on the organization of the user.
+func (c *Commands) RemovePersonalAccessTokenV2(ctx context.Context, userID, tokenID string) (*domain.ObjectDetails, error) {
+	resourceOwner, err := c.checkPermissionUpdateMachine(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	return c.RemovePersonalAccessToken(ctx, &PersonalAccessToken{
+		ObjectRoot: models.ObjectRoot{AggregateID: userID, ResourceOwner: resourceOwner},
+		TokenID:    tokenID,
+	})
+}
+
+// checkPermissionUpdateMachine ensures the user exists, is a machine
+// and the caller has the user.write permission on its organization.
+// It returns the organization of the user.
+func (c *Commands) checkPermissionUpdateMachine(ctx context.Context, userID string) (string, error) {
+	existingUser, err := c.existingUserWriteModelV2(ctx, userID)
+	if err != nil {
+		return "", err
+	}
+	if existingUser.UserType != domain.UserTypeMachine {
+		return "", caos_errs.ThrowPreconditionFailed(nil, "COMMAND-Aeb3d", "Errors.User.NotMachine")
+	}
+	if err = c.checkPermission(ctx, domain.PermissionUserWrite, existingUser.ResourceOwner, userID); err != nil {
+		return "", err
+	}
+	return existingUser.ResourceOwner, nil
+}
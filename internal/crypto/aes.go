@@ -141,3 +141,44 @@ func DecryptAES(text []byte, key string) ([]byte, error) {
 
 	return cipherText, err
 }
+
+// EncryptAESGCMString encrypts the data authenticated (AES-GCM),
+// so that the decryption with a wrong key fails instead of returning garbage
+func EncryptAESGCMString(data string, key string) (string, error) {
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	nonce := make([]byte, gcm.NonceSize())
+	if _, err = io.ReadFull(rand.Reader, nonce); err != nil {
+		return "", err
+	}
+	return base64.URLEncoding.EncodeToString(gcm.Seal(nonce, nonce, []byte(data), nil)), nil
+}
+
+func DecryptAESGCMString(data string, key string) (string, error) {
+	text, err := base64.URLEncoding.DecodeString(data)
+	if err != nil {
+		return "", err
+	}
+	gcm, err := newGCM(key)
+	if err != nil {
+		return "", err
+	}
+	if len(text) < gcm.NonceSize() {
+		return "", errors.ThrowPreconditionFailed(nil, "CRYPT-Ohb3e", "cipher text too short")
+	}
+	decrypted, err := gcm.Open(nil, text[:gcm.NonceSize()], text[gcm.NonceSize():], nil)
+	if err != nil {
+		return "", err
+	}
+	return string(decrypted), nil
+}
+
+func newGCM(key string) (cipher.AEAD, error) {
+	block, err := aes.NewCipher([]byte(key))
+	if err != nil {
+		return nil, err
+	}
+	return cipher.NewGCM(block)
+}
@@ -16,3 +16,15 @@ func TestDecrypt_OK(t *testing.T) {
 
 	assert.Equal(t, "ThisIsMySecretPw", decryptedpw)
 }
+
+func TestDecryptAESGCMString(t *testing.T) {
+	encrypted, err := EncryptAESGCMString("ThisIsMySecretPw", "passphrasewhichneedstobe32bytes!")
+	assert.NoError(t, err)
+
+	decrypted, err := DecryptAESGCMString(encrypted, "passphrasewhichneedstobe32bytes!")
+	assert.NoError(t, err)
+	assert.Equal(t, "ThisIsMySecretPw", decrypted)
+
+	_, err = DecryptAESGCMString(encrypted, "anotherpassphrasewith32bytes!!!!")
+	assert.Error(t, err)
+}
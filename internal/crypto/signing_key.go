@@ -0,0 +1,193 @@
+package crypto
+
+import (
+	gocrypto "crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/pem"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// JOSE signature algorithms (RFC 7518 and RFC 8037) supported for signing keys
+const (
+	SigningAlgorithmRS256 = "RS256"
+	SigningAlgorithmRS384 = "RS384"
+	SigningAlgorithmRS512 = "RS512"
+	SigningAlgorithmES256 = "ES256"
+	SigningAlgorithmES384 = "ES384"
+	SigningAlgorithmES512 = "ES512"
+	SigningAlgorithmEdDSA = "EdDSA"
+)
+
+// SigningAlgorithms returns all JOSE signature algorithms a signing key can be generated for
+func SigningAlgorithms() []string {
+	return []string{
+		SigningAlgorithmRS256,
+		SigningAlgorithmRS384,
+		SigningAlgorithmRS512,
+		SigningAlgorithmES256,
+		SigningAlgorithmES384,
+		SigningAlgorithmES512,
+		SigningAlgorithmEdDSA,
+	}
+}
+
+// IsSigningAlgorithmSupported checks if a signing key can be generated for the JOSE signature algorithm
+func IsSigningAlgorithmSupported(algorithm string) bool {
+	for _, alg := range SigningAlgorithms() {
+		if alg == algorithm {
+			return true
+		}
+	}
+	return false
+}
+
+// IsRSASigningAlgorithm checks if the JOSE signature algorithm requires an RSA key
+func IsRSASigningAlgorithm(algorithm string) bool {
+	switch algorithm {
+	case SigningAlgorithmRS256, SigningAlgorithmRS384, SigningAlgorithmRS512:
+		return true
+	default:
+		return false
+	}
+}
+
+// GenerateSigningKeyPair generates a key pair matching the JOSE signature algorithm.
+// The bits are only used for RSA keys, elliptic curve and Ed25519 keys have a fixed size.
+func GenerateSigningKeyPair(algorithm string, bits int) (gocrypto.Signer, gocrypto.PublicKey, error) {
+	var (
+		privateKey gocrypto.Signer
+		err        error
+	)
+	switch algorithm {
+	case SigningAlgorithmRS256, SigningAlgorithmRS384, SigningAlgorithmRS512:
+		privateKey, err = rsa.GenerateKey(rand.Reader, bits)
+	case SigningAlgorithmES256:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	case SigningAlgorithmES384:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P384(), rand.Reader)
+	case SigningAlgorithmES512:
+		privateKey, err = ecdsa.GenerateKey(elliptic.P521(), rand.Reader)
+	case SigningAlgorithmEdDSA:
+		_, privateKey, err = ed25519.GenerateKey(rand.Reader)
+	default:
+		return nil, nil, errors.ThrowInvalidArgument(nil, "CRYPT-Quoh3", "Errors.Key.AlgorithmNotSupported")
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	return privateKey, privateKey.Public(), nil
+}
+
+// GenerateEncryptedSigningKeyPair generates a key pair matching the JOSE signature algorithm
+// and returns both keys encrypted
+func GenerateEncryptedSigningKeyPair(algorithm string, bits int, alg EncryptionAlgorithm) (*CryptoValue, *CryptoValue, error) {
+	privateKey, publicKey, err := GenerateSigningKeyPair(algorithm, bits)
+	if err != nil {
+		return nil, nil, err
+	}
+	privateKeyBytes, err := SigningPrivateKeyToBytes(privateKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	publicKeyBytes, err := SigningPublicKeyToBytes(publicKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryptedPrivateKey, err := Encrypt(privateKeyBytes, alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	encryptedPublicKey, err := Encrypt(publicKeyBytes, alg)
+	if err != nil {
+		return nil, nil, err
+	}
+	return encryptedPrivateKey, encryptedPublicKey, nil
+}
+
+// SigningPrivateKeyToBytes encodes the private key as PEM.
+// RSA keys are kept in the PKCS #1 format of [PrivateKeyToBytes], all other keys are encoded as PKCS #8.
+func SigningPrivateKeyToBytes(priv gocrypto.Signer) ([]byte, error) {
+	if rsaKey, ok := priv.(*rsa.PrivateKey); ok {
+		return PrivateKeyToBytes(rsaKey), nil
+	}
+	der, err := x509.MarshalPKCS8PrivateKey(priv)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PRIVATE KEY",
+		Bytes: der,
+	}), nil
+}
+
+// SigningPublicKeyToBytes encodes the public key as PKIX PEM.
+// RSA keys are kept in the format of [PublicKeyToBytes].
+func SigningPublicKeyToBytes(pub gocrypto.PublicKey) ([]byte, error) {
+	if rsaKey, ok := pub.(*rsa.PublicKey); ok {
+		return PublicKeyToBytes(rsaKey)
+	}
+	der, err := x509.MarshalPKIXPublicKey(pub)
+	if err != nil {
+		return nil, err
+	}
+	return pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: der,
+	}), nil
+}
+
+// BytesToSigningPrivateKey parses a PEM encoded RSA (PKCS #1), elliptic curve or Ed25519 (PKCS #8) private key
+func BytesToSigningPrivateKey(priv []byte) (gocrypto.Signer, error) {
+	block, _ := pem.Decode(priv)
+	if block == nil {
+		return nil, ErrEmpty
+	}
+	if block.Type == "RSA PRIVATE KEY" {
+		return BytesToPrivateKey(priv)
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return k, nil
+	case *ecdsa.PrivateKey:
+		return k, nil
+	case ed25519.PrivateKey:
+		return k, nil
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-Ahch8", "Errors.Key.AlgorithmNotSupported")
+	}
+}
+
+// BytesToSigningPublicKey parses a PEM encoded PKIX RSA, elliptic curve or Ed25519 public key
+func BytesToSigningPublicKey(pub []byte) (gocrypto.PublicKey, error) {
+	if pub == nil {
+		return nil, ErrEmpty
+	}
+	block, _ := pem.Decode(pub)
+	if block == nil {
+		return nil, ErrEmpty
+	}
+	key, err := x509.ParsePKIXPublicKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return k, nil
+	case *ecdsa.PublicKey:
+		return k, nil
+	case ed25519.PublicKey:
+		return k, nil
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "CRYPT-eiW4u", "Errors.Key.AlgorithmNotSupported")
+	}
+}
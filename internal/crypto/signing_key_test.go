@@ -0,0 +1,65 @@
+package crypto
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerateEncryptedSigningKeyPair(t *testing.T) {
+	tests := []struct {
+		algorithm string
+		wantPriv  interface{}
+		wantPub   interface{}
+		wantErr   bool
+	}{
+		{
+			algorithm: SigningAlgorithmRS256,
+			wantPriv:  &rsa.PrivateKey{},
+			wantPub:   &rsa.PublicKey{},
+		},
+		{
+			algorithm: SigningAlgorithmES256,
+			wantPriv:  &ecdsa.PrivateKey{},
+			wantPub:   &ecdsa.PublicKey{},
+		},
+		{
+			algorithm: SigningAlgorithmES384,
+			wantPriv:  &ecdsa.PrivateKey{},
+			wantPub:   &ecdsa.PublicKey{},
+		},
+		{
+			algorithm: SigningAlgorithmEdDSA,
+			wantPriv:  ed25519.PrivateKey{},
+			wantPub:   ed25519.PublicKey{},
+		},
+		{
+			algorithm: "HS256",
+			wantErr:   true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.algorithm, func(t *testing.T) {
+			privCrypto, pubCrypto, err := GenerateEncryptedSigningKeyPair(tt.algorithm, 1024, CreateMockEncryptionAlg(gomock.NewController(t)))
+			if tt.wantErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			priv, err := BytesToSigningPrivateKey(privCrypto.Crypted)
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantPriv, priv)
+
+			pub, err := BytesToSigningPublicKey(pubCrypto.Crypted)
+			require.NoError(t, err)
+			assert.IsType(t, tt.wantPub, pub)
+			assert.Equal(t, priv.Public(), pub)
+		})
+	}
+}
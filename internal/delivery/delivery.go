@@ -0,0 +1,81 @@
+// Package delivery sends the outbound calls of projection handlers to external endpoints,
+// e.g. the logout tokens of the back-channel logout or the users of the SCIM provisioning.
+//
+// The calls are made when the statement of the event is executed.
+// Failed calls fail the statement, so the handler retries the event after RetryFailedAfter
+// until MaxFailureCount (see [handler.Config]) is reached.
+// As all targets of an event are called again on a retry, receivers must be idempotent.
+package delivery
+
+import (
+	"context"
+	"errors"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+)
+
+// Target is a single call of a statement, e.g. the logout token for an application
+type Target struct {
+	// Fields describe the target in the logs, e.g. "app", appID
+	Fields []interface{}
+	// Send calls the target
+	Send func(ctx context.Context) error
+	// Done is called with the result after the call succeeded or failed on the last attempt.
+	// The returned exec is executed in the transaction of the statement, e.g. to log the result, and can be nil.
+	// Done can be nil.
+	Done func(ctx context.Context, attempts uint8, err error) (handler.Exec, error)
+}
+
+// NewStatement returns a statement, which calls the targets when it's executed by the handler.
+// If a call fails, the statement fails, unless it's the last attempt of the event (maxFailureCount),
+// on which the failure is only logged and passed to [Target.Done], so the handler continues with the next events.
+// The execs returned by [Target.Done] are executed on the table, if it's set, otherwise on the projection.
+func NewStatement(ctx context.Context, event eventstore.Event, maxFailureCount uint8, table string, targets ...*Target) *handler.Statement {
+	if len(targets) == 0 {
+		return handler.NewNoOpStatement(event)
+	}
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		failures, err := handler.FailureCount(ex, projectionName, event)
+		if err != nil {
+			return err
+		}
+		attempts := failures + 1
+		lastAttempt := attempts >= maxFailureCount
+		if table == "" {
+			table = projectionName
+		}
+
+		var failed error
+		execs := make([]handler.Exec, 0, len(targets))
+		for _, target := range targets {
+			err := target.Send(ctx)
+			logging.WithFields(target.Fields...).WithField("attempts", attempts).OnError(err).Info("delivery failed")
+			if err != nil && !lastAttempt {
+				failed = errors.Join(failed, err)
+				continue
+			}
+			if target.Done == nil {
+				continue
+			}
+			exec, err := target.Done(ctx, attempts, err)
+			if err != nil {
+				return err
+			}
+			if exec != nil {
+				execs = append(execs, exec)
+			}
+		}
+		if failed != nil {
+			return failed
+		}
+		for _, exec := range execs {
+			if err = exec(ex, table); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
@@ -0,0 +1,123 @@
+package delivery
+
+import (
+	"context"
+	"database/sql"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/eventstore/repository"
+)
+
+type mockExecuter struct{}
+
+func (*mockExecuter) Exec(string, ...interface{}) (sql.Result, error) {
+	return nil, nil
+}
+
+type result struct {
+	attempts uint8
+	err      error
+	table    string
+}
+
+func mockTarget(sendErr error, results *[]*result) *Target {
+	return &Target{
+		Send: func(context.Context) error {
+			return sendErr
+		},
+		Done: func(_ context.Context, attempts uint8, err error) (handler.Exec, error) {
+			r := &result{attempts: attempts, err: err}
+			*results = append(*results, r)
+			return func(_ handler.Executer, table string) error {
+				r.table = table
+				return nil
+			}, nil
+		},
+	}
+}
+
+func TestNewStatement(t *testing.T) {
+	sendErr := errors.New("send failed")
+	tests := []struct {
+		name            string
+		maxFailureCount uint8
+		table           string
+		sendErrs        []error
+		wantErr         bool
+		want            []*result
+	}{
+		{
+			name:            "succeeded",
+			maxFailureCount: 3,
+			sendErrs:        []error{nil, nil},
+			want: []*result{
+				{attempts: 1, table: "projection"},
+				{attempts: 1, table: "projection"},
+			},
+		},
+		{
+			name:            "succeeded, log table",
+			maxFailureCount: 3,
+			table:           "log",
+			sendErrs:        []error{nil},
+			want: []*result{
+				{attempts: 1, table: "log"},
+			},
+		},
+		{
+			name:            "failed, retried",
+			maxFailureCount: 3,
+			sendErrs:        []error{nil, sendErr},
+			wantErr:         true,
+			want: []*result{
+				{attempts: 1},
+			},
+		},
+		{
+			name:            "failed on last attempt",
+			maxFailureCount: 1,
+			sendErrs:        []error{nil, sendErr},
+			want: []*result{
+				{attempts: 1, table: "projection"},
+				{attempts: 1, err: sendErr, table: "projection"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			results := make([]*result, 0, len(tt.sendErrs))
+			targets := make([]*Target, len(tt.sendErrs))
+			for i, err := range tt.sendErrs {
+				targets[i] = mockTarget(err, &results)
+			}
+			stmt := NewStatement(context.Background(), mockEvent(), tt.maxFailureCount, tt.table, targets...)
+			err := stmt.Execute(&mockExecuter{}, "projection")
+			if tt.wantErr {
+				require.ErrorIs(t, err, sendErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tt.want, results)
+		})
+	}
+}
+
+func TestNewStatement_noTargets(t *testing.T) {
+	stmt := NewStatement(context.Background(), mockEvent(), 3, "")
+	assert.Nil(t, stmt.Execute)
+}
+
+func mockEvent() eventstore.Event {
+	return eventstore.BaseEventFromRepo(&repository.Event{
+		InstanceID:    "instance",
+		AggregateType: "user",
+		AggregateID:   "user1",
+		Seq:           1,
+	})
+}
@@ -0,0 +1,34 @@
+package delivery
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"net/http"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+// maxResponseSize is the maximum number of bytes read from a response
+const maxResponseSize = 1 << 20
+
+// Post sends the body with the header to the uri and returns the body of the response.
+// Responses without a success status (2xx) are returned as error.
+func Post(ctx context.Context, client *http.Client, uri string, header http.Header, body []byte) ([]byte, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, errors.ThrowUnavailablef(nil, "DELIV-Eet3a", "endpoint responded with status %d", resp.StatusCode)
+	}
+	return io.ReadAll(io.LimitReader(resp.Body, maxResponseSize))
+}
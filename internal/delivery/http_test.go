@@ -0,0 +1,56 @@
+package delivery
+
+import (
+	"context"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestPost(t *testing.T) {
+	tests := []struct {
+		name    string
+		status  int
+		want    []byte
+		wantErr func(error) bool
+	}{
+		{
+			name:   "success",
+			status: http.StatusOK,
+			want:   []byte("response"),
+		},
+		{
+			name:    "error status",
+			status:  http.StatusInternalServerError,
+			wantErr: errors.IsUnavailable,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				assert.Equal(t, http.MethodPost, r.Method)
+				assert.Equal(t, "text/plain", r.Header.Get("Content-Type"))
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, "request", string(body))
+				w.WriteHeader(tt.status)
+				_, _ = w.Write([]byte("response"))
+			}))
+			defer server.Close()
+
+			got, err := Post(context.Background(), server.Client(), server.URL, http.Header{"Content-Type": {"text/plain"}}, []byte("request"))
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"net/url"
 	"time"
 
 	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
@@ -14,10 +15,49 @@ type Action struct {
 	Timeout       time.Duration
 	AllowedToFail bool
 	State         ActionState
+
+	Type ActionType
+	// TargetURL is called instead of running the script if the Type is ActionTypeTarget
+	TargetURL string
+	// MaxRetries of the call to the TargetURL if it fails, limited by the Timeout
+	MaxRetries uint32
+	// SigningKey is only returned in plain on the creation of a target action
+	SigningKey string
 }
 
 func (a *Action) IsValid() bool {
-	return a.Name != ""
+	if a.Name == "" || !a.Type.Valid() {
+		return false
+	}
+	if a.Type != ActionTypeTarget {
+		return a.Script != ""
+	}
+	return IsValidActionTargetURL(a.TargetURL) && a.MaxRetries <= ActionMaxRetries
+}
+
+// ActionMaxRetries is the maximum number of retries of a target call
+const ActionMaxRetries = 5
+
+func IsValidActionTargetURL(target string) bool {
+	u, err := url.Parse(target)
+	if err != nil {
+		return false
+	}
+	return (u.Scheme == "https" || u.Scheme == "http") && u.Host != ""
+}
+
+type ActionType int32
+
+const (
+	// ActionTypeScript runs the JavaScript of the action
+	ActionTypeScript ActionType = iota
+	// ActionTypeTarget calls an external endpoint with a signed JSON payload
+	ActionTypeTarget
+	actionTypeCount
+)
+
+func (t ActionType) Valid() bool {
+	return t >= 0 && t < actionTypeCount
 }
 
 type ActionState int32
@@ -1,6 +1,7 @@
 package domain
 
 import (
+	"net/url"
 	"strings"
 	"time"
 
@@ -46,6 +47,15 @@ type OIDCApp struct {
 	ClockSkew                time.Duration
 	AdditionalOrigins        []string
 	SkipNativeAppSuccessPage bool
+	BackChannelLogoutURI     string
+	FrontChannelLogoutURI    string
+	RequirePushedAuthRequest bool
+	RequireSignedRequest     bool
+	DPoPBoundAccessTokens    bool
+	ForceRSASignedTokens     bool
+	// BackChannelClientNotificationURI is the endpoint the client is notified on,
+	// when a backchannel authentication request (CIBA) in ping mode is completed
+	BackChannelClientNotificationURI string
 
 	State AppState
 }
@@ -91,6 +101,8 @@ const (
 	OIDCGrantTypeImplicit
 	OIDCGrantTypeRefreshToken
 	OIDCGrantTypeDeviceCode
+	OIDCGrantTypeTokenExchange
+	OIDCGrantTypeCIBA
 )
 
 type OIDCApplicationType int32
@@ -123,7 +135,7 @@ const (
 )
 
 func (a *OIDCApp) IsValid() bool {
-	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() {
+	if a.ClockSkew > time.Second*5 || a.ClockSkew < time.Second*0 || !a.OriginsValid() || !a.LogoutURIsValid() || !a.BackChannelClientNotificationURIValid() {
 		return false
 	}
 	grantTypes := a.getRequiredGrantTypes()
@@ -148,6 +160,27 @@ func (a *OIDCApp) OriginsValid() bool {
 	return true
 }
 
+// LogoutURIsValid checks that the back-channel and front-channel logout uris are absolute http(s) urls, if set
+func (a *OIDCApp) LogoutURIsValid() bool {
+	return isAbsoluteHTTPURI(a.BackChannelLogoutURI) && isAbsoluteHTTPURI(a.FrontChannelLogoutURI)
+}
+
+// BackChannelClientNotificationURIValid checks that the client notification uri is an absolute http(s) url, if set
+func (a *OIDCApp) BackChannelClientNotificationURIValid() bool {
+	return isAbsoluteHTTPURI(a.BackChannelClientNotificationURI)
+}
+
+func isAbsoluteHTTPURI(uri string) bool {
+	if uri == "" {
+		return true
+	}
+	parsed, err := url.Parse(uri)
+	if err != nil {
+		return false
+	}
+	return (parsed.Scheme == "http" || parsed.Scheme == "https") && parsed.Host != "" && parsed.Fragment == ""
+}
+
 func ContainsRequiredGrantTypes(responseTypes []OIDCResponseType, grantTypes []OIDCGrantType) bool {
 	required := RequiredOIDCGrantTypes(responseTypes)
 	return ContainsOIDCGrantTypes(required, grantTypes)
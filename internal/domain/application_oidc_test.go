@@ -177,6 +177,49 @@ func TestApplicationValid(t *testing.T) {
 			},
 			result: false,
 		},
+		{
+			name: "valid oidc application: logout uris",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:            models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                 "AppID",
+					AppName:               "Name",
+					ResponseTypes:         []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:            []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					BackChannelLogoutURI:  "https://rp.example.com/logout/backchannel",
+					FrontChannelLogoutURI: "https://rp.example.com/logout/frontchannel",
+				},
+			},
+			result: true,
+		},
+		{
+			name: "invalid oidc application: relative back-channel logout uri",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:           models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                "AppID",
+					AppName:              "Name",
+					ResponseTypes:        []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:           []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					BackChannelLogoutURI: "/logout/backchannel",
+				},
+			},
+			result: false,
+		},
+		{
+			name: "invalid oidc application: front-channel logout uri with fragment",
+			args: args{
+				app: &OIDCApp{
+					ObjectRoot:            models.ObjectRoot{AggregateID: "AggregateID"},
+					AppID:                 "AppID",
+					AppName:               "Name",
+					ResponseTypes:         []OIDCResponseType{OIDCResponseTypeCode},
+					GrantTypes:            []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+					FrontChannelLogoutURI: "https://rp.example.com/logout#frontchannel",
+				},
+			},
+			result: false,
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
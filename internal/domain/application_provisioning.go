@@ -0,0 +1,44 @@
+package domain
+
+import (
+	"net/url"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// AppProvisioning is the SCIM service provider
+// the users granted on the project of the application are provisioned to
+type AppProvisioning struct {
+	models.ObjectRoot
+
+	AppID string
+	// Endpoint is the base url of the SCIM 2.0 api, e.g. https://app.example.com/scim/v2
+	Endpoint string
+	// Token is sent as bearer token, it's only required if the provisioning is set initially
+	Token string
+}
+
+func (p *AppProvisioning) IsValid() bool {
+	if p.AggregateID == "" || p.AppID == "" {
+		return false
+	}
+	endpoint, err := url.Parse(p.Endpoint)
+	if err != nil {
+		return false
+	}
+	return (endpoint.Scheme == "https" || endpoint.Scheme == "http") && endpoint.Host != ""
+}
+
+type AppProvisioningOperation int32
+
+const (
+	AppProvisioningOperationUnspecified AppProvisioningOperation = iota
+	// AppProvisioningOperationCreate creates the user at the service provider
+	AppProvisioningOperationCreate
+	// AppProvisioningOperationUpdate replaces the user at the service provider
+	AppProvisioningOperationUpdate
+	// AppProvisioningOperationDeactivate sets the user inactive at the service provider
+	AppProvisioningOperationDeactivate
+	// AppProvisioningOperationDelete deletes the user at the service provider
+	AppProvisioningOperationDelete
+)
@@ -12,6 +12,12 @@ type SAMLApp struct {
 	EntityID    string
 	Metadata    []byte
 	MetadataURL string
+	// EncryptAssertions encrypts the assertions with the encryption certificate of the service provider's metadata
+	EncryptAssertions bool
+	// ArtifactBinding allows the service provider to obtain the response through the HTTP-Artifact binding
+	ArtifactBinding bool
+	// SingleLogout includes the service provider in the SAML single logout
+	SingleLogout bool
 
 	State AppState
 }
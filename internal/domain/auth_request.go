@@ -130,6 +130,14 @@ const (
 	AuthRequestStateSucceeded
 )
 
+type PushedAuthRequestState int
+
+const (
+	PushedAuthRequestStateUnspecified PushedAuthRequestState = iota
+	PushedAuthRequestStatePushed
+	PushedAuthRequestStateUsed
+)
+
 func NewAuthRequestFromType(requestType AuthRequestType) (*AuthRequest, error) {
 	switch requestType {
 	case AuthRequestTypeOIDC:
@@ -138,6 +146,8 @@ func NewAuthRequestFromType(requestType AuthRequestType) (*AuthRequest, error) {
 		return &AuthRequest{Request: &AuthRequestSAML{}}, nil
 	case AuthRequestTypeDevice:
 		return &AuthRequest{Request: &AuthRequestDevice{}}, nil
+	case AuthRequestTypeBackChannel:
+		return &AuthRequest{Request: &AuthRequestBackChannel{}}, nil
 	}
 	return nil, errors.ThrowInvalidArgument(nil, "DOMAIN-ds2kl", "invalid request type")
 }
@@ -168,6 +178,32 @@ func (a *AuthRequest) MFALevel() MFALevel {
 	//PLANNED: check a.PossibleLOAs (and Prompt Login?)
 }
 
+// AuthMethodTypes returns the methods the user authenticated with in the login.
+func (a *AuthRequest) AuthMethodTypes() []UserAuthMethodType {
+	types := make([]UserAuthMethodType, 0, len(a.MFAsVerified)+2)
+	if a.PasswordVerified {
+		types = append(types, UserAuthMethodTypePassword)
+	}
+	if a.SelectedIDPConfigID != "" {
+		types = append(types, UserAuthMethodTypeIDP)
+	}
+	for _, mfa := range a.MFAsVerified {
+		switch mfa {
+		case MFATypeTOTP:
+			types = append(types, UserAuthMethodTypeTOTP)
+		case MFATypeU2F:
+			types = append(types, UserAuthMethodTypeU2F)
+		case MFATypeU2FUserVerification:
+			types = append(types, UserAuthMethodTypePasswordless)
+		case MFATypeOTPSMS:
+			types = append(types, UserAuthMethodTypeOTPSMS)
+		case MFATypeOTPEmail:
+			types = append(types, UserAuthMethodTypeOTPEmail)
+		}
+	}
+	return types
+}
+
 func (a *AuthRequest) AppendAudIfNotExisting(aud string) {
 	for _, a := range a.Audience {
 		if a == aud {
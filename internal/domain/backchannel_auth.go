@@ -0,0 +1,98 @@
+package domain
+
+import (
+	"strconv"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// BackChannelAuth describes a Client-Initiated Backchannel Authentication (CIBA) request.
+// It is used as input and output model in the command and query packages.
+type BackChannelAuth struct {
+	models.ObjectRoot
+
+	ClientID                string
+	AuthReqID               string
+	UserID                  string
+	Scopes                  []string
+	BindingMessage          string
+	ClientNotificationToken string
+	Expires                 time.Time
+	Notification            BackChannelAuthNotification
+	WebhookURL              string
+	State                   BackChannelAuthState
+	AuthTime                time.Time
+	AuthMethods             []UserAuthMethodType
+}
+
+// BackChannelAuthState describes the step
+// the backchannel authentication process is in.
+//
+//go:generate stringer -type=BackChannelAuthState -linecomment
+type BackChannelAuthState uint
+
+const (
+	BackChannelAuthStateUndefined BackChannelAuthState = iota // undefined
+	BackChannelAuthStateInitiated                             // initiated
+	BackChannelAuthStateApproved                              // approved
+	BackChannelAuthStateDenied                                // denied
+	BackChannelAuthStateExpired                               // expired
+	BackChannelAuthStateRemoved                               // removed
+)
+
+// Exists returns true when not Undefined and
+// any status lower than Removed.
+func (s BackChannelAuthState) Exists() bool {
+	return s > BackChannelAuthStateUndefined && s < BackChannelAuthStateRemoved
+}
+
+// Done returns true when BackChannelAuthState is Approved.
+func (s BackChannelAuthState) Done() bool {
+	return s == BackChannelAuthStateApproved
+}
+
+// Denied returns true when BackChannelAuthState is Denied, Expired or Removed.
+func (s BackChannelAuthState) Denied() bool {
+	return s >= BackChannelAuthStateDenied
+}
+
+func (s BackChannelAuthState) GoString() string {
+	return strconv.Itoa(int(s))
+}
+
+// BackChannelAuthCanceled is a subset of BackChannelAuthState, allowed to
+// be used in the backchannelauth.CanceledEvent.
+type BackChannelAuthCanceled string
+
+const (
+	BackChannelAuthCanceledDenied  = "denied"
+	BackChannelAuthCanceledExpired = "expired"
+)
+
+func (c BackChannelAuthCanceled) State() BackChannelAuthState {
+	switch c {
+	case BackChannelAuthCanceledDenied:
+		return BackChannelAuthStateDenied
+	case BackChannelAuthCanceledExpired:
+		return BackChannelAuthStateExpired
+	default:
+		return BackChannelAuthStateUndefined
+	}
+}
+
+// BackChannelAuthNotification is the channel the user is asked
+// to approve the backchannel authentication request on.
+type BackChannelAuthNotification int32
+
+const (
+	BackChannelAuthNotificationEmail BackChannelAuthNotification = iota
+	BackChannelAuthNotificationSMS
+	BackChannelAuthNotificationWebhook
+
+	backChannelAuthNotificationCount
+)
+
+func (n BackChannelAuthNotification) Valid() bool {
+	return n >= 0 && n < backChannelAuthNotificationCount
+}
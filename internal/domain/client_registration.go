@@ -0,0 +1,128 @@
+package domain
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// ClientRegistrationPolicy restricts the OIDC applications,
+// which can be registered dynamically (RFC 7591) in the project using an initial access token
+type ClientRegistrationPolicy struct {
+	models.ObjectRoot
+
+	// AllowedGrantTypes are the grant types a registered client is allowed to use
+	AllowedGrantTypes []OIDCGrantType
+	// RedirectURIPatterns are matched against the redirect, post logout redirect, logout and notification uris
+	// of a registered client. The scheme, host, port, path and query must be equal,
+	// only the leftmost label of the host can be a wildcard, e.g. https://*.example.com/callback
+	RedirectURIPatterns []string
+}
+
+func (p *ClientRegistrationPolicy) IsValid() bool {
+	if p.AggregateID == "" || len(p.AllowedGrantTypes) == 0 || len(p.RedirectURIPatterns) == 0 {
+		return false
+	}
+	for _, pattern := range p.RedirectURIPatterns {
+		if _, err := parseRedirectURIPattern(pattern); err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// Check returns an error if the app does not comply with the policy
+func (p *ClientRegistrationPolicy) Check(app *OIDCApp) error {
+	for _, grantType := range app.GrantTypes {
+		if !containsOIDCGrantType(p.AllowedGrantTypes, grantType) {
+			return errors.ThrowInvalidArgument(nil, "DOMAIN-Oof5a", "Errors.Project.ClientRegistration.GrantTypeNotAllowed")
+		}
+	}
+	if !p.redirectURIsAllowed(app.RedirectUris) || !p.redirectURIsAllowed(app.PostLogoutRedirectUris) {
+		return errors.ThrowInvalidArgument(nil, "DOMAIN-aiN4i", "Errors.Project.ClientRegistration.RedirectURINotAllowed")
+	}
+	// ZITADEL calls the back-channel uris itself, they must be restricted the same way
+	// to prevent requests to arbitrary (internal) hosts
+	for _, uri := range []string{app.BackChannelLogoutURI, app.FrontChannelLogoutURI, app.BackChannelClientNotificationURI} {
+		if uri != "" && !p.redirectURIAllowed(uri) {
+			return errors.ThrowInvalidArgument(nil, "DOMAIN-Iey3a", "Errors.Project.ClientRegistration.CallbackURINotAllowed")
+		}
+	}
+	return nil
+}
+
+func (p *ClientRegistrationPolicy) redirectURIsAllowed(uris []string) bool {
+	for _, uri := range uris {
+		if !p.redirectURIAllowed(uri) {
+			return false
+		}
+	}
+	return true
+}
+
+func (p *ClientRegistrationPolicy) redirectURIAllowed(uri string) bool {
+	parsed, err := url.Parse(uri)
+	if err != nil || parsed.User != nil || parsed.Fragment != "" || parsed.Opaque != "" {
+		return false
+	}
+	for _, pattern := range p.RedirectURIPatterns {
+		parsedPattern, err := parseRedirectURIPattern(pattern)
+		if err != nil {
+			continue
+		}
+		if redirectURIMatches(parsedPattern, parsed) {
+			return true
+		}
+	}
+	return false
+}
+
+const redirectURIWildcardLabel = "*."
+
+// parseRedirectURIPattern parses the pattern as absolute uri,
+// a wildcard is only allowed as leftmost label of the host
+func parseRedirectURIPattern(pattern string) (*url.URL, error) {
+	parsed, err := url.Parse(pattern)
+	if err != nil {
+		return nil, err
+	}
+	if parsed.Scheme == "" || parsed.Host == "" || parsed.User != nil || parsed.Fragment != "" || parsed.Opaque != "" {
+		return nil, errors.ThrowInvalidArgument(nil, "DOMAIN-Tho4e", "Errors.Project.ClientRegistration.PolicyInvalid")
+	}
+	host := strings.TrimPrefix(parsed.Hostname(), redirectURIWildcardLabel)
+	if host == "" || strings.Contains(host, "*") || strings.Contains(parsed.Port(), "*") ||
+		strings.Contains(parsed.EscapedPath(), "*") || strings.Contains(parsed.RawQuery, "*") {
+		return nil, errors.ThrowInvalidArgument(nil, "DOMAIN-Ahz7u", "Errors.Project.ClientRegistration.PolicyInvalid")
+	}
+	return parsed, nil
+}
+
+func redirectURIMatches(pattern, uri *url.URL) bool {
+	if !strings.EqualFold(pattern.Scheme, uri.Scheme) ||
+		pattern.Port() != uri.Port() ||
+		pattern.EscapedPath() != uri.EscapedPath() ||
+		pattern.RawQuery != uri.RawQuery {
+		return false
+	}
+	patternHost, host := strings.ToLower(pattern.Hostname()), strings.ToLower(uri.Hostname())
+	domain, ok := strings.CutPrefix(patternHost, redirectURIWildcardLabel)
+	if !ok {
+		return patternHost == host
+	}
+	label, ok := strings.CutSuffix(host, "."+domain)
+	return ok && label != "" && !strings.Contains(label, ".")
+}
+
+// ClientRegistrationToken is an initial access token (RFC 7591),
+// which allows to register OIDC applications in the project until it expires
+type ClientRegistrationToken struct {
+	models.ObjectRoot
+
+	TokenID        string
+	ExpirationDate time.Time
+	// Token is only returned when it is created
+	Token string
+}
@@ -0,0 +1,204 @@
+package domain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+func TestClientRegistrationPolicy_IsValid(t *testing.T) {
+	tests := []struct {
+		name     string
+		patterns []string
+		want     bool
+	}{
+		{
+			name:     "exact uri",
+			patterns: []string{"https://example.com/callback"},
+			want:     true,
+		},
+		{
+			name:     "wildcard label",
+			patterns: []string{"https://*.example.com/callback"},
+			want:     true,
+		},
+		{
+			name:     "wildcard in path",
+			patterns: []string{"https://example.com/*"},
+			want:     false,
+		},
+		{
+			name:     "wildcard not leftmost label",
+			patterns: []string{"https://app.*.example.com/callback"},
+			want:     false,
+		},
+		{
+			name:     "wildcard only",
+			patterns: []string{"https://*/callback"},
+			want:     false,
+		},
+		{
+			name:     "relative uri",
+			patterns: []string{"/callback"},
+			want:     false,
+		},
+		{
+			name:     "fragment",
+			patterns: []string{"https://example.com/callback#fragment"},
+			want:     false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := &ClientRegistrationPolicy{
+				ObjectRoot:          models.ObjectRoot{AggregateID: "project1"},
+				AllowedGrantTypes:   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectURIPatterns: tt.patterns,
+			}
+			assert.Equal(t, tt.want, p.IsValid())
+		})
+	}
+}
+
+func TestClientRegistrationPolicy_Check(t *testing.T) {
+	policy := &ClientRegistrationPolicy{
+		ObjectRoot:          models.ObjectRoot{AggregateID: "project1"},
+		AllowedGrantTypes:   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+		RedirectURIPatterns: []string{"https://*.example.com/callback", "http://localhost:8080/callback?app=1"},
+	}
+	tests := []struct {
+		name string
+		app  *OIDCApp
+		err  func(error) bool
+	}{
+		{
+			name: "grant type not allowed",
+			app: &OIDCApp{
+				GrantTypes:   []OIDCGrantType{OIDCGrantTypeImplicit},
+				RedirectUris: []string{"https://app.example.com/callback"},
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "wildcard label matches",
+			app: &OIDCApp{
+				GrantTypes:             []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris:           []string{"https://app.example.com/callback", "https://APP.example.com/callback"},
+				PostLogoutRedirectUris: []string{"https://other.example.com/callback"},
+			},
+		},
+		{
+			name: "port and query match",
+			app: &OIDCApp{
+				GrantTypes:   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris: []string{"http://localhost:8080/callback?app=1"},
+			},
+		},
+		{
+			name: "multiple labels",
+			app: &OIDCApp{
+				GrantTypes:   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris: []string{"https://attacker.com.app.example.com/callback"},
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "domain without label",
+			app: &OIDCApp{
+				GrantTypes:   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris: []string{"https://example.com/callback"},
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "fragment",
+			app: &OIDCApp{
+				GrantTypes:   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris: []string{"https://attacker.com#.example.com/callback"},
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "query",
+			app: &OIDCApp{
+				GrantTypes:   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris: []string{"https://attacker.com?.example.com/callback"},
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "user info",
+			app: &OIDCApp{
+				GrantTypes:   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris: []string{"https://app.example.com@attacker.com/callback"},
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "other scheme",
+			app: &OIDCApp{
+				GrantTypes:   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris: []string{"http://app.example.com/callback"},
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "other path",
+			app: &OIDCApp{
+				GrantTypes:   []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris: []string{"https://app.example.com/callback/other"},
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "back-channel logout uri not allowed",
+			app: &OIDCApp{
+				GrantTypes:           []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris:         []string{"https://app.example.com/callback"},
+				BackChannelLogoutURI: "http://10.0.0.1/logout",
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "front-channel logout uri not allowed",
+			app: &OIDCApp{
+				GrantTypes:            []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris:          []string{"https://app.example.com/callback"},
+				FrontChannelLogoutURI: "https://attacker.com/logout",
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "client notification uri not allowed",
+			app: &OIDCApp{
+				GrantTypes:                       []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris:                     []string{"https://app.example.com/callback"},
+				BackChannelClientNotificationURI: "http://localhost:8080/notify",
+			},
+			err: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "back-channel uris allowed",
+			app: &OIDCApp{
+				GrantTypes:                       []OIDCGrantType{OIDCGrantTypeAuthorizationCode},
+				RedirectUris:                     []string{"https://app.example.com/callback"},
+				BackChannelLogoutURI:             "https://app.example.com/callback",
+				FrontChannelLogoutURI:            "https://app.example.com/callback",
+				BackChannelClientNotificationURI: "https://app.example.com/callback",
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := policy.Check(tt.app)
+			if tt.err == nil {
+				assert.NoError(t, err)
+				return
+			}
+			assert.True(t, tt.err(err), "got wrong err: %v", err)
+		})
+	}
+}
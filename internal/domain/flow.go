@@ -22,6 +22,8 @@ const (
 	FlowTypeCustomiseToken
 	FlowTypeInternalAuthentication
 	FlowTypeCustomizeSAMLResponse
+	FlowTypeAPIRequest
+	FlowTypeEvent
 	flowTypeCount
 )
 
@@ -61,6 +63,18 @@ func (s FlowType) TriggerTypes() []TriggerType {
 		return []TriggerType{
 			TriggerTypePreSAMLResponseCreation,
 		}
+	case FlowTypeAPIRequest:
+		return []TriggerType{
+			TriggerTypePreRequest,
+			TriggerTypePostRequest,
+		}
+	case FlowTypeEvent:
+		return []TriggerType{
+			TriggerTypeUserHumanAdded,
+			TriggerTypeUserRemoved,
+			TriggerTypeUserGrantAdded,
+			TriggerTypeUserGrantRemoved,
+		}
 	default:
 		return nil
 	}
@@ -87,6 +101,10 @@ func (s FlowType) LocalizationKey() string {
 		return "Action.Flow.Type.InternalAuthentication"
 	case FlowTypeCustomizeSAMLResponse:
 		return "Action.Flow.Type.CustomizeSAMLResponse"
+	case FlowTypeAPIRequest:
+		return "Action.Flow.Type.APIRequest"
+	case FlowTypeEvent:
+		return "Action.Flow.Type.Event"
 	default:
 		return "Action.Flow.Type.Unspecified"
 	}
@@ -102,6 +120,12 @@ const (
 	TriggerTypePreUserinfoCreation
 	TriggerTypePreAccessTokenCreation
 	TriggerTypePreSAMLResponseCreation
+	TriggerTypePreRequest
+	TriggerTypePostRequest
+	TriggerTypeUserHumanAdded
+	TriggerTypeUserRemoved
+	TriggerTypeUserGrantAdded
+	TriggerTypeUserGrantRemoved
 	triggerTypeCount
 )
 
@@ -134,6 +158,18 @@ func (s TriggerType) LocalizationKey() string {
 		return "Action.TriggerType.PreAccessTokenCreation"
 	case TriggerTypePreSAMLResponseCreation:
 		return "Action.TriggerType.PreSAMLResponseCreation"
+	case TriggerTypePreRequest:
+		return "Action.TriggerType.PreRequest"
+	case TriggerTypePostRequest:
+		return "Action.TriggerType.PostRequest"
+	case TriggerTypeUserHumanAdded:
+		return "Action.TriggerType.UserHumanAdded"
+	case TriggerTypeUserRemoved:
+		return "Action.TriggerType.UserRemoved"
+	case TriggerTypeUserGrantAdded:
+		return "Action.TriggerType.UserGrantAdded"
+	case TriggerTypeUserGrantRemoved:
+		return "Action.TriggerType.UserGrantRemoved"
 	default:
 		return "Action.TriggerType.Unspecified"
 	}
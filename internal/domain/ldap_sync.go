@@ -0,0 +1,130 @@
+package domain
+
+import (
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// LDAPSync configures the mapping of LDAP group memberships to user grants
+// and the scheduled synchronisation of the users of an LDAP identity provider.
+// The AggregateID is the ID of the identity provider.
+type LDAPSync struct {
+	models.ObjectRoot
+
+	// GroupAttribute is the (multi-valued) attribute containing the groups of the user, e.g. memberOf
+	GroupAttribute string
+	GroupMappings  []*LDAPGroupMapping
+	// SyncEnabled enables the scheduled synchronisation of all users of the directory
+	SyncEnabled bool
+	// SyncInterval is the minimal duration between two synchronisations
+	SyncInterval time.Duration
+}
+
+// LDAPGroupMapping grants the roles of the project to the members of the group
+type LDAPGroupMapping struct {
+	// Group is the value of the group attribute, usually the DN of the group
+	Group     string   `json:"group"`
+	ProjectID string   `json:"projectId"`
+	Roles     []string `json:"roles,omitempty"`
+}
+
+func (s *LDAPSync) IsValid() bool {
+	if s.AggregateID == "" {
+		return false
+	}
+	if len(s.GroupMappings) > 0 && s.GroupAttribute == "" {
+		return false
+	}
+	if s.SyncEnabled && s.SyncInterval < time.Minute {
+		return false
+	}
+	for _, mapping := range s.GroupMappings {
+		if mapping.Group == "" || mapping.ProjectID == "" {
+			return false
+		}
+	}
+	return true
+}
+
+// LDAPSyncReport is the result of a synchronisation run
+type LDAPSyncReport struct {
+	Started  time.Time `json:"started"`
+	Finished time.Time `json:"finished"`
+	// Error is set if the run was aborted, e.g. because the directory wasn't reachable
+	Error string `json:"error,omitempty"`
+
+	UsersFound       uint32 `json:"usersFound,omitempty"`
+	UsersCreated     uint32 `json:"usersCreated,omitempty"`
+	UsersUpdated     uint32 `json:"usersUpdated,omitempty"`
+	UsersDeactivated uint32 `json:"usersDeactivated,omitempty"`
+	UsersReactivated uint32 `json:"usersReactivated,omitempty"`
+	GrantsAdded      uint32 `json:"grantsAdded,omitempty"`
+	GrantsChanged    uint32 `json:"grantsChanged,omitempty"`
+	GrantsRemoved    uint32 `json:"grantsRemoved,omitempty"`
+	// Failures are the errors of single users, which didn't abort the run
+	Failures []*LDAPSyncFailure `json:"failures,omitempty"`
+}
+
+// LDAPSyncFailure is the error of the synchronisation of a single user
+type LDAPSyncFailure struct {
+	ExternalUserID string `json:"externalUserId"`
+	UserID         string `json:"userId,omitempty"`
+	Error          string `json:"error"`
+}
+
+const LDAPSyncMaxFailures = 100
+
+// AddFailure adds the failure to the report, at most LDAPSyncMaxFailures are kept
+func (r *LDAPSyncReport) AddFailure(externalUserID, userID string, err error) {
+	if len(r.Failures) >= LDAPSyncMaxFailures {
+		return
+	}
+	r.Failures = append(r.Failures, &LDAPSyncFailure{
+		ExternalUserID: externalUserID,
+		UserID:         userID,
+		Error:          err.Error(),
+	})
+}
+
+func (r *LDAPSyncReport) Succeeded() bool {
+	return r.Error == ""
+}
+
+// LDAPGroupRoles returns the roles per project granted by the groups of the user,
+// the groups are compared case-insensitive as DNs are.
+// Every project of the mappings is contained: projects the user is granted by a group have a non-nil slice (which might be empty),
+// the others have a nil slice, so the grants of these projects can be removed.
+func LDAPGroupRoles(mappings []*LDAPGroupMapping, groups []string) map[string][]string {
+	projectRoles := make(map[string][]string, len(mappings))
+	for _, mapping := range mappings {
+		roles, ok := projectRoles[mapping.ProjectID]
+		if !ok {
+			projectRoles[mapping.ProjectID] = nil
+		}
+		if !containsGroup(groups, mapping.Group) {
+			continue
+		}
+		if roles == nil {
+			roles = make([]string, 0, len(mapping.Roles))
+		}
+		for _, role := range mapping.Roles {
+			if !slices.Contains(roles, role) {
+				roles = append(roles, role)
+			}
+		}
+		projectRoles[mapping.ProjectID] = roles
+	}
+	return projectRoles
+}
+
+func containsGroup(groups []string, group string) bool {
+	for _, g := range groups {
+		if strings.EqualFold(g, group) {
+			return true
+		}
+	}
+	return false
+}
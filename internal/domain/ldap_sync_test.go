@@ -0,0 +1,81 @@
+package domain
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+func TestLDAPSync_IsValid(t *testing.T) {
+	tests := []struct {
+		name string
+		sync *LDAPSync
+		want bool
+	}{
+		{
+			name: "idp id missing, invalid",
+			sync: &LDAPSync{},
+			want: false,
+		},
+		{
+			name: "mappings without group attribute, invalid",
+			sync: &LDAPSync{
+				ObjectRoot:    models.ObjectRoot{AggregateID: "idp"},
+				GroupMappings: []*LDAPGroupMapping{{Group: "cn=admins", ProjectID: "project"}},
+			},
+			want: false,
+		},
+		{
+			name: "mapping without project, invalid",
+			sync: &LDAPSync{
+				ObjectRoot:     models.ObjectRoot{AggregateID: "idp"},
+				GroupAttribute: "memberOf",
+				GroupMappings:  []*LDAPGroupMapping{{Group: "cn=admins"}},
+			},
+			want: false,
+		},
+		{
+			name: "sync interval too short, invalid",
+			sync: &LDAPSync{
+				ObjectRoot:   models.ObjectRoot{AggregateID: "idp"},
+				SyncEnabled:  true,
+				SyncInterval: time.Second,
+			},
+			want: false,
+		},
+		{
+			name: "valid",
+			sync: &LDAPSync{
+				ObjectRoot:     models.ObjectRoot{AggregateID: "idp"},
+				GroupAttribute: "memberOf",
+				GroupMappings:  []*LDAPGroupMapping{{Group: "cn=admins", ProjectID: "project", Roles: []string{"admin"}}},
+				SyncEnabled:    true,
+				SyncInterval:   time.Hour,
+			},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, tt.sync.IsValid())
+		})
+	}
+}
+
+func TestLDAPGroupRoles(t *testing.T) {
+	mappings := []*LDAPGroupMapping{
+		{Group: "cn=admins,dc=example,dc=com", ProjectID: "project1", Roles: []string{"admin", "user"}},
+		{Group: "cn=users,dc=example,dc=com", ProjectID: "project1", Roles: []string{"user"}},
+		{Group: "cn=users,dc=example,dc=com", ProjectID: "project2"},
+		{Group: "cn=others,dc=example,dc=com", ProjectID: "project3", Roles: []string{"other"}},
+	}
+	got := LDAPGroupRoles(mappings, []string{"CN=Admins,DC=example,DC=com", "cn=users,dc=example,dc=com"})
+	assert.Equal(t, map[string][]string{
+		"project1": {"admin", "user"},
+		"project2": {},
+		"project3": nil,
+	}, got)
+}
@@ -16,6 +16,8 @@ type OIDCSettings struct {
 	IdTokenLifetime            time.Duration
 	RefreshTokenIdleExpiration time.Duration
 	RefreshTokenExpiration     time.Duration
+	// SigningAlgorithm of the keys used to sign tokens, empty uses the default of the system
+	SigningAlgorithm string
 }
 
 type OIDCSettingsState int32
@@ -27,8 +27,18 @@ func (p *Permissions) appendPermission(ctxID, permission string) {
 type PermissionCheck func(ctx context.Context, permission, orgID, resourceID string) (err error)
 
 const (
-	PermissionUserWrite     = "user.write"
-	PermissionUserRead      = "user.read"
-	PermissionSessionWrite  = "session.write"
-	PermissionSessionDelete = "session.delete"
+	PermissionUserWrite      = "user.write"
+	PermissionUserRead       = "user.read"
+	PermissionUserDelete     = "user.delete"
+	PermissionUserGrantRead  = "user.grant.read"
+	PermissionUserGrantWrite = "user.grant.write"
+	PermissionSessionWrite   = "session.write"
+	PermissionSessionDelete  = "session.delete"
+	PermissionOrgRead        = "org.read"
+	PermissionOrgWrite       = "org.write"
+	PermissionOrgDelete      = "org.delete"
+	PermissionPolicyWrite    = "policy.write"
+	PermissionPolicyDelete   = "policy.delete"
+	PermissionIAMPolicyWrite = "iam.policy.write"
+	PermissionImpersonation  = "impersonation"
 )
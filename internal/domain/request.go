@@ -23,6 +23,7 @@ const (
 	AuthRequestTypeOIDC AuthRequestType = iota
 	AuthRequestTypeSAML
 	AuthRequestTypeDevice
+	AuthRequestTypeBackChannel
 )
 
 type AuthRequestOIDC struct {
@@ -72,3 +73,19 @@ func (*AuthRequestDevice) Type() AuthRequestType {
 func (a *AuthRequestDevice) IsValid() bool {
 	return a.DeviceCode != "" && a.UserCode != "" && len(a.Scopes) > 0
 }
+
+// AuthRequestBackChannel is the login of the user to approve
+// a Client-Initiated Backchannel Authentication (CIBA) request.
+type AuthRequestBackChannel struct {
+	ID             string
+	Scopes         []string
+	BindingMessage string
+}
+
+func (*AuthRequestBackChannel) Type() AuthRequestType {
+	return AuthRequestTypeBackChannel
+}
+
+func (a *AuthRequestBackChannel) IsValid() bool {
+	return a.ID != "" && len(a.Scopes) > 0
+}
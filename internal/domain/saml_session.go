@@ -0,0 +1,33 @@
+package domain
+
+import (
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+)
+
+type SAMLSessionState int32
+
+const (
+	SAMLSessionStateUnspecified SAMLSessionState = iota
+	SAMLSessionStateActive
+	SAMLSessionStateTerminated
+)
+
+// SAMLSession is the session of a user on a SAML service provider,
+// which is created for every issued SAML response.
+type SAMLSession struct {
+	ID                string
+	UserID            string
+	UserResourceOwner string
+	UserAgentID       string
+	AppID             string
+	EntityID          string
+	NameID            string
+	NameIDFormat      string
+	AuthTime          time.Time
+	// Response is the encrypted SAML response, which is only stored
+	// until the service provider resolves the artifact of the HTTP-Artifact binding.
+	Response           *crypto.CryptoValue
+	ArtifactExpiration time.Time
+}
@@ -20,6 +20,7 @@ type Token struct {
 	Expiration        time.Time
 	Scopes            []string
 	PreferredLanguage string
+	DPoPJKT           string
 }
 
 func AddAudScopeToAudience(ctx context.Context, audience, scopes []string) []string {
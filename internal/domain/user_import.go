@@ -0,0 +1,71 @@
+package domain
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+// UserImportMaxSize is the maximal size of the data of a single import
+const UserImportMaxSize = 10 << 20
+
+// UserImport is a bulk import of human users into an organization,
+// which is processed asynchronously. The AggregateID is the ID of the import
+// and the ResourceOwner the organization the users are imported into.
+type UserImport struct {
+	models.ObjectRoot
+
+	Format UserImportFormat
+	// DryRun only validates the rows without creating any user
+	DryRun bool
+	Data   []byte
+}
+
+func (i *UserImport) IsValid() bool {
+	return i.Format.Specified() && len(i.Data) > 0 && len(i.Data) <= UserImportMaxSize
+}
+
+type UserImportFormat int32
+
+const (
+	UserImportFormatUnspecified UserImportFormat = iota
+	// UserImportFormatCSV is a comma separated file with a header row
+	UserImportFormatCSV
+	// UserImportFormatNDJSON contains a JSON object per line
+	UserImportFormatNDJSON
+
+	userImportFormatCount
+)
+
+func (f UserImportFormat) Specified() bool {
+	return f > UserImportFormatUnspecified && f < userImportFormatCount
+}
+
+type UserImportState int32
+
+const (
+	UserImportStateUnspecified UserImportState = iota
+	// UserImportStateQueued is the state until the import is picked up
+	UserImportStateQueued
+	UserImportStateRunning
+	// UserImportStateDone is set when all rows were processed, single rows might have failed
+	UserImportStateDone
+	// UserImportStateFailed is set if the import was aborted, e.g. because the data couldn't be parsed
+	UserImportStateFailed
+)
+
+func (s UserImportState) Exists() bool {
+	return s > UserImportStateUnspecified
+}
+
+// UserImportRowResult is the result of the import of a single row
+type UserImportRowResult struct {
+	// Row is the number of the row in the data, starting with 1 (excluding the header of CSV)
+	Row      uint32 `json:"row"`
+	Username string `json:"username,omitempty"`
+	// UserID is the ID of the created user, or the ID the user would have received on a dry run
+	UserID string `json:"userId,omitempty"`
+	Error  string `json:"error,omitempty"`
+}
+
+func (r *UserImportRowResult) Succeeded() bool {
+	return r.Error == ""
+}
@@ -0,0 +1,8 @@
+package eventactions
+
+// Config of the event actions.
+// Failed executions are retried by the handler, see RetryFailedAfter and MaxFailureCount
+// of the projection customization.
+type Config struct {
+	Enabled bool
+}
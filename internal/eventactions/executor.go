@@ -0,0 +1,182 @@
+package eventactions
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/delivery"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+const (
+	// ProjectionName is used to store the position of the executor in the current states
+	// and the events which could not be reduced in the failed events.
+	// The executions are stored with the runs of all flows in the query.ActionExecutionTable.
+	ProjectionName = "projections.action_event_executions"
+)
+
+// secretPayloadFields are removed from the payload of the events before it's passed to the actions
+var secretPayloadFields = []string{"secret", "encodedHash", "code"}
+
+type Queries interface {
+	GetActiveActionsByFlowAndTriggerType(ctx context.Context, flowType domain.FlowType, triggerType domain.TriggerType, orgID string) ([]*query.Action, error)
+}
+
+// Start starts the executor, which runs the actions of the event flow
+// asynchronously on the events of the triggers.
+func Start(
+	ctx context.Context,
+	config *Config,
+	handlerCustomConfig projection.CustomConfig,
+	queries Queries,
+) {
+	if config == nil || !config.Enabled {
+		return
+	}
+	handlerConfig := projection.ApplyCustomConfig(handlerCustomConfig)
+	handler.NewHandler(ctx, &handlerConfig, newExecutor(queries, handlerConfig.MaxFailureCount)).Start(ctx)
+	logging.Info("event actions started")
+}
+
+type executor struct {
+	queries         Queries
+	maxFailureCount uint8
+}
+
+func newExecutor(queries Queries, maxFailureCount uint8) *executor {
+	return &executor{
+		queries:         queries,
+		maxFailureCount: maxFailureCount,
+	}
+}
+
+func (e *executor) Name() string {
+	return ProjectionName
+}
+
+func (e *executor) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.HumanAddedType,
+					Reduce: e.reduce(domain.TriggerTypeUserHumanAdded),
+				},
+				{
+					Event:  user.HumanRegisteredType,
+					Reduce: e.reduce(domain.TriggerTypeUserHumanAdded),
+				},
+				{
+					Event:  user.UserRemovedType,
+					Reduce: e.reduce(domain.TriggerTypeUserRemoved),
+				},
+			},
+		},
+		{
+			Aggregate: usergrant.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  usergrant.UserGrantAddedType,
+					Reduce: e.reduce(domain.TriggerTypeUserGrantAdded),
+				},
+				{
+					Event:  usergrant.UserGrantRemovedType,
+					Reduce: e.reduce(domain.TriggerTypeUserGrantRemoved),
+				},
+				{
+					Event:  usergrant.UserGrantCascadeRemovedType,
+					Reduce: e.reduce(domain.TriggerTypeUserGrantRemoved),
+				},
+			},
+		},
+	}
+}
+
+// reduce runs the actions of the trigger of the organisation of the event.
+// The actions are run when the statement is executed.
+// Failed executions are retried by the handler, each attempt is stored as execution of the action.
+func (e *executor) reduce(triggerType domain.TriggerType) handler.Reduce {
+	return func(event eventstore.Event) (*handler.Statement, error) {
+		ctx := eventContext(event)
+		triggerActions, err := e.queries.GetActiveActionsByFlowAndTriggerType(ctx, domain.FlowTypeEvent, triggerType, event.Aggregate().ResourceOwner)
+		if err != nil {
+			return nil, err
+		}
+		if len(triggerActions) == 0 {
+			return handler.NewNoOpStatement(event), nil
+		}
+		ctxFields, err := eventContextFields(event)
+		if err != nil {
+			return nil, err
+		}
+		targets := make([]*delivery.Target, len(triggerActions))
+		for i, action := range triggerActions {
+			action := action
+			// the error of the action is always needed to retry the execution
+			action.AllowedToFail = false
+			targets[i] = &delivery.Target{
+				Fields: []interface{}{"action", action.ID, "event", event.Type()},
+				Send: func(ctx context.Context) error {
+					return run(ctx, action, triggerType, event, ctxFields)
+				},
+			}
+		}
+		return delivery.NewStatement(ctx, event, e.maxFailureCount, "", targets...), nil
+	}
+}
+
+func run(ctx context.Context, action *query.Action, triggerType domain.TriggerType, event eventstore.Event, ctxFields []actions.FieldOption) error {
+	actionCtx, cancel := context.WithTimeout(ctx, action.Timeout())
+	defer cancel()
+	return actions.Run(
+		actionCtx,
+		actions.SetContextFields(ctxFields...),
+		actions.WithAPIFields(),
+		action.Script,
+		action.Name,
+		append(actions.ActionToOptions(action),
+			actions.WithTrigger(domain.FlowTypeEvent, triggerType),
+			actions.WithEvent(string(event.Aggregate().Type), event.Aggregate().ID, event.Sequence(), string(event.Type())),
+			actions.WithHTTP(actionCtx),
+			actions.WithUUID(actionCtx),
+		)...,
+	)
+}
+
+func eventContextFields(event eventstore.Event) ([]actions.FieldOption, error) {
+	payload := make(map[string]interface{})
+	if err := event.Unmarshal(&payload); err != nil {
+		return nil, err
+	}
+	for _, field := range secretPayloadFields {
+		delete(payload, field)
+	}
+	return []actions.FieldOption{
+		actions.SetFields("v1",
+			actions.SetFields("event",
+				actions.SetFields("type", string(event.Type())),
+				actions.SetFields("aggregateType", string(event.Aggregate().Type)),
+				actions.SetFields("aggregateId", event.Aggregate().ID),
+				actions.SetFields("resourceOwner", event.Aggregate().ResourceOwner),
+				actions.SetFields("sequence", event.Sequence()),
+				actions.SetFields("creationDate", event.CreatedAt()),
+				actions.SetFields("payload", payload),
+			),
+		),
+	}, nil
+}
+
+func eventContext(event eventstore.Event) context.Context {
+	return authz.WithInstanceID(call.WithTimestamp(context.Background()), event.Aggregate().InstanceID)
+}
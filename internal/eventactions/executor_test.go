@@ -0,0 +1,175 @@
+package eventactions
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+
+	"github.com/benbjohnson/clock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/actions"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+type mockQueries struct {
+	actions map[domain.TriggerType][]*query.Action
+}
+
+func (q *mockQueries) GetActiveActionsByFlowAndTriggerType(_ context.Context, flowType domain.FlowType, triggerType domain.TriggerType, _ string) ([]*query.Action, error) {
+	if flowType != domain.FlowTypeEvent {
+		return nil, nil
+	}
+	return q.actions[triggerType], nil
+}
+
+type execution struct {
+	stmt string
+	args []interface{}
+}
+
+type mockExecuter struct {
+	executions []execution
+}
+
+func (ex *mockExecuter) Exec(stmt string, args ...interface{}) (sql.Result, error) {
+	if stmt != "SAVEPOINT stmt_exec" && stmt != "RELEASE SAVEPOINT stmt_exec" {
+		ex.executions = append(ex.executions, execution{stmt: stmt, args: args})
+	}
+	return nil, nil
+}
+
+func humanAddedEvent() eventstore.Event {
+	return &eventstore.BaseEvent{
+		Agg: &eventstore.Aggregate{
+			ID:            "user1",
+			Type:          user.AggregateType,
+			ResourceOwner: "org1",
+			InstanceID:    "instance1",
+		},
+		Seq:       1,
+		EventType: user.HumanAddedType,
+		Data:      []byte(`{"userName": "gigi", "encodedHash": "hash"}`),
+	}
+}
+
+// recordRuns sets a logstore service on the actions, which records the runs of the actions
+func recordRuns(t *testing.T) *[]*record.ExecutionRun {
+	runs := make([]*record.ExecutionRun, 0)
+	emitter, err := logstore.NewEmitter[*record.ExecutionLog](context.Background(), clock.New(), &logstore.EmitterConfig{Enabled: true}, logstore.LogEmitterFunc[*record.ExecutionLog](func(_ context.Context, bulk []*record.ExecutionLog) error {
+		for _, r := range bulk {
+			if r.Run != nil {
+				runs = append(runs, r.Run)
+			}
+		}
+		return nil
+	}))
+	require.NoError(t, err)
+	actions.SetLogstoreService(logstore.New[*record.ExecutionLog](nil, nil, emitter))
+	return &runs
+}
+
+func Test_executor_reduce(t *testing.T) {
+	runs := recordRuns(t)
+	queries := &mockQueries{
+		actions: map[domain.TriggerType][]*query.Action{
+			domain.TriggerTypeUserHumanAdded: {
+				{
+					ID:   "action1",
+					Name: "check",
+					Script: `function check(ctx, api) {
+	if (ctx.v1.event.payload.userName != 'gigi' || ctx.v1.event.payload.encodedHash) { throw 'wrong payload' }
+	if (ctx.v1.event.aggregateId != 'user1' || ctx.v1.event.type != 'user.human.added') { throw 'wrong event' }
+}`,
+				},
+			},
+		},
+	}
+	e := newExecutor(queries, 1)
+
+	stmt, err := e.reduce(domain.TriggerTypeUserHumanAdded)(humanAddedEvent())
+	require.NoError(t, err)
+	ex := new(mockExecuter)
+	require.NoError(t, stmt.Execute(ex, ProjectionName))
+
+	// the execution is stored by the logstore and not by the statement
+	assert.Empty(t, ex.executions)
+	require.Len(t, *runs, 1)
+	run := (*runs)[0]
+	assert.True(t, run.Succeeded)
+	assert.Equal(t, domain.FlowTypeEvent, run.FlowType)
+	assert.Equal(t, domain.TriggerTypeUserHumanAdded, run.TriggerType)
+	assert.Equal(t, &record.ExecutionRunEvent{
+		AggregateType: user.AggregateType,
+		AggregateID:   "user1",
+		Sequence:      1,
+		EventType:     string(user.HumanAddedType),
+	}, run.Event)
+}
+
+func Test_executor_reduce_retry(t *testing.T) {
+	runs := recordRuns(t)
+	queries := &mockQueries{
+		actions: map[domain.TriggerType][]*query.Action{
+			domain.TriggerTypeUserHumanAdded: {
+				{
+					ID:            "action1",
+					Name:          "fail",
+					Script:        "function fail(ctx, api) { throw 'failed' }",
+					AllowedToFail: true,
+				},
+			},
+		},
+	}
+	e := newExecutor(queries, 2)
+
+	stmt, err := e.reduce(domain.TriggerTypeUserHumanAdded)(humanAddedEvent())
+	require.NoError(t, err)
+	ex := new(mockExecuter)
+	// the handler retries the statement until the max failure count is reached
+	require.Error(t, stmt.Execute(ex, ProjectionName))
+	assert.Empty(t, ex.executions)
+	// each attempt is stored as execution
+	require.Len(t, *runs, 1)
+	assert.False(t, (*runs)[0].Succeeded)
+}
+
+func Test_executor_reduce_lastAttempt(t *testing.T) {
+	runs := recordRuns(t)
+	queries := &mockQueries{
+		actions: map[domain.TriggerType][]*query.Action{
+			domain.TriggerTypeUserHumanAdded: {
+				{
+					ID:            "action1",
+					Name:          "fail",
+					Script:        "function fail(ctx, api) { throw 'failed' }",
+					AllowedToFail: true,
+				},
+			},
+		},
+	}
+	e := newExecutor(queries, 1)
+
+	stmt, err := e.reduce(domain.TriggerTypeUserHumanAdded)(humanAddedEvent())
+	require.NoError(t, err)
+	ex := new(mockExecuter)
+	require.NoError(t, stmt.Execute(ex, ProjectionName))
+
+	require.Len(t, *runs, 1)
+	assert.False(t, (*runs)[0].Succeeded)
+	assert.Contains(t, (*runs)[0].Error, "failed")
+}
+
+func Test_executor_reduce_noActions(t *testing.T) {
+	e := newExecutor(&mockQueries{}, 1)
+
+	stmt, err := e.reduce(domain.TriggerTypeUserRemoved)(humanAddedEvent())
+	require.NoError(t, err)
+	assert.Nil(t, stmt.Execute, "no op statement expected")
+}
@@ -0,0 +1,48 @@
+package eventforwarding
+
+import (
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type Config struct {
+	Enabled bool
+	// Forwarders are identified by their name,
+	// which is used to track the position of each forwarder independently
+	Forwarders map[string]*ForwarderConfig
+}
+
+type ForwarderConfig struct {
+	Events []*EventsConfig
+	Sink   SinkConfig
+}
+
+// EventsConfig defines the event types of an aggregate which are forwarded
+type EventsConfig struct {
+	Aggregate string
+	Types     []string
+}
+
+type SinkConfig struct {
+	Type   string
+	Config map[string]interface{} `mapstructure:",remain"`
+}
+
+func (c *ForwarderConfig) Validate() error {
+	if len(c.Events) == 0 {
+		return errors.ThrowInvalidArgument(nil, "EVFWD-Ohf3e", "no events configured")
+	}
+	for _, events := range c.Events {
+		if events.Aggregate == "" || len(events.Types) == 0 {
+			return errors.ThrowInvalidArgument(nil, "EVFWD-aiC3u", "aggregate and event types must be configured")
+		}
+	}
+	return nil
+}
+
+func (c *SinkConfig) NewSink() (Sink, error) {
+	create, ok := sinks[c.Type]
+	if !ok {
+		return nil, errors.ThrowInternalf(nil, "EVFWD-Ieph4", "sink type %s not supported", c.Type)
+	}
+	return create(c.Config)
+}
@@ -0,0 +1,91 @@
+package eventforwarding
+
+import (
+	"context"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+)
+
+const (
+	// ProjectionPrefix is prepended to the name of the forwarder,
+	// the resulting name is used to store the position in the current states
+	// and the failed deliveries in the failed events
+	ProjectionPrefix = "projections.event_forwarding_"
+)
+
+// Start starts a handler for each configured forwarder.
+// As each forwarder tracks its own position, forwarders can be added without affecting the others.
+func Start(ctx context.Context, config *Config, handlerCustomConfig projection.CustomConfig) error {
+	if config == nil || !config.Enabled {
+		return nil
+	}
+	for name, forwarderConfig := range config.Forwarders {
+		if err := forwarderConfig.Validate(); err != nil {
+			return err
+		}
+		sink, err := forwarderConfig.Sink.NewSink()
+		if err != nil {
+			return err
+		}
+		handlerConfig := projection.ApplyCustomConfig(handlerCustomConfig)
+		handler.NewHandler(ctx, &handlerConfig, newForwarder(name, forwarderConfig.Events, sink)).Start(ctx)
+		logging.WithFields("forwarder", name, "sink", forwarderConfig.Sink.Type).Info("event forwarder started")
+	}
+	return nil
+}
+
+type forwarder struct {
+	name   string
+	events []*EventsConfig
+	sink   Sink
+}
+
+func newForwarder(name string, events []*EventsConfig, sink Sink) *forwarder {
+	return &forwarder{
+		name:   name,
+		events: events,
+		sink:   sink,
+	}
+}
+
+func (f *forwarder) Name() string {
+	return ProjectionPrefix + f.name
+}
+
+func (f *forwarder) Reducers() []handler.AggregateReducer {
+	reducers := make([]handler.AggregateReducer, len(f.events))
+	for i, events := range f.events {
+		eventReducers := make([]handler.EventReducer, len(events.Types))
+		for j, eventType := range events.Types {
+			eventReducers[j] = handler.EventReducer{
+				Event:  eventstore.EventType(eventType),
+				Reduce: f.reduceEvent,
+			}
+		}
+		reducers[i] = handler.AggregateReducer{
+			Aggregate:     eventstore.AggregateType(events.Aggregate),
+			EventReducers: eventReducers,
+		}
+	}
+	return reducers
+}
+
+// reduceEvent delivers the event to the sink.
+// If the delivery fails, the statement fails and the event is retried
+// until the max failure count is reached and the event is stored as failed event.
+func (f *forwarder) reduceEvent(event eventstore.Event) (*handler.Statement, error) {
+	return handler.NewStatement(event, func(handler.Executer, string) error {
+		forward, err := eventToForward(event)
+		if err != nil {
+			return err
+		}
+		ctx := authz.WithInstanceID(call.WithTimestamp(context.Background()), event.Aggregate().InstanceID)
+		return f.sink.Send(ctx, forward)
+	}), nil
+}
@@ -0,0 +1,70 @@
+package eventforwarding
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+type mockSink struct {
+	events []*Event
+	err    error
+}
+
+func (s *mockSink) Send(_ context.Context, event *Event) error {
+	if s.err != nil {
+		return s.err
+	}
+	s.events = append(s.events, event)
+	return nil
+}
+
+func Test_forwarder_Reducers(t *testing.T) {
+	f := newForwarder("warehouse", []*EventsConfig{
+		{Aggregate: "user", Types: []string{"user.human.added", "user.removed"}},
+		{Aggregate: "org", Types: []string{"org.removed"}},
+	}, new(mockSink))
+
+	assert.Equal(t, "projections.event_forwarding_warehouse", f.Name())
+	reducers := f.Reducers()
+	require.Len(t, reducers, 2)
+	assert.Equal(t, eventstore.AggregateType("user"), reducers[0].Aggregate)
+	require.Len(t, reducers[0].EventReducers, 2)
+	assert.Equal(t, eventstore.EventType("user.human.added"), reducers[0].EventReducers[0].Event)
+	assert.Equal(t, eventstore.EventType("user.removed"), reducers[0].EventReducers[1].Event)
+	assert.Equal(t, eventstore.AggregateType("org"), reducers[1].Aggregate)
+	require.Len(t, reducers[1].EventReducers, 1)
+	assert.Equal(t, eventstore.EventType("org.removed"), reducers[1].EventReducers[0].Event)
+}
+
+func Test_forwarder_reduceEvent(t *testing.T) {
+	event := &eventstore.BaseEvent{
+		Agg: &eventstore.Aggregate{
+			ID:            "user1",
+			Type:          "user",
+			ResourceOwner: "org1",
+			InstanceID:    "instance",
+		},
+		Seq:       3,
+		EventType: "user.human.added",
+		Data:      []byte(`{"userName":"username"}`),
+	}
+	sink := new(mockSink)
+	stmt, err := newForwarder("warehouse", nil, sink).reduceEvent(event)
+	require.NoError(t, err)
+	require.NoError(t, stmt.Execute(nil, ""))
+	require.Len(t, sink.events, 1)
+	assert.Equal(t, &Event{
+		InstanceID:    "instance",
+		AggregateType: "user",
+		AggregateID:   "user1",
+		ResourceOwner: "org1",
+		Sequence:      3,
+		EventType:     "user.human.added",
+		Payload:       []byte(`{"userName":"username"}`),
+	}, sink.events[0])
+}
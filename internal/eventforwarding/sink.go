@@ -0,0 +1,72 @@
+package eventforwarding
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+// Sink delivers forwarded events to an external consumer.
+// Send must only return without error if the event was delivered,
+// otherwise the event is retried.
+type Sink interface {
+	Send(ctx context.Context, event *Event) error
+}
+
+type CreateSink func(rawConfig map[string]interface{}) (Sink, error)
+
+var sinks = map[string]CreateSink{
+	"webhook": NewWebhookSink,
+	"file":    NewFileSink,
+}
+
+// RegisterSink makes additional sink types (e.g. message brokers) available for the configuration.
+// It must be called before the forwarders are started.
+func RegisterSink(sinkType string, create CreateSink) {
+	sinks[sinkType] = create
+}
+
+// Event is the representation of a forwarded event.
+// As events are delivered at least once, consumers can deduplicate them
+// by the instance id, aggregate type, aggregate id and sequence.
+type Event struct {
+	InstanceID    string          `json:"instanceId"`
+	AggregateType string          `json:"aggregateType"`
+	AggregateID   string          `json:"aggregateId"`
+	ResourceOwner string          `json:"resourceOwner"`
+	Sequence      uint64          `json:"sequence"`
+	Position      float64         `json:"position"`
+	EventType     string          `json:"eventType"`
+	Creator       string          `json:"creator"`
+	CreatedAt     time.Time       `json:"createdAt"`
+	Payload       json.RawMessage `json:"payload,omitempty"`
+}
+
+func eventToForward(event eventstore.Event) (*Event, error) {
+	var payload json.RawMessage
+	if err := event.Unmarshal(&payload); err != nil {
+		return nil, err
+	}
+	return &Event{
+		InstanceID:    event.Aggregate().InstanceID,
+		AggregateType: string(event.Aggregate().Type),
+		AggregateID:   event.Aggregate().ID,
+		ResourceOwner: event.Aggregate().ResourceOwner,
+		Sequence:      event.Sequence(),
+		Position:      event.Position(),
+		EventType:     string(event.Type()),
+		Creator:       event.Creator(),
+		CreatedAt:     event.CreatedAt(),
+		Payload:       payload,
+	}, nil
+}
+
+func mapConfig(rawConfig map[string]interface{}, config any) error {
+	configData, err := json.Marshal(rawConfig)
+	if err != nil {
+		return err
+	}
+	return json.Unmarshal(configData, config)
+}
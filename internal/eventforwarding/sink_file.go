@@ -0,0 +1,55 @@
+package eventforwarding
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"sync"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+type FileConfig struct {
+	Path string
+}
+
+// fileSink appends the events as JSON lines to a local file
+type fileSink struct {
+	path string
+	mu   sync.Mutex
+}
+
+func NewFileSink(rawConfig map[string]interface{}) (Sink, error) {
+	config := new(FileConfig)
+	if err := mapConfig(rawConfig, config); err != nil {
+		return nil, errors.ThrowInternal(err, "EVFWD-Eir1u", "could not map file config")
+	}
+	if config.Path == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "EVFWD-ooN5e", "file path is missing")
+	}
+	return &fileSink{path: config.Path}, nil
+}
+
+func (s *fileSink) Send(_ context.Context, event *Event) error {
+	line, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	file, err := os.OpenFile(s.path, os.O_APPEND|os.O_CREATE|os.O_WRONLY, 0600)
+	if err != nil {
+		return err
+	}
+	if _, err = file.Write(append(line, '\n')); err != nil {
+		file.Close()
+		return err
+	}
+	// the position of the forwarder is only updated after the event is persisted
+	if err = file.Sync(); err != nil {
+		file.Close()
+		return err
+	}
+	return file.Close()
+}
@@ -0,0 +1,33 @@
+package eventforwarding
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_fileSink_Send(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "events.jsonl")
+	sink, err := NewFileSink(map[string]interface{}{"path": path})
+	require.NoError(t, err)
+
+	require.NoError(t, sink.Send(context.Background(), &Event{AggregateID: "user1", Sequence: 1, EventType: "user.human.added"}))
+	require.NoError(t, sink.Send(context.Background(), &Event{AggregateID: "user1", Sequence: 2, EventType: "user.human.changed"}))
+
+	got, err := os.ReadFile(path)
+	require.NoError(t, err)
+	assert.Equal(t,
+		`{"instanceId":"","aggregateType":"","aggregateId":"user1","resourceOwner":"","sequence":1,"position":0,"eventType":"user.human.added","creator":"","createdAt":"0001-01-01T00:00:00Z"}`+"\n"+
+			`{"instanceId":"","aggregateType":"","aggregateId":"user1","resourceOwner":"","sequence":2,"position":0,"eventType":"user.human.changed","creator":"","createdAt":"0001-01-01T00:00:00Z"}`+"\n",
+		string(got),
+	)
+}
+
+func TestNewFileSink(t *testing.T) {
+	_, err := NewFileSink(map[string]interface{}{})
+	assert.Error(t, err)
+}
@@ -0,0 +1,73 @@
+package eventforwarding
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	webhookTimeout = 10 * time.Second
+)
+
+type WebhookConfig struct {
+	URL        string
+	Headers    map[string]string
+	SigningKey string
+}
+
+type webhookSink struct {
+	config *WebhookConfig
+	client *http.Client
+	now    func() time.Time
+}
+
+func NewWebhookSink(rawConfig map[string]interface{}) (Sink, error) {
+	config := new(WebhookConfig)
+	if err := mapConfig(rawConfig, config); err != nil {
+		return nil, errors.ThrowInternal(err, "EVFWD-aeK4o", "could not map webhook config")
+	}
+	if config.URL == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "EVFWD-Woh5a", "webhook url is missing")
+	}
+	return &webhookSink{
+		config: config,
+		client: &http.Client{Timeout: webhookTimeout},
+		now:    time.Now,
+	}, nil
+}
+
+func (s *webhookSink) Send(ctx context.Context, event *Event) error {
+	body, err := json.Marshal(event)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.config.URL, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	for key, value := range s.config.Headers {
+		req.Header.Set(key, value)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.config.SigningKey != "" {
+		req.Header.Set(http_utils.SignatureHeader, http_utils.Sign([]byte(s.config.SigningKey), s.now(), body))
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return err
+	}
+	if err = resp.Body.Close(); err != nil {
+		return err
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return errors.ThrowUnknown(fmt.Errorf("calling url %s returned %s", s.config.URL, resp.Status), "EVFWD-Uo4ie", "webhook didn't return a success status")
+	}
+	return nil
+}
@@ -0,0 +1,74 @@
+package eventforwarding
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+)
+
+func Test_webhookSink_Send(t *testing.T) {
+	event := &Event{
+		InstanceID:    "instance",
+		AggregateType: "user",
+		AggregateID:   "user1",
+		ResourceOwner: "org1",
+		Sequence:      1,
+		EventType:     "user.human.added",
+		Payload:       json.RawMessage(`{"userName":"username"}`),
+	}
+	now := time.Unix(1700000000, 0)
+	tests := []struct {
+		name       string
+		statusCode int
+		wantErr    bool
+	}{
+		{
+			name:       "delivered",
+			statusCode: http.StatusOK,
+		},
+		{
+			name:       "not delivered, error",
+			statusCode: http.StatusInternalServerError,
+			wantErr:    true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				body, err := io.ReadAll(r.Body)
+				require.NoError(t, err)
+				assert.Equal(t, http_utils.Sign([]byte("key"), now, body), r.Header.Get(http_utils.SignatureHeader))
+				assert.Equal(t, "value", r.Header.Get("X-Custom"))
+				got := new(Event)
+				require.NoError(t, json.Unmarshal(body, got))
+				assert.Equal(t, event, got)
+				w.WriteHeader(tt.statusCode)
+			}))
+			defer server.Close()
+
+			sink, err := NewWebhookSink(map[string]interface{}{
+				"url":        server.URL,
+				"signingkey": "key",
+				"headers":    map[string]interface{}{"X-Custom": "value"},
+			})
+			require.NoError(t, err)
+			sink.(*webhookSink).now = func() time.Time { return now }
+
+			err = sink.Send(context.Background(), event)
+			if tt.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			assert.NoError(t, err)
+		})
+	}
+}
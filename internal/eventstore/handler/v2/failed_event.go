@@ -61,8 +61,23 @@ func (h *Handler) handleFailedStmt(tx *sql.Tx, currentState *state, f *failure)
 }
 
 func (h *Handler) failureCount(tx *sql.Tx, f *failure) (count uint8, err error) {
+	return failureCount(tx, h.projection.Name(), f)
+}
+
+// FailureCount returns how often the statement of the event failed before.
+// Statements calling external systems can use it together with [Config.MaxFailureCount]
+// to recognize their last attempt.
+func FailureCount(ex Executer, projectionName string, event eventstore.Event) (uint8, error) {
+	tx, ok := ex.(*sql.Tx)
+	if !ok {
+		return 0, nil
+	}
+	return failureCount(tx, projectionName, failureFromEvent(event, nil))
+}
+
+func failureCount(tx *sql.Tx, projectionName string, f *failure) (count uint8, err error) {
 	row := tx.QueryRow(failureCountStmt,
-		h.projection.Name(),
+		projectionName,
 		f.instance,
 		f.aggregateType,
 		f.aggregateID,
@@ -301,12 +301,7 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 		return false, err
 	}
 	defer func() {
-		if err != nil {
-			rollbackErr := tx.Rollback()
-			h.log().OnError(rollbackErr).Debug("unable to rollback tx")
-			return
-		}
-		err = tx.Commit()
+		err = h.endTx(tx, err)
 	}()
 
 	currentState, err := h.currentState(ctx, tx, config)
@@ -338,6 +333,23 @@ func (h *Handler) processEvents(ctx context.Context, config *triggerConfig) (add
 	return additionalIteration, err
 }
 
+// endTx commits the transaction if no error occurred or if a statement failed,
+// because the failure count of the statement must be stored.
+// On any other error the transaction is rolled back.
+func (h *Handler) endTx(tx *sql.Tx, err error) error {
+	if err != nil && !errors.Is(err, &executionError{}) {
+		rollbackErr := tx.Rollback()
+		h.log().OnError(rollbackErr).Debug("unable to rollback tx")
+		return err
+	}
+	commitErr := tx.Commit()
+	if err == nil {
+		return commitErr
+	}
+	h.log().OnError(commitErr).Debug("unable to commit tx")
+	return err
+}
+
 func (h *Handler) generateStatements(ctx context.Context, tx *sql.Tx, currentState *state) (_ []*Statement, additionalIteration bool, err error) {
 	if h.triggerWithoutEvents != nil {
 		stmt, err := h.triggerWithoutEvents(pseudo.NewScheduledEvent(ctx, time.Now(), currentState.instanceID))
@@ -414,23 +426,44 @@ func (h *Handler) executeStatement(ctx context.Context, tx *sql.Tx, currentState
 		h.log().WithError(err).Debug("create savepoint failed")
 		return err
 	}
-	var shouldContinue bool
-	defer func() {
-		_, err = tx.Exec("RELEASE SAVEPOINT exec")
-	}()
 
 	if err = statement.Execute(tx, h.projection.Name()); err != nil {
 		h.log().WithError(err).Error("statement execution failed")
 
-		shouldContinue = h.handleFailedStmt(tx, currentState, failureFromStatement(statement, err))
-		if shouldContinue {
+		_, savepointErr := tx.Exec("ROLLBACK TO SAVEPOINT exec")
+		if savepointErr != nil {
+			h.log().WithError(savepointErr).Debug("rollback savepoint failed")
+			return savepointErr
+		}
+
+		if shouldContinue := h.handleFailedStmt(tx, currentState, failureFromStatement(statement, err)); shouldContinue {
 			return nil
 		}
 
-		return err
+		return &executionError{parent: err}
 	}
 
-	return nil
+	_, err = tx.Exec("RELEASE SAVEPOINT exec")
+	return err
+}
+
+// executionError is returned if a statement failed and is retried,
+// the transaction is committed anyway to store the failure count
+type executionError struct {
+	parent error
+}
+
+func (s *executionError) Error() string {
+	return "statement execution: " + s.parent.Error()
+}
+
+func (s *executionError) Is(err error) bool {
+	_, ok := err.(*executionError)
+	return ok
+}
+
+func (s *executionError) Unwrap() error {
+	return s.parent
 }
 
 func (h *Handler) eventQuery(currentState *state) *eventstore.SearchQueryBuilder {
@@ -0,0 +1,269 @@
+package handler
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/database/mock"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func TestHandler_executeStatement(t *testing.T) {
+	errExecute := errors.New("execute failed")
+	creationDate := time.Now()
+	statement := func(err error) *Statement {
+		return &Statement{
+			AggregateType: "aggregate",
+			AggregateID:   "id",
+			Sequence:      1,
+			CreationDate:  creationDate,
+			InstanceID:    "instance",
+			Execute: func(ex Executer, projectionName string) error {
+				return err
+			},
+		}
+	}
+	type fields struct {
+		mock            *mock.SQLMock
+		maxFailureCount uint8
+	}
+	tests := []struct {
+		name      string
+		fields    fields
+		statement *Statement
+		isErr     func(t *testing.T, err error)
+	}{
+		{
+			name: "no op",
+			fields: fields{
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+				),
+			},
+			statement: &Statement{},
+		},
+		{
+			name: "executed, savepoint released",
+			fields: fields{
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec("SAVEPOINT exec", mock.WithExecNoRowsAffected()),
+					mock.ExcpectExec("RELEASE SAVEPOINT exec", mock.WithExecNoRowsAffected()),
+				),
+			},
+			statement: statement(nil),
+		},
+		{
+			name: "failed, rolled back to savepoint and retried",
+			fields: fields{
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec("SAVEPOINT exec", mock.WithExecNoRowsAffected()),
+					mock.ExcpectExec("ROLLBACK TO SAVEPOINT exec", mock.WithExecNoRowsAffected()),
+					mock.ExpectQuery(failureCountStmt,
+						mock.WithQueryArgs("projection", "instance", "aggregate", "id", uint64(1)),
+						mock.WithQueryResult([]string{"failure_count"}, [][]driver.Value{{0}}),
+					),
+					mock.ExcpectExec(setFailedEventStmt,
+						mock.WithExecArgs("projection", "instance", "aggregate", "id", creationDate, uint64(1), uint8(1), errExecute.Error()),
+						mock.WithExecRowsAffected(1),
+					),
+				),
+				maxFailureCount: 2,
+			},
+			statement: statement(errExecute),
+			isErr: func(t *testing.T, err error) {
+				if !errors.Is(err, &executionError{}) || !errors.Is(err, errExecute) {
+					t.Errorf("expected execution error, got: %v", err)
+				}
+			},
+		},
+		{
+			name: "failed, max failure count reached",
+			fields: fields{
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec("SAVEPOINT exec", mock.WithExecNoRowsAffected()),
+					mock.ExcpectExec("ROLLBACK TO SAVEPOINT exec", mock.WithExecNoRowsAffected()),
+					mock.ExpectQuery(failureCountStmt,
+						mock.WithQueryArgs("projection", "instance", "aggregate", "id", uint64(1)),
+						mock.WithQueryResult([]string{"failure_count"}, [][]driver.Value{{1}}),
+					),
+					mock.ExcpectExec(setFailedEventStmt,
+						mock.WithExecArgs("projection", "instance", "aggregate", "id", creationDate, uint64(1), uint8(2), errExecute.Error()),
+						mock.WithExecRowsAffected(1),
+					),
+				),
+				maxFailureCount: 2,
+			},
+			statement: statement(errExecute),
+		},
+		{
+			name: "failed, rollback to savepoint failed",
+			fields: fields{
+				mock: mock.NewSQLMock(t,
+					mock.ExpectBegin(nil),
+					mock.ExcpectExec("SAVEPOINT exec", mock.WithExecNoRowsAffected()),
+					mock.ExcpectExec("ROLLBACK TO SAVEPOINT exec",
+						mock.WithExecErr(sql.ErrTxDone),
+					),
+				),
+				maxFailureCount: 2,
+			},
+			statement: statement(errExecute),
+			isErr: func(t *testing.T, err error) {
+				if !errors.Is(err, sql.ErrTxDone) || errors.Is(err, &executionError{}) {
+					t.Errorf("unexpected error, want: %v got: %v", sql.ErrTxDone, err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		if tt.isErr == nil {
+			tt.isErr = func(t *testing.T, err error) {
+				if err != nil {
+					t.Error("expected no error got:", err)
+				}
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				projection: &projection{
+					name: "projection",
+				},
+				maxFailureCount: tt.fields.maxFailureCount,
+			}
+
+			tx, err := tt.fields.mock.DB.Begin()
+			if err != nil {
+				t.Fatalf("unable to begin transaction: %v", err)
+			}
+
+			err = h.executeStatement(context.Background(), tx, &state{instanceID: "instance"}, tt.statement)
+			tt.isErr(t, err)
+
+			tt.fields.mock.Assert(t)
+		})
+	}
+}
+
+func TestHandler_endTx(t *testing.T) {
+	errFailed := errors.New("failed")
+	tests := []struct {
+		name  string
+		mock  *mock.SQLMock
+		err   error
+		isErr func(t *testing.T, err error)
+	}{
+		{
+			name: "no error, committed",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectCommit(nil),
+			),
+		},
+		{
+			name: "commit failed",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectCommit(sql.ErrConnDone),
+			),
+			isErr: func(t *testing.T, err error) {
+				if !errors.Is(err, sql.ErrConnDone) {
+					t.Errorf("unexpected error, want: %v got: %v", sql.ErrConnDone, err)
+				}
+			},
+		},
+		{
+			name: "execution error, committed to store the failure count",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectCommit(nil),
+			),
+			err: &executionError{parent: errFailed},
+			isErr: func(t *testing.T, err error) {
+				if !errors.Is(err, errFailed) {
+					t.Errorf("unexpected error, want: %v got: %v", errFailed, err)
+				}
+			},
+		},
+		{
+			name: "other error, rolled back",
+			mock: mock.NewSQLMock(t,
+				mock.ExpectBegin(nil),
+				mock.ExpectRollback(nil),
+			),
+			err: errFailed,
+			isErr: func(t *testing.T, err error) {
+				if !errors.Is(err, errFailed) {
+					t.Errorf("unexpected error, want: %v got: %v", errFailed, err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		if tt.isErr == nil {
+			tt.isErr = func(t *testing.T, err error) {
+				if err != nil {
+					t.Error("expected no error got:", err)
+				}
+			}
+		}
+		t.Run(tt.name, func(t *testing.T) {
+			h := &Handler{
+				projection: &projection{
+					name: "projection",
+				},
+			}
+
+			tx, err := tt.mock.DB.Begin()
+			if err != nil {
+				t.Fatalf("unable to begin transaction: %v", err)
+			}
+
+			err = h.endTx(tx, tt.err)
+			tt.isErr(t, err)
+
+			tt.mock.Assert(t)
+		})
+	}
+}
+
+func TestFailureCount(t *testing.T) {
+	event := &eventstore.BaseEvent{
+		Agg: &eventstore.Aggregate{
+			ID:         "id",
+			Type:       "aggregate",
+			InstanceID: "instance",
+		},
+		Seq: 1,
+	}
+	t.Run("no transaction", func(t *testing.T) {
+		count, err := FailureCount(nil, "projection", event)
+		if err != nil || count != 0 {
+			t.Errorf("expected no failures, got: %d, %v", count, err)
+		}
+	})
+	t.Run("transaction", func(t *testing.T) {
+		sqlMock := mock.NewSQLMock(t,
+			mock.ExpectBegin(nil),
+			mock.ExpectQuery(failureCountStmt,
+				mock.WithQueryArgs("projection", "instance", "aggregate", "id", uint64(1)),
+				mock.WithQueryResult([]string{"failure_count"}, [][]driver.Value{{2}}),
+			),
+		)
+		tx, err := sqlMock.DB.Begin()
+		if err != nil {
+			t.Fatalf("unable to begin transaction: %v", err)
+		}
+		count, err := FailureCount(tx, "projection", event)
+		if err != nil || count != 2 {
+			t.Errorf("expected 2 failures, got: %d, %v", count, err)
+		}
+		sqlMock.Assert(t)
+	})
+}
@@ -300,6 +300,16 @@ func NewArrayAppendCol(column string, value interface{}) Column {
 	}
 }
 
+func NewIncrementCol(column string, value interface{}) Column {
+	return Column{
+		Name:  column,
+		Value: value,
+		ParameterOpt: func(placeholder string) string {
+			return column + " + " + placeholder
+		},
+	}
+}
+
 func NewArrayRemoveCol(column string, value interface{}) Column {
 	return Column{
 		Name:  column,
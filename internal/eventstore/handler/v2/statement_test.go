@@ -1616,6 +1616,16 @@ func TestParameterOpts(t *testing.T) {
 			constructor: NewArrayRemoveCol,
 			want:        "array_remove(testCol, $1)",
 		},
+		{
+			name: "NewIncrementCol",
+			args: args{
+				column:      "testCol",
+				value:       1,
+				placeholder: "$1",
+			},
+			constructor: NewIncrementCol,
+			want:        "testCol + $1",
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -14,17 +14,32 @@ var (
 type Subscription struct {
 	Events chan Event
 	types  map[AggregateType][]EventType
+	// overflow is only set for subscriptions which must not block the push of events
+	overflow     chan struct{}
+	overflowOnce sync.Once
 }
 
 // SubscribeAggregates subscribes for all events on the given aggregates
 func SubscribeAggregates(eventQueue chan Event, aggregates ...AggregateType) *Subscription {
+	return subscribeAggregates(eventQueue, nil, aggregates...)
+}
+
+// SubscribeAggregatesWithOverflow subscribes for all events on the given aggregates like [SubscribeAggregates],
+// but events are never pushed blocking. If the queue is full the event is dropped
+// and the channel returned by [Subscription.Overflow] is closed.
+func SubscribeAggregatesWithOverflow(eventQueue chan Event, aggregates ...AggregateType) *Subscription {
+	return subscribeAggregates(eventQueue, make(chan struct{}), aggregates...)
+}
+
+func subscribeAggregates(eventQueue chan Event, overflow chan struct{}, aggregates ...AggregateType) *Subscription {
 	types := make(map[AggregateType][]EventType, len(aggregates))
 	for _, aggregate := range aggregates {
 		types[aggregate] = nil
 	}
 	sub := &Subscription{
-		Events: eventQueue,
-		types:  types,
+		Events:   eventQueue,
+		types:    types,
+		overflow: overflow,
 	}
 
 	subsMutext.Lock()
@@ -67,7 +82,7 @@ func (es *Eventstore) notify(events []Event) {
 			eventTypes := sub.types[event.Aggregate().Type]
 			//subscription for all events
 			if len(eventTypes) == 0 {
-				sub.Events <- event
+				sub.push(event)
 				continue
 			}
 			//subscription for certain events
@@ -85,6 +100,24 @@ func (es *Eventstore) notify(events []Event) {
 	}
 }
 
+func (s *Subscription) push(event Event) {
+	if s.overflow == nil {
+		s.Events <- event
+		return
+	}
+	select {
+	case s.Events <- event:
+	default:
+		s.overflowOnce.Do(func() { close(s.overflow) })
+	}
+}
+
+// Overflow is closed as soon as an event was dropped because the queue was full.
+// It's nil (and therefore never closed) if the subscription wasn't created by [SubscribeAggregatesWithOverflow].
+func (s *Subscription) Overflow() <-chan struct{} {
+	return s.overflow
+}
+
 func (s *Subscription) Unsubscribe() {
 	subsMutext.Lock()
 	defer subsMutext.Unlock()
@@ -101,8 +134,14 @@ func (s *Subscription) Unsubscribe() {
 			}
 		}
 	}
-	_, ok := <-s.Events
-	if ok {
+	// the subscription is removed, so no events are sent to the queue anymore
+	// reading must not block, otherwise unsubscribing an empty queue would never return
+	select {
+	case _, ok := <-s.Events:
+		if ok {
+			close(s.Events)
+		}
+	default:
 		close(s.Events)
 	}
 }
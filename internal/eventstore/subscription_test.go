@@ -0,0 +1,29 @@
+package eventstore
+
+import (
+	"testing"
+)
+
+func TestSubscribeAggregatesWithOverflow(t *testing.T) {
+	event := &BaseEvent{Agg: &Aggregate{Type: "overflow.test"}}
+	queue := make(chan Event, 1)
+	sub := SubscribeAggregatesWithOverflow(queue, "overflow.test")
+	defer sub.Unsubscribe()
+
+	(&Eventstore{}).notify([]Event{event})
+	select {
+	case <-sub.Overflow():
+		t.Fatal("overflow before the queue is full")
+	default:
+	}
+
+	(&Eventstore{}).notify([]Event{event, event})
+	select {
+	case <-sub.Overflow():
+	default:
+		t.Fatal("no overflow after the queue is full")
+	}
+	if len(queue) != 1 {
+		t.Errorf("expected 1 queued event, got %d", len(queue))
+	}
+}
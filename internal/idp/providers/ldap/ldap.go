@@ -44,6 +44,7 @@ type Provider struct {
 	preferredLanguageAttribute string
 	avatarURLAttribute         string
 	profileAttribute           string
+	groupAttribute             string
 }
 
 type ProviderOpts func(provider *Provider)
@@ -175,6 +176,13 @@ func WithProfileAttribute(name string) ProviderOpts {
 	}
 }
 
+// WithGroupAttribute configures to map the (multi-valued) LDAP attribute to the groups of the user, e.g. memberOf
+func WithGroupAttribute(name string) ProviderOpts {
+	return func(p *Provider) {
+		p.groupAttribute = name
+	}
+}
+
 func New(
 	name string,
 	servers []string,
@@ -283,5 +291,8 @@ func (p *Provider) getNecessaryAttributes() []string {
 	if p.profileAttribute != "" {
 		attributes = append(attributes, p.profileAttribute)
 	}
+	if p.groupAttribute != "" {
+		attributes = append(attributes, p.groupAttribute)
+	}
 	return attributes
 }
@@ -61,22 +61,7 @@ func (s *Session) FetchUser(_ context.Context) (_ idp.User, err error) {
 	}
 	s.Entry = user
 
-	return mapLDAPEntryToUser(
-		user,
-		s.Provider.idAttribute,
-		s.Provider.firstNameAttribute,
-		s.Provider.lastNameAttribute,
-		s.Provider.displayNameAttribute,
-		s.Provider.nickNameAttribute,
-		s.Provider.preferredUsernameAttribute,
-		s.Provider.emailAttribute,
-		s.Provider.emailVerifiedAttribute,
-		s.Provider.phoneAttribute,
-		s.Provider.phoneVerifiedAttribute,
-		s.Provider.preferredLanguageAttribute,
-		s.Provider.avatarURLAttribute,
-		s.Provider.profileAttribute,
-	)
+	return s.Provider.mapEntry(user)
 }
 
 func tryBind(
@@ -241,7 +226,8 @@ func mapLDAPEntryToUser(
 	phoneVerifiedAttribute,
 	preferredLanguageAttribute,
 	avatarURLAttribute,
-	profileAttribute string,
+	profileAttribute,
+	groupAttribute string,
 ) (_ *User, err error) {
 	var emailVerified bool
 	if v := user.GetAttributeValue(emailVerifiedAttribute); v != "" {
@@ -258,7 +244,7 @@ func mapLDAPEntryToUser(
 		}
 	}
 
-	return NewUser(
+	mapped := NewUser(
 		user.GetAttributeValue(idAttribute),
 		user.GetAttributeValue(firstNameAttribute),
 		user.GetAttributeValue(lastNameAttribute),
@@ -272,5 +258,11 @@ func mapLDAPEntryToUser(
 		language.Make(user.GetAttributeValue(preferredLanguageAttribute)),
 		user.GetAttributeValue(avatarURLAttribute),
 		user.GetAttributeValue(profileAttribute),
-	), nil
+	)
+	if groupAttribute != "" {
+		if groups := user.GetAttributeValues(groupAttribute); len(groups) > 0 {
+			mapped.Groups = groups
+		}
+	}
+	return mapped, nil
 }
@@ -173,6 +173,7 @@ func TestProvider_mapLDAPEntryToUser(t *testing.T) {
 		preferredLanguageAttribute string
 		avatarURLAttribute         string
 		profileAttribute           string
+		groupAttribute             string
 	}
 	type want struct {
 		user *User
@@ -367,6 +368,26 @@ func TestProvider_mapLDAPEntryToUser(t *testing.T) {
 				},
 			},
 		},
+		{
+			name: "user with groups",
+			fields: fields{
+				user: &ldap.Entry{
+					Attributes: []*ldap.EntryAttribute{
+						{Name: "id", Values: []string{"id"}},
+						{Name: "memberOf", Values: []string{"cn=admins,ou=groups,dc=example,dc=com", "cn=users,ou=groups,dc=example,dc=com"}},
+					},
+				},
+				idAttribute:    "id",
+				groupAttribute: "memberOf",
+			},
+			want: want{
+				user: &User{
+					ID:                "id",
+					PreferredLanguage: language.Make(""),
+					Groups:            []string{"cn=admins,ou=groups,dc=example,dc=com", "cn=users,ou=groups,dc=example,dc=com"},
+				},
+			},
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -385,6 +406,7 @@ func TestProvider_mapLDAPEntryToUser(t *testing.T) {
 				tt.fields.preferredLanguageAttribute,
 				tt.fields.avatarURLAttribute,
 				tt.fields.profileAttribute,
+				tt.fields.groupAttribute,
 			)
 			if tt.want.err == nil {
 				assert.NoError(t, err)
@@ -0,0 +1,110 @@
+package ldap
+
+import (
+	"context"
+	"errors"
+
+	"github.com/go-ldap/ldap/v3"
+)
+
+var ErrNoServerReachable = errors.New("no ldap server reachable")
+
+// SearchUsers pages through all users matching the configured object classes
+// and calls handlePage with the mapped users of each page.
+// The search is executed with the bind user on the first reachable server.
+// If handlePage returns an error, the search is aborted and the error is returned.
+func (p *Provider) SearchUsers(ctx context.Context, pageSize uint32, handlePage func(users []*User) error) error {
+	conn, err := p.bindSyncConnection()
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	searchQuery := usersSearchQuery(p.userObjectClasses)
+	paging := ldap.NewControlPaging(pageSize)
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		searchRequest := ldap.NewSearchRequest(
+			p.baseDN,
+			ldap.ScopeWholeSubtree, ldap.NeverDerefAliases, 0, int(p.timeout.Seconds()), false,
+			searchQuery,
+			p.getNecessaryAttributes(),
+			[]ldap.Control{paging},
+		)
+		result, err := conn.Search(searchRequest)
+		if err != nil {
+			return err
+		}
+		users := make([]*User, 0, len(result.Entries))
+		for _, entry := range result.Entries {
+			user, err := p.mapEntry(entry)
+			if err != nil {
+				return err
+			}
+			// users without id can't be linked and are therefore ignored
+			if user.ID == "" {
+				continue
+			}
+			users = append(users, user)
+		}
+		if err = handlePage(users); err != nil {
+			return err
+		}
+		pagingResult, ok := ldap.FindControl(result.Controls, ldap.ControlTypePaging).(*ldap.ControlPaging)
+		if !ok || len(pagingResult.Cookie) == 0 {
+			return nil
+		}
+		paging.SetCookie(pagingResult.Cookie)
+	}
+}
+
+// usersSearchQuery returns the filter matching all entries with all the object classes
+func usersSearchQuery(objectClasses []string) string {
+	if len(objectClasses) == 0 {
+		return "(objectClass=*)"
+	}
+	queries := make([]string, len(objectClasses))
+	for i, class := range objectClasses {
+		queries[i] = objectClassesToSearchQuery([]string{class})
+	}
+	return queriesAndToSearchQuery(queries...)
+}
+
+func (p *Provider) bindSyncConnection() (*ldap.Conn, error) {
+	err := ErrNoServerReachable
+	for _, server := range p.servers {
+		var conn *ldap.Conn
+		conn, err = getConnection(server, p.startTLS, p.timeout)
+		if err != nil {
+			continue
+		}
+		if err = conn.Bind(p.bindDN, p.bindPassword); err != nil {
+			conn.Close()
+			continue
+		}
+		return conn, nil
+	}
+	return nil, err
+}
+
+func (p *Provider) mapEntry(entry *ldap.Entry) (*User, error) {
+	return mapLDAPEntryToUser(
+		entry,
+		p.idAttribute,
+		p.firstNameAttribute,
+		p.lastNameAttribute,
+		p.displayNameAttribute,
+		p.nickNameAttribute,
+		p.preferredUsernameAttribute,
+		p.emailAttribute,
+		p.emailVerifiedAttribute,
+		p.phoneAttribute,
+		p.phoneVerifiedAttribute,
+		p.preferredLanguageAttribute,
+		p.avatarURLAttribute,
+		p.profileAttribute,
+		p.groupAttribute,
+	)
+}
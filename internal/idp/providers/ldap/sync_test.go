@@ -0,0 +1,36 @@
+package ldap
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func Test_usersSearchQuery(t *testing.T) {
+	tests := []struct {
+		name          string
+		objectClasses []string
+		want          string
+	}{
+		{
+			"no object classes",
+			nil,
+			"(objectClass=*)",
+		},
+		{
+			"single object class",
+			[]string{"user"},
+			"(objectClass=user)",
+		},
+		{
+			"multiple object classes",
+			[]string{"user", "person"},
+			"(&(objectClass=user)(objectClass=person))",
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, usersSearchQuery(tt.objectClasses))
+		})
+	}
+}
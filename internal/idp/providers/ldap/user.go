@@ -20,6 +20,7 @@ type User struct {
 	PreferredLanguage language.Tag        `json:"preferredLanguage,omitempty"`
 	AvatarURL         string              `json:"avatarURL,omitempty"`
 	Profile           string              `json:"profile,omitempty"`
+	Groups            []string            `json:"groups,omitempty"`
 }
 
 func NewUser(
@@ -51,6 +52,7 @@ func NewUser(
 		preferredLanguage,
 		avatarURL,
 		profile,
+		nil,
 	}
 }
 
@@ -93,3 +95,6 @@ func (u *User) GetAvatarURL() string {
 func (u *User) GetProfile() string {
 	return u.Profile
 }
+func (u *User) GetGroups() []string {
+	return u.Groups
+}
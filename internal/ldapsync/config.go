@@ -0,0 +1,27 @@
+package ldapsync
+
+import (
+	"time"
+)
+
+type Config struct {
+	Enabled bool
+	// CheckInterval is the interval in which the syncer checks for LDAP identity providers with a due sync
+	CheckInterval time.Duration
+	// PageSize is the number of users requested from the LDAP server per page
+	PageSize uint32
+}
+
+func (c *Config) checkInterval() time.Duration {
+	if c.CheckInterval == 0 {
+		return time.Minute
+	}
+	return c.CheckInterval
+}
+
+func (c *Config) pageSize() uint32 {
+	if c.PageSize == 0 {
+		return 500
+	}
+	return c.PageSize
+}
@@ -0,0 +1,79 @@
+package ldapsync
+
+import (
+	"context"
+	"slices"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type GrantQueries interface {
+	ProjectByID(ctx context.Context, shouldTriggerBulk bool, id string) (*query.Project, error)
+	UserGrants(ctx context.Context, queries *query.UserGrantsQueries, shouldTriggerBulk, withOwnerRemoved bool) (*query.UserGrants, error)
+}
+
+// UserGrants returns the desired and the existing grants of the user on the projects of the group mappings,
+// which can be passed to [command.Commands.SetLDAPUserGrants].
+// Only grants directly on the project (not on a project grant) are managed by the group mappings.
+func UserGrants(ctx context.Context, queries GrantQueries, userID string, mappings []*domain.LDAPGroupMapping, groups []string) ([]*command.LDAPUserGrant, error) {
+	if len(mappings) == 0 {
+		return nil, nil
+	}
+	projectRoles := domain.LDAPGroupRoles(mappings, groups)
+	projectIDs := make([]string, 0, len(projectRoles))
+	for projectID := range projectRoles {
+		projectIDs = append(projectIDs, projectID)
+	}
+	slices.Sort(projectIDs)
+	existing, err := existingUserGrants(ctx, queries, userID, projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	grants := make([]*command.LDAPUserGrant, 0, len(projectRoles))
+	for _, projectID := range projectIDs {
+		grant := &command.LDAPUserGrant{
+			ProjectID: projectID,
+			Roles:     projectRoles[projectID],
+		}
+		if existingGrant, ok := existing[projectID]; ok {
+			grant.GrantID = existingGrant.ID
+			grant.ProjectResourceOwner = existingGrant.ResourceOwner
+		} else if grant.Roles != nil {
+			project, err := queries.ProjectByID(ctx, false, projectID)
+			if err != nil {
+				return nil, err
+			}
+			grant.ProjectResourceOwner = project.ResourceOwner
+		}
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
+
+func existingUserGrants(ctx context.Context, queries GrantQueries, userID string, projectIDs []string) (map[string]*query.UserGrant, error) {
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(userID)
+	if err != nil {
+		return nil, err
+	}
+	projectIDsQuery, err := query.NewUserGrantProjectIDsSearchQuery(projectIDs)
+	if err != nil {
+		return nil, err
+	}
+	grantIDQuery, err := query.NewUserGrantGrantIDSearchQuery("")
+	if err != nil {
+		return nil, err
+	}
+	grants, err := queries.UserGrants(ctx, &query.UserGrantsQueries{
+		Queries: []query.SearchQuery{userIDQuery, projectIDsQuery, grantIDQuery},
+	}, true, false)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]*query.UserGrant, len(grants.UserGrants))
+	for _, grant := range grants.UserGrants {
+		existing[grant.ProjectID] = grant
+	}
+	return existing, nil
+}
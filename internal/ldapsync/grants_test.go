@@ -0,0 +1,98 @@
+package ldapsync
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockGrantQueries struct {
+	projects map[string]*query.Project
+	grants   []*query.UserGrant
+}
+
+func (q *mockGrantQueries) ProjectByID(_ context.Context, _ bool, id string) (*query.Project, error) {
+	return q.projects[id], nil
+}
+
+func (q *mockGrantQueries) UserGrants(context.Context, *query.UserGrantsQueries, bool, bool) (*query.UserGrants, error) {
+	return &query.UserGrants{UserGrants: q.grants}, nil
+}
+
+func TestUserGrants(t *testing.T) {
+	mappings := []*domain.LDAPGroupMapping{
+		{Group: "cn=admins,dc=example,dc=com", ProjectID: "project1", Roles: []string{"admin"}},
+		{Group: "cn=users,dc=example,dc=com", ProjectID: "project1", Roles: []string{"user"}},
+		{Group: "cn=users,dc=example,dc=com", ProjectID: "project2"},
+		{Group: "cn=support,dc=example,dc=com", ProjectID: "project3", Roles: []string{"support"}},
+	}
+	type args struct {
+		mappings []*domain.LDAPGroupMapping
+		groups   []string
+	}
+	tests := []struct {
+		name    string
+		queries *mockGrantQueries
+		args    args
+		want    []*command.LDAPUserGrant
+	}{
+		{
+			name:    "no mappings",
+			queries: &mockGrantQueries{},
+			args: args{
+				groups: []string{"cn=admins,dc=example,dc=com"},
+			},
+			want: nil,
+		},
+		{
+			name: "new and existing grants",
+			queries: &mockGrantQueries{
+				projects: map[string]*query.Project{
+					"project1": {ID: "project1", ResourceOwner: "org1"},
+					"project2": {ID: "project2", ResourceOwner: "org2"},
+				},
+				grants: []*query.UserGrant{
+					{ID: "grant3", ProjectID: "project3", ResourceOwner: "org3", Roles: []string{"support"}},
+				},
+			},
+			args: args{
+				mappings: mappings,
+				groups:   []string{"CN=Admins,DC=example,DC=com", "cn=users,dc=example,dc=com"},
+			},
+			want: []*command.LDAPUserGrant{
+				{ProjectID: "project1", ProjectResourceOwner: "org1", Roles: []string{"admin", "user"}},
+				{ProjectID: "project2", ProjectResourceOwner: "org2", Roles: []string{}},
+				{ProjectID: "project3", ProjectResourceOwner: "org3", GrantID: "grant3"},
+			},
+		},
+		{
+			name: "not member of any group",
+			queries: &mockGrantQueries{
+				grants: []*query.UserGrant{
+					{ID: "grant1", ProjectID: "project1", ResourceOwner: "org1", Roles: []string{"admin"}},
+				},
+			},
+			args: args{
+				mappings: mappings,
+			},
+			want: []*command.LDAPUserGrant{
+				{ProjectID: "project1", ProjectResourceOwner: "org1", GrantID: "grant1"},
+				{ProjectID: "project2"},
+				{ProjectID: "project3"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := UserGrants(context.Background(), tt.queries, "user1", tt.args.mappings, tt.args.groups)
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -0,0 +1,54 @@
+package ldapsync
+
+import (
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+// ProviderOptions maps the configuration of the LDAP identity provider to the options of the [ldap.Provider]
+func ProviderOptions(template *query.LDAPIDPTemplate) []ldap.ProviderOpts {
+	var opts []ldap.ProviderOpts
+	if !template.StartTLS {
+		opts = append(opts, ldap.WithoutStartTLS())
+	}
+	if template.LDAPAttributes.IDAttribute != "" {
+		opts = append(opts, ldap.WithCustomIDAttribute(template.LDAPAttributes.IDAttribute))
+	}
+	if template.LDAPAttributes.FirstNameAttribute != "" {
+		opts = append(opts, ldap.WithFirstNameAttribute(template.LDAPAttributes.FirstNameAttribute))
+	}
+	if template.LDAPAttributes.LastNameAttribute != "" {
+		opts = append(opts, ldap.WithLastNameAttribute(template.LDAPAttributes.LastNameAttribute))
+	}
+	if template.LDAPAttributes.DisplayNameAttribute != "" {
+		opts = append(opts, ldap.WithDisplayNameAttribute(template.LDAPAttributes.DisplayNameAttribute))
+	}
+	if template.LDAPAttributes.NickNameAttribute != "" {
+		opts = append(opts, ldap.WithNickNameAttribute(template.LDAPAttributes.NickNameAttribute))
+	}
+	if template.LDAPAttributes.PreferredUsernameAttribute != "" {
+		opts = append(opts, ldap.WithPreferredUsernameAttribute(template.LDAPAttributes.PreferredUsernameAttribute))
+	}
+	if template.LDAPAttributes.EmailAttribute != "" {
+		opts = append(opts, ldap.WithEmailAttribute(template.LDAPAttributes.EmailAttribute))
+	}
+	if template.LDAPAttributes.EmailVerifiedAttribute != "" {
+		opts = append(opts, ldap.WithEmailVerifiedAttribute(template.LDAPAttributes.EmailVerifiedAttribute))
+	}
+	if template.LDAPAttributes.PhoneAttribute != "" {
+		opts = append(opts, ldap.WithPhoneAttribute(template.LDAPAttributes.PhoneAttribute))
+	}
+	if template.LDAPAttributes.PhoneVerifiedAttribute != "" {
+		opts = append(opts, ldap.WithPhoneVerifiedAttribute(template.LDAPAttributes.PhoneVerifiedAttribute))
+	}
+	if template.LDAPAttributes.PreferredLanguageAttribute != "" {
+		opts = append(opts, ldap.WithPreferredLanguageAttribute(template.LDAPAttributes.PreferredLanguageAttribute))
+	}
+	if template.LDAPAttributes.AvatarURLAttribute != "" {
+		opts = append(opts, ldap.WithAvatarURLAttribute(template.LDAPAttributes.AvatarURLAttribute))
+	}
+	if template.LDAPAttributes.ProfileAttribute != "" {
+		opts = append(opts, ldap.WithProfileAttribute(template.LDAPAttributes.ProfileAttribute))
+	}
+	return opts
+}
@@ -0,0 +1,416 @@
+package ldapsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/idp/providers/ldap"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// syncUserID is set as editor of the events pushed by the syncer
+	syncUserID = "LDAP-SYNC"
+)
+
+type Commands interface {
+	StartLDAPSync(ctx context.Context, idpID, resourceOwner string) (*domain.ObjectDetails, error)
+	FinishLDAPSync(ctx context.Context, idpID, resourceOwner string, report *domain.LDAPSyncReport) (*domain.ObjectDetails, error)
+	AddHuman(ctx context.Context, resourceOwner string, human *command.AddHuman, allowInitMail bool) error
+	ChangeHumanProfile(ctx context.Context, profile *domain.Profile) (*domain.Profile, error)
+	ChangeHumanEmail(ctx context.Context, email *domain.Email, emailCodeGenerator crypto.Generator) (*domain.Email, error)
+	ChangeHumanPhone(ctx context.Context, phone *domain.Phone, resourceOwner string, phoneCodeGenerator crypto.Generator) (*domain.Phone, error)
+	DeactivateUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error)
+	ReactivateUser(ctx context.Context, userID, resourceOwner string) (*domain.ObjectDetails, error)
+	SetLDAPUserGrants(ctx context.Context, userID string, grants []*command.LDAPUserGrant) (*command.LDAPUserGrantChanges, error)
+}
+
+type Queries interface {
+	GrantQueries
+	DueLDAPSyncs(ctx context.Context) ([]*query.LDAPSync, error)
+	InstanceByID(ctx context.Context) (authz.Instance, error)
+	IDPTemplateByID(ctx context.Context, shouldTriggerBulk bool, id string, withOwnerRemoved bool, queries ...query.SearchQuery) (*query.IDPTemplate, error)
+	IDPUserLinks(ctx context.Context, queries *query.IDPUserLinksSearchQuery, withOwnerRemoved bool) (*query.IDPUserLinks, error)
+	GetUserByID(ctx context.Context, shouldTriggerBulk bool, userID string, queries ...query.SearchQuery) (*query.User, error)
+	InitEncryptionGenerator(ctx context.Context, generatorType domain.SecretGeneratorType, algorithm crypto.EncryptionAlgorithm) (crypto.Generator, error)
+}
+
+// Start starts the syncer, which periodically synchronises the users of the LDAP identity providers
+// with a sync enabled and reconciles their grants based on the group mappings.
+// The LDAP directory is the source of truth for the existence of the linked users.
+func Start(
+	ctx context.Context,
+	config *Config,
+	commands Commands,
+	queries Queries,
+	idpAlg crypto.EncryptionAlgorithm,
+	userCodeAlg crypto.EncryptionAlgorithm,
+) {
+	if config == nil || !config.Enabled {
+		return
+	}
+	s := &syncer{
+		config:      config,
+		commands:    commands,
+		queries:     queries,
+		idpAlg:      idpAlg,
+		userCodeAlg: userCodeAlg,
+	}
+	go s.schedule(ctx)
+	logging.Info("ldap sync started")
+}
+
+type syncer struct {
+	config      *Config
+	commands    Commands
+	queries     Queries
+	idpAlg      crypto.EncryptionAlgorithm
+	userCodeAlg crypto.EncryptionAlgorithm
+}
+
+func (s *syncer) schedule(ctx context.Context) {
+	ticker := time.NewTicker(s.config.checkInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			s.syncDue(ctx)
+		}
+	}
+}
+
+func (s *syncer) syncDue(ctx context.Context) {
+	syncs, err := s.queries.DueLDAPSyncs(ctx)
+	if err != nil {
+		logging.WithError(err).Warn("unable to query due ldap syncs")
+		return
+	}
+	for _, sync := range syncs {
+		s.sync(ctx, sync)
+	}
+}
+
+func (s *syncer) sync(ctx context.Context, sync *query.LDAPSync) {
+	ctx = authz.WithInstanceID(ctx, sync.InstanceID)
+	instance, err := s.queries.InstanceByID(ctx)
+	if err != nil {
+		logging.WithFields("instance", sync.InstanceID, "idp", sync.IDPID).WithError(err).Warn("unable to get instance for ldap sync")
+		return
+	}
+	ctx = authz.WithInstance(ctx, instance)
+	ctx = setContext(ctx, sync.ResourceOwner)
+
+	// the sync might have been started by another ZITADEL process in the meantime
+	if _, err = s.commands.StartLDAPSync(ctx, sync.IDPID, sync.ResourceOwner); err != nil {
+		logging.WithFields("instance", sync.InstanceID, "idp", sync.IDPID).WithError(err).Debug("ldap sync not started")
+		return
+	}
+	report := &domain.LDAPSyncReport{Started: time.Now()}
+	if err = s.run(ctx, sync, report); err != nil {
+		report.Error = err.Error()
+	}
+	report.Finished = time.Now()
+	_, err = s.commands.FinishLDAPSync(ctx, sync.IDPID, sync.ResourceOwner, report)
+	logging.WithFields("instance", sync.InstanceID, "idp", sync.IDPID).OnError(err).Error("unable to finish ldap sync")
+}
+
+func (s *syncer) run(ctx context.Context, sync *query.LDAPSync, report *domain.LDAPSyncReport) error {
+	template, err := s.queries.IDPTemplateByID(ctx, false, sync.IDPID, false)
+	if err != nil {
+		return err
+	}
+	if template.LDAPIDPTemplate == nil {
+		return errors.ThrowPreconditionFailed(nil, "LDAPS-Eeg3a", "Errors.IDPConfig.NotExisting")
+	}
+	provider, err := s.provider(template, sync.GroupAttribute)
+	if err != nil {
+		return err
+	}
+	links, err := s.userLinks(ctx, sync.IDPID)
+	if err != nil {
+		return err
+	}
+	found := make(map[string]struct{}, len(links))
+	err = provider.SearchUsers(ctx, s.config.pageSize(), func(users []*ldap.User) error {
+		for _, user := range users {
+			report.UsersFound++
+			userID, err := s.syncUser(ctx, template, sync, links[user.GetID()], user, report)
+			if userID != "" {
+				found[userID] = struct{}{}
+			}
+			if err != nil {
+				report.AddFailure(user.GetID(), userID, err)
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	// users are only deactivated if the whole directory could be read
+	for externalID, link := range links {
+		if _, ok := found[link.UserID]; ok {
+			continue
+		}
+		deactivated, err := s.deactivateUser(ctx, link.UserID)
+		if err != nil {
+			report.AddFailure(externalID, link.UserID, err)
+			continue
+		}
+		if deactivated {
+			report.UsersDeactivated++
+		}
+	}
+	return nil
+}
+
+// userLinks returns the links of the users to the identity provider by the id of the LDAP user
+func (s *syncer) userLinks(ctx context.Context, idpID string) (map[string]*query.IDPUserLink, error) {
+	idpQuery, err := query.NewIDPUserLinkIDPIDSearchQuery(idpID)
+	if err != nil {
+		return nil, err
+	}
+	links, err := s.queries.IDPUserLinks(ctx, &query.IDPUserLinksSearchQuery{Queries: []query.SearchQuery{idpQuery}}, false)
+	if err != nil {
+		return nil, err
+	}
+	userLinks := make(map[string]*query.IDPUserLink, len(links.Links))
+	for _, link := range links.Links {
+		userLinks[link.ProvidedUserID] = link
+	}
+	return userLinks, nil
+}
+
+// syncUser creates or updates the user and reconciles the grants,
+// it returns the id of the ZITADEL user, which might be empty if the user was not (auto) created
+func (s *syncer) syncUser(ctx context.Context, template *query.IDPTemplate, sync *query.LDAPSync, link *query.IDPUserLink, ldapUser *ldap.User, report *domain.LDAPSyncReport) (string, error) {
+	var userID string
+	if link == nil {
+		if !template.IsAutoCreation {
+			return "", nil
+		}
+		created, err := s.createUser(ctx, template, ldapUser)
+		if err != nil {
+			return "", err
+		}
+		report.UsersCreated++
+		userID = created
+	} else {
+		userID = link.UserID
+		if err := s.updateUser(ctx, template, userID, ldapUser, report); err != nil {
+			return userID, err
+		}
+	}
+	grants, err := UserGrants(ctx, s.queries, userID, sync.GroupMappings, ldapUser.GetGroups())
+	if err != nil {
+		return userID, err
+	}
+	changes, err := s.commands.SetLDAPUserGrants(ctx, userID, grants)
+	if err != nil {
+		return userID, err
+	}
+	report.GrantsAdded += changes.Added
+	report.GrantsChanged += changes.Changed
+	report.GrantsRemoved += changes.Removed
+	return userID, nil
+}
+
+func (s *syncer) createUser(ctx context.Context, template *query.IDPTemplate, ldapUser *ldap.User) (string, error) {
+	resourceOwner := template.ResourceOwner
+	if template.OwnerType == domain.IdentityProviderTypeSystem {
+		resourceOwner = authz.GetInstance(ctx).DefaultOrganisationID()
+	}
+	username := ldapUser.GetPreferredUsername()
+	if username == "" {
+		username = string(ldapUser.GetEmail())
+	}
+	human := &command.AddHuman{
+		Username:          username,
+		FirstName:         ldapUser.GetFirstName(),
+		LastName:          ldapUser.GetLastName(),
+		NickName:          ldapUser.GetNickname(),
+		DisplayName:       ldapUser.GetDisplayName(),
+		PreferredLanguage: ldapUser.GetPreferredLanguage(),
+		Email: command.Email{
+			Address:  ldapUser.GetEmail(),
+			Verified: ldapUser.IsEmailVerified(),
+		},
+		Phone: command.Phone{
+			Number:   ldapUser.GetPhone(),
+			Verified: ldapUser.IsPhoneVerified(),
+		},
+		ExternalIDP: true,
+		Links: []*command.AddLink{
+			{
+				IDPID:         template.ID,
+				DisplayName:   username,
+				IDPExternalID: ldapUser.GetID(),
+			},
+		},
+	}
+	if err := s.commands.AddHuman(setContext(ctx, resourceOwner), resourceOwner, human, false); err != nil {
+		return "", err
+	}
+	return human.ID, nil
+}
+
+func (s *syncer) updateUser(ctx context.Context, template *query.IDPTemplate, userID string, ldapUser *ldap.User, report *domain.LDAPSyncReport) error {
+	user, err := s.queries.GetUserByID(ctx, false, userID)
+	if err != nil {
+		return err
+	}
+	if user.Human == nil {
+		return errors.ThrowPreconditionFailed(nil, "LDAPS-ohS5i", "Errors.User.NotHuman")
+	}
+	ctx = setContext(ctx, user.ResourceOwner)
+	if user.State == domain.UserStateInactive {
+		if _, err = s.commands.ReactivateUser(ctx, user.ID, user.ResourceOwner); err != nil {
+			return err
+		}
+		report.UsersReactivated++
+	}
+	if !template.IsAutoUpdate {
+		return nil
+	}
+	emailChanged, err := s.updateEmail(ctx, user, ldapUser)
+	if err != nil {
+		return err
+	}
+	phoneChanged, err := s.updatePhone(ctx, user, ldapUser)
+	if err != nil {
+		return err
+	}
+	profileChanged, err := s.updateProfile(ctx, user, ldapUser)
+	if err != nil {
+		return err
+	}
+	if emailChanged || phoneChanged || profileChanged {
+		report.UsersUpdated++
+	}
+	return nil
+}
+
+func (s *syncer) updateEmail(ctx context.Context, user *query.User, ldapUser *ldap.User) (bool, error) {
+	email := ldapUser.GetEmail().Normalize()
+	if email == "" {
+		return false, nil
+	}
+	// ignore if the same email is not set to verified anymore
+	if email == user.Human.Email && (user.Human.IsEmailVerified || !ldapUser.IsEmailVerified()) {
+		return false, nil
+	}
+	emailCodeGenerator, err := s.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyEmailCode, s.userCodeAlg)
+	if err != nil {
+		return false, err
+	}
+	_, err = s.commands.ChangeHumanEmail(ctx,
+		&domain.Email{
+			ObjectRoot:      models.ObjectRoot{AggregateID: user.ID},
+			EmailAddress:    email,
+			IsEmailVerified: ldapUser.IsEmailVerified(),
+		},
+		emailCodeGenerator)
+	return err == nil, err
+}
+
+func (s *syncer) updatePhone(ctx context.Context, user *query.User, ldapUser *ldap.User) (bool, error) {
+	if ldapUser.GetPhone() == "" {
+		return false, nil
+	}
+	phone, err := ldapUser.GetPhone().Normalize()
+	if err != nil {
+		return false, err
+	}
+	// ignore if the same phone is not set to verified anymore
+	if phone == user.Human.Phone && (user.Human.IsPhoneVerified || !ldapUser.IsPhoneVerified()) {
+		return false, nil
+	}
+	phoneCodeGenerator, err := s.queries.InitEncryptionGenerator(ctx, domain.SecretGeneratorTypeVerifyPhoneCode, s.userCodeAlg)
+	if err != nil {
+		return false, err
+	}
+	_, err = s.commands.ChangeHumanPhone(ctx,
+		&domain.Phone{
+			ObjectRoot:      models.ObjectRoot{AggregateID: user.ID},
+			PhoneNumber:     phone,
+			IsPhoneVerified: ldapUser.IsPhoneVerified(),
+		},
+		user.ResourceOwner,
+		phoneCodeGenerator)
+	return err == nil, err
+}
+
+func (s *syncer) updateProfile(ctx context.Context, user *query.User, ldapUser *ldap.User) (bool, error) {
+	if ldapUser.GetFirstName() == user.Human.FirstName &&
+		ldapUser.GetLastName() == user.Human.LastName &&
+		ldapUser.GetNickname() == user.Human.NickName &&
+		ldapUser.GetDisplayName() == user.Human.DisplayName &&
+		ldapUser.GetPreferredLanguage() == user.Human.PreferredLanguage {
+		return false, nil
+	}
+	_, err := s.commands.ChangeHumanProfile(ctx, &domain.Profile{
+		ObjectRoot:        models.ObjectRoot{AggregateID: user.ID},
+		FirstName:         ldapUser.GetFirstName(),
+		LastName:          ldapUser.GetLastName(),
+		NickName:          ldapUser.GetNickname(),
+		DisplayName:       ldapUser.GetDisplayName(),
+		PreferredLanguage: ldapUser.GetPreferredLanguage(),
+		Gender:            user.Human.Gender,
+	})
+	return err == nil, err
+}
+
+// deactivateUser deactivates the user, which is not present in the LDAP directory anymore.
+// It returns false if the user was already inactive.
+func (s *syncer) deactivateUser(ctx context.Context, userID string) (bool, error) {
+	user, err := s.queries.GetUserByID(ctx, false, userID)
+	if err != nil {
+		return false, err
+	}
+	if !user.State.NotDisabled() {
+		return false, nil
+	}
+	_, err = s.commands.DeactivateUser(setContext(ctx, user.ResourceOwner), user.ID, user.ResourceOwner)
+	return err == nil, err
+}
+
+func (s *syncer) provider(template *query.IDPTemplate, groupAttribute string) (*ldap.Provider, error) {
+	password, err := crypto.DecryptString(template.LDAPIDPTemplate.BindPassword, s.idpAlg)
+	if err != nil {
+		return nil, err
+	}
+	opts := ProviderOptions(template.LDAPIDPTemplate)
+	if groupAttribute != "" {
+		opts = append(opts, ldap.WithGroupAttribute(groupAttribute))
+	}
+	return ldap.New(
+		template.Name,
+		template.Servers,
+		template.BaseDN,
+		template.BindDN,
+		password,
+		template.UserBase,
+		template.UserObjectClasses,
+		template.UserFilters,
+		template.Timeout,
+		"",
+		opts...,
+	), nil
+}
+
+func setContext(ctx context.Context, resourceOwner string) context.Context {
+	return authz.SetCtxData(ctx, authz.CtxData{
+		UserID: syncUserID,
+		OrgID:  resourceOwner,
+	})
+}
@@ -1,5 +1,9 @@
 package logstore
 
+import (
+	"time"
+)
+
 type Configs struct {
 	Access    *Config
 	Execution *Config
@@ -7,6 +11,12 @@ type Configs struct {
 
 type Config struct {
 	Stdout *StdConfig
+	// Database stores the records in the database,
+	// it's only used for the executions of actions
+	Database *EmitterConfig
+	// RunsCleanupInterval is the interval in which the stored runs older than the retention of their instance are removed,
+	// it's only used for the executions of actions
+	RunsCleanupInterval time.Duration
 }
 
 type StdConfig struct {
@@ -0,0 +1,189 @@
+package execution
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/logstore"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var _ logstore.LogEmitter[*record.ExecutionLog] = (*databaseRunStorage)(nil)
+
+type RetentionQueries interface {
+	ActionExecutionRetention(ctx context.Context) (time.Duration, error)
+}
+
+// databaseRunStorage stores the runs of the actions so they can be queried per action
+// and periodically removes the runs older than the retention of the instance
+type databaseRunStorage struct {
+	dbClient *database.DB
+	queries  RetentionQueries
+	now      func() time.Time
+}
+
+func NewDatabaseRunStorage(dbClient *database.DB, queries RetentionQueries) *databaseRunStorage {
+	return &databaseRunStorage{dbClient: dbClient, queries: queries, now: time.Now}
+}
+
+func (l *databaseRunStorage) Emit(ctx context.Context, bulk []*record.ExecutionLog) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	runs := make([]*record.ExecutionLog, 0, len(bulk))
+	for _, r := range bulk {
+		// only the runs of stored actions can be queried
+		if r.InstanceID != "" && r.ActionID != "" && r.Run != nil {
+			runs = append(runs, r)
+		}
+	}
+	if len(runs) == 0 {
+		return nil
+	}
+	return l.store(ctx, runs)
+}
+
+func (l *databaseRunStorage) store(ctx context.Context, bulk []*record.ExecutionLog) error {
+	builder := sq.Insert(query.ActionExecutionTable).
+		Columns(
+			query.ActionExecutionInstanceIDCol,
+			query.ActionExecutionIDCol,
+			query.ActionExecutionResourceOwnerCol,
+			query.ActionExecutionActionIDCol,
+			query.ActionExecutionFlowTypeCol,
+			query.ActionExecutionTriggerTypeCol,
+			query.ActionExecutionAggregateTypeCol,
+			query.ActionExecutionAggregateIDCol,
+			query.ActionExecutionSequenceCol,
+			query.ActionExecutionEventTypeCol,
+			query.ActionExecutionLogDateCol,
+			query.ActionExecutionTookCol,
+			query.ActionExecutionSucceededCol,
+			query.ActionExecutionErrorCol,
+			query.ActionExecutionLogsCol,
+		).
+		PlaceholderFormat(sq.Dollar)
+	for _, r := range bulk {
+		logs, err := json.Marshal(r.Run.Logs)
+		if err != nil {
+			return err
+		}
+		// the event is only set on the runs of the event flow
+		var aggregateType, aggregateID, eventType *string
+		var sequence *uint64
+		if event := r.Run.Event; event != nil {
+			aggregateType, aggregateID, sequence, eventType = &event.AggregateType, &event.AggregateID, &event.Sequence, &event.EventType
+		}
+		builder = builder.Values(
+			r.InstanceID,
+			r.Run.ID,
+			r.Run.ResourceOwner,
+			r.ActionID,
+			r.Run.FlowType,
+			r.Run.TriggerType,
+			aggregateType,
+			aggregateID,
+			sequence,
+			eventType,
+			r.Run.Started,
+			r.Took,
+			r.Run.Succeeded,
+			r.Run.Error,
+			logs,
+		)
+	}
+	stmt, args, err := builder.ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = l.dbClient.ExecContext(ctx, stmt, args...)
+	return err
+}
+
+// StartCleanup periodically removes the runs which are older than the retention of their instance,
+// so the removal doesn't slow down the storage of new runs
+func (l *databaseRunStorage) StartCleanup(ctx context.Context, interval time.Duration) {
+	if interval == 0 {
+		return
+	}
+	go l.scheduleCleanup(ctx, interval)
+}
+
+func (l *databaseRunStorage) scheduleCleanup(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			logging.OnError(l.cleanup(ctx)).Warn("unable to remove expired action runs")
+		}
+	}
+}
+
+// cleanup removes the runs of all instances which are older than the retention of the instance
+func (l *databaseRunStorage) cleanup(ctx context.Context) (err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	instanceIDs, err := l.instanceIDs(ctx)
+	if err != nil {
+		return err
+	}
+	for _, instanceID := range instanceIDs {
+		err = errors.Join(err, l.cleanupInstance(authz.WithInstanceID(ctx, instanceID), instanceID))
+	}
+	return err
+}
+
+func (l *databaseRunStorage) instanceIDs(ctx context.Context) ([]string, error) {
+	stmt, args, err := sq.Select(query.ActionExecutionInstanceIDCol).
+		Distinct().
+		From(query.ActionExecutionTable).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return nil, err
+	}
+	instanceIDs := make([]string, 0)
+	err = l.dbClient.QueryContext(ctx, func(rows *sql.Rows) error {
+		for rows.Next() {
+			var instanceID string
+			if err := rows.Scan(&instanceID); err != nil {
+				return err
+			}
+			instanceIDs = append(instanceIDs, instanceID)
+		}
+		return rows.Err()
+	}, stmt, args...)
+	return instanceIDs, err
+}
+
+// cleanupInstance removes the runs of the instance which are older than its retention
+func (l *databaseRunStorage) cleanupInstance(ctx context.Context, instanceID string) error {
+	retention, err := l.queries.ActionExecutionRetention(ctx)
+	if err != nil || retention == 0 {
+		return err
+	}
+	stmt, args, err := sq.Delete(query.ActionExecutionTable).
+		Where(sq.Eq{query.ActionExecutionInstanceIDCol: instanceID}).
+		Where(sq.Lt{query.ActionExecutionLogDateCol: l.now().Add(-retention)}).
+		PlaceholderFormat(sq.Dollar).
+		ToSql()
+	if err != nil {
+		return err
+	}
+	_, err = l.dbClient.ExecContext(ctx, stmt, args...)
+	return err
+}
@@ -4,6 +4,13 @@ import (
 	"time"
 
 	"github.com/sirupsen/logrus"
+
+	"github.com/zitadel/zitadel/internal/domain"
+)
+
+const (
+	maxRunLogs       = 100
+	maxMessageLength = 2000
 )
 
 type ExecutionLog struct {
@@ -14,9 +21,57 @@ type ExecutionLog struct {
 	InstanceID string                 `json:"instanceId"`
 	ActionID   string                 `json:"actionId,omitempty"`
 	Metadata   map[string]interface{} `json:"metadata,omitempty"`
+	// Run is only set on the last record of an action run
+	Run *ExecutionRun `json:"run,omitempty"`
+}
+
+// ExecutionRun describes a single run of an action
+type ExecutionRun struct {
+	ID            string             `json:"id"`
+	ResourceOwner string             `json:"resourceOwner"`
+	FlowType      domain.FlowType    `json:"flowType"`
+	TriggerType   domain.TriggerType `json:"triggerType"`
+	// Event is only set on the runs of the event flow
+	Event     *ExecutionRunEvent `json:"event,omitempty"`
+	Started   time.Time          `json:"started"`
+	Succeeded bool               `json:"succeeded"`
+	Error     string             `json:"error,omitempty"`
+	// Logs contains the output of the log module (console.log) of the action
+	Logs []*ExecutionRunLog `json:"logs,omitempty"`
+}
+
+// ExecutionRunEvent is the event an action of the event flow ran on
+type ExecutionRunEvent struct {
+	AggregateType string `json:"aggregateType"`
+	AggregateID   string `json:"aggregateId"`
+	Sequence      uint64 `json:"sequence"`
+	EventType     string `json:"eventType"`
+}
+
+type ExecutionRunLog struct {
+	LogDate  time.Time    `json:"logDate"`
+	LogLevel logrus.Level `json:"logLevel"`
+	Message  string       `json:"message"`
 }
 
 func (e ExecutionLog) Normalize() *ExecutionLog {
-	e.Message = cutString(e.Message, 2000)
+	e.Message = cutString(e.Message, maxMessageLength)
+	if e.Run != nil {
+		run := *e.Run
+		run.Error = cutString(run.Error, maxMessageLength)
+		if len(run.Logs) > maxRunLogs {
+			run.Logs = run.Logs[:maxRunLogs]
+		}
+		logs := make([]*ExecutionRunLog, len(run.Logs))
+		for i, log := range run.Logs {
+			logs[i] = &ExecutionRunLog{
+				LogDate:  log.LogDate,
+				LogLevel: log.LogLevel,
+				Message:  cutString(log.Message, maxMessageLength),
+			}
+		}
+		run.Logs = logs
+		e.Run = &run
+	}
 	return &e
 }
@@ -35,6 +35,20 @@ func (m *MockCommands) EXPECT() *MockCommandsMockRecorder {
 	return m.recorder
 }
 
+// BackChannelAuthUserNotified mocks base method
+func (m *MockCommands) BackChannelAuthUserNotified(arg0 context.Context, arg1 string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BackChannelAuthUserNotified", arg0, arg1)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// BackChannelAuthUserNotified indicates an expected call of BackChannelAuthUserNotified
+func (mr *MockCommandsMockRecorder) BackChannelAuthUserNotified(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BackChannelAuthUserNotified", reflect.TypeOf((*MockCommands)(nil).BackChannelAuthUserNotified), arg0, arg1)
+}
+
 // HumanEmailVerificationCodeSent mocks base method
 func (m *MockCommands) HumanEmailVerificationCodeSent(arg0 context.Context, arg1, arg2 string) error {
 	m.ctrl.T.Helper()
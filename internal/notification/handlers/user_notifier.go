@@ -2,6 +2,7 @@ package handlers
 
 import (
 	"context"
+	"net/http"
 	"strings"
 	"time"
 
@@ -12,8 +13,10 @@ import (
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/notification/channels/webhook"
 	"github.com/zitadel/zitadel/internal/notification/types"
 	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
 	"github.com/zitadel/zitadel/internal/repository/session"
 	"github.com/zitadel/zitadel/internal/repository/user"
 )
@@ -121,6 +124,15 @@ func (u *userNotifier) Reducers() []handler.AggregateReducer {
 				},
 			},
 		},
+		{
+			Aggregate: backchannelauth.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  backchannelauth.AddedEventType,
+					Reduce: u.reduceBackChannelAuthAdded,
+				},
+			},
+		},
 	}
 }
 
@@ -718,6 +730,64 @@ func (u *userNotifier) reducePhoneCodeAdded(event eventstore.Event) (*handler.St
 	}), nil
 }
 
+func (u *userNotifier) reduceBackChannelAuthAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*backchannelauth.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Oonu7", "reduce.wrong.event.type %s", backchannelauth.AddedEventType)
+	}
+	if e.Expires.Before(time.Now()) {
+		return handler.NewNoOpStatement(e), nil
+	}
+
+	return handler.NewStatement(event, func(ex handler.Executer, projectionName string) error {
+		ctx := HandlerContext(event.Aggregate())
+		alreadyHandled, err := u.queries.IsAlreadyHandled(ctx, event, nil, backchannelauth.AggregateType, backchannelauth.UserNotifiedEventType)
+		if err != nil {
+			return err
+		}
+		if alreadyHandled {
+			return nil
+		}
+		notifyUser, err := u.queries.GetNotifyUserByID(ctx, true, e.UserID)
+		if err != nil {
+			return err
+		}
+		colors, err := u.queries.ActiveLabelPolicyByOrg(ctx, notifyUser.ResourceOwner, false)
+		if err != nil {
+			return err
+		}
+		translator, err := u.queries.GetTranslatorWithOrgTexts(ctx, notifyUser.ResourceOwner, domain.BackChannelAuthMessageType)
+		if err != nil {
+			return err
+		}
+		ctx, err = u.queries.Origin(ctx, e)
+		if err != nil {
+			return err
+		}
+		switch {
+		case e.Notification == domain.BackChannelAuthNotificationWebhook:
+			err = types.SendJSON(ctx, webhook.Config{CallURL: e.WebhookURL, Method: http.MethodPost}, u.channels,
+				types.NewBackChannelAuthWebhookPayload(ctx, notifyUser, e.Aggregate().ID, e.BindingMessage, e.Expires), e,
+			).WithoutTemplate()
+		// users without a verified phone are notified by email instead
+		case e.Notification == domain.BackChannelAuthNotificationSMS && notifyUser.VerifiedPhone != "":
+			err = types.SendSMSTwilio(ctx, u.channels, translator, notifyUser, colors, e).
+				SendBackChannelAuth(ctx, e.Aggregate().ID, e.BindingMessage)
+		default:
+			template, templateErr := u.queries.MailTemplateByOrg(ctx, notifyUser.ResourceOwner, false)
+			if templateErr != nil {
+				return templateErr
+			}
+			err = types.SendEmail(ctx, u.channels, string(template.Template), translator, notifyUser, colors, e).
+				SendBackChannelAuth(ctx, e.Aggregate().ID, e.BindingMessage)
+		}
+		if err != nil {
+			return err
+		}
+		return u.commands.BackChannelAuthUserNotified(ctx, e.Aggregate().ID)
+	}), nil
+}
+
 func (u *userNotifier) checkIfCodeAlreadyHandledOrExpired(ctx context.Context, event eventstore.Event, expiry time.Duration, data map[string]interface{}, eventTypes ...eventstore.EventType) (bool, error) {
 	if event.CreatedAt().Add(expiry).Before(time.Now().UTC()) {
 		return true, nil
@@ -0,0 +1,39 @@
+package types
+
+import (
+	"context"
+	"time"
+
+	http_utils "github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/api/ui/login"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+func (notify Notify) SendBackChannelAuth(ctx context.Context, id, bindingMessage string) error {
+	url := login.BackChannelAuthLink(http_utils.ComposedOrigin(ctx), id)
+	args := make(map[string]interface{})
+	args["BindingMessage"] = bindingMessage
+	args["URL"] = url
+	return notify(url, args, domain.BackChannelAuthMessageType, false)
+}
+
+// BackChannelAuthWebhookPayload is sent to the configured webhook,
+// which is expected to push the login request to the user's device.
+type BackChannelAuthWebhookPayload struct {
+	UserID         string    `json:"userId"`
+	LoginName      string    `json:"loginName"`
+	BindingMessage string    `json:"bindingMessage,omitempty"`
+	URL            string    `json:"url"`
+	Expires        time.Time `json:"expires"`
+}
+
+func NewBackChannelAuthWebhookPayload(ctx context.Context, user *query.NotifyUser, id, bindingMessage string, expires time.Time) *BackChannelAuthWebhookPayload {
+	return &BackChannelAuthWebhookPayload{
+		UserID:         user.ID,
+		LoginName:      user.PreferredLoginName,
+		BindingMessage: bindingMessage,
+		URL:            login.BackChannelAuthLink(http_utils.ComposedOrigin(ctx), id),
+		Expires:        expires,
+	}
+}
@@ -0,0 +1,14 @@
+package provisioning
+
+import (
+	"time"
+)
+
+// Config of the provisioning.
+// Failed deliveries are retried by the handler, see RetryFailedAfter and MaxFailureCount
+// of the projection customization.
+type Config struct {
+	Enabled bool
+	// Timeout of a single request to the service provider
+	Timeout time.Duration
+}
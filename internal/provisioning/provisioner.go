@@ -0,0 +1,316 @@
+package provisioning
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/delivery"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+const (
+	// ProjectionName is used to store the position of the provisioner in the current states
+	// and the events which could not be reduced in the failed events.
+	// The deliveries are logged in the projection.AppProvisioningLogTable.
+	ProjectionName = projection.AppProvisioningDeliveryHandlerName
+)
+
+type Queries interface {
+	AppProvisioningsByProjectID(ctx context.Context, shouldTriggerBulk bool, projectID string) (*query.AppProvisionings, error)
+	UserGrants(ctx context.Context, queries *query.UserGrantsQueries, shouldTriggerBulk, withOwnerRemoved bool) (*query.UserGrants, error)
+}
+
+type eventFilter interface {
+	Filter(ctx context.Context, searchQuery *eventstore.SearchQueryBuilder) ([]eventstore.Event, error)
+}
+
+// Start starts the provisioner, which delivers the users granted on a project
+// to the service providers of the applications of the project with a provisioning configured.
+func Start(
+	ctx context.Context,
+	config *Config,
+	handlerCustomConfig projection.CustomConfig,
+	queries Queries,
+	es *eventstore.Eventstore,
+	tokenAlg crypto.EncryptionAlgorithm,
+) {
+	if config == nil || !config.Enabled {
+		return
+	}
+	handlerConfig := projection.ApplyCustomConfig(handlerCustomConfig)
+	handler.NewHandler(ctx, &handlerConfig, newProvisioner(config, queries, es, tokenAlg, handlerConfig.MaxFailureCount)).Start(ctx)
+	logging.Info("app provisioning started")
+}
+
+type provisioner struct {
+	queries         Queries
+	es              eventFilter
+	tokenAlg        crypto.EncryptionAlgorithm
+	client          *http.Client
+	maxFailureCount uint8
+}
+
+func newProvisioner(config *Config, queries Queries, es eventFilter, tokenAlg crypto.EncryptionAlgorithm, maxFailureCount uint8) *provisioner {
+	return &provisioner{
+		queries:         queries,
+		es:              es,
+		tokenAlg:        tokenAlg,
+		client:          &http.Client{Timeout: config.Timeout},
+		maxFailureCount: maxFailureCount,
+	}
+}
+
+func (p *provisioner) Name() string {
+	return ProjectionName
+}
+
+func (p *provisioner) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: usergrant.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  usergrant.UserGrantAddedType,
+					Reduce: p.reduceGrantAdded,
+				},
+				{
+					Event:  usergrant.UserGrantReactivatedType,
+					Reduce: p.reduceGrantChanged(domain.AppProvisioningOperationUpdate),
+				},
+				{
+					Event:  usergrant.UserGrantDeactivatedType,
+					Reduce: p.reduceGrantChanged(domain.AppProvisioningOperationDeactivate),
+				},
+				{
+					Event:  usergrant.UserGrantRemovedType,
+					Reduce: p.reduceGrantChanged(domain.AppProvisioningOperationDelete),
+				},
+				{
+					Event:  usergrant.UserGrantCascadeRemovedType,
+					Reduce: p.reduceGrantChanged(domain.AppProvisioningOperationDelete),
+				},
+			},
+		},
+		{
+			Aggregate: user.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  user.UserUserNameChangedType,
+					Reduce: p.reduceUserChanged,
+				},
+				{
+					Event:  user.HumanProfileChangedType,
+					Reduce: p.reduceUserChanged,
+				},
+				{
+					Event:  user.HumanEmailChangedType,
+					Reduce: p.reduceUserChanged,
+				},
+				{
+					Event:  user.HumanPhoneChangedType,
+					Reduce: p.reduceUserChanged,
+				},
+				{
+					Event:  user.HumanPhoneRemovedType,
+					Reduce: p.reduceUserChanged,
+				},
+				{
+					Event:  user.UserDeactivatedType,
+					Reduce: p.reduceUserChanged,
+				},
+				{
+					Event:  user.UserReactivatedType,
+					Reduce: p.reduceUserChanged,
+				},
+			},
+		},
+	}
+}
+
+func (p *provisioner) reduceGrantAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*usergrant.UserGrantAddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROV-Eis6o", "reduce.wrong.event.type %s", usergrant.UserGrantAddedType)
+	}
+	ctx := eventContext(e)
+	return p.provision(ctx, e, e.UserID, []string{e.ProjectID}, domain.AppProvisioningOperationCreate)
+}
+
+// reduceGrantChanged handles the events of the user grant, which don't contain the user and the project.
+// They are taken from the added event of the grant.
+func (p *provisioner) reduceGrantChanged(operation domain.AppProvisioningOperation) handler.Reduce {
+	return func(event eventstore.Event) (*handler.Statement, error) {
+		ctx := eventContext(event)
+		grant, err := p.grantAdded(ctx, event.Aggregate())
+		if err != nil {
+			return nil, err
+		}
+		if grant == nil {
+			return handler.NewNoOpStatement(event), nil
+		}
+		return p.provision(ctx, event, grant.UserID, []string{grant.ProjectID}, operation)
+	}
+}
+
+// reduceUserChanged updates the user on all applications of the projects the user is granted on
+func (p *provisioner) reduceUserChanged(event eventstore.Event) (*handler.Statement, error) {
+	ctx := eventContext(event)
+	userIDQuery, err := query.NewUserGrantUserIDSearchQuery(event.Aggregate().ID)
+	if err != nil {
+		return nil, err
+	}
+	grants, err := p.queries.UserGrants(ctx, &query.UserGrantsQueries{Queries: []query.SearchQuery{userIDQuery}}, false, false)
+	if err != nil {
+		return nil, err
+	}
+	projectIDs := make([]string, 0, len(grants.UserGrants))
+	for _, grant := range grants.UserGrants {
+		if grant.State != domain.UserGrantStateActive || containsString(projectIDs, grant.ProjectID) {
+			continue
+		}
+		projectIDs = append(projectIDs, grant.ProjectID)
+	}
+	return p.provision(ctx, event, event.Aggregate().ID, projectIDs, domain.AppProvisioningOperationUpdate)
+}
+
+// provision delivers the user to all applications of the projects with a provisioning configured
+// and logs the result of each delivery.
+// Failed deliveries are retried by the handler, the result of the last attempt is logged.
+func (p *provisioner) provision(ctx context.Context, event eventstore.Event, userID string, projectIDs []string, operation domain.AppProvisioningOperation) (*handler.Statement, error) {
+	provisionings := make([]*query.AppProvisioning, 0)
+	for _, projectID := range projectIDs {
+		projectProvisionings, err := p.queries.AppProvisioningsByProjectID(ctx, true, projectID)
+		if err != nil {
+			return nil, err
+		}
+		provisionings = append(provisionings, projectProvisionings.AppProvisionings...)
+	}
+	if len(provisionings) == 0 {
+		return handler.NewNoOpStatement(event), nil
+	}
+	var resource *User
+	if operation == domain.AppProvisioningOperationCreate || operation == domain.AppProvisioningOperationUpdate {
+		var err error
+		resource, err = p.userAt(ctx, userID, event)
+		if err != nil {
+			return nil, err
+		}
+		// only existing human users are provisioned
+		if resource == nil {
+			return handler.NewNoOpStatement(event), nil
+		}
+	}
+
+	targets := make([]*delivery.Target, len(provisionings))
+	for i, provisioning := range provisionings {
+		provisioning := provisioning
+		targets[i] = &delivery.Target{
+			Fields: []interface{}{"app", provisioning.AppID, "user", userID},
+			Send: func(ctx context.Context) error {
+				return p.deliver(ctx, provisioning, operation, userID, resource)
+			},
+			Done: func(_ context.Context, attempts uint8, err error) (handler.Exec, error) {
+				return addLogStatement(event, provisioning, userID, operation, attempts, err)(event), nil
+			},
+		}
+	}
+	// the log table is created by the app provisioning projection and not named after the provisioner
+	return delivery.NewStatement(ctx, event, p.maxFailureCount, projection.AppProvisioningLogTable, targets...), nil
+}
+
+// deliver calls the service provider
+func (p *provisioner) deliver(ctx context.Context, provisioning *query.AppProvisioning, operation domain.AppProvisioningOperation, userID string, resource *User) error {
+	token, err := crypto.DecryptString(provisioning.Token, p.tokenAlg)
+	if err != nil {
+		return err
+	}
+	client := newSCIMClient(p.client, provisioning.Endpoint, token)
+	switch operation {
+	case domain.AppProvisioningOperationCreate, domain.AppProvisioningOperationUpdate:
+		// the resource is shared between the applications and must not be changed by the client
+		user := *resource
+		return client.upsert(ctx, &user)
+	case domain.AppProvisioningOperationDeactivate:
+		return client.deactivate(ctx, userID)
+	case domain.AppProvisioningOperationDelete:
+		return client.delete(ctx, userID)
+	}
+	return nil
+}
+
+func addLogStatement(event eventstore.Event, provisioning *query.AppProvisioning, userID string, operation domain.AppProvisioningOperation, attempts uint8, err error) func(eventstore.Event) handler.Exec {
+	var deliveryErr *string
+	if err != nil {
+		message := err.Error()
+		deliveryErr = &message
+	}
+	return handler.AddUpsertStatement(
+		[]handler.Column{
+			handler.NewCol(projection.AppProvisioningLogColumnInstanceID, nil),
+			handler.NewCol(projection.AppProvisioningLogColumnAppID, nil),
+			handler.NewCol(projection.AppProvisioningLogColumnAggregateID, nil),
+			handler.NewCol(projection.AppProvisioningLogColumnSequence, nil),
+		},
+		[]handler.Column{
+			handler.NewCol(projection.AppProvisioningLogColumnInstanceID, event.Aggregate().InstanceID),
+			handler.NewCol(projection.AppProvisioningLogColumnAppID, provisioning.AppID),
+			handler.NewCol(projection.AppProvisioningLogColumnProjectID, provisioning.ProjectID),
+			handler.NewCol(projection.AppProvisioningLogColumnResourceOwner, provisioning.ResourceOwner),
+			handler.NewCol(projection.AppProvisioningLogColumnUserID, userID),
+			handler.NewCol(projection.AppProvisioningLogColumnAggregateID, event.Aggregate().ID),
+			handler.NewCol(projection.AppProvisioningLogColumnSequence, event.Sequence()),
+			handler.NewCol(projection.AppProvisioningLogColumnEventType, event.Type()),
+			handler.NewCol(projection.AppProvisioningLogColumnCreationDate, event.CreatedAt()),
+			handler.NewCol(projection.AppProvisioningLogColumnOperation, operation),
+			handler.NewCol(projection.AppProvisioningLogColumnSucceeded, err == nil),
+			handler.NewCol(projection.AppProvisioningLogColumnAttempts, uint16(attempts)),
+			handler.NewCol(projection.AppProvisioningLogColumnError, deliveryErr),
+		},
+	)
+}
+
+// grantAdded returns the added event of the user grant, which contains the user and the project
+func (p *provisioner) grantAdded(ctx context.Context, aggregate *eventstore.Aggregate) (*usergrant.UserGrantAddedEvent, error) {
+	events, err := p.es.Filter(ctx, eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(aggregate.InstanceID).
+		AddQuery().
+		AggregateTypes(usergrant.AggregateType).
+		AggregateIDs(aggregate.ID).
+		EventTypes(usergrant.UserGrantAddedType).
+		Builder(),
+	)
+	if err != nil {
+		return nil, err
+	}
+	for _, event := range events {
+		if added, ok := event.(*usergrant.UserGrantAddedEvent); ok {
+			return added, nil
+		}
+	}
+	return nil, nil
+}
+
+func eventContext(event eventstore.Event) context.Context {
+	return authz.WithInstanceID(call.WithTimestamp(context.Background()), event.Aggregate().InstanceID)
+}
+
+func containsString(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,315 @@
+package provisioning
+
+import (
+	"context"
+	"database/sql"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/query"
+	"github.com/zitadel/zitadel/internal/repository/user"
+	"github.com/zitadel/zitadel/internal/repository/usergrant"
+)
+
+type mockQueries struct {
+	provisionings map[string][]*query.AppProvisioning
+	grants        []*query.UserGrant
+}
+
+func (q *mockQueries) AppProvisioningsByProjectID(_ context.Context, _ bool, projectID string) (*query.AppProvisionings, error) {
+	return &query.AppProvisionings{AppProvisionings: q.provisionings[projectID]}, nil
+}
+
+func (q *mockQueries) UserGrants(context.Context, *query.UserGrantsQueries, bool, bool) (*query.UserGrants, error) {
+	return &query.UserGrants{UserGrants: q.grants}, nil
+}
+
+type mockFilter []eventstore.Event
+
+func (f mockFilter) Filter(_ context.Context, builder *eventstore.SearchQueryBuilder) ([]eventstore.Event, error) {
+	events := make([]eventstore.Event, 0, len(f))
+	for _, event := range f {
+		if builder.Matches(event, len(events)) {
+			events = append(events, event)
+		}
+	}
+	return events, nil
+}
+
+type execution struct {
+	stmt string
+	args []interface{}
+}
+
+type mockExecuter struct {
+	executions []execution
+}
+
+func (ex *mockExecuter) Exec(stmt string, args ...interface{}) (sql.Result, error) {
+	if stmt != "SAVEPOINT stmt_exec" && stmt != "RELEASE SAVEPOINT stmt_exec" {
+		ex.executions = append(ex.executions, execution{stmt: stmt, args: args})
+	}
+	return nil, nil
+}
+
+func grantAggregate() *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            "grant1",
+		Type:          usergrant.AggregateType,
+		ResourceOwner: "org1",
+		InstanceID:    "instance1",
+	}
+}
+
+func grantAddedEvent() *usergrant.UserGrantAddedEvent {
+	return &usergrant.UserGrantAddedEvent{
+		BaseEvent: eventstore.BaseEvent{
+			Agg:       grantAggregate(),
+			Seq:       2,
+			Pos:       2,
+			EventType: usergrant.UserGrantAddedType,
+		},
+		UserID:    "user1",
+		ProjectID: "project1",
+	}
+}
+
+func userAggregate() *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            "user1",
+		Type:          user.AggregateType,
+		ResourceOwner: "org1",
+		InstanceID:    "instance1",
+	}
+}
+
+func humanAddedEvent() *user.HumanAddedEvent {
+	return &user.HumanAddedEvent{
+		BaseEvent: eventstore.BaseEvent{
+			Agg:       userAggregate(),
+			Seq:       1,
+			Pos:       1,
+			EventType: user.HumanAddedType,
+		},
+		UserName:     "gigi",
+		FirstName:    "Gigi",
+		LastName:     "Giraffe",
+		EmailAddress: "gigi@zitadel.com",
+	}
+}
+
+func testQueries(endpoint string) *mockQueries {
+	return &mockQueries{
+		provisionings: map[string][]*query.AppProvisioning{
+			"project1": {
+				{
+					AppID:         "app1",
+					ProjectID:     "project1",
+					ResourceOwner: "org1",
+					Endpoint:      endpoint,
+					Token: &crypto.CryptoValue{
+						CryptoType: crypto.TypeEncryption,
+						Algorithm:  "enc",
+						KeyID:      "id",
+						Crypted:    []byte("token"),
+					},
+				},
+			},
+		},
+	}
+}
+
+func Test_provisioner_reduceGrantAdded(t *testing.T) {
+	sp, server := newServiceProvider()
+	defer server.Close()
+	p := newProvisioner(&Config{}, testQueries(server.URL), mockFilter{humanAddedEvent()}, crypto.CreateMockEncryptionAlg(gomock.NewController(t)), 1)
+
+	stmt, err := p.reduceGrantAdded(grantAddedEvent())
+	require.NoError(t, err)
+	ex := new(mockExecuter)
+	require.NoError(t, stmt.Execute(ex, ProjectionName))
+
+	assert.Equal(t, &User{
+		Schemas:    []string{schemaUser},
+		ID:         "sp-user1",
+		ExternalID: "user1",
+		UserName:   "gigi",
+		Name:       &Name{Formatted: "Gigi Giraffe", FamilyName: "Giraffe", GivenName: "Gigi"},
+		Active:     true,
+		Emails:     []*MultiValued{{Value: "gigi@zitadel.com", Primary: true}},
+	}, sp.users["sp-user1"])
+	require.Len(t, ex.executions, 1)
+	assert.Equal(t, "INSERT INTO projections.app_provisionings_logs (instance_id, app_id, project_id, resource_owner, user_id, aggregate_id, sequence, event_type, creation_date, operation, succeeded, attempts, error)"+
+		" VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13)"+
+		" ON CONFLICT (instance_id, app_id, aggregate_id, sequence) DO UPDATE SET (project_id, resource_owner, user_id, event_type, creation_date, operation, succeeded, attempts, error)"+
+		" = (EXCLUDED.project_id, EXCLUDED.resource_owner, EXCLUDED.user_id, EXCLUDED.event_type, EXCLUDED.creation_date, EXCLUDED.operation, EXCLUDED.succeeded, EXCLUDED.attempts, EXCLUDED.error)",
+		ex.executions[0].stmt,
+	)
+	assert.Equal(t, []interface{}{
+		"instance1",
+		"app1",
+		"project1",
+		"org1",
+		"user1",
+		"grant1",
+		uint64(2),
+		usergrant.UserGrantAddedType,
+		time.Time{},
+		domain.AppProvisioningOperationCreate,
+		true,
+		uint16(1),
+		(*string)(nil),
+	}, ex.executions[0].args)
+}
+
+func Test_provisioner_reduceGrantAdded_retry(t *testing.T) {
+	sp, server := newServiceProvider()
+	defer server.Close()
+	sp.failures = 1
+	p := newProvisioner(&Config{}, testQueries(server.URL), mockFilter{humanAddedEvent()}, crypto.CreateMockEncryptionAlg(gomock.NewController(t)), 2)
+
+	stmt, err := p.reduceGrantAdded(grantAddedEvent())
+	require.NoError(t, err)
+	ex := new(mockExecuter)
+	// the handler retries the statement until the max failure count is reached
+	require.Error(t, stmt.Execute(ex, ProjectionName))
+
+	assert.Empty(t, sp.users)
+	assert.Equal(t, []string{"GET /Users"}, sp.requests)
+	assert.Empty(t, ex.executions)
+}
+
+func Test_provisioner_reduceGrantAdded_lastAttempt(t *testing.T) {
+	sp, server := newServiceProvider()
+	defer server.Close()
+	sp.failures = 1
+	p := newProvisioner(&Config{}, testQueries(server.URL), mockFilter{humanAddedEvent()}, crypto.CreateMockEncryptionAlg(gomock.NewController(t)), 1)
+
+	stmt, err := p.reduceGrantAdded(grantAddedEvent())
+	require.NoError(t, err)
+	ex := new(mockExecuter)
+	require.NoError(t, stmt.Execute(ex, ProjectionName))
+
+	assert.Empty(t, sp.users)
+	assert.Equal(t, []string{"GET /Users"}, sp.requests)
+	require.Len(t, ex.executions, 1)
+	args := ex.executions[0].args
+	assert.Equal(t, false, args[10])
+	assert.Equal(t, uint16(1), args[11])
+	assert.NotNil(t, args[12])
+}
+
+func Test_provisioner_reduceGrantChanged(t *testing.T) {
+	sp, server := newServiceProvider(&User{ID: "sp-user1", ExternalID: "user1", UserName: "gigi", Active: true})
+	defer server.Close()
+	p := newProvisioner(&Config{}, testQueries(server.URL), mockFilter{grantAddedEvent()}, crypto.CreateMockEncryptionAlg(gomock.NewController(t)), 1)
+
+	removed := &usergrant.UserGrantRemovedEvent{
+		BaseEvent: eventstore.BaseEvent{
+			Agg:       grantAggregate(),
+			Seq:       3,
+			EventType: usergrant.UserGrantRemovedType,
+		},
+	}
+	stmt, err := p.reduceGrantChanged(domain.AppProvisioningOperationDelete)(removed)
+	require.NoError(t, err)
+	ex := new(mockExecuter)
+	require.NoError(t, stmt.Execute(ex, ProjectionName))
+
+	assert.Empty(t, sp.users)
+	require.Len(t, ex.executions, 1)
+	assert.Equal(t, domain.AppProvisioningOperationDelete, ex.executions[0].args[9])
+	assert.Equal(t, true, ex.executions[0].args[10])
+}
+
+func Test_provisioner_reduceUserChanged_noProvisioning(t *testing.T) {
+	queries := testQueries("")
+	queries.grants = []*query.UserGrant{{ProjectID: "project2", State: domain.UserGrantStateActive}}
+	p := newProvisioner(&Config{}, queries, nil, nil, 1)
+
+	event := &eventstore.BaseEvent{
+		Agg:       &eventstore.Aggregate{ID: "user1", Type: "user", InstanceID: "instance1"},
+		Seq:       4,
+		EventType: "user.human.profile.changed",
+	}
+	stmt, err := p.reduceUserChanged(event)
+	require.NoError(t, err)
+	assert.Nil(t, stmt.Execute, "no op statement expected")
+}
+
+func Test_provisioner_reduceGrantAdded_userAtEvent(t *testing.T) {
+	sp, server := newServiceProvider()
+	defer server.Close()
+	// the changes after the event must not be delivered, the user is removed later
+	changed := &user.HumanEmailChangedEvent{
+		BaseEvent: eventstore.BaseEvent{
+			Agg:       userAggregate(),
+			Seq:       2,
+			Pos:       3,
+			EventType: user.HumanEmailChangedType,
+		},
+		EmailAddress: "giraffe@zitadel.com",
+	}
+	removed := &user.UserRemovedEvent{
+		BaseEvent: eventstore.BaseEvent{
+			Agg:       userAggregate(),
+			Seq:       3,
+			Pos:       4,
+			EventType: user.UserRemovedType,
+		},
+	}
+	p := newProvisioner(&Config{}, testQueries(server.URL), mockFilter{humanAddedEvent(), changed, removed}, crypto.CreateMockEncryptionAlg(gomock.NewController(t)), 1)
+
+	stmt, err := p.reduceGrantAdded(grantAddedEvent())
+	require.NoError(t, err)
+	ex := new(mockExecuter)
+	require.NoError(t, stmt.Execute(ex, ProjectionName))
+
+	require.Contains(t, sp.users, "sp-user1")
+	assert.Equal(t, []*MultiValued{{Value: "gigi@zitadel.com", Primary: true}}, sp.users["sp-user1"].Emails)
+}
+
+func Test_provisioner_reduceGrantAdded_userNotExisting(t *testing.T) {
+	p := newProvisioner(&Config{}, testQueries(""), mockFilter{}, nil, 1)
+
+	stmt, err := p.reduceGrantAdded(grantAddedEvent())
+	require.NoError(t, err)
+	assert.Nil(t, stmt.Execute, "no op statement expected")
+}
+
+func Test_provisioner_reduceUserChanged_removed(t *testing.T) {
+	queries := testQueries("")
+	queries.grants = []*query.UserGrant{{ProjectID: "project1", State: domain.UserGrantStateActive}}
+	changed := &user.HumanProfileChangedEvent{
+		BaseEvent: eventstore.BaseEvent{
+			Agg:       userAggregate(),
+			Seq:       2,
+			EventType: user.HumanProfileChangedType,
+		},
+		FirstName: "Gigi",
+	}
+	removed := &user.UserRemovedEvent{
+		BaseEvent: eventstore.BaseEvent{
+			Agg:       userAggregate(),
+			Seq:       3,
+			EventType: user.UserRemovedType,
+		},
+	}
+	p := newProvisioner(&Config{}, queries, mockFilter{humanAddedEvent(), changed, removed}, nil, 1)
+
+	// a user removed before the provisioner processes the change is still delivered as of the change
+	stmt, err := p.reduceUserChanged(changed)
+	require.NoError(t, err)
+	assert.NotNil(t, stmt.Execute)
+
+	stmt, err = p.reduceUserChanged(removed)
+	require.NoError(t, err)
+	assert.Nil(t, stmt.Execute, "no op statement expected")
+}
@@ -0,0 +1,175 @@
+package provisioning
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	schemaUser      = "urn:ietf:params:scim:schemas:core:2.0:User"
+	schemaPatchOp   = "urn:ietf:params:scim:api:messages:2.0:PatchOp"
+	scimContentType = "application/scim+json"
+)
+
+// User is the SCIM representation (RFC 7643, section 4.1) of a user sent to the service provider.
+// The ZITADEL user id is sent as externalId, which is used to find the user on subsequent deliveries.
+type User struct {
+	Schemas           []string       `json:"schemas"`
+	ID                string         `json:"id,omitempty"`
+	ExternalID        string         `json:"externalId"`
+	UserName          string         `json:"userName"`
+	Name              *Name          `json:"name,omitempty"`
+	DisplayName       string         `json:"displayName,omitempty"`
+	NickName          string         `json:"nickName,omitempty"`
+	PreferredLanguage string         `json:"preferredLanguage,omitempty"`
+	Active            bool           `json:"active"`
+	Emails            []*MultiValued `json:"emails,omitempty"`
+	PhoneNumbers      []*MultiValued `json:"phoneNumbers,omitempty"`
+}
+
+type Name struct {
+	Formatted  string `json:"formatted,omitempty"`
+	FamilyName string `json:"familyName,omitempty"`
+	GivenName  string `json:"givenName,omitempty"`
+}
+
+type MultiValued struct {
+	Value   string `json:"value"`
+	Primary bool   `json:"primary,omitempty"`
+}
+
+type listResponse struct {
+	TotalResults uint64  `json:"totalResults"`
+	Resources    []*User `json:"Resources"`
+}
+
+type patchRequest struct {
+	Schemas    []string          `json:"schemas"`
+	Operations []*patchOperation `json:"Operations"`
+}
+
+type patchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+// scimClient calls the SCIM endpoint of a service provider.
+// It doesn't keep any state, the users of the service provider are identified by their externalId.
+type scimClient struct {
+	client   *http.Client
+	endpoint string
+	token    string
+}
+
+func newSCIMClient(client *http.Client, endpoint, token string) *scimClient {
+	return &scimClient{
+		client:   client,
+		endpoint: strings.TrimSuffix(endpoint, "/"),
+		token:    token,
+	}
+}
+
+// upsert creates the user at the service provider or replaces the existing one
+func (c *scimClient) upsert(ctx context.Context, user *User) error {
+	existing, err := c.userByExternalID(ctx, user.ExternalID)
+	if err != nil {
+		return err
+	}
+	user.Schemas = []string{schemaUser}
+	if existing == nil {
+		return c.do(ctx, http.MethodPost, "/Users", user, http.StatusCreated)
+	}
+	user.ID = existing.ID
+	return c.do(ctx, http.MethodPut, "/Users/"+url.PathEscape(existing.ID), user, http.StatusOK)
+}
+
+// deactivate sets the user inactive at the service provider, unknown users are ignored
+func (c *scimClient) deactivate(ctx context.Context, externalID string) error {
+	existing, err := c.userByExternalID(ctx, externalID)
+	if err != nil || existing == nil {
+		return err
+	}
+	patch := &patchRequest{
+		Schemas:    []string{schemaPatchOp},
+		Operations: []*patchOperation{{Op: "replace", Path: "active", Value: false}},
+	}
+	return c.do(ctx, http.MethodPatch, "/Users/"+url.PathEscape(existing.ID), patch, http.StatusOK, http.StatusNoContent)
+}
+
+// delete removes the user from the service provider, unknown users are ignored
+func (c *scimClient) delete(ctx context.Context, externalID string) error {
+	existing, err := c.userByExternalID(ctx, externalID)
+	if err != nil || existing == nil {
+		return err
+	}
+	return c.do(ctx, http.MethodDelete, "/Users/"+url.PathEscape(existing.ID), nil, http.StatusNoContent, http.StatusNotFound)
+}
+
+func (c *scimClient) userByExternalID(ctx context.Context, externalID string) (*User, error) {
+	filter := url.Values{"filter": []string{fmt.Sprintf("externalId eq %q", externalID)}}
+	list := new(listResponse)
+	if err := c.doWithResponse(ctx, http.MethodGet, "/Users?"+filter.Encode(), nil, list, http.StatusOK); err != nil {
+		return nil, err
+	}
+	if len(list.Resources) == 0 {
+		return nil, nil
+	}
+	return list.Resources[0], nil
+}
+
+func (c *scimClient) do(ctx context.Context, method, path string, body interface{}, expectedStatus ...int) error {
+	return c.doWithResponse(ctx, method, path, body, nil, expectedStatus...)
+}
+
+func (c *scimClient) doWithResponse(ctx context.Context, method, path string, body, response interface{}, expectedStatus ...int) error {
+	var reqBody io.Reader
+	if body != nil {
+		data, err := json.Marshal(body)
+		if err != nil {
+			return errors.ThrowInternal(err, "PROV-ieR4a", "could not marshal request")
+		}
+		reqBody = bytes.NewReader(data)
+	}
+	req, err := http.NewRequestWithContext(ctx, method, c.endpoint+path, reqBody)
+	if err != nil {
+		return errors.ThrowInternal(err, "PROV-Oox6u", "could not create request")
+	}
+	req.Header.Set("Authorization", "Bearer "+c.token)
+	req.Header.Set("Accept", scimContentType)
+	if body != nil {
+		req.Header.Set("Content-Type", scimContentType)
+	}
+	resp, err := c.client.Do(req)
+	if err != nil {
+		return errors.ThrowUnavailable(err, "PROV-Shei2", "service provider not reachable")
+	}
+	defer resp.Body.Close()
+	if !expectedStatusCode(resp.StatusCode, expectedStatus) {
+		return errors.ThrowUnknown(fmt.Errorf("%s %s returned %s", method, c.endpoint+path, resp.Status), "PROV-ahV7e", "service provider didn't return a success status")
+	}
+	if response == nil {
+		return nil
+	}
+	if err = json.NewDecoder(resp.Body).Decode(response); err != nil {
+		return errors.ThrowInternal(err, "PROV-Ax2ee", "could not parse response")
+	}
+	return nil
+}
+
+func expectedStatusCode(status int, expected []int) bool {
+	for _, code := range expected {
+		if status == code {
+			return true
+		}
+	}
+	return false
+}
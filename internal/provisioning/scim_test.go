@@ -0,0 +1,113 @@
+package provisioning
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// serviceProvider is a minimal SCIM service provider keeping the users in memory
+type serviceProvider struct {
+	mu       sync.Mutex
+	users    map[string]*User
+	requests []string
+	// failures is the number of requests answered with an error
+	failures int
+}
+
+func newServiceProvider(users ...*User) (*serviceProvider, *httptest.Server) {
+	sp := &serviceProvider{users: make(map[string]*User, len(users))}
+	for _, user := range users {
+		sp.users[user.ID] = user
+	}
+	return sp, httptest.NewServer(sp)
+}
+
+func (sp *serviceProvider) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	sp.mu.Lock()
+	defer sp.mu.Unlock()
+	sp.requests = append(sp.requests, r.Method+" "+r.URL.Path)
+	if sp.failures > 0 {
+		sp.failures--
+		w.WriteHeader(http.StatusServiceUnavailable)
+		return
+	}
+	if r.Header.Get("Authorization") != "Bearer token" {
+		w.WriteHeader(http.StatusUnauthorized)
+		return
+	}
+	id := strings.TrimPrefix(r.URL.Path, "/Users/")
+	switch r.Method {
+	case http.MethodGet:
+		list := &listResponse{}
+		externalID := strings.TrimSuffix(strings.TrimPrefix(r.URL.Query().Get("filter"), `externalId eq "`), `"`)
+		for _, user := range sp.users {
+			if user.ExternalID == externalID {
+				list.Resources = append(list.Resources, user)
+			}
+		}
+		list.TotalResults = uint64(len(list.Resources))
+		_ = json.NewEncoder(w).Encode(list)
+	case http.MethodPost:
+		user := new(User)
+		_ = json.NewDecoder(r.Body).Decode(user)
+		user.ID = "sp-" + user.ExternalID
+		sp.users[user.ID] = user
+		w.WriteHeader(http.StatusCreated)
+		_ = json.NewEncoder(w).Encode(user)
+	case http.MethodPut:
+		user := new(User)
+		_ = json.NewDecoder(r.Body).Decode(user)
+		sp.users[id] = user
+		_ = json.NewEncoder(w).Encode(user)
+	case http.MethodPatch:
+		sp.users[id].Active = false
+		w.WriteHeader(http.StatusNoContent)
+	case http.MethodDelete:
+		delete(sp.users, id)
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func Test_scimClient(t *testing.T) {
+	sp, server := newServiceProvider(&User{ID: "sp-existing", ExternalID: "existing", UserName: "old", Active: true})
+	defer server.Close()
+	client := newSCIMClient(server.Client(), server.URL+"/", "token")
+	ctx := context.Background()
+
+	require.NoError(t, client.upsert(ctx, &User{ExternalID: "new", UserName: "new", Active: true}))
+	require.NoError(t, client.upsert(ctx, &User{ExternalID: "existing", UserName: "changed", Active: true}))
+	assert.Equal(t, &User{Schemas: []string{schemaUser}, ID: "sp-new", ExternalID: "new", UserName: "new", Active: true}, sp.users["sp-new"])
+	assert.Equal(t, &User{Schemas: []string{schemaUser}, ID: "sp-existing", ExternalID: "existing", UserName: "changed", Active: true}, sp.users["sp-existing"])
+
+	require.NoError(t, client.deactivate(ctx, "existing"))
+	assert.False(t, sp.users["sp-existing"].Active)
+
+	require.NoError(t, client.delete(ctx, "new"))
+	require.NoError(t, client.delete(ctx, "unknown"))
+	assert.NotContains(t, sp.users, "sp-new")
+
+	assert.Equal(t, []string{
+		"GET /Users", "POST /Users",
+		"GET /Users", "PUT /Users/sp-existing",
+		"GET /Users", "PATCH /Users/sp-existing",
+		"GET /Users", "DELETE /Users/sp-new",
+		"GET /Users",
+	}, sp.requests)
+}
+
+func Test_scimClient_unauthorized(t *testing.T) {
+	_, server := newServiceProvider()
+	defer server.Close()
+	client := newSCIMClient(server.Client(), server.URL, "wrong")
+
+	err := client.upsert(context.Background(), &User{ExternalID: "new", UserName: "new"})
+	assert.ErrorContains(t, err, "401")
+}
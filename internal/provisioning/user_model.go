@@ -0,0 +1,189 @@
+package provisioning
+
+import (
+	"context"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/user"
+)
+
+// userReadModel is the state of the user at the time of the event which is provisioned.
+// It's reduced from the events instead of queried from the projection,
+// so the delivered payload matches the event even if the user was changed or removed afterwards.
+type userReadModel struct {
+	eventstore.ReadModel
+
+	// until is the event which is provisioned, later events are ignored
+	until eventstore.Event
+
+	human             bool
+	removed           bool
+	state             domain.UserState
+	userName          string
+	firstName         string
+	lastName          string
+	nickName          string
+	displayName       string
+	preferredLanguage language.Tag
+	email             domain.EmailAddress
+	phone             domain.PhoneNumber
+}
+
+func newUserReadModel(userID string, until eventstore.Event) *userReadModel {
+	return &userReadModel{
+		ReadModel: eventstore.ReadModel{
+			AggregateID: userID,
+			InstanceID:  until.Aggregate().InstanceID,
+		},
+		until: until,
+	}
+}
+
+func (rm *userReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		if rm.isAfterUntil(event) {
+			break
+		}
+		switch e := event.(type) {
+		case *user.HumanAddedEvent:
+			rm.human = true
+			rm.state = domain.UserStateActive
+			rm.userName = e.UserName
+			rm.firstName = e.FirstName
+			rm.lastName = e.LastName
+			rm.nickName = e.NickName
+			rm.displayName = e.DisplayName
+			rm.preferredLanguage = e.PreferredLanguage
+			rm.email = e.EmailAddress
+			rm.phone = e.PhoneNumber
+		case *user.HumanRegisteredEvent:
+			rm.human = true
+			rm.state = domain.UserStateActive
+			rm.userName = e.UserName
+			rm.firstName = e.FirstName
+			rm.lastName = e.LastName
+			rm.nickName = e.NickName
+			rm.displayName = e.DisplayName
+			rm.preferredLanguage = e.PreferredLanguage
+			rm.email = e.EmailAddress
+			rm.phone = e.PhoneNumber
+		case *user.MachineAddedEvent:
+			rm.human = false
+		case *user.UsernameChangedEvent:
+			rm.userName = e.UserName
+		case *user.HumanProfileChangedEvent:
+			if e.FirstName != "" {
+				rm.firstName = e.FirstName
+			}
+			if e.LastName != "" {
+				rm.lastName = e.LastName
+			}
+			if e.NickName != nil {
+				rm.nickName = *e.NickName
+			}
+			if e.DisplayName != nil {
+				rm.displayName = *e.DisplayName
+			}
+			if e.PreferredLanguage != nil {
+				rm.preferredLanguage = *e.PreferredLanguage
+			}
+		case *user.HumanEmailChangedEvent:
+			rm.email = e.EmailAddress
+		case *user.HumanPhoneChangedEvent:
+			rm.phone = e.PhoneNumber
+		case *user.HumanPhoneRemovedEvent:
+			rm.phone = ""
+		case *user.UserDeactivatedEvent:
+			rm.state = domain.UserStateInactive
+		case *user.UserReactivatedEvent:
+			rm.state = domain.UserStateActive
+		case *user.UserRemovedEvent:
+			rm.removed = true
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+// isAfterUntil compares the sequence on the aggregate of the user
+// and the position for events of other aggregates (e.g. user grants)
+func (rm *userReadModel) isAfterUntil(event eventstore.Event) bool {
+	if rm.until.Aggregate().Type == user.AggregateType && rm.until.Aggregate().ID == event.Aggregate().ID {
+		return event.Sequence() > rm.until.Sequence()
+	}
+	return event.Position() > rm.until.Position()
+}
+
+func (rm *userReadModel) Query() *eventstore.SearchQueryBuilder {
+	return eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		InstanceID(rm.InstanceID).
+		AddQuery().
+		AggregateTypes(user.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			user.UserV1AddedType,
+			user.HumanAddedType,
+			user.UserV1RegisteredType,
+			user.HumanRegisteredType,
+			user.MachineAddedEventType,
+			user.UserUserNameChangedType,
+			user.UserV1ProfileChangedType,
+			user.HumanProfileChangedType,
+			user.UserV1EmailChangedType,
+			user.HumanEmailChangedType,
+			user.UserV1PhoneChangedType,
+			user.HumanPhoneChangedType,
+			user.UserV1PhoneRemovedType,
+			user.HumanPhoneRemovedType,
+			user.UserDeactivatedType,
+			user.UserReactivatedType,
+			user.UserRemovedType,
+		).
+		Builder()
+}
+
+// userAt returns the user at the time of the event,
+// nil is returned if the user is not a human or did not exist (anymore)
+func (p *provisioner) userAt(ctx context.Context, userID string, event eventstore.Event) (*User, error) {
+	readModel := newUserReadModel(userID, event)
+	events, err := p.es.Filter(ctx, readModel.Query())
+	if err != nil {
+		return nil, err
+	}
+	readModel.AppendEvents(events...)
+	if err = readModel.Reduce(); err != nil {
+		return nil, err
+	}
+	if !readModel.human || readModel.removed {
+		return nil, nil
+	}
+	return readModel.toResource(), nil
+}
+
+func (rm *userReadModel) toResource() *User {
+	resource := &User{
+		ExternalID:  rm.AggregateID,
+		UserName:    rm.userName,
+		Active:      rm.state != domain.UserStateInactive,
+		DisplayName: rm.displayName,
+		NickName:    rm.nickName,
+		Name: &Name{
+			Formatted:  strings.TrimSpace(rm.firstName + " " + rm.lastName),
+			FamilyName: rm.lastName,
+			GivenName:  rm.firstName,
+		},
+	}
+	if !rm.preferredLanguage.IsRoot() {
+		resource.PreferredLanguage = rm.preferredLanguage.String()
+	}
+	if rm.email != "" {
+		resource.Emails = []*MultiValued{{Value: string(rm.email), Primary: true}}
+	}
+	if rm.phone != "" {
+		resource.PhoneNumbers = []*MultiValued{{Value: string(rm.phone), Primary: true}}
+	}
+	return resource
+}
@@ -10,6 +10,7 @@ import (
 
 	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/query/projection"
@@ -73,6 +74,22 @@ var (
 		name:  projection.ActionOwnerRemovedCol,
 		table: actionTable,
 	}
+	ActionColumnType = Column{
+		name:  projection.ActionTypeCol,
+		table: actionTable,
+	}
+	ActionColumnTargetURL = Column{
+		name:  projection.ActionTargetURLCol,
+		table: actionTable,
+	}
+	ActionColumnMaxRetries = Column{
+		name:  projection.ActionMaxRetriesCol,
+		table: actionTable,
+	}
+	ActionColumnSigningKey = Column{
+		name:  projection.ActionSigningKeyCol,
+		table: actionTable,
+	}
 )
 
 type Actions struct {
@@ -92,6 +109,12 @@ type Action struct {
 	Script        string
 	timeout       time.Duration
 	AllowedToFail bool
+
+	Type       domain.ActionType
+	TargetURL  string
+	MaxRetries uint32
+	// SigningKey is only queried for the execution of the actions of a trigger
+	SigningKey *crypto.CryptoValue
 }
 
 func (a *Action) Timeout() time.Duration {
@@ -195,6 +218,9 @@ func prepareActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 			ActionColumnScript.identifier(),
 			ActionColumnTimeout.identifier(),
 			ActionColumnAllowedToFail.identifier(),
+			ActionColumnType.identifier(),
+			ActionColumnTargetURL.identifier(),
+			ActionColumnMaxRetries.identifier(),
 			countColumn.identifier(),
 		).From(actionTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
@@ -214,6 +240,9 @@ func prepareActionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuil
 					&action.Script,
 					&action.timeout,
 					&action.AllowedToFail,
+					&action.Type,
+					&action.TargetURL,
+					&action.MaxRetries,
 					&count,
 				)
 				if err != nil {
@@ -247,6 +276,9 @@ func prepareActionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			ActionColumnScript.identifier(),
 			ActionColumnTimeout.identifier(),
 			ActionColumnAllowedToFail.identifier(),
+			ActionColumnType.identifier(),
+			ActionColumnTargetURL.identifier(),
+			ActionColumnMaxRetries.identifier(),
 		).From(actionTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Action, error) {
@@ -262,6 +294,9 @@ func prepareActionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 				&action.Script,
 				&action.timeout,
 				&action.AllowedToFail,
+				&action.Type,
+				&action.TargetURL,
+				&action.MaxRetries,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -0,0 +1,362 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+const (
+	// ActionExecutionTable is written by the run storage of the execution logs
+	ActionExecutionTable = "logstore.action_executions"
+
+	ActionExecutionInstanceIDCol    = "instance_id"
+	ActionExecutionIDCol            = "id"
+	ActionExecutionResourceOwnerCol = "resource_owner"
+	ActionExecutionActionIDCol      = "action_id"
+	ActionExecutionFlowTypeCol      = "flow_type"
+	ActionExecutionTriggerTypeCol   = "trigger_type"
+	ActionExecutionAggregateTypeCol = "aggregate_type"
+	ActionExecutionAggregateIDCol   = "aggregate_id"
+	ActionExecutionSequenceCol      = "sequence"
+	ActionExecutionEventTypeCol     = "event_type"
+	ActionExecutionLogDateCol       = "log_date"
+	ActionExecutionTookCol          = "took"
+	ActionExecutionSucceededCol     = "succeeded"
+	ActionExecutionErrorCol         = "error"
+	ActionExecutionLogsCol          = "logs"
+)
+
+var (
+	actionExecutionsTable = table{
+		name:          ActionExecutionTable,
+		instanceIDCol: ActionExecutionInstanceIDCol,
+	}
+	ActionExecutionColumnInstanceID = Column{
+		name:  ActionExecutionInstanceIDCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnID = Column{
+		name:  ActionExecutionIDCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnResourceOwner = Column{
+		name:  ActionExecutionResourceOwnerCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnActionID = Column{
+		name:  ActionExecutionActionIDCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnFlowType = Column{
+		name:  ActionExecutionFlowTypeCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnTriggerType = Column{
+		name:  ActionExecutionTriggerTypeCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnAggregateType = Column{
+		name:  ActionExecutionAggregateTypeCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnAggregateID = Column{
+		name:  ActionExecutionAggregateIDCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnSequence = Column{
+		name:  ActionExecutionSequenceCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnEventType = Column{
+		name:  ActionExecutionEventTypeCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnLogDate = Column{
+		name:  ActionExecutionLogDateCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnTook = Column{
+		name:  ActionExecutionTookCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnSucceeded = Column{
+		name:  ActionExecutionSucceededCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnError = Column{
+		name:  ActionExecutionErrorCol,
+		table: actionExecutionsTable,
+	}
+	ActionExecutionColumnLogs = Column{
+		name:  ActionExecutionLogsCol,
+		table: actionExecutionsTable,
+	}
+)
+
+type ActionExecutions struct {
+	SearchResponse
+	Executions []*ActionExecution
+}
+
+// ActionExecution is a single execution of an action including the output of its log module.
+// The event is only set on the executions of the event flow.
+type ActionExecution struct {
+	ID            string
+	ResourceOwner string
+	ActionID      string
+	FlowType      domain.FlowType
+	TriggerType   domain.TriggerType
+	AggregateType string
+	AggregateID   string
+	Sequence      uint64
+	EventType     string
+	Started       time.Time
+	Took          time.Duration
+
+	Succeeded bool
+	Error     string
+	Logs      []*record.ExecutionRunLog
+}
+
+type ActionExecutionSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *ActionExecutionSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+// ActionExecutionRetention returns the age of the executions which are kept for the instance.
+// The system default is overwritten by the limits of the instance, 0 means the executions are kept forever.
+func (q *Queries) ActionExecutionRetention(ctx context.Context) (_ time.Duration, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	retention := q.defaultActionExecutionRetention
+	instanceLimits, err := q.Limits(ctx, authz.GetInstance(ctx).InstanceID())
+	if err != nil && !errors.IsNotFound(err) {
+		return 0, err
+	}
+	if instanceLimits != nil && instanceLimits.ActionExecutionRetention != nil {
+		retention = *instanceLimits.ActionExecutionRetention
+	}
+	return retention, nil
+}
+
+func (q *Queries) SearchActionExecutions(ctx context.Context, queries *ActionExecutionSearchQueries) (executions *ActionExecutions, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareActionExecutionsQuery(ctx, q.client)
+	if queries.SortingColumn.isZero() {
+		query = query.OrderBy(ActionExecutionColumnLogDate.identifier() + " DESC")
+	}
+	query, err = q.filterActionExecutionRetention(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		ActionExecutionColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ohng4", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		executions, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ahR3i", "Errors.Internal")
+	}
+	return executions, nil
+}
+
+func (q *Queries) ActionExecutionByID(ctx context.Context, id, actionID, resourceOwner string) (execution *ActionExecution, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareActionExecutionQuery(ctx, q.client)
+	query, err = q.filterActionExecutionRetention(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	stmt, args, err := query.Where(sq.Eq{
+		ActionExecutionColumnID.identifier():            id,
+		ActionExecutionColumnActionID.identifier():      actionID,
+		ActionExecutionColumnResourceOwner.identifier(): resourceOwner,
+		ActionExecutionColumnInstanceID.identifier():    authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Iev4a", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		execution, err = scan(row)
+		return err
+	}, stmt, args...)
+	return execution, err
+}
+
+// filterActionExecutionRetention hides the executions which are older than the retention
+// even if they are not yet removed by the log storage
+func (q *Queries) filterActionExecutionRetention(ctx context.Context, query sq.SelectBuilder) (sq.SelectBuilder, error) {
+	retention, err := q.ActionExecutionRetention(ctx)
+	if err != nil || retention == 0 {
+		return query, err
+	}
+	callTime := call.FromContext(ctx)
+	if callTime.IsZero() {
+		callTime = time.Now()
+	}
+	return query.Where(sq.GtOrEq{
+		ActionExecutionColumnLogDate.identifier(): callTime.Add(-retention),
+	}), nil
+}
+
+func NewActionExecutionActionIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(ActionExecutionColumnActionID, id, TextEquals)
+}
+
+func NewActionExecutionResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(ActionExecutionColumnResourceOwner, id, TextEquals)
+}
+
+func NewActionExecutionSucceededSearchQuery(succeeded bool) (SearchQuery, error) {
+	return NewBoolQuery(ActionExecutionColumnSucceeded, succeeded)
+}
+
+func NewActionExecutionFlowTypeSearchQuery(flowType domain.FlowType) (SearchQuery, error) {
+	return NewNumberQuery(ActionExecutionColumnFlowType, flowType, NumberEquals)
+}
+
+func NewActionExecutionTriggerTypeSearchQuery(triggerType domain.TriggerType) (SearchQuery, error) {
+	return NewNumberQuery(ActionExecutionColumnTriggerType, triggerType, NumberEquals)
+}
+
+func actionExecutionColumns() []string {
+	return []string{
+		ActionExecutionColumnID.identifier(),
+		ActionExecutionColumnResourceOwner.identifier(),
+		ActionExecutionColumnActionID.identifier(),
+		ActionExecutionColumnFlowType.identifier(),
+		ActionExecutionColumnTriggerType.identifier(),
+		ActionExecutionColumnAggregateType.identifier(),
+		ActionExecutionColumnAggregateID.identifier(),
+		ActionExecutionColumnSequence.identifier(),
+		ActionExecutionColumnEventType.identifier(),
+		ActionExecutionColumnLogDate.identifier(),
+		ActionExecutionColumnTook.identifier(),
+		ActionExecutionColumnSucceeded.identifier(),
+		ActionExecutionColumnError.identifier(),
+		ActionExecutionColumnLogs.identifier(),
+	}
+}
+
+func prepareActionExecutionQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*ActionExecution, error)) {
+	return sq.Select(actionExecutionColumns()...).
+			From(actionExecutionsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*ActionExecution, error) {
+			execution, err := scanActionExecution(row.Scan)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Ep4oh", "Errors.Action.Execution.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Xae3u", "Errors.Internal")
+			}
+			return execution, nil
+		}
+}
+
+func prepareActionExecutionsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*ActionExecutions, error)) {
+	return sq.Select(append(actionExecutionColumns(), countColumn.identifier())...).
+			From(actionExecutionsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*ActionExecutions, error) {
+			executions := make([]*ActionExecution, 0)
+			var count uint64
+			for rows.Next() {
+				execution, err := scanActionExecution(func(dest ...interface{}) error {
+					return rows.Scan(append(dest, &count)...)
+				})
+				if err != nil {
+					return nil, err
+				}
+				executions = append(executions, execution)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ucho6", "Errors.Query.CloseRows")
+			}
+
+			return &ActionExecutions{
+				Executions: executions,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func scanActionExecution(scan func(dest ...interface{}) error) (*ActionExecution, error) {
+	execution := new(ActionExecution)
+	var (
+		aggregateType sql.NullString
+		aggregateID   sql.NullString
+		sequence      sql.NullInt64
+		eventType     sql.NullString
+		took          database.NullDuration
+		executionErr  sql.NullString
+		logs          []byte
+	)
+	err := scan(
+		&execution.ID,
+		&execution.ResourceOwner,
+		&execution.ActionID,
+		&execution.FlowType,
+		&execution.TriggerType,
+		&aggregateType,
+		&aggregateID,
+		&sequence,
+		&eventType,
+		&execution.Started,
+		&took,
+		&execution.Succeeded,
+		&executionErr,
+		&logs,
+	)
+	if err != nil {
+		return nil, err
+	}
+	execution.AggregateType = aggregateType.String
+	execution.AggregateID = aggregateID.String
+	execution.Sequence = uint64(sequence.Int64)
+	execution.EventType = eventType.String
+	execution.Took = took.Duration
+	execution.Error = executionErr.String
+	if len(logs) > 0 {
+		if err = json.Unmarshal(logs, &execution.Logs); err != nil {
+			return nil, errors.ThrowInternal(err, "QUERY-oa5Ph", "Errors.Internal")
+		}
+	}
+	return execution, nil
+}
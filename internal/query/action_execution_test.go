@@ -0,0 +1,278 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/sirupsen/logrus"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/logstore/record"
+)
+
+var (
+	prepareActionExecutionStmt = `SELECT logstore.action_executions.id,` +
+		` logstore.action_executions.resource_owner,` +
+		` logstore.action_executions.action_id,` +
+		` logstore.action_executions.flow_type,` +
+		` logstore.action_executions.trigger_type,` +
+		` logstore.action_executions.aggregate_type,` +
+		` logstore.action_executions.aggregate_id,` +
+		` logstore.action_executions.sequence,` +
+		` logstore.action_executions.event_type,` +
+		` logstore.action_executions.log_date,` +
+		` logstore.action_executions.took,` +
+		` logstore.action_executions.succeeded,` +
+		` logstore.action_executions.error,` +
+		` logstore.action_executions.logs` +
+		` FROM logstore.action_executions`
+	prepareActionExecutionCols = []string{
+		"id",
+		"resource_owner",
+		"action_id",
+		"flow_type",
+		"trigger_type",
+		"aggregate_type",
+		"aggregate_id",
+		"sequence",
+		"event_type",
+		"log_date",
+		"took",
+		"succeeded",
+		"error",
+		"logs",
+	}
+	prepareActionExecutionsStmt = `SELECT logstore.action_executions.id,` +
+		` logstore.action_executions.resource_owner,` +
+		` logstore.action_executions.action_id,` +
+		` logstore.action_executions.flow_type,` +
+		` logstore.action_executions.trigger_type,` +
+		` logstore.action_executions.aggregate_type,` +
+		` logstore.action_executions.aggregate_id,` +
+		` logstore.action_executions.sequence,` +
+		` logstore.action_executions.event_type,` +
+		` logstore.action_executions.log_date,` +
+		` logstore.action_executions.took,` +
+		` logstore.action_executions.succeeded,` +
+		` logstore.action_executions.error,` +
+		` logstore.action_executions.logs,` +
+		` COUNT(*) OVER ()` +
+		` FROM logstore.action_executions`
+	prepareActionExecutionsCols = append(prepareActionExecutionCols, "count")
+)
+
+func Test_ActionExecutionPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareActionExecutionsQuery no result",
+			prepare: prepareActionExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionExecutionsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &ActionExecutions{Executions: []*ActionExecution{}},
+		},
+		{
+			name:    "prepareActionExecutionsQuery multiple result",
+			prepare: prepareActionExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareActionExecutionsStmt),
+					prepareActionExecutionsCols,
+					[][]driver.Value{
+						{
+							"execution-1",
+							"ro",
+							"action-id",
+							domain.FlowTypeCustomiseToken,
+							domain.TriggerTypePreAccessTokenCreation,
+							nil,
+							nil,
+							nil,
+							nil,
+							testNow,
+							intervalDriverValue(t, time.Second),
+							true,
+							nil,
+							[]byte(`[{"logDate":"2019-04-01T00:00:00Z","logLevel":"info","message":"hello"}]`),
+						},
+						{
+							"execution-2",
+							"ro",
+							"action-id",
+							domain.FlowTypeEvent,
+							domain.TriggerTypeUserHumanAdded,
+							"user",
+							"user-id",
+							uint64(1),
+							"user.human.added",
+							testNow,
+							intervalDriverValue(t, 2*time.Second),
+							false,
+							"failed",
+							nil,
+						},
+					},
+				),
+			},
+			object: &ActionExecutions{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Executions: []*ActionExecution{
+					{
+						ID:            "execution-1",
+						ResourceOwner: "ro",
+						ActionID:      "action-id",
+						FlowType:      domain.FlowTypeCustomiseToken,
+						TriggerType:   domain.TriggerTypePreAccessTokenCreation,
+						Started:       testNow,
+						Took:          time.Second,
+						Succeeded:     true,
+						Logs: []*record.ExecutionRunLog{
+							{
+								LogDate:  time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC),
+								LogLevel: logrus.InfoLevel,
+								Message:  "hello",
+							},
+						},
+					},
+					{
+						ID:            "execution-2",
+						ResourceOwner: "ro",
+						ActionID:      "action-id",
+						FlowType:      domain.FlowTypeEvent,
+						TriggerType:   domain.TriggerTypeUserHumanAdded,
+						AggregateType: "user",
+						AggregateID:   "user-id",
+						Sequence:      1,
+						EventType:     "user.human.added",
+						Started:       testNow,
+						Took:          2 * time.Second,
+						Succeeded:     false,
+						Error:         "failed",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareActionExecutionsQuery sql err",
+			prepare: prepareActionExecutionsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareActionExecutionsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*ActionExecutions)(nil),
+		},
+		{
+			name:    "prepareActionExecutionQuery no result",
+			prepare: prepareActionExecutionQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareActionExecutionStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*ActionExecution)(nil),
+		},
+		{
+			name:    "prepareActionExecutionQuery found",
+			prepare: prepareActionExecutionQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareActionExecutionStmt),
+					prepareActionExecutionCols,
+					[]driver.Value{
+						"execution-1",
+						"ro",
+						"action-id",
+						domain.FlowTypeExternalAuthentication,
+						domain.TriggerTypePostAuthentication,
+						nil,
+						nil,
+						nil,
+						nil,
+						testNow,
+						intervalDriverValue(t, time.Second),
+						false,
+						"failed",
+						[]byte(`[{"logDate":"2019-04-01T00:00:00Z","logLevel":"warning","message":"careful"}]`),
+					},
+				),
+			},
+			object: &ActionExecution{
+				ID:            "execution-1",
+				ResourceOwner: "ro",
+				ActionID:      "action-id",
+				FlowType:      domain.FlowTypeExternalAuthentication,
+				TriggerType:   domain.TriggerTypePostAuthentication,
+				Started:       testNow,
+				Took:          time.Second,
+				Succeeded:     false,
+				Error:         "failed",
+				Logs: []*record.ExecutionRunLog{
+					{
+						LogDate:  time.Date(2019, 4, 1, 0, 0, 0, 0, time.UTC),
+						LogLevel: logrus.WarnLevel,
+						Message:  "careful",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareActionExecutionQuery sql err",
+			prepare: prepareActionExecutionQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareActionExecutionStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*ActionExecution)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
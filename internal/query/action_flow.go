@@ -165,6 +165,10 @@ func prepareTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 			ActionColumnScript.identifier(),
 			ActionColumnAllowedToFail.identifier(),
 			ActionColumnTimeout.identifier(),
+			ActionColumnType.identifier(),
+			ActionColumnTargetURL.identifier(),
+			ActionColumnMaxRetries.identifier(),
+			ActionColumnSigningKey.identifier(),
 		).
 			From(flowsTriggersTable.name).
 			LeftJoin(join(ActionColumnID, FlowsTriggersColumnActionID) + db.Timetravel(call.Took(ctx))).
@@ -184,6 +188,10 @@ func prepareTriggerActionsQuery(ctx context.Context, db prepareDatabase) (sq.Sel
 					&action.Script,
 					&action.AllowedToFail,
 					&action.timeout,
+					&action.Type,
+					&action.TargetURL,
+					&action.MaxRetries,
+					&action.SigningKey,
 				)
 				if err != nil {
 					return nil, err
@@ -211,6 +219,9 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 			ActionColumnScript.identifier(),
 			ActionColumnAllowedToFail.identifier(),
 			ActionColumnTimeout.identifier(),
+			ActionColumnType.identifier(),
+			ActionColumnTargetURL.identifier(),
+			ActionColumnMaxRetries.identifier(),
 			FlowsTriggersColumnTriggerType.identifier(),
 			FlowsTriggersColumnTriggerSequence.identifier(),
 			FlowsTriggersColumnFlowType.identifier(),
@@ -238,6 +249,9 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 					actionScript        sql.NullString
 					actionAllowedToFail sql.NullBool
 					actionTimeout       sql.NullInt64
+					actionType          sql.NullInt32
+					actionTargetURL     sql.NullString
+					actionMaxRetries    sql.NullInt64
 
 					triggerType     domain.TriggerType
 					triggerSequence int
@@ -253,6 +267,9 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 					&actionScript,
 					&actionAllowedToFail,
 					&actionTimeout,
+					&actionType,
+					&actionTargetURL,
+					&actionMaxRetries,
 					&triggerType,
 					&triggerSequence,
 					&flow.Type,
@@ -277,6 +294,9 @@ func prepareFlowQuery(ctx context.Context, db prepareDatabase, flowType domain.F
 					Script:        actionScript.String,
 					AllowedToFail: actionAllowedToFail.Bool,
 					timeout:       time.Duration(actionTimeout.Int64),
+					Type:          domain.ActionType(actionType.Int32),
+					TargetURL:     actionTargetURL.String,
+					MaxRetries:    uint32(actionMaxRetries.Int64),
 				})
 			}
 
@@ -12,20 +12,24 @@ import (
 
 	sq "github.com/Masterminds/squirrel"
 
+	"github.com/zitadel/zitadel/internal/crypto"
 	"github.com/zitadel/zitadel/internal/domain"
 )
 
 var (
-	prepareFlowStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.allowed_to_fail,` +
-		` projections.actions3.timeout,` +
+	prepareFlowStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.action_type,` +
+		` projections.actions4.target_url,` +
+		` projections.actions4.max_retries,` +
 		` projections.flow_triggers3.trigger_type,` +
 		` projections.flow_triggers3.trigger_sequence,` +
 		` projections.flow_triggers3.flow_type,` +
@@ -33,7 +37,7 @@ var (
 		` projections.flow_triggers3.sequence,` +
 		` projections.flow_triggers3.resource_owner` +
 		` FROM projections.flow_triggers3` +
-		` LEFT JOIN projections.actions3 ON projections.flow_triggers3.action_id = projections.actions3.id AND projections.flow_triggers3.instance_id = projections.actions3.instance_id`
+		` LEFT JOIN projections.actions4 ON projections.flow_triggers3.action_id = projections.actions4.id AND projections.flow_triggers3.instance_id = projections.actions4.instance_id`
 	// ` AS OF SYSTEM TIME '-1 ms'`
 	prepareFlowCols = []string{
 		"id",
@@ -46,6 +50,9 @@ var (
 		"script",
 		"allowed_to_fail",
 		"timeout",
+		"action_type",
+		"target_url",
+		"max_retries",
 		// flow
 		"trigger_type",
 		"trigger_sequence",
@@ -55,18 +62,22 @@ var (
 		"resource_owner",
 	}
 
-	prepareTriggerActionStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.allowed_to_fail,` +
-		` projections.actions3.timeout` +
+	prepareTriggerActionStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.action_type,` +
+		` projections.actions4.target_url,` +
+		` projections.actions4.max_retries,` +
+		` projections.actions4.signing_key` +
 		` FROM projections.flow_triggers3` +
-		` LEFT JOIN projections.actions3 ON projections.flow_triggers3.action_id = projections.actions3.id AND projections.flow_triggers3.instance_id = projections.actions3.instance_id`
+		` LEFT JOIN projections.actions4 ON projections.flow_triggers3.action_id = projections.actions4.id AND projections.flow_triggers3.instance_id = projections.actions4.instance_id`
 	// ` AS OF SYSTEM TIME '-1 ms'`
 
 	prepareTriggerActionCols = []string{
@@ -80,6 +91,10 @@ var (
 		"script",
 		"allowed_to_fail",
 		"timeout",
+		"action_type",
+		"target_url",
+		"max_retries",
+		"signing_key",
 	}
 
 	prepareFlowTypeStmt = `SELECT projections.flow_triggers3.flow_type` +
@@ -140,6 +155,9 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionTypeScript,
+							"",
+							0,
 							domain.TriggerTypePreCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -194,6 +212,9 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionTypeScript,
+							"",
+							0,
 							domain.TriggerTypePreCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -212,6 +233,9 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							false,
 							5000000000,
+							domain.ActionTypeScript,
+							"",
+							0,
 							domain.TriggerTypePostCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -280,6 +304,9 @@ func Test_FlowPrepares(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 							domain.TriggerTypePostCreation,
 							uint64(20211109),
 							domain.FlowTypeExternalAuthentication,
@@ -348,6 +375,10 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionTypeScript,
+							"",
+							0,
+							nil,
 						},
 					},
 				),
@@ -386,6 +417,10 @@ func Test_FlowPrepares(t *testing.T) {
 							"script",
 							true,
 							10000000000,
+							domain.ActionTypeScript,
+							"",
+							0,
+							nil,
 						},
 						{
 							"action-id-2",
@@ -395,9 +430,13 @@ func Test_FlowPrepares(t *testing.T) {
 							domain.ActionStateActive,
 							uint64(20211115),
 							"action-name-2",
-							"script",
+							"",
 							false,
 							5000000000,
+							domain.ActionTypeTarget,
+							"https://example.com/hook",
+							3,
+							[]byte(`{"cryptoType":0,"algorithm":"enc","keyID":"id","crypted":"Y3J5cHRlZA=="}`),
 						},
 					},
 				),
@@ -423,9 +462,17 @@ func Test_FlowPrepares(t *testing.T) {
 					State:         domain.ActionStateActive,
 					Sequence:      20211115,
 					Name:          "action-name-2",
-					Script:        "script",
 					AllowedToFail: false,
 					timeout:       5 * time.Second,
+					Type:          domain.ActionTypeTarget,
+					TargetURL:     "https://example.com/hook",
+					MaxRetries:    3,
+					SigningKey: &crypto.CryptoValue{
+						CryptoType: crypto.TypeEncryption,
+						Algorithm:  "enc",
+						KeyID:      "id",
+						Crypted:    []byte("crypted"),
+					},
 				},
 			},
 		},
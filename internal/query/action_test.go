@@ -14,18 +14,21 @@ import (
 )
 
 var (
-	prepareActionsStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.timeout,` +
-		` projections.actions3.allowed_to_fail,` +
+	prepareActionsStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.action_type,` +
+		` projections.actions4.target_url,` +
+		` projections.actions4.max_retries,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.actions3`
+		` FROM projections.actions4`
 		// ` AS OF SYSTEM TIME '-1 ms'`
 	prepareActionsCols = []string{
 		"id",
@@ -38,20 +41,26 @@ var (
 		"script",
 		"timeout",
 		"allowed_to_fail",
+		"action_type",
+		"target_url",
+		"max_retries",
 		"count",
 	}
 
-	prepareActionStmt = `SELECT projections.actions3.id,` +
-		` projections.actions3.creation_date,` +
-		` projections.actions3.change_date,` +
-		` projections.actions3.resource_owner,` +
-		` projections.actions3.sequence,` +
-		` projections.actions3.action_state,` +
-		` projections.actions3.name,` +
-		` projections.actions3.script,` +
-		` projections.actions3.timeout,` +
-		` projections.actions3.allowed_to_fail` +
-		` FROM projections.actions3`
+	prepareActionStmt = `SELECT projections.actions4.id,` +
+		` projections.actions4.creation_date,` +
+		` projections.actions4.change_date,` +
+		` projections.actions4.resource_owner,` +
+		` projections.actions4.sequence,` +
+		` projections.actions4.action_state,` +
+		` projections.actions4.name,` +
+		` projections.actions4.script,` +
+		` projections.actions4.timeout,` +
+		` projections.actions4.allowed_to_fail,` +
+		` projections.actions4.action_type,` +
+		` projections.actions4.target_url,` +
+		` projections.actions4.max_retries` +
+		` FROM projections.actions4`
 		// ` AS OF SYSTEM TIME '-1 ms'`
 	prepareActionCols = []string{
 		"id",
@@ -64,6 +73,9 @@ var (
 		"script",
 		"timeout",
 		"allowed_to_fail",
+		"action_type",
+		"target_url",
+		"max_retries",
 	}
 )
 
@@ -109,6 +121,9 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							domain.ActionTypeScript,
+							"",
+							uint32(0),
 						},
 					},
 				),
@@ -152,6 +167,9 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							domain.ActionTypeScript,
+							"",
+							uint32(0),
 						},
 						{
 							"id-2",
@@ -164,6 +182,9 @@ func Test_ActionPrepares(t *testing.T) {
 							"script",
 							1 * time.Second,
 							true,
+							domain.ActionTypeScript,
+							"",
+							uint32(0),
 						},
 					},
 				),
@@ -253,6 +274,9 @@ func Test_ActionPrepares(t *testing.T) {
 						"script",
 						1 * time.Second,
 						true,
+						domain.ActionTypeTarget,
+						"https://example.com/hook",
+						uint32(3),
 					},
 				),
 			},
@@ -267,6 +291,9 @@ func Test_ActionPrepares(t *testing.T) {
 				Script:        "script",
 				timeout:       1 * time.Second,
 				AllowedToFail: true,
+				Type:          domain.ActionTypeTarget,
+				TargetURL:     "https://example.com/hook",
+				MaxRetries:    3,
 			},
 		},
 		{
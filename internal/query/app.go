@@ -41,30 +41,40 @@ type App struct {
 }
 
 type OIDCApp struct {
-	RedirectURIs             database.TextArray[string]
-	ResponseTypes            database.Array[domain.OIDCResponseType]
-	GrantTypes               database.Array[domain.OIDCGrantType]
-	AppType                  domain.OIDCApplicationType
-	ClientID                 string
-	AuthMethodType           domain.OIDCAuthMethodType
-	PostLogoutRedirectURIs   database.TextArray[string]
-	Version                  domain.OIDCVersion
-	ComplianceProblems       database.TextArray[string]
-	IsDevMode                bool
-	AccessTokenType          domain.OIDCTokenType
-	AssertAccessTokenRole    bool
-	AssertIDTokenRole        bool
-	AssertIDTokenUserinfo    bool
-	ClockSkew                time.Duration
-	AdditionalOrigins        database.TextArray[string]
-	AllowedOrigins           database.TextArray[string]
-	SkipNativeAppSuccessPage bool
+	RedirectURIs                     database.TextArray[string]
+	ResponseTypes                    database.Array[domain.OIDCResponseType]
+	GrantTypes                       database.Array[domain.OIDCGrantType]
+	AppType                          domain.OIDCApplicationType
+	ClientID                         string
+	AuthMethodType                   domain.OIDCAuthMethodType
+	PostLogoutRedirectURIs           database.TextArray[string]
+	Version                          domain.OIDCVersion
+	ComplianceProblems               database.TextArray[string]
+	IsDevMode                        bool
+	AccessTokenType                  domain.OIDCTokenType
+	AssertAccessTokenRole            bool
+	AssertIDTokenRole                bool
+	AssertIDTokenUserinfo            bool
+	ClockSkew                        time.Duration
+	AdditionalOrigins                database.TextArray[string]
+	AllowedOrigins                   database.TextArray[string]
+	SkipNativeAppSuccessPage         bool
+	BackChannelLogoutURI             string
+	FrontChannelLogoutURI            string
+	RequirePushedAuthRequest         bool
+	RequireSignedRequest             bool
+	DPoPBoundAccessTokens            bool
+	ForceRSASignedTokens             bool
+	BackChannelClientNotificationURI string
 }
 
 type SAMLApp struct {
-	Metadata    []byte
-	MetadataURL string
-	EntityID    string
+	Metadata          []byte
+	MetadataURL       string
+	EntityID          string
+	EncryptAssertions bool
+	ArtifactBinding   bool
+	SingleLogout      bool
 }
 
 type APIApp struct {
@@ -149,6 +159,18 @@ var (
 		name:  projection.AppSAMLConfigColumnMetadataURL,
 		table: appSAMLConfigsTable,
 	}
+	AppSAMLConfigColumnEncryptAssertions = Column{
+		name:  projection.AppSAMLConfigColumnEncryptAssertions,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnArtifactBinding = Column{
+		name:  projection.AppSAMLConfigColumnArtifactBinding,
+		table: appSAMLConfigsTable,
+	}
+	AppSAMLConfigColumnSingleLogout = Column{
+		name:  projection.AppSAMLConfigColumnSingleLogout,
+		table: appSAMLConfigsTable,
+	}
 )
 
 var (
@@ -243,6 +265,34 @@ var (
 		name:  projection.AppOIDCConfigColumnSkipNativeAppSuccessPage,
 		table: appOIDCConfigsTable,
 	}
+	AppOIDCConfigColumnBackChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnBackChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnFrontChannelLogoutURI = Column{
+		name:  projection.AppOIDCConfigColumnFrontChannelLogoutURI,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequirePushedAuthRequest = Column{
+		name:  projection.AppOIDCConfigColumnRequirePushedAuthRequest,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnRequireSignedRequest = Column{
+		name:  projection.AppOIDCConfigColumnRequireSignedRequest,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnDPoPBoundAccessTokens = Column{
+		name:  projection.AppOIDCConfigColumnDPoPBoundAccessTokens,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnForceRSASignedTokens = Column{
+		name:  projection.AppOIDCConfigColumnForceRSASignedTokens,
+		table: appOIDCConfigsTable,
+	}
+	AppOIDCConfigColumnBackChannelClientNotificationURI = Column{
+		name:  projection.AppOIDCConfigColumnBackChannelClientNotificationURI,
+		table: appOIDCConfigsTable,
+	}
 )
 
 func (q *Queries) AppByProjectAndAppID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string) (app *App, err error) {
@@ -537,11 +587,21 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequest.identifier(),
+			AppOIDCConfigColumnRequireSignedRequest.identifier(),
+			AppOIDCConfigColumnDPoPBoundAccessTokens.identifier(),
+			AppOIDCConfigColumnForceRSASignedTokens.identifier(),
+			AppOIDCConfigColumnBackChannelClientNotificationURI.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
 			AppSAMLConfigColumnMetadata.identifier(),
 			AppSAMLConfigColumnMetadataURL.identifier(),
+			AppSAMLConfigColumnEncryptAssertions.identifier(),
+			AppSAMLConfigColumnArtifactBinding.identifier(),
+			AppSAMLConfigColumnSingleLogout.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
 			LeftJoin(join(AppOIDCConfigColumnAppID, AppColumnID)).
@@ -586,11 +646,21 @@ func prepareAppQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder,
 				&oidcConfig.clockSkew,
 				&oidcConfig.additionalOrigins,
 				&oidcConfig.skipNativeAppSuccessPage,
+				&oidcConfig.backChannelLogoutURI,
+				&oidcConfig.frontChannelLogoutURI,
+				&oidcConfig.requirePushedAuthRequest,
+				&oidcConfig.requireSignedRequest,
+				&oidcConfig.dpopBoundAccessTokens,
+				&oidcConfig.forceRSASignedTokens,
+				&oidcConfig.backChannelClientNotificationURI,
 
 				&samlConfig.appID,
 				&samlConfig.entityID,
 				&samlConfig.metadata,
 				&samlConfig.metadataURL,
+				&samlConfig.encryptAssertions,
+				&samlConfig.artifactBinding,
+				&samlConfig.singleLogout,
 			)
 
 			if err != nil {
@@ -707,11 +777,21 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 			AppOIDCConfigColumnClockSkew.identifier(),
 			AppOIDCConfigColumnAdditionalOrigins.identifier(),
 			AppOIDCConfigColumnSkipNativeAppSuccessPage.identifier(),
+			AppOIDCConfigColumnBackChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnFrontChannelLogoutURI.identifier(),
+			AppOIDCConfigColumnRequirePushedAuthRequest.identifier(),
+			AppOIDCConfigColumnRequireSignedRequest.identifier(),
+			AppOIDCConfigColumnDPoPBoundAccessTokens.identifier(),
+			AppOIDCConfigColumnForceRSASignedTokens.identifier(),
+			AppOIDCConfigColumnBackChannelClientNotificationURI.identifier(),
 
 			AppSAMLConfigColumnAppID.identifier(),
 			AppSAMLConfigColumnEntityID.identifier(),
 			AppSAMLConfigColumnMetadata.identifier(),
 			AppSAMLConfigColumnMetadataURL.identifier(),
+			AppSAMLConfigColumnEncryptAssertions.identifier(),
+			AppSAMLConfigColumnArtifactBinding.identifier(),
+			AppSAMLConfigColumnSingleLogout.identifier(),
 			countColumn.identifier(),
 		).From(appsTable.identifier()).
 			LeftJoin(join(AppAPIConfigColumnAppID, AppColumnID)).
@@ -759,11 +839,21 @@ func prepareAppsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder
 					&oidcConfig.clockSkew,
 					&oidcConfig.additionalOrigins,
 					&oidcConfig.skipNativeAppSuccessPage,
+					&oidcConfig.backChannelLogoutURI,
+					&oidcConfig.frontChannelLogoutURI,
+					&oidcConfig.requirePushedAuthRequest,
+					&oidcConfig.requireSignedRequest,
+					&oidcConfig.dpopBoundAccessTokens,
+					&oidcConfig.forceRSASignedTokens,
+					&oidcConfig.backChannelClientNotificationURI,
 
 					&samlConfig.appID,
 					&samlConfig.entityID,
 					&samlConfig.metadata,
 					&samlConfig.metadataURL,
+					&samlConfig.encryptAssertions,
+					&samlConfig.artifactBinding,
+					&samlConfig.singleLogout,
 
 					&apps.Count,
 				)
@@ -814,23 +904,30 @@ func prepareClientIDsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBu
 }
 
 type sqlOIDCConfig struct {
-	appID                    sql.NullString
-	version                  sql.NullInt32
-	clientID                 sql.NullString
-	redirectUris             database.TextArray[string]
-	applicationType          sql.NullInt16
-	authMethodType           sql.NullInt16
-	postLogoutRedirectUris   database.TextArray[string]
-	devMode                  sql.NullBool
-	accessTokenType          sql.NullInt16
-	accessTokenRoleAssertion sql.NullBool
-	iDTokenRoleAssertion     sql.NullBool
-	iDTokenUserinfoAssertion sql.NullBool
-	clockSkew                sql.NullInt64
-	additionalOrigins        database.TextArray[string]
-	responseTypes            database.Array[domain.OIDCResponseType]
-	grantTypes               database.Array[domain.OIDCGrantType]
-	skipNativeAppSuccessPage sql.NullBool
+	appID                            sql.NullString
+	version                          sql.NullInt32
+	clientID                         sql.NullString
+	redirectUris                     database.TextArray[string]
+	applicationType                  sql.NullInt16
+	authMethodType                   sql.NullInt16
+	postLogoutRedirectUris           database.TextArray[string]
+	devMode                          sql.NullBool
+	accessTokenType                  sql.NullInt16
+	accessTokenRoleAssertion         sql.NullBool
+	iDTokenRoleAssertion             sql.NullBool
+	iDTokenUserinfoAssertion         sql.NullBool
+	clockSkew                        sql.NullInt64
+	additionalOrigins                database.TextArray[string]
+	responseTypes                    database.Array[domain.OIDCResponseType]
+	grantTypes                       database.Array[domain.OIDCGrantType]
+	skipNativeAppSuccessPage         sql.NullBool
+	backChannelLogoutURI             sql.NullString
+	frontChannelLogoutURI            sql.NullString
+	requirePushedAuthRequest         sql.NullBool
+	requireSignedRequest             sql.NullBool
+	dpopBoundAccessTokens            sql.NullBool
+	forceRSASignedTokens             sql.NullBool
+	backChannelClientNotificationURI sql.NullString
 }
 
 func (c sqlOIDCConfig) set(app *App) {
@@ -838,22 +935,29 @@ func (c sqlOIDCConfig) set(app *App) {
 		return
 	}
 	app.OIDCConfig = &OIDCApp{
-		Version:                  domain.OIDCVersion(c.version.Int32),
-		ClientID:                 c.clientID.String,
-		RedirectURIs:             c.redirectUris,
-		AppType:                  domain.OIDCApplicationType(c.applicationType.Int16),
-		AuthMethodType:           domain.OIDCAuthMethodType(c.authMethodType.Int16),
-		PostLogoutRedirectURIs:   c.postLogoutRedirectUris,
-		IsDevMode:                c.devMode.Bool,
-		AccessTokenType:          domain.OIDCTokenType(c.accessTokenType.Int16),
-		AssertAccessTokenRole:    c.accessTokenRoleAssertion.Bool,
-		AssertIDTokenRole:        c.iDTokenRoleAssertion.Bool,
-		AssertIDTokenUserinfo:    c.iDTokenUserinfoAssertion.Bool,
-		ClockSkew:                time.Duration(c.clockSkew.Int64),
-		AdditionalOrigins:        c.additionalOrigins,
-		ResponseTypes:            c.responseTypes,
-		GrantTypes:               c.grantTypes,
-		SkipNativeAppSuccessPage: c.skipNativeAppSuccessPage.Bool,
+		Version:                          domain.OIDCVersion(c.version.Int32),
+		ClientID:                         c.clientID.String,
+		RedirectURIs:                     c.redirectUris,
+		AppType:                          domain.OIDCApplicationType(c.applicationType.Int16),
+		AuthMethodType:                   domain.OIDCAuthMethodType(c.authMethodType.Int16),
+		PostLogoutRedirectURIs:           c.postLogoutRedirectUris,
+		IsDevMode:                        c.devMode.Bool,
+		AccessTokenType:                  domain.OIDCTokenType(c.accessTokenType.Int16),
+		AssertAccessTokenRole:            c.accessTokenRoleAssertion.Bool,
+		AssertIDTokenRole:                c.iDTokenRoleAssertion.Bool,
+		AssertIDTokenUserinfo:            c.iDTokenUserinfoAssertion.Bool,
+		ClockSkew:                        time.Duration(c.clockSkew.Int64),
+		AdditionalOrigins:                c.additionalOrigins,
+		ResponseTypes:                    c.responseTypes,
+		GrantTypes:                       c.grantTypes,
+		SkipNativeAppSuccessPage:         c.skipNativeAppSuccessPage.Bool,
+		BackChannelLogoutURI:             c.backChannelLogoutURI.String,
+		FrontChannelLogoutURI:            c.frontChannelLogoutURI.String,
+		RequirePushedAuthRequest:         c.requirePushedAuthRequest.Bool,
+		RequireSignedRequest:             c.requireSignedRequest.Bool,
+		DPoPBoundAccessTokens:            c.dpopBoundAccessTokens.Bool,
+		ForceRSASignedTokens:             c.forceRSASignedTokens.Bool,
+		BackChannelClientNotificationURI: c.backChannelClientNotificationURI.String,
 	}
 	compliance := domain.GetOIDCCompliance(app.OIDCConfig.Version, app.OIDCConfig.AppType, app.OIDCConfig.GrantTypes, app.OIDCConfig.ResponseTypes, app.OIDCConfig.AuthMethodType, app.OIDCConfig.RedirectURIs)
 	app.OIDCConfig.ComplianceProblems = compliance.Problems
@@ -864,10 +968,13 @@ func (c sqlOIDCConfig) set(app *App) {
 }
 
 type sqlSAMLConfig struct {
-	appID       sql.NullString
-	entityID    sql.NullString
-	metadataURL sql.NullString
-	metadata    []byte
+	appID             sql.NullString
+	entityID          sql.NullString
+	metadataURL       sql.NullString
+	metadata          []byte
+	encryptAssertions sql.NullBool
+	artifactBinding   sql.NullBool
+	singleLogout      sql.NullBool
 }
 
 func (c sqlSAMLConfig) set(app *App) {
@@ -875,9 +982,12 @@ func (c sqlSAMLConfig) set(app *App) {
 		return
 	}
 	app.SAMLConfig = &SAMLApp{
-		MetadataURL: c.metadataURL.String,
-		Metadata:    c.metadata,
-		EntityID:    c.entityID.String,
+		MetadataURL:       c.metadataURL.String,
+		Metadata:          c.metadata,
+		EntityID:          c.entityID.String,
+		EncryptAssertions: c.encryptAssertions.Bool,
+		ArtifactBinding:   c.artifactBinding.Bool,
+		SingleLogout:      c.singleLogout.Bool,
 	}
 }
 
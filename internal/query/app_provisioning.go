@@ -0,0 +1,425 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	appProvisioningsTable = table{
+		name:          projection.AppProvisioningProjectionTable,
+		instanceIDCol: projection.AppProvisioningColumnInstanceID,
+	}
+	AppProvisioningColumnAppID = Column{
+		name:  projection.AppProvisioningColumnAppID,
+		table: appProvisioningsTable,
+	}
+	AppProvisioningColumnProjectID = Column{
+		name:  projection.AppProvisioningColumnProjectID,
+		table: appProvisioningsTable,
+	}
+	AppProvisioningColumnInstanceID = Column{
+		name:  projection.AppProvisioningColumnInstanceID,
+		table: appProvisioningsTable,
+	}
+	AppProvisioningColumnResourceOwner = Column{
+		name:  projection.AppProvisioningColumnResourceOwner,
+		table: appProvisioningsTable,
+	}
+	AppProvisioningColumnCreationDate = Column{
+		name:  projection.AppProvisioningColumnCreationDate,
+		table: appProvisioningsTable,
+	}
+	AppProvisioningColumnChangeDate = Column{
+		name:  projection.AppProvisioningColumnChangeDate,
+		table: appProvisioningsTable,
+	}
+	AppProvisioningColumnSequence = Column{
+		name:  projection.AppProvisioningColumnSequence,
+		table: appProvisioningsTable,
+	}
+	AppProvisioningColumnEndpoint = Column{
+		name:  projection.AppProvisioningColumnEndpoint,
+		table: appProvisioningsTable,
+	}
+	AppProvisioningColumnToken = Column{
+		name:  projection.AppProvisioningColumnToken,
+		table: appProvisioningsTable,
+	}
+)
+
+var (
+	// the logs are written by the provisioning handler, its state is returned with the logs
+	appProvisioningDeliveryState = table{
+		name: projection.AppProvisioningDeliveryHandlerName,
+	}
+	appProvisioningLogsTable = table{
+		name:          projection.AppProvisioningLogTable,
+		instanceIDCol: projection.AppProvisioningLogColumnInstanceID,
+	}
+	AppProvisioningLogColumnInstanceID = Column{
+		name:  projection.AppProvisioningLogColumnInstanceID,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnAppID = Column{
+		name:  projection.AppProvisioningLogColumnAppID,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnProjectID = Column{
+		name:  projection.AppProvisioningLogColumnProjectID,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnResourceOwner = Column{
+		name:  projection.AppProvisioningLogColumnResourceOwner,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnUserID = Column{
+		name:  projection.AppProvisioningLogColumnUserID,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnAggregateID = Column{
+		name:  projection.AppProvisioningLogColumnAggregateID,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnSequence = Column{
+		name:  projection.AppProvisioningLogColumnSequence,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnEventType = Column{
+		name:  projection.AppProvisioningLogColumnEventType,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnCreationDate = Column{
+		name:  projection.AppProvisioningLogColumnCreationDate,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnOperation = Column{
+		name:  projection.AppProvisioningLogColumnOperation,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnSucceeded = Column{
+		name:  projection.AppProvisioningLogColumnSucceeded,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnAttempts = Column{
+		name:  projection.AppProvisioningLogColumnAttempts,
+		table: appProvisioningLogsTable,
+	}
+	AppProvisioningLogColumnError = Column{
+		name:  projection.AppProvisioningLogColumnError,
+		table: appProvisioningLogsTable,
+	}
+)
+
+type AppProvisionings struct {
+	SearchResponse
+	AppProvisionings []*AppProvisioning
+}
+
+type AppProvisioning struct {
+	AppID         string
+	ProjectID     string
+	ResourceOwner string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+
+	Endpoint string
+	Token    *crypto.CryptoValue
+}
+
+type AppProvisioningLogs struct {
+	SearchResponse
+	Logs []*AppProvisioningLog
+}
+
+// AppProvisioningLog is a single delivery of a user to the service provider of an application
+type AppProvisioningLog struct {
+	AppID         string
+	ProjectID     string
+	ResourceOwner string
+	UserID        string
+	AggregateID   string
+	Sequence      uint64
+	EventType     string
+	CreationDate  time.Time
+
+	Operation domain.AppProvisioningOperation
+	Succeeded bool
+	Attempts  uint16
+	Error     string
+}
+
+type AppProvisioningLogSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *AppProvisioningLogSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) AppProvisioningByID(ctx context.Context, shouldTriggerBulk bool, projectID, appID string) (provisioning *AppProvisioning, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerAppProvisioningProjection")
+		ctx, err = projection.AppProvisioningProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+
+	stmt, scan := prepareAppProvisioningQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		AppProvisioningColumnAppID.identifier():      appID,
+		AppProvisioningColumnProjectID.identifier():  projectID,
+		AppProvisioningColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Uu4ie", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		provisioning, err = scan(row)
+		return err
+	}, query, args...)
+	return provisioning, err
+}
+
+// AppProvisioningsByProjectID returns the provisioning configurations of all apps of the project
+func (q *Queries) AppProvisioningsByProjectID(ctx context.Context, shouldTriggerBulk bool, projectID string) (provisionings *AppProvisionings, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerAppProvisioningProjection")
+		ctx, err = projection.AppProvisioningProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+
+	stmt, scan := prepareAppProvisioningsQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		AppProvisioningColumnProjectID.identifier():  projectID,
+		AppProvisioningColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-eeT1o", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		provisionings, err = scan(rows)
+		return err
+	}, query, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Sai3e", "Errors.Internal")
+	}
+	provisionings.State, err = q.latestState(ctx, appProvisioningsTable)
+	return provisionings, err
+}
+
+func (q *Queries) SearchAppProvisioningLogs(ctx context.Context, queries *AppProvisioningLogSearchQueries) (logs *AppProvisioningLogs, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareAppProvisioningLogsQuery(ctx, q.client)
+	if queries.SortingColumn.isZero() {
+		query = query.OrderBy(AppProvisioningLogColumnCreationDate.identifier() + " DESC")
+	}
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		AppProvisioningLogColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ohg4a", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		logs, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ua5Ie", "Errors.Internal")
+	}
+
+	logs.State, err = q.latestState(ctx, appProvisioningDeliveryState)
+	return logs, err
+}
+
+func NewAppProvisioningLogAppIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(AppProvisioningLogColumnAppID, id, TextEquals)
+}
+
+func NewAppProvisioningLogProjectIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(AppProvisioningLogColumnProjectID, id, TextEquals)
+}
+
+func NewAppProvisioningLogUserIDSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(AppProvisioningLogColumnUserID, id, TextEquals)
+}
+
+func NewAppProvisioningLogResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(AppProvisioningLogColumnResourceOwner, id, TextEquals)
+}
+
+func NewAppProvisioningLogSucceededSearchQuery(succeeded bool) (SearchQuery, error) {
+	return NewBoolQuery(AppProvisioningLogColumnSucceeded, succeeded)
+}
+
+func prepareAppProvisioningQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*AppProvisioning, error)) {
+	return sq.Select(
+			AppProvisioningColumnAppID.identifier(),
+			AppProvisioningColumnProjectID.identifier(),
+			AppProvisioningColumnResourceOwner.identifier(),
+			AppProvisioningColumnCreationDate.identifier(),
+			AppProvisioningColumnChangeDate.identifier(),
+			AppProvisioningColumnSequence.identifier(),
+			AppProvisioningColumnEndpoint.identifier(),
+			AppProvisioningColumnToken.identifier(),
+		).From(appProvisioningsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*AppProvisioning, error) {
+			provisioning := new(AppProvisioning)
+			err := row.Scan(
+				&provisioning.AppID,
+				&provisioning.ProjectID,
+				&provisioning.ResourceOwner,
+				&provisioning.CreationDate,
+				&provisioning.ChangeDate,
+				&provisioning.Sequence,
+				&provisioning.Endpoint,
+				&provisioning.Token,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-oP6ee", "Errors.Project.App.Provisioning.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-Ahz3u", "Errors.Internal")
+			}
+			return provisioning, nil
+		}
+}
+
+func prepareAppProvisioningsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*AppProvisionings, error)) {
+	return sq.Select(
+			AppProvisioningColumnAppID.identifier(),
+			AppProvisioningColumnProjectID.identifier(),
+			AppProvisioningColumnResourceOwner.identifier(),
+			AppProvisioningColumnCreationDate.identifier(),
+			AppProvisioningColumnChangeDate.identifier(),
+			AppProvisioningColumnSequence.identifier(),
+			AppProvisioningColumnEndpoint.identifier(),
+			AppProvisioningColumnToken.identifier(),
+			countColumn.identifier(),
+		).From(appProvisioningsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*AppProvisionings, error) {
+			provisionings := make([]*AppProvisioning, 0)
+			var count uint64
+			for rows.Next() {
+				provisioning := new(AppProvisioning)
+				err := rows.Scan(
+					&provisioning.AppID,
+					&provisioning.ProjectID,
+					&provisioning.ResourceOwner,
+					&provisioning.CreationDate,
+					&provisioning.ChangeDate,
+					&provisioning.Sequence,
+					&provisioning.Endpoint,
+					&provisioning.Token,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				provisionings = append(provisionings, provisioning)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Eeph8", "Errors.Query.CloseRows")
+			}
+
+			return &AppProvisionings{
+				AppProvisionings: provisionings,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareAppProvisioningLogsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*AppProvisioningLogs, error)) {
+	return sq.Select(
+			AppProvisioningLogColumnAppID.identifier(),
+			AppProvisioningLogColumnProjectID.identifier(),
+			AppProvisioningLogColumnResourceOwner.identifier(),
+			AppProvisioningLogColumnUserID.identifier(),
+			AppProvisioningLogColumnAggregateID.identifier(),
+			AppProvisioningLogColumnSequence.identifier(),
+			AppProvisioningLogColumnEventType.identifier(),
+			AppProvisioningLogColumnCreationDate.identifier(),
+			AppProvisioningLogColumnOperation.identifier(),
+			AppProvisioningLogColumnSucceeded.identifier(),
+			AppProvisioningLogColumnAttempts.identifier(),
+			AppProvisioningLogColumnError.identifier(),
+			countColumn.identifier(),
+		).From(appProvisioningLogsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*AppProvisioningLogs, error) {
+			logs := make([]*AppProvisioningLog, 0)
+			var count uint64
+			for rows.Next() {
+				log := new(AppProvisioningLog)
+				var deliveryErr sql.NullString
+				err := rows.Scan(
+					&log.AppID,
+					&log.ProjectID,
+					&log.ResourceOwner,
+					&log.UserID,
+					&log.AggregateID,
+					&log.Sequence,
+					&log.EventType,
+					&log.CreationDate,
+					&log.Operation,
+					&log.Succeeded,
+					&log.Attempts,
+					&deliveryErr,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				log.Error = deliveryErr.String
+				logs = append(logs, log)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Ie0ai", "Errors.Query.CloseRows")
+			}
+
+			return &AppProvisioningLogs{
+				Logs: logs,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
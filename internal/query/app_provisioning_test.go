@@ -0,0 +1,258 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareAppProvisioningStmt = `SELECT projections.app_provisionings.app_id,` +
+		` projections.app_provisionings.project_id,` +
+		` projections.app_provisionings.resource_owner,` +
+		` projections.app_provisionings.creation_date,` +
+		` projections.app_provisionings.change_date,` +
+		` projections.app_provisionings.sequence,` +
+		` projections.app_provisionings.endpoint,` +
+		` projections.app_provisionings.token` +
+		` FROM projections.app_provisionings`
+	prepareAppProvisioningCols = []string{
+		"app_id",
+		"project_id",
+		"resource_owner",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"endpoint",
+		"token",
+	}
+
+	prepareAppProvisioningLogsStmt = `SELECT projections.app_provisionings_logs.app_id,` +
+		` projections.app_provisionings_logs.project_id,` +
+		` projections.app_provisionings_logs.resource_owner,` +
+		` projections.app_provisionings_logs.user_id,` +
+		` projections.app_provisionings_logs.aggregate_id,` +
+		` projections.app_provisionings_logs.sequence,` +
+		` projections.app_provisionings_logs.event_type,` +
+		` projections.app_provisionings_logs.creation_date,` +
+		` projections.app_provisionings_logs.operation,` +
+		` projections.app_provisionings_logs.succeeded,` +
+		` projections.app_provisionings_logs.attempts,` +
+		` projections.app_provisionings_logs.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.app_provisionings_logs`
+	prepareAppProvisioningLogsCols = []string{
+		"app_id",
+		"project_id",
+		"resource_owner",
+		"user_id",
+		"aggregate_id",
+		"sequence",
+		"event_type",
+		"creation_date",
+		"operation",
+		"succeeded",
+		"attempts",
+		"error",
+		"count",
+	}
+)
+
+func Test_AppProvisioningPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareAppProvisioningQuery no result",
+			prepare: prepareAppProvisioningQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareAppProvisioningStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AppProvisioning)(nil),
+		},
+		{
+			name:    "prepareAppProvisioningQuery found",
+			prepare: prepareAppProvisioningQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareAppProvisioningStmt),
+					prepareAppProvisioningCols,
+					[]driver.Value{
+						"app-id",
+						"project-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						"https://sp.example.com/scim/v2",
+						[]byte(`{"cryptoType":0,"algorithm":"enc","keyID":"id","crypted":"dG9rZW4="}`),
+					},
+				),
+			},
+			object: &AppProvisioning{
+				AppID:         "app-id",
+				ProjectID:     "project-id",
+				ResourceOwner: "ro",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211109,
+				Endpoint:      "https://sp.example.com/scim/v2",
+				Token: &crypto.CryptoValue{
+					CryptoType: crypto.TypeEncryption,
+					Algorithm:  "enc",
+					KeyID:      "id",
+					Crypted:    []byte("token"),
+				},
+			},
+		},
+		{
+			name:    "prepareAppProvisioningQuery sql err",
+			prepare: prepareAppProvisioningQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareAppProvisioningStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AppProvisioning)(nil),
+		},
+		{
+			name:    "prepareAppProvisioningLogsQuery no result",
+			prepare: prepareAppProvisioningLogsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareAppProvisioningLogsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &AppProvisioningLogs{Logs: []*AppProvisioningLog{}},
+		},
+		{
+			name:    "prepareAppProvisioningLogsQuery multiple result",
+			prepare: prepareAppProvisioningLogsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareAppProvisioningLogsStmt),
+					prepareAppProvisioningLogsCols,
+					[][]driver.Value{
+						{
+							"app-id",
+							"project-id",
+							"ro",
+							"user-id",
+							"grant-id",
+							uint64(20211109),
+							"user.grant.added",
+							testNow,
+							domain.AppProvisioningOperationCreate,
+							true,
+							1,
+							nil,
+						},
+						{
+							"app-id",
+							"project-id",
+							"ro",
+							"user-id",
+							"user-id",
+							uint64(20211110),
+							"user.human.profile.changed",
+							testNow,
+							domain.AppProvisioningOperationUpdate,
+							false,
+							3,
+							"unexpected status 503",
+						},
+					},
+				),
+			},
+			object: &AppProvisioningLogs{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Logs: []*AppProvisioningLog{
+					{
+						AppID:         "app-id",
+						ProjectID:     "project-id",
+						ResourceOwner: "ro",
+						UserID:        "user-id",
+						AggregateID:   "grant-id",
+						Sequence:      20211109,
+						EventType:     "user.grant.added",
+						CreationDate:  testNow,
+						Operation:     domain.AppProvisioningOperationCreate,
+						Succeeded:     true,
+						Attempts:      1,
+					},
+					{
+						AppID:         "app-id",
+						ProjectID:     "project-id",
+						ResourceOwner: "ro",
+						UserID:        "user-id",
+						AggregateID:   "user-id",
+						Sequence:      20211110,
+						EventType:     "user.human.profile.changed",
+						CreationDate:  testNow,
+						Operation:     domain.AppProvisioningOperationUpdate,
+						Succeeded:     false,
+						Attempts:      3,
+						Error:         "unexpected status 503",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareAppProvisioningLogsQuery sql err",
+			prepare: prepareAppProvisioningLogsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareAppProvisioningLogsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*AppProvisioningLogs)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -15,98 +15,118 @@ import (
 )
 
 var (
-	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps6.id,` +
-		` projections.apps6.name,` +
-		` projections.apps6.project_id,` +
-		` projections.apps6.creation_date,` +
-		` projections.apps6.change_date,` +
-		` projections.apps6.resource_owner,` +
-		` projections.apps6.state,` +
-		` projections.apps6.sequence,` +
+	expectedAppQuery = regexp.QuoteMeta(`SELECT projections.apps12.id,` +
+		` projections.apps12.name,` +
+		` projections.apps12.project_id,` +
+		` projections.apps12.creation_date,` +
+		` projections.apps12.change_date,` +
+		` projections.apps12.resource_owner,` +
+		` projections.apps12.state,` +
+		` projections.apps12.sequence,` +
 		// api config
-		` projections.apps6_api_configs.app_id,` +
-		` projections.apps6_api_configs.client_id,` +
-		` projections.apps6_api_configs.auth_method,` +
+		` projections.apps12_api_configs.app_id,` +
+		` projections.apps12_api_configs.client_id,` +
+		` projections.apps12_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps6_oidc_configs.app_id,` +
-		` projections.apps6_oidc_configs.version,` +
-		` projections.apps6_oidc_configs.client_id,` +
-		` projections.apps6_oidc_configs.redirect_uris,` +
-		` projections.apps6_oidc_configs.response_types,` +
-		` projections.apps6_oidc_configs.grant_types,` +
-		` projections.apps6_oidc_configs.application_type,` +
-		` projections.apps6_oidc_configs.auth_method_type,` +
-		` projections.apps6_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps6_oidc_configs.is_dev_mode,` +
-		` projections.apps6_oidc_configs.access_token_type,` +
-		` projections.apps6_oidc_configs.access_token_role_assertion,` +
-		` projections.apps6_oidc_configs.id_token_role_assertion,` +
-		` projections.apps6_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps6_oidc_configs.clock_skew,` +
-		` projections.apps6_oidc_configs.additional_origins,` +
-		` projections.apps6_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps12_oidc_configs.app_id,` +
+		` projections.apps12_oidc_configs.version,` +
+		` projections.apps12_oidc_configs.client_id,` +
+		` projections.apps12_oidc_configs.redirect_uris,` +
+		` projections.apps12_oidc_configs.response_types,` +
+		` projections.apps12_oidc_configs.grant_types,` +
+		` projections.apps12_oidc_configs.application_type,` +
+		` projections.apps12_oidc_configs.auth_method_type,` +
+		` projections.apps12_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps12_oidc_configs.is_dev_mode,` +
+		` projections.apps12_oidc_configs.access_token_type,` +
+		` projections.apps12_oidc_configs.access_token_role_assertion,` +
+		` projections.apps12_oidc_configs.id_token_role_assertion,` +
+		` projections.apps12_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps12_oidc_configs.clock_skew,` +
+		` projections.apps12_oidc_configs.additional_origins,` +
+		` projections.apps12_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps12_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps12_oidc_configs.front_channel_logout_uri,` +
+		` projections.apps12_oidc_configs.require_pushed_auth_request,` +
+		` projections.apps12_oidc_configs.require_signed_request,` +
+		` projections.apps12_oidc_configs.dpop_bound_access_tokens,` +
+		` projections.apps12_oidc_configs.force_rsa_signed_tokens,` +
+		` projections.apps12_oidc_configs.back_channel_client_notification_uri,` +
 		//saml config
-		` projections.apps6_saml_configs.app_id,` +
-		` projections.apps6_saml_configs.entity_id,` +
-		` projections.apps6_saml_configs.metadata,` +
-		` projections.apps6_saml_configs.metadata_url` +
-		` FROM projections.apps6` +
-		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
-		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
+		` projections.apps12_saml_configs.app_id,` +
+		` projections.apps12_saml_configs.entity_id,` +
+		` projections.apps12_saml_configs.metadata,` +
+		` projections.apps12_saml_configs.metadata_url,` +
+		` projections.apps12_saml_configs.encrypt_assertions,` +
+		` projections.apps12_saml_configs.artifact_binding,` +
+		` projections.apps12_saml_configs.single_logout` +
+		` FROM projections.apps12` +
+		` LEFT JOIN projections.apps12_api_configs ON projections.apps12.id = projections.apps12_api_configs.app_id AND projections.apps12.instance_id = projections.apps12_api_configs.instance_id` +
+		` LEFT JOIN projections.apps12_oidc_configs ON projections.apps12.id = projections.apps12_oidc_configs.app_id AND projections.apps12.instance_id = projections.apps12_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps12_saml_configs ON projections.apps12.id = projections.apps12_saml_configs.app_id AND projections.apps12.instance_id = projections.apps12_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps6.id,` +
-		` projections.apps6.name,` +
-		` projections.apps6.project_id,` +
-		` projections.apps6.creation_date,` +
-		` projections.apps6.change_date,` +
-		` projections.apps6.resource_owner,` +
-		` projections.apps6.state,` +
-		` projections.apps6.sequence,` +
+	expectedAppsQuery = regexp.QuoteMeta(`SELECT projections.apps12.id,` +
+		` projections.apps12.name,` +
+		` projections.apps12.project_id,` +
+		` projections.apps12.creation_date,` +
+		` projections.apps12.change_date,` +
+		` projections.apps12.resource_owner,` +
+		` projections.apps12.state,` +
+		` projections.apps12.sequence,` +
 		// api config
-		` projections.apps6_api_configs.app_id,` +
-		` projections.apps6_api_configs.client_id,` +
-		` projections.apps6_api_configs.auth_method,` +
+		` projections.apps12_api_configs.app_id,` +
+		` projections.apps12_api_configs.client_id,` +
+		` projections.apps12_api_configs.auth_method,` +
 		// oidc config
-		` projections.apps6_oidc_configs.app_id,` +
-		` projections.apps6_oidc_configs.version,` +
-		` projections.apps6_oidc_configs.client_id,` +
-		` projections.apps6_oidc_configs.redirect_uris,` +
-		` projections.apps6_oidc_configs.response_types,` +
-		` projections.apps6_oidc_configs.grant_types,` +
-		` projections.apps6_oidc_configs.application_type,` +
-		` projections.apps6_oidc_configs.auth_method_type,` +
-		` projections.apps6_oidc_configs.post_logout_redirect_uris,` +
-		` projections.apps6_oidc_configs.is_dev_mode,` +
-		` projections.apps6_oidc_configs.access_token_type,` +
-		` projections.apps6_oidc_configs.access_token_role_assertion,` +
-		` projections.apps6_oidc_configs.id_token_role_assertion,` +
-		` projections.apps6_oidc_configs.id_token_userinfo_assertion,` +
-		` projections.apps6_oidc_configs.clock_skew,` +
-		` projections.apps6_oidc_configs.additional_origins,` +
-		` projections.apps6_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps12_oidc_configs.app_id,` +
+		` projections.apps12_oidc_configs.version,` +
+		` projections.apps12_oidc_configs.client_id,` +
+		` projections.apps12_oidc_configs.redirect_uris,` +
+		` projections.apps12_oidc_configs.response_types,` +
+		` projections.apps12_oidc_configs.grant_types,` +
+		` projections.apps12_oidc_configs.application_type,` +
+		` projections.apps12_oidc_configs.auth_method_type,` +
+		` projections.apps12_oidc_configs.post_logout_redirect_uris,` +
+		` projections.apps12_oidc_configs.is_dev_mode,` +
+		` projections.apps12_oidc_configs.access_token_type,` +
+		` projections.apps12_oidc_configs.access_token_role_assertion,` +
+		` projections.apps12_oidc_configs.id_token_role_assertion,` +
+		` projections.apps12_oidc_configs.id_token_userinfo_assertion,` +
+		` projections.apps12_oidc_configs.clock_skew,` +
+		` projections.apps12_oidc_configs.additional_origins,` +
+		` projections.apps12_oidc_configs.skip_native_app_success_page,` +
+		` projections.apps12_oidc_configs.back_channel_logout_uri,` +
+		` projections.apps12_oidc_configs.front_channel_logout_uri,` +
+		` projections.apps12_oidc_configs.require_pushed_auth_request,` +
+		` projections.apps12_oidc_configs.require_signed_request,` +
+		` projections.apps12_oidc_configs.dpop_bound_access_tokens,` +
+		` projections.apps12_oidc_configs.force_rsa_signed_tokens,` +
+		` projections.apps12_oidc_configs.back_channel_client_notification_uri,` +
 		//saml config
-		` projections.apps6_saml_configs.app_id,` +
-		` projections.apps6_saml_configs.entity_id,` +
-		` projections.apps6_saml_configs.metadata,` +
-		` projections.apps6_saml_configs.metadata_url,` +
+		` projections.apps12_saml_configs.app_id,` +
+		` projections.apps12_saml_configs.entity_id,` +
+		` projections.apps12_saml_configs.metadata,` +
+		` projections.apps12_saml_configs.metadata_url,` +
+		` projections.apps12_saml_configs.encrypt_assertions,` +
+		` projections.apps12_saml_configs.artifact_binding,` +
+		` projections.apps12_saml_configs.single_logout,` +
 		` COUNT(*) OVER ()` +
-		` FROM projections.apps6` +
-		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
-		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
+		` FROM projections.apps12` +
+		` LEFT JOIN projections.apps12_api_configs ON projections.apps12.id = projections.apps12_api_configs.app_id AND projections.apps12.instance_id = projections.apps12_api_configs.instance_id` +
+		` LEFT JOIN projections.apps12_oidc_configs ON projections.apps12.id = projections.apps12_oidc_configs.app_id AND projections.apps12.instance_id = projections.apps12_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps12_saml_configs ON projections.apps12.id = projections.apps12_saml_configs.app_id AND projections.apps12.instance_id = projections.apps12_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps6_api_configs.client_id,` +
-		` projections.apps6_oidc_configs.client_id` +
-		` FROM projections.apps6` +
-		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
-		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
+	expectedAppIDsQuery = regexp.QuoteMeta(`SELECT projections.apps12_api_configs.client_id,` +
+		` projections.apps12_oidc_configs.client_id` +
+		` FROM projections.apps12` +
+		` LEFT JOIN projections.apps12_api_configs ON projections.apps12.id = projections.apps12_api_configs.app_id AND projections.apps12.instance_id = projections.apps12_api_configs.instance_id` +
+		` LEFT JOIN projections.apps12_oidc_configs ON projections.apps12.id = projections.apps12_oidc_configs.app_id AND projections.apps12.instance_id = projections.apps12_oidc_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
-	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps6.project_id` +
-		` FROM projections.apps6` +
-		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
-		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
+	expectedProjectIDByAppQuery = regexp.QuoteMeta(`SELECT projections.apps12.project_id` +
+		` FROM projections.apps12` +
+		` LEFT JOIN projections.apps12_api_configs ON projections.apps12.id = projections.apps12_api_configs.app_id AND projections.apps12.instance_id = projections.apps12_api_configs.instance_id` +
+		` LEFT JOIN projections.apps12_oidc_configs ON projections.apps12.id = projections.apps12_oidc_configs.app_id AND projections.apps12.instance_id = projections.apps12_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps12_saml_configs ON projections.apps12.id = projections.apps12_saml_configs.app_id AND projections.apps12.instance_id = projections.apps12_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 	expectedProjectByAppQuery = regexp.QuoteMeta(`SELECT projections.projects4.id,` +
 		` projections.projects4.creation_date,` +
@@ -120,10 +140,10 @@ var (
 		` projections.projects4.has_project_check,` +
 		` projections.projects4.private_labeling_setting` +
 		` FROM projections.projects4` +
-		` JOIN projections.apps6 ON projections.projects4.id = projections.apps6.project_id AND projections.projects4.instance_id = projections.apps6.instance_id` +
-		` LEFT JOIN projections.apps6_api_configs ON projections.apps6.id = projections.apps6_api_configs.app_id AND projections.apps6.instance_id = projections.apps6_api_configs.instance_id` +
-		` LEFT JOIN projections.apps6_oidc_configs ON projections.apps6.id = projections.apps6_oidc_configs.app_id AND projections.apps6.instance_id = projections.apps6_oidc_configs.instance_id` +
-		` LEFT JOIN projections.apps6_saml_configs ON projections.apps6.id = projections.apps6_saml_configs.app_id AND projections.apps6.instance_id = projections.apps6_saml_configs.instance_id` +
+		` JOIN projections.apps12 ON projections.projects4.id = projections.apps12.project_id AND projections.projects4.instance_id = projections.apps12.instance_id` +
+		` LEFT JOIN projections.apps12_api_configs ON projections.apps12.id = projections.apps12_api_configs.app_id AND projections.apps12.instance_id = projections.apps12_api_configs.instance_id` +
+		` LEFT JOIN projections.apps12_oidc_configs ON projections.apps12.id = projections.apps12_oidc_configs.app_id AND projections.apps12.instance_id = projections.apps12_oidc_configs.instance_id` +
+		` LEFT JOIN projections.apps12_saml_configs ON projections.apps12.id = projections.apps12_saml_configs.app_id AND projections.apps12.instance_id = projections.apps12_saml_configs.instance_id` +
 		` AS OF SYSTEM TIME '-1 ms'`)
 
 	appCols = database.TextArray[string]{
@@ -157,11 +177,21 @@ var (
 		"clock_skew",
 		"additional_origins",
 		"skip_native_app_success_page",
+		"back_channel_logout_uri",
+		"front_channel_logout_uri",
+		"require_pushed_auth_request",
+		"require_signed_request",
+		"dpop_bound_access_tokens",
+		"force_rsa_signed_tokens",
+		"back_channel_client_notification_uri",
 		//saml config
 		"app_id",
 		"entity_id",
 		"metadata",
 		"metadata_url",
+		"encrypt_assertions",
+		"artifact_binding",
+		"single_logout",
 	}
 	appsCols = append(appCols, "count")
 )
@@ -228,11 +258,21 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -294,11 +334,21 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -363,11 +413,21 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							false,
+							false,
+							false,
 						},
 					},
 				),
@@ -434,11 +494,21 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -520,11 +590,21 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -606,11 +686,21 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -692,11 +782,21 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -778,11 +878,21 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -864,11 +974,21 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							true,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -950,11 +1070,21 @@ func Test_AppsPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"api-app-id",
@@ -987,11 +1117,21 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 						{
 							"saml-app-id",
@@ -1024,11 +1164,21 @@ func Test_AppsPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"saml-app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							false,
+							false,
+							false,
 						},
 					},
 				),
@@ -1192,11 +1342,21 @@ func Test_AppPrepare(t *testing.T) {
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
+						nil,
 						// saml config
 						nil,
 						nil,
 						nil,
 						nil,
+						nil,
+						nil,
+						nil,
 					},
 				),
 			},
@@ -1250,11 +1410,21 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1313,11 +1483,21 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1391,11 +1571,21 @@ func Test_AppPrepare(t *testing.T) {
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							"app-id",
 							"https://test.com/saml/metadata",
 							[]byte("<?xml version=\"1.0\"?>\n<md:EntityDescriptor xmlns:md=\"urn:oasis:names:tc:SAML:2.0:metadata\"\n                     validUntil=\"2022-08-26T14:08:16Z\"\n                     cacheDuration=\"PT604800S\"\n                     entityID=\"https://test.com/saml/metadata\">\n    <md:SPSSODescriptor AuthnRequestsSigned=\"false\" WantAssertionsSigned=\"false\" protocolSupportEnumeration=\"urn:oasis:names:tc:SAML:2.0:protocol\">\n        <md:NameIDFormat>urn:oasis:names:tc:SAML:1.1:nameid-format:unspecified</md:NameIDFormat>\n        <md:AssertionConsumerService Binding=\"urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST\"\n                                     Location=\"https://test.com/saml/acs\"\n                                     index=\"1\" />\n        \n    </md:SPSSODescriptor>\n</md:EntityDescriptor>"),
 							"https://test.com/saml/metadata",
+							false,
+							false,
+							false,
 						},
 					},
 				),
@@ -1455,11 +1645,21 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1534,11 +1734,21 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1613,11 +1823,21 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
@@ -1692,11 +1912,21 @@ func Test_AppPrepare(t *testing.T) {
 							1 * time.Second,
 							database.TextArray[string]{"additional.origin"},
 							false,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
+							nil,
 							// saml config
 							nil,
 							nil,
 							nil,
 							nil,
+							nil,
+							nil,
+							nil,
 						},
 					},
 				),
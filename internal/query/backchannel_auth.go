@@ -0,0 +1,179 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	backChannelAuthTable = table{
+		name:          projection.BackChannelAuthProjectionTable,
+		instanceIDCol: projection.BackChannelAuthColumnInstanceID,
+	}
+	BackChannelAuthColumnID = Column{
+		name:  projection.BackChannelAuthColumnID,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnClientID = Column{
+		name:  projection.BackChannelAuthColumnClientID,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnAuthReqID = Column{
+		name:  projection.BackChannelAuthColumnAuthReqID,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnUserID = Column{
+		name:  projection.BackChannelAuthColumnUserID,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnScopes = Column{
+		name:  projection.BackChannelAuthColumnScopes,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnBindingMessage = Column{
+		name:  projection.BackChannelAuthColumnBindingMessage,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnClientNotificationToken = Column{
+		name:  projection.BackChannelAuthColumnClientNotificationToken,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnExpires = Column{
+		name:  projection.BackChannelAuthColumnExpires,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnState = Column{
+		name:  projection.BackChannelAuthColumnState,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnAuthTime = Column{
+		name:  projection.BackChannelAuthColumnAuthTime,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnAuthMethods = Column{
+		name:  projection.BackChannelAuthColumnAuthMethods,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnCreationDate = Column{
+		name:  projection.BackChannelAuthColumnCreationDate,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnChangeDate = Column{
+		name:  projection.BackChannelAuthColumnChangeDate,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnSequence = Column{
+		name:  projection.BackChannelAuthColumnSequence,
+		table: backChannelAuthTable,
+	}
+	BackChannelAuthColumnInstanceID = Column{
+		name:  projection.BackChannelAuthColumnInstanceID,
+		table: backChannelAuthTable,
+	}
+)
+
+// BackChannelAuthByAuthReqID returns the backchannel authentication request the client polls for
+func (q *Queries) BackChannelAuthByAuthReqID(ctx context.Context, clientID, authReqID string) (backChannelAuth *domain.BackChannelAuth, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareBackChannelAuthQuery(ctx, q.client)
+	eq := sq.Eq{
+		BackChannelAuthColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		BackChannelAuthColumnClientID.identifier():   clientID,
+		BackChannelAuthColumnAuthReqID.identifier():  authReqID,
+	}
+	query, args, err := stmt.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ahP2o", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		backChannelAuth, err = scan(row)
+		return err
+	}, query, args...)
+	return backChannelAuth, err
+}
+
+// BackChannelAuthByID returns the backchannel authentication request the user is asked to approve
+func (q *Queries) BackChannelAuthByID(ctx context.Context, id string) (backChannelAuth *domain.BackChannelAuth, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareBackChannelAuthQuery(ctx, q.client)
+	eq := sq.Eq{
+		BackChannelAuthColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+		BackChannelAuthColumnID.identifier():         id,
+	}
+	query, args, err := stmt.Where(eq).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Eiz2a", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		backChannelAuth, err = scan(row)
+		return err
+	}, query, args...)
+	return backChannelAuth, err
+}
+
+var backChannelAuthSelectColumns = []string{
+	BackChannelAuthColumnID.identifier(),
+	BackChannelAuthColumnClientID.identifier(),
+	BackChannelAuthColumnAuthReqID.identifier(),
+	BackChannelAuthColumnUserID.identifier(),
+	BackChannelAuthColumnScopes.identifier(),
+	BackChannelAuthColumnBindingMessage.identifier(),
+	BackChannelAuthColumnClientNotificationToken.identifier(),
+	BackChannelAuthColumnExpires.identifier(),
+	BackChannelAuthColumnState.identifier(),
+	BackChannelAuthColumnAuthTime.identifier(),
+	BackChannelAuthColumnAuthMethods.identifier(),
+}
+
+func prepareBackChannelAuthQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*domain.BackChannelAuth, error)) {
+	return sq.Select(backChannelAuthSelectColumns...).From(backChannelAuthTable.identifier()).PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*domain.BackChannelAuth, error) {
+			dst := new(domain.BackChannelAuth)
+			var (
+				scopes      database.TextArray[string]
+				authTime    sql.NullTime
+				authMethods database.Array[domain.UserAuthMethodType]
+			)
+
+			err := row.Scan(
+				&dst.AggregateID,
+				&dst.ClientID,
+				&dst.AuthReqID,
+				&dst.UserID,
+				&scopes,
+				&dst.BindingMessage,
+				&dst.ClientNotificationToken,
+				&dst.Expires,
+				&dst.State,
+				&authTime,
+				&authMethods,
+			)
+			if errs.Is(err, sql.ErrNoRows) {
+				return nil, errors.ThrowNotFound(err, "QUERY-Vai4o", "Errors.BackChannelAuth.NotExisting")
+			}
+			if err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-eiL2u", "Errors.Internal")
+			}
+
+			dst.Scopes = scopes
+			dst.AuthTime = authTime.Time
+			dst.AuthMethods = authMethods
+			return dst, nil
+		}
+}
@@ -0,0 +1,179 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/database"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+)
+
+const (
+	expectedBackChannelAuthQueryC = `SELECT` +
+		` projections.backchannel_authentications.id,` +
+		` projections.backchannel_authentications.client_id,` +
+		` projections.backchannel_authentications.auth_req_id,` +
+		` projections.backchannel_authentications.user_id,` +
+		` projections.backchannel_authentications.scopes,` +
+		` projections.backchannel_authentications.binding_message,` +
+		` projections.backchannel_authentications.client_notification_token,` +
+		` projections.backchannel_authentications.expires,` +
+		` projections.backchannel_authentications.state,` +
+		` projections.backchannel_authentications.auth_time,` +
+		` projections.backchannel_authentications.auth_methods` +
+		` FROM projections.backchannel_authentications`
+	expectedBackChannelAuthWhereAuthReqIDQueryC = expectedBackChannelAuthQueryC +
+		` WHERE projections.backchannel_authentications.auth_req_id = $1` +
+		` AND projections.backchannel_authentications.client_id = $2` +
+		` AND projections.backchannel_authentications.instance_id = $3`
+	expectedBackChannelAuthWhereIDQueryC = expectedBackChannelAuthQueryC +
+		` WHERE projections.backchannel_authentications.id = $1` +
+		` AND projections.backchannel_authentications.instance_id = $2`
+)
+
+var (
+	expectedBackChannelAuthQuery               = regexp.QuoteMeta(expectedBackChannelAuthQueryC)
+	expectedBackChannelAuthWhereAuthReqIDQuery = regexp.QuoteMeta(expectedBackChannelAuthWhereAuthReqIDQueryC)
+	expectedBackChannelAuthWhereIDQuery        = regexp.QuoteMeta(expectedBackChannelAuthWhereIDQueryC)
+	expectedBackChannelAuthValues              = []driver.Value{
+		"primary-id",
+		"client-id",
+		"auth-req-id",
+		"user-id",
+		database.TextArray[string]{"openid"},
+		"binding",
+		"token",
+		testNow,
+		domain.BackChannelAuthStateApproved,
+		testNow,
+		database.Array[domain.UserAuthMethodType]{domain.UserAuthMethodTypePassword},
+	}
+	expectedBackChannelAuth = &domain.BackChannelAuth{
+		ObjectRoot: models.ObjectRoot{
+			AggregateID: "primary-id",
+		},
+		ClientID:                "client-id",
+		AuthReqID:               "auth-req-id",
+		UserID:                  "user-id",
+		Scopes:                  []string{"openid"},
+		BindingMessage:          "binding",
+		ClientNotificationToken: "token",
+		Expires:                 testNow,
+		State:                   domain.BackChannelAuthStateApproved,
+		AuthTime:                testNow,
+		AuthMethods:             []domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+	}
+)
+
+func TestQueries_BackChannelAuthByAuthReqID(t *testing.T) {
+	client, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to build mock client: %v", err)
+	}
+	defer client.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(expectedBackChannelAuthWhereAuthReqIDQuery).WillReturnRows(
+		sqlmock.NewRows(backChannelAuthSelectColumns).AddRow(expectedBackChannelAuthValues...),
+	)
+	mock.ExpectCommit()
+	q := Queries{
+		client: &database.DB{DB: client},
+	}
+	got, err := q.BackChannelAuthByAuthReqID(context.TODO(), "client-id", "auth-req-id")
+	require.NoError(t, err)
+	assert.Equal(t, expectedBackChannelAuth, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func TestQueries_BackChannelAuthByID(t *testing.T) {
+	client, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatalf("failed to build mock client: %v", err)
+	}
+	defer client.Close()
+
+	mock.ExpectBegin()
+	mock.ExpectQuery(expectedBackChannelAuthWhereIDQuery).WillReturnRows(
+		sqlmock.NewRows(backChannelAuthSelectColumns).AddRow(expectedBackChannelAuthValues...),
+	)
+	mock.ExpectCommit()
+	q := Queries{
+		client: &database.DB{DB: client},
+	}
+	got, err := q.BackChannelAuthByID(context.TODO(), "primary-id")
+	require.NoError(t, err)
+	assert.Equal(t, expectedBackChannelAuth, got)
+	require.NoError(t, mock.ExpectationsWereMet())
+}
+
+func Test_prepareBackChannelAuthQuery(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name   string
+		want   want
+		object any
+	}{
+		{
+			name: "success",
+			want: want{
+				sqlExpectations: mockQueries(
+					expectedBackChannelAuthQuery,
+					backChannelAuthSelectColumns,
+					[][]driver.Value{expectedBackChannelAuthValues},
+				),
+			},
+			object: expectedBackChannelAuth,
+		},
+		{
+			name: "not found error",
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedBackChannelAuthQuery,
+					sql.ErrNoRows,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrNoRows) {
+						return fmt.Errorf("err should be sql.ErrNoRows got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*domain.BackChannelAuth)(nil),
+		},
+		{
+			name: "other error",
+			want: want{
+				sqlExpectations: mockQueryErr(
+					expectedBackChannelAuthQuery,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*domain.BackChannelAuth)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, prepareBackChannelAuthQuery, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -0,0 +1,132 @@
+package query
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+// ClientRegistration is the policy of the dynamic client registration of a project
+// and the initial access tokens issued for it
+type ClientRegistration struct {
+	ProjectID     string
+	ResourceOwner string
+	ChangeDate    time.Time
+	Sequence      uint64
+
+	AllowedGrantTypes   []domain.OIDCGrantType
+	RedirectURIPatterns []string
+	Tokens              []*ClientRegistrationToken
+}
+
+type ClientRegistrationToken struct {
+	ID           string
+	CreationDate time.Time
+	Expiration   time.Time
+}
+
+func (q *Queries) ClientRegistrationByProjectID(ctx context.Context, projectID, resourceOwner string) (_ *ClientRegistration, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if projectID == "" {
+		return nil, errors.ThrowInvalidArgument(nil, "QUERY-Ahx4i", "Errors.IDMissing")
+	}
+	readModel := newClientRegistrationReadModel(projectID, resourceOwner)
+	if err = q.eventstore.FilterToQueryReducer(ctx, readModel); err != nil {
+		return nil, err
+	}
+	if !readModel.policySet {
+		return nil, errors.ThrowNotFound(nil, "QUERY-eiN7u", "Errors.Project.ClientRegistration.PolicyNotFound")
+	}
+	return &ClientRegistration{
+		ProjectID:           readModel.AggregateID,
+		ResourceOwner:       readModel.ResourceOwner,
+		ChangeDate:          readModel.ChangeDate,
+		Sequence:            readModel.ProcessedSequence,
+		AllowedGrantTypes:   readModel.allowedGrantTypes,
+		RedirectURIPatterns: readModel.redirectURIPatterns,
+		Tokens:              readModel.tokens,
+	}, nil
+}
+
+type clientRegistrationReadModel struct {
+	eventstore.ReadModel
+
+	allowedGrantTypes   []domain.OIDCGrantType
+	redirectURIPatterns []string
+	policySet           bool
+	tokens              []*ClientRegistrationToken
+}
+
+func newClientRegistrationReadModel(projectID, resourceOwner string) *clientRegistrationReadModel {
+	return &clientRegistrationReadModel{
+		ReadModel: eventstore.ReadModel{
+			AggregateID:   projectID,
+			ResourceOwner: resourceOwner,
+		},
+	}
+}
+
+func (rm *clientRegistrationReadModel) Reduce() error {
+	for _, event := range rm.Events {
+		switch e := event.(type) {
+		case *project.ClientRegistrationPolicySetEvent:
+			rm.allowedGrantTypes = e.AllowedGrantTypes
+			rm.redirectURIPatterns = e.RedirectURIPatterns
+			rm.policySet = true
+		case *project.ClientRegistrationPolicyRemovedEvent:
+			rm.allowedGrantTypes = nil
+			rm.redirectURIPatterns = nil
+			rm.policySet = false
+		case *project.ClientRegistrationTokenAddedEvent:
+			rm.tokens = append(rm.tokens, &ClientRegistrationToken{
+				ID:           e.TokenID,
+				CreationDate: e.CreatedAt(),
+				Expiration:   e.Expiration,
+			})
+		case *project.ClientRegistrationTokenRemovedEvent:
+			rm.removeToken(e.TokenID)
+		case *project.ProjectRemovedEvent:
+			rm.allowedGrantTypes = nil
+			rm.redirectURIPatterns = nil
+			rm.policySet = false
+			rm.tokens = nil
+		}
+	}
+	return rm.ReadModel.Reduce()
+}
+
+func (rm *clientRegistrationReadModel) removeToken(tokenID string) {
+	for i, token := range rm.tokens {
+		if token.ID == tokenID {
+			rm.tokens = append(rm.tokens[:i], rm.tokens[i+1:]...)
+			return
+		}
+	}
+}
+
+func (rm *clientRegistrationReadModel) Query() *eventstore.SearchQueryBuilder {
+	query := eventstore.NewSearchQueryBuilder(eventstore.ColumnsEvent).
+		AwaitOpenTransactions().
+		AddQuery().
+		AggregateTypes(project.AggregateType).
+		AggregateIDs(rm.AggregateID).
+		EventTypes(
+			project.ClientRegistrationPolicySetType,
+			project.ClientRegistrationPolicyRemovedType,
+			project.ClientRegistrationTokenAddedType,
+			project.ClientRegistrationTokenRemovedType,
+			project.ProjectRemovedType).
+		Builder()
+
+	if rm.ResourceOwner != "" {
+		query.ResourceOwner(rm.ResourceOwner)
+	}
+	return query
+}
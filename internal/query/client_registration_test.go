@@ -0,0 +1,106 @@
+package query
+
+import (
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestQueries_ClientRegistrationByProjectID(t *testing.T) {
+	expiration := time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC)
+	aggregate := &project.NewAggregate("project1", "org1").Aggregate
+
+	tests := []struct {
+		name       string
+		eventstore func(*testing.T) *eventstore.Eventstore
+		want       *ClientRegistration
+		wantErr    error
+	}{
+		{
+			name: "filter error",
+			eventstore: expectEventstore(
+				expectFilterError(io.ErrClosedPipe),
+			),
+			wantErr: io.ErrClosedPipe,
+		},
+		{
+			name: "policy not set, not found error",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(project.NewClientRegistrationPolicySetEvent(context.Background(),
+						aggregate,
+						[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+						[]string{"https://*.example.com/callback"},
+					)),
+					eventFromEventPusher(project.NewClientRegistrationPolicyRemovedEvent(context.Background(),
+						aggregate,
+					)),
+				),
+			),
+			wantErr: errs.ThrowNotFound(nil, "QUERY-eiN7u", "Errors.Project.ClientRegistration.PolicyNotFound"),
+		},
+		{
+			name: "policy and tokens, ok",
+			eventstore: expectEventstore(
+				expectFilter(
+					eventFromEventPusher(project.NewClientRegistrationPolicySetEvent(context.Background(),
+						aggregate,
+						[]domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+						[]string{"https://*.example.com/callback"},
+					)),
+					eventFromEventPusher(project.NewClientRegistrationTokenAddedEvent(context.Background(),
+						aggregate,
+						"token1",
+						expiration,
+					)),
+					eventFromEventPusher(project.NewClientRegistrationTokenAddedEvent(context.Background(),
+						aggregate,
+						"token2",
+						expiration,
+					)),
+					eventFromEventPusher(project.NewClientRegistrationTokenRemovedEvent(context.Background(),
+						aggregate,
+						"token1",
+					)),
+				),
+			),
+			want: &ClientRegistration{
+				ProjectID:           "project1",
+				ResourceOwner:       "org1",
+				AllowedGrantTypes:   []domain.OIDCGrantType{domain.OIDCGrantTypeAuthorizationCode},
+				RedirectURIPatterns: []string{"https://*.example.com/callback"},
+				Tokens: []*ClientRegistrationToken{
+					{
+						ID:         "token2",
+						Expiration: expiration,
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			q := &Queries{
+				eventstore: tt.eventstore(t),
+			}
+			ctx := authz.NewMockContext("instanceID", "org1", "user1")
+			got, err := q.ClientRegistrationByProjectID(ctx, "project1", "org1")
+			if tt.wantErr != nil {
+				require.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
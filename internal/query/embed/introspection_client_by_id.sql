@@ -1,11 +1,11 @@
 with config as (
 		select app_id, client_id, client_secret
-		from projections.apps6_api_configs
+		from projections.apps12_api_configs
 		where instance_id = $1
 			and client_id = $2
 	union
 		select app_id, client_id, client_secret
-		from projections.apps6_oidc_configs
+		from projections.apps12_oidc_configs
 		where instance_id = $1
 			and client_id = $2
 ),
@@ -19,5 +19,5 @@ keys as (
 	group by identifier
 )
 select config.client_id, config.client_secret, apps.project_id, keys.public_keys from config
-join projections.apps6 apps on apps.id = config.app_id
+join projections.apps12 apps on apps.id = config.app_id
 left join keys on keys.client_id = config.client_id;
@@ -15,6 +15,7 @@ type Event struct {
 	Editor       *EventEditor
 	Aggregate    *eventstore.Aggregate
 	Sequence     uint64
+	Position     float64
 	CreationDate time.Time
 	Type         string
 	Payload      []byte
@@ -113,6 +114,7 @@ func (q *Queries) convertEvent(ctx context.Context, event eventstore.Event, user
 		},
 		Aggregate:    event.Aggregate(),
 		Sequence:     event.Sequence(),
+		Position:     event.Position(),
 		CreationDate: event.CreatedAt(),
 		Type:         string(event.Type()),
 		Payload:      event.DataAsBytes(),
@@ -0,0 +1,181 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+type ImpersonationPolicy struct {
+	ID            string
+	Sequence      uint64
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	ResourceOwner string
+	State         domain.PolicyState
+
+	AllowImpersonation bool
+
+	IsDefault bool
+}
+
+var (
+	impersonationPolicyTable = table{
+		name:          projection.ImpersonationPolicyProjectionTable,
+		instanceIDCol: projection.ImpersonationPolicyColumnInstanceID,
+	}
+	ImpersonationPolicyColID = Column{
+		name:  projection.ImpersonationPolicyColumnID,
+		table: impersonationPolicyTable,
+	}
+	ImpersonationPolicyColSequence = Column{
+		name:  projection.ImpersonationPolicyColumnSequence,
+		table: impersonationPolicyTable,
+	}
+	ImpersonationPolicyColCreationDate = Column{
+		name:  projection.ImpersonationPolicyColumnCreationDate,
+		table: impersonationPolicyTable,
+	}
+	ImpersonationPolicyColChangeDate = Column{
+		name:  projection.ImpersonationPolicyColumnChangeDate,
+		table: impersonationPolicyTable,
+	}
+	ImpersonationPolicyColResourceOwner = Column{
+		name:  projection.ImpersonationPolicyColumnResourceOwner,
+		table: impersonationPolicyTable,
+	}
+	ImpersonationPolicyColInstanceID = Column{
+		name:  projection.ImpersonationPolicyColumnInstanceID,
+		table: impersonationPolicyTable,
+	}
+	ImpersonationPolicyColAllowImpersonation = Column{
+		name:  projection.ImpersonationPolicyColumnAllowImpersonation,
+		table: impersonationPolicyTable,
+	}
+	ImpersonationPolicyColIsDefault = Column{
+		name:  projection.ImpersonationPolicyColumnIsDefault,
+		table: impersonationPolicyTable,
+	}
+	ImpersonationPolicyColState = Column{
+		name:  projection.ImpersonationPolicyColumnStateCol,
+		table: impersonationPolicyTable,
+	}
+	ImpersonationPolicyColOwnerRemoved = Column{
+		name:  projection.ImpersonationPolicyColumnOwnerRemoved,
+		table: impersonationPolicyTable,
+	}
+)
+
+func (q *Queries) ImpersonationPolicyByOrg(ctx context.Context, shouldTriggerBulk bool, orgID string, withOwnerRemoved bool) (policy *ImpersonationPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerImpersonationPolicyProjection")
+		ctx, err = projection.ImpersonationPolicyProjection.Trigger(ctx, handler.WithAwaitRunning())
+		traceSpan.EndWithError(err)
+		if err != nil {
+			return nil, err
+		}
+	}
+	eq := sq.Eq{ImpersonationPolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID()}
+	if !withOwnerRemoved {
+		eq[ImpersonationPolicyColOwnerRemoved.identifier()] = false
+	}
+	stmt, scan := prepareImpersonationPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(
+		sq.And{
+			eq,
+			sq.Or{
+				sq.Eq{ImpersonationPolicyColID.identifier(): orgID},
+				sq.Eq{ImpersonationPolicyColID.identifier(): authz.GetInstance(ctx).InstanceID()},
+			},
+		}).
+		OrderBy(ImpersonationPolicyColIsDefault.identifier()).Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Thai6", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		policy, err = scan(row)
+		return err
+	}, query, args...)
+	return policy, err
+}
+
+func (q *Queries) DefaultImpersonationPolicy(ctx context.Context, shouldTriggerBulk bool) (policy *ImpersonationPolicy, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerImpersonationPolicyProjection")
+		ctx, err = projection.ImpersonationPolicyProjection.Trigger(ctx, handler.WithAwaitRunning())
+		traceSpan.EndWithError(err)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	stmt, scan := prepareImpersonationPolicyQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		ImpersonationPolicyColID.identifier():         authz.GetInstance(ctx).InstanceID(),
+		ImpersonationPolicyColInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).
+		OrderBy(ImpersonationPolicyColIsDefault.identifier()).
+		Limit(1).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ree0o", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		policy, err = scan(row)
+		return err
+	}, query, args...)
+	return policy, err
+}
+
+func prepareImpersonationPolicyQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*ImpersonationPolicy, error)) {
+	return sq.Select(
+			ImpersonationPolicyColID.identifier(),
+			ImpersonationPolicyColSequence.identifier(),
+			ImpersonationPolicyColCreationDate.identifier(),
+			ImpersonationPolicyColChangeDate.identifier(),
+			ImpersonationPolicyColResourceOwner.identifier(),
+			ImpersonationPolicyColAllowImpersonation.identifier(),
+			ImpersonationPolicyColIsDefault.identifier(),
+			ImpersonationPolicyColState.identifier(),
+		).
+			From(impersonationPolicyTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*ImpersonationPolicy, error) {
+			policy := new(ImpersonationPolicy)
+			err := row.Scan(
+				&policy.ID,
+				&policy.Sequence,
+				&policy.CreationDate,
+				&policy.ChangeDate,
+				&policy.ResourceOwner,
+				&policy.AllowImpersonation,
+				&policy.IsDefault,
+				&policy.State,
+			)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Eim6r", "Errors.Org.ImpersonationPolicy.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-aiH5e", "Errors.Internal")
+			}
+			return policy, nil
+		}
+}
@@ -0,0 +1,120 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	impersonationPolicyStmt = regexp.QuoteMeta(`SELECT projections.impersonation_policies.id,` +
+		` projections.impersonation_policies.sequence,` +
+		` projections.impersonation_policies.creation_date,` +
+		` projections.impersonation_policies.change_date,` +
+		` projections.impersonation_policies.resource_owner,` +
+		` projections.impersonation_policies.allow_impersonation,` +
+		` projections.impersonation_policies.is_default,` +
+		` projections.impersonation_policies.state` +
+		` FROM projections.impersonation_policies` +
+		` AS OF SYSTEM TIME '-1 ms'`)
+	impersonationPolicyCols = []string{
+		"id",
+		"sequence",
+		"creation_date",
+		"change_date",
+		"resource_owner",
+		"allow_impersonation",
+		"is_default",
+		"state",
+	}
+)
+
+func Test_ImpersonationPolicyPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareImpersonationPolicyQuery no result",
+			prepare: prepareImpersonationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					impersonationPolicyStmt,
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*ImpersonationPolicy)(nil),
+		},
+		{
+			name:    "prepareImpersonationPolicyQuery found",
+			prepare: prepareImpersonationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					impersonationPolicyStmt,
+					impersonationPolicyCols,
+					[]driver.Value{
+						"pol-id",
+						uint64(20211109),
+						testNow,
+						testNow,
+						"ro",
+						true,
+						true,
+						domain.PolicyStateActive,
+					},
+				),
+			},
+			object: &ImpersonationPolicy{
+				ID:                 "pol-id",
+				CreationDate:       testNow,
+				ChangeDate:         testNow,
+				Sequence:           20211109,
+				ResourceOwner:      "ro",
+				State:              domain.PolicyStateActive,
+				AllowImpersonation: true,
+				IsDefault:          true,
+			},
+		},
+		{
+			name:    "prepareImpersonationPolicyQuery sql err",
+			prepare: prepareImpersonationPolicyQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					impersonationPolicyStmt,
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*ImpersonationPolicy)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
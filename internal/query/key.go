@@ -2,7 +2,7 @@ package query
 
 import (
 	"context"
-	"crypto/rsa"
+	gocrypto "crypto"
 	"database/sql"
 	"time"
 
@@ -88,17 +88,17 @@ func (k *privateKey) Key() *crypto.CryptoValue {
 	return k.privateKey
 }
 
-type rsaPublicKey struct {
+type publicKey struct {
 	key
 	expiry    time.Time
-	publicKey *rsa.PublicKey
+	publicKey gocrypto.PublicKey
 }
 
-func (r *rsaPublicKey) Expiry() time.Time {
+func (r *publicKey) Expiry() time.Time {
 	return r.expiry
 }
 
-func (r *rsaPublicKey) Key() interface{} {
+func (r *publicKey) Key() interface{} {
 	return r.publicKey
 }
 
@@ -264,7 +264,7 @@ func preparePublicKeysQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 			keys := make([]PublicKey, 0)
 			var count uint64
 			for rows.Next() {
-				k := new(rsaPublicKey)
+				k := new(publicKey)
 				var keyValue []byte
 				err := rows.Scan(
 					&k.id,
@@ -281,7 +281,7 @@ func preparePublicKeysQuery(ctx context.Context, db prepareDatabase) (sq.SelectB
 				if err != nil {
 					return nil, err
 				}
-				k.publicKey, err = crypto.BytesToPublicKey(keyValue)
+				k.publicKey, err = crypto.BytesToSigningPublicKey(keyValue)
 				if err != nil {
 					return nil, err
 				}
@@ -417,12 +417,12 @@ func (q *Queries) GetActivePublicKeyByID(ctx context.Context, keyID string, curr
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-Ie4oh", "Errors.Internal")
 	}
-	publicKey, err := crypto.BytesToPublicKey(keyValue)
+	pubKey, err := crypto.BytesToSigningPublicKey(keyValue)
 	if err != nil {
 		return nil, errors.ThrowInternal(err, "QUERY-Kai2Z", "Errors.Internal")
 	}
 
-	return &rsaPublicKey{
+	return &publicKey{
 		key: key{
 			id:            model.AggregateID,
 			creationDate:  model.CreationDate,
@@ -433,6 +433,6 @@ func (q *Queries) GetActivePublicKeyByID(ctx context.Context, keyID string, curr
 			use:           model.Usage,
 		},
 		expiry:    model.Expiry,
-		publicKey: publicKey,
+		publicKey: pubKey,
 	}, nil
 }
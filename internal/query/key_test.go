@@ -123,7 +123,7 @@ func Test_KeyPrepares(t *testing.T) {
 					Count: 1,
 				},
 				Keys: []PublicKey{
-					&rsaPublicKey{
+					&publicKey{
 						key: key{
 							id:            "key-id",
 							creationDate:  testNow,
@@ -277,7 +277,7 @@ func TestQueries_GetActivePublicKeyByID(t *testing.T) {
 		name       string
 		eventstore func(*testing.T) *eventstore.Eventstore
 		encryption func(*testing.T) *crypto.MockEncryptionAlgorithm
-		want       *rsaPublicKey
+		want       *publicKey
 		wantErr    error
 	}{
 		{
@@ -443,7 +443,7 @@ func TestQueries_GetActivePublicKeyByID(t *testing.T) {
 				expect.Decrypt([]byte("public"), "keyID").Return([]byte(pubKey), nil)
 				return encryption
 			},
-			want: &rsaPublicKey{
+			want: &publicKey{
 				key: key{
 					id:            "keyID",
 					resourceOwner: "instanceID",
@@ -478,7 +478,7 @@ func TestQueries_GetActivePublicKeyByID(t *testing.T) {
 			require.NoError(t, err)
 			require.NotNil(t, key)
 
-			got := key.(*rsaPublicKey)
+			got := key.(*publicKey)
 			assert.WithinDuration(t, tt.want.expiry, got.expiry, time.Second)
 			tt.want.expiry = time.Time{}
 			got.expiry = time.Time{}
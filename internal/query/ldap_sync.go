@@ -0,0 +1,457 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	"encoding/json"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	ldapSyncsTable = table{
+		name:          projection.LDAPSyncProjectionTable,
+		instanceIDCol: projection.LDAPSyncColumnInstanceID,
+	}
+	LDAPSyncColumnIDPID = Column{
+		name:  projection.LDAPSyncColumnIDPID,
+		table: ldapSyncsTable,
+	}
+	LDAPSyncColumnInstanceID = Column{
+		name:  projection.LDAPSyncColumnInstanceID,
+		table: ldapSyncsTable,
+	}
+	LDAPSyncColumnResourceOwner = Column{
+		name:  projection.LDAPSyncColumnResourceOwner,
+		table: ldapSyncsTable,
+	}
+	LDAPSyncColumnCreationDate = Column{
+		name:  projection.LDAPSyncColumnCreationDate,
+		table: ldapSyncsTable,
+	}
+	LDAPSyncColumnChangeDate = Column{
+		name:  projection.LDAPSyncColumnChangeDate,
+		table: ldapSyncsTable,
+	}
+	LDAPSyncColumnSequence = Column{
+		name:  projection.LDAPSyncColumnSequence,
+		table: ldapSyncsTable,
+	}
+	LDAPSyncColumnGroupAttribute = Column{
+		name:  projection.LDAPSyncColumnGroupAttribute,
+		table: ldapSyncsTable,
+	}
+	LDAPSyncColumnGroupMappings = Column{
+		name:  projection.LDAPSyncColumnGroupMappings,
+		table: ldapSyncsTable,
+	}
+	LDAPSyncColumnSyncEnabled = Column{
+		name:  projection.LDAPSyncColumnSyncEnabled,
+		table: ldapSyncsTable,
+	}
+	LDAPSyncColumnSyncInterval = Column{
+		name:  projection.LDAPSyncColumnSyncInterval,
+		table: ldapSyncsTable,
+	}
+	LDAPSyncColumnLastStarted = Column{
+		name:  projection.LDAPSyncColumnLastStarted,
+		table: ldapSyncsTable,
+	}
+)
+
+var (
+	ldapSyncReportsTable = table{
+		name:          projection.LDAPSyncReportTable,
+		instanceIDCol: projection.LDAPSyncReportColumnInstanceID,
+	}
+	LDAPSyncReportColumnInstanceID = Column{
+		name:  projection.LDAPSyncReportColumnInstanceID,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnIDPID = Column{
+		name:  projection.LDAPSyncReportColumnIDPID,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnResourceOwner = Column{
+		name:  projection.LDAPSyncReportColumnResourceOwner,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnSequence = Column{
+		name:  projection.LDAPSyncReportColumnSequence,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnStarted = Column{
+		name:  projection.LDAPSyncReportColumnStarted,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnFinished = Column{
+		name:  projection.LDAPSyncReportColumnFinished,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnSucceeded = Column{
+		name:  projection.LDAPSyncReportColumnSucceeded,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnError = Column{
+		name:  projection.LDAPSyncReportColumnError,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnUsersFound = Column{
+		name:  projection.LDAPSyncReportColumnUsersFound,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnUsersCreated = Column{
+		name:  projection.LDAPSyncReportColumnUsersCreated,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnUsersUpdated = Column{
+		name:  projection.LDAPSyncReportColumnUsersUpdated,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnUsersDeactivated = Column{
+		name:  projection.LDAPSyncReportColumnUsersDeactivated,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnUsersReactivated = Column{
+		name:  projection.LDAPSyncReportColumnUsersReactivated,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnGrantsAdded = Column{
+		name:  projection.LDAPSyncReportColumnGrantsAdded,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnGrantsChanged = Column{
+		name:  projection.LDAPSyncReportColumnGrantsChanged,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnGrantsRemoved = Column{
+		name:  projection.LDAPSyncReportColumnGrantsRemoved,
+		table: ldapSyncReportsTable,
+	}
+	LDAPSyncReportColumnFailures = Column{
+		name:  projection.LDAPSyncReportColumnFailures,
+		table: ldapSyncReportsTable,
+	}
+)
+
+// LDAPSync is the group mapping and sync configuration of an LDAP identity provider
+type LDAPSync struct {
+	IDPID         string
+	InstanceID    string
+	ResourceOwner string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+
+	GroupAttribute string
+	GroupMappings  []*domain.LDAPGroupMapping
+	SyncEnabled    bool
+	SyncInterval   time.Duration
+	LastStarted    time.Time
+}
+
+// IsDue returns true if the sync is enabled and the last run was started at least the interval ago
+func (s *LDAPSync) IsDue(now time.Time) bool {
+	return s.SyncEnabled && now.Sub(s.LastStarted) >= s.SyncInterval
+}
+
+type LDAPSyncReports struct {
+	SearchResponse
+	Reports []*LDAPSyncReport
+}
+
+type LDAPSyncReport struct {
+	IDPID         string
+	ResourceOwner string
+	Sequence      uint64
+	Succeeded     bool
+	domain.LDAPSyncReport
+}
+
+type LDAPSyncReportSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *LDAPSyncReportSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) LDAPSyncByIDPID(ctx context.Context, shouldTriggerBulk bool, idpID string, queries ...SearchQuery) (sync *LDAPSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerLDAPSyncProjection")
+		ctx, err = projection.LDAPSyncProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+
+	stmt, scan := prepareLDAPSyncQuery(ctx, q.client)
+	for _, q := range queries {
+		stmt = q.toQuery(stmt)
+	}
+	query, args, err := stmt.Where(sq.Eq{
+		LDAPSyncColumnIDPID.identifier():      idpID,
+		LDAPSyncColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ooSh7", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		sync, err = scan(row)
+		return err
+	}, query, args...)
+	return sync, err
+}
+
+// DueLDAPSyncs returns the enabled syncs of all instances, which are due to run
+func (q *Queries) DueLDAPSyncs(ctx context.Context) (syncs []*LDAPSync, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	stmt, scan := prepareLDAPSyncsQuery(ctx, q.client)
+	query, args, err := stmt.Where(sq.Eq{
+		LDAPSyncColumnSyncEnabled.identifier(): true,
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ahm4o", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		syncs, err = scan(rows)
+		return err
+	}, query, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Zae5o", "Errors.Internal")
+	}
+	now := time.Now()
+	due := make([]*LDAPSync, 0, len(syncs))
+	for _, sync := range syncs {
+		if sync.IsDue(now) {
+			due = append(due, sync)
+		}
+	}
+	return due, nil
+}
+
+func (q *Queries) SearchLDAPSyncReports(ctx context.Context, idpID string, queries *LDAPSyncReportSearchQueries) (reports *LDAPSyncReports, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareLDAPSyncReportsQuery(ctx, q.client)
+	if queries.SortingColumn.isZero() {
+		query = query.OrderBy(LDAPSyncReportColumnStarted.identifier() + " DESC")
+	}
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		LDAPSyncReportColumnIDPID.identifier():      idpID,
+		LDAPSyncReportColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ohb7e", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		reports, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-eiK2a", "Errors.Internal")
+	}
+
+	reports.State, err = q.latestState(ctx, ldapSyncsTable)
+	return reports, err
+}
+
+func NewLDAPSyncResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(LDAPSyncColumnResourceOwner, id, TextEquals)
+}
+
+func NewLDAPSyncReportResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(LDAPSyncReportColumnResourceOwner, id, TextEquals)
+}
+
+func NewLDAPSyncReportSucceededSearchQuery(succeeded bool) (SearchQuery, error) {
+	return NewBoolQuery(LDAPSyncReportColumnSucceeded, succeeded)
+}
+
+func ldapSyncColumns() []string {
+	return []string{
+		LDAPSyncColumnIDPID.identifier(),
+		LDAPSyncColumnInstanceID.identifier(),
+		LDAPSyncColumnResourceOwner.identifier(),
+		LDAPSyncColumnCreationDate.identifier(),
+		LDAPSyncColumnChangeDate.identifier(),
+		LDAPSyncColumnSequence.identifier(),
+		LDAPSyncColumnGroupAttribute.identifier(),
+		LDAPSyncColumnGroupMappings.identifier(),
+		LDAPSyncColumnSyncEnabled.identifier(),
+		LDAPSyncColumnSyncInterval.identifier(),
+		LDAPSyncColumnLastStarted.identifier(),
+	}
+}
+
+type ldapSyncScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanLDAPSync(row ldapSyncScanner) (*LDAPSync, error) {
+	sync := new(LDAPSync)
+	var (
+		groupMappings []byte
+		syncInterval  int64
+		lastStarted   sql.NullTime
+	)
+	err := row.Scan(
+		&sync.IDPID,
+		&sync.InstanceID,
+		&sync.ResourceOwner,
+		&sync.CreationDate,
+		&sync.ChangeDate,
+		&sync.Sequence,
+		&sync.GroupAttribute,
+		&groupMappings,
+		&sync.SyncEnabled,
+		&syncInterval,
+		&lastStarted,
+	)
+	if err != nil {
+		return nil, err
+	}
+	if len(groupMappings) > 0 {
+		if err = json.Unmarshal(groupMappings, &sync.GroupMappings); err != nil {
+			return nil, err
+		}
+	}
+	sync.SyncInterval = time.Duration(syncInterval)
+	sync.LastStarted = lastStarted.Time
+	return sync, nil
+}
+
+func prepareLDAPSyncQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*LDAPSync, error)) {
+	return sq.Select(ldapSyncColumns()...).
+			From(ldapSyncsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*LDAPSync, error) {
+			sync, err := scanLDAPSync(row)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-ieG1u", "Errors.LDAPSync.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-ohN2a", "Errors.Internal")
+			}
+			return sync, nil
+		}
+}
+
+func prepareLDAPSyncsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) ([]*LDAPSync, error)) {
+	return sq.Select(ldapSyncColumns()...).
+			From(ldapSyncsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) ([]*LDAPSync, error) {
+			syncs := make([]*LDAPSync, 0)
+			for rows.Next() {
+				sync, err := scanLDAPSync(rows)
+				if err != nil {
+					return nil, err
+				}
+				syncs = append(syncs, sync)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Quai6", "Errors.Query.CloseRows")
+			}
+			return syncs, nil
+		}
+}
+
+func prepareLDAPSyncReportsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*LDAPSyncReports, error)) {
+	return sq.Select(
+			LDAPSyncReportColumnIDPID.identifier(),
+			LDAPSyncReportColumnResourceOwner.identifier(),
+			LDAPSyncReportColumnSequence.identifier(),
+			LDAPSyncReportColumnStarted.identifier(),
+			LDAPSyncReportColumnFinished.identifier(),
+			LDAPSyncReportColumnSucceeded.identifier(),
+			LDAPSyncReportColumnError.identifier(),
+			LDAPSyncReportColumnUsersFound.identifier(),
+			LDAPSyncReportColumnUsersCreated.identifier(),
+			LDAPSyncReportColumnUsersUpdated.identifier(),
+			LDAPSyncReportColumnUsersDeactivated.identifier(),
+			LDAPSyncReportColumnUsersReactivated.identifier(),
+			LDAPSyncReportColumnGrantsAdded.identifier(),
+			LDAPSyncReportColumnGrantsChanged.identifier(),
+			LDAPSyncReportColumnGrantsRemoved.identifier(),
+			LDAPSyncReportColumnFailures.identifier(),
+			countColumn.identifier(),
+		).From(ldapSyncReportsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*LDAPSyncReports, error) {
+			reports := make([]*LDAPSyncReport, 0)
+			var count uint64
+			for rows.Next() {
+				report := new(LDAPSyncReport)
+				var (
+					reportErr sql.NullString
+					failures  []byte
+				)
+				err := rows.Scan(
+					&report.IDPID,
+					&report.ResourceOwner,
+					&report.Sequence,
+					&report.Started,
+					&report.Finished,
+					&report.Succeeded,
+					&reportErr,
+					&report.UsersFound,
+					&report.UsersCreated,
+					&report.UsersUpdated,
+					&report.UsersDeactivated,
+					&report.UsersReactivated,
+					&report.GrantsAdded,
+					&report.GrantsChanged,
+					&report.GrantsRemoved,
+					&failures,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				report.Error = reportErr.String
+				if len(failures) > 0 {
+					if err = json.Unmarshal(failures, &report.Failures); err != nil {
+						return nil, err
+					}
+				}
+				reports = append(reports, report)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Dee4i", "Errors.Query.CloseRows")
+			}
+
+			return &LDAPSyncReports{
+				Reports: reports,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
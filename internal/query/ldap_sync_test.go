@@ -0,0 +1,321 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareLDAPSyncStmt = `SELECT projections.ldap_syncs.idp_id,` +
+		` projections.ldap_syncs.instance_id,` +
+		` projections.ldap_syncs.resource_owner,` +
+		` projections.ldap_syncs.creation_date,` +
+		` projections.ldap_syncs.change_date,` +
+		` projections.ldap_syncs.sequence,` +
+		` projections.ldap_syncs.group_attribute,` +
+		` projections.ldap_syncs.group_mappings,` +
+		` projections.ldap_syncs.sync_enabled,` +
+		` projections.ldap_syncs.sync_interval,` +
+		` projections.ldap_syncs.last_started` +
+		` FROM projections.ldap_syncs`
+	prepareLDAPSyncCols = []string{
+		"idp_id",
+		"instance_id",
+		"resource_owner",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"group_attribute",
+		"group_mappings",
+		"sync_enabled",
+		"sync_interval",
+		"last_started",
+	}
+
+	prepareLDAPSyncReportsStmt = `SELECT projections.ldap_syncs_reports.idp_id,` +
+		` projections.ldap_syncs_reports.resource_owner,` +
+		` projections.ldap_syncs_reports.sequence,` +
+		` projections.ldap_syncs_reports.started,` +
+		` projections.ldap_syncs_reports.finished,` +
+		` projections.ldap_syncs_reports.succeeded,` +
+		` projections.ldap_syncs_reports.error,` +
+		` projections.ldap_syncs_reports.users_found,` +
+		` projections.ldap_syncs_reports.users_created,` +
+		` projections.ldap_syncs_reports.users_updated,` +
+		` projections.ldap_syncs_reports.users_deactivated,` +
+		` projections.ldap_syncs_reports.users_reactivated,` +
+		` projections.ldap_syncs_reports.grants_added,` +
+		` projections.ldap_syncs_reports.grants_changed,` +
+		` projections.ldap_syncs_reports.grants_removed,` +
+		` projections.ldap_syncs_reports.failures,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.ldap_syncs_reports`
+	prepareLDAPSyncReportsCols = []string{
+		"idp_id",
+		"resource_owner",
+		"sequence",
+		"started",
+		"finished",
+		"succeeded",
+		"error",
+		"users_found",
+		"users_created",
+		"users_updated",
+		"users_deactivated",
+		"users_reactivated",
+		"grants_added",
+		"grants_changed",
+		"grants_removed",
+		"failures",
+		"count",
+	}
+)
+
+func Test_LDAPSyncPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareLDAPSyncQuery no result",
+			prepare: prepareLDAPSyncQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareLDAPSyncStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*LDAPSync)(nil),
+		},
+		{
+			name:    "prepareLDAPSyncQuery found",
+			prepare: prepareLDAPSyncQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareLDAPSyncStmt),
+					prepareLDAPSyncCols,
+					[]driver.Value{
+						"idp-id",
+						"instance-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						"memberOf",
+						[]byte(`[{"group":"cn=admins","projectId":"project-id","roles":["admin"]}]`),
+						true,
+						int64(time.Hour),
+						testNow,
+					},
+				),
+			},
+			object: &LDAPSync{
+				IDPID:          "idp-id",
+				InstanceID:     "instance-id",
+				ResourceOwner:  "ro",
+				CreationDate:   testNow,
+				ChangeDate:     testNow,
+				Sequence:       20211109,
+				GroupAttribute: "memberOf",
+				GroupMappings: []*domain.LDAPGroupMapping{
+					{Group: "cn=admins", ProjectID: "project-id", Roles: []string{"admin"}},
+				},
+				SyncEnabled:  true,
+				SyncInterval: time.Hour,
+				LastStarted:  testNow,
+			},
+		},
+		{
+			name:    "prepareLDAPSyncQuery sql err",
+			prepare: prepareLDAPSyncQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareLDAPSyncStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*LDAPSync)(nil),
+		},
+		{
+			name:    "prepareLDAPSyncReportsQuery no result",
+			prepare: prepareLDAPSyncReportsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareLDAPSyncReportsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &LDAPSyncReports{Reports: []*LDAPSyncReport{}},
+		},
+		{
+			name:    "prepareLDAPSyncReportsQuery multiple result",
+			prepare: prepareLDAPSyncReportsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareLDAPSyncReportsStmt),
+					prepareLDAPSyncReportsCols,
+					[][]driver.Value{
+						{
+							"idp-id",
+							"ro",
+							uint64(20211109),
+							testNow,
+							testNow,
+							true,
+							nil,
+							2,
+							1,
+							1,
+							0,
+							0,
+							1,
+							0,
+							0,
+							[]byte(`[{"externalUserId":"ext","error":"failed"}]`),
+						},
+						{
+							"idp-id",
+							"ro",
+							uint64(20211110),
+							testNow,
+							testNow,
+							false,
+							"ldap server unreachable",
+							0,
+							0,
+							0,
+							0,
+							0,
+							0,
+							0,
+							0,
+							nil,
+						},
+					},
+				),
+			},
+			object: &LDAPSyncReports{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Reports: []*LDAPSyncReport{
+					{
+						IDPID:         "idp-id",
+						ResourceOwner: "ro",
+						Sequence:      20211109,
+						Succeeded:     true,
+						LDAPSyncReport: domain.LDAPSyncReport{
+							Started:      testNow,
+							Finished:     testNow,
+							UsersFound:   2,
+							UsersCreated: 1,
+							UsersUpdated: 1,
+							GrantsAdded:  1,
+							Failures: []*domain.LDAPSyncFailure{
+								{ExternalUserID: "ext", Error: "failed"},
+							},
+						},
+					},
+					{
+						IDPID:         "idp-id",
+						ResourceOwner: "ro",
+						Sequence:      20211110,
+						Succeeded:     false,
+						LDAPSyncReport: domain.LDAPSyncReport{
+							Started:  testNow,
+							Finished: testNow,
+							Error:    "ldap server unreachable",
+						},
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareLDAPSyncReportsQuery sql err",
+			prepare: prepareLDAPSyncReportsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareLDAPSyncReportsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*LDAPSyncReports)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
+
+func TestLDAPSync_IsDue(t *testing.T) {
+	now := time.Now()
+	tests := []struct {
+		name string
+		sync *LDAPSync
+		want bool
+	}{
+		{
+			name: "disabled",
+			sync: &LDAPSync{SyncInterval: time.Hour},
+			want: false,
+		},
+		{
+			name: "never started",
+			sync: &LDAPSync{SyncEnabled: true, SyncInterval: time.Hour},
+			want: true,
+		},
+		{
+			name: "started within interval",
+			sync: &LDAPSync{SyncEnabled: true, SyncInterval: time.Hour, LastStarted: now.Add(-time.Minute)},
+			want: false,
+		},
+		{
+			name: "started before interval",
+			sync: &LDAPSync{SyncEnabled: true, SyncInterval: time.Hour, LastStarted: now.Add(-2 * time.Hour)},
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.sync.IsDue(now); got != tt.want {
+				t.Errorf("IsDue() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
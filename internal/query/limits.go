@@ -49,6 +49,10 @@ var (
 		name:  projection.LimitsColumnAuditLogRetention,
 		table: limitSettingsTable,
 	}
+	LimitsColumnActionExecutionRetention = Column{
+		name:  projection.LimitsColumnActionExecutionRetention,
+		table: limitSettingsTable,
+	}
 )
 
 type Limits struct {
@@ -58,7 +62,8 @@ type Limits struct {
 	ResourceOwner string
 	Sequence      uint64
 
-	AuditLogRetention *time.Duration
+	AuditLogRetention        *time.Duration
+	ActionExecutionRetention *time.Duration
 }
 
 func (q *Queries) Limits(ctx context.Context, resourceOwner string) (limits *Limits, err error) {
@@ -89,13 +94,15 @@ func prepareLimitsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			LimitsColumnResourceOwner.identifier(),
 			LimitsColumnSequence.identifier(),
 			LimitsColumnAuditLogRetention.identifier(),
+			LimitsColumnActionExecutionRetention.identifier(),
 		).
 			From(limitSettingsTable.identifier() + db.Timetravel(call.Took(ctx))).
 			PlaceholderFormat(sq.Dollar),
 		func(row *sql.Row) (*Limits, error) {
 			var (
-				limits            = new(Limits)
-				auditLogRetention database.NullDuration
+				limits                   = new(Limits)
+				auditLogRetention        database.NullDuration
+				actionExecutionRetention database.NullDuration
 			)
 			err := row.Scan(
 				&limits.AggregateID,
@@ -104,6 +111,7 @@ func prepareLimitsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 				&limits.ResourceOwner,
 				&limits.Sequence,
 				&auditLogRetention,
+				&actionExecutionRetention,
 			)
 			if err != nil {
 				if errs.Is(err, sql.ErrNoRows) {
@@ -114,6 +122,9 @@ func prepareLimitsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuild
 			if auditLogRetention.Valid {
 				limits.AuditLogRetention = &auditLogRetention.Duration
 			}
+			if actionExecutionRetention.Valid {
+				limits.ActionExecutionRetention = &actionExecutionRetention.Duration
+			}
 			return limits, nil
 		}
 }
@@ -15,13 +15,14 @@ import (
 )
 
 var (
-	expectedLimitsQuery = regexp.QuoteMeta("SELECT projections.limits.aggregate_id," +
-		" projections.limits.creation_date," +
-		" projections.limits.change_date," +
-		" projections.limits.resource_owner," +
-		" projections.limits.sequence," +
-		" projections.limits.audit_log_retention" +
-		" FROM projections.limits" +
+	expectedLimitsQuery = regexp.QuoteMeta("SELECT projections.limits2.aggregate_id," +
+		" projections.limits2.creation_date," +
+		" projections.limits2.change_date," +
+		" projections.limits2.resource_owner," +
+		" projections.limits2.sequence," +
+		" projections.limits2.audit_log_retention," +
+		" projections.limits2.action_execution_retention" +
+		" FROM projections.limits2" +
 		" AS OF SYSTEM TIME '-1 ms'",
 	)
 
@@ -32,6 +33,7 @@ var (
 		"resource_owner",
 		"sequence",
 		"audit_log_retention",
+		"action_execution_retention",
 	}
 )
 
@@ -78,16 +80,18 @@ func Test_LimitsPrepare(t *testing.T) {
 						"instance1",
 						0,
 						intervalDriverValue(t, time.Hour),
+						intervalDriverValue(t, 24*time.Hour),
 					},
 				),
 			},
 			object: &Limits{
-				AggregateID:       "limits1",
-				CreationDate:      testNow,
-				ChangeDate:        testNow,
-				ResourceOwner:     "instance1",
-				Sequence:          0,
-				AuditLogRetention: gu.Ptr(time.Hour),
+				AggregateID:              "limits1",
+				CreationDate:             testNow,
+				ChangeDate:               testNow,
+				ResourceOwner:            "instance1",
+				Sequence:                 0,
+				AuditLogRetention:        gu.Ptr(time.Hour),
+				ActionExecutionRetention: gu.Ptr(24 * time.Hour),
 			},
 		},
 		{
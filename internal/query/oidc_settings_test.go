@@ -13,16 +13,17 @@ import (
 )
 
 var (
-	prepareOIDCSettingsStmt = `SELECT projections.oidc_settings2.aggregate_id,` +
-		` projections.oidc_settings2.creation_date,` +
-		` projections.oidc_settings2.change_date,` +
-		` projections.oidc_settings2.resource_owner,` +
-		` projections.oidc_settings2.sequence,` +
-		` projections.oidc_settings2.access_token_lifetime,` +
-		` projections.oidc_settings2.id_token_lifetime,` +
-		` projections.oidc_settings2.refresh_token_idle_expiration,` +
-		` projections.oidc_settings2.refresh_token_expiration` +
-		` FROM projections.oidc_settings2` +
+	prepareOIDCSettingsStmt = `SELECT projections.oidc_settings3.aggregate_id,` +
+		` projections.oidc_settings3.creation_date,` +
+		` projections.oidc_settings3.change_date,` +
+		` projections.oidc_settings3.resource_owner,` +
+		` projections.oidc_settings3.sequence,` +
+		` projections.oidc_settings3.access_token_lifetime,` +
+		` projections.oidc_settings3.id_token_lifetime,` +
+		` projections.oidc_settings3.refresh_token_idle_expiration,` +
+		` projections.oidc_settings3.refresh_token_expiration,` +
+		` projections.oidc_settings3.signing_algorithm` +
+		` FROM projections.oidc_settings3` +
 		` AS OF SYSTEM TIME '-1 ms'`
 	prepareOIDCSettingsCols = []string{
 		"aggregate_id",
@@ -34,6 +35,7 @@ var (
 		"id_token_lifetime",
 		"refresh_token_idle_expiration",
 		"refresh_token_expiration",
+		"signing_algorithm",
 	}
 )
 
@@ -83,6 +85,7 @@ func Test_OIDCConfigsPrepares(t *testing.T) {
 						time.Minute * 2,
 						time.Minute * 3,
 						time.Minute * 4,
+						"ES256",
 					},
 				),
 			},
@@ -96,6 +99,7 @@ func Test_OIDCConfigsPrepares(t *testing.T) {
 				IdTokenLifetime:            time.Minute * 2,
 				RefreshTokenIdleExpiration: time.Minute * 3,
 				RefreshTokenExpiration:     time.Minute * 4,
+				SigningAlgorithm:           "ES256",
 			},
 		},
 		{
@@ -241,6 +241,37 @@ func (q *Queries) SearchOrgs(ctx context.Context, queries *OrgSearchQueries) (or
 	return orgs, err
 }
 
+// OrgByIDWithPermission returns the organization if the caller has the org.read permission on it.
+func (q *Queries) OrgByIDWithPermission(ctx context.Context, shouldTriggerBulk bool, id string) (*Org, error) {
+	if err := q.checkPermission(ctx, domain_pkg.PermissionOrgRead, id, id); err != nil {
+		return nil, err
+	}
+	return q.OrgByID(ctx, shouldTriggerBulk, id)
+}
+
+// SearchOrgsWithPermission searches organizations like [Queries.SearchOrgs],
+// but removes all organizations from the result the caller is not allowed to read.
+func (q *Queries) SearchOrgsWithPermission(ctx context.Context, queries *OrgSearchQueries) (*Orgs, error) {
+	orgs, err := q.SearchOrgs(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	orgs.RemoveNoPermission(ctx, q.checkPermission)
+	return orgs, nil
+}
+
+// RemoveNoPermission removes all organizations the caller is not allowed to read.
+func (o *Orgs) RemoveNoPermission(ctx context.Context, permissionCheck domain_pkg.PermissionCheck) {
+	orgs := make([]*Org, 0, len(o.Orgs))
+	for _, org := range o.Orgs {
+		if err := permissionCheck(ctx, domain_pkg.PermissionOrgRead, org.ID, org.ID); err == nil {
+			orgs = append(orgs, org)
+		}
+	}
+	o.Count -= uint64(len(o.Orgs) - len(orgs))
+	o.Orgs = orgs
+}
+
 func NewOrgDomainSearchQuery(method TextComparison, value string) (SearchQuery, error) {
 	return NewTextQuery(OrgColumnDomain, value, method)
 }
@@ -56,6 +56,20 @@ func NewOrgDomainVerifiedSearchQuery(verified bool) (SearchQuery, error) {
 	return NewBoolQuery(OrgDomainIsVerifiedCol, verified)
 }
 
+// SearchOrgDomainsWithPermission searches the domains of the organization,
+// if the caller has the org.read permission on it.
+func (q *Queries) SearchOrgDomainsWithPermission(ctx context.Context, orgID string, queries *OrgDomainSearchQueries) (*Domains, error) {
+	if err := q.checkPermission(ctx, domain.PermissionOrgRead, orgID, orgID); err != nil {
+		return nil, err
+	}
+	orgIDQuery, err := NewOrgDomainOrgIDSearchQuery(orgID)
+	if err != nil {
+		return nil, err
+	}
+	queries.Queries = append(queries.Queries, orgIDQuery)
+	return q.SearchOrgDomains(ctx, queries, false)
+}
+
 func (q *Queries) SearchOrgDomains(ctx context.Context, queries *OrgDomainSearchQueries, withOwnerRemoved bool) (domains *Domains, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -10,6 +10,7 @@ import (
 	"testing"
 
 	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/stretchr/testify/assert"
 
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
@@ -437,3 +438,58 @@ func TestQueries_IsOrgUnique(t *testing.T) {
 
 	}
 }
+
+func TestOrgs_RemoveNoPermission(t *testing.T) {
+	permissionCheck := func(ctx context.Context, permission, orgID, resourceID string) error {
+		if orgID == "org1" {
+			return nil
+		}
+		return errors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+	}
+	tests := []struct {
+		name string
+		orgs *Orgs
+		want *Orgs
+	}{
+		{
+			name: "all allowed",
+			orgs: &Orgs{
+				SearchResponse: SearchResponse{Count: 1},
+				Orgs:           []*Org{{ID: "org1"}},
+			},
+			want: &Orgs{
+				SearchResponse: SearchResponse{Count: 1},
+				Orgs:           []*Org{{ID: "org1"}},
+			},
+		},
+		{
+			name: "other orgs removed",
+			orgs: &Orgs{
+				SearchResponse: SearchResponse{Count: 3},
+				Orgs:           []*Org{{ID: "org2"}, {ID: "org1"}, {ID: "org3"}},
+			},
+			want: &Orgs{
+				SearchResponse: SearchResponse{Count: 1},
+				Orgs:           []*Org{{ID: "org1"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.orgs.RemoveNoPermission(context.Background(), permissionCheck)
+			assert.Equal(t, tt.want, tt.orgs)
+		})
+	}
+}
+
+func TestQueries_OrgWithPermission_denied(t *testing.T) {
+	q := &Queries{
+		checkPermission: func(ctx context.Context, permission, orgID, resourceID string) error {
+			return errors.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+		},
+	}
+	_, err := q.OrgByIDWithPermission(context.Background(), false, "org1")
+	assert.True(t, errors.IsPermissionDenied(err), "unexpected error: %v", err)
+	_, err = q.SearchOrgDomainsWithPermission(context.Background(), "org1", &OrgDomainSearchQueries{})
+	assert.True(t, errors.IsPermissionDenied(err), "unexpected error: %v", err)
+}
@@ -14,7 +14,7 @@ import (
 )
 
 const (
-	ActionTable            = "projections.actions3"
+	ActionTable            = "projections.actions4"
 	ActionIDCol            = "id"
 	ActionCreationDateCol  = "creation_date"
 	ActionChangeDateCol    = "change_date"
@@ -27,6 +27,10 @@ const (
 	ActionTimeoutCol       = "timeout"
 	ActionAllowedToFailCol = "allowed_to_fail"
 	ActionOwnerRemovedCol  = "owner_removed"
+	ActionTypeCol          = "action_type"
+	ActionTargetURLCol     = "target_url"
+	ActionMaxRetriesCol    = "max_retries"
+	ActionSigningKeyCol    = "signing_key"
 )
 
 type actionProjection struct{}
@@ -54,6 +58,10 @@ func (*actionProjection) Init() *old_handler.Check {
 			handler.NewColumn(ActionTimeoutCol, handler.ColumnTypeInt64, handler.Default(0)),
 			handler.NewColumn(ActionAllowedToFailCol, handler.ColumnTypeBool, handler.Default(false)),
 			handler.NewColumn(ActionOwnerRemovedCol, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(ActionTypeCol, handler.ColumnTypeEnum, handler.Default(0)),
+			handler.NewColumn(ActionTargetURLCol, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(ActionMaxRetriesCol, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(ActionSigningKeyCol, handler.ColumnTypeJSONB, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(ActionInstanceIDCol, ActionIDCol),
 			handler.WithIndex(handler.NewIndex("resource_owner", []string{ActionResourceOwnerCol})),
@@ -87,6 +95,10 @@ func (p *actionProjection) Reducers() []handler.AggregateReducer {
 					Event:  action.RemovedEventType,
 					Reduce: p.reduceActionRemoved,
 				},
+				{
+					Event:  action.SigningKeyChangedEventType,
+					Reduce: p.reduceActionSigningKeyChanged,
+				},
 			},
 		},
 		{
@@ -129,6 +141,10 @@ func (p *actionProjection) reduceActionAdded(event eventstore.Event) (*handler.S
 			handler.NewCol(ActionTimeoutCol, e.Timeout),
 			handler.NewCol(ActionAllowedToFailCol, e.AllowedToFail),
 			handler.NewCol(ActionStateCol, domain.ActionStateActive),
+			handler.NewCol(ActionTypeCol, e.ActionType),
+			handler.NewCol(ActionTargetURLCol, e.TargetURL),
+			handler.NewCol(ActionMaxRetriesCol, e.MaxRetries),
+			handler.NewCol(ActionSigningKeyCol, e.SigningKey),
 		},
 	), nil
 }
@@ -154,6 +170,12 @@ func (p *actionProjection) reduceActionChanged(event eventstore.Event) (*handler
 	if e.AllowedToFail != nil {
 		values = append(values, handler.NewCol(ActionAllowedToFailCol, *e.AllowedToFail))
 	}
+	if e.TargetURL != nil {
+		values = append(values, handler.NewCol(ActionTargetURLCol, *e.TargetURL))
+	}
+	if e.MaxRetries != nil {
+		values = append(values, handler.NewCol(ActionMaxRetriesCol, *e.MaxRetries))
+	}
 	return handler.NewUpdateStatement(
 		e,
 		values,
@@ -216,6 +238,25 @@ func (p *actionProjection) reduceActionRemoved(event eventstore.Event) (*handler
 	), nil
 }
 
+func (p *actionProjection) reduceActionSigningKeyChanged(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*action.SigningKeyChangedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-ohW3a", "reduce.wrong.event.type %s", action.SigningKeyChangedEventType)
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(ActionChangeDateCol, e.CreationDate()),
+			handler.NewCol(ActionSequenceCol, e.Sequence()),
+			handler.NewCol(ActionSigningKeyCol, e.SigningKey),
+		},
+		[]handler.Condition{
+			handler.NewCond(ActionIDCol, e.Aggregate().ID),
+			handler.NewCond(ActionInstanceIDCol, event.Aggregate().InstanceID),
+		},
+	), nil
+}
+
 func (p *actionProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
 	e, ok := event.(*org.OrgRemovedEvent)
 	if !ok {
@@ -15,7 +15,7 @@ import (
 )
 
 const (
-	AppProjectionTable = "projections.apps6"
+	AppProjectionTable = "projections.apps12"
 	AppAPITable        = AppProjectionTable + "_" + appAPITableSuffix
 	AppOIDCTable       = AppProjectionTable + "_" + appOIDCTableSuffix
 	AppSAMLTable       = AppProjectionTable + "_" + appSAMLTableSuffix
@@ -37,33 +37,43 @@ const (
 	AppAPIConfigColumnClientSecret = "client_secret"
 	AppAPIConfigColumnAuthMethod   = "auth_method"
 
-	appOIDCTableSuffix                          = "oidc_configs"
-	AppOIDCConfigColumnAppID                    = "app_id"
-	AppOIDCConfigColumnInstanceID               = "instance_id"
-	AppOIDCConfigColumnVersion                  = "version"
-	AppOIDCConfigColumnClientID                 = "client_id"
-	AppOIDCConfigColumnClientSecret             = "client_secret"
-	AppOIDCConfigColumnRedirectUris             = "redirect_uris"
-	AppOIDCConfigColumnResponseTypes            = "response_types"
-	AppOIDCConfigColumnGrantTypes               = "grant_types"
-	AppOIDCConfigColumnApplicationType          = "application_type"
-	AppOIDCConfigColumnAuthMethodType           = "auth_method_type"
-	AppOIDCConfigColumnPostLogoutRedirectUris   = "post_logout_redirect_uris"
-	AppOIDCConfigColumnDevMode                  = "is_dev_mode"
-	AppOIDCConfigColumnAccessTokenType          = "access_token_type"
-	AppOIDCConfigColumnAccessTokenRoleAssertion = "access_token_role_assertion"
-	AppOIDCConfigColumnIDTokenRoleAssertion     = "id_token_role_assertion"
-	AppOIDCConfigColumnIDTokenUserinfoAssertion = "id_token_userinfo_assertion"
-	AppOIDCConfigColumnClockSkew                = "clock_skew"
-	AppOIDCConfigColumnAdditionalOrigins        = "additional_origins"
-	AppOIDCConfigColumnSkipNativeAppSuccessPage = "skip_native_app_success_page"
-
-	appSAMLTableSuffix             = "saml_configs"
-	AppSAMLConfigColumnAppID       = "app_id"
-	AppSAMLConfigColumnInstanceID  = "instance_id"
-	AppSAMLConfigColumnEntityID    = "entity_id"
-	AppSAMLConfigColumnMetadata    = "metadata"
-	AppSAMLConfigColumnMetadataURL = "metadata_url"
+	appOIDCTableSuffix                                  = "oidc_configs"
+	AppOIDCConfigColumnAppID                            = "app_id"
+	AppOIDCConfigColumnInstanceID                       = "instance_id"
+	AppOIDCConfigColumnVersion                          = "version"
+	AppOIDCConfigColumnClientID                         = "client_id"
+	AppOIDCConfigColumnClientSecret                     = "client_secret"
+	AppOIDCConfigColumnRedirectUris                     = "redirect_uris"
+	AppOIDCConfigColumnResponseTypes                    = "response_types"
+	AppOIDCConfigColumnGrantTypes                       = "grant_types"
+	AppOIDCConfigColumnApplicationType                  = "application_type"
+	AppOIDCConfigColumnAuthMethodType                   = "auth_method_type"
+	AppOIDCConfigColumnPostLogoutRedirectUris           = "post_logout_redirect_uris"
+	AppOIDCConfigColumnDevMode                          = "is_dev_mode"
+	AppOIDCConfigColumnAccessTokenType                  = "access_token_type"
+	AppOIDCConfigColumnAccessTokenRoleAssertion         = "access_token_role_assertion"
+	AppOIDCConfigColumnIDTokenRoleAssertion             = "id_token_role_assertion"
+	AppOIDCConfigColumnIDTokenUserinfoAssertion         = "id_token_userinfo_assertion"
+	AppOIDCConfigColumnClockSkew                        = "clock_skew"
+	AppOIDCConfigColumnAdditionalOrigins                = "additional_origins"
+	AppOIDCConfigColumnSkipNativeAppSuccessPage         = "skip_native_app_success_page"
+	AppOIDCConfigColumnBackChannelLogoutURI             = "back_channel_logout_uri"
+	AppOIDCConfigColumnFrontChannelLogoutURI            = "front_channel_logout_uri"
+	AppOIDCConfigColumnRequirePushedAuthRequest         = "require_pushed_auth_request"
+	AppOIDCConfigColumnRequireSignedRequest             = "require_signed_request"
+	AppOIDCConfigColumnDPoPBoundAccessTokens            = "dpop_bound_access_tokens"
+	AppOIDCConfigColumnForceRSASignedTokens             = "force_rsa_signed_tokens"
+	AppOIDCConfigColumnBackChannelClientNotificationURI = "back_channel_client_notification_uri"
+
+	appSAMLTableSuffix                   = "saml_configs"
+	AppSAMLConfigColumnAppID             = "app_id"
+	AppSAMLConfigColumnInstanceID        = "instance_id"
+	AppSAMLConfigColumnEntityID          = "entity_id"
+	AppSAMLConfigColumnMetadata          = "metadata"
+	AppSAMLConfigColumnMetadataURL       = "metadata_url"
+	AppSAMLConfigColumnEncryptAssertions = "encrypt_assertions"
+	AppSAMLConfigColumnArtifactBinding   = "artifact_binding"
+	AppSAMLConfigColumnSingleLogout      = "single_logout"
 )
 
 type appProjection struct{}
@@ -124,6 +134,13 @@ func (*appProjection) Init() *old_handler.Check {
 			handler.NewColumn(AppOIDCConfigColumnClockSkew, handler.ColumnTypeInt64, handler.Default(0)),
 			handler.NewColumn(AppOIDCConfigColumnAdditionalOrigins, handler.ColumnTypeTextArray, handler.Nullable()),
 			handler.NewColumn(AppOIDCConfigColumnSkipNativeAppSuccessPage, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnBackChannelLogoutURI, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(AppOIDCConfigColumnFrontChannelLogoutURI, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(AppOIDCConfigColumnRequirePushedAuthRequest, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnRequireSignedRequest, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnDPoPBoundAccessTokens, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnForceRSASignedTokens, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppOIDCConfigColumnBackChannelClientNotificationURI, handler.ColumnTypeText, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(AppOIDCConfigColumnInstanceID, AppOIDCConfigColumnAppID),
 			appOIDCTableSuffix,
@@ -136,6 +153,9 @@ func (*appProjection) Init() *old_handler.Check {
 			handler.NewColumn(AppSAMLConfigColumnEntityID, handler.ColumnTypeText),
 			handler.NewColumn(AppSAMLConfigColumnMetadata, handler.ColumnTypeBytes),
 			handler.NewColumn(AppSAMLConfigColumnMetadataURL, handler.ColumnTypeText),
+			handler.NewColumn(AppSAMLConfigColumnEncryptAssertions, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppSAMLConfigColumnArtifactBinding, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(AppSAMLConfigColumnSingleLogout, handler.ColumnTypeBool, handler.Default(false)),
 		},
 			handler.NewPrimaryKey(AppSAMLConfigColumnInstanceID, AppSAMLConfigColumnAppID),
 			appSAMLTableSuffix,
@@ -464,6 +484,13 @@ func (p *appProjection) reduceOIDCConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppOIDCConfigColumnClockSkew, e.ClockSkew),
 				handler.NewCol(AppOIDCConfigColumnAdditionalOrigins, database.TextArray[string](e.AdditionalOrigins)),
 				handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, e.SkipNativeAppSuccessPage),
+				handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, e.BackChannelLogoutURI),
+				handler.NewCol(AppOIDCConfigColumnFrontChannelLogoutURI, e.FrontChannelLogoutURI),
+				handler.NewCol(AppOIDCConfigColumnRequirePushedAuthRequest, e.RequirePushedAuthRequest),
+				handler.NewCol(AppOIDCConfigColumnRequireSignedRequest, e.RequireSignedRequest),
+				handler.NewCol(AppOIDCConfigColumnDPoPBoundAccessTokens, e.DPoPBoundAccessTokens),
+				handler.NewCol(AppOIDCConfigColumnForceRSASignedTokens, e.ForceRSASignedTokens),
+				handler.NewCol(AppOIDCConfigColumnBackChannelClientNotificationURI, e.BackChannelClientNotificationURI),
 			},
 			handler.WithTableSuffix(appOIDCTableSuffix),
 		),
@@ -532,6 +559,27 @@ func (p *appProjection) reduceOIDCConfigChanged(event eventstore.Event) (*handle
 	if e.SkipNativeAppSuccessPage != nil {
 		cols = append(cols, handler.NewCol(AppOIDCConfigColumnSkipNativeAppSuccessPage, *e.SkipNativeAppSuccessPage))
 	}
+	if e.BackChannelLogoutURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnBackChannelLogoutURI, *e.BackChannelLogoutURI))
+	}
+	if e.FrontChannelLogoutURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnFrontChannelLogoutURI, *e.FrontChannelLogoutURI))
+	}
+	if e.RequirePushedAuthRequest != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequirePushedAuthRequest, *e.RequirePushedAuthRequest))
+	}
+	if e.RequireSignedRequest != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnRequireSignedRequest, *e.RequireSignedRequest))
+	}
+	if e.DPoPBoundAccessTokens != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnDPoPBoundAccessTokens, *e.DPoPBoundAccessTokens))
+	}
+	if e.ForceRSASignedTokens != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnForceRSASignedTokens, *e.ForceRSASignedTokens))
+	}
+	if e.BackChannelClientNotificationURI != nil {
+		cols = append(cols, handler.NewCol(AppOIDCConfigColumnBackChannelClientNotificationURI, *e.BackChannelClientNotificationURI))
+	}
 
 	if len(cols) == 0 {
 		return handler.NewNoOpStatement(e), nil
@@ -619,6 +667,9 @@ func (p *appProjection) reduceSAMLConfigAdded(event eventstore.Event) (*handler.
 				handler.NewCol(AppSAMLConfigColumnEntityID, e.EntityID),
 				handler.NewCol(AppSAMLConfigColumnMetadata, e.Metadata),
 				handler.NewCol(AppSAMLConfigColumnMetadataURL, e.MetadataURL),
+				handler.NewCol(AppSAMLConfigColumnEncryptAssertions, e.EncryptAssertions),
+				handler.NewCol(AppSAMLConfigColumnArtifactBinding, e.ArtifactBinding),
+				handler.NewCol(AppSAMLConfigColumnSingleLogout, e.SingleLogout),
 			},
 			handler.WithTableSuffix(appSAMLTableSuffix),
 		),
@@ -641,7 +692,7 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 		return nil, errors.ThrowInvalidArgument(nil, "HANDL-GMHU2", "reduce.wrong.event.type")
 	}
 
-	cols := make([]handler.Column, 0, 3)
+	cols := make([]handler.Column, 0, 6)
 	if e.Metadata != nil {
 		cols = append(cols, handler.NewCol(AppSAMLConfigColumnMetadata, e.Metadata))
 	}
@@ -651,6 +702,15 @@ func (p *appProjection) reduceSAMLConfigChanged(event eventstore.Event) (*handle
 	if e.EntityID != "" {
 		cols = append(cols, handler.NewCol(AppSAMLConfigColumnEntityID, e.EntityID))
 	}
+	if e.EncryptAssertions != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnEncryptAssertions, *e.EncryptAssertions))
+	}
+	if e.ArtifactBinding != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnArtifactBinding, *e.ArtifactBinding))
+	}
+	if e.SingleLogout != nil {
+		cols = append(cols, handler.NewCol(AppSAMLConfigColumnSingleLogout, *e.SingleLogout))
+	}
 
 	if len(cols) == 0 {
 		return handler.NewNoOpStatement(e), nil
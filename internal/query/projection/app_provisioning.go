@@ -0,0 +1,272 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+const (
+	AppProvisioningProjectionTable = "projections.app_provisionings"
+
+	AppProvisioningColumnAppID         = "app_id"
+	AppProvisioningColumnProjectID     = "project_id"
+	AppProvisioningColumnInstanceID    = "instance_id"
+	AppProvisioningColumnResourceOwner = "resource_owner"
+	AppProvisioningColumnCreationDate  = "creation_date"
+	AppProvisioningColumnChangeDate    = "change_date"
+	AppProvisioningColumnSequence      = "sequence"
+	AppProvisioningColumnEndpoint      = "endpoint"
+	AppProvisioningColumnToken         = "token"
+)
+
+// The delivery log is written by the provisioning handler (internal/provisioning),
+// which delivers the users to the service providers.
+// The table is created and cleaned up by this projection.
+const (
+	AppProvisioningDeliveryHandlerName = "projections.app_provisioning_deliveries"
+
+	AppProvisioningLogTableSuffix = "logs"
+	AppProvisioningLogTable       = AppProvisioningProjectionTable + "_" + AppProvisioningLogTableSuffix
+
+	AppProvisioningLogColumnInstanceID    = "instance_id"
+	AppProvisioningLogColumnAppID         = "app_id"
+	AppProvisioningLogColumnProjectID     = "project_id"
+	AppProvisioningLogColumnResourceOwner = "resource_owner"
+	AppProvisioningLogColumnUserID        = "user_id"
+	AppProvisioningLogColumnAggregateID   = "aggregate_id"
+	AppProvisioningLogColumnSequence      = "sequence"
+	AppProvisioningLogColumnEventType     = "event_type"
+	AppProvisioningLogColumnCreationDate  = "creation_date"
+	AppProvisioningLogColumnOperation     = "operation"
+	AppProvisioningLogColumnSucceeded     = "succeeded"
+	AppProvisioningLogColumnAttempts      = "attempts"
+	AppProvisioningLogColumnError         = "error"
+)
+
+type appProvisioningProjection struct{}
+
+func newAppProvisioningProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(appProvisioningProjection))
+}
+
+func (*appProvisioningProjection) Name() string {
+	return AppProvisioningProjectionTable
+}
+
+func (*appProvisioningProjection) Init() *old_handler.Check {
+	return handler.NewMultiTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(AppProvisioningColumnAppID, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningColumnProjectID, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(AppProvisioningColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(AppProvisioningColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(AppProvisioningColumnEndpoint, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningColumnToken, handler.ColumnTypeJSONB),
+		},
+			handler.NewPrimaryKey(AppProvisioningColumnInstanceID, AppProvisioningColumnAppID),
+			handler.WithIndex(handler.NewIndex("project_id", []string{AppProvisioningColumnProjectID})),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(AppProvisioningLogColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningLogColumnAppID, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningLogColumnProjectID, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningLogColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningLogColumnUserID, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningLogColumnAggregateID, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningLogColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(AppProvisioningLogColumnEventType, handler.ColumnTypeText),
+			handler.NewColumn(AppProvisioningLogColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(AppProvisioningLogColumnOperation, handler.ColumnTypeEnum),
+			handler.NewColumn(AppProvisioningLogColumnSucceeded, handler.ColumnTypeBool),
+			handler.NewColumn(AppProvisioningLogColumnAttempts, handler.ColumnTypeInt64),
+			handler.NewColumn(AppProvisioningLogColumnError, handler.ColumnTypeText, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(AppProvisioningLogColumnInstanceID, AppProvisioningLogColumnAppID, AppProvisioningLogColumnAggregateID, AppProvisioningLogColumnSequence),
+			AppProvisioningLogTableSuffix,
+			handler.WithIndex(handler.NewIndex("user_id", []string{AppProvisioningLogColumnUserID})),
+		),
+	)
+}
+
+func (p *appProvisioningProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: project.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  project.ApplicationProvisioningSetType,
+					Reduce: p.reduceProvisioningSet,
+				},
+				{
+					Event:  project.ApplicationProvisioningRemovedType,
+					Reduce: p.reduceProvisioningRemoved,
+				},
+				{
+					Event:  project.ApplicationRemovedType,
+					Reduce: p.reduceAppRemoved,
+				},
+				{
+					Event:  project.ProjectRemovedType,
+					Reduce: p.reduceProjectRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: p.reduceInstanceRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *appProvisioningProjection) reduceProvisioningSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.ApplicationProvisioningSetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	conflictCols := []handler.Column{
+		handler.NewCol(AppProvisioningColumnInstanceID, e.Aggregate().InstanceID),
+		handler.NewCol(AppProvisioningColumnAppID, e.AppID),
+	}
+	updateCols := []handler.Column{
+		handler.NewCol(AppProvisioningColumnInstanceID, e.Aggregate().InstanceID),
+		handler.NewCol(AppProvisioningColumnAppID, e.AppID),
+		handler.NewCol(AppProvisioningColumnProjectID, e.Aggregate().ID),
+		handler.NewCol(AppProvisioningColumnResourceOwner, e.Aggregate().ResourceOwner),
+		handler.NewCol(AppProvisioningColumnCreationDate, handler.OnlySetValueOnInsert(AppProvisioningProjectionTable, e.CreationDate())),
+		handler.NewCol(AppProvisioningColumnChangeDate, e.CreationDate()),
+		handler.NewCol(AppProvisioningColumnSequence, e.Sequence()),
+		handler.NewCol(AppProvisioningColumnEndpoint, e.Endpoint),
+	}
+	// the token is only set if it changed
+	if e.Token != nil {
+		updateCols = append(updateCols, handler.NewCol(AppProvisioningColumnToken, e.Token))
+	}
+	return handler.NewUpsertStatement(e, conflictCols, updateCols), nil
+}
+
+func (p *appProvisioningProjection) reduceProvisioningRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.ApplicationProvisioningRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(AppProvisioningColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(AppProvisioningColumnAppID, e.AppID),
+		},
+	), nil
+}
+
+func (p *appProvisioningProjection) reduceAppRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.ApplicationRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AppProvisioningColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(AppProvisioningColumnAppID, e.AppID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AppProvisioningLogColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(AppProvisioningLogColumnAppID, e.AppID),
+			},
+			handler.WithTableSuffix(AppProvisioningLogTableSuffix),
+		),
+	), nil
+}
+
+func (p *appProvisioningProjection) reduceProjectRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*project.ProjectRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AppProvisioningColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(AppProvisioningColumnProjectID, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AppProvisioningLogColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(AppProvisioningLogColumnProjectID, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(AppProvisioningLogTableSuffix),
+		),
+	), nil
+}
+
+func (p *appProvisioningProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AppProvisioningColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(AppProvisioningColumnResourceOwner, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AppProvisioningLogColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(AppProvisioningLogColumnResourceOwner, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(AppProvisioningLogTableSuffix),
+		),
+	), nil
+}
+
+func (p *appProvisioningProjection) reduceInstanceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.InstanceRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AppProvisioningColumnInstanceID, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(AppProvisioningLogColumnInstanceID, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(AppProvisioningLogTableSuffix),
+		),
+	), nil
+}
@@ -0,0 +1,271 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/project"
+)
+
+func TestAppProvisioningProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceProvisioningSet",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ApplicationProvisioningSetType,
+						project.AggregateType,
+						[]byte(`{"appId": "app-id", "endpoint": "https://app.example.com/scim/v2", "token": {"cryptoType": 0, "algorithm": "enc", "keyId": "id", "crypted": "dG9rZW4="}}`),
+					), project.ApplicationProvisioningSetEventMapper),
+			},
+			reduce: (&appProvisioningProjection{}).reduceProvisioningSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.app_provisionings (instance_id, app_id, project_id, resource_owner, creation_date, change_date, sequence, endpoint, token) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9) ON CONFLICT (instance_id, app_id) DO UPDATE SET (project_id, resource_owner, creation_date, change_date, sequence, endpoint, token) = (EXCLUDED.project_id, EXCLUDED.resource_owner, projections.app_provisionings.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.endpoint, EXCLUDED.token)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+								"agg-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"https://app.example.com/scim/v2",
+								&crypto.CryptoValue{
+									CryptoType: crypto.TypeEncryption,
+									Algorithm:  "enc",
+									KeyID:      "id",
+									Crypted:    []byte("token"),
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProvisioningSet without token",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ApplicationProvisioningSetType,
+						project.AggregateType,
+						[]byte(`{"appId": "app-id", "endpoint": "https://app.example.com/scim/v2"}`),
+					), project.ApplicationProvisioningSetEventMapper),
+			},
+			reduce: (&appProvisioningProjection{}).reduceProvisioningSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.app_provisionings (instance_id, app_id, project_id, resource_owner, creation_date, change_date, sequence, endpoint) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (instance_id, app_id) DO UPDATE SET (project_id, resource_owner, creation_date, change_date, sequence, endpoint) = (EXCLUDED.project_id, EXCLUDED.resource_owner, projections.app_provisionings.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.endpoint)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+								"agg-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"https://app.example.com/scim/v2",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProvisioningRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ApplicationProvisioningRemovedType,
+						project.AggregateType,
+						[]byte(`{"appId": "app-id"}`),
+					), project.ApplicationProvisioningRemovedEventMapper),
+			},
+			reduce: (&appProvisioningProjection{}).reduceProvisioningRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.app_provisionings WHERE (instance_id = $1) AND (app_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceAppRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ApplicationRemovedType,
+						project.AggregateType,
+						[]byte(`{"appId": "app-id"}`),
+					), project.ApplicationRemovedEventMapper),
+			},
+			reduce: (&appProvisioningProjection{}).reduceAppRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.app_provisionings WHERE (instance_id = $1) AND (app_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.app_provisionings_logs WHERE (instance_id = $1) AND (app_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"app-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceProjectRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						project.ProjectRemovedType,
+						project.AggregateType,
+						[]byte(`{}`),
+					), project.ProjectRemovedEventMapper),
+			},
+			reduce: (&appProvisioningProjection{}).reduceProjectRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("project"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.app_provisionings WHERE (instance_id = $1) AND (project_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.app_provisionings_logs WHERE (instance_id = $1) AND (project_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						org.OrgRemovedEventType,
+						org.AggregateType,
+						nil,
+					), org.OrgRemovedEventMapper),
+			},
+			reduce: (&appProvisioningProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.app_provisionings WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.app_provisionings_logs WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(
+					testEvent(
+						instance.InstanceRemovedEventType,
+						instance.AggregateType,
+						nil,
+					), instance.InstanceRemovedEventMapper),
+			},
+			reduce: (&appProvisioningProjection{}).reduceInstanceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.app_provisionings WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.app_provisionings_logs WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, AppProvisioningProjectionTable, tt.want)
+		})
+	}
+}
@@ -0,0 +1,169 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+)
+
+const (
+	BackChannelAuthProjectionTable = "projections.backchannel_authentications"
+
+	BackChannelAuthColumnID                      = "id"
+	BackChannelAuthColumnClientID                = "client_id"
+	BackChannelAuthColumnAuthReqID               = "auth_req_id"
+	BackChannelAuthColumnUserID                  = "user_id"
+	BackChannelAuthColumnScopes                  = "scopes"
+	BackChannelAuthColumnBindingMessage          = "binding_message"
+	BackChannelAuthColumnClientNotificationToken = "client_notification_token"
+	BackChannelAuthColumnExpires                 = "expires"
+	BackChannelAuthColumnState                   = "state"
+	BackChannelAuthColumnAuthTime                = "auth_time"
+	BackChannelAuthColumnAuthMethods             = "auth_methods"
+
+	BackChannelAuthColumnCreationDate = "creation_date"
+	BackChannelAuthColumnChangeDate   = "change_date"
+	BackChannelAuthColumnSequence     = "sequence"
+	BackChannelAuthColumnInstanceID   = "instance_id"
+)
+
+type backChannelAuthProjection struct{}
+
+func newBackChannelAuthProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(backChannelAuthProjection))
+}
+
+func (*backChannelAuthProjection) Name() string {
+	return BackChannelAuthProjectionTable
+}
+
+func (*backChannelAuthProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(BackChannelAuthColumnID, handler.ColumnTypeText),
+			handler.NewColumn(BackChannelAuthColumnClientID, handler.ColumnTypeText),
+			handler.NewColumn(BackChannelAuthColumnAuthReqID, handler.ColumnTypeText),
+			handler.NewColumn(BackChannelAuthColumnUserID, handler.ColumnTypeText),
+			handler.NewColumn(BackChannelAuthColumnScopes, handler.ColumnTypeTextArray),
+			handler.NewColumn(BackChannelAuthColumnBindingMessage, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(BackChannelAuthColumnClientNotificationToken, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(BackChannelAuthColumnExpires, handler.ColumnTypeTimestamp),
+			handler.NewColumn(BackChannelAuthColumnState, handler.ColumnTypeEnum, handler.Default(domain.BackChannelAuthStateInitiated)),
+			handler.NewColumn(BackChannelAuthColumnAuthTime, handler.ColumnTypeTimestamp, handler.Nullable()),
+			handler.NewColumn(BackChannelAuthColumnAuthMethods, handler.ColumnTypeEnumArray, handler.Nullable()),
+			handler.NewColumn(BackChannelAuthColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(BackChannelAuthColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(BackChannelAuthColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(BackChannelAuthColumnInstanceID, handler.ColumnTypeText),
+		},
+			handler.NewPrimaryKey(BackChannelAuthColumnInstanceID, BackChannelAuthColumnID),
+			handler.WithIndex(handler.NewIndex("auth_req_id", []string{BackChannelAuthColumnInstanceID, BackChannelAuthColumnClientID, BackChannelAuthColumnAuthReqID})),
+		),
+	)
+}
+
+func (p *backChannelAuthProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: backchannelauth.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  backchannelauth.AddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  backchannelauth.ApprovedEventType,
+					Reduce: p.reduceApproved,
+				},
+				{
+					Event:  backchannelauth.CanceledEventType,
+					Reduce: p.reduceCanceled,
+				},
+				{
+					Event:  backchannelauth.RemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *backChannelAuthProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*backchannelauth.AddedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Aej0u", "reduce.wrong.event.type %T != %s", event, backchannelauth.AddedEventType)
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(BackChannelAuthColumnID, e.Aggregate().ID),
+			handler.NewCol(BackChannelAuthColumnClientID, e.ClientID),
+			handler.NewCol(BackChannelAuthColumnAuthReqID, e.AuthReqID),
+			handler.NewCol(BackChannelAuthColumnUserID, e.UserID),
+			handler.NewCol(BackChannelAuthColumnScopes, e.Scopes),
+			handler.NewCol(BackChannelAuthColumnBindingMessage, e.BindingMessage),
+			handler.NewCol(BackChannelAuthColumnClientNotificationToken, e.ClientNotificationToken),
+			handler.NewCol(BackChannelAuthColumnExpires, e.Expires),
+			handler.NewCol(BackChannelAuthColumnCreationDate, e.CreationDate()),
+			handler.NewCol(BackChannelAuthColumnChangeDate, e.CreationDate()),
+			handler.NewCol(BackChannelAuthColumnSequence, e.Sequence()),
+			handler.NewCol(BackChannelAuthColumnInstanceID, e.Aggregate().InstanceID),
+		},
+	), nil
+}
+
+func (p *backChannelAuthProjection) reduceApproved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*backchannelauth.ApprovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-oeS2o", "reduce.wrong.event.type %T != %s", event, backchannelauth.ApprovedEventType)
+	}
+	return handler.NewUpdateStatement(e,
+		[]handler.Column{
+			handler.NewCol(BackChannelAuthColumnState, domain.BackChannelAuthStateApproved),
+			handler.NewCol(BackChannelAuthColumnAuthTime, e.AuthTime),
+			handler.NewCol(BackChannelAuthColumnAuthMethods, e.AuthMethods),
+			handler.NewCol(BackChannelAuthColumnChangeDate, e.CreationDate()),
+			handler.NewCol(BackChannelAuthColumnSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(BackChannelAuthColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(BackChannelAuthColumnID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *backChannelAuthProjection) reduceCanceled(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*backchannelauth.CanceledEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Eo9ie", "reduce.wrong.event.type %T != %s", event, backchannelauth.CanceledEventType)
+	}
+	return handler.NewUpdateStatement(e,
+		[]handler.Column{
+			handler.NewCol(BackChannelAuthColumnState, e.Reason.State()),
+			handler.NewCol(BackChannelAuthColumnChangeDate, e.CreationDate()),
+			handler.NewCol(BackChannelAuthColumnSequence, e.Sequence()),
+		},
+		[]handler.Condition{
+			handler.NewCond(BackChannelAuthColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(BackChannelAuthColumnID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *backChannelAuthProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*backchannelauth.RemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Ohr0a", "reduce.wrong.event.type %T != %s", event, backchannelauth.RemovedEventType)
+	}
+	return handler.NewDeleteStatement(e,
+		[]handler.Condition{
+			handler.NewCond(BackChannelAuthColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(BackChannelAuthColumnID, e.Aggregate().ID),
+		},
+	), nil
+}
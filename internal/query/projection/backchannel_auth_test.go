@@ -0,0 +1,162 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
+)
+
+func TestBackChannelAuthProjection_reduces(t *testing.T) {
+	expires := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	authTime := time.Date(2024, 1, 1, 11, 58, 0, 0, time.UTC)
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceAdded",
+			args: args{
+				event: getEvent(testEvent(
+					backchannelauth.AddedEventType,
+					backchannelauth.AggregateType,
+					[]byte(`{"ClientID": "clientID", "AuthReqID": "authReqID", "UserID": "userID", "Scopes": ["openid"], "BindingMessage": "binding", "ClientNotificationToken": "token", "Expires": "2024-01-01T12:00:00Z", "State": 1}`),
+				), eventstore.GenericEventMapper[backchannelauth.AddedEvent]),
+			},
+			reduce: (&backChannelAuthProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("backchannel_auth"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.backchannel_authentications (id, client_id, auth_req_id, user_id, scopes, binding_message, client_notification_token, expires, creation_date, change_date, sequence, instance_id) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"clientID",
+								"authReqID",
+								"userID",
+								[]string{"openid"},
+								"binding",
+								"token",
+								expires,
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"instance-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceApproved",
+			args: args{
+				event: getEvent(testEvent(
+					backchannelauth.ApprovedEventType,
+					backchannelauth.AggregateType,
+					[]byte(`{"UserID": "userID", "AuthTime": "2024-01-01T11:58:00Z", "AuthMethods": [4]}`),
+				), eventstore.GenericEventMapper[backchannelauth.ApprovedEvent]),
+			},
+			reduce: (&backChannelAuthProjection{}).reduceApproved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("backchannel_auth"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.backchannel_authentications SET (state, auth_time, auth_methods, change_date, sequence) = ($1, $2, $3, $4, $5) WHERE (instance_id = $6) AND (id = $7)",
+							expectedArgs: []interface{}{
+								domain.BackChannelAuthStateApproved,
+								authTime,
+								[]domain.UserAuthMethodType{domain.UserAuthMethodTypePassword},
+								anyArg{},
+								uint64(15),
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceCanceled",
+			args: args{
+				event: getEvent(testEvent(
+					backchannelauth.CanceledEventType,
+					backchannelauth.AggregateType,
+					[]byte(`{"Reason": "denied"}`),
+				), eventstore.GenericEventMapper[backchannelauth.CanceledEvent]),
+			},
+			reduce: (&backChannelAuthProjection{}).reduceCanceled,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("backchannel_auth"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.backchannel_authentications SET (state, change_date, sequence) = ($1, $2, $3) WHERE (instance_id = $4) AND (id = $5)",
+							expectedArgs: []interface{}{
+								domain.BackChannelAuthStateDenied,
+								anyArg{},
+								uint64(15),
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					backchannelauth.RemovedEventType,
+					backchannelauth.AggregateType,
+					[]byte(`{"ClientID": "clientID", "AuthReqID": "authReqID"}`),
+				), eventstore.GenericEventMapper[backchannelauth.RemovedEvent]),
+			},
+			reduce: (&backChannelAuthProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("backchannel_auth"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.backchannel_authentications WHERE (instance_id = $1) AND (id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, BackChannelAuthProjectionTable, tt.want)
+		})
+	}
+}
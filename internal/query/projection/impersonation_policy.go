@@ -0,0 +1,183 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	ImpersonationPolicyProjectionTable = "projections.impersonation_policies"
+
+	ImpersonationPolicyColumnID                 = "id"
+	ImpersonationPolicyColumnCreationDate       = "creation_date"
+	ImpersonationPolicyColumnChangeDate         = "change_date"
+	ImpersonationPolicyColumnResourceOwner      = "resource_owner"
+	ImpersonationPolicyColumnInstanceID         = "instance_id"
+	ImpersonationPolicyColumnSequence           = "sequence"
+	ImpersonationPolicyColumnStateCol           = "state"
+	ImpersonationPolicyColumnIsDefault          = "is_default"
+	ImpersonationPolicyColumnAllowImpersonation = "allow_impersonation"
+	ImpersonationPolicyColumnOwnerRemoved       = "owner_removed"
+)
+
+type impersonationPolicyProjection struct{}
+
+func newImpersonationPolicyProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(impersonationPolicyProjection))
+}
+
+func (*impersonationPolicyProjection) Name() string {
+	return ImpersonationPolicyProjectionTable
+}
+
+func (*impersonationPolicyProjection) Init() *old_handler.Check {
+	return handler.NewTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(ImpersonationPolicyColumnID, handler.ColumnTypeText),
+			handler.NewColumn(ImpersonationPolicyColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(ImpersonationPolicyColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(ImpersonationPolicyColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(ImpersonationPolicyColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(ImpersonationPolicyColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(ImpersonationPolicyColumnStateCol, handler.ColumnTypeEnum),
+			handler.NewColumn(ImpersonationPolicyColumnIsDefault, handler.ColumnTypeBool),
+			handler.NewColumn(ImpersonationPolicyColumnAllowImpersonation, handler.ColumnTypeBool),
+			handler.NewColumn(ImpersonationPolicyColumnOwnerRemoved, handler.ColumnTypeBool, handler.Default(false)),
+		},
+			handler.NewPrimaryKey(ImpersonationPolicyColumnInstanceID, ImpersonationPolicyColumnID),
+		),
+	)
+}
+
+func (p *impersonationPolicyProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.ImpersonationPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  org.ImpersonationPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+				{
+					Event:  org.ImpersonationPolicyRemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: reduceInstanceRemovedHelper(ImpersonationPolicyColumnInstanceID),
+				},
+				{
+					Event:  instance.ImpersonationPolicyAddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  instance.ImpersonationPolicyChangedEventType,
+					Reduce: p.reduceChanged,
+				},
+			},
+		},
+	}
+}
+
+func (p *impersonationPolicyProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.ImpersonationPolicyAddedEvent
+	var isDefault bool
+	switch e := event.(type) {
+	case *org.ImpersonationPolicyAddedEvent:
+		policyEvent = e.ImpersonationPolicyAddedEvent
+		isDefault = false
+	case *instance.ImpersonationPolicyAddedEvent:
+		policyEvent = e.ImpersonationPolicyAddedEvent
+		isDefault = true
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Iey7a", "reduce.wrong.event.type %v", []eventstore.EventType{org.ImpersonationPolicyAddedEventType, instance.ImpersonationPolicyAddedEventType})
+	}
+	return handler.NewCreateStatement(
+		&policyEvent,
+		[]handler.Column{
+			handler.NewCol(ImpersonationPolicyColumnCreationDate, policyEvent.CreationDate()),
+			handler.NewCol(ImpersonationPolicyColumnChangeDate, policyEvent.CreationDate()),
+			handler.NewCol(ImpersonationPolicyColumnSequence, policyEvent.Sequence()),
+			handler.NewCol(ImpersonationPolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCol(ImpersonationPolicyColumnStateCol, domain.PolicyStateActive),
+			handler.NewCol(ImpersonationPolicyColumnAllowImpersonation, policyEvent.AllowImpersonation),
+			handler.NewCol(ImpersonationPolicyColumnIsDefault, isDefault),
+			handler.NewCol(ImpersonationPolicyColumnResourceOwner, policyEvent.Aggregate().ResourceOwner),
+			handler.NewCol(ImpersonationPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *impersonationPolicyProjection) reduceChanged(event eventstore.Event) (*handler.Statement, error) {
+	var policyEvent policy.ImpersonationPolicyChangedEvent
+	switch e := event.(type) {
+	case *org.ImpersonationPolicyChangedEvent:
+		policyEvent = e.ImpersonationPolicyChangedEvent
+	case *instance.ImpersonationPolicyChangedEvent:
+		policyEvent = e.ImpersonationPolicyChangedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Ohx3o", "reduce.wrong.event.type %v", []eventstore.EventType{org.ImpersonationPolicyChangedEventType, instance.ImpersonationPolicyChangedEventType})
+	}
+	cols := []handler.Column{
+		handler.NewCol(ImpersonationPolicyColumnChangeDate, policyEvent.CreationDate()),
+		handler.NewCol(ImpersonationPolicyColumnSequence, policyEvent.Sequence()),
+	}
+	if policyEvent.AllowImpersonation != nil {
+		cols = append(cols, handler.NewCol(ImpersonationPolicyColumnAllowImpersonation, *policyEvent.AllowImpersonation))
+	}
+	return handler.NewUpdateStatement(
+		&policyEvent,
+		cols,
+		[]handler.Condition{
+			handler.NewCond(ImpersonationPolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCond(ImpersonationPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *impersonationPolicyProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	policyEvent, ok := event.(*org.ImpersonationPolicyRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-Wae2c", "reduce.wrong.event.type %s", org.ImpersonationPolicyRemovedEventType)
+	}
+	return handler.NewDeleteStatement(
+		policyEvent,
+		[]handler.Condition{
+			handler.NewCond(ImpersonationPolicyColumnID, policyEvent.Aggregate().ID),
+			handler.NewCond(ImpersonationPolicyColumnInstanceID, policyEvent.Aggregate().InstanceID),
+		}), nil
+}
+
+func (p *impersonationPolicyProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, ok := event.(*org.OrgRemovedEvent)
+	if !ok {
+		return nil, errors.ThrowInvalidArgumentf(nil, "PROJE-fuX5o", "reduce.wrong.event.type %s", org.OrgRemovedEventType)
+	}
+
+	return handler.NewDeleteStatement(
+		e,
+		[]handler.Condition{
+			handler.NewCond(ImpersonationPolicyColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(ImpersonationPolicyColumnResourceOwner, e.Aggregate().ID),
+		},
+	), nil
+}
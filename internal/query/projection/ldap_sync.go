@@ -0,0 +1,325 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/idp"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/ldapsync"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+const (
+	LDAPSyncProjectionTable = "projections.ldap_syncs"
+
+	LDAPSyncColumnIDPID          = "idp_id"
+	LDAPSyncColumnInstanceID     = "instance_id"
+	LDAPSyncColumnResourceOwner  = "resource_owner"
+	LDAPSyncColumnCreationDate   = "creation_date"
+	LDAPSyncColumnChangeDate     = "change_date"
+	LDAPSyncColumnSequence       = "sequence"
+	LDAPSyncColumnGroupAttribute = "group_attribute"
+	LDAPSyncColumnGroupMappings  = "group_mappings"
+	LDAPSyncColumnSyncEnabled    = "sync_enabled"
+	LDAPSyncColumnSyncInterval   = "sync_interval"
+	LDAPSyncColumnLastStarted    = "last_started"
+)
+
+const (
+	LDAPSyncReportTableSuffix = "reports"
+	LDAPSyncReportTable       = LDAPSyncProjectionTable + "_" + LDAPSyncReportTableSuffix
+
+	LDAPSyncReportColumnInstanceID       = "instance_id"
+	LDAPSyncReportColumnIDPID            = "idp_id"
+	LDAPSyncReportColumnResourceOwner    = "resource_owner"
+	LDAPSyncReportColumnSequence         = "sequence"
+	LDAPSyncReportColumnStarted          = "started"
+	LDAPSyncReportColumnFinished         = "finished"
+	LDAPSyncReportColumnSucceeded        = "succeeded"
+	LDAPSyncReportColumnError            = "error"
+	LDAPSyncReportColumnUsersFound       = "users_found"
+	LDAPSyncReportColumnUsersCreated     = "users_created"
+	LDAPSyncReportColumnUsersUpdated     = "users_updated"
+	LDAPSyncReportColumnUsersDeactivated = "users_deactivated"
+	LDAPSyncReportColumnUsersReactivated = "users_reactivated"
+	LDAPSyncReportColumnGrantsAdded      = "grants_added"
+	LDAPSyncReportColumnGrantsChanged    = "grants_changed"
+	LDAPSyncReportColumnGrantsRemoved    = "grants_removed"
+	LDAPSyncReportColumnFailures         = "failures"
+)
+
+type ldapSyncProjection struct{}
+
+func newLDAPSyncProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(ldapSyncProjection))
+}
+
+func (*ldapSyncProjection) Name() string {
+	return LDAPSyncProjectionTable
+}
+
+func (*ldapSyncProjection) Init() *old_handler.Check {
+	return handler.NewMultiTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(LDAPSyncColumnIDPID, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncColumnGroupAttribute, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(LDAPSyncColumnGroupMappings, handler.ColumnTypeJSONB, handler.Nullable()),
+			handler.NewColumn(LDAPSyncColumnSyncEnabled, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(LDAPSyncColumnSyncInterval, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(LDAPSyncColumnLastStarted, handler.ColumnTypeTimestamp, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(LDAPSyncColumnInstanceID, LDAPSyncColumnIDPID),
+			handler.WithIndex(handler.NewIndex("sync_enabled", []string{LDAPSyncColumnSyncEnabled})),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(LDAPSyncReportColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncReportColumnIDPID, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncReportColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(LDAPSyncReportColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncReportColumnStarted, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncReportColumnFinished, handler.ColumnTypeTimestamp),
+			handler.NewColumn(LDAPSyncReportColumnSucceeded, handler.ColumnTypeBool),
+			handler.NewColumn(LDAPSyncReportColumnError, handler.ColumnTypeText, handler.Nullable()),
+			handler.NewColumn(LDAPSyncReportColumnUsersFound, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncReportColumnUsersCreated, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncReportColumnUsersUpdated, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncReportColumnUsersDeactivated, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncReportColumnUsersReactivated, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncReportColumnGrantsAdded, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncReportColumnGrantsChanged, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncReportColumnGrantsRemoved, handler.ColumnTypeInt64),
+			handler.NewColumn(LDAPSyncReportColumnFailures, handler.ColumnTypeJSONB, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(LDAPSyncReportColumnInstanceID, LDAPSyncReportColumnIDPID, LDAPSyncReportColumnSequence),
+			LDAPSyncReportTableSuffix,
+		),
+	)
+}
+
+func (p *ldapSyncProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: ldapsync.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  ldapsync.SetEventType,
+					Reduce: p.reduceSet,
+				},
+				{
+					Event:  ldapsync.RemovedEventType,
+					Reduce: p.reduceRemoved,
+				},
+				{
+					Event:  ldapsync.StartedEventType,
+					Reduce: p.reduceStarted,
+				},
+				{
+					Event:  ldapsync.FinishedEventType,
+					Reduce: p.reduceFinished,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.IDPRemovedEventType,
+					Reduce: p.reduceIDPRemoved,
+				},
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: p.reduceInstanceRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *ldapSyncProjection) reduceSet(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*ldapsync.SetEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpsertStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(LDAPSyncColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(LDAPSyncColumnIDPID, e.Aggregate().ID),
+		},
+		[]handler.Column{
+			handler.NewCol(LDAPSyncColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(LDAPSyncColumnIDPID, e.Aggregate().ID),
+			handler.NewCol(LDAPSyncColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(LDAPSyncColumnCreationDate, handler.OnlySetValueOnInsert(LDAPSyncProjectionTable, e.CreationDate())),
+			handler.NewCol(LDAPSyncColumnChangeDate, e.CreationDate()),
+			handler.NewCol(LDAPSyncColumnSequence, e.Sequence()),
+			handler.NewCol(LDAPSyncColumnGroupAttribute, e.GroupAttribute),
+			handler.NewCol(LDAPSyncColumnGroupMappings, e.GroupMappings),
+			handler.NewCol(LDAPSyncColumnSyncEnabled, e.SyncEnabled),
+			handler.NewCol(LDAPSyncColumnSyncInterval, e.SyncInterval),
+		},
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*ldapsync.RemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return p.deleteSync(e, e.Aggregate().InstanceID, e.Aggregate().ID), nil
+}
+
+func (p *ldapSyncProjection) reduceStarted(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*ldapsync.StartedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(LDAPSyncColumnChangeDate, e.CreationDate()),
+			handler.NewCol(LDAPSyncColumnSequence, e.Sequence()),
+			handler.NewCol(LDAPSyncColumnLastStarted, e.CreationDate()),
+		},
+		[]handler.Condition{
+			handler.NewCond(LDAPSyncColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(LDAPSyncColumnIDPID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceFinished(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*ldapsync.FinishedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	report := e.Report
+	if report == nil {
+		return handler.NewNoOpStatement(e), nil
+	}
+	var reportErr *string
+	if report.Error != "" {
+		reportErr = &report.Error
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(LDAPSyncReportColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(LDAPSyncReportColumnIDPID, e.Aggregate().ID),
+			handler.NewCol(LDAPSyncReportColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(LDAPSyncReportColumnSequence, e.Sequence()),
+			handler.NewCol(LDAPSyncReportColumnStarted, report.Started),
+			handler.NewCol(LDAPSyncReportColumnFinished, report.Finished),
+			handler.NewCol(LDAPSyncReportColumnSucceeded, report.Succeeded()),
+			handler.NewCol(LDAPSyncReportColumnError, reportErr),
+			handler.NewCol(LDAPSyncReportColumnUsersFound, report.UsersFound),
+			handler.NewCol(LDAPSyncReportColumnUsersCreated, report.UsersCreated),
+			handler.NewCol(LDAPSyncReportColumnUsersUpdated, report.UsersUpdated),
+			handler.NewCol(LDAPSyncReportColumnUsersDeactivated, report.UsersDeactivated),
+			handler.NewCol(LDAPSyncReportColumnUsersReactivated, report.UsersReactivated),
+			handler.NewCol(LDAPSyncReportColumnGrantsAdded, report.GrantsAdded),
+			handler.NewCol(LDAPSyncReportColumnGrantsChanged, report.GrantsChanged),
+			handler.NewCol(LDAPSyncReportColumnGrantsRemoved, report.GrantsRemoved),
+			handler.NewCol(LDAPSyncReportColumnFailures, report.Failures),
+		},
+		handler.WithTableSuffix(LDAPSyncReportTableSuffix),
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceIDPRemoved(event eventstore.Event) (*handler.Statement, error) {
+	var idpEvent idp.RemovedEvent
+	switch e := event.(type) {
+	case *org.IDPRemovedEvent:
+		idpEvent = e.RemovedEvent
+	case *instance.IDPRemovedEvent:
+		idpEvent = e.RemovedEvent
+	default:
+		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-Aech4", "reduce.wrong.event.type %v", []eventstore.EventType{org.IDPRemovedEventType, instance.IDPRemovedEventType})
+	}
+	return p.deleteSync(&idpEvent, idpEvent.Aggregate().InstanceID, idpEvent.ID), nil
+}
+
+func (p *ldapSyncProjection) deleteSync(event eventstore.Event, instanceID, idpID string) *handler.Statement {
+	return handler.NewMultiStatement(
+		event,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncColumnInstanceID, instanceID),
+				handler.NewCond(LDAPSyncColumnIDPID, idpID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncReportColumnInstanceID, instanceID),
+				handler.NewCond(LDAPSyncReportColumnIDPID, idpID),
+			},
+			handler.WithTableSuffix(LDAPSyncReportTableSuffix),
+		),
+	)
+}
+
+func (p *ldapSyncProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(LDAPSyncColumnResourceOwner, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncReportColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(LDAPSyncReportColumnResourceOwner, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(LDAPSyncReportTableSuffix),
+		),
+	), nil
+}
+
+func (p *ldapSyncProjection) reduceInstanceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.InstanceRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncColumnInstanceID, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(LDAPSyncReportColumnInstanceID, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(LDAPSyncReportTableSuffix),
+		),
+	), nil
+}
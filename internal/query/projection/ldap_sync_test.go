@@ -0,0 +1,242 @@
+package projection
+
+import (
+	"testing"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/ldapsync"
+	"github.com/zitadel/zitadel/internal/repository/org"
+)
+
+func TestLDAPSyncProjection_reduces(t *testing.T) {
+	started := time.Date(2024, 1, 1, 12, 0, 0, 0, time.UTC)
+	finished := time.Date(2024, 1, 1, 12, 5, 0, 0, time.UTC)
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceSet",
+			args: args{
+				event: getEvent(testEvent(
+					ldapsync.SetEventType,
+					ldapsync.AggregateType,
+					[]byte(`{"groupAttribute": "memberOf", "groupMappings": [{"group": "cn=admins", "projectId": "project-id", "roles": ["admin"]}], "syncEnabled": true, "syncInterval": 3600000000000}`),
+				), eventstore.GenericEventMapper[ldapsync.SetEvent]),
+			},
+			reduce: (&ldapSyncProjection{}).reduceSet,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("ldap_sync"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.ldap_syncs (instance_id, idp_id, resource_owner, creation_date, change_date, sequence, group_attribute, group_mappings, sync_enabled, sync_interval) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10) ON CONFLICT (instance_id, idp_id) DO UPDATE SET (resource_owner, creation_date, change_date, sequence, group_attribute, group_mappings, sync_enabled, sync_interval) = (EXCLUDED.resource_owner, projections.ldap_syncs.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.group_attribute, EXCLUDED.group_mappings, EXCLUDED.sync_enabled, EXCLUDED.sync_interval)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								"memberOf",
+								[]*domain.LDAPGroupMapping{{Group: "cn=admins", ProjectID: "project-id", Roles: []string{"admin"}}},
+								true,
+								time.Hour,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceStarted",
+			args: args{
+				event: getEvent(testEvent(
+					ldapsync.StartedEventType,
+					ldapsync.AggregateType,
+					[]byte(`{"slot": 1704110400}`),
+				), eventstore.GenericEventMapper[ldapsync.StartedEvent]),
+			},
+			reduce: (&ldapSyncProjection{}).reduceStarted,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("ldap_sync"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.ldap_syncs SET (change_date, sequence, last_started) = ($1, $2, $3) WHERE (instance_id = $4) AND (idp_id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								anyArg{},
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceFinished",
+			args: args{
+				event: getEvent(testEvent(
+					ldapsync.FinishedEventType,
+					ldapsync.AggregateType,
+					[]byte(`{"slot": 1704110400, "report": {"started": "2024-01-01T12:00:00Z", "finished": "2024-01-01T12:05:00Z", "usersFound": 2, "usersCreated": 1, "grantsAdded": 1, "failures": [{"externalUserId": "ext", "error": "failed"}]}}`),
+				), eventstore.GenericEventMapper[ldapsync.FinishedEvent]),
+			},
+			reduce: (&ldapSyncProjection{}).reduceFinished,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("ldap_sync"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.ldap_syncs_reports (instance_id, idp_id, resource_owner, sequence, started, finished, succeeded, error, users_found, users_created, users_updated, users_deactivated, users_reactivated, grants_added, grants_changed, grants_removed, failures) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9, $10, $11, $12, $13, $14, $15, $16, $17)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"ro-id",
+								uint64(15),
+								started,
+								finished,
+								true,
+								(*string)(nil),
+								uint32(2),
+								uint32(1),
+								uint32(0),
+								uint32(0),
+								uint32(0),
+								uint32(1),
+								uint32(0),
+								uint32(0),
+								[]*domain.LDAPSyncFailure{{ExternalUserID: "ext", Error: "failed"}},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					ldapsync.RemovedEventType,
+					ldapsync.AggregateType,
+					nil,
+				), eventstore.GenericEventMapper[ldapsync.RemovedEvent]),
+			},
+			reduce: (&ldapSyncProjection{}).reduceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("ldap_sync"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.ldap_syncs WHERE (instance_id = $1) AND (idp_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.ldap_syncs_reports WHERE (instance_id = $1) AND (idp_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceIDPRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					org.IDPRemovedEventType,
+					org.AggregateType,
+					[]byte(`{"id": "idp-id"}`),
+				), org.IDPRemovedEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceIDPRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.ldap_syncs WHERE (instance_id = $1) AND (idp_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"idp-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.ldap_syncs_reports WHERE (instance_id = $1) AND (idp_id = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"idp-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					instance.InstanceRemovedEventType,
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: (&ldapSyncProjection{}).reduceInstanceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.ldap_syncs WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.ldap_syncs_reports WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, LDAPSyncProjectionTable, tt.want)
+		})
+	}
+}
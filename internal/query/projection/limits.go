@@ -11,7 +11,7 @@ import (
 )
 
 const (
-	LimitsProjectionTable = "projections.limits"
+	LimitsProjectionTable = "projections.limits2"
 
 	LimitsColumnAggregateID   = "aggregate_id"
 	LimitsColumnCreationDate  = "creation_date"
@@ -20,7 +20,8 @@ const (
 	LimitsColumnInstanceID    = "instance_id"
 	LimitsColumnSequence      = "sequence"
 
-	LimitsColumnAuditLogRetention = "audit_log_retention"
+	LimitsColumnAuditLogRetention        = "audit_log_retention"
+	LimitsColumnActionExecutionRetention = "action_execution_retention"
 )
 
 type limitsProjection struct{}
@@ -43,6 +44,7 @@ func (*limitsProjection) Init() *old_handler.Check {
 			handler.NewColumn(LimitsColumnInstanceID, handler.ColumnTypeText),
 			handler.NewColumn(LimitsColumnSequence, handler.ColumnTypeInt64),
 			handler.NewColumn(LimitsColumnAuditLogRetention, handler.ColumnTypeInterval, handler.Nullable()),
+			handler.NewColumn(LimitsColumnActionExecutionRetention, handler.ColumnTypeInterval, handler.Nullable()),
 		},
 			handler.NewPrimaryKey(LimitsColumnInstanceID, LimitsColumnResourceOwner),
 		),
@@ -96,6 +98,9 @@ func (p *limitsProjection) reduceLimitsSet(event eventstore.Event) (*handler.Sta
 	if e.AuditLogRetention != nil {
 		updateCols = append(updateCols, handler.NewCol(LimitsColumnAuditLogRetention, *e.AuditLogRetention))
 	}
+	if e.ActionExecutionRetention != nil {
+		updateCols = append(updateCols, handler.NewCol(LimitsColumnActionExecutionRetention, *e.ActionExecutionRetention))
+	}
 	return handler.NewUpsertStatement(e, conflictCols, updateCols), nil
 }
 
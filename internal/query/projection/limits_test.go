@@ -27,7 +27,8 @@ func TestLimitsProjection_reduces(t *testing.T) {
 					limits.SetEventType,
 					limits.AggregateType,
 					[]byte(`{
-							"auditLogRetention": 300000000000
+							"auditLogRetention": 300000000000,
+							"actionExecutionRetention": 86400000000000
 					}`),
 				), limits.SetEventMapper),
 			},
@@ -38,7 +39,7 @@ func TestLimitsProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "INSERT INTO projections.limits (instance_id, resource_owner, creation_date, change_date, sequence, aggregate_id, audit_log_retention) VALUES ($1, $2, $3, $4, $5, $6, $7) ON CONFLICT (instance_id, resource_owner) DO UPDATE SET (creation_date, change_date, sequence, aggregate_id, audit_log_retention) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.aggregate_id, EXCLUDED.audit_log_retention)",
+							expectedStmt: "INSERT INTO projections.limits2 (instance_id, resource_owner, creation_date, change_date, sequence, aggregate_id, audit_log_retention, action_execution_retention) VALUES ($1, $2, $3, $4, $5, $6, $7, $8) ON CONFLICT (instance_id, resource_owner) DO UPDATE SET (creation_date, change_date, sequence, aggregate_id, audit_log_retention, action_execution_retention) = (EXCLUDED.creation_date, EXCLUDED.change_date, EXCLUDED.sequence, EXCLUDED.aggregate_id, EXCLUDED.audit_log_retention, EXCLUDED.action_execution_retention)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"ro-id",
@@ -47,6 +48,7 @@ func TestLimitsProjection_reduces(t *testing.T) {
 								uint64(15),
 								"agg-id",
 								time.Minute * 5,
+								time.Hour * 24,
 							},
 						},
 					},
@@ -70,7 +72,7 @@ func TestLimitsProjection_reduces(t *testing.T) {
 				executer: &testExecuter{
 					executions: []execution{
 						{
-							expectedStmt: "DELETE FROM projections.limits WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedStmt: "DELETE FROM projections.limits2 WHERE (instance_id = $1) AND (resource_owner = $2)",
 							expectedArgs: []interface{}{
 								"instance-id",
 								"ro-id",
@@ -11,7 +11,7 @@ import (
 )
 
 const (
-	OIDCSettingsProjectionTable = "projections.oidc_settings2"
+	OIDCSettingsProjectionTable = "projections.oidc_settings3"
 
 	OIDCSettingsColumnAggregateID                = "aggregate_id"
 	OIDCSettingsColumnCreationDate               = "creation_date"
@@ -23,6 +23,7 @@ const (
 	OIDCSettingsColumnIdTokenLifetime            = "id_token_lifetime"
 	OIDCSettingsColumnRefreshTokenIdleExpiration = "refresh_token_idle_expiration"
 	OIDCSettingsColumnRefreshTokenExpiration     = "refresh_token_expiration"
+	OIDCSettingsColumnSigningAlgorithm           = "signing_algorithm"
 )
 
 type oidcSettingsProjection struct{}
@@ -48,6 +49,7 @@ func (*oidcSettingsProjection) Init() *old_handler.Check {
 			handler.NewColumn(OIDCSettingsColumnIdTokenLifetime, handler.ColumnTypeInt64),
 			handler.NewColumn(OIDCSettingsColumnRefreshTokenIdleExpiration, handler.ColumnTypeInt64),
 			handler.NewColumn(OIDCSettingsColumnRefreshTokenExpiration, handler.ColumnTypeInt64),
+			handler.NewColumn(OIDCSettingsColumnSigningAlgorithm, handler.ColumnTypeText, handler.Default("")),
 		},
 			handler.NewPrimaryKey(OIDCSettingsColumnInstanceID, OIDCSettingsColumnAggregateID),
 		),
@@ -94,6 +96,7 @@ func (p *oidcSettingsProjection) reduceOIDCSettingsAdded(event eventstore.Event)
 			handler.NewCol(OIDCSettingsColumnIdTokenLifetime, e.IdTokenLifetime),
 			handler.NewCol(OIDCSettingsColumnRefreshTokenIdleExpiration, e.RefreshTokenIdleExpiration),
 			handler.NewCol(OIDCSettingsColumnRefreshTokenExpiration, e.RefreshTokenExpiration),
+			handler.NewCol(OIDCSettingsColumnSigningAlgorithm, e.SigningAlgorithm),
 		},
 	), nil
 }
@@ -104,7 +107,7 @@ func (p *oidcSettingsProjection) reduceOIDCSettingsChanged(event eventstore.Even
 		return nil, errors.ThrowInvalidArgumentf(nil, "HANDL-8JJ2d", "reduce.wrong.event.type %s", instance.OIDCSettingsChangedEventType)
 	}
 
-	columns := make([]handler.Column, 0, 6)
+	columns := make([]handler.Column, 0, 7)
 	columns = append(columns,
 		handler.NewCol(OIDCSettingsColumnChangeDate, e.CreationDate()),
 		handler.NewCol(OIDCSettingsColumnSequence, e.Sequence()),
@@ -121,6 +124,9 @@ func (p *oidcSettingsProjection) reduceOIDCSettingsChanged(event eventstore.Even
 	if e.RefreshTokenExpiration != nil {
 		columns = append(columns, handler.NewCol(OIDCSettingsColumnRefreshTokenExpiration, *e.RefreshTokenExpiration))
 	}
+	if e.SigningAlgorithm != nil {
+		columns = append(columns, handler.NewCol(OIDCSettingsColumnSigningAlgorithm, *e.SigningAlgorithm))
+	}
 	return handler.NewUpdateStatement(
 		e,
 		columns,
@@ -34,9 +34,12 @@ var (
 	LoginPolicyProjection               *handler.Handler
 	IDPProjection                       *handler.Handler
 	AppProjection                       *handler.Handler
+	AppProvisioningProjection           *handler.Handler
 	IDPUserLinkProjection               *handler.Handler
 	IDPLoginPolicyLinkProjection        *handler.Handler
 	IDPTemplateProjection               *handler.Handler
+	LDAPSyncProjection                  *handler.Handler
+	UserImportProjection                *handler.Handler
 	MailTemplateProjection              *handler.Handler
 	MessageTextProjection               *handler.Handler
 	CustomTextProjection                *handler.Handler
@@ -61,10 +64,12 @@ var (
 	KeyProjection                       *handler.Handler
 	SecurityPolicyProjection            *handler.Handler
 	NotificationPolicyProjection        *handler.Handler
+	ImpersonationPolicyProjection       *handler.Handler
 	NotificationsProjection             interface{}
 	NotificationsQuotaProjection        interface{}
 	TelemetryPusherProjection           interface{}
 	DeviceAuthProjection                *handler.Handler
+	BackChannelAuthProjection           *handler.Handler
 	SessionProjection                   *handler.Handler
 	AuthRequestProjection               *handler.Handler
 	MilestoneProjection                 *handler.Handler
@@ -111,9 +116,12 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	LoginPolicyProjection = newLoginPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["login_policies"]))
 	IDPProjection = newIDPProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idps"]))
 	AppProjection = newAppProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["apps"]))
+	AppProvisioningProjection = newAppProvisioningProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["app_provisionings"]))
 	IDPUserLinkProjection = newIDPUserLinkProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_user_links"]))
 	IDPLoginPolicyLinkProjection = newIDPLoginPolicyLinkProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_login_policy_links"]))
 	IDPTemplateProjection = newIDPTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["idp_templates"]))
+	LDAPSyncProjection = newLDAPSyncProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["ldap_syncs"]))
+	UserImportProjection = newUserImportProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["user_imports"]))
 	MailTemplateProjection = newMailTemplateProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["mail_templates"]))
 	MessageTextProjection = newMessageTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["message_texts"]))
 	CustomTextProjection = newCustomTextProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["custom_texts"]))
@@ -138,7 +146,9 @@ func Create(ctx context.Context, sqlClient *database.DB, es handler.EventStore,
 	KeyProjection = newKeyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["keys"]), keyEncryptionAlgorithm, certEncryptionAlgorithm)
 	SecurityPolicyProjection = newSecurityPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["security_policies"]))
 	NotificationPolicyProjection = newNotificationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["notification_policies"]))
+	ImpersonationPolicyProjection = newImpersonationPolicyProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["impersonation_policies"]))
 	DeviceAuthProjection = newDeviceAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["device_auth"]))
+	BackChannelAuthProjection = newBackChannelAuthProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["backchannel_auth"]))
 	SessionProjection = newSessionProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["sessions"]))
 	AuthRequestProjection = newAuthRequestProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["auth_requests"]))
 	MilestoneProjection = newMilestoneProjection(ctx, applyCustomConfig(projectionConfig, config.Customizations["milestones"]), systemUsers)
@@ -216,7 +226,10 @@ func newProjectionsList() {
 		LoginPolicyProjection,
 		IDPProjection,
 		IDPTemplateProjection,
+		LDAPSyncProjection,
+		UserImportProjection,
 		AppProjection,
+		AppProvisioningProjection,
 		IDPUserLinkProjection,
 		IDPLoginPolicyLinkProjection,
 		MailTemplateProjection,
@@ -243,7 +256,9 @@ func newProjectionsList() {
 		KeyProjection,
 		SecurityPolicyProjection,
 		NotificationPolicyProjection,
+		ImpersonationPolicyProjection,
 		DeviceAuthProjection,
+		BackChannelAuthProjection,
 		SessionProjection,
 		AuthRequestProjection,
 		MilestoneProjection,
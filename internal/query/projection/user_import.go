@@ -0,0 +1,286 @@
+package projection
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	old_handler "github.com/zitadel/zitadel/internal/eventstore/handler"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/userimport"
+)
+
+const (
+	UserImportProjectionTable = "projections.user_imports"
+
+	UserImportColumnID            = "id"
+	UserImportColumnInstanceID    = "instance_id"
+	UserImportColumnResourceOwner = "resource_owner"
+	UserImportColumnCreationDate  = "creation_date"
+	UserImportColumnChangeDate    = "change_date"
+	UserImportColumnSequence      = "sequence"
+	UserImportColumnState         = "state"
+	UserImportColumnFormat        = "format"
+	UserImportColumnDryRun        = "dry_run"
+	UserImportColumnProcessedRows = "processed_rows"
+	UserImportColumnFailedRows    = "failed_rows"
+	UserImportColumnError         = "error"
+)
+
+const (
+	UserImportRowTableSuffix = "rows"
+	UserImportRowTable       = UserImportProjectionTable + "_" + UserImportRowTableSuffix
+
+	UserImportRowColumnInstanceID    = "instance_id"
+	UserImportRowColumnImportID      = "import_id"
+	UserImportRowColumnResourceOwner = "resource_owner"
+	UserImportRowColumnRow           = "row_number"
+	UserImportRowColumnUsername      = "username"
+	UserImportRowColumnUserID        = "user_id"
+	UserImportRowColumnSucceeded     = "succeeded"
+	UserImportRowColumnError         = "error"
+)
+
+type userImportProjection struct{}
+
+func newUserImportProjection(ctx context.Context, config handler.Config) *handler.Handler {
+	return handler.NewHandler(ctx, &config, new(userImportProjection))
+}
+
+func (*userImportProjection) Name() string {
+	return UserImportProjectionTable
+}
+
+func (*userImportProjection) Init() *old_handler.Check {
+	return handler.NewMultiTableCheck(
+		handler.NewTable([]*handler.InitColumn{
+			handler.NewColumn(UserImportColumnID, handler.ColumnTypeText),
+			handler.NewColumn(UserImportColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(UserImportColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(UserImportColumnCreationDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(UserImportColumnChangeDate, handler.ColumnTypeTimestamp),
+			handler.NewColumn(UserImportColumnSequence, handler.ColumnTypeInt64),
+			handler.NewColumn(UserImportColumnState, handler.ColumnTypeEnum),
+			handler.NewColumn(UserImportColumnFormat, handler.ColumnTypeEnum),
+			handler.NewColumn(UserImportColumnDryRun, handler.ColumnTypeBool, handler.Default(false)),
+			handler.NewColumn(UserImportColumnProcessedRows, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(UserImportColumnFailedRows, handler.ColumnTypeInt64, handler.Default(0)),
+			handler.NewColumn(UserImportColumnError, handler.ColumnTypeText, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(UserImportColumnInstanceID, UserImportColumnID),
+			handler.WithIndex(handler.NewIndex("state", []string{UserImportColumnState})),
+			handler.WithIndex(handler.NewIndex("resource_owner", []string{UserImportColumnResourceOwner})),
+		),
+		handler.NewSuffixedTable([]*handler.InitColumn{
+			handler.NewColumn(UserImportRowColumnInstanceID, handler.ColumnTypeText),
+			handler.NewColumn(UserImportRowColumnImportID, handler.ColumnTypeText),
+			handler.NewColumn(UserImportRowColumnResourceOwner, handler.ColumnTypeText),
+			handler.NewColumn(UserImportRowColumnRow, handler.ColumnTypeInt64),
+			handler.NewColumn(UserImportRowColumnUsername, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(UserImportRowColumnUserID, handler.ColumnTypeText, handler.Default("")),
+			handler.NewColumn(UserImportRowColumnSucceeded, handler.ColumnTypeBool),
+			handler.NewColumn(UserImportRowColumnError, handler.ColumnTypeText, handler.Nullable()),
+		},
+			handler.NewPrimaryKey(UserImportRowColumnInstanceID, UserImportRowColumnImportID, UserImportRowColumnRow),
+			UserImportRowTableSuffix,
+		),
+	)
+}
+
+func (p *userImportProjection) Reducers() []handler.AggregateReducer {
+	return []handler.AggregateReducer{
+		{
+			Aggregate: userimport.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  userimport.AddedEventType,
+					Reduce: p.reduceAdded,
+				},
+				{
+					Event:  userimport.StartedEventType,
+					Reduce: p.reduceStarted,
+				},
+				{
+					Event:  userimport.RowsProcessedEventType,
+					Reduce: p.reduceRowsProcessed,
+				},
+				{
+					Event:  userimport.FinishedEventType,
+					Reduce: p.reduceFinished,
+				},
+			},
+		},
+		{
+			Aggregate: org.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  org.OrgRemovedEventType,
+					Reduce: p.reduceOwnerRemoved,
+				},
+			},
+		},
+		{
+			Aggregate: instance.AggregateType,
+			EventReducers: []handler.EventReducer{
+				{
+					Event:  instance.InstanceRemovedEventType,
+					Reduce: p.reduceInstanceRemoved,
+				},
+			},
+		},
+	}
+}
+
+func (p *userImportProjection) reduceAdded(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*userimport.AddedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewCreateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserImportColumnID, e.Aggregate().ID),
+			handler.NewCol(UserImportColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCol(UserImportColumnResourceOwner, e.Aggregate().ResourceOwner),
+			handler.NewCol(UserImportColumnCreationDate, e.CreationDate()),
+			handler.NewCol(UserImportColumnChangeDate, e.CreationDate()),
+			handler.NewCol(UserImportColumnSequence, e.Sequence()),
+			handler.NewCol(UserImportColumnState, domain.UserImportStateQueued),
+			handler.NewCol(UserImportColumnFormat, e.Format),
+			handler.NewCol(UserImportColumnDryRun, e.DryRun),
+		},
+	), nil
+}
+
+func (p *userImportProjection) reduceStarted(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*userimport.StartedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserImportColumnChangeDate, e.CreationDate()),
+			handler.NewCol(UserImportColumnSequence, e.Sequence()),
+			handler.NewCol(UserImportColumnState, domain.UserImportStateRunning),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserImportColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserImportColumnID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *userImportProjection) reduceRowsProcessed(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*userimport.RowsProcessedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	var failed int
+	stmts := make([]func(eventstore.Event) handler.Exec, 0, len(e.Results)+1)
+	for _, result := range e.Results {
+		var resultErr *string
+		if !result.Succeeded() {
+			failed++
+			resultErr = &result.Error
+		}
+		stmts = append(stmts, handler.AddCreateStatement(
+			[]handler.Column{
+				handler.NewCol(UserImportRowColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCol(UserImportRowColumnImportID, e.Aggregate().ID),
+				handler.NewCol(UserImportRowColumnResourceOwner, e.Aggregate().ResourceOwner),
+				handler.NewCol(UserImportRowColumnRow, result.Row),
+				handler.NewCol(UserImportRowColumnUsername, result.Username),
+				handler.NewCol(UserImportRowColumnUserID, result.UserID),
+				handler.NewCol(UserImportRowColumnSucceeded, result.Succeeded()),
+				handler.NewCol(UserImportRowColumnError, resultErr),
+			},
+			handler.WithTableSuffix(UserImportRowTableSuffix),
+		))
+	}
+	stmts = append(stmts, handler.AddUpdateStatement(
+		[]handler.Column{
+			handler.NewCol(UserImportColumnChangeDate, e.CreationDate()),
+			handler.NewCol(UserImportColumnSequence, e.Sequence()),
+			handler.NewIncrementCol(UserImportColumnProcessedRows, len(e.Results)),
+			handler.NewIncrementCol(UserImportColumnFailedRows, failed),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserImportColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserImportColumnID, e.Aggregate().ID),
+		},
+	))
+	return handler.NewMultiStatement(e, stmts...), nil
+}
+
+func (p *userImportProjection) reduceFinished(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*userimport.FinishedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	state := domain.UserImportStateDone
+	var importErr *string
+	if e.Error != "" {
+		state = domain.UserImportStateFailed
+		importErr = &e.Error
+	}
+	return handler.NewUpdateStatement(
+		e,
+		[]handler.Column{
+			handler.NewCol(UserImportColumnChangeDate, e.CreationDate()),
+			handler.NewCol(UserImportColumnSequence, e.Sequence()),
+			handler.NewCol(UserImportColumnState, state),
+			handler.NewCol(UserImportColumnError, importErr),
+		},
+		[]handler.Condition{
+			handler.NewCond(UserImportColumnInstanceID, e.Aggregate().InstanceID),
+			handler.NewCond(UserImportColumnID, e.Aggregate().ID),
+		},
+	), nil
+}
+
+func (p *userImportProjection) reduceOwnerRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*org.OrgRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(UserImportColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(UserImportColumnResourceOwner, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(UserImportRowColumnInstanceID, e.Aggregate().InstanceID),
+				handler.NewCond(UserImportRowColumnResourceOwner, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(UserImportRowTableSuffix),
+		),
+	), nil
+}
+
+func (p *userImportProjection) reduceInstanceRemoved(event eventstore.Event) (*handler.Statement, error) {
+	e, err := assertEvent[*instance.InstanceRemovedEvent](event)
+	if err != nil {
+		return nil, err
+	}
+	return handler.NewMultiStatement(
+		e,
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(UserImportColumnInstanceID, e.Aggregate().ID),
+			},
+		),
+		handler.AddDeleteStatement(
+			[]handler.Condition{
+				handler.NewCond(UserImportRowColumnInstanceID, e.Aggregate().ID),
+			},
+			handler.WithTableSuffix(UserImportRowTableSuffix),
+		),
+	), nil
+}
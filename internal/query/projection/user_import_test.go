@@ -0,0 +1,252 @@
+package projection
+
+import (
+	"testing"
+
+	"github.com/muhlemmer/gu"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/repository/instance"
+	"github.com/zitadel/zitadel/internal/repository/org"
+	"github.com/zitadel/zitadel/internal/repository/userimport"
+)
+
+func TestUserImportProjection_reduces(t *testing.T) {
+	type args struct {
+		event func(t *testing.T) eventstore.Event
+	}
+	tests := []struct {
+		name   string
+		args   args
+		reduce func(event eventstore.Event) (*handler.Statement, error)
+		want   wantReduce
+	}{
+		{
+			name: "reduceAdded",
+			args: args{
+				event: getEvent(testEvent(
+					userimport.AddedEventType,
+					userimport.AggregateType,
+					[]byte(`{"format": 1, "dryRun": true, "data": "dXNlcm5hbWUKdXNlcjE="}`),
+				), eventstore.GenericEventMapper[userimport.AddedEvent]),
+			},
+			reduce: (&userImportProjection{}).reduceAdded,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("user_import"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_imports (id, instance_id, resource_owner, creation_date, change_date, sequence, state, format, dry_run) VALUES ($1, $2, $3, $4, $5, $6, $7, $8, $9)",
+							expectedArgs: []interface{}{
+								"agg-id",
+								"instance-id",
+								"ro-id",
+								anyArg{},
+								anyArg{},
+								uint64(15),
+								domain.UserImportStateQueued,
+								domain.UserImportFormatCSV,
+								true,
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceStarted",
+			args: args{
+				event: getEvent(testEvent(
+					userimport.StartedEventType,
+					userimport.AggregateType,
+					nil,
+				), eventstore.GenericEventMapper[userimport.StartedEvent]),
+			},
+			reduce: (&userImportProjection{}).reduceStarted,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("user_import"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_imports SET (change_date, sequence, state) = ($1, $2, $3) WHERE (instance_id = $4) AND (id = $5)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.UserImportStateRunning,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceRowsProcessed",
+			args: args{
+				event: getEvent(testEvent(
+					userimport.RowsProcessedEventType,
+					userimport.AggregateType,
+					[]byte(`{"results": [{"row": 1, "username": "user1", "userId": "user-id"}, {"row": 2, "username": "user2", "error": "Errors.User.AlreadyExisting"}]}`),
+				), eventstore.GenericEventMapper[userimport.RowsProcessedEvent]),
+			},
+			reduce: (&userImportProjection{}).reduceRowsProcessed,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("user_import"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "INSERT INTO projections.user_imports_rows (instance_id, import_id, resource_owner, row_number, username, user_id, succeeded, error) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"ro-id",
+								uint32(1),
+								"user1",
+								"user-id",
+								true,
+								(*string)(nil),
+							},
+						},
+						{
+							expectedStmt: "INSERT INTO projections.user_imports_rows (instance_id, import_id, resource_owner, row_number, username, user_id, succeeded, error) VALUES ($1, $2, $3, $4, $5, $6, $7, $8)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+								"ro-id",
+								uint32(2),
+								"user2",
+								"",
+								false,
+								gu.Ptr("Errors.User.AlreadyExisting"),
+							},
+						},
+						{
+							expectedStmt: "UPDATE projections.user_imports SET (change_date, sequence, processed_rows, failed_rows) = ($1, $2, processed_rows + $3, failed_rows + $4) WHERE (instance_id = $5) AND (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								2,
+								1,
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "reduceFinished",
+			args: args{
+				event: getEvent(testEvent(
+					userimport.FinishedEventType,
+					userimport.AggregateType,
+					[]byte(`{"error": "invalid header"}`),
+				), eventstore.GenericEventMapper[userimport.FinishedEvent]),
+			},
+			reduce: (&userImportProjection{}).reduceFinished,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("user_import"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "UPDATE projections.user_imports SET (change_date, sequence, state, error) = ($1, $2, $3, $4) WHERE (instance_id = $5) AND (id = $6)",
+							expectedArgs: []interface{}{
+								anyArg{},
+								uint64(15),
+								domain.UserImportStateFailed,
+								gu.Ptr("invalid header"),
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "org reduceOwnerRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					org.OrgRemovedEventType,
+					org.AggregateType,
+					nil,
+				), org.OrgRemovedEventMapper),
+			},
+			reduce: (&userImportProjection{}).reduceOwnerRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("org"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_imports WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.user_imports_rows WHERE (instance_id = $1) AND (resource_owner = $2)",
+							expectedArgs: []interface{}{
+								"instance-id",
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "instance reduceInstanceRemoved",
+			args: args{
+				event: getEvent(testEvent(
+					instance.InstanceRemovedEventType,
+					instance.AggregateType,
+					nil,
+				), instance.InstanceRemovedEventMapper),
+			},
+			reduce: (&userImportProjection{}).reduceInstanceRemoved,
+			want: wantReduce{
+				aggregateType: eventstore.AggregateType("instance"),
+				sequence:      15,
+				executer: &testExecuter{
+					executions: []execution{
+						{
+							expectedStmt: "DELETE FROM projections.user_imports WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+						{
+							expectedStmt: "DELETE FROM projections.user_imports_rows WHERE (instance_id = $1)",
+							expectedArgs: []interface{}{
+								"agg-id",
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			event := baseEvent(t)
+			got, err := tt.reduce(event)
+			if !errors.IsErrorInvalidArgument(err) {
+				t.Errorf("no wrong event mapping: %v, got: %v", err, got)
+			}
+			event = tt.args.event(t)
+			got, err = tt.reduce(event)
+			assertReduce(t, got, err, UserImportProjectionTable, tt.want)
+		})
+	}
+}
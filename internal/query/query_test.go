@@ -12,6 +12,7 @@ import (
 	"github.com/zitadel/zitadel/internal/eventstore/repository/mock"
 	action_repo "github.com/zitadel/zitadel/internal/repository/action"
 	"github.com/zitadel/zitadel/internal/repository/authrequest"
+	"github.com/zitadel/zitadel/internal/repository/backchannelauth"
 	"github.com/zitadel/zitadel/internal/repository/feature"
 	"github.com/zitadel/zitadel/internal/repository/idpintent"
 	iam_repo "github.com/zitadel/zitadel/internal/repository/instance"
@@ -21,6 +22,7 @@ import (
 	"github.com/zitadel/zitadel/internal/repository/org"
 	proj_repo "github.com/zitadel/zitadel/internal/repository/project"
 	quota_repo "github.com/zitadel/zitadel/internal/repository/quota"
+	"github.com/zitadel/zitadel/internal/repository/samlsession"
 	"github.com/zitadel/zitadel/internal/repository/session"
 	usr_repo "github.com/zitadel/zitadel/internal/repository/user"
 	"github.com/zitadel/zitadel/internal/repository/usergrant"
@@ -50,7 +52,9 @@ func expectEventstore(expects ...expect) func(*testing.T) *eventstore.Eventstore
 		session.RegisterEventMappers(es)
 		idpintent.RegisterEventMappers(es)
 		authrequest.RegisterEventMappers(es)
+		backchannelauth.RegisterEventMappers(es)
 		oidcsession.RegisterEventMappers(es)
+		samlsession.RegisterEventMappers(es)
 		quota_repo.RegisterEventMappers(es)
 		limits.RegisterEventMappers(es)
 		feature.RegisterEventMappers(es)
@@ -519,6 +519,51 @@ func (q *Queries) SearchUsers(ctx context.Context, queries *UserSearchQueries) (
 	return users, err
 }
 
+// GetUserByIDWithPermission returns the user if the caller is either the user itself
+// or has the user.read permission on the organization of the user.
+func (q *Queries) GetUserByIDWithPermission(ctx context.Context, shouldTriggerBulk bool, userID string) (user *User, err error) {
+	user, err = q.GetUserByID(ctx, shouldTriggerBulk, userID)
+	if err != nil {
+		return nil, err
+	}
+	if err = userCheckPermission(ctx, q.checkPermission, user.ResourceOwner, user.ID); err != nil {
+		return nil, err
+	}
+	return user, nil
+}
+
+// SearchUsersWithPermission searches users like [Queries.SearchUsers],
+// but removes all users from the result the caller is not allowed to read.
+// The permissions are checked after paging, so a page can contain less users than the limit.
+func (q *Queries) SearchUsersWithPermission(ctx context.Context, queries *UserSearchQueries) (users *Users, err error) {
+	users, err = q.SearchUsers(ctx, queries)
+	if err != nil {
+		return nil, err
+	}
+	users.RemoveNoPermission(ctx, q.checkPermission)
+	return users, nil
+}
+
+// RemoveNoPermission removes all users the caller is not allowed to read.
+// The removed users are subtracted from the count, so it doesn't disclose users the caller can't see.
+func (u *Users) RemoveNoPermission(ctx context.Context, permissionCheck domain.PermissionCheck) {
+	users := make([]*User, 0, len(u.Users))
+	for _, user := range u.Users {
+		if err := userCheckPermission(ctx, permissionCheck, user.ResourceOwner, user.ID); err == nil {
+			users = append(users, user)
+		}
+	}
+	u.Count -= uint64(len(u.Users) - len(users))
+	u.Users = users
+}
+
+func userCheckPermission(ctx context.Context, permissionCheck domain.PermissionCheck, resourceOwner, userID string) error {
+	if authz.GetCtxData(ctx).UserID == userID {
+		return nil
+	}
+	return permissionCheck(ctx, domain.PermissionUserRead, resourceOwner, userID)
+}
+
 func (q *Queries) IsUserUnique(ctx context.Context, username, email, resourceOwner string) (isUnique bool, err error) {
 	ctx, span := tracing.NewSpan(ctx)
 	defer func() { span.EndWithError(err) }()
@@ -0,0 +1,418 @@
+package query
+
+import (
+	"context"
+	"database/sql"
+	errs "errors"
+	"time"
+
+	sq "github.com/Masterminds/squirrel"
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/api/call"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/handler/v2"
+	"github.com/zitadel/zitadel/internal/query/projection"
+	"github.com/zitadel/zitadel/internal/telemetry/tracing"
+)
+
+var (
+	userImportsTable = table{
+		name:          projection.UserImportProjectionTable,
+		instanceIDCol: projection.UserImportColumnInstanceID,
+	}
+	UserImportColumnID = Column{
+		name:  projection.UserImportColumnID,
+		table: userImportsTable,
+	}
+	UserImportColumnInstanceID = Column{
+		name:  projection.UserImportColumnInstanceID,
+		table: userImportsTable,
+	}
+	UserImportColumnResourceOwner = Column{
+		name:  projection.UserImportColumnResourceOwner,
+		table: userImportsTable,
+	}
+	UserImportColumnCreationDate = Column{
+		name:  projection.UserImportColumnCreationDate,
+		table: userImportsTable,
+	}
+	UserImportColumnChangeDate = Column{
+		name:  projection.UserImportColumnChangeDate,
+		table: userImportsTable,
+	}
+	UserImportColumnSequence = Column{
+		name:  projection.UserImportColumnSequence,
+		table: userImportsTable,
+	}
+	UserImportColumnState = Column{
+		name:  projection.UserImportColumnState,
+		table: userImportsTable,
+	}
+	UserImportColumnFormat = Column{
+		name:  projection.UserImportColumnFormat,
+		table: userImportsTable,
+	}
+	UserImportColumnDryRun = Column{
+		name:  projection.UserImportColumnDryRun,
+		table: userImportsTable,
+	}
+	UserImportColumnProcessedRows = Column{
+		name:  projection.UserImportColumnProcessedRows,
+		table: userImportsTable,
+	}
+	UserImportColumnFailedRows = Column{
+		name:  projection.UserImportColumnFailedRows,
+		table: userImportsTable,
+	}
+	UserImportColumnError = Column{
+		name:  projection.UserImportColumnError,
+		table: userImportsTable,
+	}
+)
+
+var (
+	userImportRowsTable = table{
+		name:          projection.UserImportRowTable,
+		instanceIDCol: projection.UserImportRowColumnInstanceID,
+	}
+	UserImportRowColumnInstanceID = Column{
+		name:  projection.UserImportRowColumnInstanceID,
+		table: userImportRowsTable,
+	}
+	UserImportRowColumnImportID = Column{
+		name:  projection.UserImportRowColumnImportID,
+		table: userImportRowsTable,
+	}
+	UserImportRowColumnResourceOwner = Column{
+		name:  projection.UserImportRowColumnResourceOwner,
+		table: userImportRowsTable,
+	}
+	UserImportRowColumnRow = Column{
+		name:  projection.UserImportRowColumnRow,
+		table: userImportRowsTable,
+	}
+	UserImportRowColumnUsername = Column{
+		name:  projection.UserImportRowColumnUsername,
+		table: userImportRowsTable,
+	}
+	UserImportRowColumnUserID = Column{
+		name:  projection.UserImportRowColumnUserID,
+		table: userImportRowsTable,
+	}
+	UserImportRowColumnSucceeded = Column{
+		name:  projection.UserImportRowColumnSucceeded,
+		table: userImportRowsTable,
+	}
+	UserImportRowColumnError = Column{
+		name:  projection.UserImportRowColumnError,
+		table: userImportRowsTable,
+	}
+)
+
+type UserImports struct {
+	SearchResponse
+	Imports []*UserImport
+}
+
+// UserImport is the state of a bulk import of human users, the data itself is not projected
+type UserImport struct {
+	ID            string
+	InstanceID    string
+	ResourceOwner string
+	CreationDate  time.Time
+	ChangeDate    time.Time
+	Sequence      uint64
+
+	State         domain.UserImportState
+	Format        domain.UserImportFormat
+	DryRun        bool
+	ProcessedRows uint64
+	FailedRows    uint64
+	Error         string
+}
+
+type UserImportSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *UserImportSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+type UserImportRows struct {
+	SearchResponse
+	Rows []*domain.UserImportRowResult
+}
+
+type UserImportRowSearchQueries struct {
+	SearchRequest
+	Queries []SearchQuery
+}
+
+func (q *UserImportRowSearchQueries) toQuery(query sq.SelectBuilder) sq.SelectBuilder {
+	query = q.SearchRequest.toQuery(query)
+	for _, q := range q.Queries {
+		query = q.toQuery(query)
+	}
+	return query
+}
+
+func (q *Queries) UserImportByID(ctx context.Context, shouldTriggerBulk bool, id string, queries ...SearchQuery) (userImport *UserImport, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	if shouldTriggerBulk {
+		_, traceSpan := tracing.NewNamedSpan(ctx, "TriggerUserImportProjection")
+		ctx, err = projection.UserImportProjection.Trigger(ctx, handler.WithAwaitRunning())
+		logging.OnError(err).Debug("trigger failed")
+		traceSpan.EndWithError(err)
+	}
+
+	stmt, scan := prepareUserImportQuery(ctx, q.client)
+	for _, q := range queries {
+		stmt = q.toQuery(stmt)
+	}
+	query, args, err := stmt.Where(sq.Eq{
+		UserImportColumnID.identifier():         id,
+		UserImportColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Ohm1a", "Errors.Query.SQLStatement")
+	}
+
+	err = q.client.QueryRowContext(ctx, func(row *sql.Row) error {
+		userImport, err = scan(row)
+		return err
+	}, query, args...)
+	return userImport, err
+}
+
+func (q *Queries) SearchUserImports(ctx context.Context, queries *UserImportSearchQueries) (userImports *UserImports, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserImportsQuery(ctx, q.client)
+	if queries.SortingColumn.isZero() {
+		query = query.OrderBy(UserImportColumnCreationDate.identifier() + " DESC")
+	}
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		UserImportColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-eeN4u", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		userImports, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-ohX4e", "Errors.Internal")
+	}
+
+	userImports.State, err = q.latestState(ctx, userImportsTable)
+	return userImports, err
+}
+
+func (q *Queries) SearchUserImportRows(ctx context.Context, importID string, queries *UserImportRowSearchQueries) (rows *UserImportRows, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserImportRowsQuery(ctx, q.client)
+	if queries.SortingColumn.isZero() {
+		query = query.OrderBy(UserImportRowColumnRow.identifier())
+	}
+	stmt, args, err := queries.toQuery(query).Where(sq.Eq{
+		UserImportRowColumnImportID.identifier():   importID,
+		UserImportRowColumnInstanceID.identifier(): authz.GetInstance(ctx).InstanceID(),
+	}).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "QUERY-Ahc7e", "Errors.Query.InvalidRequest")
+	}
+
+	err = q.client.QueryContext(ctx, func(r *sql.Rows) error {
+		rows, err = scan(r)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Quu0e", "Errors.Internal")
+	}
+
+	rows.State, err = q.latestState(ctx, userImportsTable)
+	return rows, err
+}
+
+// QueuedUserImports returns the imports of all instances, which are not yet started
+func (q *Queries) QueuedUserImports(ctx context.Context) (userImports []*UserImport, err error) {
+	ctx, span := tracing.NewSpan(ctx)
+	defer func() { span.EndWithError(err) }()
+
+	query, scan := prepareUserImportsQuery(ctx, q.client)
+	stmt, args, err := query.Where(sq.Eq{
+		UserImportColumnState.identifier(): domain.UserImportStateQueued,
+	}).OrderBy(UserImportColumnCreationDate.identifier()).ToSql()
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Eeph3", "Errors.Query.SQLStatement")
+	}
+
+	var imports *UserImports
+	err = q.client.QueryContext(ctx, func(rows *sql.Rows) error {
+		imports, err = scan(rows)
+		return err
+	}, stmt, args...)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "QUERY-Gei5o", "Errors.Internal")
+	}
+	return imports.Imports, nil
+}
+
+func NewUserImportResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(UserImportColumnResourceOwner, id, TextEquals)
+}
+
+func NewUserImportRowResourceOwnerSearchQuery(id string) (SearchQuery, error) {
+	return NewTextQuery(UserImportRowColumnResourceOwner, id, TextEquals)
+}
+
+func NewUserImportRowSucceededSearchQuery(succeeded bool) (SearchQuery, error) {
+	return NewBoolQuery(UserImportRowColumnSucceeded, succeeded)
+}
+
+func userImportColumns() []string {
+	return []string{
+		UserImportColumnID.identifier(),
+		UserImportColumnInstanceID.identifier(),
+		UserImportColumnResourceOwner.identifier(),
+		UserImportColumnCreationDate.identifier(),
+		UserImportColumnChangeDate.identifier(),
+		UserImportColumnSequence.identifier(),
+		UserImportColumnState.identifier(),
+		UserImportColumnFormat.identifier(),
+		UserImportColumnDryRun.identifier(),
+		UserImportColumnProcessedRows.identifier(),
+		UserImportColumnFailedRows.identifier(),
+		UserImportColumnError.identifier(),
+	}
+}
+
+type userImportScanner interface {
+	Scan(dest ...any) error
+}
+
+func scanUserImport(row userImportScanner, dest ...any) (*UserImport, error) {
+	userImport := new(UserImport)
+	var importErr sql.NullString
+	err := row.Scan(append([]any{
+		&userImport.ID,
+		&userImport.InstanceID,
+		&userImport.ResourceOwner,
+		&userImport.CreationDate,
+		&userImport.ChangeDate,
+		&userImport.Sequence,
+		&userImport.State,
+		&userImport.Format,
+		&userImport.DryRun,
+		&userImport.ProcessedRows,
+		&userImport.FailedRows,
+		&importErr,
+	}, dest...)...)
+	if err != nil {
+		return nil, err
+	}
+	userImport.Error = importErr.String
+	return userImport, nil
+}
+
+func prepareUserImportQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Row) (*UserImport, error)) {
+	return sq.Select(userImportColumns()...).
+			From(userImportsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(row *sql.Row) (*UserImport, error) {
+			userImport, err := scanUserImport(row)
+			if err != nil {
+				if errs.Is(err, sql.ErrNoRows) {
+					return nil, errors.ThrowNotFound(err, "QUERY-Fai3e", "Errors.UserImport.NotFound")
+				}
+				return nil, errors.ThrowInternal(err, "QUERY-aiG0u", "Errors.Internal")
+			}
+			return userImport, nil
+		}
+}
+
+func prepareUserImportsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*UserImports, error)) {
+	return sq.Select(append(userImportColumns(), countColumn.identifier())...).
+			From(userImportsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserImports, error) {
+			imports := make([]*UserImport, 0)
+			var count uint64
+			for rows.Next() {
+				userImport, err := scanUserImport(rows, &count)
+				if err != nil {
+					return nil, err
+				}
+				imports = append(imports, userImport)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-ieP8o", "Errors.Query.CloseRows")
+			}
+
+			return &UserImports{
+				Imports: imports,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
+
+func prepareUserImportRowsQuery(ctx context.Context, db prepareDatabase) (sq.SelectBuilder, func(*sql.Rows) (*UserImportRows, error)) {
+	return sq.Select(
+			UserImportRowColumnRow.identifier(),
+			UserImportRowColumnUsername.identifier(),
+			UserImportRowColumnUserID.identifier(),
+			UserImportRowColumnError.identifier(),
+			countColumn.identifier(),
+		).From(userImportRowsTable.identifier() + db.Timetravel(call.Took(ctx))).
+			PlaceholderFormat(sq.Dollar),
+		func(rows *sql.Rows) (*UserImportRows, error) {
+			results := make([]*domain.UserImportRowResult, 0)
+			var count uint64
+			for rows.Next() {
+				result := new(domain.UserImportRowResult)
+				var rowErr sql.NullString
+				err := rows.Scan(
+					&result.Row,
+					&result.Username,
+					&result.UserID,
+					&rowErr,
+					&count,
+				)
+				if err != nil {
+					return nil, err
+				}
+				result.Error = rowErr.String
+				results = append(results, result)
+			}
+
+			if err := rows.Close(); err != nil {
+				return nil, errors.ThrowInternal(err, "QUERY-Lah4e", "Errors.Query.CloseRows")
+			}
+
+			return &UserImportRows{
+				Rows: results,
+				SearchResponse: SearchResponse{
+					Count: count,
+				},
+			}, nil
+		}
+}
@@ -0,0 +1,311 @@
+package query
+
+import (
+	"database/sql"
+	"database/sql/driver"
+	"errors"
+	"fmt"
+	"regexp"
+	"testing"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	errs "github.com/zitadel/zitadel/internal/errors"
+)
+
+var (
+	prepareUserImportStmt = `SELECT projections.user_imports.id,` +
+		` projections.user_imports.instance_id,` +
+		` projections.user_imports.resource_owner,` +
+		` projections.user_imports.creation_date,` +
+		` projections.user_imports.change_date,` +
+		` projections.user_imports.sequence,` +
+		` projections.user_imports.state,` +
+		` projections.user_imports.format,` +
+		` projections.user_imports.dry_run,` +
+		` projections.user_imports.processed_rows,` +
+		` projections.user_imports.failed_rows,` +
+		` projections.user_imports.error` +
+		` FROM projections.user_imports`
+	prepareUserImportCols = []string{
+		"id",
+		"instance_id",
+		"resource_owner",
+		"creation_date",
+		"change_date",
+		"sequence",
+		"state",
+		"format",
+		"dry_run",
+		"processed_rows",
+		"failed_rows",
+		"error",
+	}
+
+	prepareUserImportsStmt = `SELECT projections.user_imports.id,` +
+		` projections.user_imports.instance_id,` +
+		` projections.user_imports.resource_owner,` +
+		` projections.user_imports.creation_date,` +
+		` projections.user_imports.change_date,` +
+		` projections.user_imports.sequence,` +
+		` projections.user_imports.state,` +
+		` projections.user_imports.format,` +
+		` projections.user_imports.dry_run,` +
+		` projections.user_imports.processed_rows,` +
+		` projections.user_imports.failed_rows,` +
+		` projections.user_imports.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.user_imports`
+	prepareUserImportsCols = append(prepareUserImportCols, "count")
+
+	prepareUserImportRowsStmt = `SELECT projections.user_imports_rows.row_number,` +
+		` projections.user_imports_rows.username,` +
+		` projections.user_imports_rows.user_id,` +
+		` projections.user_imports_rows.error,` +
+		` COUNT(*) OVER ()` +
+		` FROM projections.user_imports_rows`
+	prepareUserImportRowsCols = []string{
+		"row_number",
+		"username",
+		"user_id",
+		"error",
+		"count",
+	}
+)
+
+func Test_UserImportPrepares(t *testing.T) {
+	type want struct {
+		sqlExpectations sqlExpectation
+		err             checkErr
+	}
+	tests := []struct {
+		name    string
+		prepare interface{}
+		want    want
+		object  interface{}
+	}{
+		{
+			name:    "prepareUserImportQuery no result",
+			prepare: prepareUserImportQuery,
+			want: want{
+				sqlExpectations: mockQueriesScanErr(
+					regexp.QuoteMeta(prepareUserImportStmt),
+					nil,
+					nil,
+				),
+				err: func(err error) (error, bool) {
+					if !errs.IsNotFound(err) {
+						return fmt.Errorf("err should be zitadel.NotFoundError got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserImport)(nil),
+		},
+		{
+			name:    "prepareUserImportQuery found",
+			prepare: prepareUserImportQuery,
+			want: want{
+				sqlExpectations: mockQuery(
+					regexp.QuoteMeta(prepareUserImportStmt),
+					prepareUserImportCols,
+					[]driver.Value{
+						"import-id",
+						"instance-id",
+						"ro",
+						testNow,
+						testNow,
+						uint64(20211109),
+						domain.UserImportStateDone,
+						domain.UserImportFormatCSV,
+						true,
+						uint64(10),
+						uint64(2),
+						nil,
+					},
+				),
+			},
+			object: &UserImport{
+				ID:            "import-id",
+				InstanceID:    "instance-id",
+				ResourceOwner: "ro",
+				CreationDate:  testNow,
+				ChangeDate:    testNow,
+				Sequence:      20211109,
+				State:         domain.UserImportStateDone,
+				Format:        domain.UserImportFormatCSV,
+				DryRun:        true,
+				ProcessedRows: 10,
+				FailedRows:    2,
+			},
+		},
+		{
+			name:    "prepareUserImportQuery sql err",
+			prepare: prepareUserImportQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareUserImportStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserImport)(nil),
+		},
+		{
+			name:    "prepareUserImportsQuery no result",
+			prepare: prepareUserImportsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareUserImportsStmt),
+					nil,
+					nil,
+				),
+			},
+			object: &UserImports{Imports: []*UserImport{}},
+		},
+		{
+			name:    "prepareUserImportsQuery multiple result",
+			prepare: prepareUserImportsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareUserImportsStmt),
+					prepareUserImportsCols,
+					[][]driver.Value{
+						{
+							"import-id",
+							"instance-id",
+							"ro",
+							testNow,
+							testNow,
+							uint64(20211109),
+							domain.UserImportStateQueued,
+							domain.UserImportFormatNDJSON,
+							false,
+							uint64(0),
+							uint64(0),
+							nil,
+						},
+						{
+							"import-id-2",
+							"instance-id",
+							"ro",
+							testNow,
+							testNow,
+							uint64(20211110),
+							domain.UserImportStateFailed,
+							domain.UserImportFormatCSV,
+							false,
+							uint64(0),
+							uint64(0),
+							"invalid header",
+						},
+					},
+				),
+			},
+			object: &UserImports{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Imports: []*UserImport{
+					{
+						ID:            "import-id",
+						InstanceID:    "instance-id",
+						ResourceOwner: "ro",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211109,
+						State:         domain.UserImportStateQueued,
+						Format:        domain.UserImportFormatNDJSON,
+					},
+					{
+						ID:            "import-id-2",
+						InstanceID:    "instance-id",
+						ResourceOwner: "ro",
+						CreationDate:  testNow,
+						ChangeDate:    testNow,
+						Sequence:      20211110,
+						State:         domain.UserImportStateFailed,
+						Format:        domain.UserImportFormatCSV,
+						Error:         "invalid header",
+					},
+				},
+			},
+		},
+		{
+			name:    "prepareUserImportsQuery sql err",
+			prepare: prepareUserImportsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareUserImportsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserImports)(nil),
+		},
+		{
+			name:    "prepareUserImportRowsQuery multiple result",
+			prepare: prepareUserImportRowsQuery,
+			want: want{
+				sqlExpectations: mockQueries(
+					regexp.QuoteMeta(prepareUserImportRowsStmt),
+					prepareUserImportRowsCols,
+					[][]driver.Value{
+						{
+							uint32(1),
+							"user1",
+							"user-id",
+							nil,
+						},
+						{
+							uint32(2),
+							"user2",
+							"",
+							"Errors.User.AlreadyExisting",
+						},
+					},
+				),
+			},
+			object: &UserImportRows{
+				SearchResponse: SearchResponse{
+					Count: 2,
+				},
+				Rows: []*domain.UserImportRowResult{
+					{Row: 1, Username: "user1", UserID: "user-id"},
+					{Row: 2, Username: "user2", Error: "Errors.User.AlreadyExisting"},
+				},
+			},
+		},
+		{
+			name:    "prepareUserImportRowsQuery sql err",
+			prepare: prepareUserImportRowsQuery,
+			want: want{
+				sqlExpectations: mockQueryErr(
+					regexp.QuoteMeta(prepareUserImportRowsStmt),
+					sql.ErrConnDone,
+				),
+				err: func(err error) (error, bool) {
+					if !errors.Is(err, sql.ErrConnDone) {
+						return fmt.Errorf("err should be sql.ErrConnDone got: %w", err), false
+					}
+					return nil, true
+				},
+			},
+			object: (*UserImportRows)(nil),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assertPrepare(t, tt.prepare, tt.object, tt.want.sqlExpectations, tt.want.err, defaultPrepareArgs...)
+		})
+	}
+}
@@ -1,6 +1,7 @@
 package query
 
 import (
+	"context"
 	"database/sql"
 	"database/sql/driver"
 	"errors"
@@ -8,8 +9,10 @@ import (
 	"regexp"
 	"testing"
 
+	"github.com/stretchr/testify/assert"
 	"golang.org/x/text/language"
 
+	"github.com/zitadel/zitadel/internal/api/authz"
 	"github.com/zitadel/zitadel/internal/database"
 	"github.com/zitadel/zitadel/internal/domain"
 	errs "github.com/zitadel/zitadel/internal/errors"
@@ -1223,3 +1226,73 @@ func Test_UserPrepares(t *testing.T) {
 		})
 	}
 }
+
+func TestUsers_RemoveNoPermission(t *testing.T) {
+	permissionCheck := func(ctx context.Context, permission, orgID, resourceID string) error {
+		if orgID == "org1" {
+			return nil
+		}
+		return errs.ThrowPermissionDenied(nil, "AUTHZ-HKJD33", "Errors.PermissionDenied")
+	}
+	tests := []struct {
+		name  string
+		ctx   context.Context
+		users *Users
+		want  *Users
+	}{
+		{
+			name: "all allowed",
+			ctx:  context.Background(),
+			users: &Users{
+				SearchResponse: SearchResponse{Count: 2},
+				Users:          []*User{{ID: "user1", ResourceOwner: "org1"}, {ID: "user2", ResourceOwner: "org1"}},
+			},
+			want: &Users{
+				SearchResponse: SearchResponse{Count: 2},
+				Users:          []*User{{ID: "user1", ResourceOwner: "org1"}, {ID: "user2", ResourceOwner: "org1"}},
+			},
+		},
+		{
+			name: "other org removed",
+			ctx:  context.Background(),
+			users: &Users{
+				SearchResponse: SearchResponse{Count: 3},
+				Users:          []*User{{ID: "user1", ResourceOwner: "org2"}, {ID: "user2", ResourceOwner: "org1"}, {ID: "user3", ResourceOwner: "org2"}},
+			},
+			want: &Users{
+				SearchResponse: SearchResponse{Count: 1},
+				Users:          []*User{{ID: "user2", ResourceOwner: "org1"}},
+			},
+		},
+		{
+			name: "other org removed, count of all pages reduced",
+			ctx:  context.Background(),
+			users: &Users{
+				SearchResponse: SearchResponse{Count: 10},
+				Users:          []*User{{ID: "user1", ResourceOwner: "org2"}, {ID: "user2", ResourceOwner: "org1"}, {ID: "user3", ResourceOwner: "org2"}},
+			},
+			want: &Users{
+				SearchResponse: SearchResponse{Count: 8},
+				Users:          []*User{{ID: "user2", ResourceOwner: "org1"}},
+			},
+		},
+		{
+			name: "own user kept",
+			ctx:  authz.NewMockContext("instance1", "org2", "user3"),
+			users: &Users{
+				SearchResponse: SearchResponse{Count: 2},
+				Users:          []*User{{ID: "user1", ResourceOwner: "org2"}, {ID: "user3", ResourceOwner: "org2"}},
+			},
+			want: &Users{
+				SearchResponse: SearchResponse{Count: 1},
+				Users:          []*User{{ID: "user3", ResourceOwner: "org2"}},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.users.RemoveNoPermission(tt.ctx, permissionCheck)
+			assert.Equal(t, tt.want, tt.users)
+		})
+	}
+}
@@ -4,6 +4,8 @@ import (
 	"context"
 	"time"
 
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/domain"
 	"github.com/zitadel/zitadel/internal/errors"
 	"github.com/zitadel/zitadel/internal/eventstore"
 )
@@ -16,6 +18,8 @@ const (
 	DeactivatedEventType = eventTypePrefix + "deactivated"
 	ReactivatedEventType = eventTypePrefix + "reactivated"
 	RemovedEventType     = eventTypePrefix + "removed"
+
+	SigningKeyChangedEventType = eventTypePrefix + "signing.key.changed"
 )
 
 func NewAddActionNameUniqueConstraint(actionName, resourceOwner string) *eventstore.UniqueConstraint {
@@ -38,6 +42,11 @@ type AddedEvent struct {
 	Script        string        `json:"script,omitempty"`
 	Timeout       time.Duration `json:"timeout,omitempty"`
 	AllowedToFail bool          `json:"allowedToFail"`
+
+	ActionType domain.ActionType   `json:"type,omitempty"`
+	TargetURL  string              `json:"targetUrl,omitempty"`
+	MaxRetries uint32              `json:"maxRetries,omitempty"`
+	SigningKey *crypto.CryptoValue `json:"signingKey,omitempty"`
 }
 
 func (e *AddedEvent) Payload() interface{} {
@@ -69,6 +78,33 @@ func NewAddedEvent(
 	}
 }
 
+// NewTargetAddedEvent adds an action, which calls the targetURL instead of running a script
+func NewTargetAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	name,
+	targetURL string,
+	timeout time.Duration,
+	allowedToFail bool,
+	maxRetries uint32,
+	signingKey *crypto.CryptoValue,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedEventType,
+		),
+		Name:          name,
+		Timeout:       timeout,
+		AllowedToFail: allowedToFail,
+		ActionType:    domain.ActionTypeTarget,
+		TargetURL:     targetURL,
+		MaxRetries:    maxRetries,
+		SigningKey:    signingKey,
+	}
+}
+
 func AddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &AddedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -89,6 +125,8 @@ type ChangedEvent struct {
 	Script        *string        `json:"script,omitempty"`
 	Timeout       *time.Duration `json:"timeout,omitempty"`
 	AllowedToFail *bool          `json:"allowedToFail,omitempty"`
+	TargetURL     *string        `json:"targetUrl,omitempty"`
+	MaxRetries    *uint32        `json:"maxRetries,omitempty"`
 	oldName       string
 }
 
@@ -154,6 +192,18 @@ func ChangeAllowedToFail(allowedToFail bool) func(event *ChangedEvent) {
 	}
 }
 
+func ChangeTargetURL(targetURL string) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.TargetURL = &targetURL
+	}
+}
+
+func ChangeMaxRetries(maxRetries uint32) func(event *ChangedEvent) {
+	return func(e *ChangedEvent) {
+		e.MaxRetries = &maxRetries
+	}
+}
+
 func ChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &ChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
@@ -257,3 +307,45 @@ func RemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
 	}, nil
 }
+
+type SigningKeyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	SigningKey *crypto.CryptoValue `json:"signingKey"`
+}
+
+func (e *SigningKeyChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *SigningKeyChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewSigningKeyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	signingKey *crypto.CryptoValue,
+) *SigningKeyChangedEvent {
+	return &SigningKeyChangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			SigningKeyChangedEventType,
+		),
+		SigningKey: signingKey,
+	}
+}
+
+func SigningKeyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &SigningKeyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "ACTION-Aeb5e", "unable to unmarshal action signing key changed")
+	}
+
+	return e, nil
+}
@@ -7,5 +7,6 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, ChangedEventType, ChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, DeactivatedEventType, DeactivatedEventMapper).
 		RegisterFilterEventMapper(AggregateType, ReactivatedEventType, ReactivatedEventMapper).
-		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper)
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, RemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, SigningKeyChangedEventType, SigningKeyChangedEventMapper)
 }
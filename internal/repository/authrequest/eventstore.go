@@ -8,5 +8,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, CodeAddedType, CodeAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, CodeExchangedType, CodeExchangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, FailedType, FailedEventMapper).
-		RegisterFilterEventMapper(AggregateType, SucceededType, SucceededEventMapper)
+		RegisterFilterEventMapper(AggregateType, SucceededType, SucceededEventMapper).
+		RegisterFilterEventMapper(AggregateType, PushedType, PushedEventMapper).
+		RegisterFilterEventMapper(AggregateType, PushedUsedType, PushedUsedEventMapper)
 }
@@ -0,0 +1,96 @@
+package authrequest
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	pushedEventPrefix = authRequestEventPrefix + "pushed"
+	PushedType        = pushedEventPrefix
+	PushedUsedType    = pushedEventPrefix + ".used"
+)
+
+// PushedEvent stores the parameters of an authorization request,
+// which were pushed to the pushed authorization request endpoint (RFC 9126)
+type PushedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID   string          `json:"client_id"`
+	Request    json.RawMessage `json:"request"`
+	Expiration time.Time       `json:"expiration"`
+}
+
+func (e *PushedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *PushedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewPushedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID string,
+	request json.RawMessage,
+	expiration time.Time,
+) *PushedEvent {
+	return &PushedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushedType,
+		),
+		ClientID:   clientID,
+		Request:    request,
+		Expiration: expiration,
+	}
+}
+
+func PushedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	added := &PushedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(added)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "AUTHR-ahY4o", "unable to unmarshal pushed auth request")
+	}
+
+	return added, nil
+}
+
+type PushedUsedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *PushedUsedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *PushedUsedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewPushedUsedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+) *PushedUsedEvent {
+	return &PushedUsedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			PushedUsedType,
+		),
+	}
+}
+
+func PushedUsedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &PushedUsedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
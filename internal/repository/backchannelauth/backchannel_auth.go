@@ -0,0 +1,188 @@
+package backchannelauth
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/api/http"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix       eventstore.EventType = "backchannel.authentication."
+	AddedEventType                             = eventTypePrefix + "added"
+	UserNotifiedEventType                      = eventTypePrefix + "user.notified"
+	ApprovedEventType                          = eventTypePrefix + "approved"
+	CanceledEventType                          = eventTypePrefix + "canceled"
+	RemovedEventType                           = eventTypePrefix + "removed"
+)
+
+type AddedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ClientID                string
+	AuthReqID               string
+	UserID                  string
+	Scopes                  []string
+	BindingMessage          string
+	ClientNotificationToken string
+	Expires                 time.Time
+	Notification            domain.BackChannelAuthNotification
+	WebhookURL              string
+	State                   domain.BackChannelAuthState
+	TriggeredAtOrigin       string `json:"triggerOrigin,omitempty"`
+}
+
+func (e *AddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *AddedEvent) Payload() any {
+	return e
+}
+
+func (e *AddedEvent) TriggerOrigin() string {
+	return e.TriggeredAtOrigin
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddUniqueConstraint(e.ClientID, e.AuthReqID)}
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID string,
+	authReqID string,
+	userID string,
+	scopes []string,
+	bindingMessage string,
+	clientNotificationToken string,
+	expires time.Time,
+	notification domain.BackChannelAuthNotification,
+	webhookURL string,
+) *AddedEvent {
+	return &AddedEvent{
+		eventstore.NewBaseEventForPush(
+			ctx, aggregate, AddedEventType,
+		),
+		clientID, authReqID, userID, scopes, bindingMessage, clientNotificationToken,
+		expires, notification, webhookURL, domain.BackChannelAuthStateInitiated,
+		http.ComposedOrigin(ctx),
+	}
+}
+
+// UserNotifiedEvent is pushed after the user was asked to approve the request
+// on the configured notification channel.
+type UserNotifiedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func (e *UserNotifiedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *UserNotifiedEvent) Payload() any {
+	return e
+}
+
+func (e *UserNotifiedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewUserNotifiedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *UserNotifiedEvent {
+	return &UserNotifiedEvent{eventstore.NewBaseEventForPush(ctx, aggregate, UserNotifiedEventType)}
+}
+
+type ApprovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	UserID      string
+	AuthTime    time.Time
+	AuthMethods []domain.UserAuthMethodType
+}
+
+func (e *ApprovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *ApprovedEvent) Payload() any {
+	return e
+}
+
+func (e *ApprovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewApprovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID string,
+	authTime time.Time,
+	authMethods []domain.UserAuthMethodType,
+) *ApprovedEvent {
+	return &ApprovedEvent{
+		eventstore.NewBaseEventForPush(
+			ctx, aggregate, ApprovedEventType,
+		),
+		userID, authTime, authMethods,
+	}
+}
+
+type CanceledEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Reason domain.BackChannelAuthCanceled
+}
+
+func (e *CanceledEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *CanceledEvent) Payload() any {
+	return e
+}
+
+func (e *CanceledEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewCanceledEvent(ctx context.Context, aggregate *eventstore.Aggregate, reason domain.BackChannelAuthCanceled) *CanceledEvent {
+	return &CanceledEvent{eventstore.NewBaseEventForPush(ctx, aggregate, CanceledEventType), reason}
+}
+
+type RemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	ClientID  string
+	AuthReqID string
+}
+
+func (e *RemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *RemovedEvent) Payload() any {
+	return e
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{
+		NewRemoveUniqueConstraint(e.ClientID, e.AuthReqID),
+		NewAddConsumedUniqueConstraint(e.Aggregate().ID),
+	}
+}
+
+func NewRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID, authReqID string,
+) *RemovedEvent {
+	return &RemovedEvent{
+		eventstore.NewBaseEventForPush(
+			ctx, aggregate, RemovedEventType,
+		),
+		clientID, authReqID,
+	}
+}
@@ -0,0 +1,44 @@
+package backchannelauth
+
+import (
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueAuthReqID    = "backchannel_auth_req_id"
+	DuplicateAuthReqID = "Errors.BackChannelAuth.AlreadyExists"
+
+	// UniqueConsumed is added when the result of the request is handed out to the client.
+	// A second push for the same request fails on it, so concurrent token requests can't both succeed.
+	UniqueConsumed    = "backchannel_auth_consumed"
+	DuplicateConsumed = "Errors.BackChannelAuth.AlreadyHandled"
+)
+
+func authReqIDUniqueField(clientID, authReqID string) string {
+	return strings.Join([]string{clientID, authReqID}, ":")
+}
+
+func NewAddUniqueConstraint(clientID, authReqID string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueAuthReqID,
+		authReqIDUniqueField(clientID, authReqID),
+		DuplicateAuthReqID,
+	)
+}
+
+func NewRemoveUniqueConstraint(clientID, authReqID string) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueAuthReqID,
+		authReqIDUniqueField(clientID, authReqID),
+	)
+}
+
+func NewAddConsumedUniqueConstraint(id string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueConsumed,
+		id,
+		DuplicateConsumed,
+	)
+}
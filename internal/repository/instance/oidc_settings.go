@@ -21,6 +21,7 @@ type OIDCSettingsAddedEvent struct {
 	IdTokenLifetime            time.Duration `json:"idTokenLifetime,omitempty"`
 	RefreshTokenIdleExpiration time.Duration `json:"refreshTokenIdleExpiration,omitempty"`
 	RefreshTokenExpiration     time.Duration `json:"refreshTokenExpiration,omitempty"`
+	SigningAlgorithm           string        `json:"signingAlgorithm,omitempty"`
 }
 
 func NewOIDCSettingsAddedEvent(
@@ -30,6 +31,7 @@ func NewOIDCSettingsAddedEvent(
 	idTokenLifetime,
 	refreshTokenIdleExpiration,
 	refreshTokenExpiration time.Duration,
+	signingAlgorithm string,
 ) *OIDCSettingsAddedEvent {
 	return &OIDCSettingsAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -41,6 +43,7 @@ func NewOIDCSettingsAddedEvent(
 		IdTokenLifetime:            idTokenLifetime,
 		RefreshTokenIdleExpiration: refreshTokenIdleExpiration,
 		RefreshTokenExpiration:     refreshTokenExpiration,
+		SigningAlgorithm:           signingAlgorithm,
 	}
 }
 
@@ -71,6 +74,7 @@ type OIDCSettingsChangedEvent struct {
 	IdTokenLifetime            *time.Duration `json:"idTokenLifetime,omitempty"`
 	RefreshTokenIdleExpiration *time.Duration `json:"refreshTokenIdleExpiration,omitempty"`
 	RefreshTokenExpiration     *time.Duration `json:"refreshTokenExpiration,omitempty"`
+	SigningAlgorithm           *string        `json:"signingAlgorithm,omitempty"`
 }
 
 func (e *OIDCSettingsChangedEvent) Payload() interface{} {
@@ -128,6 +132,12 @@ func ChangeOIDCSettingsRefreshTokenExpiration(refreshTokenExpiration time.Durati
 	}
 }
 
+func ChangeOIDCSettingsSigningAlgorithm(signingAlgorithm string) func(event *OIDCSettingsChangedEvent) {
+	return func(e *OIDCSettingsChangedEvent) {
+		e.SigningAlgorithm = &signingAlgorithm
+	}
+}
+
 func OIDCSettingsChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &OIDCSettingsChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
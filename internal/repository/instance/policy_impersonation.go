@@ -0,0 +1,72 @@
+package instance
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+	"github.com/zitadel/zitadel/internal/repository/policy"
+)
+
+const (
+	ImpersonationPolicyAddedEventType   = instanceEventTypePrefix + policy.ImpersonationPolicyAddedEventType
+	ImpersonationPolicyChangedEventType = instanceEventTypePrefix + policy.ImpersonationPolicyChangedEventType
+)
+
+type ImpersonationPolicyAddedEvent struct {
+	policy.ImpersonationPolicyAddedEvent
+}
+
+func NewImpersonationPolicyAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	allowImpersonation bool,
+) *ImpersonationPolicyAddedEvent {
+	return &ImpersonationPolicyAddedEvent{
+		ImpersonationPolicyAddedEvent: *policy.NewImpersonationPolicyAddedEvent(
+			eventstore.NewBaseEventForPush(
+				ctx,
+				aggregate,
+				ImpersonationPolicyAddedEventType),
+			allowImpersonation),
+	}
+}
+
+func ImpersonationPolicyAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := policy.ImpersonationPolicyAddedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImpersonationPolicyAddedEvent{ImpersonationPolicyAddedEvent: *e.(*policy.ImpersonationPolicyAddedEvent)}, nil
+}
+
+type ImpersonationPolicyChangedEvent struct {
+	policy.ImpersonationPolicyChangedEvent
+}
+
+func NewImpersonationPolicyChangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	changes []policy.ImpersonationPolicyChanges,
+) (*ImpersonationPolicyChangedEvent, error) {
+	changedEvent, err := policy.NewImpersonationPolicyChangedEvent(
+		eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ImpersonationPolicyChangedEventType),
+		changes,
+	)
+	if err != nil {
+		return nil, err
+	}
+	return &ImpersonationPolicyChangedEvent{ImpersonationPolicyChangedEvent: *changedEvent}, nil
+}
+
+func ImpersonationPolicyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e, err := policy.ImpersonationPolicyChangedEventMapper(event)
+	if err != nil {
+		return nil, err
+	}
+
+	return &ImpersonationPolicyChangedEvent{ImpersonationPolicyChangedEvent: *e.(*policy.ImpersonationPolicyChangedEvent)}, nil
+}
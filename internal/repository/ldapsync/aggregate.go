@@ -0,0 +1,20 @@
+package ldapsync
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+const (
+	AggregateType    = "ldap_sync"
+	AggregateVersion = "v1"
+)
+
+// NewAggregate returns the aggregate of the sync configuration of an LDAP identity provider,
+// the id is the id of the identity provider and the resource owner is the owner of the identity provider.
+func NewAggregate(idpID, resourceOwner, instanceID string) *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:            idpID,
+		Type:          AggregateType,
+		ResourceOwner: resourceOwner,
+		InstanceID:    instanceID,
+		Version:       AggregateVersion,
+	}
+}
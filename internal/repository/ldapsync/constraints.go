@@ -0,0 +1,34 @@
+package ldapsync
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueRun    = "ldap_sync_run"
+	DuplicateRun = "Errors.LDAPSync.AlreadyRunning"
+)
+
+func runUniqueField(idpID string, slot int64) string {
+	return strings.Join([]string{idpID, strconv.FormatInt(slot, 10)}, ":")
+}
+
+// NewAddRunUniqueConstraint locks the run of the slot,
+// so the synchronisation of an identity provider is only run once per slot.
+func NewAddRunUniqueConstraint(idpID string, slot int64) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueRun,
+		runUniqueField(idpID, slot),
+		DuplicateRun,
+	)
+}
+
+func NewRemoveRunUniqueConstraint(idpID string, slot int64) *eventstore.UniqueConstraint {
+	return eventstore.NewRemoveUniqueConstraint(
+		UniqueRun,
+		runUniqueField(idpID, slot),
+	)
+}
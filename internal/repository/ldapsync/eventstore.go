@@ -0,0 +1,10 @@
+package ldapsync
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, SetEventType, eventstore.GenericEventMapper[SetEvent]).
+		RegisterFilterEventMapper(AggregateType, RemovedEventType, eventstore.GenericEventMapper[RemovedEvent]).
+		RegisterFilterEventMapper(AggregateType, StartedEventType, eventstore.GenericEventMapper[StartedEvent]).
+		RegisterFilterEventMapper(AggregateType, FinishedEventType, eventstore.GenericEventMapper[FinishedEvent])
+}
@@ -0,0 +1,142 @@
+package ldapsync
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix   eventstore.EventType = "ldap.sync."
+	SetEventType                           = eventTypePrefix + "set"
+	RemovedEventType                       = eventTypePrefix + "removed"
+	StartedEventType                       = eventTypePrefix + "started"
+	FinishedEventType                      = eventTypePrefix + "finished"
+)
+
+// SetEvent sets the group mappings and the schedule of the synchronisation of an LDAP identity provider.
+type SetEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	GroupAttribute string                     `json:"groupAttribute,omitempty"`
+	GroupMappings  []*domain.LDAPGroupMapping `json:"groupMappings,omitempty"`
+	SyncEnabled    bool                       `json:"syncEnabled,omitempty"`
+	SyncInterval   time.Duration              `json:"syncInterval,omitempty"`
+}
+
+func (e *SetEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *SetEvent) Payload() any {
+	return e
+}
+
+func (e *SetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	groupAttribute string,
+	groupMappings []*domain.LDAPGroupMapping,
+	syncEnabled bool,
+	syncInterval time.Duration,
+) *SetEvent {
+	return &SetEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx, aggregate, SetEventType,
+		),
+		GroupAttribute: groupAttribute,
+		GroupMappings:  groupMappings,
+		SyncEnabled:    syncEnabled,
+		SyncInterval:   syncInterval,
+	}
+}
+
+type RemovedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func (e *RemovedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *RemovedEvent) Payload() any {
+	return nil
+}
+
+func (e *RemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *RemovedEvent {
+	return &RemovedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx, aggregate, RemovedEventType,
+		),
+	}
+}
+
+// StartedEvent is pushed before the synchronisation runs.
+// The unique constraint of the slot prevents other instances of ZITADEL from running the synchronisation at the same time,
+// it's released by the FinishedEvent.
+type StartedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Slot int64 `json:"slot"`
+}
+
+func (e *StartedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *StartedEvent) Payload() any {
+	return e
+}
+
+func (e *StartedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddRunUniqueConstraint(e.Aggregate().ID, e.Slot)}
+}
+
+func NewStartedEvent(ctx context.Context, aggregate *eventstore.Aggregate, slot int64) *StartedEvent {
+	return &StartedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx, aggregate, StartedEventType,
+		),
+		Slot: slot,
+	}
+}
+
+// FinishedEvent contains the report of a synchronisation run.
+type FinishedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Slot   int64                  `json:"slot"`
+	Report *domain.LDAPSyncReport `json:"report"`
+}
+
+func (e *FinishedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *FinishedEvent) Payload() any {
+	return e
+}
+
+func (e *FinishedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewRemoveRunUniqueConstraint(e.Aggregate().ID, e.Slot)}
+}
+
+func NewFinishedEvent(ctx context.Context, aggregate *eventstore.Aggregate, slot int64, report *domain.LDAPSyncReport) *FinishedEvent {
+	return &FinishedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx, aggregate, FinishedEventType,
+		),
+		Slot:   slot,
+		Report: report,
+	}
+}
@@ -17,6 +17,8 @@ const (
 type SetEvent struct {
 	*eventstore.BaseEvent `json:"-"`
 	AuditLogRetention     *time.Duration `json:"auditLogRetention,omitempty"`
+	// ActionExecutionRetention limits the age of the stored runs of the actions
+	ActionExecutionRetention *time.Duration `json:"actionExecutionRetention,omitempty"`
 }
 
 func (e *SetEvent) Payload() any {
@@ -52,6 +54,12 @@ func ChangeAuditLogRetention(auditLogRetention *time.Duration) LimitsChange {
 	}
 }
 
+func ChangeActionExecutionRetention(actionExecutionRetention *time.Duration) LimitsChange {
+	return func(e *SetEvent) {
+		e.ActionExecutionRetention = actionExecutionRetention
+	}
+}
+
 var SetEventMapper = eventstore.GenericEventMapper[SetEvent]
 
 type ResetEvent struct {
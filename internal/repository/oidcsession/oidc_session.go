@@ -28,6 +28,9 @@ type AddedEvent struct {
 	Scope       []string                    `json:"scope"`
 	AuthMethods []domain.UserAuthMethodType `json:"authMethods"`
 	AuthTime    time.Time                   `json:"authTime"`
+	DPoPJKT     string                      `json:"dpopJkt,omitempty"`
+	// Issuer of the tokens, which is used for the logout tokens of the back-channel logout
+	Issuer string `json:"issuer,omitempty"`
 }
 
 func (e *AddedEvent) Payload() interface{} {
@@ -51,6 +54,8 @@ func NewAddedEvent(ctx context.Context,
 	scope []string,
 	authMethods []domain.UserAuthMethodType,
 	authTime time.Time,
+	dpopJKT,
+	issuer string,
 ) *AddedEvent {
 	return &AddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -65,6 +70,8 @@ func NewAddedEvent(ctx context.Context,
 		Scope:       scope,
 		AuthMethods: authMethods,
 		AuthTime:    authTime,
+		DPoPJKT:     dpopJKT,
+		Issuer:      issuer,
 	}
 }
 
@@ -115,6 +115,9 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, NotificationPolicyAddedEventType, NotificationPolicyAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, NotificationPolicyChangedEventType, NotificationPolicyChangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, NotificationPolicyRemovedEventType, NotificationPolicyRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ImpersonationPolicyAddedEventType, ImpersonationPolicyAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ImpersonationPolicyChangedEventType, ImpersonationPolicyChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ImpersonationPolicyRemovedEventType, ImpersonationPolicyRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, deviceauth.AddedEventType, eventstore.GenericEventMapper[deviceauth.AddedEvent]).
 		RegisterFilterEventMapper(AggregateType, deviceauth.ApprovedEventType, eventstore.GenericEventMapper[deviceauth.ApprovedEvent]).
 		RegisterFilterEventMapper(AggregateType, deviceauth.CanceledEventType, eventstore.GenericEventMapper[deviceauth.CanceledEvent]).
@@ -0,0 +1,124 @@
+package policy
+
+import (
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	ImpersonationPolicyAddedEventType   = "policy.impersonation.added"
+	ImpersonationPolicyChangedEventType = "policy.impersonation.changed"
+	ImpersonationPolicyRemovedEventType = "policy.impersonation.removed"
+)
+
+type ImpersonationPolicyAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AllowImpersonation bool `json:"allowImpersonation,omitempty"`
+}
+
+func (e *ImpersonationPolicyAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ImpersonationPolicyAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewImpersonationPolicyAddedEvent(
+	base *eventstore.BaseEvent,
+	allowImpersonation bool,
+) *ImpersonationPolicyAddedEvent {
+	return &ImpersonationPolicyAddedEvent{
+		BaseEvent:          *base,
+		AllowImpersonation: allowImpersonation,
+	}
+}
+
+func ImpersonationPolicyAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ImpersonationPolicyAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Eeth5", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type ImpersonationPolicyChangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AllowImpersonation *bool `json:"allowImpersonation,omitempty"`
+}
+
+func (e *ImpersonationPolicyChangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ImpersonationPolicyChangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewImpersonationPolicyChangedEvent(
+	base *eventstore.BaseEvent,
+	changes []ImpersonationPolicyChanges,
+) (*ImpersonationPolicyChangedEvent, error) {
+	if len(changes) == 0 {
+		return nil, errors.ThrowPreconditionFailed(nil, "POLICY-ahk3O", "Errors.NoChangesFound")
+	}
+	changeEvent := &ImpersonationPolicyChangedEvent{
+		BaseEvent: *base,
+	}
+	for _, change := range changes {
+		change(changeEvent)
+	}
+	return changeEvent, nil
+}
+
+type ImpersonationPolicyChanges func(*ImpersonationPolicyChangedEvent)
+
+func ChangeAllowImpersonation(allowImpersonation bool) func(*ImpersonationPolicyChangedEvent) {
+	return func(e *ImpersonationPolicyChangedEvent) {
+		e.AllowImpersonation = &allowImpersonation
+	}
+}
+
+func ImpersonationPolicyChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ImpersonationPolicyChangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "POLIC-Ooc1u", "unable to unmarshal policy")
+	}
+
+	return e, nil
+}
+
+type ImpersonationPolicyRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *ImpersonationPolicyRemovedEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *ImpersonationPolicyRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewImpersonationPolicyRemovedEvent(base *eventstore.BaseEvent) *ImpersonationPolicyRemovedEvent {
+	return &ImpersonationPolicyRemovedEvent{
+		BaseEvent: *base,
+	}
+}
+
+func ImpersonationPolicyRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &ImpersonationPolicyRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
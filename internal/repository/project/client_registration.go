@@ -0,0 +1,228 @@
+package project
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	clientRegistrationEventTypePrefix = projectEventTypePrefix + "client_registration."
+
+	ClientRegistrationPolicySetType     = clientRegistrationEventTypePrefix + "policy.set"
+	ClientRegistrationPolicyRemovedType = clientRegistrationEventTypePrefix + "policy.removed"
+	ClientRegistrationTokenAddedType    = clientRegistrationEventTypePrefix + "token.added"
+	ClientRegistrationTokenRemovedType  = clientRegistrationEventTypePrefix + "token.removed"
+
+	ApplicationRegistrationTokenSetType = applicationEventTypePrefix + "registration.token.set"
+)
+
+type ClientRegistrationPolicySetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AllowedGrantTypes   []domain.OIDCGrantType `json:"allowedGrantTypes,omitempty"`
+	RedirectURIPatterns []string               `json:"redirectUriPatterns,omitempty"`
+}
+
+func (e *ClientRegistrationPolicySetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ClientRegistrationPolicySetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewClientRegistrationPolicySetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	allowedGrantTypes []domain.OIDCGrantType,
+	redirectURIPatterns []string,
+) *ClientRegistrationPolicySetEvent {
+	return &ClientRegistrationPolicySetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ClientRegistrationPolicySetType,
+		),
+		AllowedGrantTypes:   allowedGrantTypes,
+		RedirectURIPatterns: redirectURIPatterns,
+	}
+}
+
+func ClientRegistrationPolicySetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ClientRegistrationPolicySetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-ahj4U", "unable to unmarshal client registration policy")
+	}
+
+	return e, nil
+}
+
+type ClientRegistrationPolicyRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *ClientRegistrationPolicyRemovedEvent) Payload() interface{} {
+	return nil
+}
+
+func (e *ClientRegistrationPolicyRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewClientRegistrationPolicyRemovedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *ClientRegistrationPolicyRemovedEvent {
+	return &ClientRegistrationPolicyRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ClientRegistrationPolicyRemovedType,
+		),
+	}
+}
+
+func ClientRegistrationPolicyRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	return &ClientRegistrationPolicyRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}, nil
+}
+
+type ClientRegistrationTokenAddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID    string    `json:"tokenId"`
+	Expiration time.Time `json:"expiration"`
+}
+
+func (e *ClientRegistrationTokenAddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ClientRegistrationTokenAddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewClientRegistrationTokenAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+	expiration time.Time,
+) *ClientRegistrationTokenAddedEvent {
+	return &ClientRegistrationTokenAddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ClientRegistrationTokenAddedType,
+		),
+		TokenID:    tokenID,
+		Expiration: expiration,
+	}
+}
+
+func ClientRegistrationTokenAddedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ClientRegistrationTokenAddedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Ieph7", "unable to unmarshal client registration token")
+	}
+
+	return e, nil
+}
+
+type ClientRegistrationTokenRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	TokenID string `json:"tokenId"`
+}
+
+func (e *ClientRegistrationTokenRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ClientRegistrationTokenRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewClientRegistrationTokenRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	tokenID string,
+) *ClientRegistrationTokenRemovedEvent {
+	return &ClientRegistrationTokenRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ClientRegistrationTokenRemovedType,
+		),
+		TokenID: tokenID,
+	}
+}
+
+func ClientRegistrationTokenRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ClientRegistrationTokenRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Ko4ei", "unable to unmarshal client registration token removed")
+	}
+
+	return e, nil
+}
+
+// ApplicationRegistrationTokenSetEvent is pushed, when an application was registered dynamically.
+// The registration access token (RFC 7592) containing the TokenID allows the client to manage the application.
+type ApplicationRegistrationTokenSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID   string `json:"appId"`
+	TokenID string `json:"tokenId"`
+}
+
+func (e *ApplicationRegistrationTokenSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ApplicationRegistrationTokenSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewApplicationRegistrationTokenSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID,
+	tokenID string,
+) *ApplicationRegistrationTokenSetEvent {
+	return &ApplicationRegistrationTokenSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApplicationRegistrationTokenSetType,
+		),
+		AppID:   appID,
+		TokenID: tokenID,
+	}
+}
+
+func ApplicationRegistrationTokenSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ApplicationRegistrationTokenSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Ohng4", "unable to unmarshal application registration token")
+	}
+
+	return e, nil
+}
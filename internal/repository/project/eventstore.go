@@ -43,5 +43,12 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, ApplicationKeyAddedEventType, ApplicationKeyAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, ApplicationKeyRemovedEventType, ApplicationKeyRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, SAMLConfigAddedType, SAMLConfigAddedEventMapper).
-		RegisterFilterEventMapper(AggregateType, SAMLConfigChangedType, SAMLConfigChangedEventMapper)
+		RegisterFilterEventMapper(AggregateType, SAMLConfigChangedType, SAMLConfigChangedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ApplicationProvisioningSetType, ApplicationProvisioningSetEventMapper).
+		RegisterFilterEventMapper(AggregateType, ApplicationProvisioningRemovedType, ApplicationProvisioningRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ClientRegistrationPolicySetType, ClientRegistrationPolicySetEventMapper).
+		RegisterFilterEventMapper(AggregateType, ClientRegistrationPolicyRemovedType, ClientRegistrationPolicyRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ClientRegistrationTokenAddedType, ClientRegistrationTokenAddedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ClientRegistrationTokenRemovedType, ClientRegistrationTokenRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, ApplicationRegistrationTokenSetType, ApplicationRegistrationTokenSetEventMapper)
 }
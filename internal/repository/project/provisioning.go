@@ -0,0 +1,104 @@
+package project
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	ApplicationProvisioningSetType     = applicationEventTypePrefix + "provisioning.set"
+	ApplicationProvisioningRemovedType = applicationEventTypePrefix + "provisioning.removed"
+)
+
+type ApplicationProvisioningSetEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID    string              `json:"appId"`
+	Endpoint string              `json:"endpoint"`
+	Token    *crypto.CryptoValue `json:"token,omitempty"`
+}
+
+func (e *ApplicationProvisioningSetEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ApplicationProvisioningSetEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewApplicationProvisioningSetEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID,
+	endpoint string,
+	token *crypto.CryptoValue,
+) *ApplicationProvisioningSetEvent {
+	return &ApplicationProvisioningSetEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApplicationProvisioningSetType,
+		),
+		AppID:    appID,
+		Endpoint: endpoint,
+		Token:    token,
+	}
+}
+
+func ApplicationProvisioningSetEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ApplicationProvisioningSetEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-Eig5a", "unable to unmarshal application provisioning")
+	}
+
+	return e, nil
+}
+
+type ApplicationProvisioningRemovedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	AppID string `json:"appId"`
+}
+
+func (e *ApplicationProvisioningRemovedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ApplicationProvisioningRemovedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewApplicationProvisioningRemovedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	appID string,
+) *ApplicationProvisioningRemovedEvent {
+	return &ApplicationProvisioningRemovedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ApplicationProvisioningRemovedType,
+		),
+		AppID: appID,
+	}
+}
+
+func ApplicationProvisioningRemovedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	e := &ApplicationProvisioningRemovedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+
+	err := event.Unmarshal(e)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "PROJECT-ooX8e", "unable to unmarshal application provisioning removed")
+	}
+
+	return e, nil
+}
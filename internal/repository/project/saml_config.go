@@ -16,10 +16,13 @@ const (
 type SAMLConfigAddedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AppID       string `json:"appId"`
-	EntityID    string `json:"entityId"`
-	Metadata    []byte `json:"metadata,omitempty"`
-	MetadataURL string `json:"metadata_url,omitempty"`
+	AppID             string `json:"appId"`
+	EntityID          string `json:"entityId"`
+	Metadata          []byte `json:"metadata,omitempty"`
+	MetadataURL       string `json:"metadata_url,omitempty"`
+	EncryptAssertions bool   `json:"encryptAssertions,omitempty"`
+	ArtifactBinding   bool   `json:"artifactBinding,omitempty"`
+	SingleLogout      bool   `json:"singleLogout,omitempty"`
 }
 
 func (e *SAMLConfigAddedEvent) Payload() interface{} {
@@ -50,6 +53,9 @@ func NewSAMLConfigAddedEvent(
 	entityID string,
 	metadata []byte,
 	metadataURL string,
+	encryptAssertions bool,
+	artifactBinding bool,
+	singleLogout bool,
 ) *SAMLConfigAddedEvent {
 	return &SAMLConfigAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -57,10 +63,13 @@ func NewSAMLConfigAddedEvent(
 			aggregate,
 			SAMLConfigAddedType,
 		),
-		AppID:       appID,
-		EntityID:    entityID,
-		Metadata:    metadata,
-		MetadataURL: metadataURL,
+		AppID:             appID,
+		EntityID:          entityID,
+		Metadata:          metadata,
+		MetadataURL:       metadataURL,
+		EncryptAssertions: encryptAssertions,
+		ArtifactBinding:   artifactBinding,
+		SingleLogout:      singleLogout,
 	}
 }
 
@@ -80,11 +89,14 @@ func SAMLConfigAddedEventMapper(event eventstore.Event) (eventstore.Event, error
 type SAMLConfigChangedEvent struct {
 	eventstore.BaseEvent `json:"-"`
 
-	AppID       string  `json:"appId"`
-	EntityID    string  `json:"entityId"`
-	Metadata    []byte  `json:"metadata,omitempty"`
-	MetadataURL *string `json:"metadata_url,omitempty"`
-	oldEntityID string
+	AppID             string  `json:"appId"`
+	EntityID          string  `json:"entityId"`
+	Metadata          []byte  `json:"metadata,omitempty"`
+	MetadataURL       *string `json:"metadata_url,omitempty"`
+	EncryptAssertions *bool   `json:"encryptAssertions,omitempty"`
+	ArtifactBinding   *bool   `json:"artifactBinding,omitempty"`
+	SingleLogout      *bool   `json:"singleLogout,omitempty"`
+	oldEntityID       string
 }
 
 func (e *SAMLConfigChangedEvent) Payload() interface{} {
@@ -147,6 +159,24 @@ func ChangeEntityID(entityID string) func(event *SAMLConfigChangedEvent) {
 	}
 }
 
+func ChangeEncryptAssertions(encryptAssertions bool) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.EncryptAssertions = &encryptAssertions
+	}
+}
+
+func ChangeArtifactBinding(artifactBinding bool) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.ArtifactBinding = &artifactBinding
+	}
+}
+
+func ChangeSingleLogout(singleLogout bool) func(event *SAMLConfigChangedEvent) {
+	return func(e *SAMLConfigChangedEvent) {
+		e.SingleLogout = &singleLogout
+	}
+}
+
 func SAMLConfigChangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
 	e := &SAMLConfigChangedEvent{
 		BaseEvent: *eventstore.BaseEventFromRepo(event),
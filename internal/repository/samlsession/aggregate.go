@@ -0,0 +1,21 @@
+package samlsession
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	AggregateType    = "saml_session"
+	AggregateVersion = "v1"
+)
+
+func NewAggregate(id, instanceID string) *eventstore.Aggregate {
+	return &eventstore.Aggregate{
+		ID:   id,
+		Type: AggregateType,
+		// the session belongs to the instance, as the service provider might be granted to other organizations
+		ResourceOwner: instanceID,
+		InstanceID:    instanceID,
+		Version:       AggregateVersion,
+	}
+}
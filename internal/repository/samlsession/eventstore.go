@@ -0,0 +1,11 @@
+package samlsession
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedType, eventstore.GenericEventMapper[AddedEvent]).
+		RegisterFilterEventMapper(AggregateType, ArtifactResolvedType, eventstore.GenericEventMapper[ArtifactResolvedEvent]).
+		RegisterFilterEventMapper(AggregateType, TerminatedType, eventstore.GenericEventMapper[TerminatedEvent])
+}
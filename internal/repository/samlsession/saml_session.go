@@ -0,0 +1,134 @@
+package samlsession
+
+import (
+	"context"
+	"time"
+
+	"github.com/zitadel/zitadel/internal/crypto"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	samlSessionEventPrefix = "saml_session."
+	AddedType              = samlSessionEventPrefix + "added"
+	ArtifactResolvedType   = samlSessionEventPrefix + "artifact.resolved"
+	TerminatedType         = samlSessionEventPrefix + "terminated"
+)
+
+// AddedEvent is pushed as soon as a SAML response was issued for the user to a service provider.
+// If the response is transferred with the HTTP-Artifact binding, it's stored (encrypted) until the
+// service provider resolves the artifact.
+type AddedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	UserID             string              `json:"userID"`
+	UserResourceOwner  string              `json:"userResourceOwner"`
+	UserAgentID        string              `json:"userAgentID"`
+	AppID              string              `json:"appID"`
+	EntityID           string              `json:"entityID"`
+	NameID             string              `json:"nameID"`
+	NameIDFormat       string              `json:"nameIDFormat"`
+	AuthTime           time.Time           `json:"authTime"`
+	Response           *crypto.CryptoValue `json:"response,omitempty"`
+	ArtifactExpiration time.Time           `json:"artifactExpiration,omitempty"`
+}
+
+func (e *AddedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *AddedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	userID,
+	userResourceOwner,
+	userAgentID,
+	appID,
+	entityID,
+	nameID,
+	nameIDFormat string,
+	authTime time.Time,
+	response *crypto.CryptoValue,
+	artifactExpiration time.Time,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			AddedType,
+		),
+		UserID:             userID,
+		UserResourceOwner:  userResourceOwner,
+		UserAgentID:        userAgentID,
+		AppID:              appID,
+		EntityID:           entityID,
+		NameID:             nameID,
+		NameIDFormat:       nameIDFormat,
+		AuthTime:           authTime,
+		Response:           response,
+		ArtifactExpiration: artifactExpiration,
+	}
+}
+
+// ArtifactResolvedEvent is pushed after the service provider resolved the artifact,
+// the stored response is not returned again.
+type ArtifactResolvedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *ArtifactResolvedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *ArtifactResolvedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *ArtifactResolvedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewArtifactResolvedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *ArtifactResolvedEvent {
+	return &ArtifactResolvedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			ArtifactResolvedType,
+		),
+	}
+}
+
+// TerminatedEvent is pushed when the session ended by a single logout.
+type TerminatedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+}
+
+func (e *TerminatedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *TerminatedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func (e *TerminatedEvent) SetBaseEvent(event *eventstore.BaseEvent) {
+	e.BaseEvent = *event
+}
+
+func NewTerminatedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *TerminatedEvent {
+	return &TerminatedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			TerminatedType,
+		),
+	}
+}
@@ -43,6 +43,7 @@ func RegisterEventMappers(es *eventstore.Eventstore) {
 		RegisterFilterEventMapper(AggregateType, UserRemovedType, UserRemovedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserTokenAddedType, UserTokenAddedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserTokenRemovedType, UserTokenRemovedEventMapper).
+		RegisterFilterEventMapper(AggregateType, TokenExchangedType, TokenExchangedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserDomainClaimedType, DomainClaimedEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserDomainClaimedSentType, DomainClaimedSentEventMapper).
 		RegisterFilterEventMapper(AggregateType, UserUserNameChangedType, UsernameChangedEventMapper).
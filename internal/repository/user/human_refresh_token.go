@@ -28,6 +28,7 @@ type HumanRefreshTokenAddedEvent struct {
 	IdleExpiration        time.Duration `json:"idleExpiration"`
 	Expiration            time.Duration `json:"expiration"`
 	PreferredLanguage     string        `json:"preferredLanguage"`
+	DPoPJKT               string        `json:"dpopJkt,omitempty"`
 }
 
 func (e *HumanRefreshTokenAddedEvent) Payload() interface{} {
@@ -55,6 +56,7 @@ func NewHumanRefreshTokenAddedEvent(
 	authTime time.Time,
 	idleExpiration,
 	expiration time.Duration,
+	dpopJKT string,
 ) *HumanRefreshTokenAddedEvent {
 	return &HumanRefreshTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -72,6 +74,7 @@ func NewHumanRefreshTokenAddedEvent(
 		IdleExpiration:        idleExpiration,
 		Expiration:            expiration,
 		PreferredLanguage:     preferredLanguage,
+		DPoPJKT:               dpopJKT,
 	}
 }
 
@@ -0,0 +1,73 @@
+package user
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	TokenExchangedType = userEventTypePrefix + "token.exchanged"
+)
+
+// TokenExchangedEvent records a token exchange (RFC 8693) issuing a token for the user.
+// Actor is the id of the user acting on behalf of the user (delegation and impersonation).
+type TokenExchangedEvent struct {
+	eventstore.BaseEvent `json:"-"`
+
+	ClientID           string   `json:"clientId"`
+	SubjectTokenType   string   `json:"subjectTokenType"`
+	RequestedTokenType string   `json:"requestedTokenType"`
+	Actor              string   `json:"actor,omitempty"`
+	Impersonation      bool     `json:"impersonation,omitempty"`
+	Audience           []string `json:"audience,omitempty"`
+	Scopes             []string `json:"scopes,omitempty"`
+}
+
+func (e *TokenExchangedEvent) Payload() interface{} {
+	return e
+}
+
+func (e *TokenExchangedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewTokenExchangedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	clientID,
+	subjectTokenType,
+	requestedTokenType,
+	actor string,
+	impersonation bool,
+	audience,
+	scopes []string,
+) *TokenExchangedEvent {
+	return &TokenExchangedEvent{
+		BaseEvent: *eventstore.NewBaseEventForPush(
+			ctx,
+			aggregate,
+			TokenExchangedType,
+		),
+		ClientID:           clientID,
+		SubjectTokenType:   subjectTokenType,
+		RequestedTokenType: requestedTokenType,
+		Actor:              actor,
+		Impersonation:      impersonation,
+		Audience:           audience,
+		Scopes:             scopes,
+	}
+}
+
+func TokenExchangedEventMapper(event eventstore.Event) (eventstore.Event, error) {
+	tokenExchanged := &TokenExchangedEvent{
+		BaseEvent: *eventstore.BaseEventFromRepo(event),
+	}
+	err := event.Unmarshal(tokenExchanged)
+	if err != nil {
+		return nil, errors.ThrowInternal(err, "USER-Phie3", "unable to unmarshal token exchanged")
+	}
+
+	return tokenExchanged, nil
+}
@@ -217,6 +217,9 @@ type UserTokenAddedEvent struct {
 	Scopes            []string  `json:"scopes"`
 	Expiration        time.Time `json:"expiration"`
 	PreferredLanguage string    `json:"preferredLanguage"`
+	DPoPJKT           string    `json:"dpopJkt,omitempty"`
+	// Issuer of the token, which is used for the logout tokens of the back-channel logout
+	Issuer string `json:"issuer,omitempty"`
 }
 
 func (e *UserTokenAddedEvent) Payload() interface{} {
@@ -238,6 +241,8 @@ func NewUserTokenAddedEvent(
 	audience,
 	scopes []string,
 	expiration time.Time,
+	dpopJKT,
+	issuer string,
 ) *UserTokenAddedEvent {
 	return &UserTokenAddedEvent{
 		BaseEvent: *eventstore.NewBaseEventForPush(
@@ -253,6 +258,8 @@ func NewUserTokenAddedEvent(
 		Scopes:            scopes,
 		Expiration:        expiration,
 		PreferredLanguage: preferredLanguage,
+		DPoPJKT:           dpopJKT,
+		Issuer:            issuer,
 	}
 }
 
@@ -0,0 +1,20 @@
+package userimport
+
+import (
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	UniqueRun    = "user_import_run"
+	DuplicateRun = "Errors.UserImport.AlreadyRunning"
+)
+
+// NewAddRunUniqueConstraint locks the import,
+// so it is only processed once even if multiple instances of ZITADEL pick it up.
+func NewAddRunUniqueConstraint(importID string) *eventstore.UniqueConstraint {
+	return eventstore.NewAddEventUniqueConstraint(
+		UniqueRun,
+		importID,
+		DuplicateRun,
+	)
+}
@@ -0,0 +1,10 @@
+package userimport
+
+import "github.com/zitadel/zitadel/internal/eventstore"
+
+func RegisterEventMappers(es *eventstore.Eventstore) {
+	es.RegisterFilterEventMapper(AggregateType, AddedEventType, eventstore.GenericEventMapper[AddedEvent]).
+		RegisterFilterEventMapper(AggregateType, StartedEventType, eventstore.GenericEventMapper[StartedEvent]).
+		RegisterFilterEventMapper(AggregateType, RowsProcessedEventType, eventstore.GenericEventMapper[RowsProcessedEvent]).
+		RegisterFilterEventMapper(AggregateType, FinishedEventType, eventstore.GenericEventMapper[FinishedEvent])
+}
@@ -0,0 +1,137 @@
+package userimport
+
+import (
+	"context"
+
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/eventstore"
+)
+
+const (
+	eventTypePrefix        eventstore.EventType = "user.import."
+	AddedEventType                              = eventTypePrefix + "added"
+	StartedEventType                            = eventTypePrefix + "started"
+	RowsProcessedEventType                      = eventTypePrefix + "rows.processed"
+	FinishedEventType                           = eventTypePrefix + "finished"
+)
+
+// AddedEvent queues the import of the users contained in the data.
+type AddedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Format domain.UserImportFormat `json:"format"`
+	DryRun bool                    `json:"dryRun,omitempty"`
+	Data   []byte                  `json:"data"`
+}
+
+func (e *AddedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *AddedEvent) Payload() any {
+	return e
+}
+
+func (e *AddedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewAddedEvent(
+	ctx context.Context,
+	aggregate *eventstore.Aggregate,
+	format domain.UserImportFormat,
+	dryRun bool,
+	data []byte,
+) *AddedEvent {
+	return &AddedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx, aggregate, AddedEventType,
+		),
+		Format: format,
+		DryRun: dryRun,
+		Data:   data,
+	}
+}
+
+// StartedEvent is pushed before the rows are processed.
+// The unique constraint prevents other instances of ZITADEL from processing the same import.
+type StartedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+}
+
+func (e *StartedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *StartedEvent) Payload() any {
+	return nil
+}
+
+func (e *StartedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return []*eventstore.UniqueConstraint{NewAddRunUniqueConstraint(e.Aggregate().ID)}
+}
+
+func NewStartedEvent(ctx context.Context, aggregate *eventstore.Aggregate) *StartedEvent {
+	return &StartedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx, aggregate, StartedEventType,
+		),
+	}
+}
+
+// RowsProcessedEvent contains the results of a batch of processed rows.
+type RowsProcessedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Results []*domain.UserImportRowResult `json:"results"`
+}
+
+func (e *RowsProcessedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *RowsProcessedEvent) Payload() any {
+	return e
+}
+
+func (e *RowsProcessedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewRowsProcessedEvent(ctx context.Context, aggregate *eventstore.Aggregate, results []*domain.UserImportRowResult) *RowsProcessedEvent {
+	return &RowsProcessedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx, aggregate, RowsProcessedEventType,
+		),
+		Results: results,
+	}
+}
+
+// FinishedEvent is pushed after all rows were processed or if the import was aborted,
+// in which case the error is set.
+type FinishedEvent struct {
+	*eventstore.BaseEvent `json:"-"`
+
+	Error string `json:"error,omitempty"`
+}
+
+func (e *FinishedEvent) SetBaseEvent(b *eventstore.BaseEvent) {
+	e.BaseEvent = b
+}
+
+func (e *FinishedEvent) Payload() any {
+	return e
+}
+
+func (e *FinishedEvent) UniqueConstraints() []*eventstore.UniqueConstraint {
+	return nil
+}
+
+func NewFinishedEvent(ctx context.Context, aggregate *eventstore.Aggregate, err string) *FinishedEvent {
+	return &FinishedEvent{
+		BaseEvent: eventstore.NewBaseEventForPush(
+			ctx, aggregate, FinishedEventType,
+		),
+		Error: err,
+	}
+}
@@ -22,6 +22,7 @@ type TokenView struct {
 	PreferredLanguage string
 	RefreshTokenID    string
 	IsPAT             bool
+	DPoPJKT           string
 }
 
 type TokenSearchRequest struct {
@@ -38,6 +38,7 @@ type TokenView struct {
 	PreferredLanguage string                     `json:"preferredLanguage" gorm:"column:preferred_language"`
 	RefreshTokenID    string                     `json:"refreshTokenID,omitempty" gorm:"refresh_token_id"`
 	IsPAT             bool                       `json:"-" gorm:"is_pat"`
+	DPoPJKT           string                     `json:"dpopJkt,omitempty" gorm:"column:dpop_jkt"`
 	Deactivated       bool                       `json:"-" gorm:"-"`
 	InstanceID        string                     `json:"instanceID" gorm:"column:instance_id;primary_key"`
 }
@@ -58,6 +59,7 @@ func TokenViewToModel(token *TokenView) *usr_model.TokenView {
 		PreferredLanguage: token.PreferredLanguage,
 		RefreshTokenID:    token.RefreshTokenID,
 		IsPAT:             token.IsPAT,
+		DPoPJKT:           token.DPoPJKT,
 	}
 }
 
@@ -0,0 +1,27 @@
+package userimport
+
+import (
+	"time"
+)
+
+type Config struct {
+	Enabled bool
+	// CheckInterval is the interval in which the job checks for queued imports
+	CheckInterval time.Duration
+	// BatchSize is the number of rows, which are processed before their results are stored
+	BatchSize int
+}
+
+func (c *Config) checkInterval() time.Duration {
+	if c.CheckInterval == 0 {
+		return 10 * time.Second
+	}
+	return c.CheckInterval
+}
+
+func (c *Config) batchSize() int {
+	if c.BatchSize <= 0 {
+		return 100
+	}
+	return c.BatchSize
+}
@@ -0,0 +1,170 @@
+package userimport
+
+import (
+	"context"
+	"strings"
+	"time"
+
+	"github.com/zitadel/logging"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+const (
+	// importUserID is set as editor of the events pushed by the job
+	importUserID = "USER-IMPORT"
+)
+
+type Commands interface {
+	StartUserImport(ctx context.Context, importID, resourceOwner string) (*domain.UserImport, error)
+	ProcessUserImportRows(ctx context.Context, importID, resourceOwner string, results []*domain.UserImportRowResult) (*domain.ObjectDetails, error)
+	FinishUserImport(ctx context.Context, importID, resourceOwner string, importErr error) (*domain.ObjectDetails, error)
+	ImportUserRow(ctx context.Context, orgID string, user *command.ImportUser, dryRun bool) (string, error)
+}
+
+type Queries interface {
+	QueuedUserImports(ctx context.Context) ([]*query.UserImport, error)
+	InstanceByID(ctx context.Context) (authz.Instance, error)
+	IsUserUnique(ctx context.Context, username, email, resourceOwner string) (bool, error)
+}
+
+// Start starts the job, which periodically processes the queued user imports of all instances.
+func Start(
+	ctx context.Context,
+	config *Config,
+	commands Commands,
+	queries Queries,
+) {
+	if config == nil || !config.Enabled {
+		return
+	}
+	j := &job{
+		config:   config,
+		commands: commands,
+		queries:  queries,
+	}
+	go j.schedule(ctx)
+	logging.Info("user import started")
+}
+
+type job struct {
+	config   *Config
+	commands Commands
+	queries  Queries
+}
+
+func (j *job) schedule(ctx context.Context) {
+	ticker := time.NewTicker(j.config.checkInterval())
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			j.importQueued(ctx)
+		}
+	}
+}
+
+func (j *job) importQueued(ctx context.Context) {
+	imports, err := j.queries.QueuedUserImports(ctx)
+	if err != nil {
+		logging.WithError(err).Warn("unable to query queued user imports")
+		return
+	}
+	for _, userImport := range imports {
+		j.importUsers(ctx, userImport)
+	}
+}
+
+func (j *job) importUsers(ctx context.Context, userImport *query.UserImport) {
+	ctx = authz.WithInstanceID(ctx, userImport.InstanceID)
+	instance, err := j.queries.InstanceByID(ctx)
+	if err != nil {
+		logging.WithFields("instance", userImport.InstanceID, "import", userImport.ID).WithError(err).Warn("unable to get instance for user import")
+		return
+	}
+	ctx = authz.WithInstance(ctx, instance)
+	ctx = authz.SetCtxData(ctx, authz.CtxData{
+		UserID: importUserID,
+		OrgID:  userImport.ResourceOwner,
+	})
+
+	// the import might have been started by another ZITADEL process in the meantime
+	started, err := j.commands.StartUserImport(ctx, userImport.ID, userImport.ResourceOwner)
+	if err != nil {
+		logging.WithFields("instance", userImport.InstanceID, "import", userImport.ID).WithError(err).Debug("user import not started")
+		return
+	}
+	importErr := j.run(ctx, started)
+	_, err = j.commands.FinishUserImport(ctx, userImport.ID, userImport.ResourceOwner, importErr)
+	logging.WithFields("instance", userImport.InstanceID, "import", userImport.ID).OnError(err).Error("unable to finish user import")
+}
+
+// run imports the rows of the data and stores the results in batches,
+// an error is only returned if the import has to be aborted
+func (j *job) run(ctx context.Context, userImport *domain.UserImport) error {
+	rows, err := Parse(userImport.Format, userImport.Data)
+	if err != nil {
+		return err
+	}
+	imported := make(map[string]struct{}, len(rows))
+	results := make([]*domain.UserImportRowResult, 0, j.config.batchSize())
+	for _, row := range rows {
+		results = append(results, j.importRow(ctx, userImport, row, imported))
+		if len(results) < j.config.batchSize() {
+			continue
+		}
+		if _, err = j.commands.ProcessUserImportRows(ctx, userImport.AggregateID, userImport.ResourceOwner, results); err != nil {
+			return err
+		}
+		results = results[:0]
+	}
+	if len(results) == 0 {
+		return nil
+	}
+	_, err = j.commands.ProcessUserImportRows(ctx, userImport.AggregateID, userImport.ResourceOwner, results)
+	return err
+}
+
+// importRow creates the user of the row, imported contains the usernames of the previous rows
+// to detect duplicates, which are not yet stored on a dry run
+func (j *job) importRow(ctx context.Context, userImport *domain.UserImport, row *Row, imported map[string]struct{}) *domain.UserImportRowResult {
+	result := &domain.UserImportRowResult{
+		Row:      row.Number,
+		Username: row.Username,
+	}
+	userID, err := j.createUser(ctx, userImport, row, imported)
+	if err != nil {
+		result.Error = err.Error()
+		return result
+	}
+	imported[strings.ToLower(row.Username)] = struct{}{}
+	result.UserID = userID
+	return result
+}
+
+func (j *job) createUser(ctx context.Context, userImport *domain.UserImport, row *Row, imported map[string]struct{}) (string, error) {
+	user, err := row.ImportUser()
+	if err != nil {
+		return "", err
+	}
+	if _, ok := imported[strings.ToLower(row.Username)]; ok {
+		return "", errors.ThrowAlreadyExists(nil, "USERI-uThi0", "Errors.User.AlreadyExisting")
+	}
+	if userImport.DryRun {
+		// uniqueness of the username is only checked by the eventstore when the user is created
+		unique, err := j.queries.IsUserUnique(ctx, row.Username, "", userImport.ResourceOwner)
+		if err != nil {
+			return "", err
+		}
+		if !unique {
+			return "", errors.ThrowAlreadyExists(nil, "USERI-Aic4o", "Errors.User.AlreadyExisting")
+		}
+	}
+	return j.commands.ImportUserRow(ctx, userImport.ResourceOwner, user, userImport.DryRun)
+}
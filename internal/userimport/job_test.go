@@ -0,0 +1,137 @@
+package userimport
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/zitadel/zitadel/internal/api/authz"
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+	"github.com/zitadel/zitadel/internal/eventstore/v1/models"
+	"github.com/zitadel/zitadel/internal/query"
+)
+
+type mockCommands struct {
+	imported  []string
+	processed [][]*domain.UserImportRowResult
+}
+
+func (c *mockCommands) StartUserImport(context.Context, string, string) (*domain.UserImport, error) {
+	return nil, nil
+}
+
+func (c *mockCommands) ProcessUserImportRows(_ context.Context, _, _ string, results []*domain.UserImportRowResult) (*domain.ObjectDetails, error) {
+	c.processed = append(c.processed, append([]*domain.UserImportRowResult(nil), results...))
+	return nil, nil
+}
+
+func (c *mockCommands) FinishUserImport(context.Context, string, string, error) (*domain.ObjectDetails, error) {
+	return nil, nil
+}
+
+func (c *mockCommands) ImportUserRow(_ context.Context, _ string, user *command.ImportUser, _ bool) (string, error) {
+	if user.Human.Username == "invalid" {
+		return "", errors.ThrowInvalidArgument(nil, "V2-zzad3", "Errors.Invalid.Argument")
+	}
+	c.imported = append(c.imported, user.Human.Username)
+	return "id-" + user.Human.Username, nil
+}
+
+type mockQueries struct {
+	existing map[string]bool
+}
+
+func (q *mockQueries) QueuedUserImports(context.Context) ([]*query.UserImport, error) {
+	return nil, nil
+}
+
+func (q *mockQueries) InstanceByID(context.Context) (authz.Instance, error) {
+	return nil, nil
+}
+
+func (q *mockQueries) IsUserUnique(_ context.Context, username, _, _ string) (bool, error) {
+	return !q.existing[username], nil
+}
+
+func Test_job_run(t *testing.T) {
+	data := []byte(`{"username": "user1"}` + "\n" +
+		`{"username": "invalid"}` + "\n" +
+		`{"username": "User1"}` + "\n" +
+		`{"username": "existing"}`)
+	tests := []struct {
+		name          string
+		dryRun        bool
+		wantImported  []string
+		wantProcessed [][]*domain.UserImportRowResult
+	}{
+		{
+			name: "import, ok",
+			// the existing username is only rejected by the unique constraint of the eventstore
+			wantImported: []string{"user1", "existing"},
+			wantProcessed: [][]*domain.UserImportRowResult{
+				{
+					{Row: 1, Username: "user1", UserID: "id-user1"},
+					{Row: 2, Username: "invalid", Error: "ID=V2-zzad3 Message=Errors.Invalid.Argument"},
+					{Row: 3, Username: "User1", Error: "ID=USERI-uThi0 Message=Errors.User.AlreadyExisting"},
+				},
+				{
+					{Row: 4, Username: "existing", UserID: "id-existing"},
+				},
+			},
+		},
+		{
+			name:         "dry run, existing username checked",
+			dryRun:       true,
+			wantImported: []string{"user1"},
+			wantProcessed: [][]*domain.UserImportRowResult{
+				{
+					{Row: 1, Username: "user1", UserID: "id-user1"},
+					{Row: 2, Username: "invalid", Error: "ID=V2-zzad3 Message=Errors.Invalid.Argument"},
+					{Row: 3, Username: "User1", Error: "ID=USERI-uThi0 Message=Errors.User.AlreadyExisting"},
+				},
+				{
+					{Row: 4, Username: "existing", Error: "ID=USERI-Aic4o Message=Errors.User.AlreadyExisting"},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			commands := new(mockCommands)
+			j := &job{
+				config:   &Config{BatchSize: 3},
+				commands: commands,
+				queries:  &mockQueries{existing: map[string]bool{"existing": true}},
+			}
+			err := j.run(context.Background(), &domain.UserImport{
+				ObjectRoot: models.ObjectRoot{AggregateID: "import1", ResourceOwner: "org1"},
+				Format:     domain.UserImportFormatNDJSON,
+				DryRun:     tt.dryRun,
+				Data:       data,
+			})
+			require.NoError(t, err)
+			assert.Equal(t, tt.wantImported, commands.imported)
+			assert.Equal(t, tt.wantProcessed, commands.processed)
+		})
+	}
+}
+
+func Test_job_run_invalidData(t *testing.T) {
+	commands := new(mockCommands)
+	j := &job{
+		config:   new(Config),
+		commands: commands,
+		queries:  new(mockQueries),
+	}
+	err := j.run(context.Background(), &domain.UserImport{
+		ObjectRoot: models.ObjectRoot{AggregateID: "import1", ResourceOwner: "org1"},
+		Format:     domain.UserImportFormatCSV,
+		Data:       []byte("unknown\nvalue"),
+	})
+	assert.True(t, errors.IsErrorInvalidArgument(err))
+	assert.Empty(t, commands.processed)
+}
@@ -0,0 +1,373 @@
+package userimport
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"strconv"
+	"strings"
+
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+const (
+	columnUserID                 = "userId"
+	columnUsername               = "username"
+	columnFirstName              = "firstName"
+	columnLastName               = "lastName"
+	columnNickName               = "nickName"
+	columnDisplayName            = "displayName"
+	columnPreferredLanguage      = "preferredLanguage"
+	columnGender                 = "gender"
+	columnEmail                  = "email"
+	columnEmailVerified          = "emailVerified"
+	columnPhone                  = "phone"
+	columnPhoneVerified          = "phoneVerified"
+	columnPassword               = "password"
+	columnHashedPassword         = "hashedPassword"
+	columnPasswordChangeRequired = "passwordChangeRequired"
+	columnGrants                 = "grants"
+	columnIDPLinks               = "idpLinks"
+	// columnMetadataPrefix is the prefix of the CSV columns containing metadata, e.g. `metadata.department`
+	columnMetadataPrefix = "metadata."
+)
+
+// Row is a single user of the import data.
+// The JSON names are used for the NDJSON format as well as for the header of the CSV format.
+type Row struct {
+	// Number is the number of the row in the data, starting with 1 (excluding the header of CSV)
+	Number uint32 `json:"-"`
+
+	UserID                 string            `json:"userId,omitempty"`
+	Username               string            `json:"username"`
+	FirstName              string            `json:"firstName"`
+	LastName               string            `json:"lastName"`
+	NickName               string            `json:"nickName,omitempty"`
+	DisplayName            string            `json:"displayName,omitempty"`
+	PreferredLanguage      string            `json:"preferredLanguage,omitempty"`
+	Gender                 string            `json:"gender,omitempty"`
+	Email                  string            `json:"email"`
+	EmailVerified          bool              `json:"emailVerified,omitempty"`
+	Phone                  string            `json:"phone,omitempty"`
+	PhoneVerified          bool              `json:"phoneVerified,omitempty"`
+	Password               string            `json:"password,omitempty"`
+	HashedPassword         string            `json:"hashedPassword,omitempty"`
+	PasswordChangeRequired bool              `json:"passwordChangeRequired,omitempty"`
+	Metadata               map[string]string `json:"metadata,omitempty"`
+	Grants                 []*Grant          `json:"grants,omitempty"`
+	IDPLinks               []*IDPLink        `json:"idpLinks,omitempty"`
+
+	// err is set if the row itself could not be parsed
+	err error
+}
+
+// Grant is formatted as `projectID[/projectGrantID][:role,role]` in CSV, multiple grants are separated by `;`
+type Grant struct {
+	ProjectID      string   `json:"projectId"`
+	ProjectGrantID string   `json:"projectGrantId,omitempty"`
+	Roles          []string `json:"roles,omitempty"`
+}
+
+// IDPLink is formatted as `idpID:externalUserID[:userName]` in CSV, multiple links are separated by `;`
+type IDPLink struct {
+	IDPID    string `json:"idpId"`
+	UserID   string `json:"userId"`
+	UserName string `json:"userName,omitempty"`
+}
+
+// Parse reads the rows of the data in the specified format.
+// An error is only returned if the data can't be read at all,
+// errors of single rows are returned on the conversion of the row.
+func Parse(format domain.UserImportFormat, data []byte) ([]*Row, error) {
+	switch format {
+	case domain.UserImportFormatCSV:
+		return parseCSV(data)
+	case domain.UserImportFormatNDJSON:
+		return parseNDJSON(data)
+	default:
+		return nil, errors.ThrowInvalidArgument(nil, "USERI-Eeth4", "Errors.UserImport.Invalid")
+	}
+}
+
+func parseCSV(data []byte) ([]*Row, error) {
+	reader := csv.NewReader(bytes.NewReader(data))
+	reader.TrimLeadingSpace = true
+	header, err := reader.Read()
+	if err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "USERI-ooY4u", "Errors.UserImport.Data.Invalid")
+	}
+	for _, column := range header {
+		if !isKnownColumn(column) {
+			return nil, errors.ThrowInvalidArgument(fmt.Errorf("unknown column %q", column), "USERI-Ahx2i", "Errors.UserImport.Data.Invalid")
+		}
+	}
+	rows := make([]*Row, 0)
+	for number := uint32(1); ; number++ {
+		record, err := reader.Read()
+		if err == io.EOF {
+			return rows, nil
+		}
+		row := &Row{Number: number}
+		rows = append(rows, row)
+		if err != nil {
+			// a malformed line is reported on the row, the following lines can still be read
+			row.err = errors.ThrowInvalidArgument(err, "USERI-ieJ4a", "Errors.UserImport.Row.Invalid")
+			continue
+		}
+		row.err = row.setColumns(header, record)
+	}
+}
+
+func isKnownColumn(column string) bool {
+	switch column {
+	case columnUserID,
+		columnUsername,
+		columnFirstName,
+		columnLastName,
+		columnNickName,
+		columnDisplayName,
+		columnPreferredLanguage,
+		columnGender,
+		columnEmail,
+		columnEmailVerified,
+		columnPhone,
+		columnPhoneVerified,
+		columnPassword,
+		columnHashedPassword,
+		columnPasswordChangeRequired,
+		columnGrants,
+		columnIDPLinks:
+		return true
+	}
+	return strings.HasPrefix(column, columnMetadataPrefix) && len(column) > len(columnMetadataPrefix)
+}
+
+//nolint:gocognit
+func (r *Row) setColumns(header, record []string) (err error) {
+	for i, column := range header {
+		value := strings.TrimSpace(record[i])
+		switch column {
+		case columnUserID:
+			r.UserID = value
+		case columnUsername:
+			r.Username = value
+		case columnFirstName:
+			r.FirstName = value
+		case columnLastName:
+			r.LastName = value
+		case columnNickName:
+			r.NickName = value
+		case columnDisplayName:
+			r.DisplayName = value
+		case columnPreferredLanguage:
+			r.PreferredLanguage = value
+		case columnGender:
+			r.Gender = value
+		case columnEmail:
+			r.Email = value
+		case columnEmailVerified:
+			r.EmailVerified, err = parseBool(value)
+		case columnPhone:
+			r.Phone = value
+		case columnPhoneVerified:
+			r.PhoneVerified, err = parseBool(value)
+		case columnPassword:
+			r.Password = value
+		case columnHashedPassword:
+			r.HashedPassword = value
+		case columnPasswordChangeRequired:
+			r.PasswordChangeRequired, err = parseBool(value)
+		case columnGrants:
+			r.Grants, err = parseGrants(value)
+		case columnIDPLinks:
+			r.IDPLinks, err = parseIDPLinks(value)
+		default:
+			if value == "" {
+				continue
+			}
+			if r.Metadata == nil {
+				r.Metadata = make(map[string]string)
+			}
+			r.Metadata[strings.TrimPrefix(column, columnMetadataPrefix)] = value
+		}
+		if err != nil {
+			return errors.ThrowInvalidArgument(fmt.Errorf("column %q: %w", column, err), "USERI-Bai2o", "Errors.UserImport.Row.Invalid")
+		}
+	}
+	return nil
+}
+
+func parseBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+func parseGrants(value string) ([]*Grant, error) {
+	if value == "" {
+		return nil, nil
+	}
+	entries := strings.Split(value, ";")
+	grants := make([]*Grant, 0, len(entries))
+	for _, entry := range entries {
+		project, roles, _ := strings.Cut(strings.TrimSpace(entry), ":")
+		grant := new(Grant)
+		grant.ProjectID, grant.ProjectGrantID, _ = strings.Cut(project, "/")
+		if grant.ProjectID == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "USERI-Kie9o", "Errors.UserGrant.Invalid")
+		}
+		if roles != "" {
+			grant.Roles = strings.Split(roles, ",")
+		}
+		grants = append(grants, grant)
+	}
+	return grants, nil
+}
+
+func parseIDPLinks(value string) ([]*IDPLink, error) {
+	if value == "" {
+		return nil, nil
+	}
+	entries := strings.Split(value, ";")
+	links := make([]*IDPLink, 0, len(entries))
+	for _, entry := range entries {
+		parts := strings.SplitN(strings.TrimSpace(entry), ":", 3)
+		if len(parts) < 2 || parts[0] == "" || parts[1] == "" {
+			return nil, errors.ThrowInvalidArgument(nil, "USERI-Iek1u", "Errors.User.ExternalIDP.Invalid")
+		}
+		link := &IDPLink{IDPID: parts[0], UserID: parts[1]}
+		if len(parts) == 3 {
+			link.UserName = parts[2]
+		}
+		links = append(links, link)
+	}
+	return links, nil
+}
+
+func parseNDJSON(data []byte) ([]*Row, error) {
+	scanner := bufio.NewScanner(bytes.NewReader(data))
+	scanner.Buffer(make([]byte, 0, 64*1024), domain.UserImportMaxSize)
+	rows := make([]*Row, 0)
+	var number uint32
+	for scanner.Scan() {
+		line := bytes.TrimSpace(scanner.Bytes())
+		if len(line) == 0 {
+			continue
+		}
+		number++
+		row := &Row{Number: number}
+		rows = append(rows, row)
+		decoder := json.NewDecoder(bytes.NewReader(line))
+		decoder.DisallowUnknownFields()
+		if err := decoder.Decode(row); err != nil {
+			row.err = errors.ThrowInvalidArgument(err, "USERI-Ohd5a", "Errors.UserImport.Row.Invalid")
+		}
+		row.Number = number
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, errors.ThrowInvalidArgument(err, "USERI-Thoo8", "Errors.UserImport.Data.Invalid")
+	}
+	return rows, nil
+}
+
+// ImportUser converts the row to the user to be created by the command
+func (r *Row) ImportUser() (*command.ImportUser, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+	if r.Password != "" && r.HashedPassword != "" {
+		return nil, errors.ThrowInvalidArgument(nil, "USERI-Iu8ah", "Errors.UserImport.Row.Invalid")
+	}
+	preferredLanguage := language.Und
+	if r.PreferredLanguage != "" {
+		var err error
+		if preferredLanguage, err = language.Parse(r.PreferredLanguage); err != nil {
+			return nil, errors.ThrowInvalidArgument(err, "USERI-Oos9e", "Errors.UserImport.Row.Invalid")
+		}
+	}
+	gender, err := parseGender(r.Gender)
+	if err != nil {
+		return nil, err
+	}
+	human := &command.AddHuman{
+		ID:                r.UserID,
+		Username:          r.Username,
+		FirstName:         r.FirstName,
+		LastName:          r.LastName,
+		NickName:          r.NickName,
+		DisplayName:       r.DisplayName,
+		PreferredLanguage: preferredLanguage,
+		Gender:            gender,
+		Email: command.Email{
+			Address:  domain.EmailAddress(r.Email),
+			Verified: r.EmailVerified,
+		},
+		Phone: command.Phone{
+			Number:   domain.PhoneNumber(r.Phone),
+			Verified: r.PhoneVerified,
+		},
+		Password:               r.Password,
+		EncodedPasswordHash:    r.HashedPassword,
+		PasswordChangeRequired: r.PasswordChangeRequired,
+		Metadata:               make([]*command.AddMetadataEntry, 0, len(r.Metadata)),
+		Links:                  make([]*command.AddLink, 0, len(r.IDPLinks)),
+	}
+	keys := make([]string, 0, len(r.Metadata))
+	for key := range r.Metadata {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	for _, key := range keys {
+		human.Metadata = append(human.Metadata, &command.AddMetadataEntry{
+			Key:   key,
+			Value: []byte(r.Metadata[key]),
+		})
+	}
+	for _, link := range r.IDPLinks {
+		displayName := link.UserName
+		if displayName == "" {
+			displayName = r.Username
+		}
+		human.Links = append(human.Links, &command.AddLink{
+			IDPID:         link.IDPID,
+			DisplayName:   displayName,
+			IDPExternalID: link.UserID,
+		})
+	}
+	grants := make([]*domain.UserGrant, len(r.Grants))
+	for i, grant := range r.Grants {
+		grants[i] = &domain.UserGrant{
+			ProjectID:      grant.ProjectID,
+			ProjectGrantID: grant.ProjectGrantID,
+			RoleKeys:       grant.Roles,
+		}
+	}
+	return &command.ImportUser{
+		Human:  human,
+		Grants: grants,
+	}, nil
+}
+
+func parseGender(gender string) (domain.Gender, error) {
+	switch strings.ToLower(gender) {
+	case "":
+		return domain.GenderUnspecified, nil
+	case "female":
+		return domain.GenderFemale, nil
+	case "male":
+		return domain.GenderMale, nil
+	case "diverse":
+		return domain.GenderDiverse, nil
+	default:
+		return domain.GenderUnspecified, errors.ThrowInvalidArgument(nil, "USERI-Ci7ai", "Errors.UserImport.Row.Invalid")
+	}
+}
@@ -0,0 +1,227 @@
+package userimport
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/text/language"
+
+	"github.com/zitadel/zitadel/internal/command"
+	"github.com/zitadel/zitadel/internal/domain"
+	"github.com/zitadel/zitadel/internal/errors"
+)
+
+func TestParse(t *testing.T) {
+	type args struct {
+		format domain.UserImportFormat
+		data   string
+	}
+	tests := []struct {
+		name    string
+		args    args
+		want    []*Row
+		wantErr func(error) bool
+	}{
+		{
+			name: "unspecified format, error",
+			args: args{
+				data: "username\nuser1",
+			},
+			wantErr: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "csv unknown column, error",
+			args: args{
+				format: domain.UserImportFormatCSV,
+				data:   "username,unknown\nuser1,value",
+			},
+			wantErr: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "csv, ok",
+			args: args{
+				format: domain.UserImportFormatCSV,
+				data: "username,firstName,lastName,email,emailVerified,metadata.department,grants,idpLinks\n" +
+					"user1,first,last,user1@example.com,true,sales,project1:admin,idp1:ext1\n" +
+					"user2,first,last,user2@example.com,,,\"project1/grant1:viewer,reader;project2\",idp1:ext2:ext-user2",
+			},
+			want: []*Row{
+				{
+					Number:        1,
+					Username:      "user1",
+					FirstName:     "first",
+					LastName:      "last",
+					Email:         "user1@example.com",
+					EmailVerified: true,
+					Metadata:      map[string]string{"department": "sales"},
+					Grants:        []*Grant{{ProjectID: "project1", Roles: []string{"admin"}}},
+					IDPLinks:      []*IDPLink{{IDPID: "idp1", UserID: "ext1"}},
+				},
+				{
+					Number:    2,
+					Username:  "user2",
+					FirstName: "first",
+					LastName:  "last",
+					Email:     "user2@example.com",
+					Grants: []*Grant{
+						{ProjectID: "project1", ProjectGrantID: "grant1", Roles: []string{"viewer", "reader"}},
+						{ProjectID: "project2"},
+					},
+					IDPLinks: []*IDPLink{{IDPID: "idp1", UserID: "ext2", UserName: "ext-user2"}},
+				},
+			},
+		},
+		{
+			name: "csv invalid row, row error",
+			args: args{
+				format: domain.UserImportFormatCSV,
+				data:   "username,emailVerified\nuser1,maybe\nuser2,false,additional\nuser3,true",
+			},
+			want: []*Row{
+				{Number: 1, Username: "user1", err: errors.ThrowInvalidArgument(nil, "USERI-Bai2o", "Errors.UserImport.Row.Invalid")},
+				{Number: 2, err: errors.ThrowInvalidArgument(nil, "USERI-ieJ4a", "Errors.UserImport.Row.Invalid")},
+				{Number: 3, Username: "user3", EmailVerified: true},
+			},
+		},
+		{
+			name: "ndjson, ok",
+			args: args{
+				format: domain.UserImportFormatNDJSON,
+				data: `{"username": "user1", "hashedPassword": "$2a$10$hash", "metadata": {"department": "sales"}, "grants": [{"projectId": "project1", "roles": ["admin"]}]}` + "\n" +
+					"\n" +
+					`{"username": "user2", "idpLinks": [{"idpId": "idp1", "userId": "ext2"}]}`,
+			},
+			want: []*Row{
+				{
+					Number:         1,
+					Username:       "user1",
+					HashedPassword: "$2a$10$hash",
+					Metadata:       map[string]string{"department": "sales"},
+					Grants:         []*Grant{{ProjectID: "project1", Roles: []string{"admin"}}},
+				},
+				{
+					Number:   2,
+					Username: "user2",
+					IDPLinks: []*IDPLink{{IDPID: "idp1", UserID: "ext2"}},
+				},
+			},
+		},
+		{
+			name: "ndjson invalid row, row error",
+			args: args{
+				format: domain.UserImportFormatNDJSON,
+				data:   `{"username": "user1", "unknown": true}` + "\n" + `{"username": "user2"}`,
+			},
+			want: []*Row{
+				{Number: 1, Username: "user1", err: errors.ThrowInvalidArgument(nil, "USERI-Ohd5a", "Errors.UserImport.Row.Invalid")},
+				{Number: 2, Username: "user2"},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := Parse(tt.args.format, []byte(tt.args.data))
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			require.Len(t, got, len(tt.want))
+			for i, row := range got {
+				if tt.want[i].err != nil {
+					assert.ErrorIs(t, row.err, tt.want[i].err)
+					row.err = nil
+					tt.want[i].err = nil
+				}
+				assert.Equal(t, tt.want[i], row)
+			}
+		})
+	}
+}
+
+func TestRow_ImportUser(t *testing.T) {
+	tests := []struct {
+		name    string
+		row     *Row
+		want    *command.ImportUser
+		wantErr func(error) bool
+	}{
+		{
+			name: "password and hashed password, error",
+			row: &Row{
+				Username:       "user1",
+				Password:       "Password1!",
+				HashedPassword: "$2a$10$hash",
+			},
+			wantErr: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "unknown gender, error",
+			row: &Row{
+				Username: "user1",
+				Gender:   "unknown",
+			},
+			wantErr: errors.IsErrorInvalidArgument,
+		},
+		{
+			name: "ok",
+			row: &Row{
+				UserID:                 "user-id",
+				Username:               "user1",
+				FirstName:              "first",
+				LastName:               "last",
+				PreferredLanguage:      "de",
+				Gender:                 "Female",
+				Email:                  "user1@example.com",
+				EmailVerified:          true,
+				Phone:                  "+41791234567",
+				HashedPassword:         "$2a$10$hash",
+				PasswordChangeRequired: true,
+				Metadata:               map[string]string{"location": "zurich", "department": "sales"},
+				Grants:                 []*Grant{{ProjectID: "project1", ProjectGrantID: "grant1", Roles: []string{"admin"}}},
+				IDPLinks:               []*IDPLink{{IDPID: "idp1", UserID: "ext1"}},
+			},
+			want: &command.ImportUser{
+				Human: &command.AddHuman{
+					ID:                "user-id",
+					Username:          "user1",
+					FirstName:         "first",
+					LastName:          "last",
+					PreferredLanguage: language.German,
+					Gender:            domain.GenderFemale,
+					Email: command.Email{
+						Address:  "user1@example.com",
+						Verified: true,
+					},
+					Phone: command.Phone{
+						Number: "+41791234567",
+					},
+					EncodedPasswordHash:    "$2a$10$hash",
+					PasswordChangeRequired: true,
+					Metadata: []*command.AddMetadataEntry{
+						{Key: "department", Value: []byte("sales")},
+						{Key: "location", Value: []byte("zurich")},
+					},
+					Links: []*command.AddLink{
+						{IDPID: "idp1", DisplayName: "user1", IDPExternalID: "ext1"},
+					},
+				},
+				Grants: []*domain.UserGrant{
+					{ProjectID: "project1", ProjectGrantID: "grant1", RoleKeys: []string{"admin"}},
+				},
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.row.ImportUser()
+			if tt.wantErr != nil {
+				assert.True(t, tt.wantErr(err), "unexpected error: %v", err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
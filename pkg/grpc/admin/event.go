@@ -77,3 +77,10 @@ func (resp *ListEventsResponse) Localizers() []middleware.Localizer {
 	}
 	return localizers
 }
+
+func (resp *WatchEventsResponse) Localizers() []middleware.Localizer {
+	if resp == nil || resp.Event == nil {
+		return nil
+	}
+	return []middleware.Localizer{resp.Event.Type.Localized, resp.Event.Aggregate.Type.Localized}
+}